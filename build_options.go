@@ -0,0 +1,74 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// BuildOption changes how a model is built by Document.BuildV2Model or Document.BuildV3Model, so only the parts of
+// the model that are needed are built (and held in memory).
+type BuildOption func(config *datamodel.DocumentConfiguration)
+
+// WithSections will only build the top level sections of the document that are listed, for example "info" or
+// "paths". The whole document is still indexed, so references into sections that are not built are still resolved.
+func WithSections(sections ...string) BuildOption {
+	return func(config *datamodel.DocumentConfiguration) {
+		config.BuildSections = append(config.BuildSections, sections...)
+	}
+}
+
+// WithPathsOnly will only build the paths of the document.
+func WithPathsOnly() BuildOption {
+	return WithSections(v3low.PathsLabel)
+}
+
+// WithComponents will only build the components that are listed, for example "schemas" or "securitySchemes"
+// (OpenAPI 3+ only). If sections have been selected with WithSections, components are added to them.
+func WithComponents(components ...string) BuildOption {
+	return func(config *datamodel.DocumentConfiguration) {
+		config.BuildComponents = append(config.BuildComponents, components...)
+	}
+}
+
+// SkipExamples will not build any examples.
+func SkipExamples() BuildOption {
+	return func(config *datamodel.DocumentConfiguration) {
+		config.SkipExamples = true
+	}
+}
+
+// TolerateBuildErrors will build as much of the model as can be built, parts of the document that fail to build
+// are returned as errors along with the model (see datamodel.DocumentConfiguration.TolerateBuildErrors).
+func TolerateBuildErrors() BuildOption {
+	return func(config *datamodel.DocumentConfiguration) {
+		config.TolerateBuildErrors = true
+	}
+}
+
+// applyBuildOptions returns a copy of the configuration with the build options applied.
+func applyBuildOptions(config *datamodel.DocumentConfiguration, options []BuildOption) *datamodel.DocumentConfiguration {
+	if len(options) == 0 {
+		return config
+	}
+	c := *config
+	c.BuildSections = append([]string(nil), config.BuildSections...)
+	c.BuildComponents = append([]string(nil), config.BuildComponents...)
+	for _, option := range options {
+		option(&c)
+	}
+	if len(c.BuildComponents) > 0 && !c.BuildSection(v3low.ComponentsLabel) {
+		c.BuildSections = append(c.BuildSections, v3low.ComponentsLabel)
+	}
+	return &c
+}
+
+// ValidateSchema will check the specification against the OpenAPI meta-schema for its version before building the
+// model, violations are returned as errors instead of a model (see datamodel.DocumentConfiguration.ValidateSchema).
+func ValidateSchema() BuildOption {
+	return func(config *datamodel.DocumentConfiguration) {
+		config.ValidateSchema = true
+	}
+}
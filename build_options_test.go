@@ -0,0 +1,100 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_BuildV3Model_WithPathsOnly(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(spec)
+	assert.NoError(t, err)
+
+	model, errs := doc.BuildV3Model(WithPathsOnly(), SkipExamples())
+	assert.Empty(t, errs)
+	assert.Nil(t, model.Model.Info)
+	assert.Nil(t, model.Model.Components)
+	assert.Empty(t, model.Model.Tags)
+	assert.Empty(t, model.Model.Webhooks)
+
+	burgers := model.Model.Paths.PathItems["/burgers"].Post
+	assert.NotNil(t, burgers)
+	mt := burgers.Responses.Codes["200"].Content["application/json"]
+	assert.NotNil(t, mt.Schema.Schema())
+	assert.Empty(t, mt.Examples)
+
+	// models built with options are not kept, the full model is still built.
+	full, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.NotSame(t, model, full)
+	assert.NotNil(t, full.Model.Info)
+	assert.NotEmpty(t, full.Model.Paths.PathItems["/burgers"].Post.Responses.Codes["200"].
+		Content["application/json"].Examples)
+
+	again, _ := doc.BuildV3Model()
+	assert.Same(t, full, again)
+}
+
+func TestDocument_BuildV3Model_WithComponents(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, _ := NewDocument(spec)
+
+	model, errs := doc.BuildV3Model(WithPathsOnly(), WithComponents("schemas"))
+	assert.Empty(t, errs)
+	assert.NotNil(t, model.Model.Paths)
+	assert.NotEmpty(t, model.Model.Components.Schemas)
+	assert.Empty(t, model.Model.Components.Responses)
+	assert.Empty(t, model.Model.Components.SecuritySchemes)
+	assert.Nil(t, model.Model.Info)
+
+	// without sections selected, every section is built.
+	model, _ = doc.BuildV3Model(WithComponents("securitySchemes"))
+	assert.NotNil(t, model.Model.Paths)
+	assert.Empty(t, model.Model.Components.Schemas)
+	assert.NotEmpty(t, model.Model.Components.SecuritySchemes)
+}
+
+func TestDocument_BuildV2Model_WithSections(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, _ := NewDocument(spec)
+
+	model, errs := doc.BuildV2Model(WithSections("info", "definitions"), SkipExamples())
+	assert.Empty(t, errs)
+	assert.NotNil(t, model.Model.Info)
+	assert.NotNil(t, model.Model.Definitions)
+	assert.Nil(t, model.Model.Paths)
+	assert.Nil(t, model.Model.SecurityDefinitions)
+}
+
+func TestDocument_BuildV3Model_ValidateSchema(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: pizza
+paths: {}`
+	doc, _ := NewDocument([]byte(spec))
+	model, errs := doc.BuildV3Model(ValidateSchema())
+	assert.Nil(t, model)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "#/info: missing required property 'version' (line 3, column 3)", errs[0].Error())
+
+	// without validation, the model is built.
+	model, errs = doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.NotNil(t, model)
+}
+
+func TestDocument_BuildV2Model_ValidateSchema(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/petstorev2.json")
+	config := datamodel.NewClosedDocumentConfiguration()
+	config.ValidateSchema = true
+	doc, _ := NewDocumentWithConfiguration(spec, config)
+	model, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+	assert.NotNil(t, model)
+}
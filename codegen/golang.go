@@ -0,0 +1,483 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package codegen generates Go type declarations from the component schemas of a document.
+//
+// Every component schema becomes a named Go type: objects become structs with json and yaml tags, enums become a
+// typed string or number along with a constant for each value, and arrays and maps become slices and maps. Inline
+// objects and enums are declared as their own types, named by base.Schema.InferName (the 'owner' property of 'Pet'
+// becomes 'PetOwner', unless it has a title). References to other components use the type of that component.
+//
+// Optional and nullable fields are pointers, unless they are slices, maps or 'any' (which already have a zero
+// value that means 'missing'). Required fields are pointers too when the type would otherwise contain itself, like
+// two schemas that require each other.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// Config changes how Go code is generated.
+type Config struct {
+	// Package is the name of the generated package, 'models' if not set.
+	Package string
+}
+
+// GenerateFromDocument generates Go types for the component schemas of an OpenAPI 3 document.
+func GenerateFromDocument(doc *v3.Document, config *Config) ([]byte, error) {
+	var schemas map[string]*base.SchemaProxy
+	if doc.Components != nil {
+		schemas = doc.Components.Schemas
+	}
+	return Generate(schemas, config)
+}
+
+// GenerateFromSwagger generates Go types for the definitions of a Swagger (OpenAPI 2) document.
+func GenerateFromSwagger(doc *v2.Swagger, config *Config) ([]byte, error) {
+	var schemas map[string]*base.SchemaProxy
+	if doc.Definitions != nil {
+		schemas = doc.Definitions.Definitions
+	}
+	return Generate(schemas, config)
+}
+
+// Generate generates a formatted Go source file declaring a type for each schema, by name. References to
+// '#/components/schemas/<name>' and '#/definitions/<name>' use the type generated for that schema.
+func Generate(schemas map[string]*base.SchemaProxy, config *Config) ([]byte, error) {
+	if config == nil {
+		config = new(Config)
+	}
+	g := &generator{
+		names:     make(names),
+		refs:      make(map[string]string),
+		imports:   make(map[string]bool),
+		declaring: make(map[string]bool),
+		fields:    make(map[string][]string),
+	}
+	componentNames := make([]string, 0, len(schemas))
+	for name := range schemas {
+		componentNames = append(componentNames, name)
+	}
+	sort.Strings(componentNames)
+	types := make([]string, len(componentNames))
+	for i, name := range componentNames {
+		types[i] = g.names.unique(GoName(name))
+		g.refs["#/components/schemas/"+name] = types[i]
+		g.refs["#/definitions/"+name] = types[i]
+	}
+	for i, name := range componentNames {
+		if sp := schemas[name]; sp != nil {
+			g.fields[types[i]] = g.structReferences(sp.Schema(), make(map[*base.Schema]bool), nil)
+		}
+	}
+	for i, name := range componentNames {
+		g.declare(types[i], schemas[name])
+	}
+
+	pkg := config.Package
+	if pkg == "" {
+		pkg = "models"
+	}
+	var out bytes.Buffer
+	out.WriteString("// Code generated by libopenapi. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for imp := range g.imports {
+			imports = append(imports, strconv.Quote(imp))
+		}
+		sort.Strings(imports)
+		fmt.Fprintf(&out, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	for _, decl := range g.decls {
+		out.WriteString(decl)
+		out.WriteString("\n")
+	}
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	names     names
+	refs      map[string]string // the Go types of component references.
+	imports   map[string]bool
+	decls     []string
+	declaring map[string]bool     // the struct types being declared, references to them are always pointers.
+	fields    map[string][]string // the component types referenced by the fields of each component type.
+}
+
+// declare adds the declaration of a named type for a schema, along with any types declared for its inline schemas.
+func (g *generator) declare(name string, sp *base.SchemaProxy) {
+	var sch *base.Schema
+	if sp != nil {
+		sch = sp.Schema()
+	}
+	var b strings.Builder
+	if sch != nil {
+		writeComment(&b, name, sch)
+	}
+	i := len(g.decls)
+	g.decls = append(g.decls, "") // reserve a place, so inline types are declared after the type using them.
+
+	switch {
+	case sch == nil:
+		fmt.Fprintf(&b, "type %s any\n", name)
+	case isEnum(sch):
+		g.enum(&b, name, sch)
+	case isStruct(sch):
+		g.declaring[name] = true
+		g.structure(&b, name, sch)
+		delete(g.declaring, name)
+	default:
+		goType, _ := g.typeOf(sp, base.SchemaNameContext{Parent: name}, true)
+		fmt.Fprintf(&b, "type %s %s\n", name, goType)
+	}
+	g.decls[i] = b.String()
+}
+
+func writeComment(b *strings.Builder, name string, sch *base.Schema) {
+	text := strings.TrimSpace(sch.Description)
+	if text == "" {
+		text = strings.TrimSpace(sch.Title)
+	}
+	if text != "" {
+		text = name + " " + text
+		for _, line := range strings.Split(text, "\n") {
+			b.WriteString(strings.TrimSpace("// " + line))
+			b.WriteString("\n")
+		}
+	}
+	if sch.Deprecated != nil && *sch.Deprecated {
+		if text != "" {
+			b.WriteString("//\n")
+		}
+		b.WriteString("// Deprecated: this schema is deprecated.\n")
+	}
+}
+
+// typeOf returns the Go type of a schema, and if the type already has a zero value (slices, maps and 'any'). Inline
+// types that need a declaration are declared using the name inferred for the schema, from where it's found.
+func (g *generator) typeOf(sp *base.SchemaProxy, ctx base.SchemaNameContext, top bool) (string, bool) {
+	if sp == nil {
+		return "any", true
+	}
+	if sp.IsReference() {
+		if goType, ok := g.refs[sp.GetReference()]; ok {
+			sch := sp.Schema()
+			return goType, sch != nil && !isEnum(sch) && !isStruct(sch) &&
+				(schemaType(sch) == "array" || schemaType(sch) == "object")
+		}
+	}
+	sch := sp.Schema()
+	if sch == nil {
+		return "any", true
+	}
+	// an allOf of a single schema is used to add a description or default to a reference.
+	if len(sch.AllOf) == 1 && !isStruct(sch) {
+		return g.typeOf(sch.AllOf[0], ctx, top)
+	}
+	if !top && (isEnum(sch) || isStruct(sch)) {
+		name := g.names.unique(GoName(sch.InferName(&ctx)))
+		g.declare(name, sp)
+		return name, false
+	}
+
+	switch schemaType(sch) {
+	case "object":
+		value := "any"
+		if ap := sch.AdditionalProperties; ap != nil && ap.IsA() {
+			values := ctx
+			values.Values = true
+			value, _ = g.typeOf(ap.A, values, false)
+		}
+		return "map[string]" + value, true
+	case "array":
+		item := "any"
+		if sch.Items != nil && sch.Items.IsA() {
+			items := ctx
+			items.Items = true
+			item, _ = g.typeOf(sch.Items.A, items, false)
+		}
+		return "[]" + item, true
+	case "string":
+		switch sch.Format {
+		case "date-time":
+			g.imports["time"] = true
+			return "time.Time", false
+		case "binary":
+			return "[]byte", true
+		}
+		return "string", false
+	case "integer":
+		if sch.Format == "int32" {
+			return "int32", false
+		}
+		return "int64", false
+	case "number":
+		if sch.Format == "float" {
+			return "float32", false
+		}
+		return "float64", false
+	case "boolean":
+		return "bool", false
+	}
+	// oneOf, anyOf and schemas without a type can hold anything.
+	return "any", true
+}
+
+func (g *generator) structure(b *strings.Builder, name string, sch *base.Schema) {
+	required := make(map[string]bool)
+	var embedded []string
+	properties := make(map[string]*base.SchemaProxy)
+	var order []string
+	addProperties := func(s *base.Schema) {
+		for _, r := range s.Required {
+			required[r] = true
+		}
+		keys := make([]string, 0, len(s.Properties))
+		for k := range s.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, ok := properties[k]; !ok {
+				order = append(order, k)
+			}
+			properties[k] = s.Properties[k]
+		}
+	}
+
+	// allOf references to other structs are embedded, inline schemas have their properties merged.
+	for _, sp := range sch.AllOf {
+		if sp == nil {
+			continue
+		}
+		if sp.IsReference() {
+			if goType, ok := g.refs[sp.GetReference()]; ok {
+				if s := sp.Schema(); s != nil && isStruct(s) {
+					embedded = append(embedded, goType)
+					continue
+				}
+			}
+		}
+		if s := sp.Schema(); s != nil {
+			addProperties(s)
+		}
+	}
+	addProperties(sch)
+
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for _, e := range embedded {
+		if g.recursive(e) {
+			e = "*" + e
+		}
+		fmt.Fprintf(b, "\t%s\n", e)
+	}
+	fields := make(names)
+	for _, prop := range order {
+		sp := properties[prop]
+		field := fields.unique(GoName(prop))
+		goType, hasZero := g.typeOf(sp, base.SchemaNameContext{Parent: name, Property: prop}, false)
+		var propSchema *base.Schema
+		if sp != nil {
+			propSchema = sp.Schema()
+		}
+		if !hasZero && (!required[prop] || isNullable(propSchema) || g.recursive(goType)) {
+			goType = "*" + goType
+		}
+		// the descriptions of references are written on the type of the component.
+		if propSchema != nil && propSchema.Description != "" && !sp.IsReference() {
+			for _, line := range strings.Split(strings.TrimSpace(propSchema.Description), "\n") {
+				b.WriteString(strings.TrimSpace("\t// " + line))
+				b.WriteString("\n")
+			}
+		}
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:%q yaml:%q`\n", field, goType, tag, tag)
+	}
+	b.WriteString("}\n")
+}
+
+func (g *generator) enum(b *strings.Builder, name string, sch *base.Schema) {
+	goType := "string"
+	switch schemaType(sch) {
+	case "integer":
+		goType = "int64"
+	case "number":
+		goType = "float64"
+	}
+	fmt.Fprintf(b, "type %s %s\n\n", name, goType)
+	b.WriteString("const (\n")
+	for _, v := range sch.Enum {
+		if v == nil {
+			continue // null is not a value of a Go type.
+		}
+		var literal, suffix string
+		switch goType {
+		case "string":
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			literal = strconv.Quote(s)
+			suffix = utils.ToPascalCase(s)
+			if suffix == "" {
+				suffix = "Empty"
+			}
+		default:
+			literal = fmt.Sprint(v)
+			if _, err := strconv.ParseFloat(literal, 64); err != nil {
+				continue
+			}
+			suffix = strings.NewReplacer("-", "Minus", ".", "_").Replace(literal)
+		}
+		fmt.Fprintf(b, "\t%s %s = %s\n", g.names.unique(name+suffix), name, literal)
+	}
+	b.WriteString(")\n")
+}
+
+// structReferences collects the component types a value of a schema contains: the types of its required fields
+// (and those of the inline objects it declares), and the structs it embeds using allOf. Optional and nullable fields
+// are pointers, and slices and maps don't contain a value, so they are not followed.
+func (g *generator) structReferences(sch *base.Schema, seen map[*base.Schema]bool, found []string) []string {
+	if sch == nil || seen[sch] {
+		return found
+	}
+	seen[sch] = true
+	required := make(map[string]bool)
+	properties := make(map[string]*base.SchemaProxy)
+	addProperties := func(s *base.Schema) {
+		for _, r := range s.Required {
+			required[r] = true
+		}
+		for k, sp := range s.Properties {
+			properties[k] = sp
+		}
+	}
+	// the same as a struct is declared, see structure.
+	for _, sp := range sch.AllOf {
+		if sp == nil {
+			continue
+		}
+		if sp.IsReference() {
+			if goType, ok := g.refs[sp.GetReference()]; ok {
+				if s := sp.Schema(); s != nil && isStruct(s) {
+					found = append(found, goType)
+					continue
+				}
+			}
+		}
+		if s := sp.Schema(); s != nil {
+			addProperties(s)
+		}
+	}
+	addProperties(sch)
+	for k, sp := range properties {
+		if !required[k] || sp == nil || isNullable(sp.Schema()) {
+			continue
+		}
+		if sp.IsReference() {
+			if goType, ok := g.refs[sp.GetReference()]; ok {
+				found = append(found, goType)
+				continue
+			}
+		}
+		found = g.structReferences(sp.Schema(), seen, found)
+	}
+	return found
+}
+
+// recursive returns true if a value of a type (eventually) contains a value of a struct type being declared, the
+// field (or embedded type) must be a pointer, or the type would contain itself.
+func (g *generator) recursive(goType string) bool {
+	seen := make(map[string]bool)
+	var reaches func(t string) bool
+	reaches = func(t string) bool {
+		if g.declaring[t] {
+			return true
+		}
+		if seen[t] {
+			return false
+		}
+		seen[t] = true
+		for _, f := range g.fields[t] {
+			if reaches(f) {
+				return true
+			}
+		}
+		return false
+	}
+	return reaches(goType)
+}
+
+// schemaType returns the type of a schema, ignoring 'null'. Schemas without a type are objects if they have
+// properties.
+func schemaType(sch *base.Schema) string {
+	for _, t := range sch.Type {
+		if t != "null" {
+			return t
+		}
+	}
+	if len(sch.Properties) > 0 || sch.AdditionalProperties != nil {
+		return "object"
+	}
+	if sch.Items != nil {
+		return "array"
+	}
+	return ""
+}
+
+func isNullable(sch *base.Schema) bool {
+	if sch == nil {
+		return false
+	}
+	if sch.Nullable != nil && *sch.Nullable {
+		return true
+	}
+	for _, t := range sch.Type {
+		if t == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+func isEnum(sch *base.Schema) bool {
+	switch schemaType(sch) {
+	case "string", "integer", "number":
+		return len(sch.Enum) > 0
+	}
+	return false
+}
+
+// isStruct returns true for objects with properties, and schemas that combine objects using allOf.
+func isStruct(sch *base.Schema) bool {
+	if schemaType(sch) == "object" && len(sch.Properties) > 0 {
+		return true
+	}
+	for _, sp := range sch.AllOf {
+		if sp == nil {
+			continue
+		}
+		if s := sp.Schema(); s != nil && isStruct(s) {
+			return true
+		}
+	}
+	return false
+}
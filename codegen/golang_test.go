@@ -0,0 +1,210 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "PetID", GoName("pet_id"))
+	assert.Equal(t, "PetID", GoName("petId"))
+	assert.Equal(t, "HTTPServer", GoName("HTTPServer"))
+	assert.Equal(t, "ContentType", GoName("content-type"))
+	assert.Equal(t, "X2fa", GoName("2fa"))
+	assert.Equal(t, "", GoName("$"))
+}
+
+func TestGenerateFromDocument(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(`openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      description: A pet in the store.
+      type: object
+      required: [id, name, parent]
+      properties:
+        id:
+          type: integer
+          format: int32
+        name:
+          type: string
+          description: The name of the pet.
+        nickname:
+          type: string
+          nullable: true
+        status:
+          type: string
+          enum: [available, sold-out, '']
+        born:
+          type: string
+          format: date-time
+        tags:
+          type: array
+          items:
+            type: string
+        owner:
+          type: object
+          properties:
+            name:
+              type: string
+        parent:
+          $ref: '#/components/schemas/Pet'
+        labels:
+          type: object
+          additionalProperties:
+            type: number
+        size:
+          $ref: '#/components/schemas/size'
+        kind:
+          oneOf:
+            - type: string
+            - type: integer
+    size:
+      type: integer
+      enum: [1, -2]
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+        - type: object
+          required: [barks]
+          properties:
+            barks:
+              type: boolean
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'
+    Kennel:
+      deprecated: true
+      type: object
+      properties:
+        pets:
+          $ref: '#/components/schemas/Pets'
+        ref:
+          allOf:
+            - $ref: '#/components/schemas/size'`))
+	assert.NoError(t, err)
+	// the required parent is reported as an infinite circular reference, it still needs a pointer.
+	m, errs := doc.BuildV3Model()
+	assert.Len(t, errs, 1)
+
+	src, err := GenerateFromDocument(&m.Model, &Config{Package: "pets"})
+	assert.NoError(t, err)
+	assert.Equal(t, "// Code generated by libopenapi. DO NOT EDIT.\n\n"+`package pets
+
+import (
+	"time"
+)
+
+type Dog struct {
+	Pet
+	Barks bool `+"`json:\"barks\" yaml:\"barks\"`"+`
+}
+
+// Deprecated: this schema is deprecated.
+type Kennel struct {
+	Pets Pets  `+"`json:\"pets,omitempty\" yaml:\"pets,omitempty\"`"+`
+	Ref  *Size `+"`json:\"ref,omitempty\" yaml:\"ref,omitempty\"`"+`
+}
+
+// Pet A pet in the store.
+type Pet struct {
+	Born   *time.Time         `+"`json:\"born,omitempty\" yaml:\"born,omitempty\"`"+`
+	ID     int32              `+"`json:\"id\" yaml:\"id\"`"+`
+	Kind   any                `+"`json:\"kind,omitempty\" yaml:\"kind,omitempty\"`"+`
+	Labels map[string]float64 `+"`json:\"labels,omitempty\" yaml:\"labels,omitempty\"`"+`
+	// The name of the pet.
+	Name     string     `+"`json:\"name\" yaml:\"name\"`"+`
+	Nickname *string    `+"`json:\"nickname,omitempty\" yaml:\"nickname,omitempty\"`"+`
+	Owner    *PetOwner  `+"`json:\"owner,omitempty\" yaml:\"owner,omitempty\"`"+`
+	Parent   *Pet       `+"`json:\"parent\" yaml:\"parent\"`"+`
+	Size     *Size      `+"`json:\"size,omitempty\" yaml:\"size,omitempty\"`"+`
+	Status   *PetStatus `+"`json:\"status,omitempty\" yaml:\"status,omitempty\"`"+`
+	Tags     []string   `+"`json:\"tags,omitempty\" yaml:\"tags,omitempty\"`"+`
+}
+
+type PetOwner struct {
+	Name *string `+"`json:\"name,omitempty\" yaml:\"name,omitempty\"`"+`
+}
+
+type PetStatus string
+
+const (
+	PetStatusAvailable PetStatus = "available"
+	PetStatusSoldOut   PetStatus = "sold-out"
+	PetStatusEmpty     PetStatus = ""
+)
+
+type Pets []Pet
+
+type Size int64
+
+const (
+	Size1      Size = 1
+	SizeMinus2 Size = -2
+)
+`, string(src))
+}
+
+func TestGenerateFromSwagger(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(`swagger: '2.0'
+info:
+  title: pets
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    type: object
+    properties:
+      owner:
+        $ref: '#/definitions/Owner'
+  Owner:
+    type: object
+    properties:
+      name:
+        type: string`))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+
+	src, err := GenerateFromSwagger(&m.Model, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(src), "package models")
+	assert.Contains(t, string(src), "Owner *Owner `json:\"owner,omitempty\" yaml:\"owner,omitempty\"`")
+}
+
+func TestGenerateFromDocument_Vet(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go tool is not available")
+	}
+	for _, spec := range []string{"stripe.yaml", "circular-tests.yaml"} {
+		t.Run(spec, func(t *testing.T) {
+			b, _ := os.ReadFile("../test_specs/" + spec)
+			doc, err := libopenapi.NewDocument(b)
+			assert.NoError(t, err)
+			m, _ := doc.BuildV3Model()
+
+			// enum constants can't clash with types, and required references on a cycle are pointers.
+			src, err := GenerateFromDocument(&m.Model, nil)
+			assert.NoError(t, err)
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), src, 0o644))
+			cmd := exec.Command(goTool, "vet", "models.go")
+			cmd.Dir = dir
+			out, err := cmd.CombinedOutput()
+			assert.NoError(t, err, string(out))
+		})
+	}
+}
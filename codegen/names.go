@@ -0,0 +1,39 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package codegen
+
+import (
+	"strconv"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// GoName converts a name from a document (like 'pet_id', 'pet-id' or 'petId') into an exported Go name ('PetID').
+// Names that don't start with a letter are prefixed with 'X'.
+func GoName(name string) string {
+	s := utils.ToPascalCase(name)
+	if s == "" {
+		return ""
+	}
+	if r := []rune(s)[0]; !unicode.IsLetter(r) {
+		s = "X" + s
+	}
+	return s
+}
+
+// names hands out unique Go names, numbering any name that has been used before.
+type names map[string]bool
+
+func (n names) unique(name string) string {
+	if name == "" {
+		name = "Type"
+	}
+	candidate := name
+	for i := 2; n[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	n[candidate] = true
+	return candidate
+}
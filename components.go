@@ -0,0 +1,169 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Reusable components are defined in different places by Swagger (definitions, parameters, responses and
+// securityDefinitions) and OpenAPI 3 (components). The accessors below look up components the same way for either
+// model, so tooling that handles both versions only needs a single code path. Components that only exist in
+// OpenAPI 3 (like links or callbacks) are available from the components of the model.
+
+// ComponentParameter is a reusable parameter, defined by either a Swagger or an OpenAPI 3 document.
+type ComponentParameter struct {
+	Name        string                // the name of the parameter, not the name of the component.
+	In          string                // the location of the parameter, like 'query' or 'header'.
+	Description string                // the description of the parameter.
+	Required    bool                  // true if the parameter is required.
+	Schema      *highbase.SchemaProxy // the schema of the parameter, nil for Swagger parameters not in the body.
+	V2          *v2high.Parameter     // the Swagger parameter, nil for OpenAPI 3 documents.
+	V3          *v3high.Parameter     // the OpenAPI 3 parameter, nil for Swagger documents.
+}
+
+// ComponentResponse is a reusable response, defined by either a Swagger or an OpenAPI 3 document.
+type ComponentResponse struct {
+	Description string           // the description of the response.
+	V2          *v2high.Response // the Swagger response, nil for OpenAPI 3 documents.
+	V3          *v3high.Response // the OpenAPI 3 response, nil for Swagger documents.
+}
+
+// ComponentSecurityScheme is a reusable security scheme, defined by either a Swagger or an OpenAPI 3 document.
+// The type uses the names of OpenAPI 3, a Swagger 'basic' scheme has the type 'http' and the scheme 'basic'.
+type ComponentSecurityScheme struct {
+	Type        string                 // 'apiKey', 'http', 'oauth2', 'openIdConnect' or 'mutualTLS'.
+	Scheme      string                 // the HTTP authorization scheme, like 'basic' or 'bearer', for 'http' types.
+	Description string                 // the description of the security scheme.
+	Name        string                 // the name of the header, query parameter or cookie, for 'apiKey' types.
+	In          string                 // the location of the API key, for 'apiKey' types.
+	V2          *v2high.SecurityScheme // the Swagger security scheme, nil for OpenAPI 3 documents.
+	V3          *v3high.SecurityScheme // the OpenAPI 3 security scheme, nil for Swagger documents.
+}
+
+// Schemas returns the reusable schemas of the document, by name: the definitions of a Swagger document, or the
+// schemas of the components of an OpenAPI 3 document.
+func (d *DocumentModel[T]) Schemas() map[string]*highbase.SchemaProxy {
+	schemas := make(map[string]*highbase.SchemaProxy)
+	switch m := any(&d.Model).(type) {
+	case *v2high.Swagger:
+		if m.Definitions != nil {
+			for k, v := range m.Definitions.Definitions {
+				schemas[k] = v
+			}
+		}
+	case *v3high.Document:
+		if m.Components != nil {
+			for k, v := range m.Components.Schemas {
+				schemas[k] = v
+			}
+		}
+	}
+	return schemas
+}
+
+// Parameters returns the reusable parameters of the document, by name.
+func (d *DocumentModel[T]) Parameters() map[string]*ComponentParameter {
+	params := make(map[string]*ComponentParameter)
+	switch m := any(&d.Model).(type) {
+	case *v2high.Swagger:
+		if m.Parameters != nil {
+			for k, p := range m.Parameters.Definitions {
+				if p == nil {
+					continue
+				}
+				params[k] = &ComponentParameter{Name: p.Name, In: p.In, Description: p.Description,
+					Required: p.Required != nil && *p.Required, Schema: p.Schema, V2: p}
+			}
+		}
+	case *v3high.Document:
+		if m.Components != nil {
+			for k, p := range m.Components.Parameters {
+				if p == nil {
+					continue
+				}
+				params[k] = &ComponentParameter{Name: p.Name, In: p.In, Description: p.Description,
+					Required: p.Required, Schema: p.EffectiveSchema(), V3: p}
+			}
+		}
+	}
+	return params
+}
+
+// Responses returns the reusable responses of the document, by name.
+func (d *DocumentModel[T]) Responses() map[string]*ComponentResponse {
+	responses := make(map[string]*ComponentResponse)
+	switch m := any(&d.Model).(type) {
+	case *v2high.Swagger:
+		if m.Responses != nil {
+			for k, r := range m.Responses.Definitions {
+				if r != nil {
+					responses[k] = &ComponentResponse{Description: r.Description, V2: r}
+				}
+			}
+		}
+	case *v3high.Document:
+		if m.Components != nil {
+			for k, r := range m.Components.Responses {
+				if r != nil {
+					responses[k] = &ComponentResponse{Description: r.Description, V3: r}
+				}
+			}
+		}
+	}
+	return responses
+}
+
+// SecuritySchemes returns the security schemes of the document, by name: the security definitions of a Swagger
+// document, or the security schemes of the components of an OpenAPI 3 document.
+func (d *DocumentModel[T]) SecuritySchemes() map[string]*ComponentSecurityScheme {
+	schemes := make(map[string]*ComponentSecurityScheme)
+	switch m := any(&d.Model).(type) {
+	case *v2high.Swagger:
+		if m.SecurityDefinitions != nil {
+			for k, s := range m.SecurityDefinitions.Definitions {
+				if s == nil {
+					continue
+				}
+				c := &ComponentSecurityScheme{Type: s.Type, Description: s.Description, Name: s.Name, In: s.In, V2: s}
+				if s.Type == "basic" {
+					c.Type, c.Scheme = "http", "basic"
+				}
+				schemes[k] = c
+			}
+		}
+	case *v3high.Document:
+		if m.Components != nil {
+			for k, s := range m.Components.SecuritySchemes {
+				if s != nil {
+					schemes[k] = &ComponentSecurityScheme{Type: s.Type, Scheme: s.Scheme,
+						Description: s.Description, Name: s.Name, In: s.In, V3: s}
+				}
+			}
+		}
+	}
+	return schemes
+}
+
+// Schema returns the reusable schema with the supplied name, or nil if the document does not define it.
+func (d *DocumentModel[T]) Schema(name string) *highbase.SchemaProxy {
+	return d.Schemas()[name]
+}
+
+// Parameter returns the reusable parameter with the supplied name, or nil if the document does not define it.
+func (d *DocumentModel[T]) Parameter(name string) *ComponentParameter {
+	return d.Parameters()[name]
+}
+
+// Response returns the reusable response with the supplied name, or nil if the document does not define it.
+func (d *DocumentModel[T]) Response(name string) *ComponentResponse {
+	return d.Responses()[name]
+}
+
+// SecurityScheme returns the security scheme with the supplied name, or nil if the document does not define it.
+func (d *DocumentModel[T]) SecurityScheme(name string) *ComponentSecurityScheme {
+	return d.SecuritySchemes()[name]
+}
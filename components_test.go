@@ -0,0 +1,139 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentModel_Components_V2(t *testing.T) {
+
+	yml := `swagger: "2.0"
+definitions:
+  Pizza:
+    type: object
+parameters:
+  limit:
+    name: limit
+    in: query
+    type: integer
+    required: true
+  pizza:
+    name: pizza
+    in: body
+    schema:
+      $ref: '#/definitions/Pizza'
+responses:
+  NotFound:
+    description: no pizza
+securityDefinitions:
+  basic:
+    type: basic
+  key:
+    type: apiKey
+    name: X-Key
+    in: header`
+
+	doc, err := NewDocument([]byte(yml))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+
+	assert.Len(t, m.Schemas(), 1)
+	assert.NotNil(t, m.Schema("Pizza"))
+	assert.Nil(t, m.Schema("Burger"))
+
+	limit := m.Parameter("limit")
+	assert.Equal(t, "limit", limit.Name)
+	assert.Equal(t, "query", limit.In)
+	assert.True(t, limit.Required)
+	assert.Nil(t, limit.Schema)
+	assert.Equal(t, "integer", limit.V2.Type)
+	assert.Nil(t, limit.V3)
+	assert.NotNil(t, m.Parameter("pizza").Schema)
+
+	assert.Equal(t, "no pizza", m.Response("NotFound").Description)
+	assert.NotNil(t, m.Response("NotFound").V2)
+
+	basic := m.SecurityScheme("basic")
+	assert.Equal(t, "http", basic.Type)
+	assert.Equal(t, "basic", basic.Scheme)
+	assert.Equal(t, "basic", basic.V2.Type)
+	assert.Equal(t, "X-Key", m.SecurityScheme("key").Name)
+	assert.Equal(t, "header", m.SecurityScheme("key").In)
+}
+
+func TestDocumentModel_Components_V3(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object
+  parameters:
+    limit:
+      name: limit
+      in: query
+      required: true
+      schema:
+        type: integer
+    filter:
+      name: filter
+      in: query
+      content:
+        application/json:
+          schema:
+            type: object
+  responses:
+    NotFound:
+      description: no pizza
+  securitySchemes:
+    basic:
+      type: http
+      scheme: basic`
+
+	doc, err := NewDocument([]byte(yml))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	assert.Len(t, m.Schemas(), 1)
+	assert.NotNil(t, m.Schema("Pizza"))
+
+	limit := m.Parameter("limit")
+	assert.Equal(t, "limit", limit.Name)
+	assert.True(t, limit.Required)
+	assert.NotNil(t, limit.Schema)
+	assert.NotNil(t, limit.V3)
+	assert.Nil(t, limit.V2)
+	assert.NotNil(t, m.Parameter("filter").Schema)
+
+	assert.Equal(t, "no pizza", m.Response("NotFound").Description)
+	assert.NotNil(t, m.Response("NotFound").V3)
+
+	basic := m.SecurityScheme("basic")
+	assert.Equal(t, "http", basic.Type)
+	assert.Equal(t, "basic", basic.Scheme)
+	assert.NotNil(t, basic.V3)
+}
+
+func TestDocumentModel_Components_Empty(t *testing.T) {
+	doc, err := NewDocument([]byte(`openapi: 3.1.0`))
+	assert.NoError(t, err)
+	m, _ := doc.BuildV3Model()
+	assert.Empty(t, m.Schemas())
+	assert.Empty(t, m.Parameters())
+	assert.Empty(t, m.Responses())
+	assert.Nil(t, m.SecurityScheme("basic"))
+
+	doc, err = NewDocument([]byte(`swagger: "2.0"`))
+	assert.NoError(t, err)
+	v2, _ := doc.BuildV2Model()
+	assert.Empty(t, v2.Schemas())
+	assert.Empty(t, v2.Parameters())
+	assert.Empty(t, v2.Responses())
+	assert.Empty(t, v2.SecuritySchemes())
+}
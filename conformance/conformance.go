@@ -0,0 +1,609 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package conformance checks recorded HTTP traffic (like an HTTP Archive, or HAR) conforms to an OpenAPI document.
+//
+// Every exchange is routed to the operation that handles it (see Router), then its parameters, request body, status
+// code and response body are validated against the operation. The result is a Report of which operations and status
+// codes were exercised by the traffic, along with every exchange that does not conform to the document.
+//
+// Bodies are only validated when they are JSON, the content type of other bodies is checked. Parameters are read
+// according to their style and explode (like 'deepObject', or exploded arrays in a query), before they are
+// validated.
+package conformance
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a single way an exchange does not conform to the document.
+type Problem struct {
+	// In is where the problem is, path, query, header or cookie (for parameters), request, requestBody, response
+	// or responseBody.
+	In      string `json:"in" yaml:"in"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`       // the name of the parameter, if any.
+	Pointer string `json:"pointer,omitempty" yaml:"pointer,omitempty"` // the invalid part of the value, like '#/tag'.
+	Message string `json:"message" yaml:"message"`
+}
+
+// Nonconformance is an exchange that does not conform to the document.
+type Nonconformance struct {
+	Index    int        `json:"index" yaml:"index"` // the position of the exchange in those checked.
+	Exchange *Exchange  `json:"-" yaml:"-"`
+	Path     string     `json:"path,omitempty" yaml:"path,omitempty"` // the operation matched, if any.
+	Method   string     `json:"method,omitempty" yaml:"method,omitempty"`
+	Problems []*Problem `json:"problems" yaml:"problems"`
+}
+
+// OperationCoverage is how much of an operation was exercised by the exchanges checked.
+type OperationCoverage struct {
+	Path        string `json:"path" yaml:"path"`
+	Method      string `json:"method" yaml:"method"`
+	OperationId string `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+
+	// Exchanges is the number of exchanges handled by the operation.
+	Exchanges int `json:"exchanges" yaml:"exchanges"`
+
+	// Exercised and Missing are the documented responses (status codes, ranges like '2XX', or 'default') that
+	// were exercised, and those that were not.
+	Exercised []string `json:"exercised,omitempty" yaml:"exercised,omitempty"`
+	Missing   []string `json:"missing,omitempty" yaml:"missing,omitempty"`
+}
+
+// Report is the result of checking exchanges against a document.
+type Report struct {
+	// Operations is the coverage of every operation, in path and method order.
+	Operations    []*OperationCoverage `json:"operations" yaml:"operations"`
+	Nonconforming []*Nonconformance    `json:"nonconforming,omitempty" yaml:"nonconforming,omitempty"`
+	Exchanges     int                  `json:"exchanges" yaml:"exchanges"`
+}
+
+// Coverage returns the fraction of operations exercised by at least one exchange, between 0 and 1.
+func (r *Report) Coverage() float64 {
+	if len(r.Operations) == 0 {
+		return 0
+	}
+	exercised := 0
+	for _, op := range r.Operations {
+		if op.Exchanges > 0 {
+			exercised++
+		}
+	}
+	return float64(exercised) / float64(len(r.Operations))
+}
+
+// Unexercised returns the operations not exercised by any exchange.
+func (r *Report) Unexercised() []*OperationCoverage {
+	var found []*OperationCoverage
+	for _, op := range r.Operations {
+		if op.Exchanges == 0 {
+			found = append(found, op)
+		}
+	}
+	return found
+}
+
+// Checker checks exchanges conform to a document. It is safe to use from multiple goroutines.
+type Checker struct {
+	doc       *v3.Document
+	router    *Router
+	validator *validation.DocumentValidator
+}
+
+// NewChecker creates a Checker for an OpenAPI document.
+func NewChecker(doc *v3.Document) *Checker {
+	var root *yaml.Node
+	if doc.Index != nil {
+		root = doc.Index.GetRootNode()
+	}
+	return &Checker{doc: doc, router: NewRouter(doc), validator: validation.NewDocumentValidator(root)}
+}
+
+// Router returns the router used to find the operation that handles each exchange.
+func (c *Checker) Router() *Router {
+	return c.router
+}
+
+// Check validates every exchange, and reports the coverage of the document by the exchanges.
+func (c *Checker) Check(exchanges []*Exchange) *Report {
+	report := &Report{Exchanges: len(exchanges)}
+	coverage := make(map[*v3.OperationRef]*OperationCoverage)
+	exercised := make(map[*v3.OperationRef]map[string]bool)
+	for _, ref := range c.doc.GetOperationIndex().GetOperations() {
+		oc := &OperationCoverage{Path: ref.Path, Method: ref.Method, OperationId: ref.Operation.OperationId}
+		coverage[ref] = oc
+		exercised[ref] = make(map[string]bool)
+		report.Operations = append(report.Operations, oc)
+	}
+
+	for i, e := range exchanges {
+		ref, problems, code := c.check(e)
+		if ref != nil {
+			coverage[ref].Exchanges++
+			if code != "" {
+				exercised[ref][code] = true
+			}
+		}
+		if len(problems) > 0 {
+			n := &Nonconformance{Index: i, Exchange: e, Problems: problems}
+			if ref != nil {
+				n.Path, n.Method = ref.Path, ref.Method
+			}
+			report.Nonconforming = append(report.Nonconforming, n)
+		}
+	}
+
+	for ref, oc := range coverage {
+		for _, code := range documentedResponses(ref.Operation) {
+			if exercised[ref][code] {
+				oc.Exercised = append(oc.Exercised, code)
+			} else {
+				oc.Missing = append(oc.Missing, code)
+			}
+		}
+	}
+	return report
+}
+
+// check validates a single exchange, returning the operation that handles it (if any), and the documented
+// response it exercised (if any).
+func (c *Checker) check(e *Exchange) (*v3.OperationRef, []*Problem, string) {
+	ref, pathParams := c.router.Match(e.Method, e.URL)
+	if ref == nil {
+		message := fmt.Sprintf("no operation handles '%s %s'", strings.ToUpper(e.Method), e.URL)
+		if path, _ := c.router.FindPath(e.URL); path != "" {
+			message = fmt.Sprintf("the path '%s' has no '%s' operation", path, strings.ToLower(e.Method))
+		}
+		return nil, []*Problem{{In: "request", Message: message}}, ""
+	}
+	op := ref.Operation
+	var problems []*Problem
+
+	// parameters of the operation override those of the path item.
+	params := make(map[string]*v3.Parameter)
+	var order []string
+	add := func(list []*v3.Parameter) {
+		for _, p := range list {
+			if p == nil {
+				continue
+			}
+			key := p.In + ":" + p.Name
+			if _, ok := params[key]; !ok {
+				order = append(order, key)
+			}
+			params[key] = p
+		}
+	}
+	if pi := c.doc.Paths.GetPathItems()[ref.Path]; pi != nil {
+		add(pi.Parameters)
+	}
+	add(op.Parameters)
+
+	values := &requestValues{path: pathParams, headers: e.RequestHeaders}
+	if u, err := url.Parse(e.URL); err == nil {
+		values.query = u.Query()
+	}
+	values.cookies = (&http.Request{Header: e.RequestHeaders}).Cookies()
+	for _, key := range order {
+		p := params[key]
+		node, found := values.parameter(p)
+		if !found {
+			if p.Required {
+				problems = append(problems, &Problem{In: p.In, Name: p.Name,
+					Message: fmt.Sprintf("the required %s parameter '%s' is missing", p.In, p.Name)})
+			}
+			continue
+		}
+		if p.Schema == nil || (node.Kind == yaml.ScalarNode && node.Value == "" && p.AllowEmptyValue) {
+			continue
+		}
+		for _, v := range c.validate(p.Schema, node) {
+			problems = append(problems, &Problem{In: p.In, Name: p.Name, Pointer: v.Pointer,
+				Message: fmt.Sprintf("the %s parameter '%s' is invalid: %s", p.In, p.Name, v.Message)})
+		}
+	}
+
+	if rb := op.RequestBody; rb != nil {
+		if len(e.RequestBody) == 0 {
+			if rb.Required != nil && *rb.Required {
+				problems = append(problems, &Problem{In: "requestBody", Message: "the required request body is missing"})
+			}
+		} else {
+			problems = append(problems, c.checkBody("requestBody", e.RequestHeaders, e.RequestBody, rb.Content)...)
+		}
+	}
+
+	code, response := findResponse(op, e.StatusCode)
+	if response == nil {
+		problems = append(problems, &Problem{In: "response",
+			Message: fmt.Sprintf("the status code %d is not documented", e.StatusCode)})
+		return ref, problems, ""
+	}
+	if len(e.ResponseBody) > 0 {
+		if len(response.Content) == 0 {
+			problems = append(problems, &Problem{In: "responseBody",
+				Message: fmt.Sprintf("the '%s' response is not documented to have a body", code)})
+		} else {
+			problems = append(problems, c.checkBody("responseBody", e.ResponseHeaders, e.ResponseBody,
+				response.Content)...)
+		}
+	}
+	return ref, problems, code
+}
+
+// checkBody checks the content type of a body is documented, and validates JSON bodies against their schema.
+func (c *Checker) checkBody(in string, headers http.Header, body []byte, content map[string]*v3.MediaType) []*Problem {
+	contentType := headers.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	mt := findMediaType(mediaType, content)
+	if mt == nil {
+		return []*Problem{{In: in, Message: fmt.Sprintf("the content type '%s' is not documented", contentType)}}
+	}
+	if mt.Schema == nil || !isJSON(mediaType) {
+		return nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(body, &node); err != nil {
+		return []*Problem{{In: in, Message: fmt.Sprintf("the body is not valid JSON: %s", err)}}
+	}
+	var problems []*Problem
+	for _, v := range c.validate(mt.Schema, &node) {
+		problems = append(problems, &Problem{In: in, Pointer: v.Pointer, Message: v.Message})
+	}
+	return problems
+}
+
+func (c *Checker) validate(sp *base.SchemaProxy, value *yaml.Node) []*validation.Violation {
+	if sp.GoLow() == nil {
+		return nil
+	}
+	return c.validator.Validate(sp.GoLow().GetValueNode(), value)
+}
+
+// requestValues are the values of the parameters of a request.
+type requestValues struct {
+	path    map[string]string
+	query   url.Values
+	headers http.Header
+	cookies []*http.Cookie
+}
+
+// parameter finds the value of a parameter, and converts it into a yaml node typed by its schema. Arrays and
+// objects are read according to the style and explode of the parameter, the way they are serialized.
+//   - https://spec.openapis.org/oas/v3.1.0#style-values
+func (r *requestValues) parameter(p *v3.Parameter) (*yaml.Node, bool) {
+	style := p.Style
+	if style == "" {
+		style = "simple"
+		if p.In == "query" || p.In == "cookie" {
+			style = "form"
+		}
+	}
+	exploded := style == "form"
+	if p.Explode != nil {
+		exploded = *p.Explode
+	}
+	kind, sch := schemaKind(p.Schema)
+
+	switch p.In {
+	case "path":
+		value, ok := r.path[p.Name]
+		if !ok {
+			return nil, false
+		}
+		switch style {
+		case "label":
+			sep := ","
+			if exploded {
+				sep = "."
+			}
+			return splitNode(strings.TrimPrefix(value, "."), sep, exploded, p.Schema), true
+		case "matrix":
+			value = strings.TrimPrefix(value, ";")
+			if exploded && kind == "array" {
+				items := strings.Split(value, ";")
+				for i := range items {
+					items[i] = strings.TrimPrefix(items[i], p.Name+"=")
+				}
+				return arrayNode(items, sch), true
+			}
+			if exploded && kind == "object" {
+				return splitNode(value, ";", true, p.Schema), true
+			}
+			return splitNode(strings.TrimPrefix(value, p.Name+"="), ",", false, p.Schema), true
+		}
+		return splitNode(value, ",", exploded, p.Schema), true
+
+	case "query":
+		switch {
+		case kind == "object" && style == "deepObject":
+			var pairs [][2]string
+			for name, values := range r.query {
+				if property, ok := strings.CutPrefix(name, p.Name+"["); ok && strings.HasSuffix(property, "]") {
+					pairs = append(pairs, [2]string{strings.TrimSuffix(property, "]"), values[0]})
+				}
+			}
+			return objectNode(pairs, sch), len(pairs) > 0
+		case kind == "object" && style == "form" && exploded:
+			// every property is a parameter of its own.
+			var pairs [][2]string
+			for property, values := range r.query {
+				if propertySchema(sch, property) != nil {
+					pairs = append(pairs, [2]string{property, values[0]})
+				}
+			}
+			return objectNode(pairs, sch), len(pairs) > 0
+		case kind == "array" && exploded:
+			values, ok := r.query[p.Name]
+			return arrayNode(values, sch), ok
+		}
+		values, ok := r.query[p.Name]
+		if !ok {
+			return nil, false
+		}
+		sep := ","
+		switch style {
+		case "spaceDelimited":
+			sep = " "
+		case "pipeDelimited":
+			sep = "|"
+		}
+		return splitNode(strings.Join(values, sep), sep, false, p.Schema), true
+
+	case "header":
+		values := r.headers.Values(p.Name)
+		if len(values) == 0 {
+			return nil, false
+		}
+		return splitNode(strings.Join(values, ","), ",", exploded, p.Schema), true
+
+	case "cookie":
+		var pairs [][2]string
+		var values []string
+		for _, cookie := range r.cookies {
+			if cookie.Name == p.Name {
+				values = append(values, cookie.Value)
+			}
+			if kind == "object" && exploded && propertySchema(sch, cookie.Name) != nil {
+				pairs = append(pairs, [2]string{cookie.Name, cookie.Value})
+			}
+		}
+		switch {
+		case kind == "object" && exploded:
+			return objectNode(pairs, sch), len(pairs) > 0
+		case kind == "array" && exploded:
+			return arrayNode(values, sch), len(values) > 0
+		case len(values) == 0:
+			return nil, false
+		}
+		return splitNode(values[len(values)-1], ",", false, p.Schema), true
+	}
+	return nil, false
+}
+
+// schemaKind returns 'array' or 'object' if a schema is for one of them, along with the schema.
+func schemaKind(sp *base.SchemaProxy) (string, *base.Schema) {
+	var sch *base.Schema
+	if sp != nil {
+		sch = sp.Schema()
+	}
+	if sch == nil {
+		return "", nil
+	}
+	types := schemaTypes(sch, nil)
+	for _, t := range []string{"array", "object"} {
+		if types[t] {
+			return t, sch
+		}
+	}
+	return "", sch
+}
+
+// propertySchema returns the schema of a property of an object schema, or of the schemas it's composed of.
+func propertySchema(sch *base.Schema, name string) *base.SchemaProxy {
+	return composedSchema(sch, nil, func(s *base.Schema) *base.SchemaProxy {
+		return s.Properties[name]
+	})
+}
+
+// composedSchema returns the first schema found by find, in a schema or the schemas it's composed of (like the
+// schemas of 'anyOf').
+func composedSchema(sch *base.Schema, seen map[*base.Schema]bool,
+	find func(*base.Schema) *base.SchemaProxy) *base.SchemaProxy {
+	if sch == nil || seen[sch] {
+		return nil
+	}
+	if found := find(sch); found != nil {
+		return found
+	}
+	if seen == nil {
+		seen = make(map[*base.Schema]bool)
+	}
+	seen[sch] = true
+	for _, list := range [][]*base.SchemaProxy{sch.AllOf, sch.AnyOf, sch.OneOf} {
+		for _, sp := range list {
+			if sp == nil {
+				continue
+			}
+			if found := composedSchema(sp.Schema(), seen, find); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// schemaTypes returns the types a schema allows, including those of the schemas it's composed of (like the
+// schemas of 'anyOf').
+func schemaTypes(sch *base.Schema, seen map[*base.Schema]bool) map[string]bool {
+	types := make(map[string]bool)
+	if sch == nil || seen[sch] {
+		return types
+	}
+	if seen == nil {
+		seen = make(map[*base.Schema]bool)
+	}
+	seen[sch] = true
+	for _, t := range sch.Type {
+		types[t] = true
+	}
+	for _, list := range [][]*base.SchemaProxy{sch.AllOf, sch.AnyOf, sch.OneOf} {
+		for _, sp := range list {
+			if sp == nil {
+				continue
+			}
+			for t := range schemaTypes(sp.Schema(), seen) {
+				types[t] = true
+			}
+		}
+	}
+	return types
+}
+
+// splitNode converts a serialized value into a yaml node. The values of arrays are split by sep, as are the
+// properties of objects, which are 'name=value' pairs when exploded, otherwise names and values take turns.
+func splitNode(value, sep string, exploded bool, sp *base.SchemaProxy) *yaml.Node {
+	kind, sch := schemaKind(sp)
+	switch kind {
+	case "array":
+		return arrayNode(strings.Split(value, sep), sch)
+	case "object":
+		var pairs [][2]string
+		parts := strings.Split(value, sep)
+		for i := 0; i < len(parts); i++ {
+			if exploded {
+				name, v, _ := strings.Cut(parts[i], "=")
+				pairs = append(pairs, [2]string{name, v})
+			} else if i+1 < len(parts) {
+				pairs = append(pairs, [2]string{parts[i], parts[i+1]})
+				i++
+			}
+		}
+		return objectNode(pairs, sch)
+	}
+	return scalarNode(value, sp)
+}
+
+func arrayNode(values []string, sch *base.Schema) *yaml.Node {
+	items := composedSchema(sch, nil, func(s *base.Schema) *base.SchemaProxy {
+		if s.Items != nil && s.Items.IsA() {
+			return s.Items.A
+		}
+		return nil
+	})
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, v := range values {
+		seq.Content = append(seq.Content, scalarNode(v, items))
+	}
+	return seq
+}
+
+// objectNode creates a mapping from the properties of an object, in name order.
+func objectNode(pairs [][2]string, sch *base.Schema) *yaml.Node {
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i][0] < pairs[j][0]
+	})
+	m := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, pair := range pairs {
+		m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: pair[0]},
+			scalarNode(pair[1], propertySchema(sch, pair[0])))
+	}
+	return m
+}
+
+// scalarNode converts a single value into a yaml node, typed by its schema. Arrays and objects nested inside a
+// value are serialized as JSON.
+func scalarNode(value string, sp *base.SchemaProxy) *yaml.Node {
+	kind, sch := schemaKind(sp)
+	if kind != "" {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte(value), &node); err == nil && len(node.Content) > 0 {
+			return node.Content[0]
+		}
+	}
+	// values are strings, unless the schema allows something else, which is then read like YAML.
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	types := schemaTypes(sch, nil)
+	if len(types) > 0 && !(len(types) == 1 && types["string"]) {
+		node.Tag = ""
+	}
+	return node
+}
+
+// findResponse returns the documented response for a status code, an exact match, then a range (like '2XX'),
+// then the default response.
+func findResponse(op *v3.Operation, status int) (string, *v3.Response) {
+	if op.Responses == nil {
+		return "", nil
+	}
+	code := strconv.Itoa(status)
+	if r := op.Responses.Codes[code]; r != nil {
+		return code, r
+	}
+	for k, r := range op.Responses.Codes {
+		if len(k) == 3 && len(code) == 3 && k[0] == code[0] && strings.EqualFold(k[1:], "XX") {
+			return k, r
+		}
+	}
+	if op.Responses.Default != nil {
+		return "default", op.Responses.Default
+	}
+	return "", nil
+}
+
+// documentedResponses returns the status codes of every response of an operation, in order, with 'default' last.
+func documentedResponses(op *v3.Operation) []string {
+	if op.Responses == nil {
+		return nil
+	}
+	var codes []string
+	for code := range op.Responses.Codes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	if op.Responses.Default != nil {
+		codes = append(codes, "default")
+	}
+	return codes
+}
+
+// findMediaType returns the documented media type for a content type, an exact match, then a wildcard (like
+// 'application/*' or '*/*').
+func findMediaType(mediaType string, content map[string]*v3.MediaType) *v3.MediaType {
+	wildcards := []string{mediaType}
+	if major, _, ok := strings.Cut(mediaType, "/"); ok {
+		wildcards = append(wildcards, major+"/*")
+	}
+	wildcards = append(wildcards, "*/*")
+	for _, w := range wildcards {
+		for k, mt := range content {
+			parsed, _, err := mime.ParseMediaType(k)
+			if err != nil {
+				parsed = strings.ToLower(k)
+			}
+			if parsed == w {
+				return mt
+			}
+		}
+	}
+	return nil
+}
+
+func isJSON(mediaType string) bool {
+	_, sub, _ := strings.Cut(mediaType, "/")
+	return sub == "json" || strings.HasSuffix(sub, "+json")
+}
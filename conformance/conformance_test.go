@@ -0,0 +1,382 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package conformance
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/example"
+	"github.com/stretchr/testify/assert"
+)
+
+var petsSpec = `openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+servers:
+  - url: https://{host}/v1
+    variables:
+      host:
+        default: example.com
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [cat, dog]
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+        default:
+          $ref: '#/components/responses/Error'
+    post:
+      operationId: createPet
+      parameters:
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: created
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      operationId: getPet
+      responses:
+        '200':
+          description: a pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        4XX:
+          description: not found
+  /pets/mine:
+    get:
+      operationId: getMyPet
+      responses:
+        '200':
+          description: my pet
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          nullable: true
+  responses:
+    Error:
+      description: an error
+      content:
+        application/json:
+          schema:
+            type: object
+            required: [message]`
+
+func buildPetsModel(t *testing.T) *v3.Document {
+	doc, err := libopenapi.NewDocument([]byte(petsSpec))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	return &m.Model
+}
+
+func jsonHeaders() http.Header {
+	return http.Header{"Content-Type": []string{"application/json; charset=utf-8"}}
+}
+
+func TestChecker_Check(t *testing.T) {
+	exchanges := []*Exchange{
+		{ // 0: conforms.
+			Method: "GET", URL: "https://example.com/v1/pets?limit=10&tags=cat&tags=dog", StatusCode: 200,
+			ResponseHeaders: jsonHeaders(), ResponseBody: []byte(`[{"name": "fluffy", "tag": null}]`),
+		},
+		{ // 1: bad parameters, and a bad body for the default response.
+			Method: "GET", URL: "/v1/pets?limit=500&tags=fish", StatusCode: 500,
+			ResponseHeaders: jsonHeaders(), ResponseBody: []byte(`{"error": "boom"}`),
+		},
+		{ // 2: missing header and body.
+			Method: "POST", URL: "/pets", StatusCode: 201,
+		},
+		{ // 3: body missing a required property, an undocumented status code.
+			Method: "POST", URL: "/pets", StatusCode: 202,
+			RequestHeaders: http.Header{"Content-Type": []string{"application/json"}, "X-Request-Id": []string{"1"}},
+			RequestBody:    []byte(`{"tag": 12}`),
+		},
+		{ // 4: the path parameter is not an integer, a range response.
+			Method: "GET", URL: "/pets/abc", StatusCode: 404,
+		},
+		{ // 5: literal paths are preferred over templates.
+			Method: "GET", URL: "/pets/mine", StatusCode: 200,
+		},
+		{ // 6: no such operation.
+			Method: "DELETE", URL: "/pets/1", StatusCode: 204,
+		},
+		{ // 7: no such path.
+			Method: "GET", URL: "/owners", StatusCode: 200,
+		},
+		{ // 8: an undocumented content type.
+			Method: "GET", URL: "/pets/1", StatusCode: 200,
+			ResponseHeaders: http.Header{"Content-Type": []string{"text/plain"}}, ResponseBody: []byte("fluffy"),
+		},
+	}
+
+	report := NewChecker(buildPetsModel(t)).Check(exchanges)
+	assert.Equal(t, 9, report.Exchanges)
+
+	problems := make(map[int][]string)
+	for _, n := range report.Nonconforming {
+		for _, p := range n.Problems {
+			problems[n.Index] = append(problems[n.Index], p.In+": "+p.Message)
+		}
+	}
+	assert.Equal(t, map[int][]string{
+		1: {
+			"query: the query parameter 'limit' is invalid: 500 must be at most 100",
+			"query: the query parameter 'tags' is invalid: 'fish' is not one of 'cat', 'dog'",
+			"responseBody: missing required property 'message'",
+		},
+		2: {
+			"header: the required header parameter 'X-Request-Id' is missing",
+			"requestBody: the required request body is missing",
+		},
+		3: {
+			"requestBody: missing required property 'name'",
+			"requestBody: expected string, found integer",
+			"response: the status code 202 is not documented",
+		},
+		4: {"path: the path parameter 'id' is invalid: expected integer, found string"},
+		6: {"request: the path '/pets/{id}' has no 'delete' operation"},
+		7: {"request: no operation handles 'GET /owners'"},
+		8: {"responseBody: the content type 'text/plain' is not documented"},
+	}, problems)
+
+	assert.Equal(t, "/pets", report.Nonconforming[0].Path)
+	assert.Equal(t, "get", report.Nonconforming[0].Method)
+	assert.Equal(t, "#/tag", report.Nonconforming[2].Problems[1].Pointer)
+
+	assert.Len(t, report.Operations, 4)
+	list := report.Operations[0]
+	assert.Equal(t, "listPets", list.OperationId)
+	assert.Equal(t, 2, list.Exchanges)
+	assert.Equal(t, []string{"200", "default"}, list.Exercised)
+	assert.Empty(t, list.Missing)
+
+	create := report.Operations[1]
+	assert.Equal(t, "createPet", create.OperationId)
+	assert.Equal(t, []string{"201"}, create.Exercised)
+
+	get := report.Operations[3]
+	assert.Equal(t, "getPet", get.OperationId)
+	assert.Equal(t, 2, get.Exchanges)
+	assert.Equal(t, []string{"200", "4XX"}, get.Exercised)
+
+	assert.Equal(t, 1.0, report.Coverage())
+	assert.Empty(t, report.Unexercised())
+}
+
+func TestChecker_Check_Unexercised(t *testing.T) {
+	report := NewChecker(buildPetsModel(t)).Check([]*Exchange{{Method: "GET", URL: "/pets/mine", StatusCode: 200}})
+	assert.Empty(t, report.Nonconforming)
+	assert.Equal(t, 0.25, report.Coverage())
+	assert.Len(t, report.Unexercised(), 3)
+	assert.Equal(t, []string{"200", "default"}, report.Unexercised()[0].Missing)
+}
+
+func TestParseHAR(t *testing.T) {
+	exchanges, err := ParseHAR([]byte(`{"log": {"entries": [{
+  "request": {
+    "method": "POST",
+    "url": "https://example.com/v1/pets",
+    "headers": [{"name": "X-Request-Id", "value": "abc"}],
+    "postData": {"mimeType": "application/json", "text": "{\"name\": \"fluffy\"}"}
+  },
+  "response": {
+    "status": 201,
+    "headers": [],
+    "content": {"mimeType": "application/json", "text": "e30=", "encoding": "base64"}
+  }
+}]}}`))
+	assert.NoError(t, err)
+	assert.Len(t, exchanges, 1)
+	assert.Equal(t, "POST", exchanges[0].Method)
+	assert.Equal(t, "abc", exchanges[0].RequestHeaders.Get("X-Request-Id"))
+	assert.Equal(t, "application/json", exchanges[0].RequestHeaders.Get("Content-Type"))
+	assert.Equal(t, `{"name": "fluffy"}`, string(exchanges[0].RequestBody))
+	assert.Equal(t, "{}", string(exchanges[0].ResponseBody))
+	assert.Equal(t, "application/json", exchanges[0].ResponseHeaders.Get("Content-Type"))
+
+	report := NewChecker(buildPetsModel(t)).Check(exchanges)
+	assert.Len(t, report.Nonconforming, 1)
+	assert.Equal(t, "the '201' response is not documented to have a body", report.Nonconforming[0].Problems[0].Message)
+
+	_, err = ParseHAR([]byte("nope"))
+	assert.Error(t, err)
+	_, err = ParseHAR([]byte(`{"log": {"entries": [{"response": {"content": {"text": "!", "encoding": "base64"}}}]}}`))
+	assert.Error(t, err)
+}
+
+func TestRouter_Match(t *testing.T) {
+	router := NewRouter(buildPetsModel(t))
+
+	ref, params := router.Match("get", "https://example.com/v1/pets/12%2F3")
+	assert.Equal(t, "getPet", ref.Operation.OperationId)
+	assert.Equal(t, map[string]string{"id": "12/3"}, params)
+
+	ref, _ = router.Match("GET", "/pets/mine")
+	assert.Equal(t, "getMyPet", ref.Operation.OperationId)
+
+	ref, _ = router.Match("DELETE", "/pets/mine")
+	assert.Nil(t, ref)
+
+	path, params := router.FindPath("/v1/pets/7?x=1")
+	assert.Equal(t, "/pets/{id}", path)
+	assert.Equal(t, "7", params["id"])
+
+	path, _ = router.FindPath("/v2/pets")
+	assert.Empty(t, path)
+}
+
+var stylesSpec = `openapi: 3.1.0
+info:
+  title: styles
+  version: 1.0.0
+paths:
+  /things/{label}/{matrix}:
+    get:
+      parameters:
+        - name: label
+          in: path
+          required: true
+          style: label
+          explode: true
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: matrix
+          in: path
+          required: true
+          style: matrix
+          schema:
+            type: object
+            properties:
+              r:
+                type: integer
+        - name: filter
+          in: query
+          style: deepObject
+          explode: true
+          schema:
+            type: object
+            properties:
+              size:
+                type: integer
+        - name: ids
+          in: query
+          style: pipeDelimited
+          explode: false
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: csv
+          in: query
+          explode: false
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: session
+          in: cookie
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: ok`
+
+func TestChecker_Check_Styles(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(stylesSpec))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	checker := NewChecker(&m.Model)
+
+	cookie := http.Header{"Cookie": []string{"session=42"}}
+	report := checker.Check([]*Exchange{
+		{Method: "GET", URL: "/things/.1.2/;matrix=r,3?filter[size]=4&ids=5|6&csv=7,8", RequestHeaders: cookie,
+			StatusCode: 200},
+		{Method: "GET", URL: "/things/.1.x/;matrix=r,y?filter[size]=z&ids=5|w&csv=7,v",
+			RequestHeaders: http.Header{"Cookie": []string{"session=u"}}, StatusCode: 200},
+	})
+	assert.Len(t, report.Nonconforming, 1)
+	assert.Equal(t, 1, report.Nonconforming[0].Index)
+
+	names := make(map[string]string)
+	for _, p := range report.Nonconforming[0].Problems {
+		names[p.Name] = p.Pointer
+	}
+	assert.Equal(t, map[string]string{"label": "#/1", "matrix": "#/r", "filter": "#/size", "ids": "#/1",
+		"csv": "#/1", "session": "#"}, names)
+}
+
+func TestChecker_Check_ExampleRequests(t *testing.T) {
+	spec, err := os.ReadFile("../test_specs/stripe.yaml")
+	assert.NoError(t, err)
+	doc, err := libopenapi.NewDocument(spec)
+	assert.NoError(t, err)
+	m, _ := doc.BuildV3Model()
+
+	var exchanges []*Exchange
+	for _, r := range example.Requests(&m.Model, nil) {
+		exchanges = append(exchanges, &Exchange{Method: r.Method, URL: r.URL, RequestHeaders: r.Headers,
+			StatusCode: 200})
+	}
+	for _, n := range NewChecker(&m.Model).Check(exchanges).Nonconforming {
+		for _, p := range n.Problems {
+			switch p.In {
+			case "path", "query", "header", "cookie":
+				assert.Fail(t, "example request rejected", "%s %s: %s", n.Method, n.Path, p.Message)
+			}
+		}
+	}
+}
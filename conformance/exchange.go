@@ -0,0 +1,99 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package conformance
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Exchange is a recorded HTTP request, and the response it received.
+type Exchange struct {
+	Method          string
+	URL             string // the full URL of the request, or just its path (and query).
+	RequestHeaders  http.Header
+	RequestBody     []byte
+	StatusCode      int
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+}
+
+// har is the part of an HTTP Archive (HAR 1.2) needed to read exchanges.
+type har struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method   string      `json:"method"`
+				URL      string      `json:"url"`
+				Headers  []harHeader `json:"headers"`
+				PostData *struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+				} `json:"postData"`
+			} `json:"request"`
+			Response struct {
+				Status  int         `json:"status"`
+				Headers []harHeader `json:"headers"`
+				Content struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func harHeaders(headers []harHeader) http.Header {
+	h := make(http.Header, len(headers))
+	for _, header := range headers {
+		h.Add(header.Name, header.Value)
+	}
+	return h
+}
+
+// ParseHAR reads every exchange recorded in an HTTP Archive (HAR), as exported by browsers and proxies.
+func ParseHAR(data []byte) ([]*Exchange, error) {
+	var archive har
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("unable to parse HAR: %w", err)
+	}
+	exchanges := make([]*Exchange, 0, len(archive.Log.Entries))
+	for i, entry := range archive.Log.Entries {
+		e := &Exchange{
+			Method:          entry.Request.Method,
+			URL:             entry.Request.URL,
+			RequestHeaders:  harHeaders(entry.Request.Headers),
+			StatusCode:      entry.Response.Status,
+			ResponseHeaders: harHeaders(entry.Response.Headers),
+		}
+		if pd := entry.Request.PostData; pd != nil {
+			e.RequestBody = []byte(pd.Text)
+			if e.RequestHeaders.Get("Content-Type") == "" && pd.MimeType != "" {
+				e.RequestHeaders.Set("Content-Type", pd.MimeType)
+			}
+		}
+		content := entry.Response.Content
+		e.ResponseBody = []byte(content.Text)
+		if content.Encoding == "base64" {
+			body, err := base64.StdEncoding.DecodeString(content.Text)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode the response body of entry %d: %w", i, err)
+			}
+			e.ResponseBody = body
+		}
+		if e.ResponseHeaders.Get("Content-Type") == "" && content.MimeType != "" {
+			e.ResponseHeaders.Set("Content-Type", content.MimeType)
+		}
+		exchanges = append(exchanges, e)
+	}
+	return exchanges, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package conformance
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+var templateExp = regexp.MustCompile(`\{([^}/]+)}`)
+
+// Router finds the operation of a document that handles a request, by its method and path.
+//
+// Paths are matched against the path templates of the document, literal segments are preferred over template
+// parameters, so '/pets/mine' is matched before '/pets/{id}'. Requests can include the base path of any of the
+// servers of the document (like '/v1' for 'https://example.com/v1').
+type Router struct {
+	routes    []*route
+	basePaths []string
+}
+
+type route struct {
+	path       string
+	exp        *regexp.Regexp
+	params     []string
+	templated  int // the number of templated segments.
+	operations map[string]*v3.OperationRef
+}
+
+// NewRouter creates a Router for every operation defined under the paths of a document.
+func NewRouter(doc *v3.Document) *Router {
+	r := new(Router)
+	byPath := make(map[string]*route)
+	for _, ref := range doc.GetOperationIndex().GetOperations() {
+		rt := byPath[ref.Path]
+		if rt == nil {
+			rt = newRoute(ref.Path)
+			byPath[ref.Path] = rt
+			r.routes = append(r.routes, rt)
+		}
+		rt.operations[ref.Method] = ref
+	}
+	sort.SliceStable(r.routes, func(i, j int) bool {
+		return r.routes[i].templated < r.routes[j].templated
+	})
+	for _, server := range doc.Servers {
+		if base := serverBasePath(server); base != "" {
+			r.basePaths = append(r.basePaths, base)
+		}
+	}
+	// longer base paths first, so '/v1/beta' is stripped before '/v1'.
+	sort.SliceStable(r.basePaths, func(i, j int) bool {
+		return len(r.basePaths[i]) > len(r.basePaths[j])
+	})
+	return r
+}
+
+func newRoute(path string) *route {
+	rt := &route{path: path, operations: make(map[string]*v3.OperationRef)}
+	var exp strings.Builder
+	exp.WriteString("^")
+	for i, segment := range strings.Split(path, "/") {
+		if i > 0 {
+			exp.WriteString("/")
+		}
+		matches := templateExp.FindAllStringSubmatchIndex(segment, -1)
+		if len(matches) > 0 {
+			rt.templated++
+		}
+		last := 0
+		for _, m := range matches {
+			exp.WriteString(regexp.QuoteMeta(segment[last:m[0]]))
+			exp.WriteString("([^/]+)")
+			rt.params = append(rt.params, segment[m[2]:m[3]])
+			last = m[1]
+		}
+		exp.WriteString(regexp.QuoteMeta(segment[last:]))
+	}
+	exp.WriteString("$")
+	rt.exp = regexp.MustCompile(exp.String())
+	return rt
+}
+
+// serverBasePath returns the path of the URL of a server, with variables set to their defaults.
+func serverBasePath(server *v3.Server) string {
+	if server == nil {
+		return ""
+	}
+	u := server.URL
+	for name, v := range server.Variables {
+		if v != nil {
+			u = strings.ReplaceAll(u, "{"+name+"}", v.Default)
+		}
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(parsed.Path, "/")
+}
+
+// FindPath returns the path template that matches the path of a URL, along with the values of its parameters.
+// Returns an empty path if nothing matches.
+func (r *Router) FindPath(rawURL string) (string, map[string]string) {
+	rt, params := r.find(rawURL, "")
+	if rt == nil {
+		return "", nil
+	}
+	return rt.path, params
+}
+
+// Match returns the operation that handles a request, along with the values of its path parameters. Returns nil
+// if no operation handles the request.
+func (r *Router) Match(method, rawURL string) (*v3.OperationRef, map[string]string) {
+	rt, params := r.find(rawURL, strings.ToLower(method))
+	if rt == nil {
+		return nil, nil
+	}
+	return rt.operations[strings.ToLower(method)], params
+}
+
+// find returns the most specific route for a URL, that has an operation for the method (if there is one).
+func (r *Router) find(rawURL, method string) (*route, map[string]string) {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.EscapedPath()
+	}
+	candidates := []string{path}
+	for _, base := range r.basePaths {
+		if strings.HasPrefix(path, base+"/") {
+			candidates = append(candidates, strings.TrimPrefix(path, base))
+		}
+	}
+	for _, candidate := range candidates {
+		for _, rt := range r.routes {
+			if method != "" && rt.operations[method] == nil {
+				continue
+			}
+			m := rt.exp.FindStringSubmatch(candidate)
+			if m == nil {
+				continue
+			}
+			params := make(map[string]string, len(rt.params))
+			for i, name := range rt.params {
+				value, err := url.PathUnescape(m[i+1])
+				if err != nil {
+					value = m[i+1]
+				}
+				params[name] = value
+			}
+			return rt, params
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ArchiveManifests are the names of the manifest files that are checked (in order) when locating the root
+// specification of an archive. A manifest holds the path of the root specification, under a 'root' key, for example:
+//
+//	root: specs/openapi.yaml
+var ArchiveManifests = []string{"manifest.yaml", "manifest.yml", "manifest.json"}
+
+// ArchiveRootFiles are the names of the files that are checked (in order) when locating the root specification of
+// an archive that has no manifest.
+var ArchiveRootFiles = []string{
+	"openapi.yaml", "openapi.yml", "openapi.json",
+	"swagger.yaml", "swagger.yml", "swagger.json",
+}
+
+// MaxArchiveFiles is the most files an archive can contain, archives with more files are not extracted.
+var MaxArchiveFiles = 10000
+
+// MaxArchiveSize is the most bytes the files in an archive can add up to once decompressed, archives that are larger
+// are not extracted. It stops small archives that decompress into very large ones from using up all memory.
+var MaxArchiveSize int64 = 256 << 20
+
+// ExtractArchive extracts every file in a zip, tar or gzipped tar archive, into a map of paths to file contents.
+// Paths are slash separated and relative to the root of the archive. If every file in the archive is inside a single
+// directory (a common way to bundle files), that directory is treated as the root of the archive.
+//
+// An archive that has more than MaxArchiveFiles files, or files larger than MaxArchiveSize in total, returns an error.
+func ExtractArchive(archive []byte) (map[string][]byte, error) {
+	var files map[string][]byte
+	var err error
+	limits := &archiveLimits{files: MaxArchiveFiles, size: MaxArchiveSize}
+	switch {
+	case bytes.HasPrefix(archive, []byte("PK\x03\x04")), bytes.HasPrefix(archive, []byte("PK\x05\x06")):
+		files, err = extractZip(archive, limits)
+	case bytes.HasPrefix(archive, []byte{0x1f, 0x8b}):
+		var gz *gzip.Reader
+		if gz, err = gzip.NewReader(bytes.NewReader(archive)); err == nil {
+			files, err = extractTar(gz, limits)
+		}
+	default:
+		files, err = extractTar(bytes.NewReader(archive), limits)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract archive: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, errors.New("unable to extract archive: archive contains no files")
+	}
+	return stripArchiveDirectory(files), nil
+}
+
+// FindArchiveRoot locates the root specification of an extracted archive. A root that is supplied is always used,
+// otherwise the root is read from a manifest (see ArchiveManifests), and finally the well known names of root
+// specifications are checked (see ArchiveRootFiles).
+func FindArchiveRoot(files map[string][]byte, root string) (string, error) {
+	if root != "" {
+		root = cleanArchivePath(root)
+		if _, ok := files[root]; !ok {
+			return "", fmt.Errorf("root specification '%s' not found in archive", root)
+		}
+		return root, nil
+	}
+	for _, m := range ArchiveManifests {
+		data, ok := files[m]
+		if !ok {
+			continue
+		}
+		var manifest struct {
+			Root string `yaml:"root"`
+		}
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return "", fmt.Errorf("unable to read archive manifest '%s': %w", m, err)
+		}
+		if manifest.Root == "" {
+			return "", fmt.Errorf("archive manifest '%s' has no root", m)
+		}
+		return FindArchiveRoot(files, manifest.Root)
+	}
+	for _, r := range ArchiveRootFiles {
+		if _, ok := files[r]; ok {
+			return r, nil
+		}
+	}
+	return "", errors.New("unable to locate the root specification in archive, no manifest or well known root found")
+}
+
+// archiveLimits counts down the files and bytes left to extract from an archive.
+type archiveLimits struct {
+	files int
+	size  int64
+}
+
+// read reads a file from an archive, returning an error if the archive has too many files, or the file doesn't fit
+// in the bytes that are left.
+func (l *archiveLimits) read(r io.Reader) ([]byte, error) {
+	if l.files--; l.files < 0 {
+		return nil, fmt.Errorf("archive contains more than %d files", MaxArchiveFiles)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, l.size+1))
+	if err != nil {
+		return nil, err
+	}
+	if l.size -= int64(len(data)); l.size < 0 {
+		return nil, fmt.Errorf("archive is larger than %d bytes when decompressed", MaxArchiveSize)
+	}
+	return data, nil
+}
+
+func extractZip(archive []byte, limits *archiveLimits) (map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := limits.read(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[cleanArchivePath(f.Name)] = data
+	}
+	return files, nil
+}
+
+func extractTar(r io.Reader, limits *archiveLimits) (map[string][]byte, error) {
+	tr := tar.NewReader(r)
+	files := make(map[string][]byte)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := limits.read(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[cleanArchivePath(h.Name)] = data
+	}
+}
+
+// cleanArchivePath cleans a path in an archive, so it's slash separated, relative, and can't escape the archive.
+func cleanArchivePath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(p, "\\", "/")), "/")
+}
+
+// stripArchiveDirectory removes the directory every file is in, if all files share a single top level directory.
+func stripArchiveDirectory(files map[string][]byte) map[string][]byte {
+	var dir string
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		d, _, found := strings.Cut(name, "/")
+		if !found || (dir != "" && d != dir) {
+			return files
+		}
+		dir = d
+	}
+	stripped := make(map[string][]byte, len(files))
+	for name, data := range files {
+		stripped[strings.TrimPrefix(name, dir+"/")] = data
+	}
+	return stripped
+}
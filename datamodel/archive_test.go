@@ -0,0 +1,154 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createZip(files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(data))
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func createTar(files map[string]string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	_ = tw.WriteHeader(&tar.Header{Name: "bundle/", Typeflag: tar.TypeDir, Mode: 0o755})
+	for name, data := range files {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(data))})
+		_, _ = tw.Write([]byte(data))
+	}
+	_ = tw.Close()
+	return buf.Bytes()
+}
+
+func TestExtractArchive_Zip(t *testing.T) {
+	files, err := ExtractArchive(createZip(map[string]string{
+		"openapi.yaml":     "openapi: 3.1.0",
+		"schemas/pet.yaml": "type: object",
+	}))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, "type: object", string(files["schemas/pet.yaml"]))
+}
+
+func TestExtractArchive_Tar(t *testing.T) {
+	files, err := ExtractArchive(createTar(map[string]string{
+		"bundle/openapi.yaml":     "openapi: 3.1.0",
+		"bundle/schemas/pet.yaml": "type: object",
+	}))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	// the single top level directory is removed.
+	assert.Equal(t, "openapi: 3.1.0", string(files["openapi.yaml"]))
+	assert.Equal(t, "type: object", string(files["schemas/pet.yaml"]))
+}
+
+func TestExtractArchive_TarGz(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write(createTar(map[string]string{"openapi.yaml": "openapi: 3.1.0"}))
+	_ = gz.Close()
+
+	files, err := ExtractArchive(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0", string(files["openapi.yaml"]))
+}
+
+func TestExtractArchive_EscapingPaths(t *testing.T) {
+	files, err := ExtractArchive(createZip(map[string]string{
+		"../../etc/openapi.yaml": "openapi: 3.1.0",
+		"/schemas/pet.yaml":      "type: object",
+	}))
+	assert.NoError(t, err)
+	assert.Contains(t, files, "etc/openapi.yaml")
+	assert.Contains(t, files, "schemas/pet.yaml")
+}
+
+func TestExtractArchive_Bad(t *testing.T) {
+	_, err := ExtractArchive([]byte("not an archive, not even close"))
+	assert.Error(t, err)
+
+	_, err = ExtractArchive([]byte{0x1f, 0x8b, 0x00})
+	assert.Error(t, err)
+
+	_, err = ExtractArchive(createZip(map[string]string{}))
+	assert.EqualError(t, err, "unable to extract archive: archive contains no files")
+}
+
+func TestExtractArchive_Limits(t *testing.T) {
+	defer func(files int, size int64) {
+		MaxArchiveFiles, MaxArchiveSize = files, size
+	}(MaxArchiveFiles, MaxArchiveSize)
+
+	archive := createZip(map[string]string{
+		"openapi.yaml":     "openapi: 3.1.0",
+		"schemas/pet.yaml": "type: object",
+	})
+	MaxArchiveFiles = 1
+	_, err := ExtractArchive(archive)
+	assert.EqualError(t, err, "unable to extract archive: archive contains more than 1 files")
+
+	MaxArchiveFiles, MaxArchiveSize = 10, 20
+	_, err = ExtractArchive(archive)
+	assert.EqualError(t, err, "unable to extract archive: archive is larger than 20 bytes when decompressed")
+
+	_, err = ExtractArchive(createTar(map[string]string{"openapi.yaml": strings.Repeat("x", 21)}))
+	assert.EqualError(t, err, "unable to extract archive: archive is larger than 20 bytes when decompressed")
+
+	MaxArchiveSize = 26
+	files, err := ExtractArchive(archive)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestFindArchiveRoot(t *testing.T) {
+	files := map[string][]byte{
+		"swagger.json":      []byte("{}"),
+		"openapi.yaml":      []byte("openapi: 3.1.0"),
+		"specs/burger.yaml": []byte("openapi: 3.1.0"),
+	}
+
+	root, err := FindArchiveRoot(files, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "openapi.yaml", root)
+
+	root, err = FindArchiveRoot(files, "./specs/burger.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "specs/burger.yaml", root)
+
+	_, err = FindArchiveRoot(files, "pizza.yaml")
+	assert.EqualError(t, err, "root specification 'pizza.yaml' not found in archive")
+
+	files["manifest.yaml"] = []byte("root: specs/burger.yaml")
+	root, err = FindArchiveRoot(files, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "specs/burger.yaml", root)
+
+	files["manifest.yaml"] = []byte("name: burgers")
+	_, err = FindArchiveRoot(files, "")
+	assert.EqualError(t, err, "archive manifest 'manifest.yaml' has no root")
+
+	files["manifest.yaml"] = []byte("{{")
+	_, err = FindArchiveRoot(files, "")
+	assert.Error(t, err)
+
+	_, err = FindArchiveRoot(map[string][]byte{"pet.yaml": nil}, "")
+	assert.Error(t, err)
+}
@@ -46,6 +46,12 @@ var OpenAPI31SchemaData string // embedded OAS31 schema
 //go:embed schemas/swagger2-schema.json
 var OpenAPI2SchemaData string // embedded OAS3 schema
 
+// JSONSchemaDraft04Data is an embedded version of the JSON Schema draft 4 meta-schema, which the OpenAPI 2 (Swagger)
+// schema refers to.
+//
+//go:embed schemas/draft04-schema.json
+var JSONSchemaDraft04Data string // embedded draft 4 meta-schema
+
 // OAS3_1Format defines documents that can only be version 3.1
 var OAS3_1Format = []string{OAS31}
 
@@ -4,8 +4,12 @@
 package datamodel
 
 import (
+	"io/fs"
 	"net/http"
 	"net/url"
+	"time"
+
+	"github.com/pb33f/libopenapi/index"
 )
 
 // DocumentConfiguration is used to configure the document creation process. It was added in v0.6.0 to allow
@@ -23,10 +27,67 @@ type DocumentConfiguration struct {
 	// Resolves [#132]: https://github.com/pb33f/libopenapi/issues/132
 	RemoteURLHandler func(url string) (*http.Response, error)
 
+	// RemoteHTTPClient is the client used to retrieve remote documents, if no RemoteURLHandler is set.
+	RemoteHTTPClient *http.Client
+
+	// RemoteHeaders are headers sent when retrieving remote documents, by host (for example 'pb33f.io'). Useful for
+	// passing API tokens to private specification registries.
+	RemoteHeaders map[string]http.Header
+
+	// RemoteTimeout overrides the timeout used when retrieving remote documents.
+	RemoteTimeout time.Duration
+
+	// RemoteCheckRedirect overrides the redirect policy used when retrieving remote documents
+	// (see http.Client.CheckRedirect).
+	RemoteCheckRedirect func(req *http.Request, via []*http.Request) error
+
+	// RemoteCache holds remote documents between builds, so repeated builds don't download the same documents again.
+	// Use index.NewMemoryRemoteCache or index.NewDirectoryRemoteCache, or supply your own.
+	RemoteCache index.RemoteCache
+
+	// ParsedDocumentCache holds parsed external documents (files and remote documents) by the hash of their content,
+	// so documents referenced by many specifications are only parsed once. Use index.NewMemoryParsedDocumentCache,
+	// or supply your own.
+	ParsedDocumentCache index.ParsedDocumentCache
+
+	// MaxConcurrentFetches limits the number of external documents fetched at once when resolving references.
+	// Zero (the default) means no limit.
+	MaxConcurrentFetches int
+
+	// Sandbox restricts what can be read when resolving references (hosts, file roots, network access and sizes).
+	// Use it when parsing untrusted specifications.
+	Sandbox *index.ReferenceSandbox
+
+	// CircularReferencePolicy decides which circular references are reported as errors (infinite circular
+	// references always are). Use index.CircularReferencesStub to expand and stub loops when resolving.
+	CircularReferencePolicy index.CircularReferencePolicy
+
+	// CircularReferenceStubDepth is the number of times a loop is expanded before it's stubbed, when the
+	// CircularReferencePolicy is index.CircularReferencesStub.
+	CircularReferenceStubDepth int
+
+	// SchemeHandlers are used to fetch documents referenced using non-http schemes, by scheme (for example 's3' or
+	// 'registry'). Each handler is passed the URI of the document and returns its bytes.
+	SchemeHandlers map[string]func(uri string) ([]byte, error)
+
+	// LocalFS is the file system file references are read from, instead of the OS file system (for example an
+	// embed.FS). When set, BasePath is a path within LocalFS, and defaults to the root of the file system.
+	LocalFS fs.FS
+
+	// Files is a set of in-memory files (a map of paths to their contents) that file references are resolved against,
+	// instead of the OS file system. It's useful when a multi-file specification isn't on disk, for example when it's
+	// received over an API. Paths are relative to the root specification, files not in the set don't exist.
+	// Files take precedence over LocalFS.
+	Files map[string][]byte
+
 	// If resolving locally, the BasePath will be the root from which relative references will be resolved from.
 	// It's usually the location of the root specification.
 	BasePath string // set the Base Path for resolving relative references if the spec is exploded.
 
+	// SpecFilePath is the location (a file path or URL) of the root specification. It's not used for reading the
+	// specification, it's used to report where objects originated from, when working with multi-file specifications.
+	SpecFilePath string
+
 	// AllowFileReferences will allow the index to locate relative file references. This is disabled by default.
 	AllowFileReferences bool
 
@@ -37,9 +98,86 @@ type DocumentConfiguration struct {
 	// This is useful for developers building out models that should be indexed later on.
 	AvoidIndexBuild bool
 
+	// AvoidPathItemBuild will avoid building the path items of an OpenAPI 3+ document, the paths are still indexed.
+	// This is disabled by default. Path items can be built one at a time, and released once they have been used
+	// (see libopenapi.StreamV3Paths), which keeps memory down for analysis of huge documents.
+	AvoidPathItemBuild bool
+
 	// BypassDocumentCheck will bypass the document check. This is disabled by default. This will allow any document to
 	// passed in and used. Only enable this when parsing non openapi documents.
 	BypassDocumentCheck bool
+
+	// BuildModelLazily will build the path items of an OpenAPI 3+ model when they are first requested (using
+	// Paths.GetPathItem or Paths.GetPathItems), instead of building them all up front. This is disabled by default.
+	// Useful for huge documents where only a few paths are used.
+	BuildModelLazily bool
+
+	// InternStrings will make map keys (property names, media types, status codes) and references that repeat
+	// throughout the specification share a single copy in memory. This is disabled by default. Useful for huge
+	// documents, where the same schemas and responses are repeated across thousands of operations.
+	InternStrings bool
+
+	// BuildSections will only build the top level sections of the document that are listed (for example "paths" or
+	// "components"), the rest of the document is indexed, but not built. Everything is built when empty.
+	BuildSections []string
+
+	// BuildComponents will only build the components that are listed (for example "schemas" or "securitySchemes")
+	// when components are built. All components are built when empty. Only used by OpenAPI 3+ documents.
+	BuildComponents []string
+
+	// SkipExamples will avoid building any examples (of schemas, parameters, headers, media types and responses)
+	// when building the model. This is disabled by default. Useful when examples are not used, as they can be
+	// large and there can be a lot of them.
+	SkipExamples bool
+
+	// TolerateBuildErrors will carry on building the rest of the document when part of it fails to build (a bad
+	// schema or a reference that cannot be found, for example), instead of leaving out the object containing the
+	// failure. The failures are still returned as errors, and are recorded on the low-level reference to the value
+	// that failed (see low.ValueReference and low.NodeReference). Useful for editors, which need as much of the
+	// model as can be built. This is disabled by default.
+	TolerateBuildErrors bool
+
+	// ValidateSchema will check the specification against the official OpenAPI meta-schema for its version (2.0, 3.0
+	// or 3.1) before building a model. If the specification does not conform, no model is built, every violation is
+	// returned as an error instead (a *validation.Violation), located by line, column and JSON pointer. This is
+	// disabled by default.
+	ValidateSchema bool
+
+	// WarningHandler is called with every warning found while the document is indexed and built, as it is found.
+	// Warnings are things that are not right with the specification, but don't stop it from being built, like
+	// unknown or duplicate keys. Warnings are collected by the index either way (see DocumentModel.GetWarnings).
+	WarningHandler func(warning *index.Warning)
+
+	// Logger will receive debug events while the document is indexed, resolved and built, like files and remote
+	// documents being fetched, references being looked up, and how long each phase took. A *slog.Logger can be used.
+	// Nothing is logged by default.
+	Logger index.Logger
+
+	// ProgressHandler is called with progress while the document is indexed and built; documents fetched,
+	// references resolved and paths built. Useful for showing progress of huge documents that take a while to build.
+	ProgressHandler func(progress index.Progress)
+}
+
+// BuildSection returns true if the top level section with the label should be built.
+func (d *DocumentConfiguration) BuildSection(label string) bool {
+	return includesLabel(d.BuildSections, label)
+}
+
+// BuildComponent returns true if the components with the label should be built.
+func (d *DocumentConfiguration) BuildComponent(label string) bool {
+	return includesLabel(d.BuildComponents, label)
+}
+
+func includesLabel(labels []string, label string) bool {
+	if len(labels) == 0 {
+		return true
+	}
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
 }
 
 func NewOpenDocumentConfiguration() *DocumentConfiguration {
@@ -0,0 +1,73 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"strings"
+)
+
+// SplitYAMLDocuments splits a YAML stream that contains more than one document (separated by '---') into the
+// bytes of each document, in the order they appear in the stream. A stream with a single document (or JSON) is
+// returned as it is. Documents that are empty (or only contain comments) are left out.
+//
+// Each document keeps its '---' marker and any directives (like %YAML) before it, so it can be parsed on its own.
+// Line numbers of nodes parsed from a document are relative to the start of the document, not the stream.
+func SplitYAMLDocuments(spec []byte) [][]byte {
+	var documents [][]byte
+	var current []byte
+	directives := false // directives have started the next document, so its marker does not start another.
+	add := func() {
+		switch {
+		case hasYAMLContent(current):
+			documents = append(documents, current)
+		case bytes.Contains(current, []byte("---")) || bytes.Contains(current, []byte("...")):
+			// an empty document, anything in it would be read as a document of its own.
+		default:
+			return // comments before the first document are kept with it.
+		}
+		current = nil
+	}
+	for _, line := range bytes.SplitAfter(spec, []byte("\n")) {
+		switch {
+		case bytes.HasPrefix(line, []byte("%")):
+			if !directives {
+				add()
+				directives = true
+			}
+		case isDocumentMarker(line):
+			if !directives {
+				add()
+			}
+			directives = false
+		}
+		current = append(current, line...)
+	}
+	add()
+	if len(documents) == 0 {
+		return [][]byte{spec}
+	}
+	return documents
+}
+
+// isDocumentMarker returns true if a line starts a new YAML document.
+func isDocumentMarker(line []byte) bool {
+	if !bytes.HasPrefix(line, []byte("---")) {
+		return false
+	}
+	return len(line) == 3 || line[3] == ' ' || line[3] == '\t' || line[3] == '\n' || line[3] == '\r'
+}
+
+// hasYAMLContent returns true if a document has anything in it other than markers, directives and comments.
+func hasYAMLContent(document []byte) bool {
+	for _, line := range strings.Split(string(document), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" || trimmed == "..." || strings.HasPrefix(trimmed, "#") ||
+			strings.HasPrefix(trimmed, "%") {
+			continue
+		}
+		return true
+	}
+	return false
+}
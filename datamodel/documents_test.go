@@ -0,0 +1,36 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitYAMLDocuments(t *testing.T) {
+	stream := `# leading comment
+---
+openapi: 3.1.0
+description: |
+  --- not a marker
+---
+# empty document
+...
+%YAML 1.2
+---
+overlay: 1.0.0
+--- {kind: Bundle}
+`
+	documents := SplitYAMLDocuments([]byte(stream))
+	assert.Len(t, documents, 3)
+	assert.Equal(t, "# leading comment\n---\nopenapi: 3.1.0\ndescription: |\n  --- not a marker\n", string(documents[0]))
+	assert.Equal(t, "%YAML 1.2\n---\noverlay: 1.0.0\n", string(documents[1]))
+	assert.Equal(t, "--- {kind: Bundle}\n", string(documents[2]))
+
+	// single documents are returned as they are.
+	assert.Equal(t, [][]byte{[]byte("openapi: 3.1.0")}, SplitYAMLDocuments([]byte("openapi: 3.1.0")))
+	assert.Equal(t, [][]byte{[]byte(`{"openapi": "3.1.0"}`)}, SplitYAMLDocuments([]byte(`{"openapi": "3.1.0"}`)))
+	assert.Equal(t, [][]byte{[]byte("# nothing")}, SplitYAMLDocuments([]byte("# nothing")))
+}
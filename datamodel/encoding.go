@@ -0,0 +1,157 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is the text encoding a specification was written in. Specifications are always parsed as UTF-8, other
+// encodings are transcoded to UTF-8 first (see DecodeSpec), the encoding is kept so rendered specifications can be
+// encoded the same way (see Encoding.Encode).
+type Encoding int
+
+const (
+	// UTF8 is UTF-8 without a byte order mark, the encoding of almost every specification.
+	UTF8 Encoding = iota
+
+	// UTF8BOM is UTF-8 with a byte order mark.
+	UTF8BOM
+
+	// UTF16LE is little-endian UTF-16.
+	UTF16LE
+
+	// UTF16BE is big-endian UTF-16.
+	UTF16BE
+
+	// UTF32LE is little-endian UTF-32.
+	UTF32LE
+
+	// UTF32BE is big-endian UTF-32.
+	UTF32BE
+)
+
+var encodingNames = map[Encoding]string{
+	UTF8:    "UTF-8",
+	UTF8BOM: "UTF-8 (BOM)",
+	UTF16LE: "UTF-16LE",
+	UTF16BE: "UTF-16BE",
+	UTF32LE: "UTF-32LE",
+	UTF32BE: "UTF-32BE",
+}
+
+var byteOrderMarks = map[Encoding][]byte{
+	UTF8BOM: {0xEF, 0xBB, 0xBF},
+	UTF16LE: {0xFF, 0xFE},
+	UTF16BE: {0xFE, 0xFF},
+	UTF32LE: {0xFF, 0xFE, 0x00, 0x00},
+	UTF32BE: {0x00, 0x00, 0xFE, 0xFF},
+}
+
+// String returns the name of the encoding, like 'UTF-16LE'.
+func (e Encoding) String() string {
+	return encodingNames[e]
+}
+
+// DetectEncoding returns the encoding of a specification, and whether it starts with a byte order mark. Without a
+// byte order mark, the encoding is detected from the pattern of zero bytes in the first character (the same way
+// the YAML specification does), which must be ASCII.
+func DetectEncoding(spec []byte) (Encoding, bool) {
+	// UTF-32LE has to be checked before UTF-16LE, they start with the same bytes.
+	for _, e := range []Encoding{UTF32LE, UTF32BE, UTF8BOM, UTF16LE, UTF16BE} {
+		if bytes.HasPrefix(spec, byteOrderMarks[e]) {
+			return e, true
+		}
+	}
+	switch {
+	case len(spec) >= 4 && spec[0] == 0 && spec[1] == 0 && spec[2] == 0 && spec[3] != 0:
+		return UTF32BE, false
+	case len(spec) >= 4 && spec[0] != 0 && spec[1] == 0 && spec[2] == 0 && spec[3] == 0:
+		return UTF32LE, false
+	case len(spec) >= 2 && spec[0] == 0 && spec[1] != 0:
+		return UTF16BE, false
+	case len(spec) >= 2 && spec[0] != 0 && spec[1] == 0:
+		return UTF16LE, false
+	}
+	return UTF8, false
+}
+
+// DecodeSpec returns a specification transcoded into UTF-8 (without a byte order mark), along with the encoding
+// it was in. UTF-8 specifications without a byte order mark are returned as they are.
+func DecodeSpec(spec []byte) ([]byte, Encoding, error) {
+	encoding, bom := DetectEncoding(spec)
+	if encoding == UTF8 {
+		return spec, encoding, nil
+	}
+	if bom {
+		spec = spec[len(byteOrderMarks[encoding]):]
+	}
+	var decoded []byte
+	switch encoding {
+	case UTF8BOM:
+		return spec, encoding, nil
+	case UTF16LE, UTF16BE:
+		if len(spec)%2 != 0 {
+			return nil, encoding, errors.New("unable to decode specification, it is not valid " + encoding.String())
+		}
+		order := byteOrder(encoding)
+		units := make([]uint16, len(spec)/2)
+		for i := range units {
+			units[i] = order.Uint16(spec[i*2:])
+		}
+		decoded = make([]byte, 0, len(units))
+		for _, r := range utf16.Decode(units) {
+			decoded = utf8.AppendRune(decoded, r)
+		}
+	case UTF32LE, UTF32BE:
+		if len(spec)%4 != 0 {
+			return nil, encoding, errors.New("unable to decode specification, it is not valid " + encoding.String())
+		}
+		order := byteOrder(encoding)
+		decoded = make([]byte, 0, len(spec)/4)
+		for i := 0; i < len(spec); i += 4 {
+			decoded = utf8.AppendRune(decoded, rune(order.Uint32(spec[i:])))
+		}
+	}
+	return decoded, encoding, nil
+}
+
+// Encode returns UTF-8 text (like a rendered specification) encoded in this encoding. Everything other than UTF8 is
+// written with a byte order mark.
+func (e Encoding) Encode(text []byte) []byte {
+	if e == UTF8 {
+		return text
+	}
+	encoded := append([]byte(nil), byteOrderMarks[e]...)
+	switch e {
+	case UTF8BOM:
+		return append(encoded, text...)
+	case UTF16LE, UTF16BE:
+		order := byteOrder(e)
+		unit := make([]byte, 2)
+		for _, u := range utf16.Encode([]rune(string(text))) {
+			order.PutUint16(unit, u)
+			encoded = append(encoded, unit...)
+		}
+	case UTF32LE, UTF32BE:
+		order := byteOrder(e)
+		unit := make([]byte, 4)
+		for _, r := range string(text) {
+			order.PutUint32(unit, uint32(r))
+			encoded = append(encoded, unit...)
+		}
+	}
+	return encoded
+}
+
+func byteOrder(e Encoding) binary.ByteOrder {
+	if e == UTF16BE || e == UTF32BE {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
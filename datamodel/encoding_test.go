@@ -0,0 +1,64 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSpec(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: café 🍕\n")
+	for _, e := range []Encoding{UTF8, UTF8BOM, UTF16LE, UTF16BE, UTF32LE, UTF32BE} {
+		encoded := e.Encode(spec)
+		encoding, bom := DetectEncoding(encoded)
+		assert.Equal(t, e, encoding, e.String())
+		assert.Equal(t, e != UTF8, bom, e.String())
+
+		decoded, encoding, err := DecodeSpec(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, e, encoding)
+		assert.Equal(t, string(spec), string(decoded), e.String())
+
+		// without a byte order mark, the encoding is detected from the first character.
+		if e != UTF8 && e != UTF8BOM {
+			encoding, bom = DetectEncoding(bytes.TrimPrefix(encoded, byteOrderMarks[e]))
+			assert.Equal(t, e, encoding, e.String())
+			assert.False(t, bom)
+		}
+	}
+
+	_, _, err := DecodeSpec([]byte{0xFF, 0xFE, 'o'})
+	assert.EqualError(t, err, "unable to decode specification, it is not valid UTF-16LE")
+	_, _, err = DecodeSpec([]byte{0x00, 0x00, 0xFE, 0xFF, 'o'})
+	assert.EqualError(t, err, "unable to decode specification, it is not valid UTF-32BE")
+}
+
+func TestExtractSpecInfo_Encoding(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: pizza\n")
+	info, err := ExtractSpecInfo(UTF16LE.Encode(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, UTF16LE, info.Encoding)
+	assert.Equal(t, "3.1.0", info.Version)
+	assert.Equal(t, spec, *info.SpecBytes)
+
+	info, err = ExtractSpecInfo(UTF8BOM.Encode([]byte(`{"openapi": "3.1.0"}`)))
+	assert.NoError(t, err)
+	assert.Equal(t, UTF8BOM, info.Encoding)
+	assert.Equal(t, JSONFileType, info.SpecFileType)
+
+	for _, e := range []Encoding{UTF8, UTF8BOM, UTF32BE} {
+		info, err = ExtractSpecInfoFromReader(bytes.NewReader(e.Encode(spec)), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, e, info.Encoding)
+		assert.Equal(t, "3.1.0", info.Version)
+	}
+
+	_, err = ExtractSpecInfo([]byte{0xFE, 0xFF, 'o'})
+	assert.Error(t, err)
+	_, err = ExtractSpecInfoFromReader(bytes.NewReader([]byte{0xFE, 0xFF, 'o'}), nil)
+	assert.Error(t, err)
+}
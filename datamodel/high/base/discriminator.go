@@ -5,6 +5,7 @@ package base
 
 import (
 	low2 "github.com/pb33f/libopenapi/datamodel/high"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	low "github.com/pb33f/libopenapi/datamodel/low/base"
 	"gopkg.in/yaml.v3"
 )
@@ -37,6 +38,47 @@ func NewDiscriminator(disc *low.Discriminator) *Discriminator {
 	return d
 }
 
+// GetAllMappings returns the explicit mappings, combined with mappings inferred from oneOf and anyOf references.
+func (d *Discriminator) GetAllMappings() map[string]string {
+	if d.low == nil {
+		return d.Mapping
+	}
+	return d.low.GetAllMappings()
+}
+
+// FindSchema returns the schema that a value of the discriminator property maps to, explicit mappings are checked
+// before implicit ones. Returns nil if nothing matches or the schema cannot be located.
+func (d *Discriminator) FindSchema(propertyValue string) *SchemaProxy {
+	if d.low == nil {
+		return nil
+	}
+	return newMappedSchemaProxy(d.low.FindSchema(propertyValue))
+}
+
+// ResolveMappingValue resolves a mapping value (a schema name or a reference) to a schema using the index.
+func (d *Discriminator) ResolveMappingValue(value string) *SchemaProxy {
+	if d.low == nil {
+		return nil
+	}
+	return newMappedSchemaProxy(d.low.ResolveMappingValue(value))
+}
+
+// ValidateMappings reports mappings (explicit or implicit) that point to schemas that don't exist, or to
+// schemas that do not define the discriminator property.
+func (d *Discriminator) ValidateMappings() []*low.DiscriminatorMappingError {
+	if d.low == nil {
+		return nil
+	}
+	return d.low.ValidateMappings()
+}
+
+func newMappedSchemaProxy(sp *low.SchemaProxy) *SchemaProxy {
+	if sp == nil {
+		return nil
+	}
+	return NewSchemaProxy(&lowmodel.NodeReference[*low.SchemaProxy]{Value: sp, ValueNode: sp.GetValueNode()})
+}
+
 // GoLow returns the low-level Discriminator used to build the high-level one.
 func (d *Discriminator) GoLow() *low.Discriminator {
 	return d.low
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"strings"
@@ -62,3 +64,48 @@ mapping:
 	fmt.Print(highDiscriminator.Mapping["coffee"])
 	// Output: in the morning
 }
+
+func TestDiscriminator_FindSchema(t *testing.T) {
+	yml := `components:
+  schemas:
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+        meow:
+          type: boolean
+    Dog:
+      type: object
+      properties:
+        bark:
+          type: boolean
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+        mapping:
+          kitty: Cat`
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+
+	_, petNode := utils.FindKeyNodeTop("Pet", idx.GetSchemasNode().Content)
+	var lowSchema lowbase.Schema
+	_ = lowmodel.BuildModel(petNode, &lowSchema)
+	_ = lowSchema.Build(petNode, idx)
+
+	disc := NewSchema(&lowSchema).Discriminator
+	assert.True(t, disc.FindSchema("kitty").Schema().Properties["meow"].Schema().Type[0] == "boolean")
+	assert.NotNil(t, disc.FindSchema("Dog").Schema().Properties["bark"])
+	assert.NotNil(t, disc.ResolveMappingValue("#/components/schemas/Dog"))
+	assert.Nil(t, disc.FindSchema("Fish"))
+	assert.Len(t, disc.GetAllMappings(), 3)
+
+	errs := disc.ValidateMappings()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Dog", errs[0].Key)
+}
@@ -16,12 +16,14 @@ import (
 // OpenAPI 3.1 treats a Schema as a real JSON schema, which means some properties become incompatible, or others
 // now support more than one primitive type or structure.
 // The N value is a bit to make it each to know which value (A or B) is used, this prevents having to
-// if/else on the value to determine which one is set.
+// if/else on the value to determine which one is set. When N is 2, neither is set, the value read isn't valid
+// and is rendered as it was read.
 type DynamicValue[A any, B any] struct {
-	N      int // 0 == A, 1 == B
+	N      int // 0 == A, 1 == B, 2 == neither
 	A      A
 	B      B
 	inline bool
+	raw    *yaml.Node
 }
 
 // IsA will return true if the 'A' or left value is set. (OpenAPI 3)
@@ -51,6 +53,12 @@ func (d *DynamicValue[A, B]) MarshalYAML() (interface{}, error) {
 	var err error
 	var value any
 
+	if d.N == 2 {
+		if d.raw == nil {
+			return nil, nil
+		}
+		return d.raw, nil
+	}
 	if d.IsA() {
 		value = d.A
 	}
@@ -26,8 +26,8 @@ type Example struct {
 func NewExample(example *low.Example) *Example {
 	e := new(Example)
 	e.low = example
-	e.Summary = example.Summary.Value
-	e.Description = example.Description.Value
+	e.Summary = example.OverrideSummary(example.Summary.Value)
+	e.Description = example.OverrideDescription(example.Description.Value)
 	e.Value = example.Value.Value
 	e.ExternalValue = example.ExternalValue.Value
 	e.Extensions = high.ExtractExtensions(example.Extensions)
@@ -5,6 +5,7 @@ package base
 
 import (
 	"fmt"
+	"github.com/pb33f/libopenapi/datamodel/high"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +39,14 @@ x-hack: code`
 	assert.Equal(t, 2, wentLow.URL.ValueNode.Line)
 	assert.Len(t, highExt.GetExtensions(), 1)
 
+	nodes := high.GetNodeMap(highExt)
+	assert.Len(t, nodes, 3)
+	assert.Equal(t, 1, nodes.KeyNode("description").Line)
+	assert.Equal(t, "https://pb33f.io", nodes.ValueNode("url").Value)
+	assert.Equal(t, "URL", nodes["url"].Field)
+	assert.Equal(t, 3, nodes.KeyNode("x-hack").Line)
+	assert.Equal(t, "Extensions", nodes["x-hack"].Field)
+
 	// render the high-level object as YAML
 	rendered, _ := highExt.Render()
 	assert.Equal(t, strings.TrimSpace(string(rendered)), yml)
@@ -0,0 +1,205 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// The identifier of a license (OpenAPI 3.1+) is an SPDX license expression, like 'MIT', 'Apache-2.0 OR MIT' or
+// 'GPL-2.0-or-later WITH Classpath-exception-2.0'. This package does not ship the SPDX license list (it changes too
+// often), callers supply the identifiers they accept instead.
+//   - https://spdx.github.io/spdx-spec/v2.3/SPDX-license-expressions/
+
+// Validate checks the license has a name, and doesn't have both a url and an identifier (they are mutually
+// exclusive). If there is an identifier, it's checked to be a valid SPDX license expression.
+func (l *License) Validate() []error {
+	var errs []error
+	if l.Name == "" {
+		errs = append(errs, errors.New("license has no name, a name is required"))
+	}
+	if l.URL != "" && l.Identifier != "" {
+		errs = append(errs, errors.New("license has both a url and an identifier, only one is allowed"))
+	}
+	if l.Identifier != "" {
+		if _, _, err := SPDXLicenses(l.Identifier); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ValidateIdentifier checks every license used by the identifier of the license is one of the supplied SPDX
+// license identifiers (compared ignoring case). Custom licenses (LicenseRef-) are always accepted. If exceptions
+// is not nil, every exception (following WITH) must be one of the supplied SPDX exception identifiers. A license
+// without an identifier is valid.
+func (l *License) ValidateIdentifier(licenses, exceptions []string) error {
+	if l.Identifier == "" {
+		return nil
+	}
+	used, usedExceptions, err := SPDXLicenses(l.Identifier)
+	if err != nil {
+		return err
+	}
+	known := func(list []string, id string) bool {
+		for _, k := range list {
+			if strings.EqualFold(k, id) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, id := range used {
+		if !isCustomLicense(id) && !known(licenses, id) {
+			return fmt.Errorf("license identifier '%s' uses '%s', which is not a known SPDX license", l.Identifier, id)
+		}
+	}
+	if exceptions != nil {
+		for _, id := range usedExceptions {
+			if !known(exceptions, id) {
+				return fmt.Errorf("license identifier '%s' uses '%s', which is not a known SPDX license exception",
+					l.Identifier, id)
+			}
+		}
+	}
+	return nil
+}
+
+// SPDXLicenses parses an SPDX license expression, and returns every license (without any '+' suffix) and every
+// exception it uses, in the order they are found. An error is returned if the expression is not valid.
+func SPDXLicenses(expression string) (licenses, exceptions []string, err error) {
+	p := &spdxParser{expression: expression, tokens: tokenizeSPDX(expression)}
+	if len(p.tokens) == 0 {
+		return nil, nil, fmt.Errorf("license identifier '%s' is empty", expression)
+	}
+	if err = p.parseOr(); err != nil {
+		return nil, nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, nil, p.errorf("unexpected '%s'", p.tokens[p.pos])
+	}
+	return p.licenses, p.exceptions, nil
+}
+
+// isCustomLicense returns true for licenses that are not on the SPDX list, like 'LicenseRef-Pizza'.
+func isCustomLicense(id string) bool {
+	if _, ref, ok := strings.Cut(id, ":"); ok && strings.HasPrefix(id, "DocumentRef-") {
+		id = ref
+	}
+	return strings.HasPrefix(id, "LicenseRef-")
+}
+
+func tokenizeSPDX(expression string) []string {
+	expression = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression)
+	return strings.Fields(expression)
+}
+
+// spdxParser parses an SPDX expression, where WITH binds tighter than AND, which binds tighter than OR.
+type spdxParser struct {
+	expression string
+	tokens     []string
+	pos        int
+	licenses   []string
+	exceptions []string
+}
+
+func (p *spdxParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("license identifier '%s' is not a valid SPDX expression: %s", p.expression,
+		fmt.Sprintf(format, args...))
+}
+
+// operator returns true (and moves past it) if the next token is the operator, in upper or lower case.
+func (p *spdxParser) operator(op string) bool {
+	if p.pos < len(p.tokens) && (p.tokens[p.pos] == op || p.tokens[p.pos] == strings.ToLower(op)) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *spdxParser) parseOr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+	for p.operator("OR") {
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *spdxParser) parseAnd() error {
+	if err := p.parseWith(); err != nil {
+		return err
+	}
+	for p.operator("AND") {
+		if err := p.parseWith(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *spdxParser) parseWith() error {
+	if p.pos < len(p.tokens) && p.tokens[p.pos] == "(" {
+		p.pos++
+		if err := p.parseOr(); err != nil {
+			return err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return p.errorf("missing ')'")
+		}
+		p.pos++
+		return nil
+	}
+	id, err := p.identifier("license")
+	if err != nil {
+		return err
+	}
+	p.licenses = append(p.licenses, strings.TrimSuffix(id, "+"))
+	if p.operator("WITH") {
+		exception, err := p.identifier("exception")
+		if err != nil {
+			return err
+		}
+		p.exceptions = append(p.exceptions, exception)
+	}
+	return nil
+}
+
+// identifier returns the next token, if it's a valid license (or exception) identifier.
+func (p *spdxParser) identifier(kind string) (string, error) {
+	article := "a"
+	if kind == "exception" {
+		article = "an"
+	}
+	if p.pos >= len(p.tokens) {
+		return "", p.errorf("expected %s %s, but the expression ended", article, kind)
+	}
+	id := p.tokens[p.pos]
+	switch strings.ToUpper(id) {
+	case "AND", "OR", "WITH", "(", ")":
+		return "", p.errorf("expected %s %s, found '%s'", article, kind, id)
+	}
+	check := id
+	if kind == "license" {
+		check = strings.TrimSuffix(check, "+")
+		if strings.HasPrefix(check, "DocumentRef-") {
+			_, check, _ = strings.Cut(check, ":")
+		}
+	}
+	if check == "" {
+		return "", p.errorf("'%s' is not a valid %s identifier", id, kind)
+	}
+	for _, c := range check {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '.') {
+			return "", p.errorf("'%s' is not a valid %s identifier", id, kind)
+		}
+	}
+	p.pos++
+	return id, nil
+}
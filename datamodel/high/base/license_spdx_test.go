@@ -0,0 +1,74 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSPDXLicenses(t *testing.T) {
+	licenses, exceptions, err := SPDXLicenses("MIT")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"MIT"}, licenses)
+	assert.Empty(t, exceptions)
+
+	licenses, exceptions, err = SPDXLicenses("(Apache-2.0 OR MIT) AND GPL-2.0+ WITH Classpath-exception-2.0")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Apache-2.0", "MIT", "GPL-2.0"}, licenses)
+	assert.Equal(t, []string{"Classpath-exception-2.0"}, exceptions)
+
+	licenses, _, err = SPDXLicenses("mit or DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mit", "DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2"}, licenses)
+
+	for expression, message := range map[string]string{
+		"":             "license identifier '' is empty",
+		"MIT OR":       "license identifier 'MIT OR' is not a valid SPDX expression: expected a license, but the expression ended",
+		"(MIT":         "license identifier '(MIT' is not a valid SPDX expression: missing ')'",
+		"MIT)":         "license identifier 'MIT)' is not a valid SPDX expression: unexpected ')'",
+		"MIT Apache":   "license identifier 'MIT Apache' is not a valid SPDX expression: unexpected 'Apache'",
+		"AND MIT":      "license identifier 'AND MIT' is not a valid SPDX expression: expected a license, found 'AND'",
+		"MIT WITH":     "license identifier 'MIT WITH' is not a valid SPDX expression: expected an exception, but the expression ended",
+		"MIT/2":        "license identifier 'MIT/2' is not a valid SPDX expression: 'MIT/2' is not a valid license identifier",
+		"MIT WITH ex+": "license identifier 'MIT WITH ex+' is not a valid SPDX expression: 'ex+' is not a valid exception identifier",
+		"+":            "license identifier '+' is not a valid SPDX expression: '+' is not a valid license identifier",
+	} {
+		_, _, err = SPDXLicenses(expression)
+		if assert.Error(t, err, expression) {
+			assert.Equal(t, message, err.Error())
+		}
+	}
+}
+
+func TestLicense_Validate(t *testing.T) {
+	assert.Empty(t, (&License{Name: "MIT", Identifier: "MIT"}).Validate())
+	assert.Empty(t, (&License{Name: "MIT", URL: "https://opensource.org/licenses/MIT"}).Validate())
+
+	errs := (&License{URL: "https://pb33f.io", Identifier: "MIT OR"}).Validate()
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "license has no name, a name is required", errs[0].Error())
+	assert.Equal(t, "license has both a url and an identifier, only one is allowed", errs[1].Error())
+}
+
+func TestLicense_ValidateIdentifier(t *testing.T) {
+	known := []string{"MIT", "Apache-2.0", "GPL-2.0-or-later"}
+
+	assert.NoError(t, (&License{}).ValidateIdentifier(known, nil))
+	assert.NoError(t, (&License{Identifier: "mit OR Apache-2.0"}).ValidateIdentifier(known, nil))
+	assert.NoError(t, (&License{Identifier: "LicenseRef-Pizza AND MIT"}).ValidateIdentifier(known, nil))
+	assert.NoError(t, (&License{Identifier: "GPL-2.0-or-later WITH Pizza-exception"}).ValidateIdentifier(known, nil))
+
+	err := (&License{Identifier: "MIT OR Pizza-1.0"}).ValidateIdentifier(known, nil)
+	assert.Equal(t, "license identifier 'MIT OR Pizza-1.0' uses 'Pizza-1.0', which is not a known SPDX license",
+		err.Error())
+
+	err = (&License{Identifier: "GPL-2.0-or-later WITH Pizza-exception"}).ValidateIdentifier(known,
+		[]string{"Classpath-exception-2.0"})
+	assert.Equal(t, "license identifier 'GPL-2.0-or-later WITH Pizza-exception' uses 'Pizza-exception', which is "+
+		"not a known SPDX license exception", err.Error())
+
+	assert.Error(t, (&License{Identifier: "(MIT"}).ValidateIdentifier(known, nil))
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// ExampleKind is the field a NamedExample was found in.
+type ExampleKind int
+
+const (
+	// ExampleField is the singular 'example' field, of a media type, parameter, header or schema.
+	ExampleField ExampleKind = iota
+
+	// ExamplesMap is an entry of the 'examples' map of Example objects, of a media type, parameter or header.
+	ExamplesMap
+
+	// ExamplesArray is an item of the 'examples' array of a schema (JSON Schema).
+	ExamplesArray
+)
+
+var exampleKindNames = []string{"example", "examples-map", "examples-array"}
+
+// String returns the name of the kind, like 'examples-map'.
+func (k ExampleKind) String() string {
+	if k < 0 || int(k) >= len(exampleKindNames) {
+		return "ExampleKind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return exampleKindNames[k]
+}
+
+// NamedExample is an example, found in any of the ways an example can be defined. Objects define examples in
+// different ways (a single value, a map of Example objects, or an array of values), NamedExamples puts them all in
+// a single list.
+type NamedExample struct {
+	// Name is 'example' for the singular example field, the key of the Example in an examples map, or the index
+	// of the example in an examples array.
+	Name string
+
+	// Kind is the field the example was found in.
+	Kind ExampleKind
+
+	// Value is the example value. It's nil for an Example object that only has an externalValue.
+	Value any
+
+	// Example is the Example object of an entry of an examples map, nil for other kinds.
+	Example *Example
+}
+
+// NamedExamples returns every example as a single list: the singular example first (if there is one), then the
+// entries of the examples map in the order they are found in the document (entries added after building go last,
+// sorted by name), then the items of the examples array.
+func NamedExamples(example any, examples map[string]*Example, values []any) []*NamedExample {
+	var named []*NamedExample
+	if example != nil {
+		named = append(named, &NamedExample{Name: "example", Kind: ExampleField, Value: example})
+	}
+
+	names := make([]string, 0, len(examples))
+	for name, ex := range examples {
+		if ex != nil {
+			names = append(names, name)
+		}
+	}
+	line := func(name string) int {
+		if l := examples[name].GoLow(); l != nil && l.GetKeyNode() != nil {
+			return l.GetKeyNode().Line
+		}
+		return math.MaxInt
+	}
+	sort.Slice(names, func(i, j int) bool {
+		li, lj := line(names[i]), line(names[j])
+		if li != lj {
+			return li < lj
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		ex := examples[name]
+		named = append(named, &NamedExample{Name: name, Kind: ExamplesMap, Value: ex.Value, Example: ex})
+	}
+
+	for i, v := range values {
+		named = append(named, &NamedExample{Name: strconv.Itoa(i), Kind: ExamplesArray, Value: v})
+	}
+	return named
+}
+
+// NamedExamples returns the example and every item of the examples array of the schema, as a single list (see
+// NamedExamples).
+func (s *Schema) NamedExamples() []*NamedExample {
+	return NamedExamples(s.Example, nil, s.Examples)
+}
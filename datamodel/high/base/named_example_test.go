@@ -0,0 +1,47 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedExamples(t *testing.T) {
+	assert.Empty(t, NamedExamples(nil, nil, nil))
+
+	pepperoni := &Example{Value: "pepperoni"}
+	external := &Example{ExternalValue: "https://pb33f.io/pizza.json"}
+	named := NamedExamples("cheese", map[string]*Example{
+		"pepperoni": pepperoni,
+		"external":  external,
+		"missing":   nil,
+	}, []any{"margherita", 3})
+
+	assert.Len(t, named, 5)
+	assert.Equal(t, &NamedExample{Name: "example", Kind: ExampleField, Value: "cheese"}, named[0])
+	// examples without a position are sorted by name.
+	assert.Equal(t, &NamedExample{Name: "external", Kind: ExamplesMap, Example: external}, named[1])
+	assert.Equal(t, &NamedExample{Name: "pepperoni", Kind: ExamplesMap, Value: "pepperoni", Example: pepperoni},
+		named[2])
+	assert.Equal(t, &NamedExample{Name: "0", Kind: ExamplesArray, Value: "margherita"}, named[3])
+	assert.Equal(t, &NamedExample{Name: "1", Kind: ExamplesArray, Value: 3}, named[4])
+}
+
+func TestSchema_NamedExamples(t *testing.T) {
+	s := &Schema{Example: "cheese", Examples: []any{"margherita"}}
+	named := s.NamedExamples()
+	assert.Len(t, named, 2)
+	assert.Equal(t, ExampleField, named[0].Kind)
+	assert.Equal(t, "margherita", named[1].Value)
+	assert.Equal(t, ExamplesArray, named[1].Kind)
+}
+
+func TestExampleKind_String(t *testing.T) {
+	assert.Equal(t, "example", ExampleField.String())
+	assert.Equal(t, "examples-map", ExamplesMap.String())
+	assert.Equal(t, "examples-array", ExamplesArray.String())
+	assert.Equal(t, "ExampleKind(7)", ExampleKind(7).String())
+}
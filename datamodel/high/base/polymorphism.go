@@ -0,0 +1,145 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+)
+
+// Composition keywords followed when walking polymorphic schemas.
+const (
+	AllOfKeyword = "allOf"
+	OneOfKeyword = "oneOf"
+	AnyOfKeyword = "anyOf"
+)
+
+// PolymorphicBranch represents a single schema found when walking a polymorphic (composed) schema hierarchy,
+// the root schema is also a branch, it's the only one without a Parent or a Keyword.
+type PolymorphicBranch struct {
+	Schema     *SchemaProxy       // the schema for this branch.
+	Parent     *PolymorphicBranch // the branch this branch was found in, nil for the root.
+	Keyword    string             // the composition keyword this branch was found under (allOf, oneOf or anyOf).
+	Index      int                // the position of this branch in the composition.
+	Path       []string           // resolution path from the root, for example [oneOf[1], allOf[0]].
+	References []string           // every reference followed from the root to reach this branch, in order.
+	Depth      int                // the root is at depth 0.
+	Circular   bool               // the branch points back at a schema that is already on the path.
+	Leaf       bool               // the branch is not composed of any other schemas.
+}
+
+// PolymorphicVisitor is called for every branch found when walking. Returning false will stop the walker from
+// descending into the branch, the rest of the tree continues to be walked.
+type PolymorphicVisitor func(branch *PolymorphicBranch) bool
+
+// WalkPolymorphism walks the allOf, oneOf and anyOf branches of this schema (in that order), depth first, calling
+// the visitor for every branch found. Cycles are detected, a circular branch is visited (with Circular set),
+// but not descended into.
+//
+// Schemas are built as they are walked, if a schema fails to build, walking stops and the error is returned.
+func (sp *SchemaProxy) WalkPolymorphism(visitor PolymorphicVisitor) error {
+	return walkPolymorphicBranch(&PolymorphicBranch{Schema: sp}, visitor, make(map[any]bool))
+}
+
+// ConcreteSchemas returns every concrete schema reachable through oneOf and anyOf from this schema, following
+// nested compositions. A concrete schema is one that does not offer any further alternatives (no oneOf or anyOf).
+// allOf branches are considered part of the schema that composes them, so they are not followed.
+//
+// If this schema has no alternatives, it is returned as the only concrete schema. Schemas reachable more than
+// once are only returned once (the first path found wins).
+func (sp *SchemaProxy) ConcreteSchemas() ([]*PolymorphicBranch, error) {
+	var leaves []*PolymorphicBranch
+	seen := make(map[any]bool)
+	err := sp.WalkPolymorphism(func(branch *PolymorphicBranch) bool {
+		if branch.Keyword == AllOfKeyword || branch.Circular {
+			return false
+		}
+		if s := branch.Schema.Schema(); s != nil && (len(s.OneOf) > 0 || len(s.AnyOf) > 0) {
+			return true
+		}
+		if id := branch.Schema.identity(); !seen[id] {
+			seen[id] = true
+			leaves = append(leaves, branch)
+		}
+		return false
+	})
+	return leaves, err
+}
+
+func walkPolymorphicBranch(branch *PolymorphicBranch, visitor PolymorphicVisitor, onPath map[any]bool) error {
+	id := branch.Schema.identity()
+	if onPath[id] {
+		branch.Circular = true
+		visitor(branch)
+		return nil
+	}
+
+	// a proxy created from a reference string only, has nothing to build.
+	if branch.Schema.schema == nil && branch.Schema.rendered == nil {
+		branch.Leaf = true
+		visitor(branch)
+		return nil
+	}
+	sch, err := branch.Schema.BuildSchema()
+	if err != nil {
+		return err
+	}
+	if sch == nil {
+		return fmt.Errorf("schema at '%s' could not be built", branch.Schema.GetReference())
+	}
+	branch.Leaf = len(sch.AllOf) == 0 && len(sch.OneOf) == 0 && len(sch.AnyOf) == 0
+	if !visitor(branch) || branch.Leaf {
+		return nil
+	}
+
+	onPath[id] = true
+	defer delete(onPath, id)
+
+	compositions := []struct {
+		keyword string
+		schemas []*SchemaProxy
+	}{
+		{AllOfKeyword, sch.AllOf},
+		{OneOfKeyword, sch.OneOf},
+		{AnyOfKeyword, sch.AnyOf},
+	}
+	for _, c := range compositions {
+		for i, child := range c.schemas {
+			if child == nil {
+				continue
+			}
+			path := make([]string, len(branch.Path), len(branch.Path)+1)
+			copy(path, branch.Path)
+			refs := make([]string, len(branch.References), len(branch.References)+1)
+			copy(refs, branch.References)
+			if child.IsReference() {
+				refs = append(refs, child.GetReference())
+			}
+			next := &PolymorphicBranch{
+				Schema:     child,
+				Parent:     branch,
+				Keyword:    c.keyword,
+				Index:      i,
+				Path:       append(path, fmt.Sprintf("%s[%d]", c.keyword, i)),
+				References: refs,
+				Depth:      branch.Depth + 1,
+			}
+			if err = walkPolymorphicBranch(next, visitor, onPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// identity returns a value that uniquely identifies the schema behind the proxy, references to the same schema
+// share the same identity.
+func (sp *SchemaProxy) identity() any {
+	if sp.schema != nil && sp.schema.Value != nil && sp.schema.Value.GetValueNode() != nil {
+		return sp.schema.Value.GetValueNode()
+	}
+	if sp.rendered != nil {
+		return sp.rendered
+	}
+	return sp
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"testing"
+
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var polymorphicSpec = `components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Bird'
+    Base:
+      type: object
+      properties:
+        name:
+          type: string
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            meow:
+              type: boolean
+    Dog:
+      type: object
+      properties:
+        bark:
+          type: boolean
+    Bird:
+      anyOf:
+        - $ref: '#/components/schemas/Parrot'
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Pet'
+    Parrot:
+      type: object
+      properties:
+        talk:
+          type: boolean`
+
+func getPolymorphicSchema(t *testing.T, name string) *SchemaProxy {
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(polymorphicSpec), &node))
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+	_, schNode := utils.FindKeyNodeTop(name, idx.GetSchemasNode().Content)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(schNode, idx))
+	return NewSchemaProxy(&lowmodel.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: schNode})
+}
+
+func TestSchemaProxy_WalkPolymorphism(t *testing.T) {
+	pet := getPolymorphicSchema(t, "Pet")
+
+	var branches []*PolymorphicBranch
+	err := pet.WalkPolymorphism(func(branch *PolymorphicBranch) bool {
+		branches = append(branches, branch)
+		return true
+	})
+	assert.NoError(t, err)
+
+	// Pet, Cat, Base, inline, Dog, Bird, Parrot, Dog, Pet (circular)
+	assert.Len(t, branches, 9)
+	assert.Nil(t, branches[0].Parent)
+	assert.Equal(t, 0, branches[0].Depth)
+	assert.False(t, branches[0].Leaf)
+
+	base := branches[2]
+	assert.Equal(t, AllOfKeyword, base.Keyword)
+	assert.Equal(t, []string{"oneOf[0]", "allOf[0]"}, base.Path)
+	assert.Equal(t, []string{"#/components/schemas/Cat", "#/components/schemas/Base"}, base.References)
+	assert.Equal(t, 2, base.Depth)
+	assert.True(t, base.Leaf)
+	assert.Equal(t, branches[1], base.Parent)
+
+	inline := branches[3]
+	assert.Equal(t, 1, inline.Index)
+	assert.Equal(t, []string{"#/components/schemas/Cat"}, inline.References)
+
+	circular := branches[8]
+	assert.True(t, circular.Circular)
+	assert.Equal(t, AnyOfKeyword, circular.Keyword)
+	assert.Equal(t, []string{"oneOf[2]", "anyOf[2]"}, circular.Path)
+}
+
+func TestSchemaProxy_WalkPolymorphism_Stop(t *testing.T) {
+	pet := getPolymorphicSchema(t, "Pet")
+
+	count := 0
+	err := pet.WalkPolymorphism(func(branch *PolymorphicBranch) bool {
+		count++
+		return branch.Depth == 0
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
+
+func TestSchemaProxy_ConcreteSchemas(t *testing.T) {
+	pet := getPolymorphicSchema(t, "Pet")
+
+	leaves, err := pet.ConcreteSchemas()
+	assert.NoError(t, err)
+	assert.Len(t, leaves, 3)
+	assert.Equal(t, "#/components/schemas/Cat", leaves[0].Schema.GetReference())
+	assert.Equal(t, "#/components/schemas/Dog", leaves[1].Schema.GetReference())
+	assert.Equal(t, "#/components/schemas/Parrot", leaves[2].Schema.GetReference())
+	assert.Equal(t, []string{"oneOf[2]", "anyOf[0]"}, leaves[2].Path)
+
+	// a schema without any alternatives is concrete.
+	dog := getPolymorphicSchema(t, "Dog")
+	leaves, err = dog.ConcreteSchemas()
+	assert.NoError(t, err)
+	assert.Len(t, leaves, 1)
+	assert.Equal(t, dog, leaves[0].Schema)
+}
+
+func TestSchemaProxy_WalkPolymorphism_BuildError(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(polymorphicSpec), &node)
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+
+	var refNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`$ref: '#/components/schemas/Missing'`), &refNode)
+	lowProxy := new(lowbase.SchemaProxy)
+	_ = lowProxy.Build(refNode.Content[0], idx)
+
+	sp := CreateSchemaProxy(&Schema{OneOf: []*SchemaProxy{
+		CreateSchemaProxyRef("#/components/schemas/Nowhere"),
+		NewSchemaProxy(&lowmodel.NodeReference[*lowbase.SchemaProxy]{Value: lowProxy}),
+	}})
+
+	var refs []string
+	err := sp.WalkPolymorphism(func(branch *PolymorphicBranch) bool {
+		refs = append(refs, branch.References...)
+		return true
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"#/components/schemas/Nowhere"}, refs)
+}
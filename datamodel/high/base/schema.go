@@ -23,6 +23,10 @@ type Schema struct {
 	// 3.1 only, used to define a dialect for this schema, label is '$schema'.
 	SchemaTypeRef string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
 
+	// Dialect is the effective dialect of this schema, either set by '$schema', inherited from a parent schema
+	// or defined by the document. It is not rendered.
+	Dialect string `json:"-" yaml:"-"`
+
 	// In versions 2 and 3.0, this ExclusiveMaximum can only be a boolean.
 	// In version 3.1, ExclusiveMaximum is a number.
 	ExclusiveMaximum *DynamicValue[bool, float64] `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
@@ -71,35 +75,50 @@ type Schema struct {
 	// 3.1 only, part of the JSON Schema spec provides a way to identify a subschema
 	Anchor string `json:"$anchor,omitempty" yaml:"$anchor,omitempty"`
 
+	// 3.1 only, dynamic anchors and references allow recursive schemas to be extended (generics).
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty" yaml:"$dynamicAnchor,omitempty"`
+	DynamicRef    string `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
+
+	// 3.1 only, restricts a value to a single constant value, which may be of any type (including null).
+	Const any `json:"const,omitempty" yaml:"const,renderZero,omitempty"`
+
+	// 3.1 only, a map of property names to the properties that become required when the named property is present.
+	DependentRequired map[string][]string `json:"dependentRequired,omitempty" yaml:"dependentRequired,omitempty"`
+
+	// 3.1 only, describes the encoding, media type and structure of string encoded content.
+	ContentEncoding  string       `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+	ContentMediaType string       `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+	ContentSchema    *SchemaProxy `json:"contentSchema,omitempty" yaml:"contentSchema,omitempty"`
+
 	// Compatible with all versions
-	Not                  *SchemaProxy            `json:"not,omitempty" yaml:"not,omitempty"`
-	Properties           map[string]*SchemaProxy `json:"properties,omitempty" yaml:"properties,omitempty"`
-	Title                string                  `json:"title,omitempty" yaml:"title,omitempty"`
-	MultipleOf           *float64                `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
-	Maximum              *float64                `json:"maximum,omitempty" yaml:"maximum,omitempty"`
-	Minimum              *float64                `json:"minimum,omitempty" yaml:"minimum,omitempty"`
-	MaxLength            *int64                  `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
-	MinLength            *int64                  `json:"minLength,omitempty" yaml:"minLength,omitempty"`
-	Pattern              string                  `json:"pattern,omitempty" yaml:"pattern,omitempty"`
-	Format               string                  `json:"format,omitempty" yaml:"format,omitempty"`
-	MaxItems             *int64                  `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
-	MinItems             *int64                  `json:"minItems,omitempty" yaml:"minItems,omitempty"`
-	UniqueItems          *bool                   `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
-	MaxProperties        *int64                  `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
-	MinProperties        *int64                  `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
-	Required             []string                `json:"required,omitempty" yaml:"required,omitempty"`
-	Enum                 []any                   `json:"enum,omitempty" yaml:"enum,omitempty"`
-	AdditionalProperties any                     `json:"additionalProperties,omitempty" yaml:"additionalProperties,renderZero,omitempty"`
-	Description          string                  `json:"description,omitempty" yaml:"description,omitempty"`
-	Default              any                     `json:"default,omitempty" yaml:"default,renderZero,omitempty"`
-	Nullable             *bool                   `json:"nullable,omitempty" yaml:"nullable,omitempty"`
-	ReadOnly             bool                    `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`   // https://github.com/pb33f/libopenapi/issues/30
-	WriteOnly            bool                    `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"` // https://github.com/pb33f/libopenapi/issues/30
-	XML                  *XML                    `json:"xml,omitempty" yaml:"xml,omitempty"`
-	ExternalDocs         *ExternalDoc            `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
-	Example              any                     `json:"example,omitempty" yaml:"example,omitempty"`
-	Deprecated           *bool                   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-	Extensions           map[string]any          `json:"-" yaml:"-"`
+	Not                  *SchemaProxy                      `json:"not,omitempty" yaml:"not,omitempty"`
+	Properties           map[string]*SchemaProxy           `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Title                string                            `json:"title,omitempty" yaml:"title,omitempty"`
+	MultipleOf           *float64                          `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+	Maximum              *float64                          `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	Minimum              *float64                          `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	MaxLength            *int64                            `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinLength            *int64                            `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	Pattern              string                            `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Format               string                            `json:"format,omitempty" yaml:"format,omitempty"`
+	MaxItems             *int64                            `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	MinItems             *int64                            `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	UniqueItems          *bool                             `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	MaxProperties        *int64                            `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
+	MinProperties        *int64                            `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
+	Required             []string                          `json:"required,omitempty" yaml:"required,omitempty"`
+	Enum                 []any                             `json:"enum,omitempty" yaml:"enum,omitempty"`
+	AdditionalProperties *DynamicValue[*SchemaProxy, bool] `json:"additionalProperties,omitempty" yaml:"additionalProperties,renderZero,omitempty"`
+	Description          string                            `json:"description,omitempty" yaml:"description,omitempty"`
+	Default              any                               `json:"default,omitempty" yaml:"default,renderZero,omitempty"`
+	Nullable             *bool                             `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	ReadOnly             bool                              `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`   // https://github.com/pb33f/libopenapi/issues/30
+	WriteOnly            bool                              `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"` // https://github.com/pb33f/libopenapi/issues/30
+	XML                  *XML                              `json:"xml,omitempty" yaml:"xml,omitempty"`
+	ExternalDocs         *ExternalDoc                      `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	Example              any                               `json:"example,omitempty" yaml:"example,omitempty"`
+	Deprecated           *bool                             `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Extensions           map[string]any                    `json:"-" yaml:"-"`
 	low                  *base.Schema
 
 	// Parent Proxy refers back to the low level SchemaProxy that is proxying this schema.
@@ -111,6 +130,8 @@ func NewSchema(schema *base.Schema) *Schema {
 	s := new(Schema)
 	s.low = schema
 	s.Title = schema.Title.Value
+	s.SchemaTypeRef = schema.SchemaTypeRef.Value
+	s.Dialect = schema.Dialect.Value
 	if !schema.MultipleOf.IsEmpty() {
 		s.MultipleOf = &schema.MultipleOf.Value
 	}
@@ -231,8 +252,23 @@ func NewSchema(schema *base.Schema) *Schema {
 		}
 	}
 
-	if !schema.UnevaluatedProperties.IsEmpty() {
-
+	if !schema.ContentSchema.IsEmpty() {
+		s.ContentSchema = &SchemaProxy{schema: &lowmodel.NodeReference[*base.SchemaProxy]{
+			ValueNode: schema.ContentSchema.ValueNode,
+			Value:     schema.ContentSchema.Value,
+		}}
+	}
+	s.ContentEncoding = schema.ContentEncoding.Value
+	s.ContentMediaType = schema.ContentMediaType.Value
+	if !schema.Const.IsEmpty() {
+		s.Const = schema.Const.Value
+	}
+	if !schema.DependentRequired.IsEmpty() {
+		depRequired := make(map[string][]string, len(schema.DependentRequired.Value))
+		for k, v := range schema.DependentRequired.Value {
+			depRequired[k.Value] = v.Value
+		}
+		s.DependentRequired = depRequired
 	}
 
 	s.Pattern = schema.Pattern.Value
@@ -248,17 +284,19 @@ func NewSchema(schema *base.Schema) *Schema {
 			s.Type = append(s.Type, schema.Type.Value.B[i].Value)
 		}
 	}
-	if schema.AdditionalProperties.Value != nil {
-		if addPropSchema, ok := schema.AdditionalProperties.Value.(*base.SchemaProxy); ok {
-			s.AdditionalProperties = NewSchemaProxy(&lowmodel.NodeReference[*base.SchemaProxy]{
-				KeyNode:   schema.AdditionalProperties.KeyNode,
-				ValueNode: schema.AdditionalProperties.ValueNode,
-				Value:     addPropSchema,
-			})
+	if !schema.AdditionalProperties.IsEmpty() {
+		if schema.AdditionalProperties.Value.IsA() {
+			s.AdditionalProperties = &DynamicValue[*SchemaProxy, bool]{N: 0, A: NewSchemaProxy(
+				&lowmodel.NodeReference[*base.SchemaProxy]{
+					KeyNode:   schema.AdditionalProperties.KeyNode,
+					ValueNode: schema.AdditionalProperties.ValueNode,
+					Value:     schema.AdditionalProperties.Value.A,
+				})}
+		} else if schema.AdditionalProperties.Value.IsB() {
+			s.AdditionalProperties = &DynamicValue[*SchemaProxy, bool]{N: 1, B: schema.AdditionalProperties.Value.B}
 		} else {
-			// TODO: check for slice and map types and unpack correctly.
-
-			s.AdditionalProperties = schema.AdditionalProperties.Value
+			// neither a schema nor a boolean, kept so it's rendered as it was read.
+			s.AdditionalProperties = &DynamicValue[*SchemaProxy, bool]{N: 2, raw: schema.AdditionalProperties.ValueNode}
 		}
 	}
 	s.Description = schema.Description.Value
@@ -303,6 +341,12 @@ func NewSchema(schema *base.Schema) *Schema {
 	if !schema.Anchor.IsEmpty() {
 		s.Anchor = schema.Anchor.Value
 	}
+	if !schema.DynamicAnchor.IsEmpty() {
+		s.DynamicAnchor = schema.DynamicAnchor.Value
+	}
+	if !schema.DynamicRef.IsEmpty() {
+		s.DynamicRef = schema.DynamicRef.Value
+	}
 
 	// TODO: check this behavior.
 	for i := range schema.Enum.Value {
@@ -454,6 +498,16 @@ func NewSchema(schema *base.Schema) *Schema {
 	return s
 }
 
+// DynamicRefSchema will return the schema that DynamicRef resolves to, using the dynamic scope of the document.
+// Returns nil if there is no DynamicRef, or it could not be resolved. The schema is built lazily, so recursive
+// schemas are safe to walk.
+func (s *Schema) DynamicRefSchema() *SchemaProxy {
+	if s.low == nil || s.low.DynamicRefSchema.Value == nil {
+		return nil
+	}
+	return NewSchemaProxy(&s.low.DynamicRefSchema)
+}
+
 // GoLow will return the low-level instance of Schema that was used to create the high level one.
 func (s *Schema) GoLow() *base.Schema {
 	return s.low
@@ -481,11 +535,27 @@ func (s *Schema) RenderInline() ([]byte, error) {
 // MarshalYAML will create a ready to render YAML representation of the ExternalDoc object.
 func (s *Schema) MarshalYAML() (interface{}, error) {
 	nb := high.NewNodeBuilder(s, s.low)
+	s.renderTupleItems(nb)
 	return nb.Render(), nil
 }
 
 func (s *Schema) MarshalYAMLInline() (interface{}, error) {
 	nb := high.NewNodeBuilder(s, s.low)
 	nb.Resolve = true
+	s.renderTupleItems(nb)
 	return nb.Render(), nil
 }
+
+// renderTupleItems renders PrefixItems under 'items' when they were read from an array of items (before 2020-12),
+// so the schema keeps the keyword its dialect understands.
+func (s *Schema) renderTupleItems(nb *high.NodeBuilder) {
+	if s.low == nil || s.Items != nil || s.low.PrefixItems.KeyNode == nil ||
+		s.low.PrefixItems.KeyNode.Value != base.ItemsLabel {
+		return
+	}
+	for _, entry := range nb.Nodes {
+		if entry.Tag == base.PrefixItemsLabel {
+			entry.Tag = base.ItemsLabel
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// SchemaNameContext describes where a schema is found, used to infer a name for schemas that don't have one. Only
+// the fields that apply need to be set.
+type SchemaNameContext struct {
+	Parent   string // the name of the schema this schema is found in.
+	Property string // the property of the parent schema this schema defines.
+	Items    bool   // the schema is the items of an array (the parent, or its property).
+	Values   bool   // the schema is the additionalProperties of a map (the parent, or its property).
+
+	OperationId string // the operation this schema is found in, the method and path are used without one.
+	Method      string
+	Path        string
+	Parameter   string // the name of the parameter this schema is for.
+	StatusCode  string // the response this schema is the body of, empty for request bodies.
+	MediaType   string // the media type this schema is the body of.
+}
+
+// InferName returns a stable name for the schema, so every tool naming anonymous schemas agrees on the name.
+//
+// A referenced schema uses the name of the component it references (like 'Pet' for '#/components/schemas/Pet'),
+// then the title of the schema is used (in PascalCase). Otherwise, a name is built from the context:
+//   - a property is named after its parent schema and property: 'Pet' and 'owner' become 'PetOwner'.
+//   - a parameter is named after its operation and parameter: 'listPets' and 'limit' become 'ListPetsLimitParameter'.
+//   - a request body is named after its operation: 'createPet' becomes 'CreatePetRequest'.
+//   - a response body is named after its operation and status code: 'createPet' and '201' become
+//     'CreatePet201Response'.
+//
+// Operations without an operationId use their method and path ('post' and '/pets/{id}' become 'PostPetsID'). Bodies
+// with a media type that is not JSON have the media type added ('application/xml' adds 'XML'). Items of arrays add
+// 'Item', and additionalProperties add 'Value'. Returns 'Schema' if there is nothing to build a name from.
+func (s *Schema) InferName(ctx *SchemaNameContext) string {
+	if s.ParentProxy != nil && s.ParentProxy.IsReference() {
+		if name := ReferenceName(s.ParentProxy.GetReference()); name != "" {
+			return name
+		}
+	}
+	if title := utils.ToPascalCase(s.Title); title != "" {
+		return title
+	}
+	if ctx == nil {
+		ctx = new(SchemaNameContext)
+	}
+
+	var name string
+	switch {
+	case ctx.Parent != "":
+		name = utils.ToPascalCase(ctx.Parent) + utils.ToPascalCase(ctx.Property)
+	case ctx.OperationId != "" || ctx.Method != "":
+		name = utils.ToPascalCase(ctx.OperationId)
+		if name == "" {
+			name = utils.ToPascalCase(ctx.Method) + utils.ToPascalCase(ctx.Path)
+		}
+		switch {
+		case ctx.Parameter != "":
+			name += utils.ToPascalCase(ctx.Parameter) + "Parameter"
+		case ctx.StatusCode != "":
+			name += utils.ToPascalCase(ctx.StatusCode) + "Response"
+		default:
+			name += "Request"
+		}
+		if mediaType := strings.TrimSpace(strings.SplitN(ctx.MediaType, ";", 2)[0]); mediaType != "" {
+			_, sub, _ := strings.Cut(mediaType, "/")
+			if sub != "json" && !strings.HasSuffix(sub, "+json") {
+				name += utils.ToPascalCase(sub)
+			}
+		}
+	default:
+		name = utils.ToPascalCase(ctx.Property)
+	}
+	if name == "" {
+		name = "Schema"
+	}
+	if ctx.Items {
+		name += "Item"
+	}
+	if ctx.Values {
+		name += "Value"
+	}
+	return name
+}
+
+// ReferenceName returns the name of the component a reference points to, the last segment of its JSON pointer
+// (unescaped), like 'Pet' for '#/components/schemas/Pet' or 'models.yaml#/Pet'. Returns an empty string for
+// references to a whole document.
+func ReferenceName(ref string) string {
+	_, pointer, found := strings.Cut(ref, "#")
+	if !found || pointer == "" || pointer == "/" {
+		return ""
+	}
+	segment := pointer[strings.LastIndex(pointer, "/")+1:]
+	return utils.UnescapeJSONPointerSegment(segment)
+}
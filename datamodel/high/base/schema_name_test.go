@@ -0,0 +1,45 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema_InferName(t *testing.T) {
+	s := new(Schema)
+	assert.Equal(t, "Schema", s.InferName(nil))
+	assert.Equal(t, "PetOwner", s.InferName(&SchemaNameContext{Parent: "Pet", Property: "owner"}))
+	assert.Equal(t, "PetTagsItem", s.InferName(&SchemaNameContext{Parent: "Pet", Property: "tags", Items: true}))
+	assert.Equal(t, "PetLabelsValue", s.InferName(&SchemaNameContext{Parent: "Pet", Property: "labels", Values: true}))
+	assert.Equal(t, "PetID", s.InferName(&SchemaNameContext{Property: "pet_id"}))
+
+	assert.Equal(t, "CreatePetRequest", s.InferName(&SchemaNameContext{OperationId: "createPet",
+		MediaType: "application/json; charset=utf-8"}))
+	assert.Equal(t, "CreatePetRequestXML", s.InferName(&SchemaNameContext{OperationId: "createPet",
+		MediaType: "application/xml"}))
+	assert.Equal(t, "CreatePet201Response", s.InferName(&SchemaNameContext{OperationId: "createPet",
+		StatusCode: "201", MediaType: "application/problem+json"}))
+	assert.Equal(t, "GetPetsIDDefaultResponse", s.InferName(&SchemaNameContext{Method: "get", Path: "/pets/{id}",
+		StatusCode: "default"}))
+	assert.Equal(t, "ListPetsLimitParameter", s.InferName(&SchemaNameContext{OperationId: "listPets",
+		Parameter: "limit"}))
+
+	s.Title = "pet owner"
+	assert.Equal(t, "PetOwner", s.InferName(&SchemaNameContext{Parent: "Pet", Property: "human"}))
+
+	ref := CreateSchemaProxyRef("#/components/schemas/Owner")
+	s.ParentProxy = ref
+	assert.Equal(t, "Owner", s.InferName(&SchemaNameContext{Parent: "Pet", Property: "human"}))
+}
+
+func TestReferenceName(t *testing.T) {
+	assert.Equal(t, "Pet", ReferenceName("#/components/schemas/Pet"))
+	assert.Equal(t, "Pet", ReferenceName("models.yaml#/Pet"))
+	assert.Equal(t, "a/b~c", ReferenceName("#/definitions/a~1b~0c"))
+	assert.Equal(t, "", ReferenceName("models.yaml"))
+	assert.Equal(t, "", ReferenceName("models.yaml#/"))
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+// ProjectForRequest returns a copy of the schema as it applies to a request. Properties marked as readOnly are
+// removed (along with their entries in required), because they are not sent by a client.
+//
+// Properties, items, additionalProperties, prefixItems and allOf, oneOf and anyOf are projected all the way down
+// the tree. Projected sub-schemas are inlined (references are not kept) because they no longer match the schema the
+// reference points to. Circular references are left as they are. The original schema is not modified.
+func (s *Schema) ProjectForRequest() *Schema {
+	return newSchemaProjector(true).project(s, schemaIdentity(s))
+}
+
+// ProjectForResponse returns a copy of the schema as it applies to a response. Properties marked as writeOnly
+// are removed (along with their entries in required), because they are not sent by a server.
+//
+// The same rules as ProjectForRequest apply to sub-schemas and references.
+func (s *Schema) ProjectForResponse() *Schema {
+	return newSchemaProjector(false).project(s, schemaIdentity(s))
+}
+
+type schemaProjector struct {
+	request    bool
+	projected  map[any]*Schema
+	inProgress map[any]bool
+}
+
+func newSchemaProjector(request bool) *schemaProjector {
+	return &schemaProjector{
+		request:    request,
+		projected:  make(map[any]*Schema),
+		inProgress: make(map[any]bool),
+	}
+}
+
+// excluded checks if a property schema is not used in the context of the projection.
+func (p *schemaProjector) excluded(sp *SchemaProxy) bool {
+	sch := proxySchema(sp)
+	if sch == nil {
+		return false
+	}
+	if p.request {
+		return sch.ReadOnly
+	}
+	return sch.WriteOnly
+}
+
+func (p *schemaProjector) project(s *Schema, id any) *Schema {
+	if s == nil {
+		return nil
+	}
+	if c, ok := p.projected[id]; ok {
+		return c
+	}
+	p.inProgress[id] = true
+	defer delete(p.inProgress, id)
+
+	c := *s
+	c.ParentProxy = nil
+	if s.Properties != nil {
+		c.Properties = make(map[string]*SchemaProxy, len(s.Properties))
+		removed := make(map[string]bool)
+		for k, v := range s.Properties {
+			if p.excluded(v) {
+				removed[k] = true
+				continue
+			}
+			c.Properties[k] = p.projectProxy(v)
+		}
+		if len(removed) > 0 && s.Required != nil {
+			c.Required = []string{}
+			for _, r := range s.Required {
+				if !removed[r] {
+					c.Required = append(c.Required, r)
+				}
+			}
+		}
+	}
+	c.AllOf = p.projectProxies(s.AllOf)
+	c.OneOf = p.projectProxies(s.OneOf)
+	c.AnyOf = p.projectProxies(s.AnyOf)
+	c.PrefixItems = p.projectProxies(s.PrefixItems)
+	if s.Items != nil && s.Items.IsA() {
+		c.Items = &DynamicValue[*SchemaProxy, bool]{N: 0, A: p.projectProxy(s.Items.A)}
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.IsA() {
+		c.AdditionalProperties = &DynamicValue[*SchemaProxy, bool]{N: 0, A: p.projectProxy(s.AdditionalProperties.A)}
+	}
+	p.projected[id] = &c
+	return &c
+}
+
+func (p *schemaProjector) projectProxy(sp *SchemaProxy) *SchemaProxy {
+	sch := proxySchema(sp)
+	if sch == nil || p.inProgress[sp.identity()] {
+		return sp
+	}
+	return CreateSchemaProxy(p.project(sch, sp.identity()))
+}
+
+func (p *schemaProjector) projectProxies(proxies []*SchemaProxy) []*SchemaProxy {
+	if proxies == nil {
+		return nil
+	}
+	projected := make([]*SchemaProxy, len(proxies))
+	for i := range proxies {
+		projected[i] = p.projectProxy(proxies[i])
+	}
+	return projected
+}
+
+// proxySchema returns the schema behind a proxy, or nil if there is nothing to build (or it fails to build).
+func proxySchema(sp *SchemaProxy) *Schema {
+	if sp == nil || (sp.schema == nil && sp.rendered == nil) {
+		return nil
+	}
+	return sp.Schema()
+}
+
+// schemaIdentity returns the identity of the proxy that the schema was built from, or the schema itself.
+func schemaIdentity(s *Schema) any {
+	if s != nil && s.ParentProxy != nil {
+		return s.ParentProxy.identity()
+	}
+	return s
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"strings"
+	"testing"
+
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var projectionSpec = `components:
+  schemas:
+    User:
+      type: object
+      required:
+        - id
+        - name
+        - password
+      properties:
+        id:
+          type: string
+          readOnly: true
+        name:
+          type: string
+        password:
+          type: string
+          writeOnly: true
+        address:
+          $ref: '#/components/schemas/Address'
+        friends:
+          type: array
+          items:
+            $ref: '#/components/schemas/User'
+    Address:
+      type: object
+      required: [created, street]
+      properties:
+        created:
+          type: string
+          readOnly: true
+        street:
+          type: string`
+
+func getProjectionSchema(t *testing.T, name string) *Schema {
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(projectionSpec), &node))
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+	_, schNode := utils.FindKeyNodeTop(name, idx.GetSchemasNode().Content)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(schNode, idx))
+	return NewSchemaProxy(&lowmodel.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: schNode}).Schema()
+}
+
+func TestSchema_ProjectForRequest(t *testing.T) {
+	user := getProjectionSchema(t, "User")
+	req := user.ProjectForRequest()
+
+	assert.Len(t, req.Properties, 4)
+	assert.NotContains(t, req.Properties, "id")
+	assert.Contains(t, req.Properties, "password")
+	assert.Equal(t, []string{"name", "password"}, req.Required)
+
+	address := req.Properties["address"].Schema()
+	assert.Len(t, address.Properties, 1)
+	assert.Equal(t, []string{"street"}, address.Required)
+
+	// the original is untouched.
+	assert.Len(t, user.Properties, 5)
+	assert.Equal(t, []string{"id", "name", "password"}, user.Required)
+	assert.Len(t, user.Properties["address"].Schema().Properties, 2)
+}
+
+func TestSchema_ProjectForResponse(t *testing.T) {
+	user := getProjectionSchema(t, "User")
+	resp := user.ProjectForResponse()
+
+	assert.Len(t, resp.Properties, 4)
+	assert.NotContains(t, resp.Properties, "password")
+	assert.Contains(t, resp.Properties, "id")
+	assert.Equal(t, []string{"id", "name"}, resp.Required)
+
+	// nothing is writeOnly in the address, so required stays the same.
+	address := resp.Properties["address"].Schema()
+	assert.Len(t, address.Properties, 2)
+	assert.Equal(t, []string{"created", "street"}, address.Required)
+}
+
+func TestSchema_ProjectForRequest_Circular(t *testing.T) {
+	user := getProjectionSchema(t, "User")
+	req := user.ProjectForRequest()
+
+	// the circular reference back to User is kept as a reference.
+	items := req.Properties["friends"].Schema().Items.A
+	assert.True(t, items.IsReference())
+	assert.Equal(t, "#/components/schemas/User", items.GetReference())
+
+	rendered, err := req.Render()
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(rendered), "$ref: '#/components/schemas/User'"))
+	assert.False(t, strings.Contains(string(rendered), "$ref: '#/components/schemas/Address'"))
+	assert.False(t, strings.Contains(string(rendered), "readOnly"))
+}
+
+func TestSchema_ProjectForRequest_Composition(t *testing.T) {
+	yml := `type: object
+allOf:
+  - type: object
+    required: [a, b]
+    properties:
+      a:
+        type: string
+        readOnly: true
+      b:
+        type: string
+oneOf:
+  - type: object
+    properties:
+      c:
+        type: string
+        writeOnly: true
+additionalProperties:
+  type: object
+  properties:
+    d:
+      type: string
+      readOnly: true
+prefixItems:
+  - type: object
+    properties:
+      e:
+        type: string
+        readOnly: true`
+
+	sch := getHighSchema(t, yml)
+	req := sch.ProjectForRequest()
+	assert.Len(t, req.AllOf[0].Schema().Properties, 1)
+	assert.Equal(t, []string{"b"}, req.AllOf[0].Schema().Required)
+	assert.Len(t, req.OneOf[0].Schema().Properties, 1)
+	assert.Len(t, req.AdditionalProperties.A.Schema().Properties, 0)
+	assert.Len(t, req.PrefixItems[0].Schema().Properties, 0)
+
+	resp := sch.ProjectForResponse()
+	assert.Len(t, resp.AllOf[0].Schema().Properties, 2)
+	assert.Len(t, resp.OneOf[0].Schema().Properties, 0)
+	assert.Len(t, resp.AdditionalProperties.A.Schema().Properties, 1)
+}
+
+func TestSchema_ProjectForRequest_Nil(t *testing.T) {
+	var sch *Schema
+	assert.Nil(t, sch.ProjectForRequest())
+
+	sch = &Schema{Properties: map[string]*SchemaProxy{"ref": CreateSchemaProxyRef("#/components/schemas/Nope")}}
+	req := sch.ProjectForRequest()
+	assert.Equal(t, "#/components/schemas/Nope", req.Properties["ref"].GetReference())
+}
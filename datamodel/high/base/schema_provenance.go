@@ -0,0 +1,133 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"sort"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ConstraintKeywords are the keywords tracked by SchemaProvenance as constraints.
+var ConstraintKeywords = []string{
+	"type", "format", "enum", "const", "default", "nullable", "readOnly", "writeOnly",
+	"multipleOf", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum",
+	"maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "items",
+	"maxProperties", "minProperties", "additionalProperties", "discriminator",
+}
+
+// Contribution is a property or constraint that a schema contributes to a schema composed using allOf.
+type Contribution struct {
+	Keyword string             // 'properties', 'required' or one of the ConstraintKeywords.
+	Name    string             // the name of the property (for 'properties' and 'required'), empty for constraints.
+	Schema  *SchemaProxy       // the schema that defines the contribution.
+	Branch  *PolymorphicBranch // how the schema was reached from the composed schema, the root has no Parent.
+	Origin  *index.NodeOrigin  // where the property or keyword is defined, including the document it's in.
+}
+
+// SchemaProvenance records which of the schemas of an allOf composition contributed each property and constraint,
+// so errors can point at the schema that defines them rather than the composed schema.
+type SchemaProvenance struct {
+	properties  map[string][]*Contribution
+	required    map[string][]*Contribution
+	constraints map[string][]*Contribution
+}
+
+// Properties returns the name of every property of the composed schema, sorted.
+func (p *SchemaProvenance) Properties() []string {
+	names := make([]string, 0, len(p.properties))
+	for name := range p.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Property returns every schema that defines a property, in the order they were found (the composed schema
+// first, then its allOf branches depth first). Returns nil if no schema defines the property.
+func (p *SchemaProvenance) Property(name string) []*Contribution {
+	return p.properties[name]
+}
+
+// Required returns every schema that lists a property as required, in the order they were found.
+func (p *SchemaProvenance) Required(name string) []*Contribution {
+	return p.required[name]
+}
+
+// Constraint returns every schema that defines a constraint keyword (like 'maxLength'), in the order they were
+// found.
+func (p *SchemaProvenance) Constraint(keyword string) []*Contribution {
+	return p.constraints[keyword]
+}
+
+// Provenance walks the allOf branches of this schema (following references, across documents), and records which
+// schema contributes every property, required property and constraint. oneOf and anyOf are not followed, because
+// their branches are alternatives rather than part of the schema.
+//
+// Schemas are built as they are walked, if a schema fails to build, the error is returned.
+func (sp *SchemaProxy) Provenance() (*SchemaProvenance, error) {
+	p := &SchemaProvenance{
+		properties:  make(map[string][]*Contribution),
+		required:    make(map[string][]*Contribution),
+		constraints: make(map[string][]*Contribution),
+	}
+	err := sp.WalkPolymorphism(func(branch *PolymorphicBranch) bool {
+		if branch.Parent != nil && branch.Keyword != AllOfKeyword {
+			return false
+		}
+		if !branch.Circular {
+			p.record(branch)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *SchemaProvenance) record(branch *PolymorphicBranch) {
+	origin := branch.Schema.GetOrigin()
+	if origin == nil || origin.Node == nil {
+		return
+	}
+	node := origin.Node
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	contribution := func(keyword, name string, n *yaml.Node) *Contribution {
+		return &Contribution{
+			Keyword: keyword,
+			Name:    name,
+			Schema:  branch.Schema,
+			Branch:  branch,
+			Origin: &index.NodeOrigin{
+				Node:             n,
+				Line:             n.Line,
+				Column:           n.Column,
+				AbsoluteLocation: origin.AbsoluteLocation,
+				RootDocument:     origin.RootDocument,
+			},
+		}
+	}
+
+	if _, props := utils.FindKeyNodeTop("properties", node.Content); props != nil && props.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(props.Content); i += 2 {
+			k := props.Content[i]
+			p.properties[k.Value] = append(p.properties[k.Value], contribution("properties", k.Value, k))
+		}
+	}
+	if _, req := utils.FindKeyNodeTop("required", node.Content); req != nil && req.Kind == yaml.SequenceNode {
+		for _, r := range req.Content {
+			p.required[r.Value] = append(p.required[r.Value], contribution("required", r.Value, r))
+		}
+	}
+	for _, keyword := range ConstraintKeywords {
+		if k, _ := utils.FindKeyNodeTop(keyword, node.Content); k != nil {
+			p.constraints[keyword] = append(p.constraints[keyword], contribution(keyword, "", k))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"testing"
+
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var provenanceSpec = `components:
+  schemas:
+    Named:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          maxLength: 10
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Named'
+        - type: object
+          maxProperties: 5
+          properties:
+            name:
+              type: string
+            tag:
+              type: string
+        - oneOf:
+            - properties:
+                ignored:
+                  type: string
+      required: [tag]
+      properties:
+        born:
+          type: string
+    Loop:
+      allOf:
+        - $ref: '#/components/schemas/Loop'
+        - properties:
+            next:
+              type: string`
+
+func getProvenanceSchema(t *testing.T, name string) *SchemaProxy {
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(provenanceSpec), &node))
+	config := index.CreateClosedAPIIndexConfig()
+	config.SpecAbsolutePath = "/specs/pets.yaml"
+	idx := index.NewSpecIndexWithConfig(&node, config)
+	_, schNode := utils.FindKeyNodeTop(name, idx.GetSchemasNode().Content)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(schNode, idx))
+	return NewSchemaProxy(&lowmodel.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: schNode})
+}
+
+func TestSchemaProxy_Provenance(t *testing.T) {
+	p, err := getProvenanceSchema(t, "Pet").Provenance()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"born", "name", "tag"}, p.Properties())
+
+	// the composed schema comes first, then its allOf branches.
+	name := p.Property("name")
+	assert.Len(t, name, 2)
+	assert.Equal(t, []string{"#/components/schemas/Named"}, name[0].Branch.References)
+	assert.Equal(t, 7, name[0].Origin.Line)
+	assert.Equal(t, "/specs/pets.yaml", name[0].Origin.AbsoluteLocation)
+	assert.Equal(t, []string{"allOf[1]"}, name[1].Branch.Path)
+	assert.Equal(t, 16, name[1].Origin.Line)
+
+	born := p.Property("born")
+	assert.Len(t, born, 1)
+	assert.Nil(t, born[0].Branch.Parent)
+
+	assert.Equal(t, 5, p.Required("name")[0].Origin.Line)
+	assert.Equal(t, 24, p.Required("tag")[0].Origin.Line)
+	assert.Nil(t, p.Required("born"))
+
+	maxProperties := p.Constraint("maxProperties")
+	assert.Len(t, maxProperties, 1)
+	assert.Equal(t, "maxProperties", maxProperties[0].Keyword)
+	assert.Equal(t, 14, maxProperties[0].Origin.Line)
+	assert.Len(t, p.Constraint("type"), 2)
+
+	// constraints of properties belong to the property, not the schema.
+	assert.Nil(t, p.Constraint("maxLength"))
+
+	// oneOf branches are alternatives, not part of the schema.
+	assert.Nil(t, p.Property("ignored"))
+}
+
+func TestSchemaProxy_Provenance_Circular(t *testing.T) {
+	p, err := getProvenanceSchema(t, "Loop").Provenance()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"next"}, p.Properties())
+}
@@ -4,9 +4,12 @@
 package base
 
 import (
+	"sync"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
@@ -44,11 +47,14 @@ import (
 // Schemas are where things can get messy, mainly because the Schema standard changes between versions, and
 // it's not actually JSONSchema until 3.1, so lots of times a bad schema will break parsing. Errors are only found
 // when a schema is needed, so the rest of the document is parsed and ready to use.
+//
+// A SchemaProxy is safe to use from multiple goroutines.
 type SchemaProxy struct {
 	schema     *low.NodeReference[*base.SchemaProxy]
 	buildError error
 	rendered   *Schema
 	refStr     string
+	lock       sync.Mutex
 }
 
 // NewSchemaProxy creates a new high-level SchemaProxy from a low-level one.
@@ -72,19 +78,26 @@ func CreateSchemaProxyRef(ref string) *SchemaProxy {
 // If there is a problem building the Schema, then this method will return nil. Use GetBuildError to gain access
 // to that building error.
 func (sp *SchemaProxy) Schema() *Schema {
-	if sp.rendered == nil {
-		s := sp.schema.Value.Schema()
-		if s == nil {
-			sp.buildError = sp.schema.Value.GetBuildError()
-			return nil
-		}
-		sch := NewSchema(s)
-		sch.ParentProxy = sp
-		sp.rendered = sch
-		return sch
-	} else {
-		return sp.rendered
+	sp.lock.Lock()
+	rendered := sp.rendered
+	sp.lock.Unlock()
+	if rendered != nil {
+		return rendered
+	}
+	s := sp.schema.Value.Schema()
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	if sp.rendered != nil {
+		return sp.rendered // built by another goroutine.
+	}
+	if s == nil {
+		sp.buildError = sp.schema.Value.GetBuildError()
+		return nil
 	}
+	sch := NewSchema(s)
+	sch.ParentProxy = sp
+	sp.rendered = sch
+	return sch
 }
 
 // IsReference returns true if the SchemaProxy is a reference to another Schema.
@@ -108,19 +121,26 @@ func (sp *SchemaProxy) GetReference() string {
 
 // BuildSchema operates the same way as Schema, except it will return any error along with the *Schema
 func (sp *SchemaProxy) BuildSchema() (*Schema, error) {
-	if sp.rendered != nil {
-		return sp.rendered, sp.buildError
-	}
 	schema := sp.Schema()
-	er := sp.buildError
-	return schema, er
+	return schema, sp.GetBuildError()
 }
 
 // GetBuildError returns any error that was thrown when calling Schema()
 func (sp *SchemaProxy) GetBuildError() error {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
 	return sp.buildError
 }
 
+// GetOrigin returns the origin of the schema, the absolute location of the document (file or URL) it was read
+// from and its position. Returns nil if the proxy was not built from a document.
+func (sp *SchemaProxy) GetOrigin() *index.NodeOrigin {
+	if sp.schema == nil || sp.schema.Value == nil {
+		return nil
+	}
+	return sp.schema.Value.GetOrigin()
+}
+
 func (sp *SchemaProxy) GoLow() *base.SchemaProxy {
 	if sp.schema == nil {
 		return nil
@@ -4,6 +4,7 @@
 package base
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
@@ -65,3 +66,57 @@ func TestCreateSchemaProxyRef(t *testing.T) {
 	assert.Equal(t, "#/components/schemas/MySchema", sp.GetReference())
 	assert.True(t, sp.IsReference())
 }
+
+func TestSchemaProxy_GetOrigin(t *testing.T) {
+	const ymlComponents = `components:
+  schemas:
+    rice:
+      type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(ymlComponents), &idxNode)
+	c := index.CreateOpenAPIIndexConfig()
+	c.SpecAbsolutePath = "https://pb33f.io/rice.yaml"
+	idx := index.NewSpecIndexWithConfig(&idxNode, c)
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`$ref: '#/components/schemas/rice'`), &node)
+
+	lowProxy := new(lowbase.SchemaProxy)
+	err := lowProxy.Build(node.Content[0], idx)
+	assert.NoError(t, err)
+
+	// the origin of a reference, is the schema being referenced.
+	sp := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: lowProxy})
+	origin := sp.GetOrigin()
+	assert.Equal(t, "https://pb33f.io/rice.yaml", origin.AbsoluteLocation)
+	assert.Equal(t, 4, origin.Line)
+	assert.Equal(t, 4, high.GetOrigin(sp.Schema()).Line)
+
+	assert.Nil(t, CreateSchemaProxy(&Schema{}).GetOrigin())
+}
+
+func TestSchemaProxy_Schema_Concurrent(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`type: object
+properties:
+  name:
+    type: string`), &node)
+
+	lowProxy := new(lowbase.SchemaProxy)
+	assert.NoError(t, lowProxy.Build(node.Content[0], nil))
+	sp := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: lowProxy})
+
+	schemas := make(chan *Schema)
+	for i := 0; i < 10; i++ {
+		go func() {
+			schemas <- sp.Schema()
+		}()
+	}
+	first := <-schemas
+	for i := 1; i < 10; i++ {
+		assert.Same(t, first, <-schemas)
+	}
+	assert.Same(t, lowProxy.Schema(), first.GoLow())
+	assert.Equal(t, "object", first.Type[0])
+}
@@ -504,7 +504,7 @@ required: [cake, fish]`
     assert.Equal(t, float64(334), compiled.Properties["somethingB"].Schema().ExclusiveMaximum.B)
     assert.Len(t, compiled.Properties["somethingB"].Schema().Properties["somethingBProp"].Schema().Type, 2)
 
-	assert.Equal(t, "nice", compiled.AdditionalProperties.(*SchemaProxy).Schema().Description)
+	assert.Equal(t, "nice", compiled.AdditionalProperties.A.Schema().Description)
 
 	wentLow := compiled.GoLow()
 	assert.Equal(t, 97, wentLow.AdditionalProperties.ValueNode.Line)
@@ -1012,6 +1012,10 @@ func TestNewSchemaProxy_RenderSchemaCheckAdditionalPropertiesSlice(t *testing.T)
 	schemaProxy := NewSchemaProxy(&lowproxy)
 	compiled := schemaProxy.Schema()
 
+	// a slice is neither a schema nor a boolean, it's kept as it was read.
+	assert.False(t, compiled.AdditionalProperties.IsA())
+	assert.False(t, compiled.AdditionalProperties.IsB())
+
 	// now render it out, it should be identical.
 	schemaBytes, _ := compiled.Render()
 	assert.Len(t, schemaBytes, 91)
@@ -1043,6 +1047,33 @@ func TestNewSchemaProxy_RenderSchemaCheckAdditionalPropertiesSliceMap(t *testing
 	assert.Len(t, schemaBytes, 75)
 }
 
+func TestNewSchemaProxy_RenderSchemaCheckAdditionalPropertiesSchema(t *testing.T) {
+	testSpec := `additionalProperties:
+    type: string
+    description: cake`
+
+	var compNode yaml.Node
+	_ = yaml.Unmarshal([]byte(testSpec), &compNode)
+
+	sp := new(lowbase.SchemaProxy)
+	err := sp.Build(compNode.Content[0], nil)
+	assert.NoError(t, err)
+
+	lowproxy := low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: compNode.Content[0],
+	}
+
+	schemaProxy := NewSchemaProxy(&lowproxy)
+	compiled := schemaProxy.Schema()
+	assert.True(t, compiled.AdditionalProperties.IsA())
+	assert.Equal(t, "cake", compiled.AdditionalProperties.A.Schema().Description)
+
+	// now render it out, it should be identical.
+	schemaBytes, _ := compiled.Render()
+	assert.Equal(t, testSpec, strings.TrimSpace(string(schemaBytes)))
+}
+
 func TestNewSchemaProxy_CheckDefaultBooleanFalse(t *testing.T) {
 	testSpec := `default: false`
 
@@ -1083,12 +1114,45 @@ func TestNewSchemaProxy_RenderAdditionalPropertiesFalse(t *testing.T) {
 
 	schemaProxy := NewSchemaProxy(&lowproxy)
 	compiled := schemaProxy.Schema()
+	assert.True(t, compiled.AdditionalProperties.IsB())
+	assert.False(t, compiled.AdditionalProperties.B)
 
 	// now render it out, it should be identical.
 	schemaBytes, _ := compiled.Render()
 	assert.Equal(t, testSpec, strings.TrimSpace(string(schemaBytes)))
 }
 
+func TestNewSchemaProxy_RenderTupleItems(t *testing.T) {
+	testSpec := `$schema: http://json-schema.org/draft-07/schema#
+type: array
+items:
+    - type: string
+    - type: integer`
+
+	var compNode yaml.Node
+	_ = yaml.Unmarshal([]byte(testSpec), &compNode)
+
+	sp := new(lowbase.SchemaProxy)
+	err := sp.Build(compNode.Content[0], nil)
+	assert.NoError(t, err)
+
+	lowproxy := low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: compNode.Content[0],
+	}
+
+	schemaProxy := NewSchemaProxy(&lowproxy)
+	compiled := schemaProxy.Schema()
+	assert.Nil(t, compiled.Items)
+	assert.Len(t, compiled.PrefixItems, 2)
+
+	// the tuple is rendered under 'items', as it was written.
+	schemaBytes, _ := compiled.Render()
+	assert.Equal(t, testSpec, strings.TrimSpace(string(schemaBytes)))
+	schemaBytes, _ = compiled.RenderInline()
+	assert.Equal(t, testSpec, strings.TrimSpace(string(schemaBytes)))
+}
+
 func TestNewSchemaProxy_RenderMultiplePoly(t *testing.T) {
 	idxYaml := `openapi: 3.1.0
 components:
@@ -1206,3 +1270,49 @@ unevaluatedProperties: false
 	value := false
 	assert.EqualValues(t, &value, highSchema.UnevaluatedProperties.B)
 }
+
+func TestNewSchema_ConstDependentRequiredContent(t *testing.T) {
+	yml := `
+type: string
+const: false
+contentEncoding: base64
+contentMediaType: image/png
+contentSchema:
+  type: string
+dependentRequired:
+  cheese:
+    - tomato
+`
+	highSchema := getHighSchema(t, yml)
+
+	assert.Equal(t, false, highSchema.Const)
+	assert.Equal(t, "base64", highSchema.ContentEncoding)
+	assert.Equal(t, "image/png", highSchema.ContentMediaType)
+	assert.Equal(t, "string", highSchema.ContentSchema.Schema().Type[0])
+	assert.Equal(t, map[string][]string{"cheese": {"tomato"}}, highSchema.DependentRequired)
+
+	rend, _ := highSchema.Render()
+	assert.Contains(t, string(rend), "const: false")
+	assert.Contains(t, string(rend), "contentEncoding: base64")
+	assert.Contains(t, string(rend), "contentSchema:")
+	assert.Contains(t, string(rend), "dependentRequired:")
+}
+
+func TestNewSchema_Dialect(t *testing.T) {
+	yml := `
+$schema: https://json-schema.org/draft/2020-12/schema
+type: object
+properties:
+  name:
+    type: string
+`
+	highSchema := getHighSchema(t, yml)
+
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", highSchema.SchemaTypeRef)
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", highSchema.Dialect)
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", highSchema.Properties["name"].Schema().Dialect)
+
+	rend, _ := highSchema.Render()
+	assert.Contains(t, string(rend), "$schema: https://json-schema.org/draft/2020-12/schema")
+	assert.Equal(t, 1, strings.Count(string(rend), "json-schema.org"))
+}
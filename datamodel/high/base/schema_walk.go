@@ -0,0 +1,126 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// DefaultSchemaWalkDepth is the maximum depth of schemas walked by Walk, the schema being walked is at depth 0.
+const DefaultSchemaWalkDepth = 64
+
+// SchemaVisitor is called for every schema found when walking. The path is a JSON pointer to the schema, relative
+// to the schema being walked (which has an empty path), like '/properties/tags/items'. ref is true if the schema
+// was reached through a $ref. Returning false will stop the walker from descending into the schema, the rest of
+// the tree continues to be walked.
+type SchemaVisitor func(path string, s *Schema, ref bool) bool
+
+// Walk walks this schema and every schema it contains, depth first, calling the visitor for each one. References
+// are followed. Schemas that are already being walked (a circular reference) are not visited again, and nothing
+// deeper than DefaultSchemaWalkDepth is walked.
+//
+// Sub-schemas are walked in the order: allOf, oneOf, anyOf, not, if, then, else, properties, patternProperties,
+// additionalProperties, dependentSchemas, propertyNames, unevaluatedProperties, items, prefixItems, contains,
+// unevaluatedItems and contentSchema. Schemas held in maps are walked in the order of their keys (sorted). Schemas
+// that fail to build are skipped.
+func (s *Schema) Walk(visit SchemaVisitor) {
+	s.WalkDepth(DefaultSchemaWalkDepth, visit)
+}
+
+// WalkDepth is the same as Walk, except nothing deeper than maxDepth is walked.
+func (s *Schema) WalkDepth(maxDepth int, visit SchemaVisitor) {
+	if s == nil {
+		return
+	}
+	w := &schemaWalker{visit: visit, maxDepth: maxDepth, onPath: make(map[any]bool)}
+	w.walk("", s, s.ParentProxy != nil && s.ParentProxy.IsReference(), 0)
+}
+
+// Walk builds the schema and walks it, see Schema.Walk.
+func (sp *SchemaProxy) Walk(visit SchemaVisitor) {
+	if sch := proxySchema(sp); sch != nil {
+		sch.Walk(visit)
+	}
+}
+
+type schemaWalker struct {
+	visit    SchemaVisitor
+	maxDepth int
+	onPath   map[any]bool
+}
+
+// walkIdentity returns a value shared by every schema built from the same node, so cycles through references are
+// found the first time they loop.
+func walkIdentity(s *Schema) any {
+	if low := s.GoLow(); low != nil {
+		if n := low.GetOriginNode(); n != nil {
+			return n
+		}
+	}
+	return schemaIdentity(s)
+}
+
+func (w *schemaWalker) walk(path string, s *Schema, ref bool, depth int) {
+	if depth > w.maxDepth {
+		return
+	}
+	id := walkIdentity(s)
+	if w.onPath[id] {
+		return
+	}
+	if !w.visit(path, s, ref) {
+		return
+	}
+	w.onPath[id] = true
+	defer delete(w.onPath, id)
+
+	proxy := func(p string, sp *SchemaProxy) {
+		if sch := proxySchema(sp); sch != nil {
+			w.walk(p, sch, sp.IsReference(), depth+1)
+		}
+	}
+	list := func(keyword string, schemas []*SchemaProxy) {
+		for i, sp := range schemas {
+			proxy(path+"/"+keyword+"/"+strconv.Itoa(i), sp)
+		}
+	}
+	keyed := func(keyword string, schemas map[string]*SchemaProxy) {
+		keys := make([]string, 0, len(schemas))
+		for k := range schemas {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			proxy(path+"/"+keyword+"/"+utils.EscapeJSONPointerSegment(k), schemas[k])
+		}
+	}
+
+	list("allOf", s.AllOf)
+	list("oneOf", s.OneOf)
+	list("anyOf", s.AnyOf)
+	proxy(path+"/not", s.Not)
+	proxy(path+"/if", s.If)
+	proxy(path+"/then", s.Then)
+	proxy(path+"/else", s.Else)
+	keyed("properties", s.Properties)
+	keyed("patternProperties", s.PatternProperties)
+	if s.AdditionalProperties != nil && s.AdditionalProperties.IsA() {
+		proxy(path+"/additionalProperties", s.AdditionalProperties.A)
+	}
+	keyed("dependentSchemas", s.DependentSchemas)
+	proxy(path+"/propertyNames", s.PropertyNames)
+	if s.UnevaluatedProperties != nil && s.UnevaluatedProperties.IsA() {
+		proxy(path+"/unevaluatedProperties", s.UnevaluatedProperties.A)
+	}
+	if s.Items != nil && s.Items.IsA() {
+		proxy(path+"/items", s.Items.A)
+	}
+	list("prefixItems", s.PrefixItems)
+	proxy(path+"/contains", s.Contains)
+	proxy(path+"/unevaluatedItems", s.UnevaluatedItems)
+	proxy(path+"/contentSchema", s.ContentSchema)
+}
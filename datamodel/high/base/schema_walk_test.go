@@ -0,0 +1,105 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+	"testing"
+
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var walkSpec = `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        tags:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tag'
+        parent:
+          $ref: '#/components/schemas/Pet'
+        a/b:
+          not:
+            type: integer
+      additionalProperties:
+        type: string
+      allOf:
+        - $ref: '#/components/schemas/Tag'
+    Tag:
+      type: object
+      properties:
+        label:
+          type: string`
+
+func getWalkSchema(t *testing.T, name string) *SchemaProxy {
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(walkSpec), &node))
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+	_, schNode := utils.FindKeyNodeTop(name, idx.GetSchemasNode().Content)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(schNode, idx))
+	return NewSchemaProxy(&lowmodel.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: schNode})
+}
+
+func TestSchema_Walk(t *testing.T) {
+	var visited []string
+	getWalkSchema(t, "Pet").Walk(func(path string, s *Schema, ref bool) bool {
+		visited = append(visited, fmt.Sprintf("%s %v", path, ref))
+		return true
+	})
+	assert.Equal(t, []string{
+		" false",
+		"/allOf/0 true",
+		"/allOf/0/properties/label false",
+		"/properties/a~1b false",
+		"/properties/a~1b/not false",
+		"/properties/name false",
+		// parent points back at Pet, which is already being walked.
+		"/properties/tags false",
+		"/properties/tags/items true",
+		"/properties/tags/items/properties/label false",
+		"/additionalProperties false",
+	}, visited)
+}
+
+func TestSchema_Walk_Stop(t *testing.T) {
+	var visited []string
+	getWalkSchema(t, "Pet").Walk(func(path string, s *Schema, ref bool) bool {
+		visited = append(visited, path)
+		return path != "/properties/tags" && path != "/allOf/0"
+	})
+	assert.NotContains(t, visited, "/properties/tags/items")
+	assert.NotContains(t, visited, "/allOf/0/properties/label")
+	assert.Contains(t, visited, "/properties/name")
+}
+
+func TestSchema_WalkDepth(t *testing.T) {
+	var visited []string
+	getWalkSchema(t, "Pet").Schema().WalkDepth(1, func(path string, s *Schema, ref bool) bool {
+		visited = append(visited, path)
+		return true
+	})
+	assert.Equal(t, []string{"", "/allOf/0", "/properties/a~1b", "/properties/name", "/properties/tags",
+		"/additionalProperties"}, visited)
+
+	var nilSchema *Schema
+	nilSchema.Walk(func(string, *Schema, bool) bool { // no panic.
+		t.Fail()
+		return true
+	})
+	CreateSchemaProxyRef("#/nope").Walk(func(string, *Schema, bool) bool {
+		t.Fail()
+		return true
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"math"
+	"sort"
+)
+
+// Security is defined as a list of SecurityRequirement objects, any one of which grants access (a logical OR).
+// Each SecurityRequirement holds one or more security schemes, all of which are required (a logical AND). An empty
+// SecurityRequirement ({}) grants access without any security, an empty list removes all security.
+
+// SchemeScopes is a security scheme required by a SecurityRequirement, with the scopes (or roles) it requires.
+type SchemeScopes struct {
+	Scheme string   // the name of the security scheme, as defined by the document.
+	Scopes []string // the scopes required, empty if no scopes are required.
+}
+
+// Schemes returns the schemes of the requirement, all of which are required. Schemes are in the order they are
+// found in the document, schemes added after building go last, sorted by name. An empty requirement returns an
+// empty list.
+func (s *SecurityRequirement) Schemes() []*SchemeScopes {
+	names := make([]string, 0, len(s.Requirements))
+	lines := make(map[string]int, len(s.Requirements))
+	for name := range s.Requirements {
+		names = append(names, name)
+		lines[name] = math.MaxInt
+	}
+	if s.low != nil {
+		for k := range s.low.Requirements.Value {
+			if _, ok := lines[k.Value]; ok && k.KeyNode != nil {
+				lines[k.Value] = k.KeyNode.Line
+			}
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if lines[names[i]] != lines[names[j]] {
+			return lines[names[i]] < lines[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	schemes := make([]*SchemeScopes, len(names))
+	for i, name := range names {
+		schemes[i] = &SchemeScopes{Scheme: name, Scopes: s.Requirements[name]}
+	}
+	return schemes
+}
+
+// IsAnonymous returns true if the requirement has no schemes ({}), which grants access without any security.
+func (s *SecurityRequirement) IsAnonymous() bool {
+	return len(s.Requirements) == 0
+}
+
+// FlattenSecurity expands a list of security requirements into a list of alternatives, any one of which grants
+// access. Each alternative is a list of schemes, all of which are required. An empty alternative grants access
+// without any security. Returns nil if there are no requirements (no security applies).
+func FlattenSecurity(requirements []*SecurityRequirement) [][]*SchemeScopes {
+	if requirements == nil {
+		return nil
+	}
+	alternatives := make([][]*SchemeScopes, 0, len(requirements))
+	for _, req := range requirements {
+		if req != nil {
+			alternatives = append(alternatives, req.Schemes())
+		}
+	}
+	return alternatives
+}
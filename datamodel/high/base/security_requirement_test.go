@@ -43,3 +43,51 @@ cake:
 	highBytes, _ := highExt.Render()
 	assert.Equal(t, yml, strings.TrimSpace(string(highBytes)))
 }
+
+func TestSecurityRequirement_Schemes(t *testing.T) {
+
+	var cNode yaml.Node
+
+	yml := `pizza:
+    - cheese
+cake: []
+apple:
+    - pie`
+
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	var lowExt lowbase.SecurityRequirement
+	_ = lowmodel.BuildModel(cNode.Content[0], &lowExt)
+	_ = lowExt.Build(cNode.Content[0], nil)
+
+	highExt := NewSecurityRequirement(&lowExt)
+	highExt.Requirements["burger"] = []string{"fries"}
+
+	schemes := highExt.Schemes()
+	assert.Len(t, schemes, 4)
+	assert.Equal(t, "pizza", schemes[0].Scheme)
+	assert.Equal(t, []string{"cheese"}, schemes[0].Scopes)
+	assert.Equal(t, "cake", schemes[1].Scheme)
+	assert.Empty(t, schemes[1].Scopes)
+	assert.Equal(t, "apple", schemes[2].Scheme)
+	assert.Equal(t, "burger", schemes[3].Scheme)
+	assert.False(t, highExt.IsAnonymous())
+}
+
+func TestFlattenSecurity(t *testing.T) {
+	assert.Nil(t, FlattenSecurity(nil))
+	assert.Empty(t, FlattenSecurity([]*SecurityRequirement{}))
+
+	flat := FlattenSecurity([]*SecurityRequirement{
+		{Requirements: map[string][]string{"b": {"read"}, "a": nil}},
+		nil,
+		{Requirements: map[string][]string{}},
+	})
+	assert.Len(t, flat, 2)
+	assert.Len(t, flat[0], 2)
+	assert.Equal(t, "a", flat[0][0].Scheme)
+	assert.Equal(t, "b", flat[0][1].Scheme)
+	assert.Equal(t, []string{"read"}, flat[0][1].Scopes)
+	assert.Empty(t, flat[1])
+	assert.True(t, (&SecurityRequirement{}).IsAnonymous())
+}
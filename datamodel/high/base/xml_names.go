@@ -0,0 +1,210 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"sort"
+)
+
+// XMLNode represents the effective XML serialization of a schema, computed from the XML objects in a schema tree,
+// following the rules defined by the OpenAPI specification.
+//
+// An element is named after its property (or the name supplied for the root), unless xml.name is set. A property
+// with xml.attribute set is serialized as an attribute of its parent. Array items are named after the array property,
+// unless the items set their own xml.name. The items are repeated in place of the array, unless xml.wrapped is set on
+// the array, in which case they are wrapped in an element named after the array. xml.namespace and xml.prefix only
+// apply to the element or attribute they are set on.
+//
+//	v2 - https://swagger.io/specification/v2/#xmlObject
+//	v3 - https://swagger.io/specification/#xml-object
+type XMLNode struct {
+	Name      string     // the local name of the element or attribute.
+	Namespace string     // the namespace URI, if one is set.
+	Prefix    string     // the namespace prefix, if one is set.
+	Attribute bool       // the node is serialized as an attribute of its parent.
+	Wrapped   bool       // the node is a wrapper element for array items.
+	Array     bool       // the node is an array, its items are found in Items.
+	Circular  bool       // the schema points back at a schema already in the tree, it has not been expanded.
+	Schema    *Schema    // the schema this node was built from.
+	Items     *XMLNode   // the item node of an array.
+	Children  []*XMLNode // child elements (and attributes) of an object, in the order they are defined.
+	Property  string     // the name of the property this node was built from, empty for the root and items.
+	parent    *XMLNode
+}
+
+// QualifiedName returns the name of the node, with its prefix if one has been set. For example 'smp:id'.
+func (n *XMLNode) QualifiedName() string {
+	if n.Prefix != "" {
+		return n.Prefix + ":" + n.Name
+	}
+	return n.Name
+}
+
+// Attributes returns the child nodes that are serialized as attributes.
+func (n *XMLNode) Attributes() []*XMLNode {
+	var attrs []*XMLNode
+	for _, c := range n.Children {
+		if c.Attribute {
+			attrs = append(attrs, c)
+		}
+	}
+	return attrs
+}
+
+// Elements returns the child nodes that are serialized as elements.
+func (n *XMLNode) Elements() []*XMLNode {
+	var elements []*XMLNode
+	for _, c := range n.Children {
+		if !c.Attribute {
+			elements = append(elements, c)
+		}
+	}
+	return elements
+}
+
+// Parent returns the node this node was found in, nil for the root.
+func (n *XMLNode) Parent() *XMLNode {
+	return n.parent
+}
+
+// XMLName returns the effective name of the element or attribute for this schema, given the name of the property
+// (or component) it was defined as. If the schema is an array that is not wrapped, the name of the items is returned,
+// as that is the element that will be serialized.
+func (s *Schema) XMLName(name string) string {
+	n := s.newXMLNode(name)
+	if n.Array && !n.Wrapped {
+		return s.xmlItemName(name)
+	}
+	return n.QualifiedName()
+}
+
+// BuildXMLTree computes the effective XML serialization for this schema and every schema it contains (properties,
+// array items and allOf properties), the name supplied is used for the root element, unless the schema sets its
+// own xml.name. Circular schemas are flagged and not expanded.
+func (s *Schema) BuildXMLTree(name string) *XMLNode {
+	return s.buildXMLTree(name, "", nil, make(map[any]bool))
+}
+
+func (s *Schema) newXMLNode(name string) *XMLNode {
+	n := &XMLNode{Name: name, Schema: s}
+	if s.XML != nil {
+		if s.XML.Name != "" {
+			n.Name = s.XML.Name
+		}
+		n.Namespace = s.XML.Namespace
+		n.Prefix = s.XML.Prefix
+		n.Attribute = s.XML.Attribute
+	}
+	if s.isXMLArray() {
+		// arrays and objects can't be attributes.
+		n.Array = true
+		n.Attribute = false
+		n.Wrapped = s.XML != nil && s.XML.Wrapped
+	}
+	if len(s.Properties) > 0 {
+		n.Attribute = false
+	}
+	return n
+}
+
+func (s *Schema) buildXMLTree(name, property string, parent *XMLNode, onPath map[any]bool) *XMLNode {
+	n := s.newXMLNode(name)
+	n.Property = property
+	n.parent = parent
+
+	id := schemaIdentity(s)
+	if onPath[id] {
+		n.Circular = true
+		return n
+	}
+	onPath[id] = true
+	defer delete(onPath, id)
+
+	if n.Array {
+		if items := s.xmlItems(); items != nil {
+			// items are named after the array property, not the array xml name, unless they set their own name.
+			n.Items = items.buildXMLTree(name, "", n, onPath)
+			n.Items.Attribute = false
+		}
+		return n
+	}
+	for _, p := range s.xmlProperties() {
+		if sch := proxySchema(p.schema); sch != nil {
+			n.Children = append(n.Children, sch.buildXMLTree(p.name, p.name, n, onPath))
+		}
+	}
+	return n
+}
+
+func (s *Schema) isXMLArray() bool {
+	for _, t := range s.Type {
+		if t == "array" {
+			return true
+		}
+	}
+	return s.Items != nil && s.Items.IsA()
+}
+
+func (s *Schema) xmlItems() *Schema {
+	if s.Items != nil && s.Items.IsA() {
+		return proxySchema(s.Items.A)
+	}
+	return nil
+}
+
+func (s *Schema) xmlItemName(name string) string {
+	if items := s.xmlItems(); items != nil {
+		return items.newXMLNode(name).QualifiedName()
+	}
+	return name
+}
+
+type xmlProperty struct {
+	name   string
+	schema *SchemaProxy
+}
+
+// xmlProperties returns the properties of the schema (followed by those of any allOf schemas) in the order they
+// were defined in the document, or by name if the schema was not built from a document.
+func (s *Schema) xmlProperties() []xmlProperty {
+	var props []xmlProperty
+	seen := make(map[string]bool)
+	add := func(sch *Schema) {
+		for _, k := range orderedPropertyNames(sch) {
+			if !seen[k] {
+				seen[k] = true
+				props = append(props, xmlProperty{name: k, schema: sch.Properties[k]})
+			}
+		}
+	}
+	add(s)
+	for _, a := range s.AllOf {
+		if sch := proxySchema(a); sch != nil {
+			add(sch)
+		}
+	}
+	return props
+}
+
+func orderedPropertyNames(s *Schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	lines := make(map[string]int, len(s.Properties))
+	for k := range s.Properties {
+		names = append(names, k)
+	}
+	if l := s.GoLow(); l != nil {
+		for k := range l.Properties.Value {
+			if k.KeyNode != nil {
+				lines[k.Value] = k.KeyNode.Line
+			}
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if lines[names[i]] != lines[names[j]] {
+			return lines[names[i]] < lines[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
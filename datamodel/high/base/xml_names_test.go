@@ -0,0 +1,161 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"testing"
+
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema_XMLName(t *testing.T) {
+	yml := `type: object
+properties:
+  id:
+    type: integer
+    xml:
+      attribute: true
+  name:
+    type: string
+    xml:
+      name: petName
+      prefix: smp
+      namespace: http://example.com/schema/sample
+  plain:
+    type: string
+  animals:
+    type: array
+    items:
+      type: string
+      xml:
+        name: animal
+  aliens:
+    type: array
+    xml:
+      name: ufos
+      wrapped: true
+    items:
+      type: string
+  unnamed:
+    type: array
+    items:
+      type: string`
+
+	sch := getHighSchema(t, yml)
+	assert.Equal(t, "id", sch.Properties["id"].Schema().XMLName("id"))
+	assert.Equal(t, "smp:petName", sch.Properties["name"].Schema().XMLName("name"))
+	assert.Equal(t, "plain", sch.Properties["plain"].Schema().XMLName("plain"))
+	assert.Equal(t, "animal", sch.Properties["animals"].Schema().XMLName("animals"))
+	assert.Equal(t, "ufos", sch.Properties["aliens"].Schema().XMLName("aliens"))
+	assert.Equal(t, "unnamed", sch.Properties["unnamed"].Schema().XMLName("unnamed"))
+}
+
+func TestSchema_BuildXMLTree(t *testing.T) {
+	yml := `type: object
+xml:
+  name: animal
+properties:
+  id:
+    type: integer
+    xml:
+      attribute: true
+  name:
+    type: string
+    xml:
+      prefix: smp
+      namespace: http://example.com/schema/sample
+  aliens:
+    type: array
+    xml:
+      name: ufos
+      wrapped: true
+    items:
+      type: string
+      xml:
+        name: alien
+  tags:
+    type: array
+    items:
+      type: object
+      xml:
+        attribute: true
+      properties:
+        label:
+          type: string
+allOf:
+  - type: object
+    properties:
+      extra:
+        type: string
+      name:
+        type: string`
+
+	tree := getHighSchema(t, yml).BuildXMLTree("Pet")
+	assert.Equal(t, "animal", tree.Name)
+	assert.Nil(t, tree.Parent())
+	assert.Len(t, tree.Children, 5)
+
+	// properties are in the order they are defined, followed by allOf properties.
+	assert.Equal(t, "id", tree.Children[0].Property)
+	assert.Equal(t, "name", tree.Children[1].Property)
+	assert.Equal(t, "aliens", tree.Children[2].Property)
+	assert.Equal(t, "tags", tree.Children[3].Property)
+	assert.Equal(t, "extra", tree.Children[4].Property)
+
+	attrs := tree.Attributes()
+	assert.Len(t, attrs, 1)
+	assert.Equal(t, "id", attrs[0].Name)
+	assert.Len(t, tree.Elements(), 4)
+
+	name := tree.Children[1]
+	assert.Equal(t, "smp:name", name.QualifiedName())
+	assert.Equal(t, "http://example.com/schema/sample", name.Namespace)
+	assert.Equal(t, tree, name.Parent())
+
+	aliens := tree.Children[2]
+	assert.True(t, aliens.Array)
+	assert.True(t, aliens.Wrapped)
+	assert.Equal(t, "ufos", aliens.Name)
+	assert.Equal(t, "alien", aliens.Items.Name)
+
+	// items without a name are named after the property, objects are never attributes.
+	tags := tree.Children[3]
+	assert.False(t, tags.Wrapped)
+	assert.Equal(t, "tags", tags.Items.Name)
+	assert.False(t, tags.Items.Attribute)
+	assert.Len(t, tags.Items.Children, 1)
+}
+
+func TestSchema_BuildXMLTree_Circular(t *testing.T) {
+	spec := `components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'`
+
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &node))
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+	_, schNode := utils.FindKeyNodeTop("Node", idx.GetSchemasNode().Content)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(schNode, idx))
+	sch := NewSchemaProxy(&lowmodel.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: schNode}).Schema()
+
+	tree := sch.BuildXMLTree("Node")
+	children := tree.Children[0]
+	assert.True(t, children.Array)
+	assert.Equal(t, "children", children.Items.Name)
+	assert.True(t, children.Items.Circular)
+	assert.Nil(t, children.Items.Children)
+}
@@ -241,6 +241,20 @@ func (n *NodeBuilder) renderReference() []*yaml.Node {
 	nodes := make([]*yaml.Node, 2)
 	nodes[0] = utils.CreateStringNode("$ref")
 	nodes[1] = utils.CreateStringNode(fg.GetReference())
+	return append(nodes, renderReferenceSiblings(fg)...)
+}
+
+// renderReferenceSiblings will render any 'summary' or 'description' that sits alongside a $ref (OpenAPI 3.1+).
+func renderReferenceSiblings(ref any) []*yaml.Node {
+	var nodes []*yaml.Node
+	if rs, ok := ref.(low.HasReferenceSiblings); ok {
+		if s := rs.GetReferenceSummary(); !s.IsEmpty() {
+			nodes = append(nodes, utils.CreateStringNode("summary"), utils.CreateStringNode(s.Value))
+		}
+		if d := rs.GetReferenceDescription(); !d.IsEmpty() {
+			nodes = append(nodes, utils.CreateStringNode("description"), utils.CreateStringNode(d.Value))
+		}
+	}
 	return nodes
 }
 
@@ -441,6 +455,7 @@ func (n *NodeBuilder) AddYAMLNode(parent *yaml.Node, entry *NodeEntry) *yaml.Nod
 							ut.(low.IsReferenced).IsReference() {
 							if !n.Resolve {
 								refNode := utils.CreateRefNode(glu.GoLowUntyped().(low.IsReferenced).GetReference())
+								refNode.Content = append(refNode.Content, renderReferenceSiblings(ut)...)
 								sl.Content = append(sl.Content, refNode)
 								skip = true
 							} else {
@@ -519,6 +534,7 @@ func (n *NodeBuilder) AddYAMLNode(parent *yaml.Node, entry *NodeEntry) *yaml.Nod
 								rvn := utils.CreateEmptyMapNode()
 								rvn.Content = append(rvn.Content, utils.CreateStringNode("$ref"))
 								rvn.Content = append(rvn.Content, utils.CreateStringNode(gl.GoLowUntyped().(low.IsReferenced).GetReference()))
+								rvn.Content = append(rvn.Content, renderReferenceSiblings(gl.GoLowUntyped())...)
 								valueNode = rvn
 								break
 							}
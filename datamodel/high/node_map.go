@@ -0,0 +1,95 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"reflect"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"gopkg.in/yaml.v3"
+)
+
+// FieldNodes holds the YAML nodes of a single field of an object, as found in the document.
+type FieldNodes struct {
+	Field     string     // the name of the field of the low-level model, like 'URL' or 'Extensions'.
+	KeyNode   *yaml.Node // the key of the field in the document, like 'url'.
+	ValueNode *yaml.Node // the value of the field in the document.
+}
+
+// NodeMap holds the YAML nodes of every field set on an object, by the key used in the document (like 'description'
+// or 'x-internal').
+type NodeMap map[string]*FieldNodes
+
+// KeyNode returns the key node of a field, or nil if the field is not set.
+func (m NodeMap) KeyNode(key string) *yaml.Node {
+	if f := m[key]; f != nil {
+		return f.KeyNode
+	}
+	return nil
+}
+
+// ValueNode returns the value node of a field, or nil if the field is not set.
+func (m NodeMap) ValueNode(key string) *yaml.Node {
+	if f := m[key]; f != nil {
+		return f.ValueNode
+	}
+	return nil
+}
+
+// GetNodeMap returns the YAML nodes of every field set on any high-level object, including extensions, so tooling
+// can point at the exact line of a field (like the 'url' of an ExternalDoc) rather than the whole object. Fields
+// that are not in the document are not in the map.
+//
+// Returns nil if the object was not built from a document.
+func GetNodeMap(obj any) NodeMap {
+	v := reflect.ValueOf(goLow(obj))
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	m := make(NodeMap)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		if field.Name == "Extensions" {
+			if ext, ok := fv.Interface().(map[low.KeyReference[string]]low.ValueReference[any]); ok {
+				for k, e := range ext {
+					if k.KeyNode != nil {
+						m[k.Value] = &FieldNodes{Field: field.Name, KeyNode: k.KeyNode, ValueNode: e.ValueNode}
+					}
+				}
+			}
+			continue
+		}
+		if fv.Kind() != reflect.Struct {
+			continue
+		}
+		keyNode, ok := nodeField(fv, "KeyNode")
+		if !ok || keyNode == nil {
+			continue
+		}
+		valueNode, _ := nodeField(fv, "ValueNode")
+		m[keyNode.Value] = &FieldNodes{Field: field.Name, KeyNode: keyNode, ValueNode: valueNode}
+	}
+	return m
+}
+
+// nodeField returns the value of a *yaml.Node field of a struct.
+func nodeField(v reflect.Value, name string) (*yaml.Node, bool) {
+	f := v.FieldByName(name)
+	if !f.IsValid() {
+		return nil, false
+	}
+	n, ok := f.Interface().(*yaml.Node)
+	return n, ok
+}
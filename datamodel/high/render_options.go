@@ -0,0 +1,202 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RenderFormat is the format a model is rendered in.
+type RenderFormat int
+
+const (
+	// RenderYAML renders YAML, this is the default.
+	RenderYAML RenderFormat = iota
+
+	// RenderJSON renders JSON. Keys keep the same order as they are rendered in YAML.
+	RenderJSON
+)
+
+// QuoteStyle is how strings are quoted when rendered as YAML.
+type QuoteStyle int
+
+const (
+	// QuotePreserve keeps the quoting used by the original document, strings added to the model are only quoted
+	// when they need to be. This is the default.
+	QuotePreserve QuoteStyle = iota
+
+	// QuoteMinimal only quotes strings that need to be quoted (like '200', or 'true').
+	QuoteMinimal
+
+	// QuoteSingle quotes every string with single quotes.
+	QuoteSingle
+
+	// QuoteDouble quotes every string with double quotes.
+	QuoteDouble
+)
+
+// RenderOptions controls how a model is rendered (see RenderNode). The zero value renders YAML the same way as
+// the Render method of a model does.
+type RenderOptions struct {
+	// Format is the format to render, YAML or JSON.
+	Format RenderFormat
+
+	// Indent is the number of spaces each level is indented by. When zero, YAML is indented by four spaces and JSON
+	// is rendered compact (on a single line).
+	Indent int
+
+	// LineWidth is the width that long strings are wrapped at when rendered as YAML. Strings are only wrapped at
+	// spaces, and are rendered as folded block scalars (>-) so the value does not change. When zero, lines are
+	// not wrapped.
+	LineWidth int
+
+	// QuoteStyle is how string values are quoted when rendered as YAML. Strings over multiple lines are always
+	// rendered as literal block scalars.
+	QuoteStyle QuoteStyle
+
+	// KeyQuoteStyle is how keys are quoted when rendered as YAML.
+	KeyQuoteStyle QuoteStyle
+
+	// Source is the YAML specification the model was built from. When set, the parts of the source that have not
+	// changed are copied byte-for-byte (including comments and formatting), and only the parts that have changed
+	// are rendered (using the other options), so diffs of the rendered model are as small as possible. Sources in
+	// JSON, or that use anchors, are rendered in full.
+	Source []byte
+}
+
+// RenderNode renders a YAML node (like the node created by NodeBuilder.Render) using the render options. A nil
+// options renders the same as the zero value. The node is not changed.
+func RenderNode(node *yaml.Node, options *RenderOptions) ([]byte, error) {
+	if options == nil {
+		options = &RenderOptions{}
+	}
+	if options.Format == RenderJSON {
+		return utils.ConvertYAMLNodeToJSON(node, strings.Repeat(" ", options.Indent))
+	}
+	if options.QuoteStyle != QuotePreserve || options.KeyQuoteStyle != QuotePreserve || options.LineWidth > 0 {
+		node = utils.CopyNode(node)
+		applyRenderStyles(node, options, false)
+	}
+	if len(options.Source) > 0 {
+		return renderPreservingSource(node, options)
+	}
+	return renderYAML(node, options)
+}
+
+func renderYAML(node *yaml.Node, options *RenderOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&buf)
+	indent := options.Indent
+	if indent <= 0 {
+		indent = 4
+	}
+	yamlEncoder.SetIndent(indent)
+	if err := yamlEncoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if options.LineWidth > 0 {
+		return wrapFoldedScalars(buf.Bytes(), options.LineWidth), nil
+	}
+	return buf.Bytes(), nil
+}
+
+// applyRenderStyles sets the style of every string in node (and below it) from the render options.
+func applyRenderStyles(node *yaml.Node, options *RenderOptions, key bool) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			applyRenderStyles(n, options, false)
+		}
+	case yaml.MappingNode:
+		for i, n := range node.Content {
+			applyRenderStyles(n, options, i%2 == 0)
+		}
+	case yaml.ScalarNode:
+		if node.ShortTag() != "!!str" || strings.Contains(node.Value, "\n") {
+			return
+		}
+		quoteStyle := options.QuoteStyle
+		if key {
+			quoteStyle = options.KeyQuoteStyle
+		}
+		switch quoteStyle {
+		case QuoteMinimal:
+			node.Style = 0
+		case QuoteSingle:
+			node.Style = yaml.SingleQuotedStyle
+		case QuoteDouble:
+			node.Style = yaml.DoubleQuotedStyle
+		}
+		if !key && options.LineWidth > 0 && len(node.Value) > options.LineWidth && canFold(node.Value) {
+			node.Style = yaml.FoldedStyle
+		}
+	}
+}
+
+// canFold returns true if a string can be rendered as a folded block scalar and wrapped, without changing it.
+func canFold(value string) bool {
+	return strings.Contains(value, " ") && strings.TrimSpace(value) == value && !strings.Contains(value, "  ") &&
+		!strings.ContainsAny(value, "\t\r")
+}
+
+// foldedIndicator matches a line that starts a folded block scalar.
+var foldedIndicator = regexp.MustCompile(`(^|[:-] )>[-+]?$`)
+
+// wrapFoldedScalars breaks the lines of folded block scalars in rendered YAML that are longer than width, at a
+// space. A single line break within a folded block scalar is read back as a space, so the value does not change.
+func wrapFoldedScalars(rendered []byte, width int) []byte {
+	lines := strings.Split(string(rendered), "\n")
+	wrapped := make([]string, 0, len(lines))
+	blockIndent := -1 // the indentation of the folded block scalar being wrapped, -1 when not in one.
+	parentIndent := 0
+	for _, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if blockIndent >= 0 {
+			if strings.TrimSpace(line) != "" && indent <= parentIndent {
+				blockIndent = -1
+			} else if blockIndent == 0 && strings.TrimSpace(line) != "" {
+				blockIndent = indent
+			}
+		}
+		if blockIndent > 0 && indent == blockIndent {
+			wrapped = append(wrapped, wrapLine(line, blockIndent, width)...)
+			continue
+		}
+		if blockIndent < 0 && foldedIndicator.MatchString(line) {
+			blockIndent = 0
+			parentIndent = indent
+		}
+		wrapped = append(wrapped, line)
+	}
+	return []byte(strings.Join(wrapped, "\n"))
+}
+
+// wrapLine breaks a line of a folded block scalar into lines no longer than width (where possible). Lines are
+// only broken at a single space, so the break is read back as that space.
+func wrapLine(line string, indent, width int) []string {
+	var lines []string
+	prefix := line[:indent]
+	for len(line) > width {
+		split := -1
+		for i := indent + 1; i < len(line)-1; i++ {
+			if line[i] == ' ' && line[i-1] != ' ' && line[i+1] != ' ' {
+				if i > width && split > 0 {
+					break
+				}
+				split = i
+			}
+		}
+		if split < 0 {
+			break
+		}
+		lines = append(lines, line[:split])
+		line = prefix + line[split+1:]
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderNode(t *testing.T) {
+	yml := `"name": 'pizza'
+code: "200"
+tags:
+  - hot
+description: |
+  two
+  lines`
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	rendered, err := RenderNode(&node, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "\"name\": 'pizza'\ncode: \"200\"\ntags:\n    - hot\ndescription: |-\n    two\n    lines\n",
+		string(rendered))
+
+	rendered, err = RenderNode(&node, &RenderOptions{Indent: 2, QuoteStyle: QuoteDouble, KeyQuoteStyle: QuoteMinimal})
+	assert.NoError(t, err)
+	assert.Equal(t, "name: \"pizza\"\ncode: \"200\"\ntags:\n  - \"hot\"\ndescription: |-\n  two\n  lines\n",
+		string(rendered))
+
+	rendered, err = RenderNode(&node, &RenderOptions{QuoteStyle: QuoteMinimal, KeyQuoteStyle: QuoteSingle})
+	assert.NoError(t, err)
+	assert.Equal(t, "'name': pizza\n'code': \"200\"\n'tags':\n    - hot\n'description': |-\n    two\n    lines\n",
+		string(rendered))
+
+	rendered, err = RenderNode(&node, &RenderOptions{Format: RenderJSON, Indent: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n \"name\": \"pizza\",\n \"code\": \"200\",\n \"tags\": [\n  \"hot\"\n ],\n"+
+		" \"description\": \"two\\nlines\"\n}", string(rendered))
+
+	// the node is not changed.
+	assert.Equal(t, yaml.DoubleQuotedStyle, node.Content[0].Content[0].Style)
+	assert.Equal(t, yaml.SingleQuotedStyle, node.Content[0].Content[1].Style)
+}
+
+func TestRenderNode_LineWidth(t *testing.T) {
+	yml := `description: the quick brown fox jumps over the lazy dog and keeps on running
+short: not wrapped
+list:
+  - a list item that is long enough to be wrapped
+spaced: two  spaces stop  this from  being wrapped at all`
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	rendered, err := RenderNode(&node, &RenderOptions{Indent: 2, LineWidth: 20})
+	assert.NoError(t, err)
+	assert.Equal(t, `description: >-
+  the quick brown
+  fox jumps over the
+  lazy dog and keeps
+  on running
+short: not wrapped
+list:
+  - >-
+    a list item that
+    is long enough
+    to be wrapped
+spaced: two  spaces stop  this from  being wrapped at all
+`, string(rendered))
+
+	// wrapping does not change the values.
+	var wrapped, original map[string]any
+	_ = yaml.Unmarshal(rendered, &wrapped)
+	_ = yaml.Unmarshal([]byte(yml), &original)
+	assert.Equal(t, original, wrapped)
+}
+
+func TestWrapLine(t *testing.T) {
+	assert.Equal(t, []string{"  pizza", "  burger"}, wrapLine("  pizza burger", 2, 8))
+	assert.Equal(t, []string{"  pizzaburger", "  fries"}, wrapLine("  pizzaburger fries", 2, 8))
+	assert.Equal(t, []string{"  pizzaburgerfries"}, wrapLine("  pizzaburgerfries", 2, 8))
+}
@@ -0,0 +1,252 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"bytes"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sourceRenderer renders a node by copying the lines of the source document the node was built from, where the
+// node has not changed, and only rendering the parts of the node that have. The lines of each key/value pair in
+// the source are found from the positions of the nodes parsed from the source.
+type sourceRenderer struct {
+	lines   []string // the lines of the source, including line endings.
+	options *RenderOptions
+	indent  int
+	out     bytes.Buffer
+}
+
+// renderPreservingSource renders node as YAML, keeping everything in options.Source that has not changed as it
+// is, byte-for-byte. Sources that cannot be copied from (JSON, flow style or anchored YAML) are rendered in full.
+func renderPreservingSource(node *yaml.Node, options *RenderOptions) ([]byte, error) {
+	var source yaml.Node
+	if err := yaml.Unmarshal(options.Source, &source); err != nil {
+		return renderYAML(node, options)
+	}
+	original, updated := rootMapping(&source), rootMapping(node)
+	if original == nil || updated == nil || original.Style&yaml.FlowStyle != 0 || len(original.Content) == 0 ||
+		hasAnchors(&source) {
+		return renderYAML(node, options)
+	}
+	r := &sourceRenderer{
+		lines:   strings.SplitAfter(string(options.Source), "\n"),
+		options: options,
+		indent:  sourceIndent(original, options),
+	}
+	// everything before the first key (comments, document markers) is kept.
+	r.copyLines(0, original.Content[0].Line-1)
+	if err := r.renderMapping(original, updated, len(r.lines)); err != nil {
+		return nil, err
+	}
+	return r.out.Bytes(), nil
+}
+
+// renderMapping renders the pairs of updated, copying the lines of the pairs in original that have not changed.
+// The lines of the last pair of original run up to the end line.
+func (r *sourceRenderer) renderMapping(original, updated *yaml.Node, end int) error {
+	pairs := len(original.Content) / 2
+	starts := make([]int, pairs+1)
+	keys := make(map[string]int, pairs)
+	for i := 0; i < pairs; i++ {
+		starts[i] = r.pairStart(original, i)
+		if _, ok := keys[original.Content[i*2].Value]; !ok {
+			keys[original.Content[i*2].Value] = i
+		}
+	}
+	starts[pairs] = end
+	column := original.Content[0].Column - 1
+
+	for j := 0; j+1 < len(updated.Content); j += 2 {
+		key, value := updated.Content[j], updated.Content[j+1]
+		i, found := keys[key.Value]
+		if !found {
+			if err := r.writePair(key, value, column); err != nil {
+				return err
+			}
+			continue
+		}
+		originalKey, originalValue := original.Content[i*2], original.Content[i*2+1]
+		switch {
+		case nodesEqual(originalValue, value):
+			r.copyLines(starts[i], starts[i+1])
+		case isBlockMapping(originalValue) && value.Kind == yaml.MappingNode && len(value.Content) > 0:
+			// the key, and anything before the first key of the value (like comments) is kept.
+			r.copyLines(starts[i], originalValue.Content[0].Line-1)
+			if err := r.renderMapping(originalValue, value, starts[i+1]); err != nil {
+				return err
+			}
+		default:
+			r.copyLines(starts[i], originalKey.Line-1) // comments above the key are kept.
+			if err := r.writePair(key, value, column); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pairStart returns the first line of a pair in a mapping, which includes the comments and blank lines above the
+// key, as long as they can't be part of the value of the pair before.
+func (r *sourceRenderer) pairStart(mapping *yaml.Node, pair int) int {
+	key := mapping.Content[pair*2]
+	start := key.Line - 1
+	if pair == 0 {
+		return start
+	}
+	previousKey, previousValue := mapping.Content[pair*2-2], mapping.Content[pair*2-1]
+	if previousValue.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return start
+	}
+	for start-1 >= previousKey.Line {
+		line := r.lines[start-1]
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if trimmed != "" && (!strings.HasPrefix(trimmed, "#") || indent > key.Column-1) {
+			break
+		}
+		start--
+	}
+	return start
+}
+
+// copyLines copies the source lines from start, up to (but not including) end.
+func (r *sourceRenderer) copyLines(start, end int) {
+	if start < 0 || end > len(r.lines) {
+		return
+	}
+	for _, line := range r.lines[start:end] {
+		r.out.WriteString(line)
+	}
+}
+
+// writePair renders a single key/value pair, indented to column.
+func (r *sourceRenderer) writePair(key, value *yaml.Node, column int) error {
+	var buf bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&buf)
+	yamlEncoder.SetIndent(r.indent)
+	if err := yamlEncoder.Encode(&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{key, value}}); err != nil {
+		return err
+	}
+	if r.out.Len() > 0 && !bytes.HasSuffix(r.out.Bytes(), []byte("\n")) {
+		r.out.WriteByte('\n')
+	}
+	prefix := strings.Repeat(" ", column)
+	var pair strings.Builder
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if strings.TrimSpace(line) != "" {
+			pair.WriteString(prefix)
+		}
+		pair.WriteString(line)
+	}
+	if r.options.LineWidth > 0 {
+		r.out.Write(wrapFoldedScalars([]byte(pair.String()), r.options.LineWidth))
+		return nil
+	}
+	r.out.WriteString(pair.String())
+	return nil
+}
+
+// sourceIndent returns the indentation used to render changed parts of the source, the indentation of the source
+// unless the options say otherwise.
+func sourceIndent(root *yaml.Node, options *RenderOptions) int {
+	if options.Indent > 0 {
+		return options.Indent
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if value := root.Content[i+1]; isBlockMapping(value) && len(value.Content) > 0 {
+			if indent := value.Content[0].Column - root.Content[i].Column; indent > 0 {
+				return indent
+			}
+		}
+	}
+	return 4
+}
+
+func rootMapping(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	return node
+}
+
+func isBlockMapping(node *yaml.Node) bool {
+	return node.Kind == yaml.MappingNode && node.Style&yaml.FlowStyle == 0 && len(node.Content) > 0
+}
+
+// hasAnchors returns true if there are any anchors or aliases in the node (or below it), copying part of a source
+// with anchors could copy an alias without the anchor it points to.
+func hasAnchors(node *yaml.Node) bool {
+	if node.Anchor != "" || node.Kind == yaml.AliasNode {
+		return true
+	}
+	for _, n := range node.Content {
+		if hasAnchors(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodesEqual returns true if a node rendered from a model (b) has the same values as the source node it was built
+// from (a). Styles, tags, comments and the order of keys are not compared, and neither are empty values in the
+// source that a model doesn't keep (like an empty description or required: false), or the duplicates of a key, so a
+// model that has not changed is equal to its source.
+func nodesEqual(a, b *yaml.Node) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	switch a.Kind {
+	case yaml.ScalarNode:
+		return a.Value == b.Value
+	case yaml.MappingNode:
+		values := make(map[string]*yaml.Node, len(b.Content)/2)
+		for i := 0; i+1 < len(b.Content); i += 2 {
+			values[b.Content[i].Value] = b.Content[i+1]
+		}
+		found := 0
+		seen := make(map[string]bool, len(a.Content)/2)
+		for i := 0; i+1 < len(a.Content); i += 2 {
+			if seen[a.Content[i].Value] {
+				continue // models only read the first of duplicate keys.
+			}
+			seen[a.Content[i].Value] = true
+			value, ok := values[a.Content[i].Value]
+			if !ok {
+				if !emptyNode(a.Content[i+1]) {
+					return false
+				}
+				continue
+			}
+			if !nodesEqual(a.Content[i+1], value) {
+				return false
+			}
+			found++
+		}
+		return found == len(values)
+	}
+	if len(a.Content) != len(b.Content) {
+		return false
+	}
+	for i := range a.Content {
+		if !nodesEqual(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// emptyNode returns true if a node holds an empty value: null, an empty string, false, or an empty sequence or
+// mapping.
+func emptyNode(node *yaml.Node) bool {
+	if node.Kind == yaml.ScalarNode {
+		return node.Tag == "!!null" || node.Value == "" || (node.Tag == "!!bool" && node.Value == "false")
+	}
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && len(node.Content) == 0
+}
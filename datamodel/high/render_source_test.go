@@ -0,0 +1,120 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderNode_Source(t *testing.T) {
+	source := `# the best spec
+openapi:   3.1.0
+info:
+  title: "pizza"  # keep me
+  # all about the toppings
+  description: |
+    # not a comment
+    toppings
+  contact: {name: chef}
+
+# the menu
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      tags: [one, two]
+  /burger:
+    get:
+      operationId: getBurger
+`
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(source), &node)
+
+	// nothing has changed, so nothing changes.
+	rendered, err := RenderNode(&node, &RenderOptions{Source: []byte(source)})
+	assert.NoError(t, err)
+	assert.Equal(t, source, string(rendered))
+
+	root := node.Content[0]
+	info, paths := root.Content[3], root.Content[5]
+	info.Content[1].Value = "burgers" // title
+	paths.Content[1].Content[1].Content[3].Content[0].Value = "three"
+	paths.Content = paths.Content[:2] // remove /burger
+	root.Content = append(root.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: "x-added"},
+		&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "fries"}, {Kind: yaml.ScalarNode, Value: "yes", Style: yaml.DoubleQuotedStyle},
+		}})
+
+	rendered, err = RenderNode(&node, &RenderOptions{Source: []byte(source)})
+	assert.NoError(t, err)
+	assert.Equal(t, `# the best spec
+openapi:   3.1.0
+info:
+  title: "burgers" # keep me
+  # all about the toppings
+  description: |
+    # not a comment
+    toppings
+  contact: {name: chef}
+
+# the menu
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      tags: [three, two]
+x-added:
+  fries: "yes"
+`, string(rendered))
+
+	// changed parts use the render options.
+	rendered, err = RenderNode(&node, &RenderOptions{Source: []byte(source), Indent: 4, QuoteStyle: QuoteSingle})
+	assert.NoError(t, err)
+	assert.Contains(t, string(rendered), "  title: 'burgers' # keep me\n")
+	assert.Contains(t, string(rendered), "openapi:   3.1.0\n")
+	assert.Contains(t, string(rendered), "x-added:\n    fries: 'yes'\n")
+}
+
+func TestRenderNode_SourceRenderedInFull(t *testing.T) {
+	for _, source := range []string{
+		`{"openapi": "3.1.0", "info": {"title": "pizza"}}`,
+		"openapi: 3.1.0\ninfo: &info\n  title: pizza\nx-info: *info\n",
+		"- not\n- a\n- mapping\n",
+		"{{{broken",
+	} {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte("openapi:    3.1.0\n"), &node)
+		rendered, err := RenderNode(&node, &RenderOptions{Source: []byte(source)})
+		assert.NoError(t, err)
+		assert.Equal(t, "openapi: 3.1.0\n", string(rendered))
+	}
+}
+
+func TestNodesEqual(t *testing.T) {
+	var a, b yaml.Node
+	_ = yaml.Unmarshal([]byte("pizza: {size: 'large', toppings: [cheese]}"), &a)
+	_ = yaml.Unmarshal([]byte("pizza:\n  size: large\n  toppings:\n    - cheese"), &b)
+	assert.True(t, nodesEqual(&a, &b))
+	_ = yaml.Unmarshal([]byte("pizza:\n  toppings:\n    - cheese\n  size: large"), &b)
+	assert.True(t, nodesEqual(&a, &b)) // the order of keys doesn't matter.
+	_ = yaml.Unmarshal([]byte("pizza:\n  toppings:\n    - ham\n  size: large"), &b)
+	assert.False(t, nodesEqual(&a, &b))
+	_ = yaml.Unmarshal([]byte("pizza:\n  toppings:\n    - cheese\n    - ham\n  size: large"), &b)
+	assert.False(t, nodesEqual(&a, &b))
+
+	// empty values in the source aren't kept by models, neither are duplicate keys.
+	_ = yaml.Unmarshal([]byte("pizza: {size: large, toppings: [cheese], description: '', hot: false, sides: []}"), &a)
+	_ = yaml.Unmarshal([]byte("pizza:\n  size: large\n  toppings:\n    - cheese"), &b)
+	assert.True(t, nodesEqual(&a, &b))
+	_ = yaml.Unmarshal([]byte("pizza: {size: large, toppings: [cheese], size: small}"), &a)
+	assert.True(t, nodesEqual(&a, &b))
+	_ = yaml.Unmarshal([]byte("pizza: {size: large, toppings: [cheese], hot: true}"), &a)
+	assert.False(t, nodesEqual(&a, &b))
+	_ = yaml.Unmarshal([]byte("pizza: {size: large}"), &a)
+	assert.False(t, nodesEqual(&a, &b))
+}
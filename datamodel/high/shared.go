@@ -14,7 +14,11 @@
 package high
 
 import (
+	"reflect"
+	"sort"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
 )
 
 // GoesLow is used to represent any high-level model. All high level models meet this interface and can be used to
@@ -76,3 +80,82 @@ func UnpackExtensions[T any, R low.HasExtensions[T]](low GoesLow[R]) (map[string
 	}
 	return m, nil
 }
+
+// GetOrigin returns the origin of any high-level object, the absolute location of the document (file or URL)
+// it was read from, and the position it was found at. Objects located by following a $ref into another document,
+// originate from that document. Returns nil if the object was not built from a document, or if the origin is unknown.
+func GetOrigin(obj any) *index.NodeOrigin {
+	if o, ok := goLow(obj).(low.HasOrigin); ok {
+		if v := reflect.ValueOf(o); v.Kind() == reflect.Pointer && v.IsNil() {
+			return nil
+		}
+		return o.GetOrigin()
+	}
+	return nil
+}
+
+// goLow returns the low-level object of any high-level object, or nil if it's not a high-level object.
+func goLow(obj any) any {
+	if obj == nil {
+		return nil
+	}
+	switch h := obj.(type) {
+	case GoesLowUntyped:
+		return h.GoLowUntyped()
+	default:
+		// v2 models only carry a typed GoLow method.
+		m := reflect.ValueOf(obj).MethodByName("GoLow")
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			return nil
+		}
+		if v := reflect.ValueOf(obj); v.Kind() == reflect.Pointer && v.IsNil() {
+			return nil
+		}
+		return m.Call(nil)[0].Interface()
+	}
+}
+
+// ResponseKeys returns the keys of a Responses object (every response code, 'default' if there is a default
+// response and every extension), in the order of the keys found in the document (lowKeys). Codes, the default and
+// extensions added after building go last, in that order (sorted). Keys that have since been removed are left out.
+func ResponseKeys[C, E any](lowKeys []low.KeyReference[string], codes map[string]C, hasDefault bool,
+	extensions map[string]E) []string {
+	has := func(key string) bool {
+		if key == "default" {
+			return hasDefault
+		}
+		if _, ok := codes[key]; ok {
+			return true
+		}
+		_, ok := extensions[key]
+		return ok
+	}
+	var keys []string
+	seen := make(map[string]bool)
+	add := func(key string) {
+		if !seen[key] && has(key) {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	for _, k := range lowKeys {
+		add(k.Value)
+	}
+	for _, code := range sortedKeys(codes) {
+		add(code)
+	}
+	add("default")
+	for _, ext := range sortedKeys(extensions) {
+		add(ext)
+	}
+	return keys
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -128,3 +128,98 @@ power: hello`
 	assert.Error(t, er)
 	assert.Empty(t, res)
 }
+
+type originChild struct {
+	*low.Reference
+}
+
+type originParent struct {
+	low *originChild
+}
+
+func (p *originParent) GoLow() *originChild {
+	return p.low
+}
+
+type originParentUntyped struct {
+	originParent
+}
+
+func (p *originParentUntyped) GoLowUntyped() any {
+	return p.low
+}
+
+func TestGetOrigin(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("cowboy:\n  power: hello"), &node)
+
+	c := &originChild{Reference: new(low.Reference)}
+	c.SetOrigin(node.Content[0].Content[1], nil)
+
+	assert.Equal(t, 2, GetOrigin(&originParent{low: c}).Line)
+	assert.Equal(t, 2, GetOrigin(&originParentUntyped{originParent{low: c}}).Line)
+
+	assert.Nil(t, GetOrigin(nil))
+	assert.Nil(t, GetOrigin(&originParent{}))
+	assert.Nil(t, GetOrigin((*originParent)(nil)))
+	assert.Nil(t, GetOrigin(&parent{low: new(child)}))
+	assert.Nil(t, GetOrigin("not a model"))
+}
+
+type nodeMapChild struct {
+	*low.Reference
+	Name       low.NodeReference[string]
+	Missing    low.NodeReference[string]
+	Extensions map[low.KeyReference[string]]low.ValueReference[any]
+	hidden     low.NodeReference[string]
+}
+
+type nodeMapParent struct {
+	low *nodeMapChild
+}
+
+func (p *nodeMapParent) GoLow() *nodeMapChild {
+	return p.low
+}
+
+func TestGetNodeMap(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("name: pizza\nx-hot: true"), &node)
+	c := &nodeMapChild{
+		Name: low.NodeReference[string]{Value: "pizza", KeyNode: node.Content[0].Content[0],
+			ValueNode: node.Content[0].Content[1]},
+		Extensions: map[low.KeyReference[string]]low.ValueReference[any]{
+			{Value: "x-hot", KeyNode: node.Content[0].Content[2]}: {Value: true, ValueNode: node.Content[0].Content[3]},
+			{Value: "x-built"}: {Value: true},
+		},
+		hidden: low.NodeReference[string]{KeyNode: node.Content[0].Content[0]},
+	}
+
+	m := GetNodeMap(&nodeMapParent{low: c})
+	assert.Len(t, m, 2)
+	assert.Equal(t, "Name", m["name"].Field)
+	assert.Equal(t, "pizza", m.ValueNode("name").Value)
+	assert.Equal(t, 2, m.KeyNode("x-hot").Line)
+	assert.Nil(t, m.KeyNode("missing"))
+	assert.Nil(t, m.ValueNode("missing"))
+
+	assert.Nil(t, GetNodeMap(nil))
+	assert.Nil(t, GetNodeMap(&nodeMapParent{}))
+	assert.Nil(t, GetNodeMap("not a model"))
+}
+
+func TestResponseKeys(t *testing.T) {
+	lowKeys := []low.KeyReference[string]{{Value: "404"}, {Value: "x-notes"}, {Value: "default"}, {Value: "200"},
+		{Value: "500"}}
+	codes := map[string]int{"404": 1, "200": 2, "201": 3, "204": 4}
+	extensions := map[string]any{"x-notes": "tidy", "x-added": true}
+
+	// 500 has been removed, 201, 204 and x-added have been added.
+	assert.Equal(t, []string{"404", "x-notes", "default", "200", "201", "204", "x-added"},
+		ResponseKeys(lowKeys, codes, true, extensions))
+
+	// without a default response, and without a low-level model.
+	assert.Equal(t, []string{"200", "201", "204", "404", "x-added", "x-notes"},
+		ResponseKeys(nil, codes, false, extensions))
+	assert.Empty(t, ResponseKeys[int, any](lowKeys, nil, false, nil))
+}
@@ -0,0 +1,265 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Swagger 2 parameters and headers that hold an array (type 'array') serialize it as a single string, joining the
+// items with the separator of the collection format. Query and form parameters can also use the 'multi' format,
+// which sends each item as a separate value (like 'id=1&id=2'). The items of an array can be arrays themselves,
+// serialized using the collection format of the items object.
+//   - https://swagger.io/specification/v2/#parameterObject
+const (
+	CollectionFormatCSV   = "csv"   // comma separated values, 'foo,bar'. The default.
+	CollectionFormatSSV   = "ssv"   // space separated values, 'foo bar'.
+	CollectionFormatTSV   = "tsv"   // tab separated values, 'foo\tbar'.
+	CollectionFormatPipes = "pipes" // pipe separated values, 'foo|bar'.
+	CollectionFormatMulti = "multi" // a separate value for each item, 'foo=bar&foo=baz'.
+)
+
+// CollectionSeparator returns the separator used to join the items of an array with a collection format. An empty
+// format is 'csv'. Returns an error for 'multi' (which does not join items) and unknown formats.
+func CollectionSeparator(format string) (string, error) {
+	switch format {
+	case "", CollectionFormatCSV:
+		return ",", nil
+	case CollectionFormatSSV:
+		return " ", nil
+	case CollectionFormatTSV:
+		return "\t", nil
+	case CollectionFormatPipes:
+		return "|", nil
+	case CollectionFormatMulti:
+		return "", fmt.Errorf("collection format 'multi' does not join items with a separator")
+	}
+	return "", fmt.Errorf("collection format '%s' is not supported, expected csv, ssv, tsv, pipes or multi", format)
+}
+
+// SerializeValue serializes a value of the parameter into strings, ready to send in a request. Arrays are joined
+// using the collection format of the parameter (and its items), into a single string. With the 'multi' format
+// (only allowed for query and formData parameters) there is a string for each item, to be sent as separate values.
+func (p *Parameter) SerializeValue(value any) ([]string, error) {
+	return serializeValue(p.Type, p.CollectionFormat, p.Items, p.In == "query" || p.In == "formData", value)
+}
+
+// DeserializeValue parses the values of the parameter, as received in a request, into a value. Scalars are parsed
+// using the type of the parameter, arrays are split using the collection format of the parameter (and its items)
+// into a []any. Only the 'multi' format uses more than one value.
+func (p *Parameter) DeserializeValue(values []string) (any, error) {
+	return deserializeValue(p.Type, p.CollectionFormat, p.Items, p.In == "query" || p.In == "formData", values)
+}
+
+// SerializeValue serializes a value of the header into a string, see Parameter.SerializeValue. Headers can't use
+// the 'multi' format.
+func (h *Header) SerializeValue(value any) (string, error) {
+	values, err := serializeValue(h.Type, h.CollectionFormat, h.Items, false, value)
+	if err != nil {
+		return "", err
+	}
+	return values[0], nil
+}
+
+// DeserializeValue parses the value of the header into a value, see Parameter.DeserializeValue.
+func (h *Header) DeserializeValue(value string) (any, error) {
+	return deserializeValue(h.Type, h.CollectionFormat, h.Items, false, []string{value})
+}
+
+func serializeValue(typ, format string, items *Items, allowMulti bool, value any) ([]string, error) {
+	if typ != "array" {
+		s, err := formatScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+	if format == CollectionFormatMulti {
+		if !allowMulti {
+			return nil, fmt.Errorf("collection format 'multi' is only allowed for query and formData parameters")
+		}
+		list, err := toList(value)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, len(list))
+		for i := range list {
+			if values[i], err = serializeItem(items, list[i]); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+	s, err := serializeArray(format, items, value)
+	if err != nil {
+		return nil, err
+	}
+	return []string{s}, nil
+}
+
+// serializeArray joins the items of an array using the separator of a collection format.
+func serializeArray(format string, items *Items, value any) (string, error) {
+	sep, err := CollectionSeparator(format)
+	if err != nil {
+		return "", err
+	}
+	list, err := toList(value)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, len(list))
+	for i := range list {
+		if parts[i], err = serializeItem(items, list[i]); err != nil {
+			return "", err
+		}
+		if strings.Contains(parts[i], sep) {
+			return "", fmt.Errorf("item %d of the array contains the separator %q of collection format '%s'",
+				i, sep, collectionFormatName(format))
+		}
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// serializeItem serializes an item of an array, which is an array itself if the items object says so.
+func serializeItem(items *Items, value any) (string, error) {
+	if items != nil && items.Type == "array" {
+		return serializeArray(items.CollectionFormat, items.Items, value)
+	}
+	return formatScalar(value)
+}
+
+func deserializeValue(typ, format string, items *Items, allowMulti bool, values []string) (any, error) {
+	if typ != "array" {
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return parseScalar(typ, values[0])
+	}
+	if format == CollectionFormatMulti {
+		if !allowMulti {
+			return nil, fmt.Errorf("collection format 'multi' is only allowed for query and formData parameters")
+		}
+		list := make([]any, len(values))
+		for i := range values {
+			var err error
+			if list[i], err = deserializeItem(items, values[i]); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	}
+	if len(values) == 0 {
+		return []any{}, nil
+	}
+	return deserializeArray(format, items, values[0])
+}
+
+// deserializeArray splits a string into the items of an array, using the separator of a collection format.
+func deserializeArray(format string, items *Items, value string) ([]any, error) {
+	sep, err := CollectionSeparator(format)
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return []any{}, nil
+	}
+	parts := strings.Split(value, sep)
+	list := make([]any, len(parts))
+	for i := range parts {
+		if list[i], err = deserializeItem(items, parts[i]); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// deserializeItem parses an item of an array, which is an array itself if the items object says so.
+func deserializeItem(items *Items, value string) (any, error) {
+	if items == nil {
+		return value, nil
+	}
+	if items.Type == "array" {
+		return deserializeArray(items.CollectionFormat, items.Items, value)
+	}
+	return parseScalar(items.Type, value)
+}
+
+// formatScalar formats a single (non-array) value as a string.
+func formatScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(value), nil
+	}
+	return "", fmt.Errorf("value of type %T can't be serialized, expected a string, number or boolean", value)
+}
+
+// parseScalar parses a single (non-array) value using its type: integers are int64, numbers are float64, booleans
+// are bool and everything else (string, file) is a string.
+func parseScalar(typ, value string) (any, error) {
+	switch typ {
+	case "integer":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value '%s' is not an integer", value)
+		}
+		return i, nil
+	case "number":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value '%s' is not a number", value)
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("value '%s' is not a boolean", value)
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+// toList returns the items of a slice or array value.
+func toList(value any) ([]any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if list, ok := value.([]any); ok {
+		return list, nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("value of type %T is not an array", value)
+	}
+	list := make([]any, v.Len())
+	for i := range list {
+		list[i] = v.Index(i).Interface()
+	}
+	return list, nil
+}
+
+func collectionFormatName(format string) string {
+	if format == "" {
+		return CollectionFormatCSV
+	}
+	return format
+}
@@ -0,0 +1,123 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectionSeparator(t *testing.T) {
+	for format, sep := range map[string]string{"": ",", "csv": ",", "ssv": " ", "tsv": "\t", "pipes": "|"} {
+		s, err := CollectionSeparator(format)
+		assert.NoError(t, err)
+		assert.Equal(t, sep, s)
+	}
+	_, err := CollectionSeparator("multi")
+	assert.Error(t, err)
+	_, err = CollectionSeparator("pizza")
+	assert.Equal(t, "collection format 'pizza' is not supported, expected csv, ssv, tsv, pipes or multi", err.Error())
+}
+
+func TestParameter_SerializeValue(t *testing.T) {
+	p := &Parameter{In: "query", Type: "array", Items: &Items{Type: "integer"}}
+	v, err := p.SerializeValue([]int{1, 2, 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1,2,3"}, v)
+
+	for format, expected := range map[string]string{"ssv": "1 2 3", "tsv": "1\t2\t3", "pipes": "1|2|3"} {
+		p.CollectionFormat = format
+		v, err = p.SerializeValue([]any{1, 2, 3})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{expected}, v)
+	}
+
+	p.CollectionFormat = "multi"
+	v, err = p.SerializeValue([]int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, v)
+
+	p.In = "header"
+	_, err = p.SerializeValue([]int64{1, 2})
+	assert.Equal(t, "collection format 'multi' is only allowed for query and formData parameters", err.Error())
+
+	// nested arrays use the collection format of the items.
+	p = &Parameter{In: "query", Type: "array", CollectionFormat: "pipes",
+		Items: &Items{Type: "array", Items: &Items{Type: "number"}}}
+	v, err = p.SerializeValue([][]float64{{1.5, 2}, {3}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.5,2|3"}, v)
+
+	_, err = (&Parameter{Type: "array"}).SerializeValue([]string{"a,b"})
+	assert.Equal(t, "item 0 of the array contains the separator \",\" of collection format 'csv'", err.Error())
+	_, err = (&Parameter{Type: "array"}).SerializeValue("nope")
+	assert.Equal(t, "value of type string is not an array", err.Error())
+
+	v, err = (&Parameter{Type: "boolean"}).SerializeValue(true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"true"}, v)
+	_, err = (&Parameter{Type: "string"}).SerializeValue(map[string]any{})
+	assert.Error(t, err)
+}
+
+func TestParameter_DeserializeValue(t *testing.T) {
+	p := &Parameter{In: "query", Type: "array", CollectionFormat: "ssv", Items: &Items{Type: "integer"}}
+	v, err := p.DeserializeValue([]string{"1 2 3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, v)
+
+	v, err = p.DeserializeValue([]string{""})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{}, v)
+
+	_, err = p.DeserializeValue([]string{"1 two"})
+	assert.Equal(t, "value 'two' is not an integer", err.Error())
+
+	p.CollectionFormat = "multi"
+	p.Items = &Items{Type: "boolean"}
+	v, err = p.DeserializeValue([]string{"true", "false"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{true, false}, v)
+
+	p = &Parameter{In: "path", Type: "array", Items: &Items{Type: "array", CollectionFormat: "pipes",
+		Items: &Items{Type: "number"}}}
+	v, err = p.DeserializeValue([]string{"1.5|2,3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{[]any{1.5, 2.0}, []any{3.0}}, v)
+
+	v, err = (&Parameter{Type: "number"}).DeserializeValue([]string{"4.2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4.2, v)
+	v, err = (&Parameter{Type: "string"}).DeserializeValue(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+	_, err = (&Parameter{Type: "boolean"}).DeserializeValue([]string{"maybe"})
+	assert.Equal(t, "value 'maybe' is not a boolean", err.Error())
+	_, err = (&Parameter{Type: "number"}).DeserializeValue([]string{"many"})
+	assert.Equal(t, "value 'many' is not a number", err.Error())
+}
+
+func TestHeader_SerializeValue(t *testing.T) {
+	h := &Header{Type: "array", CollectionFormat: "pipes", Items: &Items{Type: "string"}}
+	s, err := h.SerializeValue([]string{"a", "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, "a|b", s)
+
+	v, err := h.DeserializeValue(s)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"a", "b"}, v)
+
+	h.CollectionFormat = "multi"
+	_, err = h.SerializeValue([]string{"a", "b"})
+	assert.Error(t, err)
+	_, err = h.DeserializeValue("a")
+	assert.Error(t, err)
+
+	h.CollectionFormat = "pizza"
+	_, err = h.SerializeValue([]string{"a"})
+	assert.Error(t, err)
+	_, err = h.DeserializeValue("a")
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"sort"
+
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+)
+
+// ResolvedOperation is an operation, along with the path and method it's defined under, and the MIME types and
+// schemes that apply to it once the defaults of the document are taken into account.
+type ResolvedOperation struct {
+	Path      string
+	Method    string
+	Operation *Operation
+	Consumes  []string
+	Produces  []string
+	Schemes   []string
+}
+
+// EffectiveConsumes returns the MIME types an operation consumes: the consumes of the operation, or the consumes
+// of the document if the operation doesn't define any. An operation that defines an empty list consumes nothing,
+// it does not use the consumes of the document.
+func (s *Swagger) EffectiveConsumes(op *Operation) []string {
+	var defined *lowmodel.NodeReference[[]lowmodel.ValueReference[string]]
+	if op != nil && op.low != nil {
+		defined = &op.low.Consumes
+	}
+	return effectiveList(op, func(o *Operation) []string { return o.Consumes }, defined, s.Consumes)
+}
+
+// EffectiveProduces returns the MIME types an operation produces: the produces of the operation, or the produces
+// of the document if the operation doesn't define any. An operation that defines an empty list produces nothing,
+// it does not use the produces of the document.
+func (s *Swagger) EffectiveProduces(op *Operation) []string {
+	var defined *lowmodel.NodeReference[[]lowmodel.ValueReference[string]]
+	if op != nil && op.low != nil {
+		defined = &op.low.Produces
+	}
+	return effectiveList(op, func(o *Operation) []string { return o.Produces }, defined, s.Produces)
+}
+
+// EffectiveSchemes returns the transfer protocols of an operation: the schemes of the operation, or the schemes
+// of the document if the operation doesn't define any. If neither define any schemes, nil is returned, and the
+// scheme used to access the document itself should be used.
+func (s *Swagger) EffectiveSchemes(op *Operation) []string {
+	var defined *lowmodel.NodeReference[[]lowmodel.ValueReference[string]]
+	if op != nil && op.low != nil {
+		defined = &op.low.Schemes
+	}
+	return effectiveList(op, func(o *Operation) []string { return o.Schemes }, defined, s.Schemes)
+}
+
+// effectiveList returns the values of an operation if it has any, an empty list if the operation was built from a
+// document that defines an empty list, or the values of the document otherwise.
+func effectiveList(op *Operation, values func(*Operation) []string,
+	defined *lowmodel.NodeReference[[]lowmodel.ValueReference[string]], global []string) []string {
+	if op == nil {
+		return global
+	}
+	if v := values(op); len(v) > 0 {
+		return v
+	}
+	if defined != nil && !defined.IsEmpty() && len(defined.Value) == 0 {
+		return []string{}
+	}
+	return global
+}
+
+// ResolvedOperations returns every operation of the document, with the consumes, produces and schemes that apply
+// to them. Operations are in order of path (sorted) and then method (get, put, post, delete, options, head, patch).
+func (s *Swagger) ResolvedOperations() []*ResolvedOperation {
+	if s.Paths == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(s.Paths.PathItems))
+	for path := range s.Paths.PathItems {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var resolved []*ResolvedOperation
+	for _, path := range paths {
+		pi := s.Paths.PathItems[path]
+		if pi == nil {
+			continue
+		}
+		ops := pi.GetOperations()
+		for _, method := range []string{low.GetLabel, low.PutLabel, low.PostLabel, low.DeleteLabel,
+			low.OptionsLabel, low.HeadLabel, low.PatchLabel} {
+			op := ops[method]
+			if op == nil {
+				continue
+			}
+			resolved = append(resolved, &ResolvedOperation{
+				Path:      path,
+				Method:    method,
+				Operation: op,
+				Consumes:  s.EffectiveConsumes(op),
+				Produces:  s.EffectiveProduces(op),
+				Schemes:   s.EffectiveSchemes(op),
+			})
+		}
+	}
+	return resolved
+}
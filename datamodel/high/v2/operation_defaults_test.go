@@ -0,0 +1,62 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwagger_EffectiveDefaults(t *testing.T) {
+
+	yml := `swagger: "2.0"
+schemes: [https]
+consumes: [application/json]
+produces: [application/json]
+paths:
+  /pizza:
+    post:
+      consumes: [application/xml]
+      schemes: [http, https]
+      responses: {}
+    get:
+      produces: []
+      responses: {}
+  /burger:
+    delete:
+      responses: {}`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, errs := v2.CreateDocument(info)
+	assert.Empty(t, errs)
+	doc := NewSwaggerDocument(lowDoc)
+
+	post := doc.Paths.PathItems["/pizza"].Post
+	assert.Equal(t, []string{"application/xml"}, doc.EffectiveConsumes(post))
+	assert.Equal(t, []string{"application/json"}, doc.EffectiveProduces(post))
+	assert.Equal(t, []string{"http", "https"}, doc.EffectiveSchemes(post))
+
+	get := doc.Paths.PathItems["/pizza"].Get
+	assert.Equal(t, []string{"application/json"}, doc.EffectiveConsumes(get))
+	assert.NotNil(t, doc.EffectiveProduces(get))
+	assert.Empty(t, doc.EffectiveProduces(get))
+
+	resolved := doc.ResolvedOperations()
+	assert.Len(t, resolved, 3)
+	assert.Equal(t, "/burger", resolved[0].Path)
+	assert.Equal(t, "delete", resolved[0].Method)
+	assert.Equal(t, []string{"https"}, resolved[0].Schemes)
+	assert.Equal(t, "get", resolved[1].Method)
+	assert.Equal(t, get, resolved[1].Operation)
+	assert.Equal(t, "post", resolved[2].Method)
+	assert.Equal(t, []string{"application/xml"}, resolved[2].Consumes)
+
+	// operations built by hand have no low model, an empty list uses the document.
+	assert.Equal(t, []string{"https"}, doc.EffectiveSchemes(&Operation{}))
+	assert.Equal(t, []string{"https"}, doc.EffectiveSchemes(nil))
+	assert.Nil(t, (&Swagger{}).ResolvedOperations())
+}
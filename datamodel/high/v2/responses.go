@@ -55,6 +55,16 @@ func NewResponses(responses *low.Responses) *Responses {
 	return r
 }
 
+// Keys returns every response code, 'default' (if there is a default response) and every extension, in the order
+// they are found in the document. Codes, the default and extensions added after building go last, in that order
+// (sorted). The Codes and Extensions maps have no order, use Keys to visit them in the author's order.
+func (r *Responses) Keys() []string {
+	if r.low == nil {
+		return high.ResponseKeys(nil, r.Codes, r.Default != nil, r.Extensions)
+	}
+	return high.ResponseKeys(r.low.Keys(), r.Codes, r.Default != nil, r.Extensions)
+}
+
 // GoLow will return the low-level object used to create the high-level one.
 func (r *Responses) GoLow() *low.Responses {
 	return r.low
@@ -0,0 +1,41 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestResponses_Keys(t *testing.T) {
+
+	yml := `x-first: 1
+"404":
+  description: nope
+default:
+  description: whatever
+"200":
+  description: ok
+x-last: true`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n v2.Responses
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	r := NewResponses(&n)
+	assert.Equal(t, []string{"x-first", "404", "default", "200", "x-last"}, r.Keys())
+
+	r.Codes["500"] = &Response{Description: "oops"}
+	delete(r.Extensions, "x-first")
+	assert.Equal(t, []string{"404", "default", "200", "x-last", "500"}, r.Keys())
+}
@@ -4,6 +4,7 @@
 package v2
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
 )
 
@@ -12,8 +13,9 @@ import (
 // Scopes lists the available scopes for an OAuth2 security scheme.
 //   - https://swagger.io/specification/v2/#scopesObject
 type Scopes struct {
-	Values map[string]string
-	low    *low.Scopes
+	Values     map[string]string
+	Extensions map[string]any
+	low        *low.Scopes
 }
 
 // NewScopes creates a new high-level instance of Scopes from a low-level one.
@@ -25,6 +27,7 @@ func NewScopes(scopes *low.Scopes) *Scopes {
 		scopeValues[k.Value] = scopes.Values[k].Value
 	}
 	s.Values = scopeValues
+	s.Extensions = high.ExtractExtensions(scopes.Extensions)
 	return s
 }
 
@@ -32,3 +35,17 @@ func NewScopes(scopes *low.Scopes) *Scopes {
 func (s *Scopes) GoLow() *low.Scopes {
 	return s.low
 }
+
+// Keys returns the name of every scope and extension, in the order they are found in the document. Use it to
+// render scopes and extensions in their original order, the Values and Extensions maps have no order.
+func (s *Scopes) Keys() []string {
+	if s.low == nil {
+		return nil
+	}
+	keys := s.low.Keys()
+	names := make([]string, len(keys))
+	for i := range keys {
+		names[i] = keys[i].Value
+	}
+	return names
+}
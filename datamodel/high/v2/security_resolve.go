@@ -0,0 +1,67 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ResolvedSecurityScheme is a security scheme required by a security requirement, mapped to the SecurityScheme
+// defined by the security definitions of the document.
+type ResolvedSecurityScheme struct {
+	Name           string          // the name of the security scheme.
+	Scopes         []string        // the scopes required.
+	SecurityScheme *SecurityScheme // the security scheme, nil if the document does not define it.
+}
+
+// EffectiveSecurity returns the security requirements that apply to an operation: the security of the operation,
+// or the security of the document if the operation doesn't define any. An operation that defines an empty list
+// has no security, it does not use the security of the document.
+func (s *Swagger) EffectiveSecurity(op *Operation) []*base.SecurityRequirement {
+	if op != nil && op.Security != nil {
+		return op.Security
+	}
+	return s.Security
+}
+
+// ResolveSecurity expands security requirements into a list of alternatives, any one of which grants access, each
+// a list of schemes that are all required (see base.FlattenSecurity). Each scheme is mapped to the SecurityScheme
+// defined by the document. An error is returned for every scheme that is not defined, and every OAuth scope
+// that is not defined by its scheme.
+func (s *Swagger) ResolveSecurity(requirements []*base.SecurityRequirement) ([][]*ResolvedSecurityScheme, []error) {
+	var schemes map[string]*SecurityScheme
+	if s.SecurityDefinitions != nil {
+		schemes = s.SecurityDefinitions.Definitions
+	}
+	flat := base.FlattenSecurity(requirements)
+	if flat == nil {
+		return nil, nil
+	}
+	var errs []error
+	resolved := make([][]*ResolvedSecurityScheme, len(flat))
+	for i := range flat {
+		resolved[i] = make([]*ResolvedSecurityScheme, len(flat[i]))
+		for j, req := range flat[i] {
+			r := &ResolvedSecurityScheme{Name: req.Scheme, Scopes: req.Scopes, SecurityScheme: schemes[req.Scheme]}
+			resolved[i][j] = r
+			if r.SecurityScheme == nil {
+				errs = append(errs, fmt.Errorf("security requirement uses the '%s' security scheme, "+
+					"which is not defined", req.Scheme))
+				continue
+			}
+			if r.SecurityScheme.Type != "oauth2" || r.SecurityScheme.Scopes == nil {
+				continue
+			}
+			for _, scope := range req.Scopes {
+				if _, ok := r.SecurityScheme.Scopes.Values[scope]; !ok {
+					errs = append(errs, fmt.Errorf("security requirement uses the '%s' scope, which is not "+
+						"defined by the '%s' security scheme", scope, req.Scheme))
+				}
+			}
+		}
+	}
+	return resolved, errs
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwagger_ResolveSecurity(t *testing.T) {
+
+	yml := `swagger: "2.0"
+security:
+  - oauth: [read]
+paths:
+  /pizza:
+    get:
+      security:
+        - oauth: [write]
+          key: []
+        - missing: []
+      responses: {}
+    post:
+      security: []
+      responses: {}
+    put:
+      responses: {}
+securityDefinitions:
+  key:
+    type: apiKey
+    in: header
+    name: X-Key
+  oauth:
+    type: oauth2
+    flow: implicit
+    authorizationUrl: https://pb33f.io/auth
+    scopes:
+      read: read things`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, errs := v2.CreateDocument(info)
+	assert.Empty(t, errs)
+	doc := NewSwaggerDocument(lowDoc)
+	pi := doc.Paths.PathItems["/pizza"]
+
+	assert.Equal(t, doc.Security, doc.EffectiveSecurity(pi.Put))
+	assert.Equal(t, doc.Security, doc.EffectiveSecurity(nil))
+	assert.Equal(t, doc.Security, doc.EffectiveSecurity(&Operation{}))
+	assert.NotNil(t, doc.EffectiveSecurity(pi.Post))
+	assert.Empty(t, doc.EffectiveSecurity(pi.Post))
+
+	resolved, rErrs := doc.ResolveSecurity(doc.EffectiveSecurity(pi.Put))
+	assert.Empty(t, rErrs)
+	assert.Len(t, resolved, 1)
+	assert.Equal(t, "oauth", resolved[0][0].Name)
+	assert.Equal(t, "implicit", resolved[0][0].SecurityScheme.Flow)
+
+	resolved, rErrs = doc.ResolveSecurity(doc.EffectiveSecurity(pi.Get))
+	assert.Len(t, resolved, 2)
+	assert.Len(t, resolved[0], 2)
+	assert.Equal(t, "key", resolved[0][1].Name)
+	assert.Nil(t, resolved[1][0].SecurityScheme)
+	assert.Len(t, rErrs, 2)
+	assert.Equal(t, "security requirement uses the 'write' scope, which is not defined by the 'oauth' security scheme",
+		rErrs[0].Error())
+	assert.Equal(t, "security requirement uses the 'missing' security scheme, which is not defined", rErrs[1].Error())
+
+	resolved, rErrs = (&Swagger{}).ResolveSecurity(nil)
+	assert.Nil(t, resolved)
+	assert.Nil(t, rErrs)
+}
@@ -130,6 +130,8 @@ func TestNewSwaggerDocument_Definitions_Security(t *testing.T) {
 	goLowest := highDoc.SecurityDefinitions.Definitions["petstore_auth"].Scopes.GoLow()
 	assert.Equal(t, 665, goLowest.FindScope("read:pets").ValueNode.Line)
 	assert.Equal(t, 18, goLowest.FindScope("read:pets").ValueNode.Column)
+	assert.Equal(t, []string{"read:pets", "write:pets"},
+		highDoc.SecurityDefinitions.Definitions["petstore_auth"].Scopes.Keys())
 }
 
 func TestNewSwaggerDocument_Definitions_Responses(t *testing.T) {
@@ -0,0 +1,222 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RenderCanonical will return a YAML representation of the Document object in a canonical form, so two documents
+// describing the same API render the same bytes, regardless of the order they were written in. In canonical form:
+//
+//   - components are sorted by name, within each type of component.
+//   - response codes are sorted numerically, with 'default' last.
+//   - indentation is two spaces, and quoting / flow styles from the original document are dropped.
+//   - flags that are false by default (like 'deprecated' or 'required') are left out when they are false.
+//
+// The order of paths and of fields within objects is the same as Render.
+func (d *Document) RenderCanonical() ([]byte, error) {
+	node, _ := d.MarshalYAMLCanonical()
+	var buf bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&buf)
+	yamlEncoder.SetIndent(2)
+	if err := yamlEncoder.Encode(node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalYAMLCanonical will create a ready to render YAML representation of the Document object in a canonical
+// form (see RenderCanonical).
+func (d *Document) MarshalYAMLCanonical() (interface{}, error) {
+	// the rendered node can share nodes with the low level model, which must not change.
+	node := utils.CopyNode(high.NewNodeBuilder(d, d.low).Render())
+	canonicalizeNode(node, false)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case low.ComponentsLabel:
+			canonicalizeComponents(node.Content[i+1])
+		case low.PathsLabel, low.WebhooksLabel:
+			canonicalizePathItems(node.Content[i+1])
+		}
+	}
+	return node, nil
+}
+
+// defaultFalseLabels are the keys of flags that are false when they are not set.
+var defaultFalseLabels = map[string]bool{
+	"deprecated":      true,
+	"required":        true,
+	"allowEmptyValue": true,
+	"allowReserved":   true,
+	"nullable":        true,
+	"readOnly":        true,
+	"writeOnly":       true,
+	"uniqueItems":     true,
+}
+
+// namedSchemaLabels are the keys of maps of schemas, the keys of these maps are names and not flags, and the
+// values can be boolean schemas.
+var namedSchemaLabels = map[string]bool{
+	"schemas":           true,
+	"properties":        true,
+	"patternProperties": true,
+	"dependentSchemas":  true,
+	"$defs":             true,
+	"definitions":       true,
+}
+
+// literalLabels are the keys of values that are rendered as they are, they are data and not part of the document.
+// 'examples' is only literal when it's a list (in a schema), a map of 'examples' holds Example objects by name.
+var literalLabels = map[string]bool{
+	"example":  true,
+	"examples": true,
+	"value":    true,
+	"default":  true,
+	"enum":     true,
+	"const":    true,
+}
+
+// canonicalizeNode drops the styles of node and its children, and removes flags that are false by default. names
+// is true when the keys of node are names, rather than fields.
+func canonicalizeNode(node *yaml.Node, names bool) {
+	node.Style = 0
+	if node.Kind == yaml.SequenceNode {
+		for _, n := range node.Content {
+			canonicalizeNode(n, false)
+		}
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		if !names && defaultFalseLabels[k.Value] && v.Kind == yaml.ScalarNode && v.Value == "false" {
+			continue
+		}
+		k.Style = 0
+		if !names && k.Value == "examples" && v.Kind == yaml.MappingNode {
+			canonicalizeNode(v, true)
+			content = append(content, k, v)
+			continue
+		}
+		if !names && (literalLabels[k.Value] || strings.HasPrefix(k.Value, "x-")) {
+			content = append(content, k, v)
+			continue
+		}
+		canonicalizeNode(v, !names && namedSchemaLabels[k.Value])
+		content = append(content, k, v)
+	}
+	node.Content = content
+}
+
+// canonicalizeComponents sorts every type of component by name.
+func canonicalizeComponents(node *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.HasPrefix(node.Content[i].Value, "x-") {
+			continue
+		}
+		value := node.Content[i+1]
+		sortMappingNode(value, func(a, b string) bool { return a < b })
+		switch node.Content[i].Value {
+		case low.PathItemsLabel:
+			canonicalizePathItems(value)
+		case low.CallbacksLabel:
+			for j := 1; j < len(value.Content); j += 2 {
+				canonicalizePathItems(value.Content[j])
+			}
+		}
+	}
+}
+
+// canonicalizePathItems sorts the response codes of every operation in a map of path items.
+func canonicalizePathItems(node *yaml.Node) {
+	for i := 1; i < len(node.Content); i += 2 {
+		pathItem := node.Content[i]
+		for j := 0; j+1 < len(pathItem.Content); j += 2 {
+			if isOperationLabel(pathItem.Content[j].Value) {
+				canonicalizeOperation(pathItem.Content[j+1])
+			}
+		}
+	}
+}
+
+// canonicalizeOperation sorts the response codes of an operation, and the operations of its callbacks.
+func canonicalizeOperation(node *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case low.ResponsesLabel:
+			sortMappingNode(node.Content[i+1], lessResponseCode)
+		case low.CallbacksLabel:
+			callbacks := node.Content[i+1]
+			for j := 1; j < len(callbacks.Content); j += 2 {
+				canonicalizePathItems(callbacks.Content[j])
+			}
+		}
+	}
+}
+
+func isOperationLabel(label string) bool {
+	switch label {
+	case low.GetLabel, low.PutLabel, low.PostLabel, low.DeleteLabel, low.OptionsLabel, low.HeadLabel, low.PatchLabel, low.TraceLabel:
+		return true
+	}
+	return false
+}
+
+// lessResponseCode orders response codes numerically, ranges (like 4XX) follow the codes they cover, then
+// 'default', then extensions.
+func lessResponseCode(a, b string) bool {
+	ra, rb := responseCodeRank(a), responseCodeRank(b)
+	if ra != rb {
+		return ra < rb
+	}
+	return a < b
+}
+
+func responseCodeRank(code string) int {
+	if strings.HasPrefix(code, "x-") {
+		return 2000
+	}
+	if code == "default" {
+		return 1000
+	}
+	if n, err := strconv.Atoi(code); err == nil {
+		return n
+	}
+	if len(code) == 3 && (code[1] == 'X' || code[1] == 'x') {
+		if n, err := strconv.Atoi(code[:1]); err == nil {
+			return n*100 + 99
+		}
+	}
+	return 1500
+}
+
+// sortMappingNode sorts the key/value pairs of a mapping node by key.
+func sortMappingNode(node *yaml.Node, less func(a, b string) bool) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i][0].Value, pairs[j][0].Value)
+	})
+	node.Content = node.Content[:0]
+	for _, p := range pairs {
+		node.Content = append(node.Content, p[0], p[1])
+	}
+}
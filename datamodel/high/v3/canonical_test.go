@@ -0,0 +1,181 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildCanonicalTestDocument(t *testing.T, spec string) *Document {
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewOpenDocumentConfiguration())
+	assert.Empty(t, err)
+	return NewDocument(lowDoc)
+}
+
+func TestDocument_RenderCanonical(t *testing.T) {
+	a := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      deprecated: false
+      description: "pizza"
+      requestBody:
+        required: false
+        description: dough
+      responses:
+        default:
+          description: oops
+        "404":
+          description: missing
+        4XX:
+          description: bad
+        '200':
+          description: ok
+components:
+  schemas:
+    Topping:
+      type: object
+      properties:
+        name: {type: string, readOnly: false, example: {required: false}}
+    Cheese:
+      type: string`
+
+	b := `openapi: 3.1.0
+paths:
+    /pizza:
+        get:
+            description: pizza
+            requestBody:
+                description: dough
+            responses:
+                '200':
+                    description: ok
+                4XX:
+                    description: bad
+                '404':
+                    description: missing
+                default:
+                    description: oops
+components:
+    schemas:
+        Cheese:
+            type: string
+        Topping:
+            type: object
+            properties:
+                name:
+                    type: string
+                    example:
+                        required: false`
+
+	docA := buildCanonicalTestDocument(t, a)
+	renderedA, err := docA.RenderCanonical()
+	assert.NoError(t, err)
+	renderedB, err := buildCanonicalTestDocument(t, b).RenderCanonical()
+	assert.NoError(t, err)
+	assert.Equal(t, string(renderedB), string(renderedA))
+
+	expected := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      description: pizza
+      requestBody:
+        description: dough
+      responses:
+        "200":
+          description: ok
+        "404":
+          description: missing
+        4XX:
+          description: bad
+        default:
+          description: oops
+components:
+  schemas:
+    Cheese:
+      type: string
+    Topping:
+      type: object
+      properties:
+        name:
+          type: string
+          example:
+            required: false
+`
+	assert.Equal(t, expected, string(renderedA))
+
+	// the model is untouched.
+	rendered, _ := docA.Render()
+	assert.Contains(t, string(rendered), `description: "pizza"`)
+	assert.Contains(t, string(rendered), "required: false")
+	assert.Contains(t, string(rendered), "Topping:\n            type: object")
+}
+
+func TestDocument_RenderCanonical_Examples(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              examples:
+                required:
+                  summary: "a topping"
+                  value:
+                    required: false
+components:
+  schemas:
+    Topping:
+      type: object
+      examples:
+        - deprecated: false
+          name: "cheese"`
+
+	rendered, err := buildCanonicalTestDocument(t, spec).RenderCanonical()
+	assert.NoError(t, err)
+
+	// examples are data, and are left as they are, but the fields of example objects are canonical.
+	expected := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              examples:
+                required:
+                  summary: a topping
+                  value:
+                    required: false
+components:
+  schemas:
+    Topping:
+      type: object
+      examples:
+        - deprecated: false
+          name: cheese
+`
+	assert.Equal(t, expected, string(rendered))
+}
+
+func TestLessResponseCode(t *testing.T) {
+	assert.True(t, lessResponseCode("200", "201"))
+	assert.True(t, lessResponseCode("299", "2XX"))
+	assert.True(t, lessResponseCode("2XX", "300"))
+	assert.True(t, lessResponseCode("5XX", "default"))
+	assert.True(t, lessResponseCode("default", "pizza"))
+	assert.True(t, lessResponseCode("pizza", "x-pizza"))
+	assert.False(t, lessResponseCode("x-pizza", "200"))
+}
@@ -22,6 +22,7 @@ const (
 	securitySchemes
 	links
 	callbacks
+	pathItems
 )
 
 // Components represents a high-level OpenAPI 3+ Components Object, that is backed by a low-level one.
@@ -39,6 +40,7 @@ type Components struct {
 	SecuritySchemes map[string]*SecurityScheme       `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
 	Links           map[string]*Link                 `json:"links,omitempty" yaml:"links,omitempty"`
 	Callbacks       map[string]*Callback             `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
+	PathItems       map[string]*PathItem             `json:"pathItems,omitempty" yaml:"pathItems,omitempty"`
 	Extensions      map[string]any                   `json:"-" yaml:"-"`
 	low             *low.Components
 }
@@ -60,6 +62,7 @@ func NewComponents(comp *low.Components) *Components {
 	requestBodyMap := make(map[string]*RequestBody)
 	headerMap := make(map[string]*Header)
 	securitySchemeMap := make(map[string]*SecurityScheme)
+	pathItemMap := make(map[string]*PathItem)
 	schemas := make(map[string]*highbase.SchemaProxy)
 	schemaChan := make(chan componentResult[*highbase.SchemaProxy])
 	cbChan := make(chan componentResult[*Callback])
@@ -70,6 +73,7 @@ func NewComponents(comp *low.Components) *Components {
 	requestBodyChan := make(chan componentResult[*RequestBody])
 	headerChan := make(chan componentResult[*Header])
 	securitySchemeChan := make(chan componentResult[*SecurityScheme])
+	pathItemChan := make(chan componentResult[*PathItem])
 
 	// build all components asynchronously.
 	for k, v := range comp.Callbacks.Value {
@@ -98,13 +102,17 @@ func NewComponents(comp *low.Components) *Components {
 		go buildComponent[*SecurityScheme, *low.SecurityScheme](securitySchemes, k.Value, v.Value,
 			securitySchemeChan, NewSecurityScheme)
 	}
+	for k, v := range comp.PathItems.Value {
+		go buildComponent[*PathItem, *low.PathItem](pathItems, k.Value, v.Value, pathItemChan, NewPathItem)
+	}
 	for k, v := range comp.Schemas.Value {
 		go buildSchema(k, v, schemaChan)
 	}
 
 	totalComponents := len(comp.Callbacks.Value) + len(comp.Links.Value) + len(comp.Responses.Value) +
 		len(comp.Parameters.Value) + len(comp.Examples.Value) + len(comp.RequestBodies.Value) +
-		len(comp.Headers.Value) + len(comp.SecuritySchemes.Value) + len(comp.Schemas.Value)
+		len(comp.Headers.Value) + len(comp.SecuritySchemes.Value) + len(comp.Schemas.Value) +
+		len(comp.PathItems.Value)
 
 	processedComponents := 0
 	for processedComponents < totalComponents {
@@ -136,6 +144,9 @@ func NewComponents(comp *low.Components) *Components {
 		case ssRes := <-securitySchemeChan:
 			processedComponents++
 			securitySchemeMap[ssRes.key] = ssRes.res
+		case piRes := <-pathItemChan:
+			processedComponents++
+			pathItemMap[piRes.key] = piRes.res
 		}
 	}
 	c.Schemas = schemas
@@ -147,6 +158,7 @@ func NewComponents(comp *low.Components) *Components {
 	c.RequestBodies = requestBodyMap
 	c.Examples = exampleMap
 	c.SecuritySchemes = securitySchemeMap
+	c.PathItems = pathItemMap
 	return c
 }
 
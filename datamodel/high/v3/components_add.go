@@ -0,0 +1,138 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"regexp"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// componentName is the pattern every component name must match.
+//   - https://spec.openapis.org/oas/v3.1.0#fixed-fields-5
+var componentName = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+
+// ComponentRef returns the reference to a component, by the type of component (like 'schemas' or 'responses')
+// and its name, for example '#/components/schemas/Pet'.
+func ComponentRef(componentType, name string) string {
+	return "#/components/" + componentType + "/" + name
+}
+
+// AddSchema adds a schema to the components, and returns the reference to it (like '#/components/schemas/Pet').
+// An error is returned if the name is not a valid component name, or a schema with the name already exists.
+//
+// If the components were built from a document, the schema is also added to the document the index was built
+// from, and the index is refreshed, so the new reference can be looked up straight away. If the index can't be
+// refreshed, the schema is added to the components anyway, and the reference is returned with the error.
+func (c *Components) AddSchema(name string, schema *highbase.SchemaProxy) (string, error) {
+	return addComponent(c, &c.Schemas, low.SchemasLabel, name, schema)
+}
+
+// AddResponse adds a response to the components and returns the reference to it, see AddSchema.
+func (c *Components) AddResponse(name string, response *Response) (string, error) {
+	return addComponent(c, &c.Responses, low.ResponsesLabel, name, response)
+}
+
+// AddParameter adds a parameter to the components and returns the reference to it, see AddSchema.
+func (c *Components) AddParameter(name string, parameter *Parameter) (string, error) {
+	return addComponent(c, &c.Parameters, low.ParametersLabel, name, parameter)
+}
+
+// AddExample adds an example to the components and returns the reference to it, see AddSchema.
+func (c *Components) AddExample(name string, example *highbase.Example) (string, error) {
+	return addComponent(c, &c.Examples, low.ExamplesLabel, name, example)
+}
+
+// AddRequestBody adds a request body to the components and returns the reference to it, see AddSchema.
+func (c *Components) AddRequestBody(name string, requestBody *RequestBody) (string, error) {
+	return addComponent(c, &c.RequestBodies, low.RequestBodiesLabel, name, requestBody)
+}
+
+// AddHeader adds a header to the components and returns the reference to it, see AddSchema.
+func (c *Components) AddHeader(name string, header *Header) (string, error) {
+	return addComponent(c, &c.Headers, low.HeadersLabel, name, header)
+}
+
+// AddSecurityScheme adds a security scheme to the components and returns the reference to it, see AddSchema.
+// Security requirements use the name of the scheme, not the reference.
+func (c *Components) AddSecurityScheme(name string, scheme *SecurityScheme) (string, error) {
+	return addComponent(c, &c.SecuritySchemes, low.SecuritySchemesLabel, name, scheme)
+}
+
+// AddLink adds a link to the components and returns the reference to it, see AddSchema.
+func (c *Components) AddLink(name string, link *Link) (string, error) {
+	return addComponent(c, &c.Links, low.LinksLabel, name, link)
+}
+
+// AddCallback adds a callback to the components and returns the reference to it, see AddSchema.
+func (c *Components) AddCallback(name string, callback *Callback) (string, error) {
+	return addComponent(c, &c.Callbacks, low.CallbacksLabel, name, callback)
+}
+
+// AddPathItem adds a path item to the components and returns the reference to it, see AddSchema.
+func (c *Components) AddPathItem(name string, pathItem *PathItem) (string, error) {
+	return addComponent(c, &c.PathItems, low.PathItemsLabel, name, pathItem)
+}
+
+func addComponent[T comparable](c *Components, components *map[string]T, componentType, name string,
+	value T) (string, error) {
+	var empty T
+	if value == empty {
+		return "", fmt.Errorf("unable to add '%s' to %s, the component is nil", name, componentType)
+	}
+	if !componentName.MatchString(name) {
+		return "", fmt.Errorf("unable to add '%s' to %s, component names can only contain letters, digits, "+
+			"'.', '-' and '_'", name, componentType)
+	}
+	if _, ok := (*components)[name]; ok {
+		return "", fmt.Errorf("unable to add '%s' to %s, a component with that name already exists",
+			name, componentType)
+	}
+
+	// render the component before anything is changed, so nothing is added if it can't be rendered.
+	var node *yaml.Node
+	if c.canIndex() {
+		node = new(yaml.Node)
+		if err := node.Encode(value); err != nil {
+			return "", fmt.Errorf("unable to add '%s' to %s: %w", name, componentType, err)
+		}
+	}
+	if *components == nil {
+		*components = make(map[string]T)
+	}
+	(*components)[name] = value
+	ref := ComponentRef(componentType, name)
+	if node != nil {
+		if err := c.indexComponent(componentType, name, node); err != nil {
+			return ref, err
+		}
+	}
+	return ref, nil
+}
+
+// canIndex returns true if the components were built from a document, with an index.
+func (c *Components) canIndex() bool {
+	return c.low != nil && c.low.GetIndex() != nil && c.low.GetRootNode() != nil &&
+		c.low.GetRootNode().Kind == yaml.MappingNode
+}
+
+// indexComponent adds the node of a new component to the document the components were built from, and refreshes
+// the index.
+func (c *Components) indexComponent(componentType, name string, node *yaml.Node) error {
+	root := c.low.GetRootNode()
+	_, section := utils.FindKeyNodeTop(componentType, root.Content)
+	if section == nil {
+		section = utils.CreateEmptyMapNode()
+		root.Content = append(root.Content, utils.CreateStringNode(componentType), section)
+	}
+	section.Content = append(section.Content, utils.CreateStringNode(name), node)
+	if err := c.low.GetIndex().Refresh(ComponentRef(componentType, name)[1:]); err != nil {
+		return fmt.Errorf("added '%s' to %s, but the index could not be refreshed: %w", name, componentType, err)
+	}
+	return nil
+}
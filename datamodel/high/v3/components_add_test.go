@@ -0,0 +1,102 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentRef(t *testing.T) {
+	assert.Equal(t, "#/components/schemas/Pet", ComponentRef("schemas", "Pet"))
+}
+
+func TestComponents_AddSchema(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object`
+
+	doc := buildOperationIndexDocument(t, yml)
+	idx := doc.Components.GoLow().GetIndex()
+	assert.Len(t, idx.GetAllComponentSchemas(), 1)
+
+	ref, err := doc.Components.AddSchema("Topping", base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}))
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/Topping", ref)
+	assert.NotNil(t, doc.Components.Schemas["Topping"])
+
+	// the index knows about the new schema.
+	assert.Len(t, idx.GetAllComponentSchemas(), 2)
+	found := idx.FindComponent(ref, nil)
+	assert.NotNil(t, found)
+	assert.Equal(t, "Topping", found.Name)
+
+	// and it renders.
+	out, _ := doc.Components.Render()
+	assert.True(t, strings.Contains(string(out), "Topping:\n"))
+
+	_, err = doc.Components.AddSchema("Topping", base.CreateSchemaProxy(&base.Schema{}))
+	assert.Equal(t, "unable to add 'Topping' to schemas, a component with that name already exists", err.Error())
+	_, err = doc.Components.AddSchema("no/pizza", base.CreateSchemaProxy(&base.Schema{}))
+	assert.Equal(t, "unable to add 'no/pizza' to schemas, component names can only contain letters, digits, "+
+		"'.', '-' and '_'", err.Error())
+	_, err = doc.Components.AddSchema("Nothing", nil)
+	assert.Equal(t, "unable to add 'Nothing' to schemas, the component is nil", err.Error())
+}
+
+func TestComponents_AddComponents(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      type: object`
+
+	doc := buildOperationIndexDocument(t, yml)
+	idx := doc.Components.GoLow().GetIndex()
+
+	ref, err := doc.Components.AddParameter("limit", &Parameter{Name: "limit", In: "query"})
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/parameters/limit", ref)
+	assert.NotNil(t, idx.GetAllParameters()[ref])
+
+	ref, err = doc.Components.AddResponse("NotFound", &Response{Description: "no pizza"})
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/responses/NotFound", ref)
+
+	refs := []func() (string, error){
+		func() (string, error) { return doc.Components.AddExample("ex", &base.Example{Summary: "hot"}) },
+		func() (string, error) { return doc.Components.AddRequestBody("body", &RequestBody{Description: "hot"}) },
+		func() (string, error) { return doc.Components.AddHeader("header", &Header{Description: "hot"}) },
+		func() (string, error) {
+			return doc.Components.AddSecurityScheme("key", &SecurityScheme{Type: "apiKey", In: "header", Name: "X"})
+		},
+		func() (string, error) { return doc.Components.AddLink("link", &Link{OperationId: "hot"}) },
+		func() (string, error) { return doc.Components.AddCallback("callback", &Callback{}) },
+		func() (string, error) { return doc.Components.AddPathItem("path", &PathItem{Summary: "hot"}) },
+	}
+	expected := []string{"examples/ex", "requestBodies/body", "headers/header", "securitySchemes/key", "links/link",
+		"callbacks/callback", "pathItems/path"}
+	for i := range refs {
+		ref, err = refs[i]()
+		assert.NoError(t, err)
+		assert.Equal(t, "#/components/"+expected[i], ref)
+	}
+	assert.Len(t, doc.Components.SecuritySchemes, 1)
+	assert.Len(t, doc.Components.PathItems, 1)
+}
+
+func TestComponents_AddSchema_NoIndex(t *testing.T) {
+	c := &Components{}
+	ref, err := c.AddSchema("Pizza", base.CreateSchemaProxy(&base.Schema{}))
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/Pizza", ref)
+	assert.Len(t, c.Schemas, 1)
+}
@@ -11,6 +11,9 @@ package v3
 
 import (
 	"bytes"
+	"fmt"
+	"sort"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -88,14 +91,28 @@ type Document struct {
 	// the original details are required to continue the work.
 	//
 	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
-	Index *index.SpecIndex `json:"-" yaml:"-"`
-	low   *low.Document
+	Index      *index.SpecIndex `json:"-" yaml:"-"`
+	low        *low.Document
+	operations *operationIndexCache
 }
 
 // NewDocument will create a new high-level Document from a low-level one.
 func NewDocument(document *low.Document) *Document {
+	return newDocument(document, false)
+}
+
+// NewDocumentLazily will create a new high-level Document from a low-level one, without building the path items.
+// Each PathItem is built the first time it's requested using Paths.GetPathItem or Paths.GetPathItems, so
+// consumers that only use a few paths of a huge document don't pay for building all of them. The document is safe to
+// read from multiple goroutines.
+func NewDocumentLazily(document *low.Document) *Document {
+	return newDocument(document, true)
+}
+
+func newDocument(document *low.Document, lazy bool) *Document {
 	d := new(Document)
 	d.low = document
+	d.operations = new(operationIndexCache)
 	d.Index = document.Index
 	if !document.Info.IsEmpty() {
 		d.Info = base.NewInfo(document.Info.Value)
@@ -123,7 +140,11 @@ func NewDocument(document *low.Document) *Document {
 		d.Components = NewComponents(document.Components.Value)
 	}
 	if !document.Paths.IsEmpty() {
-		d.Paths = NewPaths(document.Paths.Value)
+		if lazy {
+			d.Paths = NewPathsLazily(document.Paths.Value)
+		} else {
+			d.Paths = NewPaths(document.Paths.Value)
+		}
 	}
 	if !document.JsonSchemaDialect.IsEmpty() {
 		d.JsonSchemaDialect = document.JsonSchemaDialect.Value
@@ -145,6 +166,81 @@ func NewDocument(document *low.Document) *Document {
 	return d
 }
 
+// ConvertCallbacksToWebhooks will collect every callback defined by operations in the document and convert each
+// callback expression into a 3.1 webhook. Callbacks defined under components are only picked up when referenced by
+// an operation, as components have no effect on the API unless they are used.
+//
+// The returned map is keyed by the callback name, if a callback holds more than one expression, the expression is
+// appended to the name. If a name has already been used by another operation, the operationId (or the method and path)
+// is used as a prefix to keep keys unique, and if that is taken as well (for example by a duplicated operationId),
+// a number is appended to it.
+//
+// The document is not mutated, the result can be assigned to Webhooks (along with bumping the version to 3.1) to
+// complete a conversion of a 3.0 document.
+func (d *Document) ConvertCallbacksToWebhooks() map[string]*PathItem {
+	hooks := make(map[string]*PathItem)
+	addCallbacks := func(prefix string, callbacks map[string]*Callback) {
+		names := make([]string, 0, len(callbacks))
+		for name := range callbacks {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			cb := callbacks[name]
+			if cb == nil {
+				continue
+			}
+			expressions := make([]string, 0, len(cb.Expression))
+			for exp := range cb.Expression {
+				expressions = append(expressions, exp)
+			}
+			sort.Strings(expressions)
+			for _, exp := range expressions {
+				key := name
+				if len(expressions) > 1 {
+					key = fmt.Sprintf("%s %s", name, exp)
+				}
+				taken := func(k string) bool { return hooks[k] != nil && hooks[k] != cb.Expression[exp] }
+				if taken(key) {
+					prefixed := fmt.Sprintf("%s.%s", prefix, key)
+					key = prefixed
+					for n := 2; taken(key); n++ {
+						key = fmt.Sprintf("%s %d", prefixed, n)
+					}
+				}
+				hooks[key] = cb.Expression[exp]
+			}
+		}
+	}
+	if d.Paths != nil {
+		items := d.Paths.GetPathItems()
+		paths := make([]string, 0, len(items))
+		for path := range items {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			pi := items[path]
+			if pi == nil {
+				continue
+			}
+			for _, method := range []string{low.GetLabel, low.PutLabel, low.PostLabel, low.DeleteLabel,
+				low.OptionsLabel, low.HeadLabel, low.PatchLabel, low.TraceLabel} {
+				op := pi.GetOperations()[method]
+				if op == nil || len(op.Callbacks) == 0 {
+					continue
+				}
+				prefix := op.OperationId
+				if prefix == "" {
+					prefix = fmt.Sprintf("%s %s", method, path)
+				}
+				addCallbacks(prefix, op.Callbacks)
+			}
+		}
+	}
+	return hooks
+}
+
 // GoLow returns the low-level Document that was used to create the high level one.
 func (d *Document) GoLow() *low.Document {
 	return d.low
@@ -165,13 +261,19 @@ func (d *Document) RenderWithIndention(indent int) []byte {
 	return buf.Bytes()
 }
 
-// RenderJSON will return a JSON representation of the Document object as a byte slice.
+// RenderJSON will return a JSON representation of the Document object as a byte slice. Keys are rendered in the
+// same order as they are rendered in YAML (the order of the original document).
 func (d *Document) RenderJSON(indention string) []byte {
-	yamlData, _ := yaml.Marshal(d)
-	dat, _ := utils.ConvertYAMLtoJSONPretty(yamlData, "", indention)
+	dat, _ := utils.ConvertYAMLNodeToJSON(high.NewNodeBuilder(d, d.low).Render(), indention)
 	return dat
 }
 
+// RenderWithOptions will return a representation of the Document object as a byte slice, formatted using the
+// render options (see high.RenderOptions).
+func (d *Document) RenderWithOptions(options *high.RenderOptions) ([]byte, error) {
+	return high.RenderNode(high.NewNodeBuilder(d, d.low).Render(), options)
+}
+
 func (d *Document) RenderInline() ([]byte, error) {
 	di, _ := d.MarshalYAMLInline()
 	return yaml.Marshal(di)
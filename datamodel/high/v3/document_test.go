@@ -150,6 +150,158 @@ func TestNewDocument_Webhooks(t *testing.T) {
 	assert.Equal(t, "Information about a new burger", h.Webhooks["someHook"].Post.RequestBody.Description)
 }
 
+func TestDocument_ConvertCallbacksToWebhooks(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+	hooks := h.ConvertCallbacksToWebhooks()
+	assert.Len(t, hooks, 1)
+	assert.Equal(t, "Callback payload", hooks["burgerCallback"].Post.RequestBody.Description)
+}
+
+func TestDocument_ConvertCallbacksToWebhooks_Collisions(t *testing.T) {
+	yml := `openapi: 3.0.3
+paths:
+  /burgers:
+    post:
+      operationId: createBurger
+      callbacks:
+        cooked:
+          '{$request.body#/cookedUrl}':
+            post:
+              description: cooked
+          '{$request.body#/burntUrl}':
+            post:
+              description: burnt
+  /fries:
+    post:
+      callbacks:
+        cooked:
+          '{$request.body#/friesUrl}':
+            post:
+              description: fries`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDocument, _ := lowv3.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	hooks := NewDocument(lowDocument).ConvertCallbacksToWebhooks()
+	assert.Len(t, hooks, 3)
+	assert.Equal(t, "cooked", hooks["cooked {$request.body#/cookedUrl}"].Post.Description)
+	assert.Equal(t, "burnt", hooks["cooked {$request.body#/burntUrl}"].Post.Description)
+	assert.Equal(t, "fries", hooks["cooked"].Post.Description)
+}
+
+func TestDocument_ConvertCallbacksToWebhooks_DuplicateOperationIds(t *testing.T) {
+	yml := `openapi: 3.0.3
+paths:
+  /burgers:
+    post:
+      operationId: cook
+      callbacks:
+        cooked:
+          '{$request.body#/burgerUrl}':
+            post:
+              description: burger
+  /fries:
+    post:
+      operationId: cook
+      callbacks:
+        cooked:
+          '{$request.body#/friesUrl}':
+            post:
+              description: fries
+  /shakes:
+    post:
+      operationId: cook
+      callbacks:
+        cooked:
+          '{$request.body#/shakeUrl}':
+            post:
+              description: shake`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDocument, _ := lowv3.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	hooks := NewDocument(lowDocument).ConvertCallbacksToWebhooks()
+	assert.Len(t, hooks, 3)
+	assert.Equal(t, "burger", hooks["cooked"].Post.Description)
+	assert.Equal(t, "fries", hooks["cook.cooked"].Post.Description)
+	assert.Equal(t, "shake", hooks["cook.cooked 2"].Post.Description)
+}
+
+func TestNewDocument_Components_PathItems(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /burgers:
+    $ref: '#/components/pathItems/burgers'
+  /fries:
+    get:
+      description: get some fries
+webhooks:
+  newBurger:
+    $ref: '#/components/pathItems/burgers'
+components:
+  pathItems:
+    burgers:
+      get:
+        description: get some burgers`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDocument, _ := lowv3.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	h := NewDocument(lowDocument)
+
+	assert.Len(t, h.Components.PathItems, 1)
+	assert.Equal(t, "get some burgers", h.Components.PathItems["burgers"].Get.Description)
+	assert.False(t, h.Components.PathItems["burgers"].IsReference())
+
+	burgers := h.Paths.PathItems["/burgers"]
+	assert.True(t, burgers.IsReference())
+	assert.Equal(t, "#/components/pathItems/burgers", burgers.GetReference())
+	assert.Equal(t, "get some burgers", burgers.Get.Description)
+
+	fries := h.Paths.PathItems["/fries"]
+	assert.False(t, fries.IsReference())
+	assert.Empty(t, fries.GetReference())
+
+	hook := h.Webhooks["newBurger"]
+	assert.True(t, hook.IsReference())
+	assert.Equal(t, "#/components/pathItems/burgers", hook.GetReference())
+	assert.Equal(t, "get some burgers", hook.Get.Description)
+}
+
+func TestNewDocument_ReferenceSiblings(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /burgers:
+    $ref: '#/components/pathItems/burgers'
+    summary: burgers, overridden
+components:
+  pathItems:
+    burgers:
+      summary: burgers
+      get:
+        responses:
+          "200":
+            $ref: '#/components/responses/ok'
+            description: a burger, overridden
+  responses:
+    ok:
+      description: a burger`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDocument, _ := lowv3.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	h := NewDocument(lowDocument)
+
+	burgers := h.Paths.PathItems["/burgers"]
+	assert.Equal(t, "burgers, overridden", burgers.Summary)
+	assert.Equal(t, "burgers", burgers.GoLow().Summary.Value)
+	assert.Equal(t, "a burger, overridden", burgers.Get.Responses.Codes["200"].Description)
+	assert.Equal(t, "a burger", h.Components.Responses["ok"].Description)
+
+	// siblings survive a render when references are not resolved.
+	rendered, _ := h.Paths.Render()
+	assert.Contains(t, string(rendered), "summary: burgers, overridden")
+	okRendered, _ := burgers.Get.Responses.Render()
+	assert.Contains(t, string(okRendered), "description: a burger, overridden")
+}
+
 func TestNewDocument_Components_Links(t *testing.T) {
 	initTest()
 	h := NewDocument(lowDoc)
@@ -220,7 +372,7 @@ func TestNewDocument_Components_Schemas(t *testing.T) {
 
 	d := h.Components.Schemas["Drink"]
 	assert.Len(t, d.Schema().Required, 2)
-	assert.True(t, d.Schema().AdditionalProperties.(bool))
+	assert.True(t, d.Schema().AdditionalProperties.B)
 	assert.Equal(t, "drinkType", d.Schema().Discriminator.PropertyName)
 	assert.Equal(t, "some value", d.Schema().Discriminator.Mapping["drink"])
 	assert.Equal(t, 516, d.Schema().Discriminator.GoLow().PropertyName.ValueNode.Line)
@@ -728,3 +880,94 @@ components:
 
 	assert.Equal(t, desired, strings.TrimSpace(string(r)))
 }
+
+func TestNewDocument_DynamicRefGenerics(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $dynamicRef: '#node'
+    Leaf:
+      $anchor: leaf
+      type: string
+    Forest:
+      type: array
+      items:
+        $ref: '#leaf'`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDocument, errs := lowv3.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	assert.Len(t, errs, 0)
+	h := NewDocument(lowDocument)
+
+	tree := h.Components.Schemas["Tree"].Schema()
+	assert.Equal(t, "node", tree.DynamicAnchor)
+
+	items := tree.Properties["children"].Schema().Items.A.Schema()
+	assert.Equal(t, "#node", items.DynamicRef)
+
+	// walk the recursive schema a few levels deep.
+	resolved := items.DynamicRefSchema()
+	assert.NotNil(t, resolved)
+	assert.Equal(t, "#node", resolved.GetReference())
+	resolved = resolved.Schema().Properties["children"].Schema().Items.A.Schema().DynamicRefSchema()
+	assert.Equal(t, "object", resolved.Schema().Type[0])
+
+	assert.Nil(t, tree.DynamicRefSchema())
+
+	forest := h.Components.Schemas["Forest"].Schema()
+	assert.Equal(t, "string", forest.Items.A.Schema().Type[0])
+
+	rend, _ := h.Components.Schemas["Tree"].Render()
+	assert.Contains(t, string(rend), "$dynamicRef: '#node'")
+}
+
+func TestNewDocument_DynamicRefEvaluationPath(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    List:
+      $id: https://example.com/list
+      type: array
+      items:
+        $dynamicRef: '#item'
+      $defs:
+        item:
+          $dynamicAnchor: item
+          type: object
+    Strings:
+      $id: https://example.com/strings
+      $ref: list
+      $defs:
+        item:
+          $dynamicAnchor: item
+          type: string
+    Numbers:
+      $id: https://example.com/numbers
+      $ref: list
+      $defs:
+        item:
+          $dynamicAnchor: item
+          type: number`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDocument, errs := lowv3.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	assert.Len(t, errs, 0)
+	h := NewDocument(lowDocument)
+
+	// the same list resolves its items using the schemas that were passed through to reach it.
+	itemType := func(name string) string {
+		items := h.Components.Schemas[name].Schema().Items.A.Schema()
+		assert.Equal(t, "#item", items.DynamicRef)
+		return items.DynamicRefSchema().Schema().Type[0]
+	}
+	assert.Equal(t, "object", itemType("List"))
+	assert.Equal(t, "string", itemType("Strings"))
+	assert.Equal(t, "number", itemType("Numbers"))
+}
@@ -4,7 +4,10 @@
 package v3
 
 import (
+	"strings"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"gopkg.in/yaml.v3"
@@ -62,3 +65,107 @@ func ExtractEncoding(elements map[lowmodel.KeyReference[string]]lowmodel.ValueRe
 	}
 	return extracted
 }
+
+// Default encoding values, as defined by the specification.
+const (
+	EncodingDefaultStyle          = "form"
+	EncodingContentTypeJSON       = "application/json"
+	EncodingContentTypeText       = "text/plain"
+	EncodingContentTypeBinary     = "application/octet-stream"
+	MediaTypeMultipartFormData    = "multipart/form-data"
+	MediaTypeFormURLEncoded       = "application/x-www-form-urlencoded"
+	encodingContentTypeHeaderName = "content-type"
+)
+
+// EffectiveEncoding represents the encoding that applies to a single property of a multipart or form
+// payload, once the defaults defined by the specification have been applied to the Encoding object (if there is one).
+//
+// Headers are only used by multipart media types, Style, Explode and AllowReserved are only used by
+// multipart/form-data and application/x-www-form-urlencoded. Values that are ignored for the media type are left empty.
+type EffectiveEncoding struct {
+	Property      string             // the name of the property being encoded.
+	ContentType   string             // the content type of the property (can be a comma-separated list).
+	Headers       map[string]*Header // headers for the part, excluding Content-Type (which is always ignored).
+	Style         string             // the serialization style of the property, defaults to form.
+	Explode       bool               // defaults to true for the form style, false for all others.
+	AllowReserved bool               // reserved characters are allowed without percent-encoding.
+	Schema        *base.SchemaProxy  // the schema of the property, nil if the property is not defined.
+	Encoding      *Encoding          // the Encoding object defined for the property, nil if there isn't one.
+}
+
+// DefaultEncodingContentType returns the default content type for a property of a multipart or form payload,
+// based on its schema. If contentMediaType has been set, it is used. Binary strings (and strings with a
+// contentEncoding) are application/octet-stream, other primitives are text/plain, objects are application/json
+// and arrays are based on their items. If the schema is missing, application/octet-stream is returned.
+func DefaultEncodingContentType(schema *base.Schema) string {
+	if schema == nil {
+		return EncodingContentTypeBinary
+	}
+	if schema.ContentMediaType != "" {
+		return schema.ContentMediaType
+	}
+	for _, t := range schema.Type {
+		switch t {
+		case "object":
+			return EncodingContentTypeJSON
+		case "array":
+			if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+				return DefaultEncodingContentType(schema.Items.A.Schema())
+			}
+			return EncodingContentTypeBinary
+		case "string":
+			if schema.Format == "binary" || schema.ContentEncoding != "" {
+				return EncodingContentTypeBinary
+			}
+			return EncodingContentTypeText
+		case "integer", "number", "boolean":
+			return EncodingContentTypeText
+		}
+	}
+	if len(schema.Properties) > 0 {
+		return EncodingContentTypeJSON
+	}
+	return EncodingContentTypeBinary
+}
+
+func newEffectiveEncoding(mediaType, property string, schema *base.SchemaProxy, encoding *Encoding) *EffectiveEncoding {
+	ee := &EffectiveEncoding{Property: property, Schema: schema, Encoding: encoding}
+	mt := strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+	unknown := mt == ""
+	multipart := unknown || strings.HasPrefix(mt, "multipart/")
+	form := unknown || mt == MediaTypeMultipartFormData || mt == MediaTypeFormURLEncoded
+
+	if encoding != nil && encoding.ContentType != "" {
+		ee.ContentType = encoding.ContentType
+	} else {
+		var sch *base.Schema
+		if schema != nil {
+			sch = schema.Schema()
+		}
+		ee.ContentType = DefaultEncodingContentType(sch)
+	}
+	if multipart && encoding != nil && len(encoding.Headers) > 0 {
+		ee.Headers = make(map[string]*Header, len(encoding.Headers))
+		for k, v := range encoding.Headers {
+			if strings.ToLower(k) != encodingContentTypeHeaderName {
+				ee.Headers[k] = v
+			}
+		}
+	}
+	if !form {
+		return ee
+	}
+	ee.Style = EncodingDefaultStyle
+	if encoding != nil {
+		if encoding.Style != "" {
+			ee.Style = encoding.Style
+		}
+		ee.AllowReserved = encoding.AllowReserved
+	}
+	ee.Explode = ee.Style == EncodingDefaultStyle
+	if encoding != nil && encoding.Explode != nil &&
+		(encoding.low == nil || !encoding.low.Explode.IsEmpty()) {
+		ee.Explode = *encoding.Explode
+	}
+	return ee
+}
@@ -4,6 +4,7 @@
 package v3
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
@@ -65,3 +66,25 @@ style: simple`
 	assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 
 }
+
+func TestDefaultEncodingContentType(t *testing.T) {
+	assert.Equal(t, EncodingContentTypeBinary, DefaultEncodingContentType(nil))
+	assert.Equal(t, EncodingContentTypeBinary, DefaultEncodingContentType(&base.Schema{}))
+	assert.Equal(t, EncodingContentTypeJSON, DefaultEncodingContentType(&base.Schema{
+		Properties: map[string]*base.SchemaProxy{"a": nil}}))
+	assert.Equal(t, EncodingContentTypeText, DefaultEncodingContentType(&base.Schema{Type: []string{"number"}}))
+	assert.Equal(t, EncodingContentTypeBinary, DefaultEncodingContentType(&base.Schema{
+		Type: []string{"string"}, ContentEncoding: "base64"}))
+	assert.Equal(t, EncodingContentTypeBinary, DefaultEncodingContentType(&base.Schema{Type: []string{"array"}}))
+	assert.Equal(t, EncodingContentTypeJSON, DefaultEncodingContentType(&base.Schema{
+		Type:  []string{"array"},
+		Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"object"}})},
+	}))
+}
+
+func TestEncoding_EffectiveEncoding_Explode(t *testing.T) {
+	explode := true
+	ee := newEffectiveEncoding(MediaTypeFormURLEncoded, "a", nil, &Encoding{Style: "pipeDelimited", Explode: &explode})
+	assert.True(t, ee.Explode)
+	assert.Equal(t, "pipeDelimited", ee.Style)
+}
@@ -4,6 +4,9 @@
 package v3
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
@@ -20,7 +23,7 @@ type Header struct {
 	Deprecated      bool                         `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	AllowEmptyValue bool                         `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
 	Style           string                       `json:"style,omitempty" yaml:"style,omitempty"`
-	Explode         bool                         `json:"explode,omitempty" yaml:"explode,omitempty"`
+	Explode         *bool                        `json:"explode,omitempty" yaml:"explode,omitempty"`
 	AllowReserved   bool                         `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
 	Schema          *highbase.SchemaProxy        `json:"schema,omitempty" yaml:"schema,omitempty"`
 	Example         any                          `json:"example,omitempty" yaml:"example,omitempty"`
@@ -34,12 +37,14 @@ type Header struct {
 func NewHeader(header *low.Header) *Header {
 	h := new(Header)
 	h.low = header
-	h.Description = header.Description.Value
+	h.Description = header.OverrideDescription(header.Description.Value)
 	h.Required = header.Required.Value
 	h.Deprecated = header.Deprecated.Value
 	h.AllowEmptyValue = header.AllowEmptyValue.Value
 	h.Style = header.Style.Value
-	h.Explode = header.Explode.Value
+	if !header.Explode.IsEmpty() {
+		h.Explode = &header.Explode.Value
+	}
 	h.AllowReserved = header.AllowReserved.Value
 	if !header.Schema.IsEmpty() {
 		h.Schema = highbase.NewSchemaProxy(&lowmodel.NodeReference[*base.SchemaProxy]{
@@ -51,6 +56,7 @@ func NewHeader(header *low.Header) *Header {
 	h.Content = ExtractContent(header.Content.Value)
 	h.Example = header.Example.Value
 	h.Examples = highbase.ExtractExamples(header.Examples.Value)
+	h.Extensions = high.ExtractExtensions(header.Extensions)
 	return h
 }
 
@@ -83,3 +89,53 @@ func (h *Header) MarshalYAML() (interface{}, error) {
 	nb := high.NewNodeBuilder(h, h.low)
 	return nb.Render(), nil
 }
+
+// RenderInline will return a YAML representation of the Header object as a byte slice, with all references
+// resolved.
+func (h *Header) RenderInline() ([]byte, error) {
+	d, _ := h.MarshalYAMLInline()
+	return yaml.Marshal(d)
+}
+
+// MarshalYAMLInline will create a ready to render YAML representation of the Header object, with all references
+// resolved.
+func (h *Header) MarshalYAMLInline() (interface{}, error) {
+	nb := high.NewNodeBuilder(h, h.low)
+	nb.Resolve = true
+	return nb.Render(), nil
+}
+
+// IsExploded will return true if the header is exploded, false otherwise.
+func (h *Header) IsExploded() bool {
+	if h.Explode == nil {
+		return false
+	}
+	return *h.Explode
+}
+
+// IsDefaultHeaderEncoding will return true if the header has no exploded value, or has exploded set to false, and
+// no style or a style set to simple. This is the default (and only) serialization style for headers.
+func (h *Header) IsDefaultHeaderEncoding() bool {
+	return (h.Style == "" || h.Style == "simple") && !h.IsExploded()
+}
+
+// Validate checks the header is valid: it must not have a name or location ('name' and 'in' are not allowed, the
+// name of a header is its key, and it's always in a header), the style can only be 'simple', it must have either a
+// schema or content (and content can only have one media type), and it can't have both an example and examples.
+func (h *Header) Validate() []error {
+	var errs []error
+	if h.low != nil {
+		for _, k := range h.low.ForbiddenKeys() {
+			errs = append(errs, fmt.Errorf("the '%s' field is not allowed in a header (line %d, column %d)",
+				k.Value, k.KeyNode.Line, k.KeyNode.Column))
+		}
+	}
+	if h.Style != "" && h.Style != "simple" {
+		errs = append(errs, fmt.Errorf("header has the style '%s', only 'simple' is allowed", h.Style))
+	}
+	errs = append(errs, validateSchemaOrContent("header", h.Schema, h.Content)...)
+	if h.Example != nil && len(h.Examples) > 0 {
+		errs = append(errs, errors.New("header has both an example and examples, only one is allowed"))
+	}
+	return errs
+}
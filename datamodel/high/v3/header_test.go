@@ -4,21 +4,27 @@
 package v3
 
 import (
-	"github.com/pb33f/libopenapi/datamodel/high/base"
-	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestHeader_MarshalYAML(t *testing.T) {
 
+	explode := true
 	header := &Header{
 		Description:     "A header",
 		Required:        true,
 		Deprecated:      true,
 		AllowEmptyValue: true,
 		Style:           "simple",
-		Explode:         true,
+		Explode:         &explode,
 		AllowReserved:   true,
 		Example:         "example",
 		Examples:        map[string]*base.Example{"example": {Value: "example"}},
@@ -43,3 +49,66 @@ x-burgers: why not?`
 	assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 
 }
+
+func TestNewHeader(t *testing.T) {
+
+	yml := `description: a header
+style: simple
+x-pizza: hot
+schema:
+    type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n v3.Header
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	h := NewHeader(&n)
+	assert.Equal(t, "hot", h.Extensions["x-pizza"])
+	assert.Nil(t, h.Explode)
+	assert.False(t, h.IsExploded())
+	assert.True(t, h.IsDefaultHeaderEncoding())
+	assert.Empty(t, h.Validate())
+
+	rend, _ := h.RenderInline()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+
+	explode := true
+	h.Explode = &explode
+	assert.True(t, h.IsExploded())
+	assert.False(t, h.IsDefaultHeaderEncoding())
+}
+
+func TestHeader_Validate(t *testing.T) {
+
+	yml := `name: X-Pizza
+in: header
+schema:
+  type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n v3.Header
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	h := NewHeader(&n)
+	errs := h.Validate()
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "the 'name' field is not allowed in a header (line 1, column 1)")
+	assert.EqualError(t, errs[1], "the 'in' field is not allowed in a header (line 2, column 1)")
+
+	h = &Header{
+		Style:    "form",
+		Schema:   base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+		Content:  map[string]*MediaType{"text/plain": {}, "application/json": {}},
+		Example:  "pizza",
+		Examples: map[string]*base.Example{"pizza": {Value: "pizza"}},
+	}
+	assert.Len(t, h.Validate(), 4)
+}
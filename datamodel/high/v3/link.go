@@ -4,6 +4,9 @@
 package v3
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"gopkg.in/yaml.v3"
@@ -44,7 +47,7 @@ func NewLink(link *low.Link) *Link {
 	}
 	l.Parameters = params
 	l.RequestBody = link.RequestBody.Value
-	l.Description = link.Description.Value
+	l.Description = link.OverrideDescription(link.Description.Value)
 	if link.Server.Value != nil {
 		l.Server = NewServer(link.Server.Value)
 	}
@@ -52,6 +55,104 @@ func NewLink(link *low.Link) *Link {
 	return l
 }
 
+// ParameterValues returns the value of every parameter, as a runtime expression or a literal value. Literal values
+// keep the type they have in the document (like a number or an object), which the Parameters map can't hold.
+func (l *Link) ParameterValues() map[string]*LinkValue {
+	values := make(map[string]*LinkValue, len(l.Parameters))
+	for name, value := range l.Parameters {
+		var lowValue string
+		var node *yaml.Node
+		if l.low != nil {
+			if p := l.low.FindParameter(name); p != nil {
+				lowValue, node = p.Value, p.ValueNode
+			}
+		}
+		values[name] = newLinkValue(value, lowValue, node)
+	}
+	return values
+}
+
+// RequestBodyValue returns the request body, as a runtime expression or a literal value. Literal values keep the
+// type they have in the document (like an object), which the RequestBody string can't hold. Returns nil if there is
+// no request body.
+func (l *Link) RequestBodyValue() *LinkValue {
+	var lowValue string
+	var node *yaml.Node
+	if l.low != nil {
+		lowValue, node = l.low.RequestBody.Value, l.low.RequestBody.ValueNode
+	}
+	v := newLinkValue(l.RequestBody, lowValue, node)
+	if !v.IsExpression() && (v.Literal == nil || v.Literal == "") {
+		return nil
+	}
+	return v
+}
+
+// newLinkValue creates a LinkValue from the value of the yaml node it was built from, unless the value has been
+// changed since it was built.
+func newLinkValue(value, lowValue string, node *yaml.Node) *LinkValue {
+	if node != nil && value == lowValue {
+		var v any
+		if node.Decode(&v) == nil {
+			return NewLinkValue(v)
+		}
+	}
+	return NewLinkValue(value)
+}
+
+// Validate checks the link is valid: that it has either an operationId or an operationRef (not both), and that every
+// parameter value and the request body that is written as a runtime expression is a valid one. If operations are
+// supplied, the operation the link is for must be found in them (see OperationIndex.FindLinkedOperation).
+func (l *Link) Validate(operations *OperationIndex) []error {
+	var errs []error
+	if operations != nil {
+		if _, err := operations.FindLinkedOperation(l); err != nil {
+			errs = append(errs, err)
+		}
+	} else if err := l.checkOperation(); err != nil {
+		errs = append(errs, err)
+	}
+	names := make([]string, 0, len(l.Parameters))
+	for name := range l.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := l.ParameterValues()
+	for _, name := range names {
+		if err := checkLinkValue(values[name]); err != nil {
+			errs = append(errs, fmt.Errorf("link parameter '%s' is invalid: %w", name, err))
+		}
+	}
+	if err := checkLinkValue(l.RequestBodyValue()); err != nil {
+		errs = append(errs, fmt.Errorf("link request body is invalid: %w", err))
+	}
+	return errs
+}
+
+// checkOperation checks the link has either an operationId or an operationRef, not both.
+func (l *Link) checkOperation() error {
+	if l.OperationId != "" && l.OperationRef != "" {
+		return fmt.Errorf("link has both an operationId ('%s') and an operationRef ('%s'), only one is allowed",
+			l.OperationId, l.OperationRef)
+	}
+	if l.OperationId == "" && l.OperationRef == "" {
+		return fmt.Errorf("link has no operationId or operationRef")
+	}
+	return nil
+}
+
+// checkLinkValue returns an error if a literal string is written as a runtime expression, but isn't a valid one.
+func checkLinkValue(v *LinkValue) error {
+	if v == nil || v.IsExpression() {
+		return nil
+	}
+	if s, ok := v.Literal.(string); ok && looksLikeExpression(s) {
+		_, err := ParseRuntimeExpression(s)
+		return err
+	}
+	return nil
+}
+
 // GoLow will return the low-level Link instance used to create the high-level one.
 func (l *Link) GoLow() *low.Link {
 	return l.low
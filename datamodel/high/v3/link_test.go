@@ -4,9 +4,14 @@
 package v3
 
 import (
-	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestLink_MarshalYAML(t *testing.T) {
@@ -35,3 +40,65 @@ server:
 
 	assert.Equal(t, desired, strings.TrimSpace(string(dat)))
 }
+
+func TestLink_Values(t *testing.T) {
+	yml := `operationId: getPet
+parameters:
+  id: $response.body#/id
+  limit: 10
+  broken: $request.nope
+  filter:
+    size: large
+requestBody:
+  name: pizza`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n v3.Link
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+	l := NewLink(&n)
+
+	values := l.ParameterValues()
+	assert.Len(t, values, 4)
+	assert.True(t, values["id"].IsExpression())
+	assert.Equal(t, "/id", values["id"].Expression.Pointer)
+	assert.Equal(t, 10, values["limit"].Literal)
+	assert.Equal(t, map[string]any{"size": "large"}, values["filter"].Literal)
+	assert.Equal(t, map[string]any{"name": "pizza"}, l.RequestBodyValue().Literal)
+
+	errs := l.Validate(nil)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "link parameter 'broken' is invalid")
+
+	// changed values are used, rather than the values in the document.
+	l.Parameters["limit"] = "{$request.query.limit}"
+	l.RequestBody = "$request.body"
+	assert.Equal(t, "limit", l.ParameterValues()["limit"].Expression.Name)
+	assert.Equal(t, "body", l.RequestBodyValue().Expression.Location)
+
+	l = NewLink(new(v3.Link))
+	assert.Empty(t, l.ParameterValues())
+	assert.Nil(t, l.RequestBodyValue())
+}
+
+func TestLink_Validate(t *testing.T) {
+	l := &Link{
+		OperationId: "getPet",
+		Parameters:  map[string]string{"a": "$request.path.id", "b": "$statusCode", "c": "plain"},
+		RequestBody: "{$request.pizza}",
+	}
+	errs := l.Validate(nil)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "link request body is invalid")
+
+	l = &Link{OperationId: "getPet", OperationRef: "#/paths/~1pets/get"}
+	assert.Len(t, l.Validate(nil), 1)
+	assert.Len(t, (&Link{}).Validate(nil), 1)
+
+	errs = (&Link{OperationId: "getPet"}).Validate(NewOperationIndex(nil))
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "link operationId 'getPet' does not exist")
+}
@@ -24,6 +24,7 @@ type MediaType struct {
 	Encoding   map[string]*Encoding     `json:"encoding,omitempty" yaml:"encoding,omitempty"`
 	Extensions map[string]any           `json:"-" yaml:"-"`
 	low        *low.MediaType
+	name       string
 }
 
 // NewMediaType will create a new high-level MediaType instance from a low-level one.
@@ -40,6 +41,63 @@ func NewMediaType(mediaType *low.MediaType) *MediaType {
 	return m
 }
 
+// EffectiveEncoding returns the encoding that applies to a property of the schema for this media type, applying
+// the defaults defined by the specification when there is no Encoding object defined for the property.
+// Properties of allOf schemas are also considered. The media type is known when the MediaType was extracted
+// as part of content, if it's not known, headers and form values are always included.
+func (m *MediaType) EffectiveEncoding(property string) *EffectiveEncoding {
+	return newEffectiveEncoding(m.name, property, m.findProperty(property), m.Encoding[property])
+}
+
+// EffectiveEncodings returns the effective encoding of every property of the schema for this media type, keyed
+// by property name.
+func (m *MediaType) EffectiveEncodings() map[string]*EffectiveEncoding {
+	encodings := make(map[string]*EffectiveEncoding)
+	if m.Schema != nil {
+		if sch := m.Schema.Schema(); sch != nil {
+			for k := range sch.Properties {
+				encodings[k] = m.EffectiveEncoding(k)
+			}
+			for _, a := range sch.AllOf {
+				if as := a.Schema(); as != nil {
+					for k := range as.Properties {
+						if encodings[k] == nil {
+							encodings[k] = m.EffectiveEncoding(k)
+						}
+					}
+				}
+			}
+		}
+	}
+	for k := range m.Encoding {
+		if encodings[k] == nil {
+			encodings[k] = m.EffectiveEncoding(k)
+		}
+	}
+	return encodings
+}
+
+func (m *MediaType) findProperty(property string) *base.SchemaProxy {
+	if m.Schema == nil {
+		return nil
+	}
+	sch := m.Schema.Schema()
+	if sch == nil {
+		return nil
+	}
+	if p, ok := sch.Properties[property]; ok {
+		return p
+	}
+	for _, a := range sch.AllOf {
+		if as := a.Schema(); as != nil {
+			if p, ok := as.Properties[property]; ok {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
 // GoLow will return the low-level instance of MediaType used to create the high-level one.
 func (m *MediaType) GoLow() *low.MediaType {
 	return m.low
@@ -82,7 +140,9 @@ func ExtractContent(elements map[lowmodel.KeyReference[string]]lowmodel.ValueRef
 	extractContentItem := func(k lowmodel.KeyReference[string],
 		v lowmodel.ValueReference[*low.MediaType], c chan bool, e map[string]*MediaType) {
 		extLock.Lock()
-		e[k.Value] = NewMediaType(v.Value)
+		mt := NewMediaType(v.Value)
+		mt.name = k.Value
+		e[k.Value] = mt
 		extLock.Unlock()
 		c <- true
 
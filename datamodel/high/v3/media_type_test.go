@@ -168,3 +168,146 @@ func TestMediaType_Examples(t *testing.T) {
 	rend, _ := r.Render()
 	assert.Len(t, rend, 290)
 }
+
+func getEncodingRequestBody(t *testing.T) *RequestBody {
+	yml := `content:
+  multipart/form-data:
+    schema:
+      type: object
+      properties:
+        id:
+          type: string
+          format: uuid
+        avatar:
+          type: string
+          format: binary
+        address:
+          type: object
+          properties:
+            street:
+              type: string
+        tags:
+          type: array
+          items:
+            type: integer
+        doc:
+          type: string
+          contentMediaType: application/pdf
+      allOf:
+        - type: object
+          properties:
+            extra:
+              type: boolean
+    encoding:
+      avatar:
+        contentType: image/png, image/jpeg
+        headers:
+          X-Rate-Limit:
+            schema:
+              type: integer
+          Content-Type:
+            schema:
+              type: string
+      tags:
+        style: spaceDelimited
+      address:
+        explode: false
+  application/x-www-form-urlencoded:
+    schema:
+      type: object
+      properties:
+        name:
+          type: string
+    encoding:
+      name:
+        allowReserved: true
+        headers:
+          X-Ignored:
+            schema:
+              type: string
+  multipart/mixed:
+    schema:
+      type: object
+      properties:
+        name:
+          type: string
+    encoding:
+      name:
+        style: deepObject
+        headers:
+          X-Part:
+            schema:
+              type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3.RequestBody
+	assert.NoError(t, low.BuildModel(idxNode.Content[0], &n))
+	assert.NoError(t, n.Build(idxNode.Content[0], idx))
+	return NewRequestBody(&n)
+}
+
+func TestMediaType_EffectiveEncoding(t *testing.T) {
+	mt := getEncodingRequestBody(t).Content["multipart/form-data"]
+
+	id := mt.EffectiveEncoding("id")
+	assert.Equal(t, "text/plain", id.ContentType)
+	assert.Equal(t, "form", id.Style)
+	assert.True(t, id.Explode)
+	assert.Nil(t, id.Encoding)
+	assert.NotNil(t, id.Schema)
+
+	avatar := mt.EffectiveEncoding("avatar")
+	assert.Equal(t, "image/png, image/jpeg", avatar.ContentType)
+	assert.Len(t, avatar.Headers, 1)
+	assert.NotNil(t, avatar.Headers["X-Rate-Limit"])
+	assert.NotNil(t, avatar.Encoding)
+
+	address := mt.EffectiveEncoding("address")
+	assert.Equal(t, "application/json", address.ContentType)
+	assert.False(t, address.Explode)
+
+	tags := mt.EffectiveEncoding("tags")
+	assert.Equal(t, "text/plain", tags.ContentType)
+	assert.Equal(t, "spaceDelimited", tags.Style)
+	assert.False(t, tags.Explode)
+
+	assert.Equal(t, "application/pdf", mt.EffectiveEncoding("doc").ContentType)
+	assert.Equal(t, "text/plain", mt.EffectiveEncoding("extra").ContentType)
+
+	missing := mt.EffectiveEncoding("missing")
+	assert.Nil(t, missing.Schema)
+	assert.Equal(t, "application/octet-stream", missing.ContentType)
+}
+
+func TestMediaType_EffectiveEncoding_MediaTypes(t *testing.T) {
+	rb := getEncodingRequestBody(t)
+
+	// headers are ignored for forms.
+	name := rb.Content["application/x-www-form-urlencoded"].EffectiveEncoding("name")
+	assert.Nil(t, name.Headers)
+	assert.True(t, name.AllowReserved)
+	assert.Equal(t, "form", name.Style)
+
+	// style is ignored for multipart media types that are not forms.
+	name = rb.Content["multipart/mixed"].EffectiveEncoding("name")
+	assert.Len(t, name.Headers, 1)
+	assert.Empty(t, name.Style)
+	assert.False(t, name.Explode)
+}
+
+func TestMediaType_EffectiveEncodings(t *testing.T) {
+	mt := getEncodingRequestBody(t).Content["multipart/form-data"]
+	encodings := mt.EffectiveEncodings()
+	assert.Len(t, encodings, 6)
+	assert.Equal(t, "image/png, image/jpeg", encodings["avatar"].ContentType)
+	assert.Equal(t, "extra", encodings["extra"].Property)
+
+	// a media type built on its own, does not know what it is, so everything applies.
+	mt = NewMediaType(mt.GoLow())
+	avatar := mt.EffectiveEncoding("avatar")
+	assert.Len(t, avatar.Headers, 1)
+	assert.Equal(t, "form", avatar.Style)
+}
@@ -0,0 +1,25 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import "github.com/pb33f/libopenapi/datamodel/high/base"
+
+// NamedExamples returns the example and every entry of the examples map of the media type as a single list, in
+// the order they are found in the document (see base.NamedExamples).
+func (m *MediaType) NamedExamples() []*base.NamedExample {
+	return base.NamedExamples(m.Example, m.Examples, nil)
+}
+
+// NamedExamples returns the example and every entry of the examples map of the parameter as a single list, in the
+// order they are found in the document (see base.NamedExamples). The examples of the parameter schema (or the
+// media type of its content) are not included.
+func (p *Parameter) NamedExamples() []*base.NamedExample {
+	return base.NamedExamples(p.Example, p.Examples, nil)
+}
+
+// NamedExamples returns the example and every entry of the examples map of the header as a single list, in the
+// order they are found in the document (see base.NamedExamples).
+func (h *Header) NamedExamples() []*base.NamedExample {
+	return base.NamedExamples(h.Example, h.Examples, nil)
+}
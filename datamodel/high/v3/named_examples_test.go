@@ -0,0 +1,63 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMediaType_NamedExamples(t *testing.T) {
+	yml := `schema:
+  type: string
+  examples: [hawaiian]
+examples:
+  pepperoni:
+    value: pepperoni
+  cheese:
+    summary: just cheese
+    value: cheese
+  anchovy:
+    value: anchovy`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n v3.MediaType
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	mt := NewMediaType(&n)
+	named := mt.NamedExamples()
+	assert.Len(t, named, 3)
+
+	// examples are in the order of the document, the examples of the schema are not included.
+	var names []string
+	for _, ex := range named {
+		names = append(names, ex.Name)
+		assert.Equal(t, base.ExamplesMap, ex.Kind)
+	}
+	assert.Equal(t, []string{"pepperoni", "cheese", "anchovy"}, names)
+	assert.Equal(t, "cheese", named[1].Value)
+	assert.Equal(t, "just cheese", named[1].Example.Summary)
+
+	assert.Equal(t, "hawaiian", mt.Schema.Schema().NamedExamples()[0].Value)
+}
+
+func TestParameter_NamedExamples(t *testing.T) {
+	p := &Parameter{Example: "large"}
+	assert.Equal(t, []*base.NamedExample{{Name: "example", Kind: base.ExampleField, Value: "large"}},
+		p.NamedExamples())
+
+	h := &Header{Examples: map[string]*base.Example{"limit": {Value: 100}}}
+	assert.Equal(t, 100, h.NamedExamples()[0].Value)
+	assert.Empty(t, new(MediaType).NamedExamples())
+}
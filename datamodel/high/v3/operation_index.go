@@ -0,0 +1,170 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// OperationRef is an operation, along with the path and method it's defined under.
+type OperationRef struct {
+	Path      string
+	Method    string
+	Operation *Operation
+}
+
+// OperationIndex holds every operation defined under the paths of a document, indexed by operationId and tag.
+// Operations are held in order of path (sorted) and then method (get, put, post, delete, options, head, patch,
+// trace).
+type OperationIndex struct {
+	operations []*OperationRef
+	byID       map[string][]*OperationRef
+	byTag      map[string][]*OperationRef
+}
+
+// operationIndexCache holds the index of operations built for a document, once.
+type operationIndexCache struct {
+	once  sync.Once
+	index *OperationIndex
+}
+
+// NewOperationIndex builds a new OperationIndex from every operation found in the supplied paths.
+func NewOperationIndex(paths *Paths) *OperationIndex {
+	idx := &OperationIndex{
+		byID:  make(map[string][]*OperationRef),
+		byTag: make(map[string][]*OperationRef),
+	}
+	if paths == nil {
+		return idx
+	}
+	items := paths.GetPathItems()
+	keys := make([]string, 0, len(items))
+	for path := range items {
+		keys = append(keys, path)
+	}
+	sort.Strings(keys)
+	for _, path := range keys {
+		pi := items[path]
+		if pi == nil {
+			continue
+		}
+		ops := pi.GetOperations()
+		for _, method := range []string{low.GetLabel, low.PutLabel, low.PostLabel, low.DeleteLabel,
+			low.OptionsLabel, low.HeadLabel, low.PatchLabel, low.TraceLabel} {
+			op := ops[method]
+			if op == nil {
+				continue
+			}
+			ref := &OperationRef{Path: path, Method: method, Operation: op}
+			idx.operations = append(idx.operations, ref)
+			if op.OperationId != "" {
+				idx.byID[op.OperationId] = append(idx.byID[op.OperationId], ref)
+			}
+			for _, tag := range op.Tags {
+				idx.byTag[tag] = append(idx.byTag[tag], ref)
+			}
+		}
+	}
+	return idx
+}
+
+// GetOperations returns every operation in the index.
+func (o *OperationIndex) GetOperations() []*OperationRef {
+	return o.operations
+}
+
+// FindOperationByID returns the operation with the supplied operationId, nil if there is no such operation.
+// If the operationId is not unique, the first operation using it is returned (see DuplicateOperationIDs).
+func (o *OperationIndex) FindOperationByID(operationId string) *OperationRef {
+	if ops := o.byID[operationId]; len(ops) > 0 {
+		return ops[0]
+	}
+	return nil
+}
+
+// FindLinkedOperation returns the operation a link is for, found by its operationId or operationRef. An error is
+// returned if the operation can't be found, or if the link has both (or neither) an operationId and an operationRef.
+// Only operationRefs to operations in this document can be found, like '#/paths/~1pets~1{id}/get'.
+func (o *OperationIndex) FindLinkedOperation(link *Link) (*OperationRef, error) {
+	if err := link.checkOperation(); err != nil {
+		return nil, err
+	}
+	if link.OperationId != "" {
+		if op := o.FindOperationByID(link.OperationId); op != nil {
+			return op, nil
+		}
+		return nil, fmt.Errorf("link operationId '%s' does not exist", link.OperationId)
+	}
+	ref := link.OperationRef
+	pointer, ok := strings.CutPrefix(ref, "#/paths/")
+	if !ok {
+		return nil, fmt.Errorf("link operationRef '%s' does not point to an operation in this document", ref)
+	}
+	path, method, ok := strings.Cut(pointer, "/")
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+	path = utils.UnescapeJSONPointerSegment(path)
+	if ok {
+		for _, op := range o.operations {
+			if op.Path == path && op.Method == strings.ToLower(method) {
+				return op, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("link operationRef '%s' does not exist", ref)
+}
+
+// OperationsForTag returns every operation tagged with the supplied tag.
+func (o *OperationIndex) OperationsForTag(tag string) []*OperationRef {
+	return o.byTag[tag]
+}
+
+// DuplicateOperationIDs returns every operationId used by more than one operation, along with the operations that
+// use it. operationIds are required to be unique, so an empty map is returned for a valid document.
+func (o *OperationIndex) DuplicateOperationIDs() map[string][]*OperationRef {
+	dupes := make(map[string][]*OperationRef)
+	for id, ops := range o.byID {
+		if len(ops) > 1 {
+			dupes[id] = ops
+		}
+	}
+	return dupes
+}
+
+// GetOperationIndex returns an index of every operation defined under the paths of the document. For documents
+// created with NewDocument, the index is built once on first use, so changes made to the paths of the model after
+// that are not reflected (use NewOperationIndex to build a fresh one).
+func (d *Document) GetOperationIndex() *OperationIndex {
+	if d.operations == nil {
+		return NewOperationIndex(d.Paths)
+	}
+	d.operations.once.Do(func() {
+		d.operations.index = NewOperationIndex(d.Paths)
+	})
+	return d.operations.index
+}
+
+// FindOperationByID returns the operation with the supplied operationId, along with its path and method.
+// Returns nil if there is no such operation.
+func (d *Document) FindOperationByID(operationId string) *OperationRef {
+	return d.GetOperationIndex().FindOperationByID(operationId)
+}
+
+// FindLinkedOperation returns the operation a link is for, see OperationIndex.FindLinkedOperation.
+func (d *Document) FindLinkedOperation(link *Link) (*OperationRef, error) {
+	return d.GetOperationIndex().FindLinkedOperation(link)
+}
+
+// OperationsForTag returns every operation tagged with the supplied tag, along with their paths and methods.
+func (d *Document) OperationsForTag(tag string) []*OperationRef {
+	return d.GetOperationIndex().OperationsForTag(tag)
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildOperationIndexDocument(t *testing.T, yml string) *Document {
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewOpenDocumentConfiguration())
+	assert.Empty(t, err)
+	return NewDocument(lowDoc)
+}
+
+var operationIndexSpec = `openapi: 3.1.0
+paths:
+  /pets/{id}:
+    delete:
+      operationId: deletePet
+      tags: [pets]
+    get:
+      operationId: getPetById
+      tags: [pets, read]
+  /pets:
+    post:
+      operationId: createPet
+      tags: [pets]
+    get:
+      operationId: listPets
+      tags: [pets, read]
+  /stores:
+    get:
+      operationId: listPets
+    put:
+      description: no operationId`
+
+func TestDocument_FindOperationByID(t *testing.T) {
+	doc := buildOperationIndexDocument(t, operationIndexSpec)
+
+	op := doc.FindOperationByID("getPetById")
+	assert.NotNil(t, op)
+	assert.Equal(t, "/pets/{id}", op.Path)
+	assert.Equal(t, "get", op.Method)
+	assert.Equal(t, doc.Paths.PathItems["/pets/{id}"].Get, op.Operation)
+
+	// duplicate, the first (by path) wins.
+	op = doc.FindOperationByID("listPets")
+	assert.Equal(t, "/pets", op.Path)
+
+	assert.Nil(t, doc.FindOperationByID("pizza"))
+	assert.Nil(t, doc.FindOperationByID(""))
+}
+
+func TestDocument_OperationsForTag(t *testing.T) {
+	doc := buildOperationIndexDocument(t, operationIndexSpec)
+
+	ops := doc.OperationsForTag("pets")
+	assert.Len(t, ops, 4)
+	var ids []string
+	for _, op := range ops {
+		ids = append(ids, op.Method+" "+op.Path)
+	}
+	assert.Equal(t, []string{"get /pets", "post /pets", "get /pets/{id}", "delete /pets/{id}"}, ids)
+
+	assert.Len(t, doc.OperationsForTag("read"), 2)
+	assert.Empty(t, doc.OperationsForTag("stores"))
+}
+
+func TestDocument_GetOperationIndex(t *testing.T) {
+	doc := buildOperationIndexDocument(t, operationIndexSpec)
+
+	idx := doc.GetOperationIndex()
+	assert.Same(t, idx, doc.GetOperationIndex())
+	assert.Len(t, idx.GetOperations(), 6)
+
+	dupes := idx.DuplicateOperationIDs()
+	assert.Len(t, dupes, 1)
+	assert.Len(t, dupes["listPets"], 2)
+	assert.Equal(t, "/pets", dupes["listPets"][0].Path)
+	assert.Equal(t, "/stores", dupes["listPets"][1].Path)
+}
+
+func TestDocument_GetOperationIndex_NoDocument(t *testing.T) {
+	doc := &Document{}
+	assert.Nil(t, doc.FindOperationByID("getPetById"))
+	assert.Empty(t, doc.OperationsForTag("pets"))
+	assert.Empty(t, doc.GetOperationIndex().DuplicateOperationIDs())
+}
+
+func TestNewOperationIndex_Unique(t *testing.T) {
+	doc := buildOperationIndexDocument(t, `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+    post:
+      operationId: createBurger`)
+
+	idx := NewOperationIndex(doc.Paths)
+	assert.Len(t, idx.GetOperations(), 2)
+	assert.Empty(t, idx.DuplicateOperationIDs())
+	assert.Equal(t, "post", idx.FindOperationByID("createBurger").Method)
+}
+
+func TestDocument_FindLinkedOperation(t *testing.T) {
+	doc := buildOperationIndexDocument(t, operationIndexSpec)
+
+	op, err := doc.FindLinkedOperation(&Link{OperationId: "deletePet"})
+	assert.NoError(t, err)
+	assert.Equal(t, "delete", op.Method)
+
+	op, err = doc.FindLinkedOperation(&Link{OperationRef: "#/paths/~1pets~1{id}/get"})
+	assert.NoError(t, err)
+	assert.Equal(t, "getPetById", op.Operation.OperationId)
+
+	op, err = doc.FindLinkedOperation(&Link{OperationRef: "#/paths/~1pets~1%7Bid%7D/delete"})
+	assert.NoError(t, err)
+	assert.Equal(t, "deletePet", op.Operation.OperationId)
+
+	_, err = doc.FindLinkedOperation(&Link{OperationId: "feedPet"})
+	assert.EqualError(t, err, "link operationId 'feedPet' does not exist")
+
+	_, err = doc.FindLinkedOperation(&Link{OperationRef: "#/paths/~1pets/delete"})
+	assert.EqualError(t, err, "link operationRef '#/paths/~1pets/delete' does not exist")
+
+	_, err = doc.FindLinkedOperation(&Link{OperationRef: "#/paths/~1pets"})
+	assert.Error(t, err)
+
+	_, err = doc.FindLinkedOperation(&Link{OperationRef: "https://pb33f.io/openapi.yaml#/paths/~1pets/get"})
+	assert.EqualError(t, err, "link operationRef 'https://pb33f.io/openapi.yaml#/paths/~1pets/get' "+
+		"does not point to an operation in this document")
+
+	_, err = doc.FindLinkedOperation(&Link{OperationId: "listPets", OperationRef: "#/paths/~1pets/get"})
+	assert.Error(t, err)
+
+	_, err = doc.FindLinkedOperation(&Link{})
+	assert.EqualError(t, err, "link has no operationId or operationRef")
+}
@@ -38,7 +38,7 @@ func NewParameter(param *low.Parameter) *Parameter {
 	p.low = param
 	p.Name = param.Name.Value
 	p.In = param.In.Value
-	p.Description = param.Description.Value
+	p.Description = param.OverrideDescription(param.Description.Value)
 	p.Deprecated = param.Deprecated.Value
 	p.AllowEmptyValue = param.AllowEmptyValue.Value
 	p.Style = param.Style.Value
@@ -0,0 +1,99 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// A parameter (or header) describes its value in one of two ways: with a schema (serialized using the style,
+// explode and allowReserved fields), or with content, a map holding exactly one media type that describes the
+// value (along with its own schema). It can't use both, and must use one of them.
+//   - https://spec.openapis.org/oas/v3.1.0#fixed-fields-10
+
+// UsesContent returns true if the parameter describes its value with content (a media type), rather than a schema.
+func (p *Parameter) UsesContent() bool {
+	return p.Schema == nil && len(p.Content) > 0
+}
+
+// EffectiveSchema returns the schema of the parameter value, whichever way it's described: the schema of the
+// parameter, or the schema of the media type in its content. Returns nil if there is no schema.
+func (p *Parameter) EffectiveSchema() *base.SchemaProxy {
+	return effectiveSchema(p.Schema, p.Content)
+}
+
+// EffectiveMediaType returns the media type of the parameter content, along with its name (like
+// 'application/json'). Returns nil if the parameter uses a schema. If the content has more than one media type
+// (which is not valid), the first one (sorted by name) is returned.
+func (p *Parameter) EffectiveMediaType() (string, *MediaType) {
+	return effectiveMediaType(p.Schema, p.Content)
+}
+
+// Validate checks the parameter has either a schema or content, not both, and that content holds exactly one
+// media type. It also checks the parameter doesn't have both an example and examples.
+func (p *Parameter) Validate() []error {
+	errs := validateSchemaOrContent("parameter", p.Schema, p.Content)
+	if p.Example != nil && len(p.Examples) > 0 {
+		errs = append(errs, errors.New("parameter has both an example and examples, only one is allowed"))
+	}
+	return errs
+}
+
+// UsesContent returns true if the header describes its value with content (a media type), rather than a schema.
+func (h *Header) UsesContent() bool {
+	return h.Schema == nil && len(h.Content) > 0
+}
+
+// EffectiveSchema returns the schema of the header value, whichever way it's described: the schema of the header,
+// or the schema of the media type in its content. Returns nil if there is no schema.
+func (h *Header) EffectiveSchema() *base.SchemaProxy {
+	return effectiveSchema(h.Schema, h.Content)
+}
+
+// EffectiveMediaType returns the media type of the header content, along with its name. Returns nil if the header
+// uses a schema, see Parameter.EffectiveMediaType.
+func (h *Header) EffectiveMediaType() (string, *MediaType) {
+	return effectiveMediaType(h.Schema, h.Content)
+}
+
+func effectiveSchema(schema *base.SchemaProxy, content map[string]*MediaType) *base.SchemaProxy {
+	if schema != nil {
+		return schema
+	}
+	if _, mt := effectiveMediaType(nil, content); mt != nil {
+		return mt.Schema
+	}
+	return nil
+}
+
+func effectiveMediaType(schema *base.SchemaProxy, content map[string]*MediaType) (string, *MediaType) {
+	if schema != nil || len(content) == 0 {
+		return "", nil
+	}
+	names := make([]string, 0, len(content))
+	for name := range content {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names[0], content[names[0]]
+}
+
+// validateSchemaOrContent checks a parameter (or header) has either a schema or content with a single media type.
+func validateSchemaOrContent(kind string, schema *base.SchemaProxy, content map[string]*MediaType) []error {
+	var errs []error
+	switch {
+	case schema != nil && len(content) > 0:
+		errs = append(errs, fmt.Errorf("%s has both a schema and content, only one is allowed", kind))
+	case schema == nil && len(content) == 0:
+		errs = append(errs, fmt.Errorf("%s has no schema or content, one is required", kind))
+	}
+	if len(content) > 1 {
+		errs = append(errs, fmt.Errorf("%s content has %d media types, only one is allowed", kind, len(content)))
+	}
+	return errs
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameter_EffectiveSchema(t *testing.T) {
+	schema := base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})
+	p := &Parameter{Name: "size", In: "query", Schema: schema}
+	assert.False(t, p.UsesContent())
+	assert.Equal(t, schema, p.EffectiveSchema())
+	name, mt := p.EffectiveMediaType()
+	assert.Empty(t, name)
+	assert.Nil(t, mt)
+	assert.Empty(t, p.Validate())
+
+	json := &MediaType{Schema: base.CreateSchemaProxy(&base.Schema{Type: []string{"object"}})}
+	p = &Parameter{Name: "filter", In: "query", Content: map[string]*MediaType{"application/json": json}}
+	assert.True(t, p.UsesContent())
+	assert.Equal(t, json.Schema, p.EffectiveSchema())
+	name, mt = p.EffectiveMediaType()
+	assert.Equal(t, "application/json", name)
+	assert.Equal(t, json, mt)
+	assert.Empty(t, p.Validate())
+
+	p = &Parameter{Name: "filter", In: "query"}
+	assert.Nil(t, p.EffectiveSchema())
+	assert.False(t, p.UsesContent())
+	assert.Len(t, p.Validate(), 1)
+	assert.EqualError(t, p.Validate()[0], "parameter has no schema or content, one is required")
+}
+
+func TestParameter_Validate(t *testing.T) {
+	p := &Parameter{
+		Schema: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+		Content: map[string]*MediaType{
+			"text/plain":       {},
+			"application/json": {},
+		},
+		Example:  "pizza",
+		Examples: map[string]*base.Example{"pizza": {Value: "pizza"}},
+	}
+	errs := p.Validate()
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "parameter has both a schema and content, only one is allowed")
+	assert.EqualError(t, errs[1], "parameter content has 2 media types, only one is allowed")
+	assert.EqualError(t, errs[2], "parameter has both an example and examples, only one is allowed")
+
+	// the first media type is used, when there is more than one.
+	p.Schema = nil
+	name, _ := p.EffectiveMediaType()
+	assert.Equal(t, "application/json", name)
+}
+
+func TestHeader_EffectiveSchema(t *testing.T) {
+	text := &MediaType{Schema: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})}
+	h := &Header{Content: map[string]*MediaType{"text/plain": text}}
+	assert.True(t, h.UsesContent())
+	assert.Equal(t, text.Schema, h.EffectiveSchema())
+	name, mt := h.EffectiveMediaType()
+	assert.Equal(t, "text/plain", name)
+	assert.Equal(t, text, mt)
+	assert.Empty(t, h.Validate())
+
+	assert.EqualError(t, new(Header).Validate()[0], "header has no schema or content, one is required")
+}
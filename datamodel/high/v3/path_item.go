@@ -47,8 +47,8 @@ type PathItem struct {
 func NewPathItem(pathItem *low.PathItem) *PathItem {
 	pi := new(PathItem)
 	pi.low = pathItem
-	pi.Description = pathItem.Description.Value
-	pi.Summary = pathItem.Summary.Value
+	pi.Description = pathItem.OverrideDescription(pathItem.Description.Value)
+	pi.Summary = pathItem.OverrideSummary(pathItem.Summary.Value)
 	pi.Extensions = high.ExtractExtensions(pathItem.Extensions)
 	var servers []*Server
 	for _, ser := range pathItem.Servers.Value {
@@ -129,6 +129,23 @@ func (p *PathItem) GoLowUntyped() any {
 	return p.low
 }
 
+// IsReference returns true if the PathItem was pulled in via a $ref, for example from a 'paths' or 'webhooks'
+// entry pointing to 'components/pathItems' (OpenAPI 3.1+).
+func (p *PathItem) IsReference() bool {
+	if p.low == nil || p.low.Reference == nil {
+		return false
+	}
+	return p.low.IsReference()
+}
+
+// GetReference returns the $ref value the PathItem was resolved from, or an empty string if it is not a reference.
+func (p *PathItem) GetReference() string {
+	if !p.IsReference() {
+		return ""
+	}
+	return p.low.GetReference()
+}
+
 func (p *PathItem) GetOperations() map[string]*Operation {
 	o := make(map[string]*Operation)
 	if p.Get != nil {
@@ -5,6 +5,7 @@ package v3
 
 import (
 	"sort"
+	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -18,10 +19,20 @@ import (
 // Server Object in order to construct the full URL. The Paths MAY be empty, due to Access Control List (ACL)
 // constraints.
 //   - https://spec.openapis.org/oas/v3.1.0#paths-object
+//
+// Paths created using NewPathsLazily only build a PathItem when it's first requested, using GetPathItem or
+// GetPathItems. PathItems only holds the items built so far.
 type Paths struct {
 	PathItems  map[string]*PathItem `json:"-" yaml:"-"`
 	Extensions map[string]any       `json:"-" yaml:"-"`
 	low        *low.Paths
+	lazy       *lazyPathItems
+}
+
+// lazyPathItems guards the path items of lazily built Paths.
+type lazyPathItems struct {
+	lock  sync.Mutex
+	built bool // true once every path item has been built.
 }
 
 // NewPaths creates a new high-level instance of Paths from a low-level one.
@@ -55,6 +66,57 @@ func NewPaths(paths *low.Paths) *Paths {
 	return p
 }
 
+// NewPathsLazily creates a new high-level instance of Paths from a low-level one, without building any of the path
+// items. Each PathItem is built the first time it's requested using GetPathItem or GetPathItems, which are safe to
+// use from multiple goroutines.
+func NewPathsLazily(paths *low.Paths) *Paths {
+	p := new(Paths)
+	p.low = paths
+	p.lazy = new(lazyPathItems)
+	p.Extensions = high.ExtractExtensions(paths.Extensions)
+	p.PathItems = make(map[string]*PathItem)
+	return p
+}
+
+// GetPathItem returns the PathItem for a path, or nil if there is no such path. If the paths are built lazily, the
+// PathItem is built the first time it's requested.
+func (p *Paths) GetPathItem(path string) *PathItem {
+	if p.lazy == nil {
+		return p.PathItems[path]
+	}
+	p.lazy.lock.Lock()
+	defer p.lazy.lock.Unlock()
+	if pi, ok := p.PathItems[path]; ok || p.lazy.built {
+		return pi
+	}
+	lpi := p.low.FindPath(path)
+	if lpi == nil {
+		return nil
+	}
+	pi := NewPathItem(lpi.Value)
+	p.PathItems[path] = pi
+	return pi
+}
+
+// GetPathItems returns every PathItem, by path. If the paths are built lazily, any PathItem that has not been built
+// yet is built first.
+func (p *Paths) GetPathItems() map[string]*PathItem {
+	if p.lazy == nil {
+		return p.PathItems
+	}
+	p.lazy.lock.Lock()
+	defer p.lazy.lock.Unlock()
+	if !p.lazy.built {
+		for k, v := range p.low.PathItems {
+			if _, ok := p.PathItems[k.Value]; !ok {
+				p.PathItems[k.Value] = NewPathItem(v.Value)
+			}
+		}
+		p.lazy.built = true
+	}
+	return p.PathItems
+}
+
 // GoLow returns the low-level Paths instance used to create the high-level one.
 func (p *Paths) GoLow() *low.Paths {
 	return p.low
@@ -87,7 +149,7 @@ func (p *Paths) MarshalYAML() (interface{}, error) {
 	}
 	var mapped []*pathItem
 
-	for k, pi := range p.PathItems {
+	for k, pi := range p.GetPathItems() {
 		ln := 9999 // default to a high value to weight new content to the bottom.
 		if p.low != nil {
 			lpi := p.low.FindPath(k)
@@ -141,7 +203,7 @@ func (p *Paths) MarshalYAMLInline() (interface{}, error) {
 	}
 	var mapped []*pathItem
 
-	for k, pi := range p.PathItems {
+	for k, pi := range p.GetPathItems() {
 		ln := 9999 // default to a high value to weight new content to the bottom.
 		if p.low != nil {
 			lpi := p.low.FindPath(k)
@@ -117,3 +117,49 @@ func TestPaths_MarshalYAMLInline(t *testing.T) {
 	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
 
 }
+
+func TestNewPathsLazily(t *testing.T) {
+	yml := `/foo/bar/bizzle:
+    get:
+        description: get a bizzle
+/jim/jam/jizzle:
+    post:
+        description: post a jizzle
+/beer:
+    get:
+        description: get a beer now.`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3low.Paths
+	_ = low.BuildModel(&idxNode, &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	high := NewPathsLazily(&n)
+	assert.Empty(t, high.PathItems)
+	assert.Nil(t, high.GetPathItem("/nope"))
+
+	// build the same path item from many goroutines.
+	items := make(chan *PathItem)
+	for i := 0; i < 10; i++ {
+		go func() {
+			items <- high.GetPathItem("/beer")
+		}()
+	}
+	beer := <-items
+	for i := 1; i < 10; i++ {
+		assert.Same(t, beer, <-items)
+	}
+	assert.Equal(t, "get a beer now.", beer.Get.Description)
+	assert.Len(t, high.PathItems, 1)
+
+	all := high.GetPathItems()
+	assert.Len(t, all, 3)
+	assert.Same(t, beer, all["/beer"])
+	assert.Equal(t, "post a jizzle", high.GetPathItem("/jim/jam/jizzle").Post.Description)
+
+	rend, _ := high.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+}
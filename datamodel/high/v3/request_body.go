@@ -23,7 +23,7 @@ type RequestBody struct {
 func NewRequestBody(rb *low.RequestBody) *RequestBody {
 	r := new(RequestBody)
 	r.low = rb
-	r.Description = rb.Description.Value
+	r.Description = rb.OverrideDescription(rb.Description.Value)
 	if rb.Required.ValueNode != nil {
 		r.Required = &rb.Required.Value
 	}
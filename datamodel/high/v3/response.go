@@ -27,7 +27,7 @@ type Response struct {
 func NewResponse(response *low.Response) *Response {
 	r := new(Response)
 	r.low = response
-	r.Description = response.Description.Value
+	r.Description = response.OverrideDescription(response.Description.Value)
 	if !response.Headers.IsEmpty() {
 		r.Headers = ExtractHeaders(response.Headers.Value)
 	}
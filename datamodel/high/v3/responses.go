@@ -5,7 +5,6 @@ package v3
 
 import (
 	"fmt"
-	"sort"
 
 	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -77,6 +76,16 @@ func (r *Responses) FindResponseByCode(code int) *Response {
 	return r.Codes[fmt.Sprintf("%d", code)]
 }
 
+// Keys returns every response code, 'default' (if there is a default response) and every extension, in the order
+// they are found in the document. Codes, the default and extensions added after building go last, in that order
+// (sorted). The Codes and Extensions maps have no order, use Keys to visit them in the author's order.
+func (r *Responses) Keys() []string {
+	if r.low == nil {
+		return high.ResponseKeys(nil, r.Codes, r.Default != nil, r.Extensions)
+	}
+	return high.ResponseKeys(r.low.Keys(), r.Codes, r.Default != nil, r.Extensions)
+}
+
 // GoLow returns the low-level Response object used to create the high-level one.
 func (r *Responses) GoLow() *low.Responses {
 	return r.low
@@ -99,115 +108,41 @@ func (r *Responses) RenderInline() ([]byte, error) {
 
 // MarshalYAML will create a ready to render YAML representation of the Responses object.
 func (r *Responses) MarshalYAML() (interface{}, error) {
-	// map keys correctly.
-	m := utils.CreateEmptyMapNode()
-	type responseItem struct {
-		resp *Response
-		code string
-		line int
-		ext  *yaml.Node
-	}
-	var mapped []*responseItem
-
-	for k, re := range r.Codes {
-		ln := 9999 // default to a high value to weight new content to the bottom.
-		if r.low != nil {
-			for lKey := range r.low.Codes {
-				if lKey.Value == k {
-					ln = lKey.KeyNode.Line
-				}
-			}
-		}
-		mapped = append(mapped, &responseItem{re, k, ln, nil})
-	}
-
-	// extract extensions
-	nb := high.NewNodeBuilder(r, r.low)
-	extNode := nb.Render()
-	if extNode != nil && extNode.Content != nil {
-		var label string
-		for u := range extNode.Content {
-			if u%2 == 0 {
-				label = extNode.Content[u].Value
-				continue
-			}
-			mapped = append(mapped, &responseItem{nil, label,
-				extNode.Content[u].Line, extNode.Content[u]})
-		}
-	}
-
-	sort.Slice(mapped, func(i, j int) bool {
-		return mapped[i].line < mapped[j].line
-	})
-	for j := range mapped {
-		if mapped[j].resp != nil {
-			rendered, _ := mapped[j].resp.MarshalYAML()
-			m.Content = append(m.Content, utils.CreateStringNode(mapped[j].code))
-			m.Content = append(m.Content, rendered.(*yaml.Node))
-		}
-		if mapped[j].ext != nil {
-			m.Content = append(m.Content, utils.CreateStringNode(mapped[j].code))
-			m.Content = append(m.Content, mapped[j].ext)
-		}
-
-	}
-	return m, nil
+	return r.marshalYAML(false)
 }
 
 func (r *Responses) MarshalYAMLInline() (interface{}, error) {
-	// map keys correctly.
+	return r.marshalYAML(true)
+}
+
+// marshalYAML renders the codes, the default response and extensions in the order of Keys.
+func (r *Responses) marshalYAML(inline bool) (interface{}, error) {
 	m := utils.CreateEmptyMapNode()
-	type responseItem struct {
-		resp *Response
-		code string
-		line int
-		ext  *yaml.Node
-	}
-	var mapped []*responseItem
-
-	for k, re := range r.Codes {
-		ln := 9999 // default to a high value to weight new content to the bottom.
-		if r.low != nil {
-			for lKey := range r.low.Codes {
-				if lKey.Value == k {
-					ln = lKey.KeyNode.Line
-				}
-			}
-		}
-		mapped = append(mapped, &responseItem{re, k, ln, nil})
-	}
 
-	// extract extensions
+	// the default response and extensions are rendered by the node builder.
 	nb := high.NewNodeBuilder(r, r.low)
-	nb.Resolve = true
-	extNode := nb.Render()
-	if extNode != nil && extNode.Content != nil {
-		var label string
-		for u := range extNode.Content {
-			if u%2 == 0 {
-				label = extNode.Content[u].Value
-				continue
-			}
-			mapped = append(mapped, &responseItem{nil, label,
-				extNode.Content[u].Line, extNode.Content[u]})
+	nb.Resolve = inline
+	rendered := make(map[string]*yaml.Node)
+	if n := nb.Render(); n != nil {
+		for u := 0; u+1 < len(n.Content); u += 2 {
+			rendered[n.Content[u].Value] = n.Content[u+1]
 		}
 	}
 
-	sort.Slice(mapped, func(i, j int) bool {
-		return mapped[i].line < mapped[j].line
-	})
-	for j := range mapped {
-		if mapped[j].resp != nil {
-			rendered, _ := mapped[j].resp.MarshalYAMLInline()
-			m.Content = append(m.Content, utils.CreateStringNode(mapped[j].code))
-			m.Content = append(m.Content, rendered.(*yaml.Node))
-
+	for _, key := range r.Keys() {
+		node := rendered[key]
+		if resp := r.Codes[key]; resp != nil {
+			var re any
+			if inline {
+				re, _ = resp.MarshalYAMLInline()
+			} else {
+				re, _ = resp.MarshalYAML()
+			}
+			node = re.(*yaml.Node)
 		}
-		if mapped[j].ext != nil {
-			m.Content = append(m.Content, utils.CreateStringNode(mapped[j].code))
-			m.Content = append(m.Content, mapped[j].ext)
+		if node != nil {
+			m.Content = append(m.Content, utils.CreateStringNode(key), node)
 		}
-
 	}
 	return m, nil
 }
@@ -98,3 +98,41 @@ func TestResponses_MarshalYAMLInline(t *testing.T) {
 	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
 
 }
+
+func TestResponses_MarshalYAML_Order(t *testing.T) {
+
+	yml := `x-first: 1
+"404":
+    description: nope
+default:
+    description: whatever
+x-middle:
+    a: b
+"200":
+    description: ok
+x-last: true`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3.Responses
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	r := NewResponses(&n)
+	assert.Equal(t, []string{"x-first", "404", "default", "x-middle", "200", "x-last"}, r.Keys())
+
+	rend, _ := r.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+
+	rend, _ = r.RenderInline()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+
+	// new content goes last, removed content is gone.
+	r.Codes["500"] = &Response{Description: "oops"}
+	r.Extensions["x-added"] = "yes"
+	delete(r.Codes, "404")
+	r.Default = nil
+	assert.Equal(t, []string{"x-first", "x-middle", "200", "x-last", "500", "x-added"}, r.Keys())
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuntimeExpression is a parsed runtime expression, used by links and callbacks to read values from an HTTP
+// request or response when an operation is called, like '$request.path.id' or '$response.body#/user/uuid'.
+//   - https://spec.openapis.org/oas/v3.1.0#runtime-expressions
+type RuntimeExpression struct {
+	Expression string // the expression as written, without any surrounding braces.
+	Source     string // 'url', 'method', 'statusCode', 'request' or 'response'.
+	Location   string // 'header', 'query', 'path' or 'body', for request and response expressions.
+	Name       string // the name of the header, query parameter or path parameter.
+	Pointer    string // the JSON pointer into the body, like '/user/uuid', empty for the whole body.
+}
+
+// ParseRuntimeExpression parses a runtime expression, which may be wrapped in braces (like '{$request.path.id}').
+// An error is returned if the expression is not valid.
+func ParseRuntimeExpression(expression string) (*RuntimeExpression, error) {
+	exp := strings.TrimSpace(expression)
+	if strings.HasPrefix(exp, "{") && strings.HasSuffix(exp, "}") {
+		exp = exp[1 : len(exp)-1]
+	}
+	r := &RuntimeExpression{Expression: exp}
+	if !strings.HasPrefix(exp, "$") {
+		return nil, fmt.Errorf("runtime expression '%s' does not start with '$'", expression)
+	}
+	switch exp {
+	case "$url", "$method", "$statusCode":
+		r.Source = exp[1:]
+		return r, nil
+	}
+	source, rest, _ := strings.Cut(exp[1:], ".")
+	if source != "request" && source != "response" {
+		return nil, fmt.Errorf("runtime expression '%s' has an unknown source, expected $url, $method, "+
+			"$statusCode, $request or $response", expression)
+	}
+	r.Source = source
+
+	if rest == "body" || strings.HasPrefix(rest, "body#") {
+		r.Location = "body"
+		r.Pointer = strings.TrimPrefix(rest, "body")
+		if r.Pointer != "" {
+			r.Pointer = r.Pointer[1:]
+			if r.Pointer != "" && !strings.HasPrefix(r.Pointer, "/") {
+				return nil, fmt.Errorf("runtime expression '%s' has an invalid JSON pointer, it must start "+
+					"with '/'", expression)
+			}
+		}
+		return r, nil
+	}
+	location, name, _ := strings.Cut(rest, ".")
+	switch location {
+	case "header", "query", "path":
+	default:
+		return nil, fmt.Errorf("runtime expression '%s' has an unknown location, expected header, query, path "+
+			"or body", expression)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("runtime expression '%s' has no %s name", expression, location)
+	}
+	if location == "header" && !isHeaderToken(name) {
+		return nil, fmt.Errorf("runtime expression '%s' has an invalid header name '%s'", expression, name)
+	}
+	r.Location = location
+	r.Name = name
+	return r, nil
+}
+
+// String returns the expression as written, without any surrounding braces.
+func (r *RuntimeExpression) String() string {
+	return r.Expression
+}
+
+// isHeaderToken returns true if name is a valid header name (an RFC 7230 token).
+func isHeaderToken(name string) bool {
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// LinkValue is the value of a Link parameter, or the request body of a Link. It's either a runtime expression
+// that is evaluated when the link is followed, or a literal value (which can be any value).
+type LinkValue struct {
+	Expression *RuntimeExpression // the runtime expression, nil for literal values.
+	Literal    any                // the literal value, nil for runtime expressions.
+}
+
+// NewLinkValue creates a LinkValue from a parameter or request body value. Strings that are runtime expressions
+// (starting with '$', or wrapped in braces like '{$request.path.id}') are parsed. Everything else is a literal,
+// including strings that look like runtime expressions but are not valid (see Link.Validate).
+func NewLinkValue(value any) *LinkValue {
+	if s, ok := value.(string); ok && looksLikeExpression(s) {
+		if exp, err := ParseRuntimeExpression(s); err == nil {
+			return &LinkValue{Expression: exp}
+		}
+	}
+	return &LinkValue{Literal: value}
+}
+
+// IsExpression returns true if the value is a runtime expression.
+func (v *LinkValue) IsExpression() bool {
+	return v != nil && v.Expression != nil
+}
+
+// looksLikeExpression returns true if a string is written as a runtime expression.
+func looksLikeExpression(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "$") || strings.HasPrefix(s, "{$")
+}
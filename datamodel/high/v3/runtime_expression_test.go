@@ -0,0 +1,60 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRuntimeExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       RuntimeExpression
+	}{
+		{"$url", RuntimeExpression{Expression: "$url", Source: "url"}},
+		{"$method", RuntimeExpression{Expression: "$method", Source: "method"}},
+		{"$statusCode", RuntimeExpression{Expression: "$statusCode", Source: "statusCode"}},
+		{"$request.path.id", RuntimeExpression{Expression: "$request.path.id", Source: "request",
+			Location: "path", Name: "id"}},
+		{"{$request.query.queryUrl}", RuntimeExpression{Expression: "$request.query.queryUrl", Source: "request",
+			Location: "query", Name: "queryUrl"}},
+		{"$request.header.X-Rate-Limit", RuntimeExpression{Expression: "$request.header.X-Rate-Limit",
+			Source: "request", Location: "header", Name: "X-Rate-Limit"}},
+		{"$response.body", RuntimeExpression{Expression: "$response.body", Source: "response",
+			Location: "body"}},
+		{"$response.body#/user/uuid", RuntimeExpression{Expression: "$response.body#/user/uuid",
+			Source: "response", Location: "body", Pointer: "/user/uuid"}},
+	}
+	for _, tt := range tests {
+		exp, err := ParseRuntimeExpression(tt.expression)
+		assert.NoError(t, err, tt.expression)
+		assert.Equal(t, tt.want, *exp, tt.expression)
+		assert.Equal(t, tt.want.Expression, exp.String())
+	}
+
+	for _, invalid := range []string{"", "url", "$uri", "$request", "$request.cookie.id", "$request.path.",
+		"$response.body#user", "$request.header.X Rate", "$response.bodyguard"} {
+		_, err := ParseRuntimeExpression(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNewLinkValue(t *testing.T) {
+	v := NewLinkValue("$request.path.id")
+	assert.True(t, v.IsExpression())
+	assert.Equal(t, "id", v.Expression.Name)
+	assert.Nil(t, v.Literal)
+
+	v = NewLinkValue(map[string]any{"name": "pizza"})
+	assert.False(t, v.IsExpression())
+	assert.Equal(t, map[string]any{"name": "pizza"}, v.Literal)
+
+	v = NewLinkValue("$request.nope")
+	assert.False(t, v.IsExpression())
+	assert.Equal(t, "$request.nope", v.Literal)
+
+	assert.False(t, (*LinkValue)(nil).IsExpression())
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ResolvedSecurityScheme is a security scheme required by a security requirement, mapped to the SecurityScheme
+// defined by the components of the document.
+type ResolvedSecurityScheme struct {
+	Name           string          // the name of the security scheme.
+	Scopes         []string        // the scopes (or roles) required.
+	SecurityScheme *SecurityScheme // the security scheme, nil if the document does not define it.
+}
+
+// UsesRoles returns true if the required scopes are roles, rather than OAuth scopes. Schemes that are not oauth2 or
+// openIdConnect can require roles, as of OpenAPI 3.1.
+func (r *ResolvedSecurityScheme) UsesRoles() bool {
+	if r.SecurityScheme == nil {
+		return false
+	}
+	return r.SecurityScheme.Type != "oauth2" && r.SecurityScheme.Type != "openIdConnect" && len(r.Scopes) > 0
+}
+
+// EffectiveSecurity returns the security requirements that apply to an operation: the security of the operation,
+// or the security of the document if the operation doesn't define any. An operation that defines an empty list
+// has no security, it does not use the security of the document.
+func (d *Document) EffectiveSecurity(op *Operation) []*base.SecurityRequirement {
+	if op != nil && op.Security != nil {
+		return op.Security
+	}
+	return d.Security
+}
+
+// ResolveSecurity expands security requirements into a list of alternatives, any one of which grants access, each
+// a list of schemes that are all required (see base.FlattenSecurity). Each scheme is mapped to the SecurityScheme
+// defined by the document. An error is returned for every scheme that is not defined, and every OAuth scope
+// that is not defined by a flow of its scheme.
+func (d *Document) ResolveSecurity(requirements []*base.SecurityRequirement) ([][]*ResolvedSecurityScheme, []error) {
+	var schemes map[string]*SecurityScheme
+	if d.Components != nil {
+		schemes = d.Components.SecuritySchemes
+	}
+	flat := base.FlattenSecurity(requirements)
+	if flat == nil {
+		return nil, nil
+	}
+	var errs []error
+	resolved := make([][]*ResolvedSecurityScheme, len(flat))
+	for i := range flat {
+		resolved[i] = make([]*ResolvedSecurityScheme, len(flat[i]))
+		for j, req := range flat[i] {
+			r := &ResolvedSecurityScheme{Name: req.Scheme, Scopes: req.Scopes, SecurityScheme: schemes[req.Scheme]}
+			resolved[i][j] = r
+			if r.SecurityScheme == nil {
+				errs = append(errs, fmt.Errorf("security requirement uses the '%s' security scheme, "+
+					"which is not defined", req.Scheme))
+				continue
+			}
+			if r.SecurityScheme.Type != "oauth2" || r.SecurityScheme.Flows == nil {
+				continue
+			}
+			defined := r.SecurityScheme.Flows.scopes()
+			for _, scope := range req.Scopes {
+				if !defined[scope] {
+					errs = append(errs, fmt.Errorf("security requirement uses the '%s' scope, which is not "+
+						"defined by the '%s' security scheme", scope, req.Scheme))
+				}
+			}
+		}
+	}
+	return resolved, errs
+}
+
+// scopes returns every scope defined by any of the flows.
+func (o *OAuthFlows) scopes() map[string]bool {
+	scopes := make(map[string]bool)
+	for _, flow := range []*OAuthFlow{o.Implicit, o.Password, o.ClientCredentials, o.AuthorizationCode} {
+		if flow == nil {
+			continue
+		}
+		for scope := range flow.Scopes {
+			scopes[scope] = true
+		}
+	}
+	return scopes
+}
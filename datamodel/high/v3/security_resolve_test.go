@@ -0,0 +1,80 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_ResolveSecurity(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+security:
+  - oauth: [read]
+    key: []
+  - {}
+paths:
+  /pizza:
+    get:
+      security:
+        - oauth: [write, eat]
+        - key: [admin]
+        - missing: []
+    post:
+      security: []
+    put:
+      responses: {}
+components:
+  securitySchemes:
+    key:
+      type: apiKey
+      in: header
+      name: X-Key
+    oauth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://pb33f.io/auth
+          scopes:
+            read: read things
+        password:
+          tokenUrl: https://pb33f.io/token
+          scopes:
+            write: write things`
+
+	doc := buildOperationIndexDocument(t, yml)
+	pi := doc.Paths.PathItems["/pizza"]
+
+	assert.Equal(t, doc.Security, doc.EffectiveSecurity(pi.Put))
+	assert.Equal(t, doc.Security, doc.EffectiveSecurity(nil))
+	assert.NotNil(t, doc.EffectiveSecurity(pi.Post))
+	assert.Empty(t, doc.EffectiveSecurity(pi.Post))
+
+	resolved, errs := doc.ResolveSecurity(doc.EffectiveSecurity(pi.Put))
+	assert.Empty(t, errs)
+	assert.Len(t, resolved, 2)
+	assert.Len(t, resolved[0], 2)
+	assert.Equal(t, "oauth", resolved[0][0].Name)
+	assert.Equal(t, "oauth2", resolved[0][0].SecurityScheme.Type)
+	assert.False(t, resolved[0][0].UsesRoles())
+	assert.Equal(t, "key", resolved[0][1].Name)
+	assert.Equal(t, "X-Key", resolved[0][1].SecurityScheme.Name)
+	assert.Empty(t, resolved[1])
+
+	resolved, errs = doc.ResolveSecurity(doc.EffectiveSecurity(pi.Get))
+	assert.Len(t, resolved, 3)
+	assert.True(t, resolved[1][0].UsesRoles())
+	assert.Nil(t, resolved[2][0].SecurityScheme)
+	assert.False(t, resolved[2][0].UsesRoles())
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "security requirement uses the 'eat' scope, which is not defined by the 'oauth' security scheme",
+		errs[0].Error())
+	assert.Equal(t, "security requirement uses the 'missing' security scheme, which is not defined", errs[1].Error())
+
+	resolved, errs = doc.ResolveSecurity(nil)
+	assert.Nil(t, resolved)
+	assert.Nil(t, errs)
+}
@@ -37,7 +37,7 @@ func NewSecurityScheme(ss *low.SecurityScheme) *SecurityScheme {
 	s := new(SecurityScheme)
 	s.low = ss
 	s.Type = ss.Type.Value
-	s.Description = ss.Description.Value
+	s.Description = ss.OverrideDescription(ss.Description.Value)
 	s.Name = ss.Name.Value
 	s.Scheme = ss.Scheme.Value
 	s.In = ss.In.Value
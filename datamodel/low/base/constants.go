@@ -34,6 +34,9 @@ const (
 	ItemsLabel                 = "items"
 	PrefixItemsLabel           = "prefixItems"
 	ContainsLabel              = "contains"
+	ConstLabel                 = "const"
+	DependentRequiredLabel     = "dependentRequired"
+	ContentSchemaLabel         = "contentSchema"
 	AllOfLabel                 = "allOf"
 	AnyOfLabel                 = "anyOf"
 	OneOfLabel                 = "oneOf"
@@ -45,6 +48,8 @@ const (
 	SchemaLabel                = "schema"
 	SchemaTypeLabel            = "$schema"
 	AnchorLabel                = "$anchor"
+	DynamicAnchorLabel         = "$dynamicAnchor"
+	DynamicRefLabel            = "$dynamicRef"
 )
 
 /*
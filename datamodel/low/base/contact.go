@@ -4,11 +4,9 @@
 package base
 
 import (
-	"crypto/sha256"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"gopkg.in/yaml.v3"
-	"strings"
 )
 
 // Contact represents a low-level representation of the Contact definitions found at
@@ -20,11 +18,13 @@ type Contact struct {
 	URL   low.NodeReference[string]
 	Email low.NodeReference[string]
 	*low.Reference
+	low.Nodes
 }
 
 // Build is not implemented for Contact (there is nothing to build).
-func (c *Contact) Build(_ *yaml.Node, _ *index.SpecIndex) error {
+func (c *Contact) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	c.Reference = new(low.Reference)
+	c.SetNodes(root, idx)
 	// not implemented.
 	return nil
 }
@@ -33,13 +33,13 @@ func (c *Contact) Build(_ *yaml.Node, _ *index.SpecIndex) error {
 func (c *Contact) Hash() [32]byte {
 	var f []string
 	if !c.Name.IsEmpty() {
-		f = append(f, c.Name.Value)
+		f = append(f, low.HashField("name", c.Name.Value))
 	}
 	if !c.URL.IsEmpty() {
-		f = append(f, c.URL.Value)
+		f = append(f, low.HashField("url", c.URL.Value))
 	}
 	if !c.Email.IsEmpty() {
-		f = append(f, c.Email.Value)
+		f = append(f, low.HashField("email", c.Email.Value))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
@@ -4,10 +4,13 @@
 package base
 
 import (
-	"crypto/sha256"
-	"github.com/pb33f/libopenapi/datamodel/low"
+	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
 )
 
 // Discriminator is only used by OpenAPI 3+ documents, it represents a polymorphic discriminator used for schemas
@@ -23,6 +26,22 @@ type Discriminator struct {
 	PropertyName low.NodeReference[string]
 	Mapping      low.NodeReference[map[low.KeyReference[string]]low.ValueReference[string]]
 	low.Reference
+	idx    *index.SpecIndex
+	parent *Schema
+	low.Nodes
+}
+
+// DiscriminatorMappingError is reported when a discriminator mapping cannot be used, either because the schema
+// it points to cannot be found, or because that schema does not define the discriminator property.
+type DiscriminatorMappingError struct {
+	Key   string     // the mapping key (the value of the discriminator property).
+	Value string     // the mapping value (a schema name or reference).
+	Node  *yaml.Node // the mapping value node, nil if the mapping is implicit.
+	Err   error
+}
+
+func (e *DiscriminatorMappingError) Error() string {
+	return e.Err.Error()
 }
 
 // FindMappingValue will return a ValueReference containing the string mapping value
@@ -41,7 +60,7 @@ func (d *Discriminator) Hash() [32]byte {
 	// calculate a hash from every property.
 	var f []string
 	if d.PropertyName.Value != "" {
-		f = append(f, d.PropertyName.Value)
+		f = append(f, low.HashField("propertyName", d.PropertyName.Value))
 	}
 	propertyKeys := make([]string, 0, len(d.Mapping.Value))
 	for i := range d.Mapping.Value {
@@ -50,7 +69,164 @@ func (d *Discriminator) Hash() [32]byte {
 	sort.Strings(propertyKeys)
 	for k := range propertyKeys {
 		prop := d.FindMappingValue(propertyKeys[k])
-		f = append(f, prop.Value)
+		f = append(f, low.HashField("mapping", prop.Value))
+	}
+	return low.HashStrings(f)
+}
+
+// GetImplicitMappings returns the mappings that are inferred from the oneOf and anyOf references of the schema
+// that owns this Discriminator. The key is the name of the referenced schema, the value is the reference.
+// Inline schemas are not considered.
+func (d *Discriminator) GetImplicitMappings() map[string]string {
+	mappings := make(map[string]string)
+	if d.parent == nil {
+		return mappings
+	}
+	for _, poly := range [][]low.ValueReference[*SchemaProxy]{d.parent.OneOf.Value, d.parent.AnyOf.Value} {
+		for i := range poly {
+			if poly[i].Value == nil || !poly[i].Value.IsSchemaReference() {
+				continue
+			}
+			ref := poly[i].Value.GetSchemaReference()
+			segs := strings.Split(ref, "/")
+			name := segs[len(segs)-1]
+			if name != "" {
+				mappings[name] = ref
+			}
+		}
+	}
+	return mappings
+}
+
+// GetAllMappings returns the implicit (inferred) mappings combined with the explicit mappings, explicit
+// mappings always win.
+func (d *Discriminator) GetAllMappings() map[string]string {
+	mappings := d.GetImplicitMappings()
+	for k, v := range d.Mapping.Value {
+		mappings[k.Value] = v.Value
+	}
+	return mappings
+}
+
+// ResolveMappingValue will resolve a mapping value to a SchemaProxy using the index. A mapping value can be a
+// schema name, or a reference. Returns nil if the schema cannot be located.
+func (d *Discriminator) ResolveMappingValue(value string) *SchemaProxy {
+	if d.idx == nil || value == "" {
+		return nil
+	}
+	candidates := []string{value}
+	if !strings.ContainsAny(value, "#/") && index.DetermineReferenceResolveType(value) < 0 {
+		candidates = []string{
+			fmt.Sprintf("#/components/schemas/%s", value),
+			fmt.Sprintf("#/definitions/%s", value),
+		}
+	}
+	for _, ref := range candidates {
+		if found := d.idx.FindComponent(ref, nil); found != nil && found.Node != nil {
+			return &SchemaProxy{vn: found.Node, idx: d.idx, isReference: true, referenceLookup: ref}
+		}
+	}
+	return nil
+}
+
+// FindSchema will return the schema used for a value of the discriminator property. Explicit mappings are
+// checked first, then the implicit mappings. Returns nil if there is no match.
+func (d *Discriminator) FindSchema(propertyValue string) *SchemaProxy {
+	if m := d.FindMappingValue(propertyValue); m != nil {
+		return d.ResolveMappingValue(m.Value)
+	}
+	if ref, ok := d.GetImplicitMappings()[propertyValue]; ok {
+		return d.ResolveMappingValue(ref)
+	}
+	return nil
+}
+
+// ValidateMappings checks every explicit and implicit mapping, and reports any that point to schemas that
+// don't exist, or to schemas that do not define the discriminator property (either directly, or via allOf).
+func (d *Discriminator) ValidateMappings() []*DiscriminatorMappingError {
+	var errs []*DiscriminatorMappingError
+	check := func(key, value string, node *yaml.Node) {
+		sp := d.ResolveMappingValue(value)
+		if sp == nil {
+			errs = append(errs, &DiscriminatorMappingError{
+				Key: key, Value: value, Node: node,
+				Err: fmt.Errorf("discriminator mapping '%s' points to '%s', which cannot be found", key, value),
+			})
+			return
+		}
+		if d.PropertyName.Value != "" && !schemaHasProperty(sp, d.PropertyName.Value, make(map[*yaml.Node]bool)) {
+			errs = append(errs, &DiscriminatorMappingError{
+				Key: key, Value: value, Node: node,
+				Err: fmt.Errorf("discriminator mapping '%s' points to '%s', which does not define property '%s'",
+					key, value, d.PropertyName.Value),
+			})
+		}
+	}
+
+	var keys []string
+	for k := range d.Mapping.Value {
+		keys = append(keys, k.Value)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		m := d.FindMappingValue(k)
+		check(k, m.Value, m.ValueNode)
+	}
+
+	implicit := d.GetImplicitMappings()
+	keys = keys[:0]
+	for k := range implicit {
+		if d.FindMappingValue(k) == nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		check(k, implicit[k], nil)
+	}
+	return errs
+}
+
+// warnUnresolvedMappings adds a warning to the index for every explicit mapping that cannot be resolved, the
+// discriminator can still be used for the other mappings.
+func (d *Discriminator) warnUnresolvedMappings() {
+	if d.idx == nil {
+		return
+	}
+	keys := make([]string, 0, len(d.Mapping.Value))
+	for k := range d.Mapping.Value {
+		keys = append(keys, k.Value)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		m := d.FindMappingValue(k)
+		if d.ResolveMappingValue(m.Value) == nil {
+			d.idx.AddWarning(&index.Warning{
+				Type:    index.WarningUnresolvedReference,
+				Message: fmt.Sprintf("discriminator mapping '%s' points to '%s', which cannot be found", k, m.Value),
+				Node:    m.ValueNode,
+			})
+		}
+	}
+}
+
+// schemaHasProperty checks if a schema (or any schema it is composed of using allOf) defines a property.
+func schemaHasProperty(sp *SchemaProxy, name string, seen map[*yaml.Node]bool) bool {
+	if sp == nil || seen[sp.GetValueNode()] {
+		return false
+	}
+	seen[sp.GetValueNode()] = true
+	sch := sp.Schema()
+	if sch == nil {
+		return false
+	}
+	if sch.FindProperty(name) != nil {
+		return true
+	}
+	for i := range sch.AllOf.Value {
+		if schemaHasProperty(sch.AllOf.Value[i].Value, name, seen) {
+			return true
+		}
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return false
 }
@@ -5,6 +5,7 @@ package base
 
 import (
 	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"testing"
@@ -49,3 +50,91 @@ propertyName: freshCakes`
 
 	assert.Equal(t, lDoc.Hash(), rDoc.Hash())
 }
+
+func TestDiscriminator_Mappings(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        petType:
+          type: string
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+    Rock:
+      type: object
+      properties:
+        weight:
+          type: number
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Rock'
+        - type: object
+      discriminator:
+        propertyName: petType
+        mapping:
+          kitty: Cat
+          doggo: '#/components/schemas/Dog'
+          unicorn: '#/components/schemas/Unicorn'`
+
+	var iNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &iNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&iNode, index.CreateClosedAPIIndexConfig())
+
+	yml = `$ref: '#/components/schemas/Animal'`
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	res, _ := ExtractSchema(idxNode.Content[0], idx)
+	disc := res.Value.Schema().Discriminator.Value
+
+	implicit := disc.GetImplicitMappings()
+	assert.Len(t, implicit, 3)
+	assert.Equal(t, "#/components/schemas/Rock", implicit["Rock"])
+
+	all := disc.GetAllMappings()
+	assert.Len(t, all, 6)
+	assert.Equal(t, "Cat", all["kitty"])
+
+	assert.Equal(t, "#/components/schemas/Cat", disc.FindSchema("kitty").GetSchemaReference())
+	assert.Equal(t, "#/components/schemas/Dog", disc.FindSchema("Dog").GetSchemaReference())
+	assert.True(t, disc.FindSchema("doggo").IsSchemaReference())
+	assert.Nil(t, disc.FindSchema("unicorn"))
+	assert.Nil(t, disc.FindSchema("pizza"))
+	assert.Nil(t, disc.ResolveMappingValue(""))
+
+	errs := disc.ValidateMappings()
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "unicorn", errs[0].Key)
+	assert.NotNil(t, errs[0].Node)
+	assert.Equal(t, "discriminator mapping 'unicorn' points to '#/components/schemas/Unicorn', "+
+		"which cannot be found", errs[0].Error())
+	assert.Equal(t, "Rock", errs[1].Key)
+	assert.Nil(t, errs[1].Node)
+	assert.Contains(t, errs[1].Error(), "does not define property 'petType'")
+}
+
+func TestDiscriminator_Mappings_NoContext(t *testing.T) {
+	yml := `propertyName: freshCakes
+mapping:
+  something: nothing`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var n Discriminator
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	assert.Empty(t, n.GetImplicitMappings())
+	assert.Len(t, n.GetAllMappings(), 1)
+	assert.Nil(t, n.FindSchema("something"))
+	assert.Len(t, n.ValidateMappings(), 1)
+}
@@ -10,9 +10,7 @@ import (
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
 	"strconv"
-	"strings"
 )
 
 // Example represents a low-level Example object as defined by OpenAPI 3+
@@ -25,6 +23,7 @@ type Example struct {
 	ExternalValue low.NodeReference[string]
 	Extensions    map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindExtension returns a ValueReference containing the extension value, if found.
@@ -36,27 +35,20 @@ func (ex *Example) FindExtension(ext string) *low.ValueReference[any] {
 func (ex *Example) Hash() [32]byte {
 	var f []string
 	if ex.Summary.Value != "" {
-		f = append(f, ex.Summary.Value)
+		f = append(f, low.HashField("summary", ex.Summary.Value))
 	}
 	if ex.Description.Value != "" {
-		f = append(f, ex.Description.Value)
+		f = append(f, low.HashField("description", ex.Description.Value))
 	}
 	if ex.Value.Value != "" {
 		// this could be anything!
-		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(ex.Value.Value)))))
+		f = append(f, low.HashField("value", fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(ex.Value.Value))))))
 	}
 	if ex.ExternalValue.Value != "" {
-		f = append(f, ex.ExternalValue.Value)
+		f = append(f, low.HashField("externalValue", ex.ExternalValue.Value))
 	}
-	keys := make([]string, len(ex.Extensions))
-	z := 0
-	for k := range ex.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(ex.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(ex.Extensions)...)
+	return low.HashStrings(f)
 }
 
 // Build extracts extensions and example value
@@ -64,6 +56,7 @@ func (ex *Example) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	ex.Reference = new(low.Reference)
+	ex.SetNodes(root, idx)
 	ex.Extensions = low.ExtractExtensions(root)
 	_, ln, vn := utils.FindKeyNodeFull(ValueLabel, root.Content)
 
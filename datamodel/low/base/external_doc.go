@@ -4,14 +4,10 @@
 package base
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
-	"strings"
 )
 
 // ExternalDoc represents a low-level External Documentation object as defined by OpenAPI 2 and 3
@@ -25,6 +21,7 @@ type ExternalDoc struct {
 	URL         low.NodeReference[string]
 	Extensions  map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindExtension returns a ValueReference containing the extension value, if found.
@@ -37,6 +34,7 @@ func (ex *ExternalDoc) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	ex.Reference = new(low.Reference)
+	ex.SetNodes(root, idx)
 	ex.Extensions = low.ExtractExtensions(root)
 	return nil
 }
@@ -49,16 +47,9 @@ func (ex *ExternalDoc) GetExtensions() map[low.KeyReference[string]]low.ValueRef
 func (ex *ExternalDoc) Hash() [32]byte {
 	// calculate a hash from every property.
 	f := []string{
-		ex.Description.Value,
-		ex.URL.Value,
+		low.HashField("description", ex.Description.Value),
+		low.HashField("url", ex.URL.Value),
 	}
-	keys := make([]string, len(ex.Extensions))
-	z := 0
-	for k := range ex.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(ex.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(ex.Extensions)...)
+	return low.HashStrings(f)
 }
@@ -4,11 +4,7 @@
 package base
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/utils"
-	"sort"
-	"strings"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
@@ -32,6 +28,7 @@ type Info struct {
 	Version        low.NodeReference[string]
 	Extensions     map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindExtension attempts to locate an extension with the supplied key
@@ -49,6 +46,7 @@ func (i *Info) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	i.Reference = new(low.Reference)
+	i.SetNodes(root, idx)
 	i.Extensions = low.ExtractExtensions(root)
 
 	// extract contact
@@ -66,33 +64,26 @@ func (i *Info) Hash() [32]byte {
 	var f []string
 
 	if !i.Title.IsEmpty() {
-		f = append(f, i.Title.Value)
+		f = append(f, low.HashField("title", i.Title.Value))
 	}
 	if !i.Summary.IsEmpty() {
-		f = append(f, i.Summary.Value)
+		f = append(f, low.HashField("summary", i.Summary.Value))
 	}
 	if !i.Description.IsEmpty() {
-		f = append(f, i.Description.Value)
+		f = append(f, low.HashField("description", i.Description.Value))
 	}
 	if !i.TermsOfService.IsEmpty() {
-		f = append(f, i.TermsOfService.Value)
+		f = append(f, low.HashField("termsOfService", i.TermsOfService.Value))
 	}
 	if !i.Contact.IsEmpty() {
-		f = append(f, low.GenerateHashString(i.Contact.Value))
+		f = append(f, low.HashField("contact", low.GenerateHashString(i.Contact.Value)))
 	}
 	if !i.License.IsEmpty() {
-		f = append(f, low.GenerateHashString(i.License.Value))
+		f = append(f, low.HashField("license", low.GenerateHashString(i.License.Value)))
 	}
 	if !i.Version.IsEmpty() {
-		f = append(f, i.Version.Value)
+		f = append(f, low.HashField("version", i.Version.Value))
 	}
-	keys := make([]string, len(i.Extensions))
-	z := 0
-	for k := range i.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(i.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(i.Extensions)...)
+	return low.HashStrings(f)
 }
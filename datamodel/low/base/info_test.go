@@ -112,3 +112,86 @@ x-b33f: princess`
 
 	assert.Equal(t, lDoc.Hash(), rDoc.Hash())
 }
+
+func TestInfo_Hash_FieldNames(t *testing.T) {
+	left := `title: princess b33f`
+	right := `summary: princess b33f`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc Info
+	var rDoc Info
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// the same value in a different field is a different info.
+	assert.NotEqual(t, lDoc.Hash(), rDoc.Hash())
+
+	// legacy hashes don't know which field a value is in.
+	low.UseLegacyHashes(true)
+	defer low.UseLegacyHashes(false)
+	assert.Equal(t, lDoc.Hash(), rDoc.Hash())
+}
+
+func TestInfo_Hash_Options(t *testing.T) {
+	left := `title: princess b33f
+description: a thing
+x-notes: tidy up
+contact:
+  name: buckaroo
+  x-notes: tidy up`
+
+	right := `title: princess b33f
+description: a different thing
+x-notes: nothing to do
+contact:
+  name: buckaroo
+  x-notes: nothing to do`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc Info
+	var rDoc Info
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	assert.NotEqual(t, lDoc.Hash(), rDoc.Hash())
+
+	defer low.SetHashOptions(nil)
+	low.SetHashOptions(&low.HashOptions{IgnoreDescriptions: true})
+	assert.NotEqual(t, lDoc.Hash(), rDoc.Hash())
+
+	// the extension of the contact is ignored too.
+	low.SetHashOptions(&low.HashOptions{IgnoreDescriptions: true, IgnoredExtensions: []string{"x-notes"}})
+	assert.Equal(t, lDoc.Hash(), rDoc.Hash())
+}
+
+func TestInfo_Hash_Options_AddedField(t *testing.T) {
+	hash := func(yml string) [32]byte {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		var doc Info
+		_ = low.BuildModel(node.Content[0], &doc)
+		_ = doc.Build(node.Content[0], nil)
+		return doc.Hash()
+	}
+	without := `title: princess b33f`
+	with := `title: princess b33f
+description: a thing`
+
+	assert.NotEqual(t, hash(without), hash(with))
+
+	// adding or removing an ignored field doesn't change the hash.
+	defer low.SetHashOptions(nil)
+	low.SetHashOptions(&low.HashOptions{IgnoreDescriptions: true})
+	assert.Equal(t, hash(without), hash(with))
+	assert.Equal(t, hash(with), hash(without))
+}
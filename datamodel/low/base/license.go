@@ -4,13 +4,10 @@
 package base
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"strings"
 )
 
 // License is a low-level representation of a License object as defined by OpenAPI 2 and OpenAPI 3
@@ -22,6 +19,7 @@ type License struct {
 	URL        low.NodeReference[string]
 	Identifier low.NodeReference[string]
 	*low.Reference
+	low.Nodes
 }
 
 // Build out a license, complain if both a URL and identifier are present as they are mutually exclusive
@@ -29,8 +27,10 @@ func (l *License) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	l.Reference = new(low.Reference)
+	l.SetNodes(root, idx)
 	if l.URL.Value != "" && l.Identifier.Value != "" {
-		return fmt.Errorf("license cannot have both a URL and an identifier, they are mutually exclusive")
+		return low.NewBuildError(low.ErrorInvalidValue, l.Identifier.ValueNode, idx, nil,
+			"license cannot have both a URL and an identifier, they are mutually exclusive")
 	}
 	return nil
 }
@@ -39,13 +39,13 @@ func (l *License) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (l *License) Hash() [32]byte {
 	var f []string
 	if !l.Name.IsEmpty() {
-		f = append(f, l.Name.Value)
+		f = append(f, low.HashField("name", l.Name.Value))
 	}
 	if !l.URL.IsEmpty() {
-		f = append(f, l.URL.Value)
+		f = append(f, low.HashField("url", l.URL.Value))
 	}
 	if !l.Identifier.IsEmpty() {
-		f = append(f, l.Identifier.Value)
+		f = append(f, low.HashField("identifier", l.Identifier.Value))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
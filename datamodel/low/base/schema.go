@@ -3,7 +3,6 @@ package base
 import (
 	"crypto/sha256"
 	"fmt"
-	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,7 +22,7 @@ import (
 // The N value is a bit to make it each to know which value (A or B) is used, this prevents having to
 // if/else on the value to determine which one is set.
 type SchemaDynamicValue[A any, B any] struct {
-	N int // 0 == A, 1 == B
+	N int // 0 == A, 1 == B, 2 == neither (the value is only held by the node)
 	A A
 	B B
 }
@@ -62,6 +61,11 @@ type Schema struct {
 	// Reference to the '$schema' dialect setting (3.1 only)
 	SchemaTypeRef low.NodeReference[string]
 
+	// Dialect is the effective dialect of this schema. It's the '$schema' value if set, otherwise it's inherited
+	// from the parent schema, or from the document ('jsonSchemaDialect' or the default for the spec version).
+	// KeyNode and ValueNode are only set when the dialect was declared using '$schema' on this schema.
+	Dialect low.NodeReference[string]
+
 	// In versions 2 and 3.0, this ExclusiveMaximum can only be a boolean.
 	ExclusiveMaximum low.NodeReference[*SchemaDynamicValue[bool, float64]]
 
@@ -102,6 +106,12 @@ type Schema struct {
 	UnevaluatedItems      low.NodeReference[*SchemaProxy]
 	UnevaluatedProperties low.NodeReference[*SchemaDynamicValue[*SchemaProxy, *bool]]
 	Anchor                low.NodeReference[string]
+	DynamicAnchor         low.NodeReference[string]
+	DynamicRef            low.NodeReference[string]
+	DynamicRefSchema      low.NodeReference[*SchemaProxy]
+	Const                 low.NodeReference[any]
+	DependentRequired     low.NodeReference[map[low.KeyReference[string]]low.ValueReference[[]string]]
+	ContentSchema         low.NodeReference[*SchemaProxy]
 
 	// Compatible with all versions
 	Title                low.NodeReference[string]
@@ -121,7 +131,7 @@ type Schema struct {
 	Enum                 low.NodeReference[[]low.ValueReference[any]]
 	Not                  low.NodeReference[*SchemaProxy]
 	Properties           low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]
-	AdditionalProperties low.NodeReference[any]
+	AdditionalProperties low.NodeReference[*SchemaDynamicValue[*SchemaProxy, bool]]
 	Description          low.NodeReference[string]
 	ContentEncoding      low.NodeReference[string]
 	ContentMediaType     low.NodeReference[string]
@@ -138,6 +148,11 @@ type Schema struct {
 	// Parent Proxy refers back to the low level SchemaProxy that is proxying this schema.
 	ParentProxy *SchemaProxy
 	*low.Reference
+	low.Nodes
+
+	// the schemas passed through to reach this one (the evaluation path), outermost first. Once built, it ends with
+	// this schema, and the schema it references (if any).
+	path []*yaml.Node
 }
 
 // Hash will calculate a SHA256 hash from the values of the schema, This allows equality checking against
@@ -145,135 +160,107 @@ type Schema struct {
 func (s *Schema) Hash() [32]byte {
 	// calculate a hash from every property in the schema.
 	var d []string
+	legacy := low.LegacyHashes()
 	if !s.SchemaTypeRef.IsEmpty() {
-		d = append(d, fmt.Sprint(s.SchemaTypeRef.Value))
+		d = append(d, low.HashField("schemaTypeRef", fmt.Sprint(s.SchemaTypeRef.Value)))
 	}
 	if !s.Title.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Title.Value))
+		d = append(d, low.HashField("title", fmt.Sprint(s.Title.Value)))
 	}
 	if !s.MultipleOf.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MultipleOf.Value))
+		d = append(d, low.HashField("multipleOf", fmt.Sprint(s.MultipleOf.Value)))
 	}
 	if !s.Maximum.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Maximum.Value))
+		d = append(d, low.HashField("maximum", fmt.Sprint(s.Maximum.Value)))
 	}
 	if !s.Minimum.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Minimum.Value))
+		d = append(d, low.HashField("minimum", fmt.Sprint(s.Minimum.Value)))
 	}
 	if !s.MaxLength.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MaxLength.Value))
+		d = append(d, low.HashField("maxLength", fmt.Sprint(s.MaxLength.Value)))
 	}
 	if !s.MinLength.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MinLength.Value))
+		d = append(d, low.HashField("minLength", fmt.Sprint(s.MinLength.Value)))
 	}
 	if !s.Pattern.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Pattern.Value))
+		d = append(d, low.HashField("pattern", fmt.Sprint(s.Pattern.Value)))
 	}
 	if !s.Format.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Format.Value))
+		d = append(d, low.HashField("format", fmt.Sprint(s.Format.Value)))
 	}
 	if !s.MaxItems.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MaxItems.Value))
+		d = append(d, low.HashField("maxItems", fmt.Sprint(s.MaxItems.Value)))
 	}
 	if !s.MinItems.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MinItems.Value))
+		d = append(d, low.HashField("minItems", fmt.Sprint(s.MinItems.Value)))
 	}
 	if !s.UniqueItems.IsEmpty() {
-		d = append(d, fmt.Sprint(s.UniqueItems.Value))
+		d = append(d, low.HashField("uniqueItems", fmt.Sprint(s.UniqueItems.Value)))
 	}
 	if !s.MaxProperties.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MaxProperties.Value))
+		d = append(d, low.HashField("maxProperties", fmt.Sprint(s.MaxProperties.Value)))
 	}
 	if !s.MinProperties.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MinProperties.Value))
-	}
-	if !s.AdditionalProperties.IsEmpty() {
-
-		// check type of properties, if we have a low level map, we need to hash the values in a repeatable
-		// order.
-		to := reflect.TypeOf(s.AdditionalProperties.Value)
-		vo := reflect.ValueOf(s.AdditionalProperties.Value)
-		var values []string
-		switch to.Kind() {
-		case reflect.Slice:
-			for i := 0; i < vo.Len(); i++ {
-				vn := vo.Index(i).Interface()
-
-				if jh, ok := vn.(low.HasValueUnTyped); ok {
-					vn = jh.GetValueUntyped()
-					fg := reflect.TypeOf(vn)
-					gf := reflect.ValueOf(vn)
-
-					if fg.Kind() == reflect.Map {
-						for _, ky := range gf.MapKeys() {
-							hu := ky.Interface()
-							values = append(values, fmt.Sprintf("%s:%s", hu, low.GenerateHashString(gf.MapIndex(ky).Interface())))
-						}
-						continue
-					}
-					values = append(values, fmt.Sprintf("%d:%s", i, low.GenerateHashString(vn)))
-				}
-			}
-			sort.Strings(values)
-			d = append(d, strings.Join(values, "||"))
-
-		case reflect.Map:
-			for _, k := range vo.MapKeys() {
-				var x string
-				var l int
-				var v any
-				// extract key
-				if o, ok := k.Interface().(low.HasKeyNode); ok {
-					x = o.GetKeyNode().Value
-					l = o.GetKeyNode().Line
-					v = vo.MapIndex(k).Interface().(low.HasValueNodeUntyped).GetValueNode().Value
-				}
-				values = append(values, fmt.Sprintf("%d:%s:%s", l, x, low.GenerateHashString(v)))
-			}
-			sort.Strings(values)
-			d = append(d, strings.Join(values, "||"))
-		default:
-			d = append(d, low.GenerateHashString(s.AdditionalProperties.Value))
+		d = append(d, low.HashField("minProperties", fmt.Sprint(s.MinProperties.Value)))
+	}
+	if !s.AdditionalProperties.IsEmpty() && legacy {
+		if h, ok := s.legacyAdditionalPropertiesHash(); ok {
+			d = append(d, low.HashField("additionalProperties", h))
+		}
+	}
+	if !s.AdditionalProperties.IsEmpty() && !legacy && s.AdditionalProperties.Value.IsA() {
+		d = append(d, low.HashField("additionalProperties", low.GenerateHashString(s.AdditionalProperties.Value.A)))
+	}
+	if !s.AdditionalProperties.IsEmpty() && !legacy && s.AdditionalProperties.Value.IsB() {
+		d = append(d, low.HashField("additionalProperties", fmt.Sprint(s.AdditionalProperties.Value.B)))
+	}
+	if !s.AdditionalProperties.IsEmpty() && !legacy && s.AdditionalProperties.Value.N == 2 {
+		var raw any
+		_ = s.AdditionalProperties.ValueNode.Decode(&raw)
+		if raw == nil {
+			d = append(d, low.HashField("additionalProperties", "null"))
+		} else {
+			d = append(d, low.HashField("additionalProperties", low.GenerateHashString(raw)))
 		}
 	}
 	if !s.Description.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Description.Value))
+		d = append(d, low.HashField("description", fmt.Sprint(s.Description.Value)))
 	}
 	if !s.ContentEncoding.IsEmpty() {
-		d = append(d, fmt.Sprint(s.ContentEncoding.Value))
+		d = append(d, low.HashField("contentEncoding", fmt.Sprint(s.ContentEncoding.Value)))
 	}
 	if !s.ContentMediaType.IsEmpty() {
-		d = append(d, fmt.Sprint(s.ContentMediaType.Value))
+		d = append(d, low.HashField("contentMediaType", fmt.Sprint(s.ContentMediaType.Value)))
 	}
 	if !s.Default.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Default.Value))
+		d = append(d, low.HashField("default", low.GenerateHashString(s.Default.Value)))
 	}
 	if !s.Nullable.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Nullable.Value))
+		d = append(d, low.HashField("nullable", fmt.Sprint(s.Nullable.Value)))
 	}
 	if !s.ReadOnly.IsEmpty() {
-		d = append(d, fmt.Sprint(s.ReadOnly.Value))
+		d = append(d, low.HashField("readOnly", fmt.Sprint(s.ReadOnly.Value)))
 	}
 	if !s.WriteOnly.IsEmpty() {
-		d = append(d, fmt.Sprint(s.WriteOnly.Value))
+		d = append(d, low.HashField("writeOnly", fmt.Sprint(s.WriteOnly.Value)))
 	}
 	if !s.Deprecated.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Deprecated.Value))
+		d = append(d, low.HashField("deprecated", fmt.Sprint(s.Deprecated.Value)))
 	}
 	if !s.ExclusiveMaximum.IsEmpty() && s.ExclusiveMaximum.Value.IsA() {
-		d = append(d, fmt.Sprint(s.ExclusiveMaximum.Value.A))
+		d = append(d, low.HashField("exclusiveMaximum", fmt.Sprint(s.ExclusiveMaximum.Value.A)))
 	}
 	if !s.ExclusiveMaximum.IsEmpty() && s.ExclusiveMaximum.Value.IsB() {
-		d = append(d, fmt.Sprint(s.ExclusiveMaximum.Value.B))
+		d = append(d, low.HashField("exclusiveMaximum", fmt.Sprint(s.ExclusiveMaximum.Value.B)))
 	}
 	if !s.ExclusiveMinimum.IsEmpty() && s.ExclusiveMinimum.Value.IsA() {
-		d = append(d, fmt.Sprint(s.ExclusiveMinimum.Value.A))
+		d = append(d, low.HashField("exclusiveMinimum", fmt.Sprint(s.ExclusiveMinimum.Value.A)))
 	}
 	if !s.ExclusiveMinimum.IsEmpty() && s.ExclusiveMinimum.Value.IsB() {
-		d = append(d, fmt.Sprint(s.ExclusiveMinimum.Value.B))
+		d = append(d, low.HashField("exclusiveMinimum", fmt.Sprint(s.ExclusiveMinimum.Value.B)))
 	}
 	if !s.Type.IsEmpty() && s.Type.Value.IsA() {
-		d = append(d, fmt.Sprint(s.Type.Value.A))
+		d = append(d, low.HashField("type", fmt.Sprint(s.Type.Value.A)))
 	}
 	if !s.Type.IsEmpty() && s.Type.Value.IsB() {
 		j := make([]string, len(s.Type.Value.B))
@@ -281,7 +268,7 @@ func (s *Schema) Hash() [32]byte {
 			j[h] = s.Type.Value.B[h].Value
 		}
 		sort.Strings(j)
-		d = append(d, strings.Join(j, "|"))
+		d = append(d, low.HashField("type", strings.Join(j, "|")))
 	}
 
 	keys := make([]string, len(s.Required.Value))
@@ -289,17 +276,17 @@ func (s *Schema) Hash() [32]byte {
 		keys[i] = s.Required.Value[i].Value
 	}
 	sort.Strings(keys)
-	d = append(d, keys...)
+	d = append(d, low.HashFields("required", keys)...)
 
 	keys = make([]string, len(s.Enum.Value))
 	for i := range s.Enum.Value {
 		keys[i] = fmt.Sprint(s.Enum.Value[i].Value)
 	}
 	sort.Strings(keys)
-	d = append(d, keys...)
+	d = append(d, low.HashFields("enum", keys)...)
 
 	for i := range s.Enum.Value {
-		d = append(d, fmt.Sprint(s.Enum.Value[i].Value))
+		d = append(d, low.HashField("enum", fmt.Sprint(s.Enum.Value[i].Value)))
 	}
 	propKeys := make([]string, len(s.Properties.Value))
 	z := 0
@@ -309,16 +296,17 @@ func (s *Schema) Hash() [32]byte {
 	}
 	sort.Strings(propKeys)
 	for k := range propKeys {
-		d = append(d, low.GenerateHashString(s.FindProperty(propKeys[k]).Value))
+		d = append(d, low.HashField("properties",
+			low.HashEntry(propKeys[k], low.GenerateHashString(s.FindProperty(propKeys[k]).Value))))
 	}
 	if s.XML.Value != nil {
-		d = append(d, low.GenerateHashString(s.XML.Value))
+		d = append(d, low.HashField("xml", low.GenerateHashString(s.XML.Value)))
 	}
 	if s.ExternalDocs.Value != nil {
-		d = append(d, low.GenerateHashString(s.ExternalDocs.Value))
+		d = append(d, low.HashField("externalDocs", low.GenerateHashString(s.ExternalDocs.Value)))
 	}
 	if s.Discriminator.Value != nil {
-		d = append(d, low.GenerateHashString(s.Discriminator.Value))
+		d = append(d, low.HashField("discriminator", low.GenerateHashString(s.Discriminator.Value)))
 	}
 
 	// hash polymorphic data
@@ -336,7 +324,7 @@ func (s *Schema) Hash() [32]byte {
 		}
 		sort.Strings(oneOfKeys)
 		for k := range oneOfKeys {
-			d = append(d, low.GenerateHashString(oneOfEntities[oneOfKeys[k]]))
+			d = append(d, low.HashField("oneOf", low.GenerateHashString(oneOfEntities[oneOfKeys[k]])))
 		}
 	}
 
@@ -354,7 +342,7 @@ func (s *Schema) Hash() [32]byte {
 		}
 		sort.Strings(allOfKeys)
 		for k := range allOfKeys {
-			d = append(d, low.GenerateHashString(allOfEntities[allOfKeys[k]]))
+			d = append(d, low.HashField("allOf", low.GenerateHashString(allOfEntities[allOfKeys[k]])))
 		}
 	}
 
@@ -372,42 +360,71 @@ func (s *Schema) Hash() [32]byte {
 		}
 		sort.Strings(anyOfKeys)
 		for k := range anyOfKeys {
-			d = append(d, low.GenerateHashString(anyOfEntities[anyOfKeys[k]]))
+			d = append(d, low.HashField("anyOf", low.GenerateHashString(anyOfEntities[anyOfKeys[k]])))
 		}
 	}
 
 	if !s.Not.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Not.Value))
+		d = append(d, low.HashField("not", low.GenerateHashString(s.Not.Value)))
 	}
 
 	// check if items is a schema or a bool.
 	if !s.Items.IsEmpty() && s.Items.Value.IsA() {
-		d = append(d, low.GenerateHashString(s.Items.Value.A))
+		d = append(d, low.HashField("items", low.GenerateHashString(s.Items.Value.A)))
 	}
 	if !s.Items.IsEmpty() && s.Items.Value.IsB() {
-		d = append(d, fmt.Sprint(s.Items.Value.B))
+		d = append(d, low.HashField("items", fmt.Sprint(s.Items.Value.B)))
 	}
 	// 3.1 only props
 	if !s.If.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.If.Value))
+		d = append(d, low.HashField("if", low.GenerateHashString(s.If.Value)))
 	}
 	if !s.Else.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Else.Value))
+		d = append(d, low.HashField("else", low.GenerateHashString(s.Else.Value)))
 	}
 	if !s.Then.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Then.Value))
+		d = append(d, low.HashField("then", low.GenerateHashString(s.Then.Value)))
 	}
 	if !s.PropertyNames.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.PropertyNames.Value))
+		d = append(d, low.HashField("propertyNames", low.GenerateHashString(s.PropertyNames.Value)))
 	}
 	if !s.UnevaluatedProperties.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.UnevaluatedProperties.Value))
+		d = append(d, low.HashField("unevaluatedProperties", low.GenerateHashString(s.UnevaluatedProperties.Value)))
 	}
 	if !s.UnevaluatedItems.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.UnevaluatedItems.Value))
+		d = append(d, low.HashField("unevaluatedItems", low.GenerateHashString(s.UnevaluatedItems.Value)))
 	}
 	if !s.Anchor.IsEmpty() {
-		d = append(d, fmt.Sprint(s.Anchor.Value))
+		d = append(d, low.HashField("anchor", fmt.Sprint(s.Anchor.Value)))
+	}
+	// older versions didn't read the following fields, so legacy hashes leave them out.
+	if !s.DynamicAnchor.IsEmpty() && !legacy {
+		d = append(d, low.HashField("dynamicAnchor", fmt.Sprint(s.DynamicAnchor.Value)))
+	}
+	if !s.DynamicRef.IsEmpty() && !legacy {
+		d = append(d, low.HashField("dynamicRef", fmt.Sprint(s.DynamicRef.Value)))
+	}
+	if !s.Const.IsEmpty() && !legacy {
+		if s.Const.Value == nil {
+			// const: null
+			d = append(d, low.HashField("const", "null"))
+		} else {
+			d = append(d, low.HashField("const", low.GenerateHashString(s.Const.Value)))
+		}
+	}
+	if !s.ContentSchema.IsEmpty() && !legacy {
+		d = append(d, low.HashField("contentSchema", low.GenerateHashString(s.ContentSchema.Value)))
+	}
+
+	depRequiredKeys := make([]string, len(s.DependentRequired.Value))
+	z = 0
+	for k, v := range s.DependentRequired.Value {
+		depRequiredKeys[z] = fmt.Sprintf("%s:%s", k.Value, strings.Join(v.Value, ","))
+		z++
+	}
+	sort.Strings(depRequiredKeys)
+	if !legacy {
+		d = append(d, low.HashFields("dependentRequired", depRequiredKeys)...)
 	}
 
 	depSchemasKeys := make([]string, len(s.DependentSchemas.Value))
@@ -418,7 +435,8 @@ func (s *Schema) Hash() [32]byte {
 	}
 	sort.Strings(depSchemasKeys)
 	for k := range depSchemasKeys {
-		d = append(d, low.GenerateHashString(s.FindDependentSchema(depSchemasKeys[k]).Value))
+		d = append(d, low.HashField("dependentSchemas",
+			low.HashEntry(depSchemasKeys[k], low.GenerateHashString(s.FindDependentSchema(depSchemasKeys[k]).Value))))
 	}
 
 	patternPropsKeys := make([]string, len(s.PatternProperties.Value))
@@ -429,7 +447,9 @@ func (s *Schema) Hash() [32]byte {
 	}
 	sort.Strings(patternPropsKeys)
 	for k := range patternPropsKeys {
-		d = append(d, low.GenerateHashString(s.FindPatternProperty(patternPropsKeys[k]).Value))
+		pattern := patternPropsKeys[k]
+		d = append(d, low.HashField("patternProperties",
+			low.HashEntry(pattern, low.GenerateHashString(s.FindPatternProperty(pattern).Value))))
 	}
 
 	if len(s.PrefixItems.Value) > 0 {
@@ -445,32 +465,25 @@ func (s *Schema) Hash() [32]byte {
 		}
 		sort.Strings(itemsKeys)
 		for k := range itemsKeys {
-			d = append(d, low.GenerateHashString(itemsEntities[itemsKeys[k]]))
+			d = append(d, low.HashField("prefixItems", low.GenerateHashString(itemsEntities[itemsKeys[k]])))
 		}
 	}
 
 	// add extensions to hash
-	keys = make([]string, len(s.Extensions))
-	z = 0
-	for k := range s.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(s.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	d = append(d, keys...)
+	d = append(d, low.HashExtensions(s.Extensions)...)
 	if s.Example.Value != nil {
-		d = append(d, low.GenerateHashString(s.Example.Value))
+		d = append(d, low.HashField("example", low.GenerateHashString(s.Example.Value)))
 	}
 
 	// contains
 	if !s.Contains.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Contains.Value))
+		d = append(d, low.HashField("contains", low.GenerateHashString(s.Contains.Value)))
 	}
 	if !s.MinContains.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MinContains.Value))
+		d = append(d, low.HashField("minContains", fmt.Sprint(s.MinContains.Value)))
 	}
 	if !s.MaxContains.IsEmpty() {
-		d = append(d, fmt.Sprint(s.MaxContains.Value))
+		d = append(d, low.HashField("maxContains", fmt.Sprint(s.MaxContains.Value)))
 	}
 	if !s.Examples.IsEmpty() {
 		var xph []string
@@ -478,9 +491,52 @@ func (s *Schema) Hash() [32]byte {
 			xph = append(xph, low.GenerateHashString(s.Examples.Value[w].Value))
 		}
 		sort.Strings(xph)
-		d = append(d, strings.Join(xph, "|"))
+		d = append(d, low.HashField("examples", strings.Join(xph, "|")))
 	}
-	return sha256.Sum256([]byte(strings.Join(d, "|")))
+	return low.HashStrings(d)
+}
+
+// legacyAdditionalPropertiesHash returns additionalProperties hashed the way older versions did, for legacy hashes
+// (see low.UseLegacyHashes). Older versions only read a map as a schema if it had a type or was a reference, any
+// other map or sequence was hashed entry by entry, and other values weren't read at all (returning false).
+func (s *Schema) legacyAdditionalPropertiesHash() (string, bool) {
+	node := s.AdditionalProperties.ValueNode
+	if s.AdditionalProperties.Value.IsB() {
+		return low.GenerateHashString(s.AdditionalProperties.Value.B), true
+	}
+	if !utils.IsNodeMap(node) && !utils.IsNodeArray(node) {
+		return "", false
+	}
+	if s.AdditionalProperties.Value.IsA() {
+		_, typeNode := utils.FindKeyNodeTop(TypeLabel, node.Content)
+		if isRef, _, _ := utils.IsNodeRefValue(node); isRef || typeNode != nil {
+			return low.GenerateHashString(s.AdditionalProperties.Value.A), true
+		}
+	}
+	var values []string
+	if utils.IsNodeMap(node) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			values = append(values, fmt.Sprintf("%d:%s:%s", node.Content[i].Line, node.Content[i].Value,
+				low.GenerateHashString(node.Content[i+1].Value)))
+		}
+	} else {
+		for i, item := range node.Content {
+			if !utils.IsNodeMap(item) {
+				values = append(values, fmt.Sprintf("%d:%s", i, low.GenerateHashString(item.Value)))
+				continue
+			}
+			var entries map[string]any
+			_ = item.Decode(&entries)
+			for k, v := range entries {
+				if v == nil {
+					v = "<nil>"
+				}
+				values = append(values, fmt.Sprintf("%s:%s", k, low.GenerateHashString(v)))
+			}
+		}
+	}
+	sort.Strings(values)
+	return strings.Join(values, "||"), true
 }
 
 // FindProperty will return a ValueReference pointer containing a SchemaProxy pointer
@@ -495,6 +551,12 @@ func (s *Schema) FindDependentSchema(name string) *low.ValueReference[*SchemaPro
 	return low.FindItemInMap[*SchemaProxy](name, s.DependentSchemas.Value)
 }
 
+// FindDependentRequired will return a ValueReference pointer containing the names of properties that are
+// required when the named property is present. if found (3.1+ only)
+func (s *Schema) FindDependentRequired(name string) *low.ValueReference[[]string] {
+	return low.FindItemInMap[[]string](name, s.DependentRequired.Value)
+}
+
 // FindPatternProperty will return a ValueReference pointer containing a SchemaProxy pointer
 // from a pattern property key name. if found (3.1+ only)
 func (s *Schema) FindPatternProperty(name string) *low.ValueReference[*SchemaProxy] {
@@ -530,25 +592,38 @@ func (s *Schema) GetExtensions() map[low.KeyReference[string]]low.ValueReference
 //   - UnevaluatedItems
 //   - UnevaluatedProperties
 //   - Anchor
+//   - DynamicAnchor
+//   - DynamicRef
+//   - DependentRequired
+//   - ContentSchema
 func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	s.Reference = new(low.Reference)
+	inheritedDialect := s.Dialect
+	s.Dialect = low.NodeReference[string]{}
+	s.path = append(s.path[:len(s.path):len(s.path)], root)
 	if h, _, _ := utils.IsNodeRefValue(root); h {
+		inheritedDialect = low.NodeReference[string]{} // a referenced schema does not inherit from the referrer.
 		ref, err := low.LocateRefNode(root, idx)
 		if ref != nil {
-			root = ref
 			if err != nil {
 				if !idx.AllowCircularReferenceResolving() {
-					return fmt.Errorf("build schema failed: %s", err.Error())
+					return low.NewBuildError(low.ErrorCircularReference, root, idx, nil,
+						"build schema failed: %s", err.Error())
 				}
 			}
+			root = ref
+			s.path = append(s.path, root)
 		} else {
-			return fmt.Errorf("build schema failed: reference cannot be found: '%s', line %d, col %d",
+			return low.NewBuildError(low.ErrorReferenceNotFound, root.Content[1], idx, nil,
+				"build schema failed: reference cannot be found: '%s', line %d, col %d",
 				root.Content[1].Value, root.Content[1].Line, root.Content[1].Column)
 		}
 	}
 
+	s.SetNodes(root, idx)
+
 	// Build model using possibly dereferenced root
 	if err := low.BuildModel(root, s); err != nil {
 		return err
@@ -629,27 +704,96 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	_, schemaRefLabel, schemaRefNode := utils.FindKeyNodeFullTop(SchemaTypeLabel, root.Content)
 	if schemaRefNode != nil {
 		s.SchemaTypeRef = low.NodeReference[string]{
-			Value: schemaRefNode.Value, KeyNode: schemaRefLabel, ValueNode: schemaRefLabel,
+			Value: schemaRefNode.Value, KeyNode: schemaRefLabel, ValueNode: schemaRefNode,
 		}
 	}
 
+	// determine the effective dialect, a '$schema' starts a new schema resource with its own dialect.
+	switch {
+	case schemaRefNode != nil:
+		s.Dialect = s.SchemaTypeRef
+	case inheritedDialect.Value != "":
+		s.Dialect = inheritedDialect
+	case idx != nil:
+		s.Dialect = low.NodeReference[string]{Value: idx.GetJSONSchemaDialect()}
+	}
+
 	// handle anchor if set. (3.1)
 	_, anchorLabel, anchorNode := utils.FindKeyNodeFullTop(AnchorLabel, root.Content)
 	if anchorNode != nil {
 		s.Anchor = low.NodeReference[string]{
-			Value: anchorNode.Value, KeyNode: anchorLabel, ValueNode: anchorLabel,
+			Value: anchorNode.Value, KeyNode: anchorLabel, ValueNode: anchorNode,
+		}
+	}
+
+	// handle dynamic anchor if set. (3.1)
+	_, dynAnchorLabel, dynAnchorNode := utils.FindKeyNodeFullTop(DynamicAnchorLabel, root.Content)
+	if dynAnchorNode != nil {
+		s.DynamicAnchor = low.NodeReference[string]{
+			Value: dynAnchorNode.Value, KeyNode: dynAnchorLabel, ValueNode: dynAnchorNode,
+		}
+	}
+
+	// handle dynamic reference if set. (3.1) the schema it points to is resolved using the dynamic scope
+	// of the evaluation path, and is only built when requested (these are very often recursive).
+	_, dynRefLabel, dynRefNode := utils.FindKeyNodeFullTop(DynamicRefLabel, root.Content)
+	if dynRefNode != nil {
+		s.DynamicRef = low.NodeReference[string]{
+			Value: dynRefNode.Value, KeyNode: dynRefLabel, ValueNode: dynRefNode,
+		}
+		if idx != nil {
+			if resolved := idx.ResolveDynamicRefInScope(dynRefNode.Value, root, s.path); resolved != nil {
+				s.DynamicRefSchema = low.NodeReference[*SchemaProxy]{
+					Value: &SchemaProxy{
+						kn:              dynRefLabel,
+						vn:              resolved.Node,
+						idx:             idx,
+						isReference:     true,
+						referenceLookup: dynRefNode.Value,
+						path:            s.path,
+					},
+					KeyNode:   dynRefLabel,
+					ValueNode: dynRefNode,
+				}
+			}
+		}
+	}
+
+	// handle dependent required if set. (3.1)
+	_, depReqLabel, depReqNode := utils.FindKeyNodeFullTop(DependentRequiredLabel, root.Content)
+	if depReqNode != nil && utils.IsNodeMap(depReqNode) {
+		depRequired := make(map[low.KeyReference[string]]low.ValueReference[[]string])
+		var currentKey *yaml.Node
+		for i := range depReqNode.Content {
+			if i%2 == 0 {
+				currentKey = depReqNode.Content[i]
+				continue
+			}
+			var required []string
+			for _, n := range depReqNode.Content[i].Content {
+				required = append(required, n.Value)
+			}
+			depRequired[low.KeyReference[string]{Value: currentKey.Value, KeyNode: currentKey}] =
+				low.ValueReference[[]string]{Value: required, ValueNode: depReqNode.Content[i]}
+		}
+		s.DependentRequired = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[[]string]]{
+			Value: depRequired, KeyNode: depReqLabel, ValueNode: depReqNode,
 		}
 	}
 
 	// handle example if set. (3.0)
 	_, expLabel, expNode := utils.FindKeyNodeFull(ExampleLabel, root.Content)
-	if expNode != nil {
+	if idx.SkipExamples() {
+		s.Example = low.NodeReference[any]{} // set by BuildModel.
+	} else if expNode != nil {
 		s.Example = low.NodeReference[any]{Value: ExtractExampleValue(expNode), KeyNode: expLabel, ValueNode: expNode}
 	}
 
 	// handle examples if set.(3.1)
 	_, expArrLabel, expArrNode := utils.FindKeyNodeFullTop(ExamplesLabel, root.Content)
-	if expArrNode != nil {
+	if idx.SkipExamples() {
+		s.Examples = low.NodeReference[[]low.ValueReference[any]]{} // set by BuildModel.
+	} else if expArrNode != nil {
 		if utils.IsNodeArray(expArrNode) {
 			var examples []low.ValueReference[any]
 			for i := range expArrNode.Content {
@@ -663,79 +807,27 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		}
 	}
 
+	// additionalProperties is either a schema (A) or a boolean (B), anything else (like a map that holds no schema
+	// keywords, or a sequence) isn't valid, but it's kept (N == 2) so it's rendered as it was read.
 	_, addPLabel, addPNode := utils.FindKeyNodeFullTop(AdditionalPropertiesLabel, root.Content)
 	if addPNode != nil {
-		if utils.IsNodeMap(addPNode) || utils.IsNodeArray(addPNode) {
-			// check if this is a reference, or an inline schema.
-			isRef, _, _ := utils.IsNodeRefValue(addPNode)
-			var sp *SchemaProxy
-			// now check if this object has a 'type' if so, it's a schema, if not... it's a random
-			// object, and we should treat it as a raw map.
-			if _, v := utils.FindKeyNodeTop(TypeLabel, addPNode.Content); v != nil {
-				sp = &SchemaProxy{
-					kn:  addPLabel,
-					vn:  addPNode,
-					idx: idx,
-				}
-			}
-			if isRef {
-				_, vn := utils.FindKeyNodeTop("$ref", addPNode.Content)
-				sp = &SchemaProxy{
-					kn:              addPLabel,
-					vn:              addPNode,
-					idx:             idx,
-					isReference:     true,
-					referenceLookup: vn.Value,
-				}
-			}
-
-			// if this is a reference, or a schema, we're done.
-			if sp != nil {
-				s.AdditionalProperties = low.NodeReference[any]{Value: sp, KeyNode: addPLabel, ValueNode: addPNode}
-			} else {
-
-				// if this is a map, collect all the keys and values.
-				if utils.IsNodeMap(addPNode) {
-
-					addProps := make(map[low.KeyReference[string]]low.ValueReference[any])
-					var label string
-					for g := range addPNode.Content {
-						if g%2 == 0 {
-							label = addPNode.Content[g].Value
-							continue
-						} else {
-							addProps[low.KeyReference[string]{Value: label, KeyNode: addPNode.Content[g-1]}] =
-								low.ValueReference[any]{Value: addPNode.Content[g].Value, ValueNode: addPNode.Content[g]}
-						}
-					}
-					s.AdditionalProperties = low.NodeReference[any]{Value: addProps, KeyNode: addPLabel, ValueNode: addPNode}
-				}
-
-				// if the node is an array, extract everything into a trackable structure
-				if utils.IsNodeArray(addPNode) {
-					var addProps []low.ValueReference[any]
-
-					// if this is an array or maps, encode the map items correctly.
-					for i := range addPNode.Content {
-						if utils.IsNodeMap(addPNode.Content[i]) {
-							var prop map[string]any
-							_ = addPNode.Content[i].Decode(&prop)
-							addProps = append(addProps,
-								low.ValueReference[any]{Value: prop, ValueNode: addPNode.Content[i]})
-						} else {
-							addProps = append(addProps,
-								low.ValueReference[any]{Value: addPNode.Content[i].Value, ValueNode: addPNode.Content[i]})
-						}
-					}
-
-					s.AdditionalProperties =
-						low.NodeReference[any]{Value: addProps, KeyNode: addPLabel, ValueNode: addPNode}
-				}
+		addProps := &SchemaDynamicValue[*SchemaProxy, bool]{N: 2}
+		if isSchemaNode(addPNode) {
+			sp := &SchemaProxy{kn: addPLabel, vn: addPNode, idx: idx}
+			if isRef, _, refLocation := utils.IsNodeRefValue(addPNode); isRef {
+				sp.isReference = true
+				sp.referenceLookup = refLocation
 			}
+			addProps = &SchemaDynamicValue[*SchemaProxy, bool]{N: 0, A: sp}
 		}
 		if utils.IsNodeBoolValue(addPNode) {
 			b, _ := strconv.ParseBool(addPNode.Value)
-			s.AdditionalProperties = low.NodeReference[any]{Value: b, KeyNode: addPLabel, ValueNode: addPNode}
+			addProps = &SchemaDynamicValue[*SchemaProxy, bool]{N: 1, B: b}
+		}
+		s.AdditionalProperties = low.NodeReference[*SchemaDynamicValue[*SchemaProxy, bool]]{
+			Value:     addProps,
+			KeyNode:   addPLabel,
+			ValueNode: addPNode,
 		}
 	}
 
@@ -744,6 +836,8 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	if discNode != nil {
 		var discriminator Discriminator
 		_ = low.BuildModel(discNode, &discriminator)
+		discriminator.SetNodes(discNode, idx)
+		discriminator.SetKeyNode(discLabel)
 		s.Discriminator = low.NodeReference[*Discriminator]{Value: &discriminator, KeyNode: discLabel, ValueNode: discNode}
 	}
 
@@ -753,6 +847,8 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		var exDoc ExternalDoc
 		_ = low.BuildModel(extDocNode, &exDoc)
 		_ = exDoc.Build(extDocNode, idx) // throws no errors, can't check for one.
+		exDoc.SetOrigin(extDocNode, idx)
+		exDoc.SetKeyNode(extDocLabel)
 		s.ExternalDocs = low.NodeReference[*ExternalDoc]{Value: &exDoc, KeyNode: extDocLabel, ValueNode: extDocNode}
 	}
 
@@ -763,6 +859,8 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		_ = low.BuildModel(xmlNode, &xml)
 		// extract extensions if set.
 		_ = xml.Build(xmlNode, idx) // returns no errors, can't check for one.
+		xml.SetOrigin(xmlNode, idx)
+		xml.SetKeyNode(xmlLabel)
 		s.XML = low.NodeReference[*XML]{Value: &xml, KeyNode: xmlLabel, ValueNode: xmlNode}
 	}
 
@@ -836,7 +934,8 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	}
 
 	var allOf, anyOf, oneOf, prefixItems []low.ValueReference[*SchemaProxy]
-	var items, not, contains, sif, selse, sthen, propertyNames, unevalItems, unevalProperties low.ValueReference[*SchemaProxy]
+	var items, not, contains, sif, selse, sthen, propertyNames, unevalItems, unevalProperties,
+		contentSchema low.ValueReference[*SchemaProxy]
 
 	_, allOfLabel, allOfValue := utils.FindKeyNodeFullTop(AllOfLabel, root.Content)
 	_, anyOfLabel, anyOfValue := utils.FindKeyNodeFullTop(AnyOfLabel, root.Content)
@@ -850,6 +949,13 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	_, propNamesLabel, propNamesValue := utils.FindKeyNodeFullTop(PropertyNamesLabel, root.Content)
 	_, unevalItemsLabel, unevalItemsValue := utils.FindKeyNodeFullTop(UnevaluatedItemsLabel, root.Content)
 	_, unevalPropsLabel, unevalPropsValue := utils.FindKeyNodeFullTop(UnevaluatedPropertiesLabel, root.Content)
+	_, contentSchemaLabel, contentSchemaValue := utils.FindKeyNodeFullTop(ContentSchemaLabel, root.Content)
+
+	// before 2020-12, an array of items is used for tuple validation, which is what prefixItems is used for now.
+	if !itemsIsBool && utils.IsNodeArray(itemsValue) && prefixItemsValue == nil && s.usesTupleItems() {
+		prefixItemsLabel, prefixItemsValue = itemsLabel, itemsValue
+		itemsValue = nil
+	}
 
 	errorChan := make(chan error)
 	allOfChan := make(chan schemaProxyBuildResult)
@@ -865,6 +971,7 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	propNamesChan := make(chan schemaProxyBuildResult)
 	unevalItemsChan := make(chan schemaProxyBuildResult)
 	unevalPropsChan := make(chan schemaProxyBuildResult)
+	contentSchemaChan := make(chan schemaProxyBuildResult)
 
 	totalBuilds := countSubSchemaItems(allOfValue) +
 		countSubSchemaItems(anyOfValue) +
@@ -919,6 +1026,10 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		totalBuilds++
 		go buildSchema(unevalPropsChan, unevalPropsLabel, unevalPropsValue, errorChan, idx)
 	}
+	if contentSchemaValue != nil {
+		totalBuilds++
+		go buildSchema(contentSchemaChan, contentSchemaLabel, contentSchemaValue, errorChan, idx)
+	}
 
 	completeCount := 0
 	for completeCount < totalBuilds {
@@ -964,6 +1075,9 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		case r := <-unevalPropsChan:
 			completeCount++
 			unevalProperties = r.v
+		case r := <-contentSchemaChan:
+			completeCount++
+			contentSchema = r.v
 		}
 	}
 
@@ -1053,6 +1167,13 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			ValueNode: unevalItemsValue,
 		}
 	}
+	if !contentSchema.IsEmpty() {
+		s.ContentSchema = low.NodeReference[*SchemaProxy]{
+			Value:     contentSchema.Value,
+			KeyNode:   contentSchemaLabel,
+			ValueNode: contentSchemaValue,
+		}
+	}
 	if !unevalIsBool && !unevalProperties.IsEmpty() {
 		s.UnevaluatedProperties = low.NodeReference[*SchemaDynamicValue[*SchemaProxy, *bool]]{
 			Value: &SchemaDynamicValue[*SchemaProxy, *bool]{
@@ -1062,15 +1183,82 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			ValueNode: unevalPropsValue,
 		}
 	}
+	s.inherit()
+
+	// the discriminator needs the index and its owner to resolve mappings.
+	if s.Discriminator.Value != nil {
+		s.Discriminator.Value.idx = idx
+		s.Discriminator.Value.parent = s
+		s.Discriminator.Value.warnUnresolvedMappings()
+	}
 	return nil
 }
 
+// GetDraft returns the JSON Schema draft the effective dialect of this schema is based on.
+func (s *Schema) GetDraft() index.SchemaDraft {
+	return index.DialectDraft(s.Dialect.Value)
+}
+
+// usesTupleItems returns true if the dialect of the schema treats an array of items as a tuple. OpenAPI 3.0 and
+// Swagger schemas do not allow an array of items at all, so they are left as they are.
+func (s *Schema) usesTupleItems() bool {
+	if s.Dialect.Value == index.DialectOpenAPI30 || s.Dialect.Value == index.DialectSwagger20 {
+		return false
+	}
+	d := s.GetDraft()
+	return d != index.DraftUnknown && d < index.Draft202012
+}
+
+// inherit passes the evaluation path down to all sub-schemas, and the dialect of this schema down to all inline
+// sub-schemas. References keep their dialect, because they take the dialect of the document (or resource) they
+// live in.
+func (s *Schema) inherit() {
+	inherit := func(sp *SchemaProxy) {
+		if sp == nil {
+			return
+		}
+		sp.path = s.path
+		if !sp.isReference {
+			sp.dialect = s.Dialect
+		}
+	}
+	for _, sch := range [][]low.ValueReference[*SchemaProxy]{
+		s.AllOf.Value, s.OneOf.Value, s.AnyOf.Value, s.PrefixItems.Value,
+	} {
+		for i := range sch {
+			inherit(sch[i].Value)
+		}
+	}
+	for _, sch := range []map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]{
+		s.Properties.Value, s.DependentSchemas.Value, s.PatternProperties.Value,
+	} {
+		for _, v := range sch {
+			inherit(v.Value)
+		}
+	}
+	for _, sp := range []*SchemaProxy{
+		s.Not.Value, s.Contains.Value, s.If.Value, s.Else.Value, s.Then.Value, s.PropertyNames.Value,
+		s.UnevaluatedItems.Value, s.ContentSchema.Value,
+	} {
+		inherit(sp)
+	}
+	if s.Items.Value != nil && s.Items.Value.IsA() {
+		inherit(s.Items.Value.A)
+	}
+	if s.UnevaluatedProperties.Value != nil && s.UnevaluatedProperties.Value.IsA() {
+		inherit(s.UnevaluatedProperties.Value.A)
+	}
+	if s.AdditionalProperties.Value != nil && s.AdditionalProperties.Value.IsA() {
+		inherit(s.AdditionalProperties.Value.A)
+	}
+}
+
 func buildPropertyMap(root *yaml.Node, idx *index.SpecIndex, label string) (*low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]], error) {
 	// for property, build in a new thread!
 	bChan := make(chan schemaProxyBuildResult)
 
 	buildProperty := func(label *yaml.Node, value *yaml.Node, c chan schemaProxyBuildResult, isRef bool,
-		refString string,
+		refString string, refNode *yaml.Node,
 	) {
 		c <- schemaProxyBuildResult{
 			k: low.KeyReference[string]{
@@ -1078,7 +1266,8 @@ func buildPropertyMap(root *yaml.Node, idx *index.SpecIndex, label string) (*low
 				Value:   label.Value,
 			},
 			v: low.ValueReference[*SchemaProxy]{
-				Value:     &SchemaProxy{kn: label, vn: value, idx: idx, isReference: isRef, referenceLookup: refString},
+				Value: &SchemaProxy{kn: label, vn: value, idx: idx, isReference: isRef, referenceLookup: refString,
+					refNode: refNode},
 				ValueNode: value,
 			},
 		}
@@ -1098,19 +1287,22 @@ func buildPropertyMap(root *yaml.Node, idx *index.SpecIndex, label string) (*low
 			// check our prop isn't reference
 			isRef := false
 			refString := ""
+			var refNode *yaml.Node
 			if h, _, l := utils.IsNodeRefValue(prop); h {
 				ref, _ := low.LocateRefNode(prop, idx)
 				if ref != nil {
 					isRef = true
+					refNode = prop
 					prop = ref
 					refString = l
 				} else {
-					return nil, fmt.Errorf("schema properties build failed: cannot find reference %s, line %d, col %d",
+					return nil, low.NewBuildError(low.ErrorReferenceNotFound, prop.Content[1], idx, nil,
+						"schema properties build failed: cannot find reference %s, line %d, col %d",
 						prop.Content[1].Value, prop.Content[1].Line, prop.Content[1].Column)
 				}
 			}
 			totalProps++
-			go buildProperty(currentProp, prop, bChan, isRef, refString)
+			go buildProperty(currentProp, prop, bChan, isRef, refString, refNode)
 		}
 		completedProps := 0
 		for completedProps < totalProps {
@@ -1140,6 +1332,39 @@ func countSubSchemaItems(node *yaml.Node) int {
 	return 0
 }
 
+// schemaKeywords are the keys that make a map a schema, see isSchemaNode.
+var schemaKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$ref": true, "$anchor": true, "$dynamicAnchor": true, "$dynamicRef": true,
+	"$defs": true, "$comment": true, "definitions": true, "type": true, "enum": true, "const": true, "title": true,
+	"description": true, "default": true, "example": true, "examples": true, "deprecated": true, "readOnly": true,
+	"writeOnly": true, "nullable": true, "format": true, "pattern": true, "multipleOf": true, "maximum": true,
+	"minimum": true, "exclusiveMaximum": true, "exclusiveMinimum": true, "maxLength": true, "minLength": true,
+	"maxItems": true, "minItems": true, "uniqueItems": true, "maxContains": true, "minContains": true,
+	"maxProperties": true, "minProperties": true, "required": true, "dependentRequired": true, "properties": true,
+	"patternProperties": true, "additionalProperties": true, "propertyNames": true, "unevaluatedProperties": true,
+	"items": true, "prefixItems": true, "contains": true, "unevaluatedItems": true, "allOf": true, "anyOf": true,
+	"oneOf": true, "not": true, "if": true, "then": true, "else": true, "dependentSchemas": true,
+	"contentEncoding": true, "contentMediaType": true, "contentSchema": true, "discriminator": true, "xml": true,
+	"externalDocs": true,
+}
+
+// isSchemaNode returns true if a node is a schema: an empty map, or a map holding a reference, a schema keyword or
+// an extension. A map holding none of those (like 'why: yes') isn't read as a schema, it would be empty.
+func isSchemaNode(node *yaml.Node) bool {
+	if !utils.IsNodeMap(node) {
+		return false
+	}
+	if len(node.Content) == 0 {
+		return true
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		if schemaKeywords[node.Content[i].Value] || strings.HasPrefix(node.Content[i].Value, "x-") {
+			return true
+		}
+	}
+	return false
+}
+
 // schema build result container used for async building.
 type schemaProxyBuildResult struct {
 	k low.KeyReference[string]
@@ -1163,7 +1388,7 @@ func buildSchema(schemas chan schemaProxyBuildResult, labelNode, valueNode *yaml
 
 		// build out a SchemaProxy for every sub-schema.
 		build := func(kn *yaml.Node, vn *yaml.Node, schemaIdx int, c chan buildResult,
-			isRef bool, refLocation string,
+			isRef bool, refLocation string, refNode *yaml.Node,
 		) {
 			// a proxy design works best here. polymorphism, pretty much guarantees that a sub-schema can
 			// take on circular references through polymorphism. Like the resolver, if we try and follow these
@@ -1178,6 +1403,7 @@ func buildSchema(schemas chan schemaProxyBuildResult, labelNode, valueNode *yaml
 			if isRef {
 				sp.referenceLookup = refLocation
 				sp.isReference = true
+				sp.refNode = refNode
 			}
 			res := &low.ValueReference[*SchemaProxy]{
 				Value:     sp,
@@ -1191,22 +1417,25 @@ func buildSchema(schemas chan schemaProxyBuildResult, labelNode, valueNode *yaml
 
 		isRef := false
 		refLocation := ""
+		var refNode *yaml.Node
 		if utils.IsNodeMap(valueNode) {
 			h := false
 			if h, _, refLocation = utils.IsNodeRefValue(valueNode); h {
 				isRef = true
 				ref, _ := low.LocateRefNode(valueNode, idx)
 				if ref != nil {
+					refNode = valueNode
 					valueNode = ref
 				} else {
-					errors <- fmt.Errorf("build schema failed: reference cannot be found: %s, line %d, col %d",
+					errors <- low.NewBuildError(low.ErrorReferenceNotFound, valueNode.Content[1], idx, nil,
+						"build schema failed: reference cannot be found: %s, line %d, col %d",
 						valueNode.Content[1].Value, valueNode.Content[1].Line, valueNode.Content[1].Column)
 				}
 			}
 
 			// this only runs once, however to keep things consistent, it makes sense to use the same async method
 			// that arrays will use.
-			go build(labelNode, valueNode, -1, syncChan, isRef, refLocation)
+			go build(labelNode, valueNode, -1, syncChan, isRef, refLocation, refNode)
 			select {
 			case r := <-syncChan:
 				schemas <- schemaProxyBuildResult{
@@ -1224,21 +1453,24 @@ func buildSchema(schemas chan schemaProxyBuildResult, labelNode, valueNode *yaml
 
 			for i, vn := range valueNode.Content {
 				isRef = false
+				refNode = nil
 				h := false
 				if h, _, refLocation = utils.IsNodeRefValue(vn); h {
 					isRef = true
 					ref, _ := low.LocateRefNode(vn, idx)
 					if ref != nil {
+						refNode = vn
 						vn = ref
 					} else {
-						err := fmt.Errorf("build schema failed: reference cannot be found: %s, line %d, col %d",
+						err := low.NewBuildError(low.ErrorReferenceNotFound, vn.Content[1], idx, nil,
+							"build schema failed: reference cannot be found: %s, line %d, col %d",
 							vn.Content[1].Value, vn.Content[1].Line, vn.Content[1].Column)
 						errors <- err
 						return
 					}
 				}
 				refBuilds++
-				go build(vn, vn, i, syncChan, isRef, refLocation)
+				go build(vn, vn, i, syncChan, isRef, refLocation, refNode)
 			}
 
 			completedBuilds := 0
@@ -1272,15 +1504,17 @@ func ExtractSchema(root *yaml.Node, idx *index.SpecIndex) (*low.NodeReference[*S
 
 	isRef := false
 	refLocation := ""
+	var refNode *yaml.Node
 	if rf, rl, _ := utils.IsNodeRefValue(root); rf {
 		// locate reference in index.
 		isRef = true
 		ref, _ := low.LocateRefNode(root, idx)
 		if ref != nil {
+			refNode = root
 			schNode = ref
 			schLabel = rl
 		} else {
-			return nil, fmt.Errorf(errStr,
+			return nil, low.NewBuildError(low.ErrorReferenceNotFound, root.Content[1], idx, nil, errStr,
 				root.Content[1].Value, root.Content[1].Line, root.Content[1].Column)
 		}
 	} else {
@@ -1291,9 +1525,10 @@ func ExtractSchema(root *yaml.Node, idx *index.SpecIndex) (*low.NodeReference[*S
 				isRef = true
 				ref, _ := low.LocateRefNode(schNode, idx)
 				if ref != nil {
+					refNode = schNode
 					schNode = ref
 				} else {
-					return nil, fmt.Errorf(errStr,
+					return nil, low.NewBuildError(low.ErrorReferenceNotFound, schNode.Content[1], idx, nil, errStr,
 						schNode.Content[1].Value, schNode.Content[1].Line, schNode.Content[1].Column)
 				}
 			}
@@ -1302,7 +1537,8 @@ func ExtractSchema(root *yaml.Node, idx *index.SpecIndex) (*low.NodeReference[*S
 
 	if schNode != nil {
 		// check if schema has already been built.
-		schema := &SchemaProxy{kn: schLabel, vn: schNode, idx: idx, isReference: isRef, referenceLookup: refLocation}
+		schema := &SchemaProxy{kn: schLabel, vn: schNode, idx: idx, isReference: isRef, referenceLookup: refLocation,
+			refNode: refNode}
 		return &low.NodeReference[*SchemaProxy]{Value: schema, KeyNode: schLabel, ValueNode: schNode, ReferenceNode: isRef,
 			Reference: refLocation}, nil
 	}
@@ -5,7 +5,9 @@ package base
 
 import (
 	"crypto/sha256"
+	"sync"
 
+	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
@@ -49,8 +51,12 @@ type SchemaProxy struct {
 	idx             *index.SpecIndex
 	rendered        *Schema
 	buildError      error
-	isReference     bool   // Is the schema underneath originally a $ref?
-	referenceLookup string // If the schema is a $ref, what's its name?
+	isReference     bool                      // Is the schema underneath originally a $ref?
+	referenceLookup string                    // If the schema is a $ref, what's its name?
+	dialect         low.NodeReference[string] // dialect inherited from the parent schema, if any.
+	refNode         *yaml.Node                // the $ref node this proxy was located through, if already resolved.
+	path            []*yaml.Node              // the schemas passed through to reach this one (the evaluation path).
+	lock            sync.Mutex                // guards rendered and buildError, a proxy can be used concurrently.
 }
 
 // Build will prepare the SchemaProxy for rendering, it does not build the Schema, only sets up internal state.
@@ -76,17 +82,32 @@ func (sp *SchemaProxy) Build(root *yaml.Node, idx *index.SpecIndex) error {
 // If anything goes wrong during the build, then nothing is returned and the error that occurred can
 // be retrieved by using GetBuildError()
 func (sp *SchemaProxy) Schema() *Schema {
-	if sp.rendered != nil {
-		return sp.rendered
+	sp.lock.Lock()
+	rendered := sp.rendered
+	sp.lock.Unlock()
+	if rendered != nil {
+		return rendered
 	}
+
+	// the lock is not held while building, a schema can lead back to its own proxy. If two goroutines build the
+	// same schema at once, the first one built is kept.
 	schema := new(Schema)
+	schema.Dialect = sp.dialect
+	schema.path = sp.path
 	utils.CheckForMergeNodes(sp.vn)
 	err := schema.Build(sp.vn, sp.idx)
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	if sp.rendered != nil {
+		return sp.rendered
+	}
 	if err != nil {
 		sp.buildError = err
 		return nil
 	}
 	schema.ParentProxy = sp // https://github.com/pb33f/libopenapi/issues/29
+	schema.SetOrigin(sp.originNode(), sp.idx)
+	schema.SetKeyNode(sp.kn)
 	sp.rendered = schema
 	return schema
 }
@@ -94,6 +115,8 @@ func (sp *SchemaProxy) Schema() *Schema {
 // GetBuildError returns the build error that was set when Schema() was called. If Schema() has not been run, or
 // there were no errors during build, then nil will be returned.
 func (sp *SchemaProxy) GetBuildError() error {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
 	return sp.buildError
 }
 
@@ -120,6 +143,29 @@ func (sp *SchemaProxy) SetReference(ref string) {
 	sp.referenceLookup = ref
 }
 
+// SetReferenceNode will record the $ref node this SchemaProxy was located through, used when the proxy has been
+// built from an already resolved node.
+func (sp *SchemaProxy) SetReferenceNode(node *yaml.Node, idx *index.SpecIndex) {
+	sp.refNode = node
+	if sp.idx == nil {
+		sp.idx = idx
+	}
+}
+
+// GetResolutionChain returns every hop made while resolving the $ref this schema points to, in the order they were
+// made. Returns nil if the schema is not a reference.
+func (sp *SchemaProxy) GetResolutionChain() []*low.ReferenceHop {
+	node := sp.refNode
+	if node == nil && sp.isReference {
+		node = sp.vn
+	}
+	if node == nil || sp.idx == nil {
+		return nil
+	}
+	_, chain, _ := low.LocateRefNodeChain(node, sp.idx)
+	return chain
+}
+
 // GetSchemaReference will return the lookup defined by the $ref that this schema points to. If the schema
 // is inline, and not a reference, then this method returns an empty string. Only useful when combined with
 // IsSchemaReference()
@@ -127,24 +173,61 @@ func (sp *SchemaProxy) GetSchemaReference() string {
 	return sp.referenceLookup
 }
 
+// GetOrigin returns the origin of the schema, the absolute location of the document (file or URL) it was read
+// from and its position. If the schema is a reference, this is the origin of the schema being referenced.
+func (sp *SchemaProxy) GetOrigin() *index.NodeOrigin {
+	node := sp.originNode()
+	if node == nil {
+		return nil
+	}
+	if sp.idx == nil {
+		return &index.NodeOrigin{Node: node, Line: node.Line, Column: node.Column}
+	}
+	return sp.idx.FindNodeOrigin(node)
+}
+
+// originNode returns the node the schema is built from, following the reference if the proxy has not been
+// resolved yet.
+func (sp *SchemaProxy) originNode() *yaml.Node {
+	if sp.isReference && sp.idx != nil {
+		if located, _ := low.LocateRefNode(sp.vn, sp.idx); located != nil {
+			return located
+		}
+	}
+	return sp.vn
+}
+
 // GetValueNode will return the yaml.Node pointer used by the proxy to generate the Schema.
 func (sp *SchemaProxy) GetValueNode() *yaml.Node {
 	return sp.vn
 }
 
+// SetKeyNode records the key node that holds the schema, like the name of a property.
+func (sp *SchemaProxy) SetKeyNode(keyNode *yaml.Node) {
+	sp.kn = keyNode
+}
+
+// GetKeyNode returns the key node that holds the schema, or nil if the schema is not held by a key (like the items
+// of an allOf).
+func (sp *SchemaProxy) GetKeyNode() *yaml.Node {
+	return sp.kn
+}
+
+// GetRootNode returns the node the Schema is built from, following the reference if the schema is a reference.
+func (sp *SchemaProxy) GetRootNode() *yaml.Node {
+	return sp.originNode()
+}
+
+// GetIndex returns the index used to build the Schema.
+func (sp *SchemaProxy) GetIndex() *index.SpecIndex {
+	return sp.idx
+}
+
 // Hash will return a consistent SHA256 Hash of the SchemaProxy object (it will resolve it)
 func (sp *SchemaProxy) Hash() [32]byte {
-	if sp.rendered != nil {
-		if !sp.isReference {
-			return sp.rendered.Hash()
-		}
-	} else {
-		if !sp.isReference {
-			// only resolve this proxy if it's not a ref.
-			sch := sp.Schema()
-			sp.rendered = sch
-			return sch.Hash()
-		}
+	if !sp.isReference {
+		// only resolve this proxy if it's not a ref.
+		return sp.Schema().Hash()
 	}
 	// hash reference value only, do not resolve!
 	return sha256.Sum256([]byte(sp.referenceLookup))
@@ -5,6 +5,7 @@ package base
 
 import (
 	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"testing"
@@ -22,7 +23,7 @@ description: something`
 	err := sch.Build(idxNode.Content[0], nil)
 	assert.NoError(t, err)
 
-	assert.Equal(t, "db2a35dd6fb3d9481d0682571b9d687616bb2a34c1887f7863f0b2e769ca7b23",
+	assert.Equal(t, "6fdc1777787663ed903299dfe93b349f036bfc5477e52e44d1c2a058d9c19f40",
 		low.GenerateHashString(&sch))
 
 	assert.Equal(t, "something", sch.Schema().Description.Value)
@@ -35,7 +36,7 @@ description: something`
 	assert.Equal(t, "coffee", sch.GetReference())
 
 	// already rendered, should spit out the same
-	assert.Equal(t, "db2a35dd6fb3d9481d0682571b9d687616bb2a34c1887f7863f0b2e769ca7b23",
+	assert.Equal(t, "6fdc1777787663ed903299dfe93b349f036bfc5477e52e44d1c2a058d9c19f40",
 		low.GenerateHashString(&sch))
 
 	assert.Len(t, sch.Schema().GetExtensions(), 1)
@@ -70,7 +71,7 @@ func TestSchemaProxy_Build_HashInline(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, sch.IsSchemaReference())
 	assert.NotNil(t, sch.Schema())
-	assert.Equal(t, "6da88c34ba124c41f977db66a4fc5c1a951708d285c81bb0d47c3206f4c27ca8",
+	assert.Equal(t, "8ed0241ae50eb15fa6b1c05dcb7cb6e1d3c87c14feb121ef4a65dabbfd722a63",
 		low.GenerateHashString(&sch))
 }
 
@@ -94,3 +95,44 @@ x-common-definitions:
 	assert.Equal(t, "The type of life cycle", sch.Schema().Description.Value)
 
 }
+
+func TestSchemaProxy_GetResolutionChain(t *testing.T) {
+
+	yml := `components:
+  schemas:
+    Pet:
+      type: object
+    Dog:
+      $ref: '#/components/schemas/Pet'
+    Owner:
+      properties:
+        dog:
+          $ref: '#/components/schemas/Dog'
+        name:
+          type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+
+	owner := idxNode.Content[0].Content[1].Content[1].Content[5]
+	var sch SchemaProxy
+	err := sch.Build(owner, idx)
+	assert.NoError(t, err)
+	assert.Nil(t, sch.GetResolutionChain())
+
+	dog := sch.Schema().FindProperty("dog").Value
+	chain := dog.GetResolutionChain()
+	assert.Len(t, chain, 2)
+	assert.Equal(t, "#/components/schemas/Dog", chain[0].Reference)
+	assert.Equal(t, 10, chain[0].Node.Line)
+	assert.Equal(t, "#/components/schemas/Pet", chain[1].Reference)
+	assert.Equal(t, 4, chain[1].Target.Line)
+	assert.Nil(t, sch.Schema().FindProperty("name").Value.GetResolutionChain())
+
+	// a proxy built directly from a $ref node.
+	var ref SchemaProxy
+	err = ref.Build(owner.Content[1].Content[1], idx)
+	assert.NoError(t, err)
+	assert.Len(t, ref.GetResolutionChain(), 2)
+}
@@ -1,6 +1,8 @@
 package base
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -169,7 +171,8 @@ func Test_Schema(t *testing.T) {
 	schErr := sch.Build(rootNode.Content[0], nil)
 	assert.NoError(t, schErr)
 	assert.Equal(t, "something object", sch.Description.Value)
-	assert.True(t, sch.AdditionalProperties.Value.(bool))
+	assert.True(t, sch.AdditionalProperties.Value.IsB())
+	assert.True(t, sch.AdditionalProperties.Value.B)
 
 	assert.Len(t, sch.Properties.Value, 2)
 	v := sch.FindProperty("somethingB")
@@ -195,6 +198,7 @@ func Test_Schema(t *testing.T) {
 	assert.Len(t, j.XML.Value.GetExtensions(), 1)
 
 	assert.NotNil(t, v.Value.Schema().AdditionalProperties.Value)
+	assert.Equal(t, 2, v.Value.Schema().AdditionalProperties.Value.N)
 
 	var addProps map[string]interface{}
 	v.Value.Schema().AdditionalProperties.ValueNode.Decode(&addProps)
@@ -1154,7 +1158,7 @@ func TestExtractSchema_AdditionalPropertiesAsSchema(t *testing.T) {
 
 	res, err := ExtractSchema(idxNode.Content[0], idx)
 
-	assert.NotNil(t, res.Value.Schema().AdditionalProperties.Value.(*SchemaProxy).Schema())
+	assert.NotNil(t, res.Value.Schema().AdditionalProperties.Value.A.Schema())
 	assert.Nil(t, err)
 
 }
@@ -1178,11 +1182,71 @@ func TestExtractSchema_AdditionalPropertiesAsSchemaSlice(t *testing.T) {
 
 	res, err := ExtractSchema(idxNode.Content[0], idx)
 
-	assert.NotNil(t, res.Value.Schema().AdditionalProperties.Value.([]low.ValueReference[interface{}]))
+	// a slice is neither a schema nor a boolean, it's kept as it was read.
+	assert.Equal(t, 2, res.Value.Schema().AdditionalProperties.Value.N)
+	assert.Len(t, res.Value.Schema().AdditionalProperties.ValueNode.Content, 1)
 	assert.Nil(t, err)
 
 }
 
+func TestExtractSchema_AdditionalPropertiesFalse(t *testing.T) {
+	yml := `additionalProperties: false`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var sch Schema
+	_ = low.BuildModel(idxNode.Content[0], &sch)
+	assert.NoError(t, sch.Build(idxNode.Content[0], index.NewSpecIndex(&idxNode)))
+	assert.True(t, sch.AdditionalProperties.Value.IsB())
+	assert.False(t, sch.AdditionalProperties.Value.B)
+
+	yml = `additionalProperties: {}`
+	var emptyNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &emptyNode)
+
+	var empty Schema
+	_ = low.BuildModel(emptyNode.Content[0], &empty)
+	assert.NoError(t, empty.Build(emptyNode.Content[0], index.NewSpecIndex(&emptyNode)))
+	assert.True(t, empty.AdditionalProperties.Value.IsA())
+	assert.NotNil(t, empty.AdditionalProperties.Value.A.Schema())
+
+	// the boolean and the empty schema forms must not hash the same.
+	assert.NotEqual(t, sch.Hash(), empty.Hash())
+}
+
+func TestExtractSchema_AdditionalPropertiesRaw(t *testing.T) {
+	build := func(yml string) *Schema {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		var sch Schema
+		_ = low.BuildModel(node.Content[0], &sch)
+		assert.NoError(t, sch.Build(node.Content[0], index.NewSpecIndex(&node)))
+		return &sch
+	}
+
+	// a map without any schema keywords is not a schema.
+	why := build(`additionalProperties:
+  why: yes
+  thatIs: true`)
+	assert.Equal(t, 2, why.AdditionalProperties.Value.N)
+	assert.Equal(t, "why", why.AdditionalProperties.ValueNode.Content[0].Value)
+
+	whyNot := build(`additionalProperties:
+  why: no
+  thatIs: true`)
+	assert.NotEqual(t, why.Hash(), whyNot.Hash())
+
+	// a keyword or an extension makes it a schema.
+	ext := build(`additionalProperties:
+  x-why: yes`)
+	assert.True(t, ext.AdditionalProperties.Value.IsA())
+
+	null := build(`additionalProperties: null`)
+	assert.Equal(t, 2, null.AdditionalProperties.Value.N)
+	assert.NotEqual(t, why.Hash(), null.Hash())
+}
+
 func TestExtractSchema_DoNothing(t *testing.T) {
 	yml := `components:
   schemas:
@@ -1228,7 +1292,8 @@ func TestExtractSchema_AdditionalProperties_Ref(t *testing.T) {
 	_ = yaml.Unmarshal([]byte(yml), &idxNode)
 
 	res, err := ExtractSchema(idxNode.Content[0], idx)
-	assert.NotNil(t, res.Value.Schema().AdditionalProperties.Value.(*SchemaProxy).Schema())
+	assert.NotNil(t, res.Value.Schema().AdditionalProperties.Value.A.Schema())
+	assert.True(t, res.Value.Schema().AdditionalProperties.Value.A.IsSchemaReference())
 	assert.Nil(t, err)
 }
 
@@ -1646,3 +1711,160 @@ func TestSchema_UnevaluatedPropertiesAsBool_Undefined(t *testing.T) {
 	assert.Nil(t, res.Value.Schema().UnevaluatedProperties.Value)
 
 }
+
+func TestSchema_Build_ConstDependentRequiredContentSchema(t *testing.T) {
+	yml := `const: pizza
+contentEncoding: base64
+contentMediaType: application/json
+contentSchema:
+  type: object
+dependentRequired:
+  cheese: [tomato, dough]
+  pepperoni: [cheese]`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var sch Schema
+	mbErr := low.BuildModel(idxNode.Content[0], &sch)
+	assert.NoError(t, mbErr)
+	assert.NoError(t, sch.Build(idxNode.Content[0], idx))
+
+	assert.Equal(t, "pizza", sch.Const.Value)
+	assert.Equal(t, "base64", sch.ContentEncoding.Value)
+	assert.Equal(t, "application/json", sch.ContentMediaType.Value)
+	assert.Equal(t, "object", sch.ContentSchema.Value.Schema().Type.Value.A)
+	assert.Len(t, sch.DependentRequired.Value, 2)
+	assert.Equal(t, []string{"tomato", "dough"}, sch.FindDependentRequired("cheese").Value)
+	assert.Nil(t, sch.FindDependentRequired("pineapple"))
+
+	// changing a dependency must change the hash.
+	yml2 := strings.Replace(yml, "[cheese]", "[cheese, tomato]", 1)
+	var idxNode2 yaml.Node
+	_ = yaml.Unmarshal([]byte(yml2), &idxNode2)
+
+	var sch2 Schema
+	_ = low.BuildModel(idxNode2.Content[0], &sch2)
+	_ = sch2.Build(idxNode2.Content[0], index.NewSpecIndex(&idxNode2))
+	assert.NotEqual(t, sch.Hash(), sch2.Hash())
+}
+
+func TestSchema_Build_Dialect(t *testing.T) {
+	yml := `openapi: 3.1.0
+jsonSchemaDialect: https://json-schema.org/draft/2019-09/schema
+components:
+  schemas:
+    Inherits:
+      type: object
+      properties:
+        name:
+          type: string
+    Legacy:
+      $schema: http://json-schema.org/draft-07/schema#
+      properties:
+        tuple:
+          items:
+            - type: string
+            - type: integer
+        ref:
+          $ref: '#/components/schemas/Inherits'`
+
+	var iNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &iNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&iNode, index.CreateOpenAPIIndexConfig())
+
+	yml = `$ref: '#/components/schemas/Inherits'`
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	res, _ := ExtractSchema(idxNode.Content[0], idx)
+	sch := res.Value.Schema()
+	assert.Equal(t, index.DialectJSONSchema201909, sch.Dialect.Value)
+	assert.Nil(t, sch.Dialect.ValueNode)
+	assert.Equal(t, index.Draft201909, sch.GetDraft())
+	assert.Equal(t, index.DialectJSONSchema201909, sch.FindProperty("name").Value.Schema().Dialect.Value)
+
+	yml = `$ref: '#/components/schemas/Legacy'`
+	var legacyNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &legacyNode)
+	res, _ = ExtractSchema(legacyNode.Content[0], idx)
+	sch = res.Value.Schema()
+	assert.Equal(t, index.DialectJSONSchemaDraft07, sch.Dialect.Value)
+	assert.Equal(t, index.DialectJSONSchemaDraft07, sch.SchemaTypeRef.ValueNode.Value)
+	assert.Equal(t, index.Draft07, sch.GetDraft())
+
+	// draft-07 uses an items array for tuples, which is modeled as prefixItems.
+	tuple := sch.FindProperty("tuple").Value.Schema()
+	assert.Equal(t, index.DialectJSONSchemaDraft07, tuple.Dialect.Value)
+	assert.Nil(t, tuple.Items.Value)
+	assert.Len(t, tuple.PrefixItems.Value, 2)
+	assert.Equal(t, "integer", tuple.PrefixItems.Value[1].Value.Schema().Type.Value.A)
+	assert.Equal(t, index.DialectJSONSchemaDraft07, tuple.PrefixItems.Value[0].Value.Schema().Dialect.Value)
+
+	// references take the dialect of the document, not the referrer.
+	ref := sch.FindProperty("ref").Value.Schema()
+	assert.Equal(t, index.DialectJSONSchema201909, ref.Dialect.Value)
+}
+
+func TestSchema_Hash_MapKeys(t *testing.T) {
+	hash := func(yml string) [32]byte {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		sch, _ := ExtractSchema(node.Content[0], nil)
+		return sch.Value.Schema().Hash()
+	}
+
+	// the same schema under a different key is a different schema.
+	assert.NotEqual(t,
+		hash("schema:\n  properties:\n    a:\n      type: string"),
+		hash("schema:\n  properties:\n    b:\n      type: string"))
+	assert.NotEqual(t,
+		hash("schema:\n  dependentSchemas:\n    a:\n      type: string"),
+		hash("schema:\n  dependentSchemas:\n    b:\n      type: string"))
+	assert.NotEqual(t,
+		hash("schema:\n  patternProperties:\n    '^a':\n      type: string"),
+		hash("schema:\n  patternProperties:\n    '^b':\n      type: string"))
+
+	// legacy hashes only hash the values.
+	low.UseLegacyHashes(true)
+	defer low.UseLegacyHashes(false)
+	assert.Equal(t,
+		hash("schema:\n  properties:\n    a:\n      type: string"),
+		hash("schema:\n  properties:\n    b:\n      type: string"))
+}
+
+func TestSchema_Hash_ConstNull(t *testing.T) {
+	hash := func(yml string) [32]byte {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		sch, _ := ExtractSchema(node.Content[0], nil)
+		return sch.Value.Schema().Hash()
+	}
+	assert.NotPanics(t, func() { hash("schema:\n  const: null") })
+	assert.Equal(t, hash("schema:\n  const: null"), hash("schema:\n  const: ~"))
+	assert.NotEqual(t, hash("schema:\n  const: null"), hash("schema:\n  const: 'null'"))
+	assert.NotEqual(t, hash("schema:\n  const: null"), hash("schema:\n  type: string"))
+}
+
+func TestSchema_Hash_LegacyAdditionalProperties(t *testing.T) {
+	low.UseLegacyHashes(true)
+	defer low.UseLegacyHashes(false)
+
+	hash := func(yml string) string {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		var sch Schema
+		_ = low.BuildModel(node.Content[0], &sch)
+		_ = sch.Build(node.Content[0], nil)
+		return fmt.Sprintf("%x", sch.Hash())
+	}
+
+	// hashes generated by older versions of libopenapi.
+	assert.Equal(t, "e91536bb152de7bb725b1403cab8efb0af7cc3da3909edac0fac14ea5b1941b6",
+		hash("additionalProperties:\n  why: yes\n  thatIs: true"))
+	assert.Equal(t, "f722705d7d724f08847909f93e4ebb2dba19873e7f9c4d49fce965b478b6e30b",
+		hash("additionalProperties:\n  - nice: cake\n  - yummy: beer\n  - one"))
+	assert.Equal(t, "c563b6087611cb176859a4810fc0f183c9dbd6a4ed0d42af72a06d3d9661ebad",
+		hash("additionalProperties:\n  description: no type"))
+}
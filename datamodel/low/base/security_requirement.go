@@ -4,7 +4,6 @@
 package base
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
@@ -25,13 +24,15 @@ import (
 type SecurityRequirement struct {
 	Requirements low.ValueReference[map[low.KeyReference[string]]low.ValueReference[[]low.ValueReference[string]]]
 	*low.Reference
+	low.Nodes
 }
 
 // Build will extract security requirements from the node (the structure is odd, to be honest)
-func (s *SecurityRequirement) Build(root *yaml.Node, _ *index.SpecIndex) error {
+func (s *SecurityRequirement) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	s.Reference = new(low.Reference)
+	s.SetNodes(root, idx)
 	var labelNode *yaml.Node
 	valueMap := make(map[low.KeyReference[string]]low.ValueReference[[]low.ValueReference[string]])
 	var arr []low.ValueReference[string]
@@ -101,7 +102,7 @@ func (s *SecurityRequirement) Hash() [32]byte {
 	}
 	sort.Strings(valKeys)
 	for val := range valKeys {
-		f = append(f, fmt.Sprintf("%s-%s", valKeys[val], strings.Join(values[valKeys[val]], "|")))
+		f = append(f, low.HashField("requirements", fmt.Sprintf("%s-%s", valKeys[val], strings.Join(values[valKeys[val]], "|"))))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
@@ -4,14 +4,10 @@
 package base
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
-	"strings"
 )
 
 // Tag represents a low-level Tag instance that is backed by a low-level one.
@@ -26,6 +22,7 @@ type Tag struct {
 	ExternalDocs low.NodeReference[*ExternalDoc]
 	Extensions   map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindExtension returns a ValueReference containing the extension value, if found.
@@ -38,6 +35,7 @@ func (t *Tag) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	t.Reference = new(low.Reference)
+	t.SetNodes(root, idx)
 	t.Extensions = low.ExtractExtensions(root)
 
 	// extract externalDocs
@@ -55,23 +53,16 @@ func (t *Tag) GetExtensions() map[low.KeyReference[string]]low.ValueReference[an
 func (t *Tag) Hash() [32]byte {
 	var f []string
 	if !t.Name.IsEmpty() {
-		f = append(f, t.Name.Value)
+		f = append(f, low.HashField("name", t.Name.Value))
 	}
 	if !t.Description.IsEmpty() {
-		f = append(f, t.Description.Value)
+		f = append(f, low.HashField("description", t.Description.Value))
 	}
 	if !t.ExternalDocs.IsEmpty() {
-		f = append(f, low.GenerateHashString(t.ExternalDocs.Value))
+		f = append(f, low.HashField("externalDocs", low.GenerateHashString(t.ExternalDocs.Value)))
 	}
-	keys := make([]string, len(t.Extensions))
-	z := 0
-	for k := range t.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(t.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(t.Extensions)...)
+	return low.HashStrings(f)
 }
 
 // TODO: future mutation API experiment code is here. this snippet is to re-marshal the object.
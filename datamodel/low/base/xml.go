@@ -1,14 +1,11 @@
 package base
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
-	"strings"
 )
 
 // XML represents a low-level representation of an XML object defined by all versions of OpenAPI.
@@ -28,13 +25,15 @@ type XML struct {
 	Wrapped    low.NodeReference[bool]
 	Extensions map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // Build will extract extensions from the XML instance.
-func (x *XML) Build(root *yaml.Node, _ *index.SpecIndex) error {
+func (x *XML) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	x.Reference = new(low.Reference)
+	x.SetNodes(root, idx)
 	x.Extensions = low.ExtractExtensions(root)
 	return nil
 }
@@ -48,27 +47,20 @@ func (x *XML) GetExtensions() map[low.KeyReference[string]]low.ValueReference[an
 func (x *XML) Hash() [32]byte {
 	var f []string
 	if !x.Name.IsEmpty() {
-		f = append(f, x.Name.Value)
+		f = append(f, low.HashField("name", x.Name.Value))
 	}
 	if !x.Namespace.IsEmpty() {
-		f = append(f, x.Namespace.Value)
+		f = append(f, low.HashField("namespace", x.Namespace.Value))
 	}
 	if !x.Prefix.IsEmpty() {
-		f = append(f, x.Prefix.Value)
+		f = append(f, low.HashField("prefix", x.Prefix.Value))
 	}
 	if !x.Attribute.IsEmpty() {
-		f = append(f, fmt.Sprint(x.Attribute.Value))
+		f = append(f, low.HashField("attribute", fmt.Sprint(x.Attribute.Value)))
 	}
 	if !x.Wrapped.IsEmpty() {
-		f = append(f, fmt.Sprint(x.Wrapped.Value))
+		f = append(f, low.HashField("wrapped", fmt.Sprint(x.Wrapped.Value)))
 	}
-	keys := make([]string, len(x.Extensions))
-	z := 0
-	for k := range x.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(x.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(x.Extensions)...)
+	return low.HashStrings(f)
 }
@@ -0,0 +1,76 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrorCode describes what kind of failure a BuildError is.
+type ErrorCode string
+
+const (
+	// ErrorReferenceNotFound is used when a reference cannot be found.
+	ErrorReferenceNotFound ErrorCode = "reference-not-found"
+
+	// ErrorCircularReference is used when a circular reference cannot be resolved.
+	ErrorCircularReference ErrorCode = "circular-reference"
+
+	// ErrorInvalidNode is used when a node is not the kind of node that was expected, for example an array where
+	// a map should be.
+	ErrorInvalidNode ErrorCode = "invalid-node"
+
+	// ErrorInvalidValue is used when a value is not allowed by the specification.
+	ErrorInvalidValue ErrorCode = "invalid-value"
+
+	// ErrorBuildFailed is used when building an object failed because something it contains failed to build, the
+	// wrapped error is the reason.
+	ErrorBuildFailed ErrorCode = "build-failed"
+)
+
+// BuildError is returned when a model cannot be built from a specification. It carries where the failure is (the
+// JSON pointer, the document and the position), and what kind of failure it is, so failures can be handled without
+// parsing the error message. Use errors.As to get at it, as it's often wrapped by other build errors.
+type BuildError struct {
+	Pointer string     // JSON pointer to the node in the document it was found in, if known.
+	File    string     // the file or URL of the document, empty for the root document (or when not known).
+	Line    int        // the line of the node.
+	Col     int        // the column of the node.
+	Code    ErrorCode  // what kind of failure this is.
+	Node    *yaml.Node // the node that could not be built.
+	Message string     // describes the failure.
+	Wrapped error      // the error that caused this one, if there is one.
+}
+
+// NewBuildError creates a BuildError for a node. The message is created using the format and arguments, the pointer
+// and the file of the node are looked up using the index (when there is one).
+func NewBuildError(code ErrorCode, node *yaml.Node, idx *index.SpecIndex, wrapped error,
+	format string, args ...any) *BuildError {
+	e := &BuildError{Code: code, Node: node, Message: fmt.Sprintf(format, args...), Wrapped: wrapped}
+	if node != nil {
+		e.Line, e.Col = node.Line, node.Column
+	}
+	if idx != nil && node != nil {
+		e.Pointer = idx.FindNodePointer(node)
+		if origin := idx.FindNodeOrigin(node); origin != nil && !origin.RootDocument {
+			e.File = origin.AbsoluteLocation
+		}
+	}
+	return e
+}
+
+func (e *BuildError) Error() string {
+	if e.Wrapped == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Wrapped.Error()
+}
+
+// Unwrap returns the error that caused this one.
+func (e *BuildError) Unwrap() error {
+	return e.Wrapped
+}
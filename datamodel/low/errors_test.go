@@ -0,0 +1,51 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildError(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`things:
+  name: not an array
+thing:
+  dontWork: 123`), &root)
+	idx := index.NewSpecIndexWithConfig(&root, index.CreateClosedAPIIndexConfig())
+
+	_, _, _, err := ExtractArray[*pizza]("things", root.Content[0], idx)
+	var buildErr *BuildError
+	assert.True(t, errors.As(err, &buildErr))
+	assert.Equal(t, ErrorInvalidNode, buildErr.Code)
+	assert.Equal(t, "#/things", buildErr.Pointer)
+	assert.Empty(t, buildErr.File)
+	assert.Equal(t, 2, buildErr.Line)
+	assert.Equal(t, 3, buildErr.Col)
+	assert.Nil(t, buildErr.Unwrap())
+	assert.Equal(t, "array build failed, input is not an array, line 2, column 3", err.Error())
+
+	// the error that caused the failure is wrapped, and build errors can be found through other errors.
+	_, thing := utils.FindKeyNodeTop("thing", root.Content[0].Content)
+	cause := errors.New("I am always going to fail a core build")
+	err = fmt.Errorf("not built: %w", NewBuildError(ErrorBuildFailed, thing, idx, cause, "object extraction failed"))
+	assert.True(t, errors.As(err, &buildErr))
+	assert.Equal(t, ErrorBuildFailed, buildErr.Code)
+	assert.Equal(t, "#/thing", buildErr.Pointer)
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, "object extraction failed: I am always going to fail a core build", buildErr.Error())
+
+	// without a node or an index, there is nothing to locate.
+	buildErr = NewBuildError(ErrorInvalidValue, nil, nil, nil, "%d is not allowed", 3)
+	assert.Equal(t, "3 is not allowed", buildErr.Error())
+	assert.Empty(t, buildErr.Pointer)
+	assert.Zero(t, buildErr.Line)
+}
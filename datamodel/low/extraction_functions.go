@@ -8,11 +8,11 @@ import (
 	"fmt"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
-	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
 	"gopkg.in/yaml.v3"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // FindItemInMap accepts a string key and a collection of KeyReference[string] and ValueReference[T]. Every
@@ -43,14 +43,42 @@ func generateIndexCollection(idx *index.SpecIndex) []func() map[string]*index.Re
 		idx.GetAllRequestBodies,
 		idx.GetAllResponses,
 		idx.GetAllSecuritySchemes,
+		idx.GetAllComponentPathItems,
+		idx.GetAllWebhooks,
 	}
 }
 
 // LocateRefNode will perform a complete lookup for a $ref node. This function searches the entire index for
 // the reference being supplied. If there is a match found, the reference *yaml.Node is returned.
 func LocateRefNode(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, error) {
+	return locateRefNode(root, idx, nil)
+}
+
+// LocateRefNodeChain performs the same lookup as LocateRefNode, but also returns every hop that was made while
+// resolving the reference. A reference that points to another reference (and so on) will return a hop for each
+// $ref that was followed, in the order they were followed.
+func LocateRefNodeChain(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, []*ReferenceHop, error) {
+	var chain []*ReferenceHop
+	located, err := locateRefNode(root, idx, &chain)
+	return located, chain, err
+}
+
+func locateRefNode(root *yaml.Node, idx *index.SpecIndex, chain *[]*ReferenceHop) (*yaml.Node, error) {
 	if rf, _, rv := utils.IsNodeRefValue(root); rf {
 
+		// record the hop, if the chain is being tracked.
+		hop := func(target *yaml.Node, circular bool) {
+			if chain != nil {
+				*chain = append(*chain, &ReferenceHop{
+					Reference: rv,
+					Node:      root,
+					Target:    target,
+					Circular:  circular,
+					idx:       idx,
+				})
+			}
+		}
+
 		// run through everything and return as soon as we find a match.
 		// this operates as fast as possible as ever
 		collections := generateIndexCollection(idx)
@@ -66,52 +94,54 @@ func LocateRefNode(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, error) {
 			}
 		}
 
+		// anchors referenced from inside a schema with an '$id' are indexed by their absolute URI.
+		key := idx.GetReferenceDefinition(root, rv)
+
 		var found map[string]*index.Reference
 		for _, collection := range collections {
 			found = collection()
-			if found != nil && found[rv] != nil {
+			if found != nil && found[key] != nil {
 
 				// if this is a ref node, we need to keep diving
 				// until we hit something that isn't a ref.
-				if jh, _, _ := utils.IsNodeRefValue(found[rv].Node); jh {
+				if jh, _, _ := utils.IsNodeRefValue(found[key].Node); jh {
 					// if this node is circular, stop drop and roll.
-					if !IsCircular(found[rv].Node, idx) {
-						return LocateRefNode(found[rv].Node, idx)
+					if !IsCircular(found[key].Node, idx) {
+						hop(found[key].Node, false)
+						return locateRefNode(found[key].Node, idx, chain)
 					} else {
-						return found[rv].Node, fmt.Errorf("circular reference '%s' found during lookup at line "+
-							"%d, column %d, It cannot be resolved",
-							GetCircularReferenceResult(found[rv].Node, idx).GenerateJourneyPath(),
-							found[rv].Node.Line,
-							found[rv].Node.Column)
+						hop(found[key].Node, true)
+						return found[key].Node, NewBuildError(ErrorCircularReference, found[key].Node, idx, nil,
+							"circular reference '%s' found during lookup at line %d, column %d, It cannot be resolved",
+							GetCircularReferenceResult(found[key].Node, idx).GenerateJourneyPath(),
+							found[key].Node.Line,
+							found[key].Node.Column)
 					}
 				}
-				return utils.NodeAlias(found[rv].Node), nil
+				hop(found[key].Node, false)
+				return utils.NodeAlias(found[key].Node), nil
 			}
 		}
 
 		// perform a search for the reference in the index
-		foundRefs := idx.SearchIndexForReference(rv)
+		foundRefs := idx.SearchIndexForReference(key)
 		if len(foundRefs) > 0 {
+			hop(foundRefs[0].Node, false)
 			return utils.NodeAlias(foundRefs[0].Node), nil
 		}
 
 		// let's try something else to find our references.
 
-		// cant be found? last resort is to try a path lookup
-		_, friendly := utils.ConvertComponentIdIntoFriendlyPathSearch(rv)
-		if friendly != "" {
-			path, err := yamlpath.NewPath(friendly)
-			if err == nil {
-				nodes, fErr := path.Find(idx.GetRootNode())
-				if fErr == nil {
-					if len(nodes) > 0 {
-						return utils.NodeAlias(nodes[0]), nil
-					}
-				}
+		// cant be found? last resort is to look up the component in the root of the document, which
+		// checks the components mapped when indexing, before searching the document by path.
+		if _, friendly := utils.ConvertComponentIdIntoFriendlyPathSearch(rv); friendly != "" {
+			if found := idx.FindComponentInRoot(rv); found != nil {
+				hop(found.Node, false)
+				return utils.NodeAlias(found.Node), nil
 			}
 		}
-		return nil, fmt.Errorf("reference '%s' at line %d, column %d was not found",
-			rv, root.Line, root.Column)
+		return nil, NewBuildError(ErrorReferenceNotFound, root, idx, nil,
+			"reference '%s' at line %d, column %d was not found", rv, root.Line, root.Column)
 	}
 	return nil, nil
 }
@@ -122,10 +152,12 @@ func ExtractObjectRaw[T Buildable[N], N any](root *yaml.Node, idx *index.SpecInd
 	var circError error
 	var isReference bool
 	var referenceValue string
+	var refNode *yaml.Node
 	root = utils.NodeAlias(root)
 	if h, _, rv := utils.IsNodeRefValue(root); h {
 		ref, err := LocateRefNode(root, idx)
 		if ref != nil {
+			refNode = root
 			root = ref
 			isReference = true
 			referenceValue = rv
@@ -134,23 +166,30 @@ func ExtractObjectRaw[T Buildable[N], N any](root *yaml.Node, idx *index.SpecInd
 			}
 		} else {
 			if err != nil {
-				return nil, fmt.Errorf("object extraction failed: %s", err.Error()), isReference, referenceValue
+				err = NewBuildError(ErrorBuildFailed, root, idx, err, "object extraction failed")
+				if !TolerateBuildError(idx, err) {
+					return nil, err, isReference, referenceValue
+				}
+				root = UnresolvedNode(root)
 			}
 		}
 	}
 	var n T = new(N)
 	err := BuildModel(root, n)
-	if err != nil {
+	if err != nil && !TolerateBuildError(idx, err) {
 		return n, err, isReference, referenceValue
 	}
 	err = n.Build(root, idx)
-	if err != nil {
+	if err != nil && !TolerateBuildError(idx, err) {
 		return n, err, isReference, referenceValue
 	}
+	SetOrigin(n, root, idx)
 
 	// if this is a reference, keep track of the reference in the value
 	if isReference {
 		SetReference(n, referenceValue)
+		SetReferenceSiblings(n, refNode)
+		SetReferenceNode(n, refNode, idx)
 	}
 
 	// do we want to throw an error as well if circular error reporting is on?
@@ -167,10 +206,13 @@ func ExtractObject[T Buildable[N], N any](label string, root *yaml.Node, idx *in
 	var circError error
 	var isReference bool
 	var referenceValue string
+	var refNode *yaml.Node
+	var buildErr error
 	root = utils.NodeAlias(root)
 	if rf, rl, refVal := utils.IsNodeRefValue(root); rf {
 		ref, err := LocateRefNode(root, idx)
 		if ref != nil {
+			refNode = root
 			vn = ref
 			ln = rl
 			isReference = true
@@ -180,7 +222,10 @@ func ExtractObject[T Buildable[N], N any](label string, root *yaml.Node, idx *in
 			}
 		} else {
 			if err != nil {
-				return NodeReference[T]{}, fmt.Errorf("object extraction failed: %s", err.Error())
+				buildErr = NewBuildError(ErrorBuildFailed, root, idx, err, "object extraction failed")
+				if !TolerateBuildError(idx, buildErr) {
+					return NodeReference[T]{}, buildErr
+				}
 			}
 		}
 	} else {
@@ -189,6 +234,7 @@ func ExtractObject[T Buildable[N], N any](label string, root *yaml.Node, idx *in
 			if h, _, rVal := utils.IsNodeRefValue(vn); h {
 				ref, lerr := LocateRefNode(vn, idx)
 				if ref != nil {
+					refNode = vn
 					vn = ref
 					isReference = true
 					referenceValue = rVal
@@ -197,7 +243,11 @@ func ExtractObject[T Buildable[N], N any](label string, root *yaml.Node, idx *in
 					}
 				} else {
 					if lerr != nil {
-						return NodeReference[T]{}, fmt.Errorf("object extraction failed: %s", lerr.Error())
+						buildErr = NewBuildError(ErrorBuildFailed, vn, idx, lerr, "object extraction failed")
+						if !TolerateBuildError(idx, buildErr) {
+							return NodeReference[T]{}, buildErr
+						}
+						vn = UnresolvedNode(vn)
 					}
 				}
 			}
@@ -206,19 +256,29 @@ func ExtractObject[T Buildable[N], N any](label string, root *yaml.Node, idx *in
 	var n T = new(N)
 	err := BuildModel(vn, n)
 	if err != nil {
-		return NodeReference[T]{}, err
+		if !TolerateBuildError(idx, err) {
+			return NodeReference[T]{}, err
+		}
+		buildErr = err
 	}
 	if ln == nil {
 		return NodeReference[T]{}, nil
 	}
 	err = n.Build(vn, idx)
-	if err != nil {
-		return NodeReference[T]{}, err
+	if err != nil && buildErr == nil {
+		if !TolerateBuildError(idx, err) {
+			return NodeReference[T]{}, err
+		}
+		buildErr = err
 	}
+	SetOrigin(n, vn, idx)
+	SetKeyNode(n, ln)
 
 	// if this is a reference, keep track of the reference in the value
 	if isReference {
 		SetReference(n, referenceValue)
+		SetReferenceSiblings(n, refNode)
+		SetReferenceNode(n, refNode, idx)
 	}
 
 	res := NodeReference[T]{
@@ -227,6 +287,7 @@ func ExtractObject[T Buildable[N], N any](label string, root *yaml.Node, idx *in
 		ValueNode:     vn,
 		ReferenceNode: isReference,
 		Reference:     referenceValue,
+		Error:         buildErr,
 	}
 
 	// do we want to throw an error as well if circular error reporting is on?
@@ -245,6 +306,51 @@ func SetReference(obj any, ref string) {
 	}
 }
 
+// SetOrigin will record the node an object was built from, and the index used to build it, on the object. This
+// allows the origin (file and position) of the object to be looked up (see Reference.GetOrigin).
+func SetOrigin(obj any, node *yaml.Node, idx *index.SpecIndex) {
+	if obj == nil || node == nil {
+		return
+	}
+	if r, ok := obj.(HasOrigin); ok {
+		r.SetOrigin(node, idx)
+	}
+}
+
+// SetReferenceNode will record the $ref node an object was located through, and the index used to locate it,
+// on the object. This allows the chain of references followed to be looked up (see Reference.GetResolutionChain).
+func SetReferenceNode(obj any, refNode *yaml.Node, idx *index.SpecIndex) {
+	if obj == nil || refNode == nil {
+		return
+	}
+	if r, ok := obj.(HasResolutionChain); ok {
+		r.SetReferenceNode(refNode, idx)
+	}
+}
+
+// SetReferenceSiblings will extract any 'summary' or 'description' siblings from a $ref node and set them on
+// the object that was built from the reference (OpenAPI 3.1+).
+func SetReferenceSiblings(obj any, refNode *yaml.Node) {
+	if obj == nil || refNode == nil {
+		return
+	}
+	r, ok := obj.(HasReferenceSiblings)
+	if !ok {
+		return
+	}
+	var summary, description NodeReference[string]
+	if _, ln, vn := utils.FindKeyNodeFullTop("summary", refNode.Content); vn != nil {
+		summary = NodeReference[string]{Value: vn.Value, KeyNode: ln, ValueNode: vn}
+	}
+	if _, ln, vn := utils.FindKeyNodeFullTop("description", refNode.Content); vn != nil {
+		description = NodeReference[string]{Value: vn.Value, KeyNode: ln, ValueNode: vn}
+	}
+	if summary.IsEmpty() && description.IsEmpty() {
+		return
+	}
+	r.SetReferenceSiblings(summary, description)
+}
+
 // ExtractArray will extract a slice of []ValueReference[T] from a root yaml.Node that is defined as a sequence.
 // Used when the value being extracted is an array.
 func ExtractArray[T Buildable[N], N any](label string, root *yaml.Node, idx *index.SpecIndex) ([]ValueReference[T],
@@ -262,8 +368,12 @@ func ExtractArray[T Buildable[N], N any](label string, root *yaml.Node, idx *ind
 				circError = err
 			}
 		} else {
-			return []ValueReference[T]{}, nil, nil, fmt.Errorf("array build failed: reference cannot be found: %s",
-				root.Content[1].Value)
+			err = NewBuildError(ErrorReferenceNotFound, root.Content[1], idx, nil,
+				"array build failed: reference cannot be found: %s", root.Content[1].Value)
+			if TolerateBuildError(idx, err) {
+				return nil, nil, nil, nil
+			}
+			return []ValueReference[T]{}, nil, nil, err
 		}
 	} else {
 		_, ln, vn = utils.FindKeyNodeFullTop(label, root.Content)
@@ -278,8 +388,12 @@ func ExtractArray[T Buildable[N], N any](label string, root *yaml.Node, idx *ind
 					}
 				} else {
 					if err != nil {
-						return []ValueReference[T]{}, nil, nil, fmt.Errorf("array build failed: reference cannot be found: %s",
-							err.Error())
+						err = NewBuildError(ErrorReferenceNotFound, vn, idx, err,
+							"array build failed: reference cannot be found")
+						if TolerateBuildError(idx, err) {
+							return nil, ln, vn, nil
+						}
+						return []ValueReference[T]{}, nil, nil, err
 					}
 				}
 			}
@@ -289,15 +403,23 @@ func ExtractArray[T Buildable[N], N any](label string, root *yaml.Node, idx *ind
 	var items []ValueReference[T]
 	if vn != nil && ln != nil {
 		if !utils.IsNodeArray(vn) {
-			return []ValueReference[T]{}, nil, nil, fmt.Errorf("array build failed, input is not an array, line %d, column %d", vn.Line, vn.Column)
+			err := NewBuildError(ErrorInvalidNode, vn, idx, nil,
+				"array build failed, input is not an array, line %d, column %d", vn.Line, vn.Column)
+			if TolerateBuildError(idx, err) {
+				return nil, ln, vn, nil
+			}
+			return []ValueReference[T]{}, nil, nil, err
 		}
 		for _, node := range vn.Content {
 			localReferenceValue := ""
 			//localIsReference := false
+			var refNode *yaml.Node
+			var buildErr error
 
 			if rf, _, rv := utils.IsNodeRefValue(node); rf {
 				refg, err := LocateRefNode(node, idx)
 				if refg != nil {
+					refNode = node
 					node = refg
 					//localIsReference = true
 					localReferenceValue = rv
@@ -306,23 +428,36 @@ func ExtractArray[T Buildable[N], N any](label string, root *yaml.Node, idx *ind
 					}
 				} else {
 					if err != nil {
-						return []ValueReference[T]{}, nil, nil, fmt.Errorf("array build failed: reference cannot be found: %s",
-							err.Error())
+						buildErr = NewBuildError(ErrorReferenceNotFound, node, idx, err,
+							"array build failed: reference cannot be found")
+						if !TolerateBuildError(idx, buildErr) {
+							return []ValueReference[T]{}, nil, nil, buildErr
+						}
+						node = UnresolvedNode(node)
 					}
 				}
 			}
 			var n T = new(N)
 			err := BuildModel(node, n)
 			if err != nil {
-				return []ValueReference[T]{}, ln, vn, err
+				if !TolerateBuildError(idx, err) {
+					return []ValueReference[T]{}, ln, vn, err
+				}
+				buildErr = err
 			}
 			berr := n.Build(node, idx)
-			if berr != nil {
-				return nil, ln, vn, berr
+			if berr != nil && buildErr == nil {
+				if !TolerateBuildError(idx, berr) {
+					return nil, ln, vn, berr
+				}
+				buildErr = berr
 			}
+			SetOrigin(n, node, idx)
 
 			if localReferenceValue != "" {
 				SetReference(n, localReferenceValue)
+				SetReferenceSiblings(n, refNode)
+				SetReferenceNode(n, refNode, idx)
 			}
 
 			items = append(items, ValueReference[T]{
@@ -330,6 +465,7 @@ func ExtractArray[T Buildable[N], N any](label string, root *yaml.Node, idx *ind
 				ValueNode:     node,
 				ReferenceNode: localReferenceValue != "",
 				Reference:     localReferenceValue,
+				Error:         buildErr,
 			})
 		}
 	}
@@ -367,7 +503,7 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 	idx *index.SpecIndex,
 	includeExtensions bool,
 ) (map[KeyReference[string]]ValueReference[PT], error) {
-	valueMap := make(map[KeyReference[string]]ValueReference[PT])
+	valueMap := make(map[KeyReference[string]]ValueReference[PT], len(root.Content)/2)
 	var circError error
 	if utils.IsNodeMap(root) {
 		var currentKey *yaml.Node
@@ -377,7 +513,7 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 		for i := 0; i < rlen; i++ {
 			node := root.Content[i]
 			if !includeExtensions {
-				if strings.HasPrefix(strings.ToLower(node.Value), "x-") {
+				if isExtensionKey(node.Value) {
 					skip = true
 					continue
 				}
@@ -401,10 +537,13 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 
 			var isReference bool
 			var referenceValue string
+			var refNode *yaml.Node
+			var buildErr error
 			// if value is a reference, we have to look it up in the index!
 			if h, _, rv := utils.IsNodeRefValue(node); h {
 				ref, err := LocateRefNode(node, idx)
 				if ref != nil {
+					refNode = node
 					node = ref
 					isReference = true
 					referenceValue = rv
@@ -413,7 +552,12 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 					}
 				} else {
 					if err != nil {
-						return nil, fmt.Errorf("map build failed: reference cannot be found: %s", err.Error())
+						buildErr = NewBuildError(ErrorReferenceNotFound, node, idx, err,
+							"map build failed: reference cannot be found")
+						if !TolerateBuildError(idx, buildErr) {
+							return nil, buildErr
+						}
+						node = UnresolvedNode(node)
 					}
 				}
 			}
@@ -421,14 +565,24 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 			var n PT = new(N)
 			err := BuildModel(node, n)
 			if err != nil {
-				return nil, err
+				if !TolerateBuildError(idx, err) {
+					return nil, err
+				}
+				buildErr = err
 			}
 			berr := n.Build(node, idx)
-			if berr != nil {
-				return nil, berr
+			if berr != nil && buildErr == nil {
+				if !TolerateBuildError(idx, berr) {
+					return nil, berr
+				}
+				buildErr = berr
 			}
+			SetOrigin(n, node, idx)
+			SetKeyNode(n, currentKey)
 			if isReference {
 				SetReference(n, referenceValue)
+				SetReferenceSiblings(n, refNode)
+				SetReferenceNode(n, refNode, idx)
 			}
 			if currentKey != nil {
 				valueMap[KeyReference[string]{
@@ -439,6 +593,7 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 					ValueNode: node,
 					//IsReference: isReference,
 					Reference: referenceValue,
+					Error:     buildErr,
 				}
 			}
 		}
@@ -462,6 +617,23 @@ func ExtractMapNoLookup[PT Buildable[N], N any](
 	return ExtractMapNoLookupExtensions[PT, N](root, idx, false)
 }
 
+// TolerateBuildError returns true if the build should carry on after failing to build part of a model, in which
+// case the error has been added to the index (see index.SpecIndex.TolerateBuildErrors).
+func TolerateBuildError(idx *index.SpecIndex, err error) bool {
+	if !idx.TolerateBuildErrors() {
+		return false
+	}
+	idx.AddBuildError(err)
+	return true
+}
+
+// UnresolvedNode returns an empty map node to build in place of a reference that cannot be found, when build errors
+// are tolerated. Building the empty node creates an empty model (rather than no model at all), the node has the
+// position of the reference.
+func UnresolvedNode(ref *yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: ref.Line, Column: ref.Column}
+}
+
 type mappingResult[T any] struct {
 	k KeyReference[string]
 	v ValueReference[T]
@@ -496,8 +668,12 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 				circError = err
 			}
 		} else {
-			return nil, labelNode, valueNode, fmt.Errorf("map build failed: reference cannot be found: %s",
-				root.Content[1].Value)
+			err = NewBuildError(ErrorReferenceNotFound, root.Content[1], idx, nil,
+				"map build failed: reference cannot be found: %s", root.Content[1].Value)
+			if TolerateBuildError(idx, err) {
+				return nil, labelNode, valueNode, nil
+			}
+			return nil, labelNode, valueNode, err
 		}
 	} else {
 		_, labelNode, valueNode = utils.FindKeyNodeFull(label, root.Content)
@@ -513,8 +689,12 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 					}
 				} else {
 					if err != nil {
-						return nil, labelNode, valueNode, fmt.Errorf("map build failed: reference cannot be found: %s",
-							err.Error())
+						err = NewBuildError(ErrorReferenceNotFound, valueNode, idx, err,
+							"map build failed: reference cannot be found")
+						if TolerateBuildError(idx, err) {
+							return nil, labelNode, valueNode, nil
+						}
+						return nil, labelNode, valueNode, err
 					}
 				}
 			}
@@ -522,28 +702,37 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 	}
 	if valueNode != nil {
 		var currentLabelNode *yaml.Node
-		valueMap := make(map[KeyReference[string]]ValueReference[PT])
 
-		bChan := make(chan mappingResult[PT])
-		eChan := make(chan error)
+		// every entry is built in its own goroutine, straight into its own slot.
+		results := make([]mappingResult[PT], len(valueNode.Content)/2)
+		errs := make([]error, len(results))
+		var wg sync.WaitGroup
 
-		buildMap := func(label *yaml.Node, value *yaml.Node, c chan mappingResult[PT], ec chan<- error, ref string) {
+		buildMap := func(slot int, label *yaml.Node, value *yaml.Node, ref string, refNode *yaml.Node, buildErr error) {
+			defer wg.Done()
 			var n PT = new(N)
 			value = utils.NodeAlias(value)
 			_ = BuildModel(value, n)
 			err := n.Build(value, idx)
-			if err != nil {
-				ec <- err
-				return
+			if err != nil && buildErr == nil {
+				if !TolerateBuildError(idx, err) {
+					errs[slot] = err
+					return
+				}
+				buildErr = err
 			}
+			SetOrigin(n, value, idx)
+			SetKeyNode(n, label)
 
 			//isRef := false
 			if ref != "" {
 				//isRef = true
 				SetReference(n, ref)
+				SetReferenceSiblings(n, refNode)
+				SetReferenceNode(n, refNode, idx)
 			}
 
-			c <- mappingResult[PT]{
+			results[slot] = mappingResult[PT]{
 				k: KeyReference[string]{
 					KeyNode: label,
 					Value:   label.Value,
@@ -553,6 +742,7 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 					ValueNode: value,
 					//IsReference: isRef,
 					Reference: ref,
+					Error:     buildErr,
 				},
 			}
 		}
@@ -561,6 +751,8 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 		for i, en := range valueNode.Content {
 			en = utils.NodeAlias(en)
 			referenceValue = ""
+			var refNode *yaml.Node
+			var refErr error
 			if i%2 == 0 {
 				currentLabelNode = en
 				continue
@@ -569,6 +761,7 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 			if h, _, refVal := utils.IsNodeRefValue(en); h {
 				ref, err := LocateRefNode(en, idx)
 				if ref != nil {
+					refNode = en
 					en = ref
 					referenceValue = refVal
 					if err != nil {
@@ -576,8 +769,13 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 					}
 				} else {
 					if err != nil {
-						return nil, labelNode, valueNode, fmt.Errorf("flat map build failed: reference cannot be found: %s",
-							err.Error())
+						refErr = NewBuildError(ErrorReferenceNotFound, en, idx, err,
+							"flat map build failed: reference cannot be found")
+						if !TolerateBuildError(idx, refErr) {
+							wg.Wait()
+							return nil, labelNode, valueNode, refErr
+						}
+						en = UnresolvedNode(en)
 					}
 				}
 			}
@@ -587,19 +785,18 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 					continue // yo, don't pay any attention to extensions, not here anyway.
 				}
 			}
+			wg.Add(1)
+			go buildMap(totalKeys, currentLabelNode, en, referenceValue, refNode, refErr)
 			totalKeys++
-			go buildMap(currentLabelNode, en, bChan, eChan, referenceValue)
 		}
+		wg.Wait()
 
-		completedKeys := 0
-		for completedKeys < totalKeys {
-			select {
-			case err := <-eChan:
-				return valueMap, labelNode, valueNode, err
-			case res := <-bChan:
-				completedKeys++
-				valueMap[res.k] = res.v
+		valueMap := make(map[KeyReference[string]]ValueReference[PT], totalKeys)
+		for i := 0; i < totalKeys; i++ {
+			if errs[i] != nil {
+				return valueMap, labelNode, valueNode, errs[i]
 			}
+			valueMap[results[i].k] = results[i].v
 		}
 		if circError != nil && !idx.AllowCircularReferenceResolving() {
 			return valueMap, labelNode, valueNode, circError
@@ -637,62 +834,54 @@ func ExtractMap[PT Buildable[N], N any](
 //	int64, float64, bool, string
 func ExtractExtensions(root *yaml.Node) map[KeyReference[string]]ValueReference[any] {
 	root = utils.NodeAlias(root)
-	extensions := utils.FindExtensionNodes(root.Content)
 	extensionMap := make(map[KeyReference[string]]ValueReference[any])
-	for _, ext := range extensions {
-		if utils.IsNodeMap(ext.Value) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode := root.Content[i]
+		if !strings.HasPrefix(keyNode.Value, "x-") {
+			continue
+		}
+		valueNode := utils.NodeAlias(root.Content[i+1])
+		key := KeyReference[string]{Value: keyNode.Value, KeyNode: keyNode}
+		if utils.IsNodeMap(valueNode) {
 			var v interface{}
-			_ = ext.Value.Decode(&v)
-			extensionMap[KeyReference[string]{
-				Value:   ext.Key.Value,
-				KeyNode: ext.Key,
-			}] = ValueReference[any]{Value: v, ValueNode: ext.Value}
-		}
-		if utils.IsNodeStringValue(ext.Value) {
-			extensionMap[KeyReference[string]{
-				Value:   ext.Key.Value,
-				KeyNode: ext.Key,
-			}] = ValueReference[any]{Value: ext.Value.Value, ValueNode: ext.Value}
-		}
-		if utils.IsNodeFloatValue(ext.Value) {
-			fv, _ := strconv.ParseFloat(ext.Value.Value, 64)
-			extensionMap[KeyReference[string]{
-				Value:   ext.Key.Value,
-				KeyNode: ext.Key,
-			}] = ValueReference[any]{Value: fv, ValueNode: ext.Value}
-		}
-		if utils.IsNodeIntValue(ext.Value) {
-			iv, _ := strconv.ParseInt(ext.Value.Value, 10, 64)
-			extensionMap[KeyReference[string]{
-				Value:   ext.Key.Value,
-				KeyNode: ext.Key,
-			}] = ValueReference[any]{Value: iv, ValueNode: ext.Value}
-		}
-		if utils.IsNodeBoolValue(ext.Value) {
-			bv, _ := strconv.ParseBool(ext.Value.Value)
-			extensionMap[KeyReference[string]{
-				Value:   ext.Key.Value,
-				KeyNode: ext.Key,
-			}] = ValueReference[any]{Value: bv, ValueNode: ext.Value}
-		}
-		if utils.IsNodeArray(ext.Value) {
+			_ = valueNode.Decode(&v)
+			extensionMap[key] = ValueReference[any]{Value: v, ValueNode: valueNode}
+		}
+		if utils.IsNodeStringValue(valueNode) {
+			extensionMap[key] = ValueReference[any]{Value: valueNode.Value, ValueNode: valueNode}
+		}
+		if utils.IsNodeFloatValue(valueNode) {
+			fv, _ := strconv.ParseFloat(valueNode.Value, 64)
+			extensionMap[key] = ValueReference[any]{Value: fv, ValueNode: valueNode}
+		}
+		if utils.IsNodeIntValue(valueNode) {
+			iv, _ := strconv.ParseInt(valueNode.Value, 10, 64)
+			extensionMap[key] = ValueReference[any]{Value: iv, ValueNode: valueNode}
+		}
+		if utils.IsNodeBoolValue(valueNode) {
+			bv, _ := strconv.ParseBool(valueNode.Value)
+			extensionMap[key] = ValueReference[any]{Value: bv, ValueNode: valueNode}
+		}
+		if utils.IsNodeArray(valueNode) {
 			var v []interface{}
-			_ = ext.Value.Decode(&v)
-			extensionMap[KeyReference[string]{
-				Value:   ext.Key.Value,
-				KeyNode: ext.Key,
-			}] = ValueReference[any]{Value: v, ValueNode: ext.Value}
+			_ = valueNode.Decode(&v)
+			extensionMap[key] = ValueReference[any]{Value: v, ValueNode: valueNode}
 		}
 	}
 	return extensionMap
 }
 
+// isExtensionKey returns true if a value starts with 'x-', in any case, without allocating a lowered copy.
+func isExtensionKey(value string) bool {
+	return len(value) >= 2 && (value[0] == 'x' || value[0] == 'X') && value[1] == '-'
+}
+
 // AreEqual returns true if two Hashable objects are equal or not.
 func AreEqual(l, r Hashable) bool {
 	if l == nil || r == nil {
 		return false
 	}
-	return l.Hash() == r.Hash()
+	return hashOf(l) == hashOf(r)
 }
 
 // GenerateHashString will generate a SHA36 hash of any object passed in. If the object is Hashable
@@ -700,7 +889,7 @@ func AreEqual(l, r Hashable) bool {
 func GenerateHashString(v any) string {
 	if h, ok := v.(Hashable); ok {
 		if h != nil {
-			return fmt.Sprintf(HASH, h.Hash())
+			return fmt.Sprintf(HASH, hashOf(h))
 		}
 	}
 	// if we get here, we're a primitive, check if we're a pointer and de-point
@@ -709,3 +898,28 @@ func GenerateHashString(v any) string {
 	}
 	return fmt.Sprintf(HASH, sha256.Sum256([]byte(fmt.Sprint(v))))
 }
+
+// HashStrings returns the SHA256 hash of values, each prefixed by its length, so values are never ambiguous. The
+// values are joined into a pooled buffer rather than a new string. When legacy hashes are used (see
+// UseLegacyHashes), the values are joined by '|' instead. Fields ignored by HashOptions are left out.
+func HashStrings(values []string) [32]byte {
+	b := utils.GetBuffer()
+	defer utils.PutBuffer(b)
+	legacy := legacyHashes.Load()
+	first := true
+	for _, v := range values {
+		if v == ignoredHashField {
+			continue
+		}
+		if legacy {
+			if !first {
+				*b = append(*b, '|')
+			}
+			first = false
+			*b = append(*b, v...)
+			continue
+		}
+		*b = appendHashValue(*b, v)
+	}
+	return sha256.Sum256(*b)
+}
@@ -5,6 +5,7 @@ package low
 
 import (
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -114,6 +115,28 @@ func TestLocateRefNode_Path(t *testing.T) {
 
 }
 
+func TestLocateRefNode_ComponentLookup(t *testing.T) {
+
+	yml := `x-cakes:
+  "Bob's cake":
+    description: hello`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+
+	yml = `$ref: "#/x-cakes/Bob's cake"`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	located, err := LocateRefNode(cNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.NotNil(t, located)
+	assert.Equal(t, "hello", located.Content[1].Value)
+}
+
 func TestLocateRefNode_Path_NotFound(t *testing.T) {
 
 	yml := `paths:
@@ -168,6 +191,45 @@ func TestExtractObject(t *testing.T) {
 	assert.Equal(t, "hello pizza", tag.Value.Description.Value)
 }
 
+type pasta struct {
+	*Reference
+	Description NodeReference[string]
+}
+
+func (p *pasta) Build(_ *yaml.Node, _ *index.SpecIndex) error {
+	p.Reference = new(Reference)
+	return nil
+}
+
+func TestExtractObject_Origin(t *testing.T) {
+
+	yml := `components:
+  schemas:
+    pasta:
+      description: hello pasta`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+
+	yml = `tags:
+  $ref: '#/components/schemas/pasta'`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	// the origin of a reference is the object it references.
+	tag, err := ExtractObject[*pasta]("tags", &cNode, idx)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, tag.Value.GetOrigin().Line)
+	assert.True(t, tag.Value.GetOrigin().RootDocument)
+
+	// objects without an embedded reference are ignored.
+	SetOrigin(new(pizza), &cNode, idx)
+	SetOrigin(nil, &cNode, idx)
+}
+
 func TestExtractObject_Ref(t *testing.T) {
 
 	yml := `components:
@@ -218,6 +280,77 @@ func TestExtractObject_DoubleRef(t *testing.T) {
 	assert.Equal(t, "cake time!", tag.Value.Description.Value)
 }
 
+func TestExtractObject_DoubleRef_ResolutionChain(t *testing.T) {
+
+	yml := `components:
+  schemas:
+    cake:
+      description: cake time!
+    pizza:
+      $ref: '#/components/schemas/cake'`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+
+	yml = `tags:
+  $ref: '#/components/schemas/pizza'`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	tag, err := ExtractObject[*pasta]("tags", &cNode, idx)
+	assert.NoError(t, err)
+
+	chain := tag.Value.GetResolutionChain()
+	assert.Len(t, chain, 2)
+	assert.Equal(t, 2, tag.Value.GetResolutionDepth())
+	assert.Equal(t, "#/components/schemas/pizza", chain[0].Reference)
+	assert.Equal(t, 2, chain[0].Node.Line)
+	assert.Equal(t, 6, chain[0].Target.Line)
+	assert.Nil(t, chain[0].GetOrigin()) // not part of the indexed document.
+	assert.Equal(t, "#/components/schemas/cake", chain[1].Reference)
+	assert.Equal(t, 6, chain[1].GetOrigin().Line)
+	assert.Equal(t, 4, chain[1].GetTargetOrigin().Line)
+	assert.False(t, chain[1].Circular)
+
+	// not a reference
+	yml = `tags:
+  description: hello pasta`
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+	tag, err = ExtractObject[*pasta]("tags", &cNode, idx)
+	assert.NoError(t, err)
+	assert.Nil(t, tag.Value.GetResolutionChain())
+	assert.Zero(t, tag.Value.GetResolutionDepth())
+}
+
+func TestLocateRefNodeChain_Circular(t *testing.T) {
+	yml := `components:
+  schemas:
+    loopy:
+      $ref: '#/components/schemas/cake'
+    cake:
+      $ref: '#/components/schemas/loopy'
+    pizza:
+      $ref: '#/components/schemas/cake'`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+	resolv := resolver.NewResolver(idx)
+	assert.Len(t, resolv.CheckForCircularReferences(), 1)
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`$ref: '#/components/schemas/pizza'`), &cNode)
+
+	_, chain, err := LocateRefNodeChain(cNode.Content[0], idx)
+	assert.Error(t, err)
+	assert.NotEmpty(t, chain)
+	assert.True(t, chain[len(chain)-1].Circular)
+}
+
 func TestExtractObject_DoubleRef_Circular(t *testing.T) {
 	yml := `components:
   schemas:
@@ -642,6 +775,36 @@ func TestExtractObjectRaw_Ref_AlmostBuildable(t *testing.T) {
 
 }
 
+func TestSetReferenceSiblings(t *testing.T) {
+
+	yml := `$ref: '#/components/responses/ok'
+summary: ok
+description: all good`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	r := new(Reference)
+	SetReferenceSiblings(r, cNode.Content[0])
+	assert.Equal(t, "ok", r.GetReferenceSummary().Value)
+	assert.Equal(t, "all good", r.GetReferenceDescription().Value)
+	assert.Equal(t, "ok", r.OverrideSummary("nope"))
+	assert.Equal(t, "all good", r.OverrideDescription("nope"))
+
+	yml = `$ref: '#/components/responses/ok'`
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	r = new(Reference)
+	SetReferenceSiblings(r, cNode.Content[0])
+	assert.True(t, r.GetReferenceSummary().IsEmpty())
+	assert.True(t, r.GetReferenceDescription().IsEmpty())
+	assert.Equal(t, "nope", r.OverrideSummary("nope"))
+	assert.Equal(t, "nope", r.OverrideDescription("nope"))
+
+	var nilRef *Reference
+	assert.Equal(t, "nope", nilRef.OverrideDescription("nope"))
+}
+
 func TestExtractArray(t *testing.T) {
 
 	yml := `components:
@@ -1705,3 +1868,146 @@ func TestSetReference_nil(t *testing.T) {
 	SetReference(nil, "#/pigeon/street")
 	assert.NotEqual(t, "#/pigeon/street", n.GetReference())
 }
+
+// benchmarkMapNode returns a node with a map of 'size' pizzas, with an extension after every tenth pizza.
+func TestHashStrings(t *testing.T) {
+	values := []string{"pizza", "burgers", "", "cake"}
+	assert.Equal(t, sha256.Sum256([]byte("5:pizza7:burgers0:4:cake")), HashStrings(values))
+	assert.Equal(t, sha256.Sum256([]byte("")), HashStrings(nil))
+
+	// values containing the legacy separator are not ambiguous.
+	assert.NotEqual(t, HashStrings([]string{"a|b", "c"}), HashStrings([]string{"a", "b|c"}))
+
+	UseLegacyHashes(true)
+	defer UseLegacyHashes(false)
+	assert.Equal(t, sha256.Sum256([]byte(strings.Join(values, "|"))), HashStrings(values))
+	assert.Equal(t, HashStrings([]string{"a|b", "c"}), HashStrings([]string{"a", "b|c"}))
+}
+
+func benchmarkMapNode(size int) (*yaml.Node, *index.SpecIndex) {
+	var b strings.Builder
+	b.WriteString("pizzas:\n")
+	for i := 0; i < size; i++ {
+		fmt.Fprintf(&b, "  pizza%d:\n    description: pizza number %d\n    x-cheese: true\n", i, i)
+		if i%10 == 0 {
+			fmt.Fprintf(&b, "  x-pizza%d: extra\n", i)
+		}
+	}
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(b.String()), &root)
+	return root.Content[0], index.NewSpecIndexWithConfig(&root, index.CreateClosedAPIIndexConfig())
+}
+
+func BenchmarkExtractMap(b *testing.B) {
+	root, idx := benchmarkMapNode(100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, _, _, err := ExtractMap[*pizza]("pizzas", root, idx)
+		if err != nil || len(m) != 100 {
+			b.Fatal("bad map")
+		}
+	}
+}
+
+func BenchmarkExtractMapExtensions(b *testing.B) {
+	root, idx := benchmarkMapNode(100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, _, _, err := ExtractMapExtensions[*pizza]("pizzas", root, idx, true)
+		if err != nil || len(m) != 110 {
+			b.Fatal("bad map")
+		}
+	}
+}
+
+func BenchmarkExtractMapNoLookup(b *testing.B) {
+	root, idx := benchmarkMapNode(100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, err := ExtractMapNoLookup[*pizza](root.Content[1], idx)
+		if err != nil || len(m) != 100 {
+			b.Fatal("bad map")
+		}
+	}
+}
+
+func BenchmarkExtractObjectRaw(b *testing.B) {
+	root, idx := benchmarkMapNode(1)
+	node := root.Content[1].Content[1]
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p, err, _, _ := ExtractObjectRaw[*pizza](node, idx)
+		if err != nil || p.Description.Value != "pizza number 0" {
+			b.Fatal("bad object")
+		}
+	}
+}
+
+func BenchmarkExtractExtensions(b *testing.B) {
+	root, _ := benchmarkMapNode(100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if len(ExtractExtensions(root.Content[1])) != 10 {
+			b.Fatal("bad extensions")
+		}
+	}
+}
+
+func TestExtractArray_TolerateBuildErrors(t *testing.T) {
+	yml := `things:
+  - description: one
+  - $ref: '#/components/schemas/missing'
+  - description: three`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	config := index.CreateClosedAPIIndexConfig()
+	config.TolerateBuildErrors = true
+	idx := index.NewSpecIndexWithConfig(&idxNode, config)
+
+	things, _, _, err := ExtractArray[*pizza]("things", idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Len(t, things, 3)
+	assert.Equal(t, "one", things[0].Value.Description.Value)
+	assert.NoError(t, things[0].Error)
+	assert.Empty(t, things[1].Value.Description.Value)
+	assert.Equal(t, 3, things[1].ValueNode.Line)
+	assert.Equal(t, "three", things[2].Value.Description.Value)
+	assert.Len(t, idx.GetBuildErrors(), 1)
+	assert.Equal(t, things[1].Error, idx.GetBuildErrors()[0])
+}
+
+func TestExtractMap_TolerateBuildErrors(t *testing.T) {
+	yml := `things:
+  one:
+    description: one
+  two:
+    $ref: '#/components/schemas/missing'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	config := index.CreateClosedAPIIndexConfig()
+	config.TolerateBuildErrors = true
+	idx := index.NewSpecIndexWithConfig(&idxNode, config)
+
+	things, _, _, err := ExtractMap[*pizza]("things", idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Len(t, things, 2)
+	assert.Equal(t, "one", FindItemInMap("one", things).Value.Description.Value)
+	two := FindItemInMap("two", things)
+	assert.NotNil(t, two.Value)
+	var buildErr *BuildError
+	assert.True(t, errors.As(two.Error, &buildErr))
+	assert.Equal(t, ErrorReferenceNotFound, buildErr.Code)
+
+	// without tolerating errors, nothing is built.
+	idx = index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+	things, _, _, err = ExtractMap[*pizza]("things", idxNode.Content[0], idx)
+	assert.Error(t, err)
+	assert.Nil(t, things)
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// legacyHashes is set by UseLegacyHashes.
+var legacyHashes atomic.Bool
+
+// HashOptions choose which fields of a model are hashed by its Hash method. By default every field is hashed, so
+// a change to any of them changes the hash. Ignoring the editorial fields (like descriptions and examples) means
+// models that only differ editorially hash the same, so they are 'semantically' equal.
+//
+// Ignored fields are ignored in every model, and every model found in them, like the description of a property of
+// a schema of a response. They are never ignored while documents are compared (see SuspendHashOptions), a comparison
+// only looks inside models whose hashes differ, so it would miss changes made to ignored fields.
+type HashOptions struct {
+	IgnoreDescriptions bool     // ignore 'description' fields.
+	IgnoreSummaries    bool     // ignore 'summary' fields.
+	IgnoreExamples     bool     // ignore 'example' and 'examples' fields.
+	IgnoreExtensions   bool     // ignore every extension.
+	IgnoredExtensions  []string // ignore these extensions, like 'x-internal-notes'.
+	IgnoredFields      []string // ignore any field, by the name it has in the specification, like 'title'.
+}
+
+// hashFilter is the set of fields and extensions ignored by HashOptions.
+type hashFilter struct {
+	fields     map[string]bool
+	extensions map[string]bool
+	allExt     bool
+}
+
+// hashOptions is set by SetHashOptions, nil when every field is hashed.
+var hashOptions atomic.Pointer[hashFilter]
+
+// SetHashOptions changes which fields are hashed by every model, see HashOptions. Passing nil hashes every field
+// again.
+//
+// This changes the hashes of every model, so it should be set before anything is hashed, and never while hashes
+// are being cached (see HashCache).
+func SetHashOptions(options *HashOptions) {
+	if options == nil {
+		hashOptions.Store(nil)
+		return
+	}
+	f := &hashFilter{
+		fields:     make(map[string]bool),
+		extensions: make(map[string]bool),
+		allExt:     options.IgnoreExtensions,
+	}
+	if options.IgnoreDescriptions {
+		f.fields["description"] = true
+	}
+	if options.IgnoreSummaries {
+		f.fields["summary"] = true
+	}
+	if options.IgnoreExamples {
+		f.fields["example"] = true
+		f.fields["examples"] = true
+	}
+	for _, field := range options.IgnoredFields {
+		f.fields[field] = true
+	}
+	for _, ext := range options.IgnoredExtensions {
+		f.extensions[ext] = true
+	}
+	hashOptions.Store(f)
+}
+
+// suspendedHashOptions counts the callers of SuspendHashOptions that haven't resumed them yet.
+var suspendedHashOptions atomic.Int32
+
+// SuspendHashOptions hashes every field of every model, ignoring the HashOptions set, until the returned function
+// is called. Comparing documents suspends them, so changes to ignored fields are still found.
+//
+// Like SetHashOptions, this changes the hashes of every model, models hashed while options are suspended (by any
+// goroutine) hash every field.
+func SuspendHashOptions() (resume func()) {
+	suspendedHashOptions.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			suspendedHashOptions.Add(-1)
+		})
+	}
+}
+
+// activeHashOptions returns the fields and extensions ignored by the HashOptions in use, nil if every field is
+// hashed.
+func activeHashOptions() *hashFilter {
+	if suspendedHashOptions.Load() > 0 {
+		return nil
+	}
+	return hashOptions.Load()
+}
+
+// ignoredField returns true if a field is ignored by the HashOptions in use.
+func ignoredField(name string) bool {
+	f := activeHashOptions()
+	return f != nil && f.fields[name]
+}
+
+// ignoredExtension returns true if an extension is ignored by the HashOptions in use.
+func ignoredExtension(name string) bool {
+	f := activeHashOptions()
+	return f != nil && (f.allExt || f.extensions[name])
+}
+
+// UseLegacyHashes switches every Hash method back to hashing the values of a model without the names of their
+// fields, and HashStrings back to joining values with '|'. This produces the hashes generated by older versions of
+// libopenapi, for anyone that has stored them, so fields those versions didn't read (like '$dynamicRef' or 'const'
+// of a schema) are left out, and a change to one of them doesn't change a legacy hash. Legacy hashes are ambiguous: two different models can hash the same
+// when their values are in the same order (like a title of 'pets' and an empty summary, or an empty title and a
+// summary of 'pets'), so they should only be used to compare with stored hashes.
+//
+// This changes the hashes of every model, so it should be set before anything is hashed, and never while hashes
+// are being cached (see HashCache).
+func UseLegacyHashes(legacy bool) {
+	legacyHashes.Store(legacy)
+}
+
+// LegacyHashes returns true if legacy hashes are in use, see UseLegacyHashes.
+func LegacyHashes() bool {
+	return legacyHashes.Load()
+}
+
+// ignoredHashField is returned by HashField for a field ignored by HashOptions. HashStrings drops it, so an ignored
+// field changes nothing, whether it's set or not.
+const ignoredHashField = "\x00ignored"
+
+// HashField returns the value of a field, prefixed with the name of the field, to be hashed by HashStrings. Naming
+// the field a value belongs to stops two models with the same values in different fields from hashing the same.
+//
+// A field ignored by HashOptions is not hashed at all.
+func HashField(name, value string) string {
+	if ignoredField(name) {
+		return ignoredHashField
+	}
+	if legacyHashes.Load() {
+		return value
+	}
+	return name + ":" + value
+}
+
+// HashFields is the same as HashField, for each value of a field that holds many values.
+func HashFields(name string, values []string) []string {
+	if ignoredField(name) {
+		return nil
+	}
+	if legacyHashes.Load() {
+		return values
+	}
+	fields := make([]string, len(values))
+	for i := range values {
+		fields[i] = name + ":" + values[i]
+	}
+	return fields
+}
+
+// HashEntry returns an entry of a map (like a property of a schema) to be hashed as the value of a field, the key of
+// the entry followed by the hash of its value, so two maps with the same values under different keys don't hash the
+// same. Legacy hashes only hash the value.
+func HashEntry(key, valueHash string) string {
+	if legacyHashes.Load() {
+		return valueHash
+	}
+	return key + "-" + valueHash
+}
+
+// HashExtensions returns the extensions of a model to be hashed by HashStrings, sorted by name. Extensions ignored
+// by HashOptions are not hashed.
+func HashExtensions(extensions map[KeyReference[string]]ValueReference[any]) []string {
+	keys := make([]string, 0, len(extensions))
+	for k := range extensions {
+		if ignoredExtension(k.Value) {
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(extensions[k].Value)))))
+	}
+	sort.Strings(keys)
+	return HashFields("extensions", keys)
+}
+
+// HashExtensionValues is the same as HashExtensions, except the values of extensions are hashed as they are printed
+// rather than by their SHA256 hash. Older versions hashed the extensions of some models this way, so only legacy
+// hashes use it (see UseLegacyHashes).
+func HashExtensionValues(extensions map[KeyReference[string]]ValueReference[any]) []string {
+	keys := make([]string, 0, len(extensions))
+	for k := range extensions {
+		if ignoredExtension(k.Value) {
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("%s-%v", k.Value, extensions[k].Value))
+	}
+	sort.Strings(keys)
+	return HashFields("extensions", keys)
+}
+
+// appendHashValue appends a value to be hashed to b, prefixed by its length so values containing a separator
+// can't be mistaken for more than one value.
+func appendHashValue(b []byte, value string) []byte {
+	b = strconv.AppendInt(b, int64(len(value)), 10)
+	b = append(b, ':')
+	return append(b, value...)
+}
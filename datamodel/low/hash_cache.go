@@ -0,0 +1,80 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/pb33f/libopenapi/index"
+)
+
+// HashCache memoizes the hashes of models (by their pointer) for a single comparison. Comparing two documents hashes
+// the same models over and over (every level of a changed path hashes everything below it again), with hashes
+// cached, each model is only hashed once.
+//
+// A HashCache only caches the hashes of models built from the indexes it's attached to, and only while it's
+// attached. Models must not be changed while their hashes are cached.
+type HashCache struct {
+	hashes sync.Map
+}
+
+// NewHashCache creates an empty HashCache, ready to be attached to the indexes of the documents being compared.
+func NewHashCache() *HashCache {
+	return new(HashCache)
+}
+
+// Attach attaches the cache to every index (and all of their children), until the returned function is called.
+// Indexes that already have a cache attached (by another comparison) are left alone, their models are hashed
+// using that cache.
+func (c *HashCache) Attach(indexes ...*index.SpecIndex) (detach func()) {
+	var attached []*index.SpecIndex
+	seen := make(map[*index.SpecIndex]bool)
+	var attach func(idx *index.SpecIndex)
+	attach = func(idx *index.SpecIndex) {
+		if idx == nil || seen[idx] {
+			return
+		}
+		seen[idx] = true
+		if idx.SetHashCache(&c.hashes) {
+			attached = append(attached, idx)
+		}
+		for _, child := range idx.GetChildren() {
+			attach(child)
+		}
+	}
+	for _, idx := range indexes {
+		attach(idx)
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for _, idx := range attached {
+				idx.ClearHashCache(&c.hashes)
+			}
+		})
+	}
+}
+
+// hashOf returns the hash of a model, from the cache attached to the index it was built from, if there is one.
+func hashOf(h Hashable) [32]byte {
+	hi, ok := h.(HasIndex)
+	if v := reflect.ValueOf(h); !ok || v.Kind() != reflect.Pointer || v.IsNil() {
+		return h.Hash()
+	}
+	idx := hi.GetIndex()
+	if idx == nil {
+		return h.Hash()
+	}
+	hashes := idx.GetHashCache()
+	if hashes == nil {
+		return h.Hash()
+	}
+	if hash, ok := hashes.Load(h); ok {
+		return hash.([32]byte)
+	}
+	hash := h.Hash()
+	hashes.Store(h, hash)
+	return hash
+}
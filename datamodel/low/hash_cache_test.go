@@ -0,0 +1,91 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type countedHash struct {
+	Nodes
+	value  string
+	hashed int
+}
+
+func (c *countedHash) Hash() [32]byte {
+	c.hashed++
+	return [32]byte{c.value[0]}
+}
+
+func newCountedHash(value string, idx *index.SpecIndex) *countedHash {
+	c := &countedHash{value: value}
+	c.Nodes.index = idx
+	return c
+}
+
+func newHashCacheIndex() *index.SpecIndex {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0"), &root)
+	return index.NewSpecIndexWithConfig(&root, index.CreateOpenAPIIndexConfig())
+}
+
+func TestHashCache(t *testing.T) {
+	lIdx, rIdx := newHashCacheIndex(), newHashCacheIndex()
+	l, r := newCountedHash("a", lIdx), newCountedHash("a", rIdx)
+
+	detach := NewHashCache().Attach(lIdx, rIdx)
+	assert.True(t, AreEqual(l, r))
+	assert.True(t, AreEqual(l, r))
+	assert.Equal(t, GenerateHashString(l), GenerateHashString(r))
+	assert.Equal(t, 1, l.hashed)
+	assert.Equal(t, 1, r.hashed)
+
+	// another comparison of the same index uses the cache that is already attached, and leaves it attached.
+	other := NewHashCache().Attach(lIdx)
+	other()
+	assert.True(t, AreEqual(l, r))
+	assert.Equal(t, 1, l.hashed)
+
+	detach()
+	detach()
+
+	// models are hashed every time once the cache is detached.
+	assert.True(t, AreEqual(l, r))
+	assert.True(t, AreEqual(l, r))
+	assert.Equal(t, 3, l.hashed)
+}
+
+func TestHashCache_OtherIndexes(t *testing.T) {
+	attachedIdx, otherIdx := newHashCacheIndex(), newHashCacheIndex()
+	attached, other := newCountedHash("a", attachedIdx), newCountedHash("a", otherIdx)
+	noIndex := newCountedHash("a", nil)
+
+	defer NewHashCache().Attach(attachedIdx)()
+
+	// only models built from an attached index are cached.
+	for i := 0; i < 2; i++ {
+		assert.True(t, AreEqual(attached, other))
+		assert.True(t, AreEqual(attached, noIndex))
+	}
+	assert.Equal(t, 1, attached.hashed)
+	assert.Equal(t, 2, other.hashed)
+	assert.Equal(t, 2, noIndex.hashed)
+}
+
+func TestHashCache_Children(t *testing.T) {
+	root, child := newHashCacheIndex(), newHashCacheIndex()
+	root.AddChild(child)
+	c := newCountedHash("a", child)
+
+	detach := NewHashCache().Attach(root)
+	GenerateHashString(c)
+	GenerateHashString(c)
+	assert.Equal(t, 1, c.hashed)
+	detach()
+	assert.Nil(t, child.GetHashCache())
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashField(t *testing.T) {
+	assert.Equal(t, "title:pizza", HashField("title", "pizza"))
+	assert.Equal(t, []string{"tags:a", "tags:b"}, HashFields("tags", []string{"a", "b"}))
+	assert.Empty(t, HashFields("tags", nil))
+
+	assert.NotEqual(t,
+		HashStrings([]string{HashField("title", "pizza"), HashField("summary", "")}),
+		HashStrings([]string{HashField("title", ""), HashField("summary", "pizza")}))
+}
+
+func TestHashEntry(t *testing.T) {
+	assert.Equal(t, "pizza-hash", HashEntry("pizza", "hash"))
+	assert.NotEqual(t,
+		HashStrings([]string{HashField("properties", HashEntry("a", "hash"))}),
+		HashStrings([]string{HashField("properties", HashEntry("b", "hash"))}))
+}
+
+func TestUseLegacyHashes(t *testing.T) {
+	assert.False(t, LegacyHashes())
+	UseLegacyHashes(true)
+	defer UseLegacyHashes(false)
+	assert.True(t, LegacyHashes())
+
+	assert.Equal(t, "pizza", HashField("title", "pizza"))
+	assert.Equal(t, []string{"a", "b"}, HashFields("tags", []string{"a", "b"}))
+	assert.Equal(t, "hash", HashEntry("pizza", "hash"))
+	assert.Equal(t,
+		HashStrings([]string{HashField("title", "pizza")}),
+		HashStrings([]string{HashField("summary", "pizza")}))
+}
+
+func TestSetHashOptions(t *testing.T) {
+	defer SetHashOptions(nil)
+	SetHashOptions(&HashOptions{
+		IgnoreDescriptions: true,
+		IgnoreExamples:     true,
+		IgnoredExtensions:  []string{"x-notes"},
+		IgnoredFields:      []string{"title"},
+	})
+	assert.Equal(t, ignoredHashField, HashField("description", "pizza"))
+	assert.Equal(t, ignoredHashField, HashField("title", "pizza"))
+	assert.Equal(t, "summary:pizza", HashField("summary", "pizza"))
+	assert.Equal(t, ignoredHashField, HashField("example", "pizza"))
+	assert.Nil(t, HashFields("examples", []string{"pizza"}))
+
+	// an ignored field hashes the same as no field at all.
+	summary := HashField("summary", "pizza")
+	assert.Equal(t, HashStrings([]string{summary}), HashStrings([]string{HashField("description", "pizza"), summary}))
+	assert.Equal(t, HashStrings([]string{summary}), HashStrings([]string{summary, HashField("example", "")}))
+
+	ext := func(name, value string) map[KeyReference[string]]ValueReference[any] {
+		return map[KeyReference[string]]ValueReference[any]{
+			{Value: name}: {Value: value},
+		}
+	}
+	assert.Empty(t, HashExtensions(ext("x-notes", "pizza")))
+	assert.Len(t, HashExtensions(ext("x-pizza", "hot")), 1)
+
+	SetHashOptions(&HashOptions{IgnoreExtensions: true})
+	assert.Empty(t, HashExtensions(ext("x-pizza", "hot")))
+	assert.Equal(t, "description:pizza", HashField("description", "pizza"))
+
+	SetHashOptions(nil)
+	assert.Len(t, HashExtensions(ext("x-notes", "pizza")), 1)
+}
+
+func TestSetHashOptions_Legacy(t *testing.T) {
+	UseLegacyHashes(true)
+	defer UseLegacyHashes(false)
+	defer SetHashOptions(nil)
+	SetHashOptions(&HashOptions{IgnoreDescriptions: true})
+
+	title := HashField("title", "pizza")
+	assert.Equal(t, HashStrings([]string{title}), HashStrings([]string{HashField("description", "hot"), title}))
+	assert.Equal(t, HashStrings([]string{title}), HashStrings([]string{title, HashField("description", "hot")}))
+}
+
+func TestSuspendHashOptions(t *testing.T) {
+	defer SetHashOptions(nil)
+	SetHashOptions(&HashOptions{IgnoreDescriptions: true})
+
+	resume := SuspendHashOptions()
+	assert.Equal(t, "description:pizza", HashField("description", "pizza"))
+	resume()
+	resume() // resuming twice is harmless.
+	assert.Equal(t, ignoredHashField, HashField("description", "pizza"))
+}
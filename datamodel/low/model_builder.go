@@ -30,26 +30,14 @@ func BuildModel(node *yaml.Node, model interface{}) error {
 		return fmt.Errorf("cannot build model on non-pointer: %v", reflect.ValueOf(model).Type().Kind())
 	}
 	v := reflect.ValueOf(model).Elem()
-	num := v.NumField()
-	for i := 0; i < num; i++ {
-
-		fName := v.Type().Field(i).Name
-
-		if fName == "Extensions" {
-			continue // internal construct
-		}
-
-		if fName == "PathItems" {
-			continue // internal construct
-		}
-
-		kn, vn := utils.FindKeyNodeTop(strings.ToLower(fName), node.Content)
+	for _, f := range modelFields(v.Type()) {
+		kn, vn := utils.FindKeyNodeTop(f.label, node.Content)
 		if vn == nil {
 			// no point in going on.
 			continue
 		}
 
-		field := v.FieldByName(fName)
+		field := v.Field(f.index)
 		kind := field.Kind()
 		switch kind {
 		case reflect.Struct, reflect.Slice, reflect.Map, reflect.Pointer:
@@ -67,6 +55,32 @@ func BuildModel(node *yaml.Node, model interface{}) error {
 	return nil
 }
 
+// modelField is a field of a model that can be built by BuildModel, and the label it's looked up with.
+type modelField struct {
+	index int
+	label string
+}
+
+// modelFieldCache holds the fields of every model type built so far, so they are only analyzed once.
+var modelFieldCache sync.Map
+
+// modelFields returns the fields of a model type that can be built by BuildModel.
+func modelFields(t reflect.Type) []modelField {
+	if f, ok := modelFieldCache.Load(t); ok {
+		return f.([]modelField)
+	}
+	var fields []modelField
+	for i := 0; i < t.NumField(); i++ {
+		fName := t.Field(i).Name
+		if fName == "Extensions" || fName == "PathItems" || t.Field(i).Anonymous {
+			continue // internal construct
+		}
+		fields = append(fields, modelField{index: i, label: strings.ToLower(fName)})
+	}
+	f, _ := modelFieldCache.LoadOrStore(t, fields)
+	return f.([]modelField)
+}
+
 // SetField accepts a field reflection value, a yaml.Node valueNode and a yaml.Node keyNode. Using reflection, the
 // function will attempt to set the value of the field based on the key and value nodes. This method is only useful
 // for low-level models, it has no value to high-level ones.
@@ -80,7 +94,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 	case reflect.TypeOf(map[string]NodeReference[any]{}):
 		if utils.IsNodeMap(valueNode) {
 			if field.CanSet() {
-				items := make(map[string]NodeReference[any])
+				items := make(map[string]NodeReference[any], len(valueNode.Content)/2)
 				var currentLabel string
 				for i, sliceItem := range valueNode.Content {
 					if i%2 == 0 {
@@ -96,7 +110,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						KeyNode:   valueNode,
 					}
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -104,7 +118,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 
 		if utils.IsNodeMap(valueNode) {
 			if field.CanSet() {
-				items := make(map[string]NodeReference[string])
+				items := make(map[string]NodeReference[string], len(valueNode.Content)/2)
 				var currentLabel string
 				for i, sliceItem := range valueNode.Content {
 					if i%2 == 0 {
@@ -112,12 +126,12 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						continue
 					}
 					items[currentLabel] = NodeReference[string]{
-						Value:     fmt.Sprintf("%v", sliceItem.Value),
+						Value:     sliceItem.Value,
 						ValueNode: sliceItem,
 						KeyNode:   valueNode,
 					}
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -127,7 +141,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 		_ = valueNode.Decode(&decoded)
 		if field.CanSet() {
 			or := NodeReference[any]{Value: decoded, ValueNode: valueNode, KeyNode: keyNode}
-			field.Set(reflect.ValueOf(or))
+			setFieldValue(field, or)
 		}
 
 	case reflect.TypeOf([]NodeReference[any]{}):
@@ -147,7 +161,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						KeyNode:   valueNode,
 					})
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -155,21 +169,21 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 
 		if field.CanSet() {
 			nr := NodeReference[string]{
-				Value:     fmt.Sprintf("%v", valueNode.Value),
+				Value:     valueNode.Value,
 				ValueNode: valueNode,
 				KeyNode:   keyNode,
 			}
-			field.Set(reflect.ValueOf(nr))
+			setFieldValue(field, nr)
 		}
 
 	case reflect.TypeOf(ValueReference[string]{}):
 
 		if field.CanSet() {
 			nr := ValueReference[string]{
-				Value:     fmt.Sprintf("%v", valueNode.Value),
+				Value:     valueNode.Value,
 				ValueNode: valueNode,
 			}
-			field.Set(reflect.ValueOf(nr))
+			setFieldValue(field, nr)
 		}
 
 	case reflect.TypeOf(NodeReference[bool]{}):
@@ -182,7 +196,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					ValueNode: valueNode,
 					KeyNode:   keyNode,
 				}
-				field.Set(reflect.ValueOf(nr))
+				setFieldValue(field, nr)
 			}
 		}
 
@@ -196,7 +210,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					ValueNode: valueNode,
 					KeyNode:   keyNode,
 				}
-				field.Set(reflect.ValueOf(nr))
+				setFieldValue(field, nr)
 			}
 		}
 
@@ -210,7 +224,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					ValueNode: valueNode,
 					KeyNode:   keyNode,
 				}
-				field.Set(reflect.ValueOf(nr))
+				setFieldValue(field, nr)
 			}
 		}
 
@@ -224,7 +238,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					ValueNode: valueNode,
 					KeyNode:   keyNode,
 				}
-				field.Set(reflect.ValueOf(nr))
+				setFieldValue(field, nr)
 			}
 		}
 
@@ -238,7 +252,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					ValueNode: valueNode,
 					KeyNode:   keyNode,
 				}
-				field.Set(reflect.ValueOf(nr))
+				setFieldValue(field, nr)
 			}
 		}
 
@@ -254,7 +268,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						KeyNode:   valueNode,
 					})
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -271,7 +285,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						KeyNode:   valueNode,
 					})
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -284,7 +298,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					fv, _ := strconv.ParseFloat(sliceItem.Value, 64)
 					items = append(items, NodeReference[float64]{Value: fv, ValueNode: sliceItem})
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -301,7 +315,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						KeyNode:   valueNode,
 					})
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -318,7 +332,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						KeyNode:   valueNode,
 					})
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -335,7 +349,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						KeyNode:   valueNode,
 					})
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -344,7 +358,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 
 		if utils.IsNodeMap(valueNode) {
 			if field.CanSet() {
-				items := make(map[KeyReference[string]]ValueReference[string])
+				items := make(map[KeyReference[string]]ValueReference[string], len(valueNode.Content)/2)
 				var cf *yaml.Node
 				for i, sliceItem := range valueNode.Content {
 					if i%2 == 0 {
@@ -359,7 +373,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 						ValueNode: sliceItem,
 					}
 				}
-				field.Set(reflect.ValueOf(items))
+				setFieldValue(field, items)
 			}
 		}
 
@@ -367,7 +381,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 
 		if utils.IsNodeMap(valueNode) {
 			if field.CanSet() {
-				items := make(map[KeyReference[string]]ValueReference[string])
+				items := make(map[KeyReference[string]]ValueReference[string], len(valueNode.Content)/2)
 				var cf *yaml.Node
 				for i, sliceItem := range valueNode.Content {
 					if i%2 == 0 {
@@ -386,13 +400,13 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					Value:   items,
 					KeyNode: keyNode,
 				}
-				field.Set(reflect.ValueOf(ref))
+				setFieldValue(field, ref)
 			}
 		}
 	case reflect.TypeOf(NodeReference[map[KeyReference[string]]ValueReference[string]]{}):
 		if utils.IsNodeMap(valueNode) {
 			if field.CanSet() {
-				items := make(map[KeyReference[string]]ValueReference[string])
+				items := make(map[KeyReference[string]]ValueReference[string], len(valueNode.Content)/2)
 				var cf *yaml.Node
 				for i, sliceItem := range valueNode.Content {
 					if i%2 == 0 {
@@ -412,7 +426,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					KeyNode:   keyNode,
 					ValueNode: valueNode,
 				}
-				field.Set(reflect.ValueOf(ref))
+				setFieldValue(field, ref)
 			}
 		}
 	case reflect.TypeOf(NodeReference[[]ValueReference[string]]{}):
@@ -431,7 +445,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					KeyNode:   keyNode,
 					ValueNode: valueNode,
 				}
-				field.Set(reflect.ValueOf(n))
+				setFieldValue(field, n)
 			}
 		}
 
@@ -467,7 +481,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 					KeyNode:   keyNode,
 					ValueNode: valueNode,
 				}
-				field.Set(reflect.ValueOf(n))
+				setFieldValue(field, n)
 			}
 		}
 
@@ -478,6 +492,16 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 	return nil
 }
 
+// setFieldValue sets the value of a field, without boxing the value into an interface first (when the field is
+// addressable). The value must be the same type as the field.
+func setFieldValue[T any](field *reflect.Value, value T) {
+	if field.CanAddr() {
+		*field.Addr().Interface().(*T) = value
+		return
+	}
+	field.Set(reflect.ValueOf(value))
+}
+
 // BuildModelAsync is a convenience function for calling BuildModel from a goroutine, requires a sync.WaitGroup
 func BuildModelAsync(n *yaml.Node, model interface{}, lwg *sync.WaitGroup, errors *[]error) {
 	if n != nil {
@@ -0,0 +1,116 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// Nodes holds the key node and root node a low-level object was built from, and the index used to build it.
+// It's embedded by every low-level object, so they all implement HasKeyNode, HasValueNode, HasRootNode and HasIndex
+// and can be handled generically, without knowing the type of the object.
+type Nodes struct {
+	keyNode  *yaml.Node
+	rootNode *yaml.Node
+	index    *index.SpecIndex
+}
+
+// SetNodes records the root node the object was built from (after following any references), and the index used.
+func (n *Nodes) SetNodes(root *yaml.Node, idx *index.SpecIndex) {
+	if n == nil {
+		return
+	}
+	n.rootNode = root
+	n.index = idx
+}
+
+// SetKeyNode records the key node that holds the object, like the 'info' key holding an Info object.
+func (n *Nodes) SetKeyNode(keyNode *yaml.Node) {
+	if n == nil {
+		return
+	}
+	n.keyNode = keyNode
+}
+
+// GetKeyNode returns the key node that holds the object, or nil if the object is not held by a key (like an item
+// of an array, or the root of a document).
+func (n *Nodes) GetKeyNode() *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	return n.keyNode
+}
+
+// GetRootNode returns the node the object was built from, after following any references.
+func (n *Nodes) GetRootNode() *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	return n.rootNode
+}
+
+// GetValueNode returns the node the object was built from, the same as GetRootNode.
+func (n *Nodes) GetValueNode() *yaml.Node {
+	return n.GetRootNode()
+}
+
+// GetIndex returns the index used to build the object, the index of the document the object was found in.
+func (n *Nodes) GetIndex() *index.SpecIndex {
+	if n == nil {
+		return nil
+	}
+	return n.index
+}
+
+// DocumentRootNode returns the root mapping node of the document an index was created for, or nil if there is no
+// index (or document).
+func DocumentRootNode(idx *index.SpecIndex) *yaml.Node {
+	if idx == nil {
+		return nil
+	}
+	root := idx.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil
+		}
+		return root.Content[0]
+	}
+	return root
+}
+
+// SetKeyNode will record the key node that holds an object on the object, if it can record one (see Nodes).
+func SetKeyNode(obj any, keyNode *yaml.Node) {
+	if obj == nil || keyNode == nil {
+		return
+	}
+	if k, ok := obj.(interface{ SetKeyNode(*yaml.Node) }); ok {
+		k.SetKeyNode(keyNode)
+	}
+}
+
+// SortKeysByPosition sorts keys into the order they are found in the document, by the line and column of their key
+// nodes. Keys without a node (added after building) go last, sorted by name.
+func SortKeysByPosition(keys []KeyReference[string]) {
+	position := func(k KeyReference[string]) (int, int) {
+		if k.KeyNode == nil {
+			return math.MaxInt, 0
+		}
+		return k.KeyNode.Line, k.KeyNode.Column
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		li, ci := position(keys[i])
+		lj, cj := position(keys[j])
+		if li != lj {
+			return li < lj
+		}
+		if ci != cj {
+			return ci < cj
+		}
+		return keys[i].Value < keys[j].Value
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type nodesModel struct {
+	Name NodeReference[string]
+	Nodes
+}
+
+func (n *nodesModel) Build(root *yaml.Node, idx *index.SpecIndex) error {
+	n.SetNodes(root, idx)
+	return nil
+}
+
+func TestNodes(t *testing.T) {
+	var n *Nodes
+	assert.Nil(t, n.GetKeyNode())
+	assert.Nil(t, n.GetRootNode())
+	assert.Nil(t, n.GetValueNode())
+	assert.Nil(t, n.GetIndex())
+	n.SetNodes(nil, nil) // no panic.
+	n.SetKeyNode(nil)
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("pizza:\n  name: hot"), &node)
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+
+	n = new(Nodes)
+	n.SetNodes(node.Content[0].Content[1], idx)
+	n.SetKeyNode(node.Content[0].Content[0])
+	assert.Equal(t, "pizza", n.GetKeyNode().Value)
+	assert.Equal(t, node.Content[0].Content[1], n.GetRootNode())
+	assert.Equal(t, node.Content[0].Content[1], n.GetValueNode())
+	assert.Equal(t, idx, n.GetIndex())
+}
+
+func TestNodes_Interfaces(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("pizza:\n  name: hot"), &node)
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+
+	res, err := ExtractObject[*nodesModel]("pizza", node.Content[0], idx)
+	assert.NoError(t, err)
+
+	var model any = res.Value
+	assert.Equal(t, res.KeyNode, model.(HasKeyNode).GetKeyNode())
+	assert.Equal(t, res.ValueNode, model.(HasRootNode).GetRootNode())
+	assert.Equal(t, idx, model.(HasIndex).GetIndex())
+	assert.Equal(t, "hot", res.Value.Name.Value)
+	assert.Equal(t, res.ValueNode, valueNodeOf[nodesModel](res.Value))
+}
+
+func valueNodeOf[T any, PT HasValueNode[T]](v PT) *yaml.Node {
+	return v.GetValueNode()
+}
+
+func TestDocumentRootNode(t *testing.T) {
+	assert.Nil(t, DocumentRootNode(nil))
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0"), &node)
+	idx := index.NewSpecIndexWithConfig(&node, index.CreateClosedAPIIndexConfig())
+	assert.Equal(t, node.Content[0], DocumentRootNode(idx))
+}
+
+func TestSetKeyNode(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("pizza: hot"), &node)
+
+	SetKeyNode(nil, node.Content[0].Content[0]) // no panic.
+	SetKeyNode("pizza", node.Content[0].Content[0])
+
+	n := new(nodesModel)
+	SetKeyNode(n, nil)
+	assert.Nil(t, n.GetKeyNode())
+	SetKeyNode(n, node.Content[0].Content[0])
+	assert.Equal(t, "pizza", n.GetKeyNode().Value)
+}
+
+func TestSortKeysByPosition(t *testing.T) {
+	keys := []KeyReference[string]{
+		{Value: "new"},
+		{Value: "second", KeyNode: &yaml.Node{Line: 2, Column: 1}},
+		{Value: "added"},
+		{Value: "first", KeyNode: &yaml.Node{Line: 1, Column: 5}},
+		{Value: "zeroth", KeyNode: &yaml.Node{Line: 1, Column: 1}},
+	}
+	SortKeysByPosition(keys)
+	var names []string
+	for _, k := range keys {
+		names = append(names, k.Value)
+	}
+	assert.Equal(t, []string{"zeroth", "first", "second", "added", "new"}, names)
+}
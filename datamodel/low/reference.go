@@ -13,12 +13,144 @@ const (
 
 type Reference struct {
 	Reference string `json:"-" yaml:"-"`
+
+	// ReferenceSummary and ReferenceDescription hold any 'summary' or 'description' found alongside a $ref
+	// (OpenAPI 3.1+). When set, they override the values of the referenced object.
+	ReferenceSummary     NodeReference[string] `json:"-" yaml:"-"`
+	ReferenceDescription NodeReference[string] `json:"-" yaml:"-"`
+
+	originNode  *yaml.Node
+	originIndex *index.SpecIndex
+	refNode     *yaml.Node
+	refIndex    *index.SpecIndex
+}
+
+// ReferenceHop is a single step made when resolving a $ref. A reference that points to another reference (that
+// may live in another document) will be resolved through multiple hops, one for every $ref followed.
+type ReferenceHop struct {
+	Reference string     // the $ref value that was followed.
+	Node      *yaml.Node // the node containing the $ref.
+	Target    *yaml.Node // the node the $ref resolved to, this is another $ref for every hop but the last.
+	Circular  bool       // the reference is circular, resolution stopped at this hop.
+	idx       *index.SpecIndex
+}
+
+// GetOrigin returns the origin of the $ref followed by this hop, the absolute location of the document (file or URL)
+// and its position. Returns nil if the origin is not known.
+func (h *ReferenceHop) GetOrigin() *index.NodeOrigin {
+	if h == nil || h.idx == nil {
+		return nil
+	}
+	return h.idx.FindNodeOrigin(h.Node)
+}
+
+// GetTargetOrigin returns the origin of the node the $ref followed by this hop resolved to. Returns nil if the
+// origin is not known.
+func (h *ReferenceHop) GetTargetOrigin() *index.NodeOrigin {
+	if h == nil || h.idx == nil {
+		return nil
+	}
+	return h.idx.FindNodeOrigin(h.Target)
+}
+
+// SetReferenceNode records the $ref node this object was located through, and the index used to locate it.
+func (r *Reference) SetReferenceNode(node *yaml.Node, idx *index.SpecIndex) {
+	if r == nil {
+		return
+	}
+	r.refNode = node
+	r.refIndex = idx
+}
+
+// GetResolutionChain returns every hop made while resolving the $ref this object was located through, in the
+// order they were made. The last hop resolves to this object. Returns nil if this object is not a reference.
+func (r *Reference) GetResolutionChain() []*ReferenceHop {
+	if r == nil || r.refNode == nil || r.refIndex == nil {
+		return nil
+	}
+	_, chain, _ := LocateRefNodeChain(r.refNode, r.refIndex)
+	return chain
+}
+
+// GetResolutionDepth returns the number of $ref hops made to resolve this object, zero if it's not a reference.
+func (r *Reference) GetResolutionDepth() int {
+	return len(r.GetResolutionChain())
+}
+
+// SetOrigin records the node this object was built from (after following any references), and the index used.
+func (r *Reference) SetOrigin(node *yaml.Node, idx *index.SpecIndex) {
+	if r == nil {
+		return
+	}
+	r.originNode = node
+	r.originIndex = idx
+}
+
+// GetOriginNode returns the node this object was built from (after following any references), or nil if it's not
+// known. Objects built from the same node share the same origin node.
+func (r *Reference) GetOriginNode() *yaml.Node {
+	if r == nil {
+		return nil
+	}
+	return r.originNode
+}
+
+// GetOrigin returns the origin of this object, the absolute location of the document (file or URL) it was
+// read from, and the position it was found at. If the object was located through a $ref into another document,
+// the origin is that document. Returns nil if the origin is not known.
+func (r *Reference) GetOrigin() *index.NodeOrigin {
+	if r == nil || r.originNode == nil {
+		return nil
+	}
+	if r.originIndex == nil {
+		return &index.NodeOrigin{Node: r.originNode, Line: r.originNode.Line, Column: r.originNode.Column}
+	}
+	return r.originIndex.FindNodeOrigin(r.originNode)
 }
 
 func (r *Reference) GetReference() string {
 	return r.Reference
 }
 
+// GetReferenceSummary returns the 'summary' sibling of the $ref used to locate this object, if one was set.
+func (r *Reference) GetReferenceSummary() NodeReference[string] {
+	if r == nil {
+		return NodeReference[string]{}
+	}
+	return r.ReferenceSummary
+}
+
+// GetReferenceDescription returns the 'description' sibling of the $ref used to locate this object, if one was set.
+func (r *Reference) GetReferenceDescription() NodeReference[string] {
+	if r == nil {
+		return NodeReference[string]{}
+	}
+	return r.ReferenceDescription
+}
+
+// SetReferenceSiblings will set the 'summary' and 'description' siblings of the $ref used to locate this object.
+func (r *Reference) SetReferenceSiblings(summary, description NodeReference[string]) {
+	r.ReferenceSummary = summary
+	r.ReferenceDescription = description
+}
+
+// OverrideSummary returns the $ref sibling summary if one was set, otherwise the supplied summary is returned.
+func (r *Reference) OverrideSummary(summary string) string {
+	if s := r.GetReferenceSummary(); !s.IsEmpty() {
+		return s.Value
+	}
+	return summary
+}
+
+// OverrideDescription returns the $ref sibling description if one was set, otherwise the supplied description
+// is returned.
+func (r *Reference) OverrideDescription(description string) string {
+	if d := r.GetReferenceDescription(); !d.IsEmpty() {
+		return d.Value
+	}
+	return description
+}
+
 func (r *Reference) IsReference() bool {
 	return r.Reference != ""
 }
@@ -33,6 +165,26 @@ type IsReferenced interface {
 	SetReference(string)
 }
 
+// HasOrigin is implemented by any object that can report the document and position it originated from.
+type HasOrigin interface {
+	SetOrigin(node *yaml.Node, idx *index.SpecIndex)
+	GetOrigin() *index.NodeOrigin
+}
+
+// HasResolutionChain is implemented by any object that can report the chain of $ref hops made to resolve it.
+type HasResolutionChain interface {
+	SetReferenceNode(node *yaml.Node, idx *index.SpecIndex)
+	GetResolutionChain() []*ReferenceHop
+}
+
+// HasReferenceSiblings is implemented by any low-level object that can carry the 'summary' and 'description'
+// siblings that OpenAPI 3.1 allows next to a $ref.
+type HasReferenceSiblings interface {
+	GetReferenceSummary() NodeReference[string]
+	GetReferenceDescription() NodeReference[string]
+	SetReferenceSiblings(summary, description NodeReference[string])
+}
+
 // Buildable is an interface for any struct that can be 'built out'. This means that a struct can accept
 // a root node and a reference to the index that carries data about any references used.
 //
@@ -42,7 +194,8 @@ type Buildable[T any] interface {
 	*T
 }
 
-// HasValueNode is implemented by NodeReference and ValueReference to return the yaml.Node backing the value.
+// HasValueNode is implemented by NodeReference, ValueReference and every low-level object to return the yaml.Node
+// backing the value.
 type HasValueNode[T any] interface {
 	GetValueNode() *yaml.Node
 	*T
@@ -88,11 +241,21 @@ type HasValueUnTyped interface {
 	GetValueNode() *yaml.Node
 }
 
-// HasKeyNode is implemented by KeyReference to return the yaml.Node backing the key.
+// HasKeyNode is implemented by KeyReference and every low-level object to return the yaml.Node backing the key.
 type HasKeyNode interface {
 	GetKeyNode() *yaml.Node
 }
 
+// HasRootNode is implemented by every low-level object to return the yaml.Node it was built from.
+type HasRootNode interface {
+	GetRootNode() *yaml.Node
+}
+
+// HasIndex is implemented by every low-level object to return the index.SpecIndex used to build it.
+type HasIndex interface {
+	GetIndex() *index.SpecIndex
+}
+
 // NodeReference is a low-level container for holding a Value of type T, as well as references to
 // a key yaml.Node that points to the key node that contains the value node, and the value node that contains
 // the actual value.
@@ -112,6 +275,10 @@ type NodeReference[T any] struct {
 
 	// If HasReference is true, then Reference contains the original $ref value.
 	Reference string
+
+	// Error is set when the value failed to build, and the build carried on anyway. Value holds as much of the
+	// value as could be built. Only set when build errors are tolerated (see index.SpecIndexConfig).
+	Error error
 }
 
 // KeyReference is a low-level container for key nodes holding a Value of type T. A KeyNode is a pointer to the
@@ -140,6 +307,10 @@ type ValueReference[T any] struct {
 
 	// If HasReference is true, then Reference contains the original $ref value.
 	Reference string
+
+	// Error is set when the value failed to build, and the build carried on anyway. Value holds as much of the
+	// value as could be built. Only set when build errors are tolerated (see index.SpecIndexConfig).
+	Error error
 }
 
 // IsEmpty will return true if this reference has no key or value nodes assigned (it's been ignored)
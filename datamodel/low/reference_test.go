@@ -749,3 +749,31 @@ func TestKeyReference_GetKeyNode(t *testing.T) {
 	assert.Equal(t, 3, nr.GetKeyNode().Line)
 	assert.Equal(t, "pizza", nr.GetKeyNode().Value)
 }
+
+func TestReference_GetOrigin(t *testing.T) {
+	var r *Reference
+	assert.Nil(t, r.GetOrigin())
+	assert.Nil(t, r.GetOriginNode())
+	r.SetOrigin(nil, nil) // no panic.
+
+	r = new(Reference)
+	assert.Nil(t, r.GetOrigin())
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte("pizza:\n  description: hot"), &node)
+	r.SetOrigin(node.Content[0].Content[1], nil)
+	assert.Equal(t, node.Content[0].Content[1], r.GetOriginNode())
+	origin := r.GetOrigin()
+	assert.Equal(t, 2, origin.Line)
+	assert.Equal(t, 3, origin.Column)
+	assert.Empty(t, origin.AbsoluteLocation)
+
+	c := index.CreateClosedAPIIndexConfig()
+	c.SpecAbsolutePath = "https://pb33f.io/openapi.yaml"
+	idx := index.NewSpecIndexWithConfig(&node, c)
+	r.SetOrigin(node.Content[0].Content[1], idx)
+	origin = r.GetOrigin()
+	assert.Equal(t, "https://pb33f.io/openapi.yaml", origin.AbsoluteLocation)
+	assert.True(t, origin.RootDocument)
+	assert.Equal(t, 2, origin.Line)
+}
@@ -4,14 +4,12 @@
 package v2
 
 import (
-	"crypto/sha256"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // ParameterDefinitions is a low-level representation of a Swagger / OpenAPI 2 Parameters Definitions object.
@@ -21,6 +19,7 @@ import (
 //   - https://swagger.io/specification/v2/#parametersDefinitionsObject
 type ParameterDefinitions struct {
 	Definitions map[low.KeyReference[string]]low.ValueReference[*Parameter]
+	low.Nodes
 }
 
 // ResponsesDefinitions is a low-level representation of a Swagger / OpenAPI 2 Responses Definitions object.
@@ -30,6 +29,7 @@ type ParameterDefinitions struct {
 //   - https://swagger.io/specification/v2/#responsesDefinitionsObject
 type ResponsesDefinitions struct {
 	Definitions map[low.KeyReference[string]]low.ValueReference[*Response]
+	low.Nodes
 }
 
 // SecurityDefinitions is a low-level representation of a Swagger / OpenAPI 2 Security Definitions object.
@@ -39,6 +39,7 @@ type ResponsesDefinitions struct {
 //   - https://swagger.io/specification/v2/#securityDefinitionsObject
 type SecurityDefinitions struct {
 	Definitions map[low.KeyReference[string]]low.ValueReference[*SecurityScheme]
+	low.Nodes
 }
 
 // Definitions is a low-level representation of a Swagger / OpenAPI 2 Definitions object
@@ -48,6 +49,7 @@ type SecurityDefinitions struct {
 //   - https://swagger.io/specification/v2/#definitionsObject
 type Definitions struct {
 	Schemas map[low.KeyReference[string]]low.ValueReference[*base.SchemaProxy]
+	low.Nodes
 }
 
 // FindSchema will attempt to locate a base.SchemaProxy instance using a name.
@@ -74,6 +76,7 @@ func (s *SecurityDefinitions) FindSecurityDefinition(securityDef string) *low.Va
 func (d *Definitions) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	d.SetNodes(root, idx)
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*base.SchemaProxy])
 	var defLabel *yaml.Node
@@ -91,6 +94,9 @@ func (d *Definitions) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			if err != nil {
 				e <- err
 			}
+			if obj != nil {
+				low.SetKeyNode(obj, label)
+			}
 			r <- definitionResult[*base.SchemaProxy]{k: label, v: low.ValueReference[*base.SchemaProxy]{
 				Value: obj, ValueNode: value, Reference: rv,
 			}}
@@ -127,13 +133,14 @@ func (d *Definitions) Hash() [32]byte {
 	}
 	sort.Strings(keys)
 	for k := range keys {
-		f = append(f, low.GenerateHashString(d.FindSchema(keys[k]).Value))
+		f = append(f, low.HashField("schemas", low.GenerateHashString(d.FindSchema(keys[k]).Value)))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
 
 // Build will extract all ParameterDefinitions into Parameter instances.
 func (pd *ParameterDefinitions) Build(root *yaml.Node, idx *index.SpecIndex) error {
+	pd.SetNodes(root, idx)
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*Parameter])
 	var defLabel *yaml.Node
@@ -151,6 +158,9 @@ func (pd *ParameterDefinitions) Build(root *yaml.Node, idx *index.SpecIndex) err
 			if err != nil {
 				e <- err
 			}
+			if obj != nil {
+				low.SetKeyNode(obj, label)
+			}
 			r <- definitionResult[*Parameter]{k: label, v: low.ValueReference[*Parameter]{Value: obj,
 				ValueNode: value, Reference: rv}}
 		}
@@ -183,6 +193,7 @@ type definitionResult[T any] struct {
 
 // Build will extract all ResponsesDefinitions into Response instances.
 func (r *ResponsesDefinitions) Build(root *yaml.Node, idx *index.SpecIndex) error {
+	r.SetNodes(root, idx)
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*Response])
 	var defLabel *yaml.Node
@@ -200,6 +211,9 @@ func (r *ResponsesDefinitions) Build(root *yaml.Node, idx *index.SpecIndex) erro
 			if err != nil {
 				e <- err
 			}
+			if obj != nil {
+				low.SetKeyNode(obj, label)
+			}
 			r <- definitionResult[*Response]{k: label, v: low.ValueReference[*Response]{Value: obj,
 				ValueNode: value, Reference: rv}}
 		}
@@ -226,6 +240,7 @@ func (r *ResponsesDefinitions) Build(root *yaml.Node, idx *index.SpecIndex) erro
 
 // Build will extract all SecurityDefinitions into SecurityScheme instances.
 func (s *SecurityDefinitions) Build(root *yaml.Node, idx *index.SpecIndex) error {
+	s.SetNodes(root, idx)
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*SecurityScheme])
 	var defLabel *yaml.Node
@@ -243,6 +258,9 @@ func (s *SecurityDefinitions) Build(root *yaml.Node, idx *index.SpecIndex) error
 			if err != nil {
 				e <- err
 			}
+			if obj != nil {
+				low.SetKeyNode(obj, label)
+			}
 			r <- definitionResult[*SecurityScheme]{k: label, v: low.ValueReference[*SecurityScheme]{
 				Value: obj, ValueNode: value, Reference: rv,
 			}}
@@ -64,7 +64,7 @@ func TestDefinitions_Hash(t *testing.T) {
 	assert.NoError(t, err)
 
 	_ = n.Build(idxNode.Content[0], idx)
-	assert.Equal(t, "26d23786e6873e1a337f8e9be85f7de1490e4ff6cd303c3b15e593a25a6a149d",
+	assert.Equal(t, "bce171118398e2354c59bb5b1cee7f9614c57e1e1a426d97ba3703a520167c61",
 		low.GenerateHashString(&n))
 
 }
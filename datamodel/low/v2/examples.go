@@ -4,14 +4,12 @@
 package v2
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Examples represents a low-level Swagger / OpenAPI 2 Example object.
@@ -19,6 +17,7 @@ import (
 //   - https://swagger.io/specification/v2/#exampleObject
 type Examples struct {
 	Values map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // FindExample attempts to locate an example value, using a key label.
@@ -27,9 +26,10 @@ func (e *Examples) FindExample(name string) *low.ValueReference[any] {
 }
 
 // Build will extract all examples and will attempt to unmarshal content into a map or slice based on type.
-func (e *Examples) Build(root *yaml.Node, _ *index.SpecIndex) error {
+func (e *Examples) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	e.SetNodes(root, idx)
 	var keyNode, currNode *yaml.Node
 	var err error
 	e.Values = make(map[low.KeyReference[string]]low.ValueReference[any])
@@ -89,7 +89,7 @@ func (e *Examples) Hash() [32]byte {
 	}
 	sort.Strings(keys)
 	for k := range keys {
-		f = append(f, fmt.Sprintf("%v", e.FindExample(keys[k]).Value))
+		f = append(f, low.HashField("values", fmt.Sprintf("%v", e.FindExample(keys[k]).Value)))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
@@ -11,7 +11,6 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Header Represents a low-level Swagger / OpenAPI 2 Header object.
@@ -38,6 +37,7 @@ type Header struct {
 	Enum             low.NodeReference[[]low.ValueReference[any]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // FindExtension will attempt to locate an extension value using a name lookup.
@@ -54,6 +54,7 @@ func (h *Header) GetExtensions() map[low.KeyReference[string]]low.ValueReference
 func (h *Header) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	h.SetNodes(root, idx)
 	h.Extensions = low.ExtractExtensions(root)
 	items, err := low.ExtractObject[*Items](ItemsLabel, root, idx)
 	if err != nil {
@@ -101,55 +102,48 @@ func (h *Header) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (h *Header) Hash() [32]byte {
 	var f []string
 	if h.Description.Value != "" {
-		f = append(f, h.Description.Value)
+		f = append(f, low.HashField("description", h.Description.Value))
 	}
 	if h.Type.Value != "" {
-		f = append(f, h.Type.Value)
+		f = append(f, low.HashField("type", h.Type.Value))
 	}
 	if h.Format.Value != "" {
-		f = append(f, h.Format.Value)
+		f = append(f, low.HashField("format", h.Format.Value))
 	}
 	if h.CollectionFormat.Value != "" {
-		f = append(f, h.CollectionFormat.Value)
+		f = append(f, low.HashField("collectionFormat", h.CollectionFormat.Value))
 	}
 	if h.Default.Value != "" {
-		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(h.Default.Value)))))
+		f = append(f, low.HashField("default", fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(h.Default.Value))))))
 	}
-	f = append(f, fmt.Sprint(h.Maximum.Value))
-	f = append(f, fmt.Sprint(h.Minimum.Value))
-	f = append(f, fmt.Sprint(h.ExclusiveMinimum.Value))
-	f = append(f, fmt.Sprint(h.ExclusiveMaximum.Value))
-	f = append(f, fmt.Sprint(h.MinLength.Value))
-	f = append(f, fmt.Sprint(h.MaxLength.Value))
-	f = append(f, fmt.Sprint(h.MinItems.Value))
-	f = append(f, fmt.Sprint(h.MaxItems.Value))
-	f = append(f, fmt.Sprint(h.MultipleOf.Value))
-	f = append(f, fmt.Sprint(h.UniqueItems.Value))
+	f = append(f, low.HashField("maximum", fmt.Sprint(h.Maximum.Value)))
+	f = append(f, low.HashField("minimum", fmt.Sprint(h.Minimum.Value)))
+	f = append(f, low.HashField("exclusiveMinimum", fmt.Sprint(h.ExclusiveMinimum.Value)))
+	f = append(f, low.HashField("exclusiveMaximum", fmt.Sprint(h.ExclusiveMaximum.Value)))
+	f = append(f, low.HashField("minLength", fmt.Sprint(h.MinLength.Value)))
+	f = append(f, low.HashField("maxLength", fmt.Sprint(h.MaxLength.Value)))
+	f = append(f, low.HashField("minItems", fmt.Sprint(h.MinItems.Value)))
+	f = append(f, low.HashField("maxItems", fmt.Sprint(h.MaxItems.Value)))
+	f = append(f, low.HashField("multipleOf", fmt.Sprint(h.MultipleOf.Value)))
+	f = append(f, low.HashField("uniqueItems", fmt.Sprint(h.UniqueItems.Value)))
 	if h.Pattern.Value != "" {
-		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(h.Pattern.Value)))))
+		f = append(f, low.HashField("pattern", fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(h.Pattern.Value))))))
 	}
 
-	keys := make([]string, len(h.Extensions))
-	z := 0
-	for k := range h.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(h.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashExtensions(h.Extensions)...)
 
-	keys = make([]string, len(h.Enum.Value))
-	z = 0
+	keys := make([]string, len(h.Enum.Value))
+	z := 0
 	for k := range h.Enum.Value {
 		keys[z] = fmt.Sprint(h.Enum.Value[k].Value)
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("enum", keys)...)
 	if h.Items.Value != nil {
-		f = append(f, low.GenerateHashString(h.Items.Value))
+		f = append(f, low.HashField("items", low.GenerateHashString(h.Items.Value)))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
 
 // Getter methods to satisfy SwaggerHeader interface.
@@ -11,7 +11,6 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Items is a low-level representation of a Swagger / OpenAPI 2 Items object.
@@ -38,6 +37,7 @@ type Items struct {
 	Enum             low.NodeReference[[]low.ValueReference[any]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // FindExtension will attempt to locate an extension value using a name lookup.
@@ -54,29 +54,29 @@ func (i *Items) GetExtensions() map[low.KeyReference[string]]low.ValueReference[
 func (i *Items) Hash() [32]byte {
 	var f []string
 	if i.Type.Value != "" {
-		f = append(f, i.Type.Value)
+		f = append(f, low.HashField("type", i.Type.Value))
 	}
 	if i.Format.Value != "" {
-		f = append(f, i.Format.Value)
+		f = append(f, low.HashField("format", i.Format.Value))
 	}
 	if i.CollectionFormat.Value != "" {
-		f = append(f, i.CollectionFormat.Value)
+		f = append(f, low.HashField("collectionFormat", i.CollectionFormat.Value))
 	}
 	if i.Default.Value != "" {
-		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(i.Default.Value)))))
+		f = append(f, low.HashField("default", fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(i.Default.Value))))))
 	}
-	f = append(f, fmt.Sprint(i.Maximum.Value))
-	f = append(f, fmt.Sprint(i.Minimum.Value))
-	f = append(f, fmt.Sprint(i.ExclusiveMinimum.Value))
-	f = append(f, fmt.Sprint(i.ExclusiveMaximum.Value))
-	f = append(f, fmt.Sprint(i.MinLength.Value))
-	f = append(f, fmt.Sprint(i.MaxLength.Value))
-	f = append(f, fmt.Sprint(i.MinItems.Value))
-	f = append(f, fmt.Sprint(i.MaxItems.Value))
-	f = append(f, fmt.Sprint(i.MultipleOf.Value))
-	f = append(f, fmt.Sprint(i.UniqueItems.Value))
+	f = append(f, low.HashField("maximum", fmt.Sprint(i.Maximum.Value)))
+	f = append(f, low.HashField("minimum", fmt.Sprint(i.Minimum.Value)))
+	f = append(f, low.HashField("exclusiveMinimum", fmt.Sprint(i.ExclusiveMinimum.Value)))
+	f = append(f, low.HashField("exclusiveMaximum", fmt.Sprint(i.ExclusiveMaximum.Value)))
+	f = append(f, low.HashField("minLength", fmt.Sprint(i.MinLength.Value)))
+	f = append(f, low.HashField("maxLength", fmt.Sprint(i.MaxLength.Value)))
+	f = append(f, low.HashField("minItems", fmt.Sprint(i.MinItems.Value)))
+	f = append(f, low.HashField("maxItems", fmt.Sprint(i.MaxItems.Value)))
+	f = append(f, low.HashField("multipleOf", fmt.Sprint(i.MultipleOf.Value)))
+	f = append(f, low.HashField("uniqueItems", fmt.Sprint(i.UniqueItems.Value)))
 	if i.Pattern.Value != "" {
-		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(i.Pattern.Value)))))
+		f = append(f, low.HashField("pattern", fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(i.Pattern.Value))))))
 	}
 	keys := make([]string, len(i.Enum.Value))
 	z := 0
@@ -85,26 +85,20 @@ func (i *Items) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("enum", keys)...)
 
 	if i.Items.Value != nil {
-		f = append(f, low.GenerateHashString(i.Items.Value))
+		f = append(f, low.HashField("items", low.GenerateHashString(i.Items.Value)))
 	}
-	keys = make([]string, len(i.Extensions))
-	z = 0
-	for k := range i.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(i.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(i.Extensions)...)
+	return low.HashStrings(f)
 }
 
 // Build will build out items and default value.
 func (i *Items) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	i.SetNodes(root, idx)
 	i.Extensions = low.ExtractExtensions(root)
 	items, iErr := low.ExtractObject[*Items](ItemsLabel, root, idx)
 	if iErr != nil {
@@ -4,7 +4,6 @@
 package v2
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
@@ -12,7 +11,6 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Operation represents a low-level Swagger / OpenAPI 2 Operation object.
@@ -33,12 +31,14 @@ type Operation struct {
 	Deprecated   low.NodeReference[bool]
 	Security     low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]
 	Extensions   map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // Build will extract external docs, extensions, parameters, responses and security requirements.
 func (o *Operation) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	o.SetNodes(root, idx)
 	o.Extensions = low.ExtractExtensions(root)
 
 	// extract externalDocs
@@ -80,6 +80,15 @@ func (o *Operation) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			ValueNode: svn,
 		}
 	}
+
+	// if security is set, but no requirements are defined (security is removed for the operation).
+	if sln != nil && len(svn.Content) == 0 && sec == nil {
+		o.Security = low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]{
+			Value:     []low.ValueReference[*base.SecurityRequirement]{}, // empty
+			KeyNode:   sln,
+			ValueNode: svn,
+		}
+	}
 	return nil
 }
 
@@ -87,25 +96,25 @@ func (o *Operation) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (o *Operation) Hash() [32]byte {
 	var f []string
 	if !o.Summary.IsEmpty() {
-		f = append(f, o.Summary.Value)
+		f = append(f, low.HashField("summary", o.Summary.Value))
 	}
 	if !o.Description.IsEmpty() {
-		f = append(f, o.Description.Value)
+		f = append(f, low.HashField("description", o.Description.Value))
 	}
 	if !o.OperationId.IsEmpty() {
-		f = append(f, o.OperationId.Value)
+		f = append(f, low.HashField("operationId", o.OperationId.Value))
 	}
 	if !o.Summary.IsEmpty() {
-		f = append(f, o.Summary.Value)
+		f = append(f, low.HashField("summary", o.Summary.Value))
 	}
 	if !o.ExternalDocs.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.ExternalDocs.Value))
+		f = append(f, low.HashField("externalDocs", low.GenerateHashString(o.ExternalDocs.Value)))
 	}
 	if !o.Responses.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.Responses.Value))
+		f = append(f, low.HashField("responses", low.GenerateHashString(o.Responses.Value)))
 	}
 	if !o.Deprecated.IsEmpty() {
-		f = append(f, fmt.Sprint(o.Deprecated.Value))
+		f = append(f, low.HashField("deprecated", fmt.Sprint(o.Deprecated.Value)))
 	}
 	var keys []string
 	keys = make([]string, len(o.Tags.Value))
@@ -113,51 +122,44 @@ func (o *Operation) Hash() [32]byte {
 		keys[k] = o.Tags.Value[k].Value
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("tags", keys)...)
 
 	keys = make([]string, len(o.Consumes.Value))
 	for k := range o.Consumes.Value {
 		keys[k] = o.Consumes.Value[k].Value
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("consumes", keys)...)
 
 	keys = make([]string, len(o.Produces.Value))
 	for k := range o.Produces.Value {
 		keys[k] = o.Produces.Value[k].Value
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("produces", keys)...)
 
 	keys = make([]string, len(o.Schemes.Value))
 	for k := range o.Schemes.Value {
 		keys[k] = o.Schemes.Value[k].Value
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("schemes", keys)...)
 
 	keys = make([]string, len(o.Parameters.Value))
 	for k := range o.Parameters.Value {
 		keys[k] = low.GenerateHashString(o.Parameters.Value[k].Value)
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("parameters", keys)...)
 
 	keys = make([]string, len(o.Security.Value))
 	for k := range o.Security.Value {
 		keys[k] = low.GenerateHashString(o.Security.Value[k].Value)
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
-	keys = make([]string, len(o.Extensions))
-	z := 0
-	for k := range o.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(o.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashFields("security", keys)...)
+	f = append(f, low.HashExtensions(o.Extensions)...)
+	return low.HashStrings(f)
 }
 
 // methods to satisfy swagger operations interface
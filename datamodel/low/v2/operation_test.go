@@ -184,3 +184,23 @@ security:
 	assert.Len(t, n.GetSecurity().Value, 1)
 	assert.Len(t, n.GetExtensions(), 1)
 }
+
+func TestOperation_Build_EmptySecurity(t *testing.T) {
+
+	yml := `security: []`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Operation
+	err := low.BuildModel(&idxNode, &n)
+	assert.NoError(t, err)
+
+	err = n.Build(idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.False(t, n.Security.IsEmpty())
+	assert.NotNil(t, n.Security.Value)
+	assert.Len(t, n.Security.Value, 0)
+}
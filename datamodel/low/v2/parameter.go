@@ -12,7 +12,6 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Parameter represents a low-level Swagger / OpenAPI 2 Parameter object.
@@ -81,6 +80,7 @@ type Parameter struct {
 	Enum             low.NodeReference[[]low.ValueReference[any]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // FindExtension attempts to locate a extension value given a name.
@@ -97,6 +97,7 @@ func (p *Parameter) GetExtensions() map[low.KeyReference[string]]low.ValueRefere
 func (p *Parameter) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	p.SetNodes(root, idx)
 	p.Extensions = low.ExtractExtensions(root)
 	sch, sErr := base.ExtractSchema(root, idx)
 	if sErr != nil {
@@ -149,43 +150,43 @@ func (p *Parameter) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (p *Parameter) Hash() [32]byte {
 	var f []string
 	if p.Name.Value != "" {
-		f = append(f, p.Name.Value)
+		f = append(f, low.HashField("name", p.Name.Value))
 	}
 	if p.In.Value != "" {
-		f = append(f, p.In.Value)
+		f = append(f, low.HashField("in", p.In.Value))
 	}
 	if p.Type.Value != "" {
-		f = append(f, p.Type.Value)
+		f = append(f, low.HashField("type", p.Type.Value))
 	}
 	if p.Format.Value != "" {
-		f = append(f, p.Format.Value)
+		f = append(f, low.HashField("format", p.Format.Value))
 	}
 	if p.Description.Value != "" {
-		f = append(f, p.Description.Value)
+		f = append(f, low.HashField("description", p.Description.Value))
 	}
-	f = append(f, fmt.Sprint(p.Required.Value))
-	f = append(f, fmt.Sprint(p.AllowEmptyValue.Value))
+	f = append(f, low.HashField("required", fmt.Sprint(p.Required.Value)))
+	f = append(f, low.HashField("allowEmptyValue", fmt.Sprint(p.AllowEmptyValue.Value)))
 	if p.Schema.Value != nil {
-		f = append(f, low.GenerateHashString(p.Schema.Value.Schema()))
+		f = append(f, low.HashField("schema", low.GenerateHashString(p.Schema.Value.Schema())))
 	}
 	if p.CollectionFormat.Value != "" {
-		f = append(f, p.CollectionFormat.Value)
+		f = append(f, low.HashField("collectionFormat", p.CollectionFormat.Value))
 	}
 	if p.Default.Value != "" {
-		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(p.Default.Value)))))
+		f = append(f, low.HashField("default", fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(p.Default.Value))))))
 	}
-	f = append(f, fmt.Sprint(p.Maximum.Value))
-	f = append(f, fmt.Sprint(p.Minimum.Value))
-	f = append(f, fmt.Sprint(p.ExclusiveMinimum.Value))
-	f = append(f, fmt.Sprint(p.ExclusiveMaximum.Value))
-	f = append(f, fmt.Sprint(p.MinLength.Value))
-	f = append(f, fmt.Sprint(p.MaxLength.Value))
-	f = append(f, fmt.Sprint(p.MinItems.Value))
-	f = append(f, fmt.Sprint(p.MaxItems.Value))
-	f = append(f, fmt.Sprint(p.MultipleOf.Value))
-	f = append(f, fmt.Sprint(p.UniqueItems.Value))
+	f = append(f, low.HashField("maximum", fmt.Sprint(p.Maximum.Value)))
+	f = append(f, low.HashField("minimum", fmt.Sprint(p.Minimum.Value)))
+	f = append(f, low.HashField("exclusiveMinimum", fmt.Sprint(p.ExclusiveMinimum.Value)))
+	f = append(f, low.HashField("exclusiveMaximum", fmt.Sprint(p.ExclusiveMaximum.Value)))
+	f = append(f, low.HashField("minLength", fmt.Sprint(p.MinLength.Value)))
+	f = append(f, low.HashField("maxLength", fmt.Sprint(p.MaxLength.Value)))
+	f = append(f, low.HashField("minItems", fmt.Sprint(p.MinItems.Value)))
+	f = append(f, low.HashField("maxItems", fmt.Sprint(p.MaxItems.Value)))
+	f = append(f, low.HashField("multipleOf", fmt.Sprint(p.MultipleOf.Value)))
+	f = append(f, low.HashField("uniqueItems", fmt.Sprint(p.UniqueItems.Value)))
 	if p.Pattern.Value != "" {
-		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(p.Pattern.Value)))))
+		f = append(f, low.HashField("pattern", fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(p.Pattern.Value))))))
 	}
 
 	keys := make([]string, len(p.Enum.Value))
@@ -195,20 +196,13 @@ func (p *Parameter) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("enum", keys)...)
 
-	keys = make([]string, len(p.Extensions))
-	z = 0
-	for k := range p.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(p.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashExtensions(p.Extensions)...)
 	if p.Items.Value != nil {
-		f = append(f, fmt.Sprintf("%x", p.Items.Value.Hash()))
+		f = append(f, low.HashField("items", fmt.Sprintf("%x", p.Items.Value.Hash())))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
 
 // Getters used by what-changed feature to satisfy the SwaggerParameter interface.
@@ -4,7 +4,6 @@
 package v2
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
@@ -33,6 +32,7 @@ type PathItem struct {
 	Patch      low.NodeReference[*Operation]
 	Parameters low.NodeReference[[]low.ValueReference[*Parameter]]
 	Extensions map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // FindExtension will attempt to locate an extension given a name.
@@ -50,6 +50,7 @@ func (p *PathItem) GetExtensions() map[low.KeyReference[string]]low.ValueReferen
 func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	p.SetNodes(root, idx)
 	p.Extensions = low.ExtractExtensions(root)
 	skip := false
 	var currentNode *yaml.Node
@@ -161,6 +162,8 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		if er != nil {
 			errCh <- er
 		}
+		low.SetOrigin(op.Value, op.ValueNode, idx)
+		low.SetKeyNode(op.Value, op.KeyNode)
 		ch <- true
 	}
 
@@ -220,14 +223,7 @@ func (p *PathItem) Hash() [32]byte {
 		keys[k] = low.GenerateHashString(p.Parameters.Value[k].Value)
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
-	keys = make([]string, len(p.Extensions))
-	z := 0
-	for k := range p.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(p.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashFields("parameters", keys)...)
+	f = append(f, low.HashExtensions(p.Extensions)...)
+	return low.HashStrings(f)
 }
@@ -4,8 +4,6 @@
 package v2
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
@@ -18,6 +16,7 @@ import (
 type Paths struct {
 	PathItems  map[low.KeyReference[string]]low.ValueReference[*PathItem]
 	Extensions map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // GetExtensions returns all Paths extensions and satisfies the low.HasExtensions interface.
@@ -54,6 +53,7 @@ func (p *Paths) FindExtension(ext string) *low.ValueReference[any] {
 func (p *Paths) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	p.SetNodes(root, idx)
 	p.Extensions = low.ExtractExtensions(root)
 	skip := false
 	var currentNode *yaml.Node
@@ -76,6 +76,8 @@ func (p *Paths) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			e <- err
 			return
 		}
+		low.SetOrigin(path, pNode, idx)
+		low.SetKeyNode(path, cNode)
 		b <- pathBuildResult{
 			k: low.KeyReference[string]{
 				Value:   cNode.Value,
@@ -131,15 +133,8 @@ func (p *Paths) Hash() [32]byte {
 	}
 	sort.Strings(l)
 	for k := range l {
-		f = append(f, low.GenerateHashString(keys[l[k]].Value))
+		f = append(f, low.HashField("pathItems", low.HashEntry(l[k], low.GenerateHashString(keys[l[k]].Value))))
 	}
-	ekeys := make([]string, len(p.Extensions))
-	z = 0
-	for k := range p.Extensions {
-		ekeys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(p.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(ekeys)
-	f = append(f, ekeys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(p.Extensions)...)
+	return low.HashStrings(f)
 }
@@ -4,15 +4,11 @@
 package v2
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
-	"strings"
 )
 
 // Response is a representation of a high-level Swagger / OpenAPI 2 Response object, backed by a low-level one.
@@ -25,6 +21,7 @@ type Response struct {
 	Headers     low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Header]]
 	Examples    low.NodeReference[*Examples]
 	Extensions  map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // FindExtension will attempt to locate an extension value given a key to lookup.
@@ -46,6 +43,7 @@ func (r *Response) FindHeader(hType string) *low.ValueReference[*Header] {
 func (r *Response) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	r.SetNodes(root, idx)
 	r.Extensions = low.ExtractExtensions(root)
 	s, err := base.ExtractSchema(root, idx)
 	if err != nil {
@@ -56,11 +54,13 @@ func (r *Response) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	}
 
 	// extract examples
-	examples, expErr := low.ExtractObject[*Examples](ExamplesLabel, root, idx)
-	if expErr != nil {
-		return expErr
+	if !idx.SkipExamples() {
+		examples, expErr := low.ExtractObject[*Examples](ExamplesLabel, root, idx)
+		if expErr != nil {
+			return expErr
+		}
+		r.Examples = examples
 	}
-	r.Examples = examples
 
 	//extract headers
 	headers, lN, kN, err := low.ExtractMap[*Header](HeadersLabel, root, idx)
@@ -81,23 +81,16 @@ func (r *Response) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (r *Response) Hash() [32]byte {
 	var f []string
 	if r.Description.Value != "" {
-		f = append(f, r.Description.Value)
+		f = append(f, low.HashField("description", r.Description.Value))
 	}
 	if !r.Schema.IsEmpty() {
-		f = append(f, low.GenerateHashString(r.Schema.Value))
+		f = append(f, low.HashField("schema", low.GenerateHashString(r.Schema.Value)))
 	}
 	if !r.Examples.IsEmpty() {
 		for k := range r.Examples.Value.Values {
-			f = append(f, low.GenerateHashString(r.Examples.Value.Values[k].Value))
+			f = append(f, low.HashField("examples", low.GenerateHashString(r.Examples.Value.Values[k].Value)))
 		}
 	}
-	keys := make([]string, len(r.Extensions))
-	z := 0
-	for k := range r.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(r.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(r.Extensions)...)
+	return low.HashStrings(f)
 }
@@ -4,7 +4,6 @@
 package v2
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
@@ -19,6 +18,7 @@ type Responses struct {
 	Codes      map[low.KeyReference[string]]low.ValueReference[*Response]
 	Default    low.NodeReference[*Response]
 	Extensions map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // GetExtensions returns all Responses extensions and satisfies the low.HasExtensions interface.
@@ -30,6 +30,7 @@ func (r *Responses) GetExtensions() map[low.KeyReference[string]]low.ValueRefere
 func (r *Responses) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	r.SetNodes(root, idx)
 	r.Extensions = low.ExtractExtensions(root)
 
 	if utils.IsNodeMap(root) {
@@ -48,7 +49,8 @@ func (r *Responses) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			r.deleteCode(DefaultLabel)
 		}
 	} else {
-		return fmt.Errorf("responses build failed: vn node is not a map! line %d, col %d",
+		return low.NewBuildError(low.ErrorInvalidNode, root, idx, nil,
+			"responses build failed: vn node is not a map! line %d, col %d",
 			root.Line, root.Column)
 	}
 	return nil
@@ -84,6 +86,24 @@ func (r *Responses) deleteCode(code string) {
 	}
 }
 
+// Keys returns the key of every response code, the default response and every extension, in the order they are
+// found in the document. Codes, default and extensions can be mixed together, this allows them to be rendered in
+// their original order.
+func (r *Responses) Keys() []low.KeyReference[string] {
+	keys := make([]low.KeyReference[string], 0, len(r.Codes)+len(r.Extensions)+1)
+	for k := range r.Codes {
+		keys = append(keys, k)
+	}
+	if !r.Default.IsEmpty() {
+		keys = append(keys, low.KeyReference[string]{Value: DefaultLabel, KeyNode: r.Default.KeyNode})
+	}
+	for k := range r.Extensions {
+		keys = append(keys, k)
+	}
+	low.SortKeysByPosition(keys)
+	return keys
+}
+
 // FindResponseByCode will attempt to locate a Response instance using an HTTP response code string.
 func (r *Responses) FindResponseByCode(code string) *low.ValueReference[*Response] {
 	return low.FindItemInMap[*Response](code, r.Codes)
@@ -103,18 +123,11 @@ func (r *Responses) Hash() [32]byte {
 	}
 	sort.Strings(keys)
 	for k := range keys {
-		f = append(f, fmt.Sprintf("%s-%s", keys[k], low.GenerateHashString(cmap[keys[k]])))
+		f = append(f, low.HashField("codes", fmt.Sprintf("%s-%s", keys[k], low.GenerateHashString(cmap[keys[k]]))))
 	}
 	if !r.Default.IsEmpty() {
-		f = append(f, low.GenerateHashString(r.Default.Value))
-	}
-	keys = make([]string, len(r.Extensions))
-	z = 0
-	for k := range r.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(r.Extensions[k].Value))))
-		z++
+		f = append(f, low.HashField("default", low.GenerateHashString(r.Default.Value)))
 	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(r.Extensions)...)
+	return low.HashStrings(f)
 }
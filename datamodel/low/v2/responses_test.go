@@ -117,3 +117,28 @@ x-tea: warm`
 	assert.Len(t, n.GetExtensions(), 1)
 
 }
+
+func TestResponses_Keys(t *testing.T) {
+
+	yml := `"404":
+  description: nope
+x-middle: 1
+default:
+  description: whatever
+"200":
+  description: ok`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Responses
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	var keys []string
+	for _, k := range n.Keys() {
+		keys = append(keys, k.Value)
+	}
+	assert.Equal(t, []string{"404", "x-middle", "default", "200"}, keys)
+}
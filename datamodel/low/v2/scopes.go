@@ -4,7 +4,6 @@
 package v2
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
@@ -21,6 +20,7 @@ import (
 type Scopes struct {
 	Values     map[low.KeyReference[string]]low.ValueReference[string]
 	Extensions map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // GetExtensions returns all Scopes extensions and satisfies the low.HasExtensions interface.
@@ -37,21 +37,21 @@ func (s *Scopes) FindScope(scope string) *low.ValueReference[string] {
 func (s *Scopes) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	s.SetNodes(root, idx)
 	s.Extensions = low.ExtractExtensions(root)
 	valueMap := make(map[low.KeyReference[string]]low.ValueReference[string])
 	if utils.IsNodeMap(root) {
-		for k := range root.Content {
-			if k%2 == 0 {
-				if strings.Contains(root.Content[k].Value, "x-") {
-					continue
-				}
-				valueMap[low.KeyReference[string]{
-					Value:   root.Content[k].Value,
-					KeyNode: root.Content[k],
-				}] = low.ValueReference[string]{
-					Value:     root.Content[k+1].Value,
-					ValueNode: root.Content[k+1],
-				}
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			keyNode, valueNode := root.Content[i], utils.NodeAlias(root.Content[i+1])
+			if strings.HasPrefix(keyNode.Value, "x-") {
+				continue // an extension, extracted along with its value above.
+			}
+			valueMap[low.KeyReference[string]{
+				Value:   keyNode.Value,
+				KeyNode: keyNode,
+			}] = low.ValueReference[string]{
+				Value:     valueNode.Value,
+				ValueNode: valueNode,
 			}
 		}
 		s.Values = valueMap
@@ -59,6 +59,20 @@ func (s *Scopes) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	return nil
 }
 
+// Keys returns the key of every scope and extension, in the order they are found in the document. Scopes and
+// extensions can be mixed together, this allows them to be rendered in their original order.
+func (s *Scopes) Keys() []low.KeyReference[string] {
+	keys := make([]low.KeyReference[string], 0, len(s.Values)+len(s.Extensions))
+	for k := range s.Values {
+		keys = append(keys, k)
+	}
+	for k := range s.Extensions {
+		keys = append(keys, k)
+	}
+	low.SortKeysByPosition(keys)
+	return keys
+}
+
 // Hash will return a consistent SHA256 Hash of the Scopes object
 func (s *Scopes) Hash() [32]byte {
 	var f []string
@@ -72,15 +86,8 @@ func (s *Scopes) Hash() [32]byte {
 	}
 	sort.Strings(keys)
 	for k := range keys {
-		f = append(f, fmt.Sprintf("%s-%s", keys[k], vals[keys[k]].Value))
+		f = append(f, low.HashField("values", fmt.Sprintf("%s-%s", keys[k], vals[keys[k]].Value)))
 	}
-	keys = make([]string, len(s.Extensions))
-	z = 0
-	for k := range s.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(s.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(s.Extensions)...)
+	return low.HashStrings(f)
 }
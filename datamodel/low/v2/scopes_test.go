@@ -42,3 +42,37 @@ burgers: chips`
 	assert.Len(t, n.GetExtensions(), 1)
 
 }
+
+func TestScopes_Build_Extensions(t *testing.T) {
+
+	yml := `x-men: needs a reboot or a refresh
+read:x-rays: see through things
+write:x-rays: edit x-rays
+x-order: 1
+X-shouting: loud`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Scopes
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	// scopes containing 'x-' are not extensions, only keys starting with 'x-' are.
+	assert.Len(t, n.Values, 3)
+	assert.Equal(t, "see through things", n.FindScope("read:x-rays").Value)
+	assert.Equal(t, 2, n.FindScope("read:x-rays").ValueNode.Line)
+	assert.Equal(t, "edit x-rays", n.FindScope("write:x-rays").Value)
+	assert.Equal(t, "loud", n.FindScope("X-shouting").Value)
+
+	assert.Len(t, n.Extensions, 2)
+	assert.Equal(t, "needs a reboot or a refresh", low.FindItemInMap[any]("x-men", n.Extensions).Value)
+	assert.Equal(t, int64(1), low.FindItemInMap[any]("x-order", n.Extensions).Value)
+
+	var keys []string
+	for _, k := range n.Keys() {
+		keys = append(keys, k.Value)
+	}
+	assert.Equal(t, []string{"x-men", "read:x-rays", "write:x-rays", "x-order", "X-shouting"}, keys)
+}
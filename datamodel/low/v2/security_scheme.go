@@ -4,14 +4,10 @@
 package v2
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
-	"strings"
 )
 
 // SecurityScheme is a low-level representation of a Swagger / OpenAPI 2 SecurityScheme object.
@@ -30,6 +26,7 @@ type SecurityScheme struct {
 	TokenUrl         low.NodeReference[string]
 	Scopes           low.NodeReference[*Scopes]
 	Extensions       map[low.KeyReference[string]]low.ValueReference[any]
+	low.Nodes
 }
 
 // GetExtensions returns all SecurityScheme extensions and satisfies the low.HasExtensions interface.
@@ -41,6 +38,7 @@ func (ss *SecurityScheme) GetExtensions() map[low.KeyReference[string]]low.Value
 func (ss *SecurityScheme) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
+	ss.SetNodes(root, idx)
 	ss.Extensions = low.ExtractExtensions(root)
 
 	scopes, sErr := low.ExtractObject[*Scopes](ScopesLabel, root, idx)
@@ -55,36 +53,29 @@ func (ss *SecurityScheme) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (ss *SecurityScheme) Hash() [32]byte {
 	var f []string
 	if !ss.Type.IsEmpty() {
-		f = append(f, ss.Type.Value)
+		f = append(f, low.HashField("type", ss.Type.Value))
 	}
 	if !ss.Description.IsEmpty() {
-		f = append(f, ss.Description.Value)
+		f = append(f, low.HashField("description", ss.Description.Value))
 	}
 	if !ss.Name.IsEmpty() {
-		f = append(f, ss.Name.Value)
+		f = append(f, low.HashField("name", ss.Name.Value))
 	}
 	if !ss.In.IsEmpty() {
-		f = append(f, ss.In.Value)
+		f = append(f, low.HashField("in", ss.In.Value))
 	}
 	if !ss.Flow.IsEmpty() {
-		f = append(f, ss.Flow.Value)
+		f = append(f, low.HashField("flow", ss.Flow.Value))
 	}
 	if !ss.AuthorizationUrl.IsEmpty() {
-		f = append(f, ss.AuthorizationUrl.Value)
+		f = append(f, low.HashField("authorizationUrl", ss.AuthorizationUrl.Value))
 	}
 	if !ss.TokenUrl.IsEmpty() {
-		f = append(f, ss.TokenUrl.Value)
+		f = append(f, low.HashField("tokenUrl", ss.TokenUrl.Value))
 	}
 	if !ss.Scopes.IsEmpty() {
-		f = append(f, low.GenerateHashString(ss.Scopes.Value))
+		f = append(f, low.HashField("scopes", low.GenerateHashString(ss.Scopes.Value)))
 	}
-	keys := make([]string, len(ss.Extensions))
-	z := 0
-	for k := range ss.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(ss.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(ss.Extensions)...)
+	return low.HashStrings(f)
 }
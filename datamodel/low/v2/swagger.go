@@ -121,6 +121,26 @@ func (s *Swagger) GetExtensions() map[low.KeyReference[string]]low.ValueReferenc
 	return s.Extensions
 }
 
+// GetKeyNode always returns nil, a Swagger document is not held by a key. Satisfies the low.HasKeyNode interface.
+func (s *Swagger) GetKeyNode() *yaml.Node {
+	return nil
+}
+
+// GetRootNode returns the root mapping node of the Swagger document, or nil if there is no index.
+func (s *Swagger) GetRootNode() *yaml.Node {
+	return low.DocumentRootNode(s.Index)
+}
+
+// GetValueNode returns the root mapping node of the Swagger document, the same as GetRootNode.
+func (s *Swagger) GetValueNode() *yaml.Node {
+	return s.GetRootNode()
+}
+
+// GetIndex returns the index created for the Swagger document.
+func (s *Swagger) GetIndex() *index.SpecIndex {
+	return s.Index
+}
+
 // CreateDocumentFromConfig will create a new Swagger document from the provided SpecInfo and DocumentConfiguration.
 func CreateDocumentFromConfig(info *datamodel.SpecInfo,
 	configuration *datamodel.DocumentConfiguration) (*Swagger, []error) {
@@ -137,19 +157,49 @@ func CreateDocument(info *datamodel.SpecInfo) (*Swagger, []error) {
 	})
 }
 
+// swaggerKeys are the top level keys a Swagger document can have.
+var swaggerKeys = []string{"swagger", base.InfoLabel, "host", "basePath", "schemes", "consumes", "produces",
+	PathsLabel, DefinitionsLabel, ParametersLabel, ResponsesLabel, SecurityDefinitionsLabel, SecurityLabel,
+	base.TagsLabel, base.ExternalDocsLabel}
+
 func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Swagger, []error) {
 	doc := Swagger{Swagger: low.ValueReference[string]{Value: info.Version, ValueNode: info.RootNode}}
 	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
 
+	localFS := config.LocalFS
+	if config.Files != nil {
+		localFS = index.NewVirtualFS(config.Files)
+	}
+
 	// build an index
 	idx := index.NewSpecIndexWithConfig(info.RootNode, &index.SpecIndexConfig{
-		BaseURL:           config.BaseURL,
-		RemoteURLHandler:  config.RemoteURLHandler,
-		AllowRemoteLookup: config.AllowRemoteReferences,
-		AllowFileLookup:   config.AllowFileReferences,
+		BaseURL:                    config.BaseURL,
+		RemoteURLHandler:           config.RemoteURLHandler,
+		RemoteHTTPClient:           config.RemoteHTTPClient,
+		RemoteHeaders:              config.RemoteHeaders,
+		RemoteTimeout:              config.RemoteTimeout,
+		RemoteCheckRedirect:        config.RemoteCheckRedirect,
+		RemoteCache:                config.RemoteCache,
+		ParsedDocumentCache:        config.ParsedDocumentCache,
+		MaxConcurrentFetches:       config.MaxConcurrentFetches,
+		Sandbox:                    config.Sandbox,
+		CircularReferencePolicy:    config.CircularReferencePolicy,
+		CircularReferenceStubDepth: config.CircularReferenceStubDepth,
+		SchemeHandlers:             config.SchemeHandlers,
+		LocalFS:                    localFS,
+		SpecAbsolutePath:           config.SpecFilePath,
+		AllowRemoteLookup:          config.AllowRemoteReferences,
+		AllowFileLookup:            config.AllowFileReferences,
+		InternStrings:              config.InternStrings,
+		SkipExamples:               config.SkipExamples,
+		TolerateBuildErrors:        config.TolerateBuildErrors,
+		WarningHandler:             config.WarningHandler,
+		Logger:                     config.Logger,
+		ProgressHandler:            config.ProgressHandler,
 	})
 	doc.Index = idx
 	doc.SpecInfo = info
+	low.WarnUnknownKeys(info.RootNode.Content[0], idx, swaggerKeys)
 
 	var errors []error
 
@@ -157,13 +207,14 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	_ = low.BuildModel(info.RootNode.Content[0], &doc)
 
 	// extract externalDocs
-	extDocs, err := low.ExtractObject[*base.ExternalDoc](base.ExternalDocsLabel, info.RootNode, idx)
-	if err != nil {
-		errors = append(errors, err)
+	if config.BuildSection(base.ExternalDocsLabel) {
+		extDocs, err := low.ExtractObject[*base.ExternalDoc](base.ExternalDocsLabel, info.RootNode, idx)
+		if err != nil {
+			errors = append(errors, err)
+		}
+		doc.ExternalDocs = extDocs
 	}
 
-	doc.ExternalDocs = extDocs
-
 	// create resolver and check for circular references.
 	resolve := resolver.NewResolver(idx)
 	resolvingErrors := resolve.CheckForCircularReferences()
@@ -174,23 +225,30 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		}
 	}
 
-	extractionFuncs := []documentFunction{
-		extractInfo,
-		extractPaths,
-		extractDefinitions,
-		extractParamDefinitions,
-		extractResponsesDefinitions,
-		extractSecurityDefinitions,
-		extractTags,
-		extractSecurity,
+	extractionFuncs := []struct {
+		label string
+		run   documentFunction
+	}{
+		{base.InfoLabel, extractInfo},
+		{PathsLabel, extractPaths},
+		{DefinitionsLabel, extractDefinitions},
+		{ParametersLabel, extractParamDefinitions},
+		{ResponsesLabel, extractResponsesDefinitions},
+		{SecurityDefinitionsLabel, extractSecurityDefinitions},
+		{base.TagsLabel, extractTags},
+		{SecurityLabel, extractSecurity},
 	}
 	doneChan := make(chan bool)
 	errChan := make(chan error)
+	totalExtractions := 0
 	for i := range extractionFuncs {
-		go extractionFuncs[i](info.RootNode.Content[0], &doc, idx, doneChan, errChan)
+		if config.BuildSection(extractionFuncs[i].label) {
+			totalExtractions++
+			go extractionFuncs[i].run(info.RootNode.Content[0], &doc, idx, doneChan, errChan)
+		}
 	}
 	completedExtractions := 0
-	for completedExtractions < len(extractionFuncs) {
+	for completedExtractions < totalExtractions {
 		select {
 		case <-doneChan:
 			completedExtractions++
@@ -199,6 +257,7 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 			errors = append(errors, e)
 		}
 	}
+	errors = append(errors, idx.GetBuildErrors()...) // parts of the document that were built anyway.
 
 	return &doc, errors
 }
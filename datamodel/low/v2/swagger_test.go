@@ -6,6 +6,7 @@ package v2
 import (
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"testing"
@@ -166,6 +167,30 @@ func TestCreateDocument_Paths(t *testing.T) {
 
 }
 
+func TestCreateDocument_Nodes(t *testing.T) {
+	initTest()
+	uploadImage := doc.Paths.Value.FindPath("/pet/{petId}/uploadImage")
+	petStoreAuth := doc.SecurityDefinitions.Value.FindSecurityDefinition("petstore_auth").Value
+	objects := []any{
+		doc, doc.Info.Value, doc.Paths.Value, uploadImage.Value, uploadImage.Value.Post.Value,
+		doc.Definitions.Value, doc.SecurityDefinitions.Value, petStoreAuth, petStoreAuth.Scopes.Value,
+		doc.Responses.Value, doc.Parameters.Value,
+	}
+	for _, obj := range objects {
+		assert.Equal(t, doc.Index, obj.(low.HasIndex).GetIndex(), "%T", obj)
+		assert.NotNil(t, obj.(low.HasRootNode).GetRootNode(), "%T", obj)
+		assert.Implements(t, (*low.HasKeyNode)(nil), obj)
+	}
+
+	assert.Nil(t, doc.GetKeyNode())
+	assert.Equal(t, doc.SpecInfo.RootNode.Content[0], doc.GetRootNode())
+	assert.Equal(t, "/pet/{petId}/uploadImage", uploadImage.Value.GetKeyNode().Value)
+	assert.Equal(t, "post", uploadImage.Value.Post.Value.GetKeyNode().Value)
+	assert.Equal(t, "petstore_auth", petStoreAuth.GetKeyNode().Value)
+	assert.Equal(t, "scopes", petStoreAuth.Scopes.Value.GetKeyNode().Value)
+	assert.Equal(t, "Pet", doc.Definitions.Value.FindSchema("Pet").Value.GetKeyNode().Value)
+}
+
 func TestCreateDocument_Bad(t *testing.T) {
 
 	yml := `swagger:
@@ -4,8 +4,6 @@
 package v3
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/utils"
 	"sort"
 	"strings"
@@ -26,6 +24,7 @@ type Callback struct {
 	Expression low.ValueReference[map[low.KeyReference[string]]low.ValueReference[*PathItem]]
 	Extensions map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // GetExtensions returns all Callback extensions and satisfies the low.HasExtensions interface.
@@ -43,6 +42,7 @@ func (cb *Callback) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	cb.Reference = new(low.Reference)
+	cb.SetNodes(root, idx)
 	cb.Extensions = low.ExtractExtensions(root)
 
 	// handle callback
@@ -90,16 +90,9 @@ func (cb *Callback) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("expression", keys)...)
 
-	keys = make([]string, len(cb.Extensions))
-	z = 0
-	for k := range cb.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(cb.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashExtensions(cb.Extensions)...)
 
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
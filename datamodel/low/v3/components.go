@@ -4,8 +4,6 @@
 package v3
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
@@ -30,8 +28,10 @@ type Components struct {
 	SecuritySchemes low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SecurityScheme]]
 	Links           low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Link]]
 	Callbacks       low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Callback]]
+	PathItems       low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*PathItem]]
 	Extensions      map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // GetExtensions returns all Components extensions and satisfies the low.HasExtensions interface.
@@ -42,27 +42,24 @@ func (co *Components) GetExtensions() map[low.KeyReference[string]]low.ValueRefe
 // Hash will return a consistent SHA256 Hash of the Encoding object
 func (co *Components) Hash() [32]byte {
 	var f []string
-	generateHashForObjectMap(co.Schemas.Value, &f)
-	generateHashForObjectMap(co.Responses.Value, &f)
-	generateHashForObjectMap(co.Parameters.Value, &f)
-	generateHashForObjectMap(co.Examples.Value, &f)
-	generateHashForObjectMap(co.RequestBodies.Value, &f)
-	generateHashForObjectMap(co.Headers.Value, &f)
-	generateHashForObjectMap(co.SecuritySchemes.Value, &f)
-	generateHashForObjectMap(co.Links.Value, &f)
-	generateHashForObjectMap(co.Callbacks.Value, &f)
-	keys := make([]string, len(co.Extensions))
-	z := 0
-	for k := range co.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(co.Extensions[k].Value))))
-		z++
+	generateHashForObjectMap("schemas", co.Schemas.Value, &f)
+	generateHashForObjectMap("responses", co.Responses.Value, &f)
+	generateHashForObjectMap("parameters", co.Parameters.Value, &f)
+	generateHashForObjectMap("examples", co.Examples.Value, &f)
+	generateHashForObjectMap("requestBodies", co.RequestBodies.Value, &f)
+	generateHashForObjectMap("headers", co.Headers.Value, &f)
+	generateHashForObjectMap("securitySchemes", co.SecuritySchemes.Value, &f)
+	generateHashForObjectMap("links", co.Links.Value, &f)
+	generateHashForObjectMap("callbacks", co.Callbacks.Value, &f)
+	if !low.LegacyHashes() {
+		// older versions didn't read path items, so legacy hashes leave them out.
+		generateHashForObjectMap("pathItems", co.PathItems.Value, &f)
 	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(co.Extensions)...)
+	return low.HashStrings(f)
 }
 
-func generateHashForObjectMap[T any](collection map[low.KeyReference[string]]low.ValueReference[T], hash *[]string) {
+func generateHashForObjectMap[T any](name string, collection map[low.KeyReference[string]]low.ValueReference[T], hash *[]string) {
 	if collection == nil {
 		return
 	}
@@ -76,7 +73,7 @@ func generateHashForObjectMap[T any](collection map[low.KeyReference[string]]low
 	}
 	sort.Strings(l)
 	for k := range l {
-		*hash = append(*hash, low.GenerateHashString(keys[l[k]].Value))
+		*hash = append(*hash, low.HashField(name, low.GenerateHashString(keys[l[k]].Value)))
 	}
 }
 
@@ -126,11 +123,28 @@ func (co *Components) FindCallback(callback string) *low.ValueReference[*Callbac
 	return low.FindItemInMap[*Callback](callback, co.Callbacks.Value)
 }
 
+// FindPathItem attempts to locate a PathItem from 'pathItems' with a specific name (OpenAPI 3.1+)
+func (co *Components) FindPathItem(pathItem string) *low.ValueReference[*PathItem] {
+	return low.FindItemInMap[*PathItem](pathItem, co.PathItems.Value)
+}
+
+// componentKeys are the keys Components can have.
+var componentKeys = []string{SchemasLabel, ResponsesLabel, ParametersLabel, base.ExamplesLabel, RequestBodiesLabel,
+	HeadersLabel, SecuritySchemesLabel, LinksLabel, CallbacksLabel, PathItemsLabel}
+
 func (co *Components) Build(root *yaml.Node, idx *index.SpecIndex) error {
+	return co.BuildSelected(root, idx, nil)
+}
+
+// BuildSelected will extract only the components that build returns true for (by label, for example "schemas"),
+// all components are extracted if build is nil.
+func (co *Components) BuildSelected(root *yaml.Node, idx *index.SpecIndex, build func(label string) bool) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	co.Reference = new(low.Reference)
+	co.SetNodes(root, idx)
 	co.Extensions = low.ExtractExtensions(root)
+	low.WarnUnknownKeys(root, idx, componentKeys)
 
 	// build out components asynchronously for speed. There could be some significant weight here.
 	skipChan := make(chan bool)
@@ -144,19 +158,21 @@ func (co *Components) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	securitySchemesChan := make(chan low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SecurityScheme]])
 	linkChan := make(chan low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Link]])
 	callbackChan := make(chan low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Callback]])
+	pathItemChan := make(chan low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*PathItem]])
 
-	go extractComponentValues[*base.SchemaProxy](SchemasLabel, root, skipChan, errorChan, schemaChan, idx)
-	go extractComponentValues[*Parameter](ParametersLabel, root, skipChan, errorChan, paramChan, idx)
-	go extractComponentValues[*Response](ResponsesLabel, root, skipChan, errorChan, responsesChan, idx)
-	go extractComponentValues[*base.Example](base.ExamplesLabel, root, skipChan, errorChan, examplesChan, idx)
-	go extractComponentValues[*RequestBody](RequestBodiesLabel, root, skipChan, errorChan, requestBodiesChan, idx)
-	go extractComponentValues[*Header](HeadersLabel, root, skipChan, errorChan, headersChan, idx)
-	go extractComponentValues[*SecurityScheme](SecuritySchemesLabel, root, skipChan, errorChan, securitySchemesChan, idx)
-	go extractComponentValues[*Link](LinksLabel, root, skipChan, errorChan, linkChan, idx)
-	go extractComponentValues[*Callback](CallbacksLabel, root, skipChan, errorChan, callbackChan, idx)
+	go extractComponentValues[*base.SchemaProxy](SchemasLabel, root, skipChan, errorChan, schemaChan, build, idx)
+	go extractComponentValues[*Parameter](ParametersLabel, root, skipChan, errorChan, paramChan, build, idx)
+	go extractComponentValues[*Response](ResponsesLabel, root, skipChan, errorChan, responsesChan, build, idx)
+	go extractComponentValues[*base.Example](base.ExamplesLabel, root, skipChan, errorChan, examplesChan, build, idx)
+	go extractComponentValues[*RequestBody](RequestBodiesLabel, root, skipChan, errorChan, requestBodiesChan, build, idx)
+	go extractComponentValues[*Header](HeadersLabel, root, skipChan, errorChan, headersChan, build, idx)
+	go extractComponentValues[*SecurityScheme](SecuritySchemesLabel, root, skipChan, errorChan, securitySchemesChan, build, idx)
+	go extractComponentValues[*Link](LinksLabel, root, skipChan, errorChan, linkChan, build, idx)
+	go extractComponentValues[*Callback](CallbacksLabel, root, skipChan, errorChan, callbackChan, build, idx)
+	go extractComponentValues[*PathItem](PathItemsLabel, root, skipChan, errorChan, pathItemChan, build, idx)
 
 	n := 0
-	total := 9
+	total := 10
 
 	for n < total {
 		select {
@@ -191,6 +207,9 @@ func (co *Components) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		case callbacks := <-callbackChan:
 			co.Callbacks = callbacks
 			n++
+		case pathItems := <-pathItemChan:
+			co.PathItems = pathItems
+			n++
 		}
 	}
 	return nil
@@ -202,7 +221,12 @@ type componentBuildResult[T any] struct {
 }
 
 func extractComponentValues[T low.Buildable[N], N any](label string, root *yaml.Node,
-	skip chan bool, errorChan chan<- error, resultChan chan<- low.NodeReference[map[low.KeyReference[string]]low.ValueReference[T]], idx *index.SpecIndex) {
+	skip chan bool, errorChan chan<- error, resultChan chan<- low.NodeReference[map[low.KeyReference[string]]low.ValueReference[T]],
+	build func(label string) bool, idx *index.SpecIndex) {
+	if (build != nil && !build(label)) || (label == base.ExamplesLabel && idx.SkipExamples()) {
+		skip <- true
+		return
+	}
 	_, nodeLabel, nodeValue := utils.FindKeyNodeFullTop(label, root.Content)
 	if nodeValue == nil {
 		skip <- true
@@ -211,7 +235,13 @@ func extractComponentValues[T low.Buildable[N], N any](label string, root *yaml.
 	var currentLabel *yaml.Node
 	componentValues := make(map[low.KeyReference[string]]low.ValueReference[T])
 	if utils.IsNodeArray(nodeValue) {
-		errorChan <- fmt.Errorf("node is array, cannot be used in components: line %d, column %d", nodeValue.Line, nodeValue.Column)
+		err := low.NewBuildError(low.ErrorInvalidNode, nodeValue, idx, nil,
+			"node is array, cannot be used in components: line %d, column %d", nodeValue.Line, nodeValue.Column)
+		if low.TolerateBuildError(idx, err) {
+			skip <- true
+			return
+		}
+		errorChan <- err
 		return
 	}
 
@@ -224,22 +254,34 @@ func extractComponentValues[T low.Buildable[N], N any](label string, root *yaml.
 		// if this is a reference, extract it (although components with references is an antipattern)
 		// If you're building components as references... pls... stop, this code should not need to be here.
 		// TODO: check circular crazy on this. It may explode
-		var err error
+		var err, buildErr error
+		refNode := value
 		if h, _, _ := utils.IsNodeRefValue(value); h && parentLabel != SchemasLabel {
 			value, err = low.LocateRefNode(value, idx)
 		}
 		if err != nil {
-			ec <- err
-			return
+			if !low.TolerateBuildError(idx, err) {
+				ec <- err
+				return
+			}
+			if value == nil {
+				value = low.UnresolvedNode(refNode)
+			}
+			buildErr = err
 		}
 
 		// build.
 		_ = low.BuildModel(value, n)
 		err = n.Build(value, idx)
-		if err != nil {
-			ec <- err
-			return
+		if err != nil && buildErr == nil {
+			if !low.TolerateBuildError(idx, err) {
+				ec <- err
+				return
+			}
+			buildErr = err
 		}
+		low.SetOrigin(n, value, idx)
+		low.SetKeyNode(n, label)
 		c <- componentBuildResult[T]{
 			k: low.KeyReference[string]{
 				KeyNode: label,
@@ -248,6 +290,7 @@ func extractComponentValues[T low.Buildable[N], N any](label string, root *yaml.
 			v: low.ValueReference[T]{
 				Value:     n,
 				ValueNode: value,
+				Error:     buildErr,
 			},
 		}
 	}
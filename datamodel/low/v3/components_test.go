@@ -98,11 +98,32 @@ func TestComponents_Build_Success(t *testing.T) {
 	assert.Equal(t, "eighteen of many",
 		n.FindCallback("eighteen").Value.FindExpression("{raference}").Value.Post.Value.Description.Value)
 
-	assert.Equal(t, "7add1a6c63a354b1a8ffe22552c213fe26d1229beb0b0cbe7c7ca06e63f9a364",
+	assert.Equal(t, "45882c36b5a5dc11a1f91637b9b15d99d184c1e8cdf351888522ac01bc87f660",
 		low.GenerateHashString(&n))
 
 }
 
+func TestComponents_BuildSelected(t *testing.T) {
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(testComponentsYaml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, &index.SpecIndexConfig{SkipExamples: true})
+
+	var n Components
+	err := n.BuildSelected(idxNode.Content[0], idx, func(label string) bool {
+		return label == SchemasLabel || label == ExamplesLabel
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "one of many", n.FindSchema("one").Value.Schema().Description.Value)
+	assert.Nil(t, n.FindResponse("three"))
+	assert.Nil(t, n.FindSecurityScheme("thirteen"))
+
+	// examples are skipped by the index.
+	assert.Nil(t, n.FindExample("seven"))
+}
+
 func TestComponents_Build_Success_Skip(t *testing.T) {
 
 	yml := `components:`
@@ -222,7 +243,7 @@ func TestComponents_Build_HashEmpty(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "seagull", n.FindExtension("x-curry").Value)
 	assert.Len(t, n.GetExtensions(), 1)
-	assert.Equal(t, "9cf2c6ab3f9ff7e5231fcb391c8af5c47406711d2ca366533f21a8bb2f67edfe",
+	assert.Equal(t, "e02944e6461627891085fff088c113c3c63bf2220592cb58f06b351ff4459773",
 		low.GenerateHashString(&n))
 
 }
@@ -21,6 +21,7 @@ const (
 	PathsLabel                 = "paths"
 	PathLabel                  = "path"
 	WebhooksLabel              = "webhooks"
+	PathItemsLabel             = "pathItems"
 	JSONSchemaDialectLabel     = "jsonSchemaDialect"
 	JSONSchemaLabel            = "$schema"
 	GetLabel                   = "get"
@@ -46,6 +47,7 @@ const (
 	DescriptionLabel           = "description"
 	URLLabel                   = "url"
 	NameLabel                  = "name"
+	IdentifierLabel            = "identifier"
 	EmailLabel                 = "email"
 	TitleLabel                 = "title"
 	TermsOfServiceLabel        = "termsOfService"
@@ -137,4 +139,10 @@ const (
 	DependentSchemasLabel      = "dependentSchemas"
 	PatternPropertiesLabel     = "patternProperties"
 	AnchorLabel                = "$anchor"
+	DynamicAnchorLabel         = "$dynamicAnchor"
+	DynamicRefLabel            = "$dynamicRef"
+	PrefixItemsLabel           = "prefixItems"
+	ConstLabel                 = "const"
+	DependentRequiredLabel     = "dependentRequired"
+	ContentSchemaLabel         = "contentSchema"
 )
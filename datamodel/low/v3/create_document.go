@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -13,6 +14,10 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 )
 
+// documentKeys are the top level keys an OpenAPI 3+ document can have.
+var documentKeys = []string{OpenAPILabel, base.InfoLabel, JSONSchemaDialectLabel, ServersLabel, PathsLabel,
+	WebhooksLabel, ComponentsLabel, SecurityLabel, base.TagsLabel, base.ExternalDocsLabel, "$self"}
+
 // CreateDocument will create a new Document instance from the provided SpecInfo.
 //
 // Deprecated: Use CreateDocumentFromConfig instead. This function will be removed in a later version, it
@@ -39,41 +44,15 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	version = low.NodeReference[string]{Value: versionNode.Value, KeyNode: labelNode, ValueNode: versionNode}
 	doc := Document{Version: version}
 
-	// get current working directory as a basePath
-	cwd, _ := os.Getwd()
-
-	// If basePath is provided override it
-	if config.BasePath != "" {
-		cwd = config.BasePath
-	}
-	// build an index
-	idx := index.NewSpecIndexWithConfig(info.RootNode, &index.SpecIndexConfig{
-		BaseURL:           config.BaseURL,
-		RemoteURLHandler:  config.RemoteURLHandler,
-		BasePath:          cwd,
-		AllowFileLookup:   config.AllowFileReferences,
-		AllowRemoteLookup: config.AllowRemoteReferences,
-		AvoidBuildIndex:   config.AvoidIndexBuild,
-	})
+	idx := newDocumentIndex(info, config)
 	doc.Index = idx
 
-	var errs []error
-
-	errs = idx.GetReferenceIndexErrors()
-
-	// create resolver and check for circular references.
-	resolve := resolver.NewResolver(idx)
-	resolvingErrors := resolve.CheckForCircularReferences()
-
-	if len(resolvingErrors) > 0 {
-		for r := range resolvingErrors {
-			errs = append(errs, resolvingErrors[r])
-		}
-	}
+	errs := checkReferences(idx)
 
 	var wg sync.WaitGroup
 
 	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
+	low.WarnUnknownKeys(info.RootNode.Content[0], idx, documentKeys)
 
 	// if set, extract jsonSchemaDialect (3.1)
 	_, dialectLabel, dialectNode := utils.FindKeyNodeFull(JSONSchemaDialectLabel, info.RootNode.Content)
@@ -83,41 +62,129 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		}
 	}
 
-	runExtraction := func(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex,
+	runExtraction := func(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex, label string,
 		runFunc func(i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error,
-		ers *[]error,
+		er *error,
 		wg *sync.WaitGroup,
 	) {
-		if er := runFunc(info, doc, idx); er != nil {
-			*ers = append(*ers, er)
+		start := time.Now()
+		*er = runFunc(info, doc, idx)
+		if logger := idx.GetLogger(); logger != nil {
+			logger.Debug("built section", "section", label, "duration", time.Since(start))
 		}
 		wg.Done()
 	}
-	extractionFuncs := []func(i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error{
-		extractInfo,
-		extractServers,
-		extractTags,
-		extractComponents,
-		extractSecurity,
-		extractExternalDocs,
-		extractPaths,
-		extractWebhooks,
+	extractPathsFunc := extractPaths
+	if config.AvoidPathItemBuild {
+		extractPathsFunc = extractPathsWithoutItems
+	}
+	extractComponentsFunc := func(i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error {
+		return extractComponents(i, d, idx, config.BuildComponent)
+	}
+	extractionFuncs := []struct {
+		label string
+		run   func(i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error
+	}{
+		{base.InfoLabel, extractInfo},
+		{ServersLabel, extractServers},
+		{base.TagsLabel, extractTags},
+		{ComponentsLabel, extractComponentsFunc},
+		{SecurityLabel, extractSecurity},
+		{base.ExternalDocsLabel, extractExternalDocs},
+		{PathsLabel, extractPathsFunc},
+		{WebhooksLabel, extractWebhooks},
 	}
 
-	wg.Add(len(extractionFuncs))
-	for _, f := range extractionFuncs {
-		go runExtraction(info, &doc, idx, f, &errs, &wg)
+	// each section has its own slot for an error, so sections can fail at the same time.
+	sectionErrs := make([]error, len(extractionFuncs))
+	for i, f := range extractionFuncs {
+		if config.BuildSection(f.label) {
+			wg.Add(1)
+			go runExtraction(info, &doc, idx, f.label, f.run, &sectionErrs[i], &wg)
+		}
 	}
 	wg.Wait()
+	for _, er := range sectionErrs {
+		if er != nil {
+			errs = append(errs, er)
+		}
+	}
+	errs = append(errs, idx.GetBuildErrors()...) // parts of the document that were built anyway.
 	return &doc, errs
 }
 
+// newDocumentIndex builds an index for a document (or a fragment of one) from the configuration.
+func newDocumentIndex(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) *index.SpecIndex {
+	// get current working directory as a basePath (or the root, if reading from a file system)
+	cwd, _ := os.Getwd()
+	if config.LocalFS != nil || config.Files != nil {
+		cwd = "."
+	}
+
+	// If basePath is provided override it
+	if config.BasePath != "" {
+		cwd = config.BasePath
+	}
+	localFS := config.LocalFS
+	if config.Files != nil {
+		localFS = index.NewVirtualFS(config.Files)
+	}
+
+	// build an index
+	idx := index.NewSpecIndexWithConfig(info.RootNode, &index.SpecIndexConfig{
+		BaseURL:                    config.BaseURL,
+		RemoteURLHandler:           config.RemoteURLHandler,
+		RemoteHTTPClient:           config.RemoteHTTPClient,
+		RemoteHeaders:              config.RemoteHeaders,
+		RemoteTimeout:              config.RemoteTimeout,
+		RemoteCheckRedirect:        config.RemoteCheckRedirect,
+		RemoteCache:                config.RemoteCache,
+		ParsedDocumentCache:        config.ParsedDocumentCache,
+		MaxConcurrentFetches:       config.MaxConcurrentFetches,
+		Sandbox:                    config.Sandbox,
+		CircularReferencePolicy:    config.CircularReferencePolicy,
+		CircularReferenceStubDepth: config.CircularReferenceStubDepth,
+		SchemeHandlers:             config.SchemeHandlers,
+		LocalFS:                    localFS,
+		BasePath:                   cwd,
+		SpecAbsolutePath:           config.SpecFilePath,
+		AllowFileLookup:            config.AllowFileReferences,
+		AllowRemoteLookup:          config.AllowRemoteReferences,
+		AvoidBuildIndex:            config.AvoidIndexBuild,
+		InternStrings:              config.InternStrings,
+		SkipExamples:               config.SkipExamples,
+		TolerateBuildErrors:        config.TolerateBuildErrors,
+		WarningHandler:             config.WarningHandler,
+		Logger:                     config.Logger,
+		ProgressHandler:            config.ProgressHandler,
+	})
+	return idx
+}
+
+// checkReferences returns the reference errors found indexing a document, along with any circular references.
+func checkReferences(idx *index.SpecIndex) []error {
+	errs := idx.GetReferenceIndexErrors()
+
+	// create resolver and check for circular references.
+	resolve := resolver.NewResolver(idx)
+	resolvingErrors := resolve.CheckForCircularReferences()
+
+	if len(resolvingErrors) > 0 {
+		for r := range resolvingErrors {
+			errs = append(errs, resolvingErrors[r])
+		}
+	}
+	return errs
+}
+
 func extractInfo(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex) error {
 	_, ln, vn := utils.FindKeyNodeFullTop(base.InfoLabel, info.RootNode.Content[0].Content)
 	if vn != nil {
 		ir := base.Info{}
 		_ = low.BuildModel(vn, &ir)
 		_ = ir.Build(vn, idx)
+		ir.SetOrigin(vn, idx)
+		ir.SetKeyNode(ln)
 		nr := low.NodeReference[*base.Info]{Value: &ir, ValueNode: vn, KeyNode: ln}
 		doc.Info = nr
 	}
@@ -148,15 +215,17 @@ func extractExternalDocs(info *datamodel.SpecInfo, doc *Document, idx *index.Spe
 	return nil
 }
 
-func extractComponents(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex) error {
+func extractComponents(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex, build func(label string) bool) error {
 	_, ln, vn := utils.FindKeyNodeFullTop(ComponentsLabel, info.RootNode.Content[0].Content)
 	if vn != nil {
 		ir := Components{}
 		_ = low.BuildModel(vn, &ir)
-		err := ir.Build(vn, idx)
+		err := ir.BuildSelected(vn, idx, build)
 		if err != nil {
 			return err
 		}
+		ir.SetOrigin(vn, idx)
+		ir.SetKeyNode(ln)
 		nr := low.NodeReference[*Components]{Value: &ir, ValueNode: vn, KeyNode: ln}
 		doc.Components = nr
 	}
@@ -173,6 +242,7 @@ func extractServers(info *datamodel.SpecInfo, doc *Document, idx *index.SpecInde
 					srvr := Server{}
 					_ = low.BuildModel(srvN, &srvr)
 					_ = srvr.Build(srvN, idx)
+					srvr.SetOrigin(srvN, idx)
 					servers = append(servers, low.ValueReference[*Server]{
 						Value:     &srvr,
 						ValueNode: srvN,
@@ -201,6 +271,7 @@ func extractTags(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex)
 					if err := tag.Build(tagN, idx); err != nil {
 						return err
 					}
+					tag.SetOrigin(tagN, idx)
 					tags = append(tags, low.ValueReference[*base.Tag]{
 						Value:     &tag,
 						ValueNode: tagN,
@@ -225,12 +296,26 @@ func extractPaths(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex)
 		if err != nil {
 			return err
 		}
+		ir.SetOrigin(vn, idx)
+		ir.SetKeyNode(ln)
 		nr := low.NodeReference[*Paths]{Value: &ir, ValueNode: vn, KeyNode: ln}
 		doc.Paths = nr
 	}
 	return nil
 }
 
+func extractPathsWithoutItems(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex) error {
+	_, ln, vn := utils.FindKeyNodeFull(PathsLabel, info.RootNode.Content[0].Content)
+	if vn != nil {
+		ir := Paths{}
+		_ = ir.BuildWithoutPathItems(vn, idx)
+		ir.SetOrigin(vn, idx)
+		ir.SetKeyNode(ln)
+		doc.Paths = low.NodeReference[*Paths]{Value: &ir, ValueNode: vn, KeyNode: ln}
+	}
+	return nil
+}
+
 func extractWebhooks(info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex) error {
 	hooks, hooksL, hooksN, eErr := low.ExtractMap[*PathItem](WebhooksLabel, info.RootNode, idx)
 	if eErr != nil {
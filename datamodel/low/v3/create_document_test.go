@@ -3,10 +3,13 @@ package v3
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/pb33f/libopenapi/datamodel"
-	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -134,25 +137,166 @@ func TestCreateDocument(t *testing.T) {
 	assert.Len(t, doc.GetExtensions(), 1)
 }
 
-//func TestCreateDocumentHash(t *testing.T) {
-//	data, _ := os.ReadFile("../../../test_specs/all-the-components.yaml")
-//	info, _ := datamodel.ExtractSpecInfo(data)
-//	d, _ := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
-//		AllowFileReferences:   false,
-//		AllowRemoteReferences: false,
-//		BasePath:              "/here",
-//	})
-//
-//	dataB, _ := os.ReadFile("../../../test_specs/all-the-components.yaml")
-//	infoB, _ := datamodel.ExtractSpecInfo(dataB)
-//	e, _ := CreateDocumentFromConfig(infoB, &datamodel.DocumentConfiguration{
-//		AllowFileReferences:   false,
-//		AllowRemoteReferences: false,
-//		BasePath:              "/here",
-//	})
-//
-//	assert.Equal(t, d.Hash(), e.Hash())
-//}
+func TestCreateDocumentHash(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/all-the-components.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	d, _ := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowFileReferences:   false,
+		AllowRemoteReferences: false,
+		BasePath:              "/here",
+	})
+
+	dataB, _ := os.ReadFile("../../../test_specs/all-the-components.yaml")
+	infoB, _ := datamodel.ExtractSpecInfo(dataB)
+	e, _ := CreateDocumentFromConfig(infoB, &datamodel.DocumentConfiguration{
+		AllowFileReferences:   false,
+		AllowRemoteReferences: false,
+		BasePath:              "/here",
+	})
+
+	assert.Equal(t, d.Hash(), e.Hash())
+}
+
+func TestCreateDocument_Origin(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/first.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	d, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		BasePath:            "../../../test_specs",
+		SpecFilePath:        "../../../test_specs/first.yaml",
+	})
+	assert.Empty(t, err)
+
+	first, _ := filepath.Abs("../../../test_specs/first.yaml")
+	second, _ := filepath.Abs("../../../test_specs/second.yaml")
+
+	origin := d.Info.Value.GetOrigin()
+	assert.Equal(t, first, origin.AbsoluteLocation)
+	assert.True(t, origin.RootDocument)
+	assert.Equal(t, 3, origin.Line)
+
+	op := d.Paths.Value.FindPath("/items").Value.Get.Value
+	assert.Equal(t, 11, op.GetOrigin().Line)
+
+	mt := op.Responses.Value.FindResponseByCode("200").Value.FindContent("application/json").Value
+	schema := mt.Schema.Value.Schema()
+	assert.Equal(t, first, schema.GetOrigin().AbsoluteLocation)
+
+	// follow the $ref into second.yaml
+	second1 := schema.AdditionalProperties.Value.A.Schema().FindProperty("second").Value
+	origin = second1.GetOrigin()
+	assert.Equal(t, second, origin.AbsoluteLocation)
+	assert.False(t, origin.RootDocument)
+	assert.Equal(t, 1, origin.Line)
+	assert.Equal(t, second, second1.Schema().GetOrigin().AbsoluteLocation)
+
+	prop := second1.Schema().FindProperty("property2").Value.Schema()
+	origin = prop.GetOrigin()
+	assert.Equal(t, second, origin.AbsoluteLocation)
+	assert.Equal(t, 23, origin.Line)
+}
+
+func TestCreateDocument_Nodes(t *testing.T) {
+	initTest()
+
+	pathItem := doc.Paths.Value.FindPath("/burgers")
+	op := pathItem.Value.Post.Value
+	mt := op.RequestBody.Value.FindContent("application/json").Value
+	objects := []any{
+		doc, doc.Info.Value, doc.Info.Value.Contact.Value, doc.Paths.Value, pathItem.Value, op,
+		op.RequestBody.Value, mt, mt.Schema.Value, mt.Schema.Value.Schema(), op.Responses.Value,
+		doc.Components.Value, doc.Components.Value.FindSchema("Burger").Value,
+	}
+	for _, obj := range objects {
+		assert.Implements(t, (*low.HasKeyNode)(nil), obj)
+		assert.Implements(t, (*low.HasRootNode)(nil), obj)
+		assert.Implements(t, (*low.HasIndex)(nil), obj)
+		assert.Equal(t, doc.Index, obj.(low.HasIndex).GetIndex(), "%T", obj)
+		assert.NotNil(t, obj.(low.HasRootNode).GetRootNode(), "%T", obj)
+	}
+
+	assert.Nil(t, doc.GetKeyNode())
+	assert.Equal(t, doc.Index.GetRootNode().Content[0], doc.GetRootNode())
+	assert.Equal(t, doc.GetRootNode(), doc.GetValueNode())
+	assert.Equal(t, "info", doc.Info.Value.GetKeyNode().Value)
+	assert.Equal(t, doc.Info.ValueNode, doc.Info.Value.GetValueNode())
+	assert.Equal(t, "paths", doc.Paths.Value.GetKeyNode().Value)
+	assert.Equal(t, "/burgers", pathItem.Value.GetKeyNode().Value)
+	assert.Equal(t, "post", op.GetKeyNode().Value)
+	assert.Equal(t, "requestBody", op.RequestBody.Value.GetKeyNode().Value)
+	assert.Equal(t, "application/json", mt.GetKeyNode().Value)
+	assert.Equal(t, "schema", mt.Schema.Value.GetKeyNode().Value)
+	assert.Equal(t, "schema", mt.Schema.Value.Schema().GetKeyNode().Value)
+	assert.Equal(t, "Burger", doc.Components.Value.FindSchema("Burger").Value.GetKeyNode().Value)
+	assert.Equal(t, 443, doc.Components.Value.FindSchema("Burger").Value.GetRootNode().Line)
+}
+
+func TestCreateDocument_LocalFS(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/first.yaml")
+	second, _ := os.ReadFile("../../../test_specs/second.yaml")
+	third, _ := os.ReadFile("../../../test_specs/third.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	d, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		LocalFS: fstest.MapFS{
+			"second.yaml": {Data: second},
+			"third.yaml":  {Data: third},
+		},
+	})
+	assert.Empty(t, err)
+
+	op := d.Paths.Value.FindPath("/items").Value.Get.Value
+	mt := op.Responses.Value.FindResponseByCode("200").Value.FindContent("application/json").Value
+	schema := mt.Schema.Value.Schema()
+	assert.NotNil(t, schema.AdditionalProperties.Value.A.Schema().FindProperty("second"))
+}
+
+func TestCreateDocument_Files(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/first.yaml")
+	second, _ := os.ReadFile("../../../test_specs/second.yaml")
+	third, _ := os.ReadFile("../../../test_specs/third.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	d, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		Files: map[string][]byte{
+			"second.yaml": second,
+			"third.yaml":  third,
+		},
+	})
+	assert.Empty(t, err)
+	assert.Len(t, d.Index.GetChildren(), 1)
+
+	// files that are not in the set don't exist.
+	_, err = CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		Files: map[string][]byte{
+			"second.yaml": second,
+		},
+	})
+	assert.Len(t, err, 1)
+	assert.Contains(t, err[0].Error(), "third.yaml")
+}
+
+func TestCreateDocument_CircularReferencePolicy(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Person:
+      type: object
+      properties:
+        friend:
+          $ref: '#/components/schemas/Person'`
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+	_, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{})
+	assert.Empty(t, err)
+
+	info, _ = datamodel.ExtractSpecInfo([]byte(spec))
+	_, err = CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		CircularReferencePolicy: index.CircularReferencesAllowValidRecursion,
+	})
+	assert.Len(t, err, 1)
+	assert.Contains(t, err[0].Error(), "circular reference detected (optional)")
+}
 
 func TestCreateDocument_Info(t *testing.T) {
 	initTest()
@@ -174,6 +318,88 @@ func TestCreateDocument_WebHooks(t *testing.T) {
 	}
 }
 
+func TestCreateDocument_WebHooks_Ref(t *testing.T) {
+	yml := `openapi: 3.1.0
+webhooks:
+  newBurger:
+    post:
+      description: a new burger has arrived
+  anotherBurger:
+    $ref: '#/webhooks/newBurger'`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	assert.Empty(t, err)
+	assert.Len(t, d.Webhooks.Value, 2)
+
+	hook := d.FindWebhook("anotherBurger")
+	assert.NotNil(t, hook)
+	assert.Equal(t, "#/webhooks/newBurger", hook.Reference)
+	assert.Equal(t, "a new burger has arrived", hook.Value.Post.Value.Description.Value)
+	assert.Nil(t, d.FindWebhook("pizza"))
+}
+
+func TestCreateDocument_ComponentPathItems_Ref(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /burgers:
+    $ref: '#/components/pathItems/burgers'
+webhooks:
+  newBurger:
+    $ref: '#/components/pathItems/burgers'
+components:
+  pathItems:
+    burgers:
+      get:
+        description: get some burgers`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	assert.Empty(t, err)
+
+	assert.Len(t, d.Components.Value.PathItems.Value, 1)
+	comp := d.Components.Value.FindPathItem("burgers")
+	assert.NotNil(t, comp)
+	assert.False(t, comp.Value.IsReference())
+	assert.Equal(t, "get some burgers", comp.Value.Get.Value.Description.Value)
+	assert.Nil(t, d.Components.Value.FindPathItem("pizza"))
+
+	path := d.Paths.Value.FindPath("/burgers")
+	assert.NotNil(t, path)
+	assert.True(t, path.IsReference())
+	assert.Equal(t, "#/components/pathItems/burgers", path.Reference)
+	assert.Equal(t, "#/components/pathItems/burgers", path.Value.GetReference())
+	assert.Equal(t, "get some burgers", path.Value.Get.Value.Description.Value)
+
+	hook := d.FindWebhook("newBurger")
+	assert.NotNil(t, hook)
+	assert.Equal(t, "#/components/pathItems/burgers", hook.Value.GetReference())
+	assert.Equal(t, "get some burgers", hook.Value.Get.Value.Description.Value)
+}
+
+func TestDocument_Hash_Webhooks(t *testing.T) {
+	left := `openapi: 3.1.0
+webhooks:
+  newBurger:
+    post:
+      description: a new burger has arrived`
+
+	right := `openapi: 3.1.0
+webhooks:
+  newBurger:
+    post:
+      description: a new burger has been cooked`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := CreateDocumentFromConfig(lInfo, datamodel.NewClosedDocumentConfiguration())
+	rDoc, _ := CreateDocumentFromConfig(rInfo, datamodel.NewClosedDocumentConfiguration())
+	again, _ := CreateDocumentFromConfig(lInfo, datamodel.NewClosedDocumentConfiguration())
+
+	assert.NotEqual(t, lDoc.Hash(), rDoc.Hash())
+	assert.Equal(t, lDoc.Hash(), again.Hash())
+}
+
 func TestCreateDocument_WebHooks_Error(t *testing.T) {
 	yml := `webhooks:
       $ref: #bork`
@@ -543,11 +769,8 @@ func TestCreateDocument_CheckAdditionalProperties_Schema(t *testing.T) {
 	components := doc.Components.Value
 	d := components.FindSchema("Dressing")
 	assert.NotNil(t, d.Value.Schema().AdditionalProperties.Value)
-	if n, ok := d.Value.Schema().AdditionalProperties.Value.(*base.SchemaProxy); ok {
-		assert.Equal(t, "something in here.", n.Schema().Description.Value)
-	} else {
-		assert.Fail(t, "should be a schema")
-	}
+	assert.True(t, d.Value.Schema().AdditionalProperties.Value.IsA())
+	assert.Equal(t, "something in here.", d.Value.Schema().AdditionalProperties.Value.A.Schema().Description.Value)
 }
 
 func TestCreateDocument_CheckAdditionalProperties_Bool(t *testing.T) {
@@ -555,7 +778,8 @@ func TestCreateDocument_CheckAdditionalProperties_Bool(t *testing.T) {
 	components := doc.Components.Value
 	d := components.FindSchema("Drink")
 	assert.NotNil(t, d.Value.Schema().AdditionalProperties.Value)
-	assert.True(t, d.Value.Schema().AdditionalProperties.Value.(bool))
+	assert.True(t, d.Value.Schema().AdditionalProperties.Value.IsB())
+	assert.True(t, d.Value.Schema().AdditionalProperties.Value.B)
 }
 
 func TestCreateDocument_Components_Error(t *testing.T) {
@@ -9,9 +9,13 @@
 package v3
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
 )
 
 type Document struct {
@@ -101,6 +105,26 @@ func (d *Document) GetExtensions() map[low.KeyReference[string]]low.ValueReferen
 	return d.Extensions
 }
 
+// GetKeyNode always returns nil, a Document is not held by a key. Satisfies the low.HasKeyNode interface.
+func (d *Document) GetKeyNode() *yaml.Node {
+	return nil
+}
+
+// GetRootNode returns the root mapping node of the Document, or nil if there is no index.
+func (d *Document) GetRootNode() *yaml.Node {
+	return low.DocumentRootNode(d.Index)
+}
+
+// GetValueNode returns the root mapping node of the Document, the same as GetRootNode.
+func (d *Document) GetValueNode() *yaml.Node {
+	return d.GetRootNode()
+}
+
+// GetIndex returns the index created for the Document.
+func (d *Document) GetIndex() *index.SpecIndex {
+	return d.Index
+}
+
 func (d *Document) GetExternalDocs() *low.NodeReference[any] {
 	return &low.NodeReference[any]{
 		KeyNode:   d.ExternalDocs.KeyNode,
@@ -109,67 +133,58 @@ func (d *Document) GetExternalDocs() *low.NodeReference[any] {
 	}
 }
 
-// TODO: some behavior in this hash is not correct, disabled for now
 // Hash will return a consistent SHA256 Hash of the Document object
-//func (d *Document) Hash() [32]byte {
-//	var f []string
-//	if d.Version.Value != "" {
-//		f = append(f, d.Version.Value)
-//	}
-//	if d.Info.Value != nil {
-//		f = append(f, low.GenerateHashString(d.Info.Value))
-//	}
-//	if d.JsonSchemaDialect.Value != "" {
-//		f = append(f, d.JsonSchemaDialect.Value)
-//	}
-//	keys := make([]string, len(d.Webhooks.Value))
-//	z := 0
-//	for k := range d.Webhooks.Value {
-//		keys[z] = fmt.Sprintf("%s-%s", k.Value, low.GenerateHashString(d.Webhooks.Value[k].Value))
-//		z++
-//	}
-//	z = 0
-//	sort.Strings(keys)
-//	f = append(f, keys...)
-//	keys = make([]string, len(d.Servers.Value))
-//	for k := range d.Servers.Value {
-//		keys[z] = fmt.Sprintf("%s", low.GenerateHashString(d.Servers.Value[k].Value))
-//		z++
-//	}
-//	sort.Strings(keys)
-//	f = append(f, keys...)
-//	if d.Paths.Value != nil {
-//		f = append(f, low.GenerateHashString(d.Paths.Value))
-//	}
-//	if d.Components.Value != nil {
-//		f = append(f, low.GenerateHashString(d.Components.Value))
-//	}
-//	keys = make([]string, len(d.Security.Value))
-//	z = 0
-//	for k := range d.Security.Value {
-//		keys[z] = fmt.Sprintf("%s", low.GenerateHashString(d.Security.Value[k].Value))
-//		z++
-//	}
-//	sort.Strings(keys)
-//	f = append(f, keys...)
-//	keys = make([]string, len(d.Tags.Value))
-//	z = 0
-//	for k := range d.Tags.Value {
-//		keys[z] = fmt.Sprintf("%s", low.GenerateHashString(d.Tags.Value[k].Value))
-//		z++
-//	}
-//	sort.Strings(keys)
-//	f = append(f, keys...)
-//	if d.ExternalDocs.Value != nil {
-//		f = append(f, low.GenerateHashString(d.ExternalDocs.Value))
-//	}
-//	keys = make([]string, len(d.Extensions))
-//	z = 0
-//	for k := range d.Extensions {
-//		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(d.Extensions[k].Value))))
-//		z++
-//	}
-//	sort.Strings(keys)
-//	f = append(f, keys...)
-//	return sha256.Sum256([]byte(strings.Join(f, "|")))
-//}
+func (d *Document) Hash() [32]byte {
+	var f []string
+	if d.Version.Value != "" {
+		f = append(f, low.HashField("version", d.Version.Value))
+	}
+	if d.Info.Value != nil {
+		f = append(f, low.HashField("info", low.GenerateHashString(d.Info.Value)))
+	}
+	if d.JsonSchemaDialect.Value != "" {
+		f = append(f, low.HashField("jsonSchemaDialect", d.JsonSchemaDialect.Value))
+	}
+	keys := make([]string, len(d.Webhooks.Value))
+	z := 0
+	for k := range d.Webhooks.Value {
+		keys[z] = fmt.Sprintf("%s-%s", k.Value, low.GenerateHashString(d.Webhooks.Value[k].Value))
+		z++
+	}
+	sort.Strings(keys)
+	f = append(f, low.HashFields("webhooks", keys)...)
+	keys = make([]string, len(d.Servers.Value))
+	for k := range d.Servers.Value {
+		keys[k] = low.GenerateHashString(d.Servers.Value[k].Value)
+	}
+	sort.Strings(keys)
+	f = append(f, low.HashFields("servers", keys)...)
+	if d.Paths.Value != nil {
+		f = append(f, low.HashField("paths", low.GenerateHashString(d.Paths.Value)))
+	}
+	if d.Components.Value != nil {
+		f = append(f, low.HashField("components", low.GenerateHashString(d.Components.Value)))
+	}
+	keys = make([]string, len(d.Security.Value))
+	for k := range d.Security.Value {
+		keys[k] = low.GenerateHashString(d.Security.Value[k].Value)
+	}
+	sort.Strings(keys)
+	f = append(f, low.HashFields("security", keys)...)
+	keys = make([]string, len(d.Tags.Value))
+	for k := range d.Tags.Value {
+		keys[k] = low.GenerateHashString(d.Tags.Value[k].Value)
+	}
+	sort.Strings(keys)
+	f = append(f, low.HashFields("tags", keys)...)
+	if d.ExternalDocs.Value != nil {
+		f = append(f, low.HashField("externalDocs", low.GenerateHashString(d.ExternalDocs.Value)))
+	}
+	f = append(f, low.HashExtensions(d.Extensions)...)
+	return low.HashStrings(f)
+}
+
+// FindWebhook will attempt to locate a webhook PathItem by name.
+func (d *Document) FindWebhook(name string) *low.ValueReference[*PathItem] {
+	return low.FindItemInMap[*PathItem](name, d.Webhooks.Value)
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"strings"
 )
 
 // Encoding represents a low-level OpenAPI 3+ Encoding object
@@ -22,6 +21,7 @@ type Encoding struct {
 	Explode       low.NodeReference[bool]
 	AllowReserved low.NodeReference[bool]
 	*low.Reference
+	low.Nodes
 }
 
 // FindHeader attempts to locate a Header with the supplied name
@@ -33,7 +33,7 @@ func (en *Encoding) FindHeader(hType string) *low.ValueReference[*Header] {
 func (en *Encoding) Hash() [32]byte {
 	var f []string
 	if en.ContentType.Value != "" {
-		f = append(f, en.ContentType.Value)
+		f = append(f, low.HashField("contentType", en.ContentType.Value))
 	}
 	if len(en.Headers.Value) > 0 {
 		l := make([]string, len(en.Headers.Value))
@@ -46,15 +46,15 @@ func (en *Encoding) Hash() [32]byte {
 		}
 
 		for k := range en.Headers.Value {
-			f = append(f, fmt.Sprintf("%s-%x", k.Value, en.Headers.Value[k].Value.Hash()))
+			f = append(f, low.HashField("headers", fmt.Sprintf("%s-%x", k.Value, en.Headers.Value[k].Value.Hash())))
 		}
 	}
 	if en.Style.Value != "" {
-		f = append(f, en.Style.Value)
+		f = append(f, low.HashField("style", en.Style.Value))
 	}
-	f = append(f, fmt.Sprint(sha256.Sum256([]byte(fmt.Sprint(en.Explode.Value)))))
-	f = append(f, fmt.Sprint(sha256.Sum256([]byte(fmt.Sprint(en.AllowReserved.Value)))))
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashField("explode", fmt.Sprint(sha256.Sum256([]byte(fmt.Sprint(en.Explode.Value))))))
+	f = append(f, low.HashField("allowReserved", fmt.Sprint(sha256.Sum256([]byte(fmt.Sprint(en.AllowReserved.Value))))))
+	return low.HashStrings(f)
 }
 
 // Build will extract all Header objects from supplied node.
@@ -62,6 +62,7 @@ func (en *Encoding) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	en.Reference = new(low.Reference)
+	en.SetNodes(root, idx)
 	headers, hL, hN, err := low.ExtractMap[*Header](HeadersLabel, root, idx)
 	if err != nil {
 		return err
@@ -0,0 +1,83 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"errors"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CreateSchemaFragment creates a Schema from a specification fragment that contains a lone schema (like a schema
+// shared between specifications in its own file). References in the schema are indexed and resolved relative to
+// the fragment, the same way they are for a complete document.
+func CreateSchemaFragment(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*base.Schema, *index.SpecIndex, []error) {
+	root, err := fragmentRoot(info)
+	if err != nil {
+		return nil, nil, []error{err}
+	}
+	idx := newDocumentIndex(info, config)
+	errs := checkReferences(idx)
+	schema := new(base.Schema)
+	utils.CheckForMergeNodes(root)
+	if err = schema.Build(root, idx); err != nil {
+		return nil, idx, append(errs, err)
+	}
+	schema.SetOrigin(root, idx)
+	return schema, idx, append(errs, idx.GetBuildErrors()...)
+}
+
+// CreatePathItemFragment creates a PathItem from a specification fragment that contains a lone path item (like the
+// target of a $ref from the paths of a specification).
+func CreatePathItemFragment(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*PathItem, *index.SpecIndex, []error) {
+	root, err := fragmentRoot(info)
+	if err != nil {
+		return nil, nil, []error{err}
+	}
+	idx := newDocumentIndex(info, config)
+	errs := checkReferences(idx)
+	pathItem := new(PathItem)
+	_ = low.BuildModel(root, pathItem)
+	if err = pathItem.Build(root, idx); err != nil {
+		return nil, idx, append(errs, err)
+	}
+	pathItem.SetOrigin(root, idx)
+	return pathItem, idx, append(errs, idx.GetBuildErrors()...)
+}
+
+// CreateComponentsFragment creates Components from a specification fragment that contains shared components. The
+// components can either be under a 'components' key (like a complete document without paths), or at the top of the
+// fragment (like 'schemas', 'responses' and so on).
+func CreateComponentsFragment(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Components, *index.SpecIndex, []error) {
+	root, err := fragmentRoot(info)
+	if err != nil {
+		return nil, nil, []error{err}
+	}
+	idx := newDocumentIndex(info, config)
+	errs := checkReferences(idx)
+	if _, vn := utils.FindKeyNodeTop(ComponentsLabel, root.Content); vn != nil {
+		root = vn
+	}
+	components := new(Components)
+	_ = low.BuildModel(root, components)
+	if err = components.BuildSelected(root, idx, config.BuildComponent); err != nil {
+		return nil, idx, append(errs, err)
+	}
+	components.SetOrigin(root, idx)
+	return components, idx, append(errs, idx.GetBuildErrors()...)
+}
+
+// fragmentRoot returns the mapping at the top of a fragment.
+func fragmentRoot(info *datamodel.SpecInfo) (*yaml.Node, error) {
+	if info == nil || info.RootNode == nil || len(info.RootNode.Content) == 0 ||
+		!utils.IsNodeMap(info.RootNode.Content[0]) {
+		return nil, errors.New("fragment is not an object, cannot create fragment")
+	}
+	return info.RootNode.Content[0], nil
+}
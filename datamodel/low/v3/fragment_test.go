@@ -0,0 +1,46 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateComponentsFragment_Selected(t *testing.T) {
+	spec := `schemas:
+  Pizza:
+    type: object
+responses:
+  Pizza:
+    description: a pizza`
+
+	info, _ := datamodel.ExtractSpecInfoWithDocumentCheck([]byte(spec), true)
+	config := &datamodel.DocumentConfiguration{BuildComponents: []string{SchemasLabel}}
+	components, idx, errs := CreateComponentsFragment(info, config)
+	assert.Empty(t, errs)
+	assert.NotNil(t, idx)
+	assert.Len(t, components.Schemas.Value, 1)
+	assert.Len(t, components.Responses.Value, 0)
+}
+
+func TestCreateFragment_NotAnObject(t *testing.T) {
+	info, _ := datamodel.ExtractSpecInfoWithDocumentCheck([]byte("- pizza"), true)
+	config := &datamodel.DocumentConfiguration{}
+
+	schema, idx, errs := CreateSchemaFragment(info, config)
+	assert.Nil(t, schema)
+	assert.Nil(t, idx)
+	assert.Len(t, errs, 1)
+
+	pathItem, _, errs := CreatePathItemFragment(info, config)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+
+	components, _, errs := CreateComponentsFragment(info, config)
+	assert.Nil(t, components)
+	assert.Equal(t, "fragment is not an object, cannot create fragment", errs[0].Error())
+}
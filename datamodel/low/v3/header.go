@@ -4,15 +4,12 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
-	"strings"
 )
 
 // Header represents a low-level OpenAPI 3+ Header object.
@@ -31,6 +28,7 @@ type Header struct {
 	Content         low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*MediaType]]
 	Extensions      map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindExtension will attempt to locate an extension with the supplied name
@@ -57,41 +55,34 @@ func (h *Header) GetExtensions() map[low.KeyReference[string]]low.ValueReference
 func (h *Header) Hash() [32]byte {
 	var f []string
 	if h.Description.Value != "" {
-		f = append(f, h.Description.Value)
+		f = append(f, low.HashField("description", h.Description.Value))
 	}
-	f = append(f, fmt.Sprint(h.Required.Value))
-	f = append(f, fmt.Sprint(h.Deprecated.Value))
-	f = append(f, fmt.Sprint(h.AllowEmptyValue.Value))
+	f = append(f, low.HashField("required", fmt.Sprint(h.Required.Value)))
+	f = append(f, low.HashField("deprecated", fmt.Sprint(h.Deprecated.Value)))
+	f = append(f, low.HashField("allowEmptyValue", fmt.Sprint(h.AllowEmptyValue.Value)))
 	if h.Style.Value != "" {
-		f = append(f, h.Style.Value)
+		f = append(f, low.HashField("style", h.Style.Value))
 	}
-	f = append(f, fmt.Sprint(h.Explode.Value))
-	f = append(f, fmt.Sprint(h.AllowReserved.Value))
+	f = append(f, low.HashField("explode", fmt.Sprint(h.Explode.Value)))
+	f = append(f, low.HashField("allowReserved", fmt.Sprint(h.AllowReserved.Value)))
 	if h.Schema.Value != nil {
-		f = append(f, low.GenerateHashString(h.Schema.Value))
+		f = append(f, low.HashField("schema", low.GenerateHashString(h.Schema.Value)))
 	}
 	if h.Example.Value != nil {
-		f = append(f, fmt.Sprint(h.Example.Value))
+		f = append(f, low.HashField("example", fmt.Sprint(h.Example.Value)))
 	}
 	if len(h.Examples.Value) > 0 {
 		for k := range h.Examples.Value {
-			f = append(f, fmt.Sprintf("%s-%x", k.Value, h.Examples.Value[k].Value.Hash()))
+			f = append(f, low.HashField("examples", fmt.Sprintf("%s-%x", k.Value, h.Examples.Value[k].Value.Hash())))
 		}
 	}
 	if len(h.Content.Value) > 0 {
 		for k := range h.Content.Value {
-			f = append(f, fmt.Sprintf("%s-%x", k.Value, h.Content.Value[k].Value.Hash()))
+			f = append(f, low.HashField("content", fmt.Sprintf("%s-%x", k.Value, h.Content.Value[k].Value.Hash())))
 		}
 	}
-	keys := make([]string, len(h.Extensions))
-	z := 0
-	for k := range h.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(h.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(h.Extensions)...)
+	return low.HashStrings(f)
 }
 
 // Build will extract extensions, examples, schema and content/media types from node.
@@ -99,24 +90,29 @@ func (h *Header) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	h.Reference = new(low.Reference)
+	h.SetNodes(root, idx)
 	h.Extensions = low.ExtractExtensions(root)
 
 	// handle example if set.
 	_, expLabel, expNode := utils.FindKeyNodeFull(base.ExampleLabel, root.Content)
-	if expNode != nil {
+	if idx.SkipExamples() {
+		h.Example = low.NodeReference[any]{} // set by BuildModel.
+	} else if expNode != nil {
 		h.Example = low.ExtractExample(expNode, expLabel)
 	}
 
 	// handle examples if set.
-	exps, expsL, expsN, eErr := low.ExtractMap[*base.Example](base.ExamplesLabel, root, idx)
-	if eErr != nil {
-		return eErr
-	}
-	if exps != nil {
-		h.Examples = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*base.Example]]{
-			Value:     exps,
-			KeyNode:   expsL,
-			ValueNode: expsN,
+	if !idx.SkipExamples() {
+		exps, expsL, expsN, eErr := low.ExtractMap[*base.Example](base.ExamplesLabel, root, idx)
+		if eErr != nil {
+			return eErr
+		}
+		if exps != nil {
+			h.Examples = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*base.Example]]{
+				Value:     exps,
+				KeyNode:   expsL,
+				ValueNode: expsN,
+			}
 		}
 	}
 
@@ -142,6 +138,23 @@ func (h *Header) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	return nil
 }
 
+// ForbiddenKeys returns the keys found in the header that are not allowed in a header, 'name' and 'in'. A header
+// is a parameter without a name or location, its name is the key of the header, and it's always in a header.
+func (h *Header) ForbiddenKeys() []low.KeyReference[string] {
+	root := h.GetRootNode()
+	if root == nil {
+		return nil
+	}
+	var keys []low.KeyReference[string]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch root.Content[i].Value {
+		case NameLabel, InLabel:
+			keys = append(keys, low.KeyReference[string]{Value: root.Content[i].Value, KeyNode: root.Content[i]})
+		}
+	}
+	return keys
+}
+
 // Getter methods to satisfy OpenAPIHeader interface.
 
 func (h *Header) GetDescription() *low.NodeReference[string] {
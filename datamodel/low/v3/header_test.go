@@ -244,3 +244,26 @@ schema:
 	assert.Len(t, n.GetContent().Value.(map[low.KeyReference[string]]low.ValueReference[*MediaType]), 1)
 
 }
+
+func TestHeader_ForbiddenKeys(t *testing.T) {
+
+	yml := `description: a header
+name: X-Pizza
+in: header`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Header
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	keys := n.ForbiddenKeys()
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "name", keys[0].Value)
+	assert.Equal(t, 2, keys[0].KeyNode.Line)
+	assert.Equal(t, "in", keys[1].Value)
+
+	assert.Nil(t, new(Header).ForbiddenKeys())
+}
@@ -4,14 +4,11 @@
 package v3
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Link represents a low-level OpenAPI 3+ Link object.
@@ -35,6 +32,7 @@ type Link struct {
 	Server       low.NodeReference[*Server]
 	Extensions   map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // GetExtensions returns all Link extensions and satisfies the low.HasExtensions interface.
@@ -57,6 +55,7 @@ func (l *Link) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	l.Reference = new(low.Reference)
+	l.SetNodes(root, idx)
 	l.Extensions = low.ExtractExtensions(root)
 	// extract server.
 	ser, sErr := low.ExtractObject[*Server](ServerLabel, root, idx)
@@ -71,19 +70,19 @@ func (l *Link) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (l *Link) Hash() [32]byte {
 	var f []string
 	if l.Description.Value != "" {
-		f = append(f, l.Description.Value)
+		f = append(f, low.HashField("description", l.Description.Value))
 	}
 	if l.OperationRef.Value != "" {
-		f = append(f, l.OperationRef.Value)
+		f = append(f, low.HashField("operationRef", l.OperationRef.Value))
 	}
 	if l.OperationId.Value != "" {
-		f = append(f, l.OperationId.Value)
+		f = append(f, low.HashField("operationId", l.OperationId.Value))
 	}
 	if l.RequestBody.Value != "" {
-		f = append(f, l.RequestBody.Value)
+		f = append(f, low.HashField("requestBody", l.RequestBody.Value))
 	}
 	if l.Server.Value != nil {
-		f = append(f, low.GenerateHashString(l.Server.Value))
+		f = append(f, low.HashField("server", low.GenerateHashString(l.Server.Value)))
 	}
 	// todo: needs ordering.
 
@@ -94,14 +93,7 @@ func (l *Link) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
-	keys = make([]string, len(l.Extensions))
-	z = 0
-	for k := range l.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(l.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashFields("parameters", keys)...)
+	f = append(f, low.HashExtensions(l.Extensions)...)
+	return low.HashStrings(f)
 }
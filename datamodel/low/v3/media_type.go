@@ -12,7 +12,6 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // MediaType represents a low-level OpenAPI MediaType object.
@@ -26,6 +25,7 @@ type MediaType struct {
 	Encoding   low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Encoding]]
 	Extensions map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // GetExtensions returns all MediaType extensions and satisfies the low.HasExtensions interface.
@@ -58,11 +58,14 @@ func (mt *MediaType) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	mt.Reference = new(low.Reference)
+	mt.SetNodes(root, idx)
 	mt.Extensions = low.ExtractExtensions(root)
 
 	// handle example if set.
 	_, expLabel, expNode := utils.FindKeyNodeFull(base.ExampleLabel, root.Content)
-	if expNode != nil {
+	if idx.SkipExamples() {
+		mt.Example = low.NodeReference[any]{} // set by BuildModel.
+	} else if expNode != nil {
 		var value any
 		if utils.IsNodeMap(expNode) {
 			var h map[string]any
@@ -92,15 +95,17 @@ func (mt *MediaType) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	}
 
 	// handle examples if set.
-	exps, expsL, expsN, eErr := low.ExtractMap[*base.Example](base.ExamplesLabel, root, idx)
-	if eErr != nil {
-		return eErr
-	}
-	if exps != nil {
-		mt.Examples = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*base.Example]]{
-			Value:     exps,
-			KeyNode:   expsL,
-			ValueNode: expsN,
+	if !idx.SkipExamples() {
+		exps, expsL, expsN, eErr := low.ExtractMap[*base.Example](base.ExamplesLabel, root, idx)
+		if eErr != nil {
+			return eErr
+		}
+		if exps != nil {
+			mt.Examples = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*base.Example]]{
+				Value:     exps,
+				KeyNode:   expsL,
+				ValueNode: expsN,
+			}
 		}
 	}
 
@@ -123,33 +128,31 @@ func (mt *MediaType) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (mt *MediaType) Hash() [32]byte {
 	var f []string
 	if mt.Schema.Value != nil {
-		f = append(f, low.GenerateHashString(mt.Schema.Value))
+		f = append(f, low.HashField("schema", low.GenerateHashString(mt.Schema.Value)))
 	}
 	if mt.Example.Value != nil {
-		f = append(f, fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(mt.Example.Value)))))
+		f = append(f, low.HashField("example", fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprint(mt.Example.Value))))))
 	}
 	keys := make([]string, len(mt.Examples.Value))
 	z := 0
 	for k := range mt.Examples.Value {
-		keys[z] = low.GenerateHashString(mt.Examples.Value[k].Value)
+		keys[z] = low.HashEntry(k.Value, low.GenerateHashString(mt.Examples.Value[k].Value))
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("examples", keys)...)
 	keys = make([]string, len(mt.Encoding.Value))
 	z = 0
 	for k := range mt.Encoding.Value {
-		keys[z] = low.GenerateHashString(mt.Encoding.Value[k].Value)
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	keys = make([]string, len(mt.Extensions))
-	z = 0
-	for k := range mt.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(mt.Extensions[k].Value))))
+		keys[z] = low.HashEntry(k.Value, low.GenerateHashString(mt.Encoding.Value[k].Value))
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	if low.LegacyHashes() && len(keys) > 1 {
+		// older versions only hashed one encoding, leaving the rest empty.
+		keys = append(make([]string, len(keys)-1), keys[len(keys)-1])
+	}
+	f = append(f, low.HashFields("encoding", keys)...)
+	f = append(f, low.HashExtensions(mt.Extensions)...)
+	return low.HashStrings(f)
 }
@@ -143,3 +143,59 @@ example: a thing`
 	assert.Equal(t, n.Hash(), n2.Hash())
 	assert.Len(t, n.GetExtensions(), 1)
 }
+
+func TestMediaType_Hash_Names(t *testing.T) {
+	hash := func(yml string) [32]byte {
+		var idxNode yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &idxNode)
+		idx := index.NewSpecIndex(&idxNode)
+		var n MediaType
+		_ = low.BuildModel(idxNode.Content[0], &n)
+		_ = n.Build(idxNode.Content[0], idx)
+		return n.Hash()
+	}
+
+	// examples and encodings are hashed with their names.
+	assert.NotEqual(t,
+		hash("examples:\n  thing1:\n    summary: thing"),
+		hash("examples:\n  thing2:\n    summary: thing"))
+	assert.NotEqual(t,
+		hash("encoding:\n  meaty/chewy:\n    style: suave"),
+		hash("encoding:\n  crispy/crunchy:\n    style: suave"))
+
+	// every encoding is hashed, not just one of them.
+	assert.NotEqual(t,
+		hash("encoding:\n  a:\n    style: suave\n  b:\n    style: suave"),
+		hash("encoding:\n  a:\n    style: suave\n  b:\n    style: rough"))
+	assert.NotEqual(t,
+		hash("encoding:\n  a:\n    style: suave\n  b:\n    style: suave"),
+		hash("encoding:\n  a:\n    style: rough\n  b:\n    style: suave"))
+}
+
+func TestMediaType_Hash_Options_AddedExample(t *testing.T) {
+	hash := func(yml string) [32]byte {
+		var idxNode yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &idxNode)
+		idx := index.NewSpecIndex(&idxNode)
+		var n MediaType
+		_ = low.BuildModel(idxNode.Content[0], &n)
+		_ = n.Build(idxNode.Content[0], idx)
+		return n.Hash()
+	}
+	without := `schema:
+  type: string`
+	with := `schema:
+  type: string
+  description: a thing
+example: a thing
+examples:
+  thing1:
+    summary: thing`
+
+	assert.NotEqual(t, hash(without), hash(with))
+
+	// adding or removing ignored examples and descriptions doesn't change the hash.
+	defer low.SetHashOptions(nil)
+	low.SetHashOptions(&low.HashOptions{IgnoreDescriptions: true, IgnoreExamples: true})
+	assert.Equal(t, hash(without), hash(with))
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // OAuthFlows represents a low-level OpenAPI 3+ OAuthFlows object.
@@ -23,6 +22,7 @@ type OAuthFlows struct {
 	AuthorizationCode low.NodeReference[*OAuthFlow]
 	Extensions        map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // GetExtensions returns all OAuthFlows extensions and satisfies the low.HasExtensions interface.
@@ -40,6 +40,7 @@ func (o *OAuthFlows) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	o.Reference = new(low.Reference)
+	o.SetNodes(root, idx)
 	o.Extensions = low.ExtractExtensions(root)
 
 	v, vErr := low.ExtractObject[*OAuthFlow](ImplicitLabel, root, idx)
@@ -72,21 +73,23 @@ func (o *OAuthFlows) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (o *OAuthFlows) Hash() [32]byte {
 	var f []string
 	if !o.Implicit.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.Implicit.Value))
+		f = append(f, low.HashField("implicit", low.GenerateHashString(o.Implicit.Value)))
 	}
 	if !o.Password.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.Password.Value))
+		f = append(f, low.HashField("password", low.GenerateHashString(o.Password.Value)))
 	}
 	if !o.ClientCredentials.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.ClientCredentials.Value))
+		f = append(f, low.HashField("clientCredentials", low.GenerateHashString(o.ClientCredentials.Value)))
 	}
 	if !o.AuthorizationCode.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.AuthorizationCode.Value))
+		f = append(f, low.HashField("authorizationCode", low.GenerateHashString(o.AuthorizationCode.Value)))
 	}
-	for k := range o.Extensions {
-		f = append(f, fmt.Sprintf("%s-%v", k.Value, o.Extensions[k].Value))
+	if low.LegacyHashes() {
+		f = append(f, low.HashExtensionValues(o.Extensions)...)
+	} else {
+		f = append(f, low.HashExtensions(o.Extensions)...)
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
 
 // OAuthFlow represents a low-level OpenAPI 3+ OAuthFlow object.
@@ -98,6 +101,7 @@ type OAuthFlow struct {
 	Scopes           low.NodeReference[map[low.KeyReference[string]]low.ValueReference[string]]
 	Extensions       map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // GetExtensions returns all OAuthFlow extensions and satisfies the low.HasExtensions interface.
@@ -118,6 +122,7 @@ func (o *OAuthFlow) FindExtension(ext string) *low.ValueReference[any] {
 // Build will extract extensions from the node.
 func (o *OAuthFlow) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	o.Reference = new(low.Reference)
+	o.SetNodes(root, idx)
 	o.Extensions = low.ExtractExtensions(root)
 	return nil
 }
@@ -126,13 +131,13 @@ func (o *OAuthFlow) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (o *OAuthFlow) Hash() [32]byte {
 	var f []string
 	if !o.AuthorizationUrl.IsEmpty() {
-		f = append(f, o.AuthorizationUrl.Value)
+		f = append(f, low.HashField("authorizationUrl", o.AuthorizationUrl.Value))
 	}
 	if !o.TokenUrl.IsEmpty() {
-		f = append(f, o.TokenUrl.Value)
+		f = append(f, low.HashField("tokenUrl", o.TokenUrl.Value))
 	}
 	if !o.RefreshUrl.IsEmpty() {
-		f = append(f, o.RefreshUrl.Value)
+		f = append(f, low.HashField("refreshUrl", o.RefreshUrl.Value))
 	}
 	keys := make([]string, len(o.Scopes.Value))
 	z := 0
@@ -141,14 +146,7 @@ func (o *OAuthFlow) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
-	keys = make([]string, len(o.Extensions))
-	z = 0
-	for k := range o.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(o.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashFields("scopes", keys)...)
+	f = append(f, low.HashExtensions(o.Extensions)...)
+	return low.HashStrings(f)
 }
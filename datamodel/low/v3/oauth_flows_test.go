@@ -4,6 +4,7 @@
 package v3
 
 import (
+	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
@@ -263,3 +264,22 @@ password:
 	// hash
 	assert.Equal(t, n.Hash(), n2.Hash())
 }
+
+func TestOAuthFlows_Hash_Legacy(t *testing.T) {
+	low.UseLegacyHashes(true)
+	defer low.UseLegacyHashes(false)
+
+	yml := `implicit:
+  authorizationUrl: https://pb33f.io
+x-burger: nice`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+
+	var n OAuthFlows
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], nil)
+
+	// the hash generated by older versions of libopenapi.
+	assert.Equal(t, "ad1d47b271e8ca6f9bc390694a10d396fcde4bf8f14adc84fe5f4e366efdf425", fmt.Sprintf("%x", n.Hash()))
+}
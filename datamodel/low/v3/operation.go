@@ -4,7 +4,6 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
@@ -12,7 +11,6 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Operation is a low-level representation of an OpenAPI 3+ Operation object.
@@ -35,6 +33,7 @@ type Operation struct {
 	Servers      low.NodeReference[[]low.ValueReference[*Server]]
 	Extensions   map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindCallback will attempt to locate a Callback instance by the supplied name.
@@ -54,12 +53,18 @@ func (o *Operation) FindSecurityRequirement(name string) []low.ValueReference[st
 	return nil
 }
 
+// operationKeys are the keys an Operation can have.
+var operationKeys = []string{base.TagsLabel, SummaryLabel, DescriptionLabel, base.ExternalDocsLabel, "operationId",
+	ParametersLabel, RequestBodyLabel, ResponsesLabel, CallbacksLabel, "deprecated", SecurityLabel, ServersLabel}
+
 // Build will extract external docs, parameters, request body, responses, callbacks, security and servers.
 func (o *Operation) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	o.Reference = new(low.Reference)
+	o.SetNodes(root, idx)
 	o.Extensions = low.ExtractExtensions(root)
+	low.WarnUnknownKeys(root, idx, operationKeys)
 
 	// extract externalDocs
 	extDocs, dErr := low.ExtractObject[*base.ExternalDoc](base.ExternalDocsLabel, root, idx)
@@ -152,33 +157,33 @@ func (o *Operation) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (o *Operation) Hash() [32]byte {
 	var f []string
 	if !o.Summary.IsEmpty() {
-		f = append(f, o.Summary.Value)
+		f = append(f, low.HashField("summary", o.Summary.Value))
 	}
 	if !o.Description.IsEmpty() {
-		f = append(f, o.Description.Value)
+		f = append(f, low.HashField("description", o.Description.Value))
 	}
 	if !o.OperationId.IsEmpty() {
-		f = append(f, o.OperationId.Value)
+		f = append(f, low.HashField("operationId", o.OperationId.Value))
 	}
 	if !o.RequestBody.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.RequestBody.Value))
+		f = append(f, low.HashField("requestBody", low.GenerateHashString(o.RequestBody.Value)))
 	}
 	if !o.Summary.IsEmpty() {
-		f = append(f, o.Summary.Value)
+		f = append(f, low.HashField("summary", o.Summary.Value))
 	}
 	if !o.ExternalDocs.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.ExternalDocs.Value))
+		f = append(f, low.HashField("externalDocs", low.GenerateHashString(o.ExternalDocs.Value)))
 	}
 	if !o.Responses.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.Responses.Value))
+		f = append(f, low.HashField("responses", low.GenerateHashString(o.Responses.Value)))
 	}
 	if !o.Security.IsEmpty() {
 		for k := range o.Security.Value {
-			f = append(f, low.GenerateHashString(o.Security.Value[k].Value))
+			f = append(f, low.HashField("security", low.GenerateHashString(o.Security.Value[k].Value)))
 		}
 	}
 	if !o.Deprecated.IsEmpty() {
-		f = append(f, fmt.Sprint(o.Deprecated.Value))
+		f = append(f, low.HashField("deprecated", fmt.Sprint(o.Deprecated.Value)))
 	}
 	var keys []string
 	keys = make([]string, len(o.Tags.Value))
@@ -186,21 +191,21 @@ func (o *Operation) Hash() [32]byte {
 		keys[k] = o.Tags.Value[k].Value
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("tags", keys)...)
 
 	keys = make([]string, len(o.Servers.Value))
 	for k := range o.Servers.Value {
 		keys[k] = low.GenerateHashString(o.Servers.Value[k].Value)
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("servers", keys)...)
 
 	keys = make([]string, len(o.Parameters.Value))
 	for k := range o.Parameters.Value {
 		keys[k] = low.GenerateHashString(o.Parameters.Value[k].Value)
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("parameters", keys)...)
 
 	keys = make([]string, len(o.Callbacks.Value))
 	z := 0
@@ -209,18 +214,11 @@ func (o *Operation) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("callbacks", keys)...)
 
-	keys = make([]string, len(o.Extensions))
-	z = 0
-	for k := range o.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(o.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashExtensions(o.Extensions)...)
 
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
 
 // methods to satisfy swagger operations interface
@@ -4,7 +4,6 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
@@ -12,7 +11,6 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Parameter represents a high-level OpenAPI 3+ Parameter object, that is backed by a low-level one.
@@ -35,6 +33,7 @@ type Parameter struct {
 	Content         low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*MediaType]]
 	Extensions      map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindContent will attempt to locate a MediaType instance using the specified name.
@@ -62,11 +61,15 @@ func (p *Parameter) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	p.Reference = new(low.Reference)
+	p.SetNodes(root, idx)
 	p.Extensions = low.ExtractExtensions(root)
+	low.WarnDeprecatedKey(root, idx, "allowEmptyValue", "it is likely to be removed in a later version of OpenAPI")
 
 	// handle example if set.
 	_, expLabel, expNode := utils.FindKeyNodeFull(base.ExampleLabel, root.Content)
-	if expNode != nil {
+	if idx.SkipExamples() {
+		p.Example = low.NodeReference[any]{} // set by BuildModel.
+	} else if expNode != nil {
 		p.Example = low.ExtractExample(expNode, expLabel)
 	}
 
@@ -80,15 +83,17 @@ func (p *Parameter) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	}
 
 	// handle examples if set.
-	exps, expsL, expsN, eErr := low.ExtractMap[*base.Example](base.ExamplesLabel, root, idx)
-	if eErr != nil {
-		return eErr
-	}
-	if exps != nil {
-		p.Examples = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*base.Example]]{
-			Value:     exps,
-			KeyNode:   expsL,
-			ValueNode: expsN,
+	if !idx.SkipExamples() {
+		exps, expsL, expsN, eErr := low.ExtractMap[*base.Example](base.ExamplesLabel, root, idx)
+		if eErr != nil {
+			return eErr
+		}
+		if exps != nil {
+			p.Examples = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*base.Example]]{
+				Value:     exps,
+				KeyNode:   expsL,
+				ValueNode: expsN,
+			}
 		}
 	}
 
@@ -109,27 +114,27 @@ func (p *Parameter) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (p *Parameter) Hash() [32]byte {
 	var f []string
 	if p.Name.Value != "" {
-		f = append(f, p.Name.Value)
+		f = append(f, low.HashField("name", p.Name.Value))
 	}
 	if p.In.Value != "" {
-		f = append(f, p.In.Value)
+		f = append(f, low.HashField("in", p.In.Value))
 	}
 	if p.Description.Value != "" {
-		f = append(f, p.Description.Value)
+		f = append(f, low.HashField("description", p.Description.Value))
 	}
-	f = append(f, fmt.Sprint(p.Required.Value))
-	f = append(f, fmt.Sprint(p.Deprecated.Value))
-	f = append(f, fmt.Sprint(p.AllowEmptyValue.Value))
+	f = append(f, low.HashField("required", fmt.Sprint(p.Required.Value)))
+	f = append(f, low.HashField("deprecated", fmt.Sprint(p.Deprecated.Value)))
+	f = append(f, low.HashField("allowEmptyValue", fmt.Sprint(p.AllowEmptyValue.Value)))
 	if p.Style.Value != "" {
-		f = append(f, fmt.Sprint(p.Style.Value))
+		f = append(f, low.HashField("style", fmt.Sprint(p.Style.Value)))
 	}
-	f = append(f, fmt.Sprint(p.Explode.Value))
-	f = append(f, fmt.Sprint(p.AllowReserved.Value))
+	f = append(f, low.HashField("explode", fmt.Sprint(p.Explode.Value)))
+	f = append(f, low.HashField("allowReserved", fmt.Sprint(p.AllowReserved.Value)))
 	if p.Schema.Value != nil {
-		f = append(f, fmt.Sprintf("%x", p.Schema.Value.Schema().Hash()))
+		f = append(f, low.HashField("schema", fmt.Sprintf("%x", p.Schema.Value.Schema().Hash())))
 	}
 	if p.Example.Value != nil {
-		f = append(f, fmt.Sprintf("%x", p.Example.Value))
+		f = append(f, low.HashField("example", fmt.Sprintf("%x", p.Example.Value)))
 	}
 
 	var keys []string
@@ -140,7 +145,7 @@ func (p *Parameter) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("examples", keys)...)
 	keys = make([]string, len(p.Content.Value))
 	z = 0
 	for k := range p.Content.Value {
@@ -148,17 +153,10 @@ func (p *Parameter) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
-	keys = make([]string, len(p.Extensions))
-	z = 0
-	for k := range p.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(p.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("content", keys)...)
+	f = append(f, low.HashExtensions(p.Extensions)...)
 
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
 
 // IsParameter compliance methods.
@@ -282,3 +282,41 @@ content:
 	assert.Len(t, n.GetExamples().Value.(map[low.KeyReference[string]]low.ValueReference[*base.Example]), 2)
 	assert.Len(t, n.GetContent().Value.(map[low.KeyReference[string]]low.ValueReference[*MediaType]), 1)
 }
+
+func TestParameter_Build_SkipExamples(t *testing.T) {
+	yml := `name: happy
+in: path
+example: hello
+examples:
+  nice:
+    value: there
+schema:
+  type: string
+  example: hello
+content:
+  family/love:
+    example: hello
+    examples:
+      nice:
+        value: there`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, &index.SpecIndexConfig{SkipExamples: true})
+
+	var n Parameter
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, "happy", n.Name.Value)
+	assert.True(t, n.Example.IsEmpty())
+	assert.True(t, n.Examples.IsEmpty())
+	assert.Equal(t, "string", n.Schema.Value.Schema().Type.Value.A)
+	assert.True(t, n.Schema.Value.Schema().Example.IsEmpty())
+	mt := n.FindContent("family/love").Value
+	assert.True(t, mt.Example.IsEmpty())
+	assert.True(t, mt.Examples.IsEmpty())
+}
@@ -4,7 +4,6 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"sort"
 	"strings"
@@ -37,16 +36,17 @@ type PathItem struct {
 	Parameters  low.NodeReference[[]low.ValueReference[*Parameter]]
 	Extensions  map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *PathItem) Hash() [32]byte {
 	var f []string
 	if !p.Description.IsEmpty() {
-		f = append(f, p.Description.Value)
+		f = append(f, low.HashField("description", p.Description.Value))
 	}
 	if !p.Summary.IsEmpty() {
-		f = append(f, p.Summary.Value)
+		f = append(f, low.HashField("summary", p.Summary.Value))
 	}
 	if !p.Get.IsEmpty() {
 		f = append(f, fmt.Sprintf("%s-%s", GetLabel, low.GenerateHashString(p.Get.Value)))
@@ -77,23 +77,16 @@ func (p *PathItem) Hash() [32]byte {
 		keys[k] = low.GenerateHashString(p.Parameters.Value[k].Value)
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("parameters", keys)...)
 	keys = make([]string, len(p.Servers.Value))
 	for k := range p.Servers.Value {
 		keys[k] = low.GenerateHashString(p.Servers.Value[k].Value)
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("servers", keys)...)
 
-	keys = make([]string, len(p.Extensions))
-	z := 0
-	for k := range p.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(p.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(p.Extensions)...)
+	return low.HashStrings(f)
 }
 
 // FindExtension attempts to find an extension
@@ -106,13 +99,19 @@ func (p *PathItem) GetExtensions() map[low.KeyReference[string]]low.ValueReferen
 	return p.Extensions
 }
 
+// pathItemKeys are the keys a PathItem can have.
+var pathItemKeys = []string{"$ref", SummaryLabel, DescriptionLabel, GetLabel, PutLabel, PostLabel, DeleteLabel,
+	OptionsLabel, HeadLabel, PatchLabel, TraceLabel, ServersLabel, ParametersLabel}
+
 // Build extracts extensions, parameters, servers and each http method defined.
 // everything is extracted asynchronously for speed.
 func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	p.Reference = new(low.Reference)
+	p.SetNodes(root, idx)
 	p.Extensions = low.ExtractExtensions(root)
+	low.WarnUnknownKeys(root, idx, pathItemKeys)
 	skip := false
 	var currentNode *yaml.Node
 
@@ -143,6 +142,7 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 					srvr := new(Server)
 					_ = low.BuildModel(srvN, srvr)
 					srvr.Build(srvN, idx)
+					srvr.SetOrigin(srvN, idx)
 					servers = append(servers, low.ValueReference[*Server]{
 						Value:     srvr,
 						ValueNode: srvN,
@@ -200,6 +200,7 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		var op Operation
 		opIsRef := false
 		var opRefVal string
+		var opErr error
 		if ok, _, ref := utils.IsNodeRefValue(pathNode); ok {
 			// According to OpenAPI spec the only valid $ref for paths is
 			// reference for the whole pathItem. Unfortunately, internet is full of invalid specs
@@ -225,12 +226,18 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 
 				if err != nil {
 					if !idx.AllowCircularReferenceResolving() {
-						return fmt.Errorf("build schema failed: %s", err.Error())
+						return low.NewBuildError(low.ErrorCircularReference, pathNode, idx, nil,
+							"build schema failed: %s", err.Error())
 					}
 				}
 			} else {
-				return fmt.Errorf("path item build failed: cannot find reference: %s at line %d, col %d",
+				opErr = low.NewBuildError(low.ErrorReferenceNotFound, pathNode.Content[1], idx, nil,
+					"path item build failed: cannot find reference: %s at line %d, col %d",
 					pathNode.Content[1].Value, pathNode.Content[1].Line, pathNode.Content[1].Column)
+				if !low.TolerateBuildError(idx, opErr) {
+					return opErr
+				}
+				pathNode = low.UnresolvedNode(pathNode)
 			}
 		}
 		wg.Add(1)
@@ -240,6 +247,7 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			Value:     &op,
 			KeyNode:   currentNode,
 			ValueNode: pathNode,
+			Error:     opErr,
 		}
 		if opIsRef {
 			opRef.Reference = opRefVal
@@ -247,39 +255,28 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		}
 
 		ops = append(ops, opRef)
-
-		switch currentNode.Value {
-		case GetLabel:
-			p.Get = opRef
-		case PostLabel:
-			p.Post = opRef
-		case PutLabel:
-			p.Put = opRef
-		case PatchLabel:
-			p.Patch = opRef
-		case DeleteLabel:
-			p.Delete = opRef
-		case HeadLabel:
-			p.Head = opRef
-		case OptionsLabel:
-			p.Options = opRef
-		case TraceLabel:
-			p.Trace = opRef
-		}
+		p.setOperation(opRef)
 	}
 
 	// all operations have been superficially built,
 	// now we need to build out the operation, we will do this asynchronously for speed.
 	opBuildChan := make(chan bool)
 	opErrorChan := make(chan error)
+	opErrors := make([]error, len(ops)) // errors of operations that could not be built, when tolerated.
 
-	buildOpFunc := func(op low.NodeReference[*Operation], ch chan<- bool, errCh chan<- error, ref string) {
+	buildOpFunc := func(slot int, op low.NodeReference[*Operation], ch chan<- bool, errCh chan<- error, ref string) {
 		er := op.Value.Build(op.ValueNode, idx)
+		low.SetOrigin(op.Value, op.ValueNode, idx)
+		low.SetKeyNode(op.Value, op.KeyNode)
 		if ref != "" {
 			op.Value.Reference.Reference = ref
 		}
-		if er != nil {
-			errCh <- er
+		if er != nil && op.Error == nil {
+			if low.TolerateBuildError(idx, er) {
+				opErrors[slot] = er
+			} else {
+				errCh <- er
+			}
 		}
 		ch <- true
 	}
@@ -288,12 +285,12 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		return nil // nothing to do.
 	}
 
-	for _, op := range ops {
+	for i, op := range ops {
 		ref := ""
 		if op.ReferenceNode {
 			ref = op.Reference
 		}
-		go buildOpFunc(op, opBuildChan, opErrorChan, ref)
+		go buildOpFunc(i, op, opBuildChan, opErrorChan, ref)
 	}
 
 	n := 0
@@ -306,6 +303,12 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			n++
 		}
 	}
+	for i, er := range opErrors {
+		if er != nil {
+			ops[i].Error = er
+			p.setOperation(ops[i])
+		}
+	}
 
 	// make sure we don't exit before the path is finished building.
 	if len(ops) > 0 {
@@ -313,3 +316,25 @@ func (p *PathItem) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	}
 	return nil
 }
+
+// setOperation sets the operation for the http method the operation is defined by (the key of the operation).
+func (p *PathItem) setOperation(op low.NodeReference[*Operation]) {
+	switch op.KeyNode.Value {
+	case GetLabel:
+		p.Get = op
+	case PostLabel:
+		p.Post = op
+	case PutLabel:
+		p.Put = op
+	case PatchLabel:
+		p.Patch = op
+	case DeleteLabel:
+		p.Delete = op
+	case HeadLabel:
+		p.Head = op
+	case OptionsLabel:
+		p.Options = op
+	case TraceLabel:
+		p.Trace = op
+	}
+}
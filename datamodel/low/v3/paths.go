@@ -4,7 +4,6 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"sort"
 	"strings"
@@ -25,6 +24,7 @@ type Paths struct {
 	PathItems  map[low.KeyReference[string]]low.ValueReference[*PathItem]
 	Extensions map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindPath will attempt to locate a PathItem using the provided path string.
@@ -62,6 +62,7 @@ func (p *Paths) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	p.Reference = new(low.Reference)
+	p.SetNodes(root, idx)
 	p.Extensions = low.ExtractExtensions(root)
 	skip := false
 	var currentNode *yaml.Node
@@ -77,46 +78,12 @@ func (p *Paths) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	bChan := make(chan pathBuildResult)
 	eChan := make(chan error)
 	buildPathItem := func(cNode, pNode *yaml.Node, b chan<- pathBuildResult, e chan<- error) {
-		if ok, _, _ := utils.IsNodeRefValue(pNode); ok {
-			r, err := low.LocateRefNode(pNode, idx)
-			if r != nil {
-				pNode = r
-				if r.Tag == "" {
-					// If it's a node from file, tag is empty
-					// If it's a reference we need to extract actual operation node
-					pNode = r.Content[0]
-				}
-
-				if err != nil {
-					if !idx.AllowCircularReferenceResolving() {
-						e <- fmt.Errorf("path item build failed: %s", err.Error())
-						return
-					}
-				}
-			} else {
-				e <- fmt.Errorf("path item build failed: cannot find reference: %s at line %d, col %d",
-					pNode.Content[1].Value, pNode.Content[1].Line, pNode.Content[1].Column)
-				return
-			}
-		}
-
-		path := new(PathItem)
-		_ = low.BuildModel(pNode, path)
-		err := path.Build(pNode, idx)
+		k, v, err := BuildPathItem(cNode, pNode, idx)
 		if err != nil {
 			e <- err
 			return
 		}
-		b <- pathBuildResult{
-			k: low.KeyReference[string]{
-				Value:   cNode.Value,
-				KeyNode: cNode,
-			},
-			v: low.ValueReference[*PathItem]{
-				Value:     path,
-				ValueNode: pNode,
-			},
-		}
+		b <- pathBuildResult{k: k, v: v}
 	}
 
 	pathCount := 0
@@ -145,12 +112,91 @@ func (p *Paths) Build(root *yaml.Node, idx *index.SpecIndex) error {
 		case res := <-bChan:
 			completedItems++
 			pathsMap[res.k] = res.v
+			idx.ReportProgress(index.Progress{Stage: index.ProgressBuildingPaths, Done: completedItems, Total: pathCount})
 		}
 	}
 	p.PathItems = pathsMap
 	return nil
 }
 
+// BuildWithoutPathItems will only extract extensions, none of the PathItems are built. Individual PathItems can be
+// built using BuildPathItem, when they are needed.
+func (p *Paths) BuildWithoutPathItems(root *yaml.Node, idx *index.SpecIndex) error {
+	root = utils.NodeAlias(root)
+	utils.CheckForMergeNodes(root)
+	p.Reference = new(low.Reference)
+	p.SetNodes(root, idx)
+	p.Extensions = low.ExtractExtensions(root)
+	p.PathItems = make(map[low.KeyReference[string]]low.ValueReference[*PathItem])
+	return nil
+}
+
+// BuildPathItem will build a single PathItem from the key and value nodes of a path, following the value if it's a
+// reference.
+func BuildPathItem(keyNode, valueNode *yaml.Node, idx *index.SpecIndex) (low.KeyReference[string],
+	low.ValueReference[*PathItem], error) {
+	var refValue string
+	var refNode *yaml.Node
+	var buildErr error
+	pNode := valueNode
+	if ok, _, ref := utils.IsNodeRefValue(pNode); ok {
+		refValue = ref
+		refNode = pNode
+		r, err := low.LocateRefNode(pNode, idx)
+		if r != nil {
+			pNode = r
+			if r.Tag == "" {
+				// If it's a node from file, tag is empty
+				// If it's a reference we need to extract actual operation node
+				pNode = r.Content[0]
+			}
+
+			if err != nil {
+				if !idx.AllowCircularReferenceResolving() {
+					return low.KeyReference[string]{}, low.ValueReference[*PathItem]{},
+						low.NewBuildError(low.ErrorCircularReference, refNode, idx, nil,
+							"path item build failed: %s", err.Error())
+				}
+			}
+		} else {
+			buildErr = low.NewBuildError(low.ErrorReferenceNotFound, pNode.Content[1], idx, nil,
+				"path item build failed: cannot find reference: %s at line %d, col %d",
+				pNode.Content[1].Value, pNode.Content[1].Line, pNode.Content[1].Column)
+			if !low.TolerateBuildError(idx, buildErr) {
+				return low.KeyReference[string]{}, low.ValueReference[*PathItem]{}, buildErr
+			}
+			pNode = low.UnresolvedNode(pNode)
+		}
+	}
+
+	path := new(PathItem)
+	_ = low.BuildModel(pNode, path)
+	err := path.Build(pNode, idx)
+	if err != nil && buildErr == nil {
+		if !low.TolerateBuildError(idx, err) {
+			return low.KeyReference[string]{}, low.ValueReference[*PathItem]{}, err
+		}
+		buildErr = err
+	}
+	low.SetOrigin(path, pNode, idx)
+	low.SetKeyNode(path, keyNode)
+
+	// if this path item is a reference (to components/pathItems for example), keep track of it.
+	if refValue != "" {
+		low.SetReference(path, refValue)
+		low.SetReferenceSiblings(path, refNode)
+		low.SetReferenceNode(path, refNode, idx)
+	}
+	key := low.KeyReference[string]{Value: keyNode.Value, KeyNode: keyNode}
+	return key, low.ValueReference[*PathItem]{
+		Value:         path,
+		ValueNode:     pNode,
+		Reference:     refValue,
+		ReferenceNode: refValue != "",
+		Error:         buildErr,
+	}, nil
+}
+
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *Paths) Hash() [32]byte {
 	var f []string
@@ -164,15 +210,8 @@ func (p *Paths) Hash() [32]byte {
 	}
 	sort.Strings(l)
 	for k := range l {
-		f = append(f, fmt.Sprintf("%s-%s", l[k], low.GenerateHashString(keys[l[k]].Value)))
-	}
-	ekeys := make([]string, len(p.Extensions))
-	z = 0
-	for k := range p.Extensions {
-		ekeys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(p.Extensions[k].Value))))
-		z++
+		f = append(f, low.HashField("pathItems", fmt.Sprintf("%s-%s", l[k], low.GenerateHashString(keys[l[k]].Value))))
 	}
-	sort.Strings(ekeys)
-	f = append(f, ekeys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(p.Extensions)...)
+	return low.HashStrings(f)
 }
@@ -119,6 +119,40 @@ func TestPaths_Build_FailRef(t *testing.T) {
 	assert.Equal(t, "bloody dog ate my biscuit.", anotherPath.Get.Value.Description.Value)
 }
 
+func TestPaths_BuildWithoutPathItems(t *testing.T) {
+
+	yml := `"/some/path":
+ get:
+   description: bloody dog ate my biscuit.
+"/another/path":
+ $ref: '#/~1some~1path'
+"/missing/path":
+ $ref: '#/~1missing'
+x-milk: cold`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Paths
+	err := n.BuildWithoutPathItems(idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Empty(t, n.PathItems)
+	assert.Equal(t, "cold", n.FindExtension("x-milk").Value)
+
+	// path items are built one at a time.
+	root := idxNode.Content[0]
+	k, v, err := BuildPathItem(root.Content[2], root.Content[3], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, "/another/path", k.Value)
+	assert.Equal(t, "#/~1some~1path", v.Reference)
+	assert.True(t, v.ReferenceNode)
+	assert.Equal(t, "bloody dog ate my biscuit.", v.Value.Get.Value.Description.Value)
+
+	_, _, err = BuildPathItem(root.Content[4], root.Content[5], idx)
+	assert.Error(t, err)
+}
+
 func TestPaths_Build_FailRefDeadEnd(t *testing.T) {
 
 	// this is nuts.
@@ -4,14 +4,11 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
-	"strings"
 )
 
 // RequestBody represents a low-level OpenAPI 3+ RequestBody object.
@@ -22,6 +19,7 @@ type RequestBody struct {
 	Required    low.NodeReference[bool]
 	Extensions  map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindExtension attempts to locate an extension using the provided name.
@@ -44,6 +42,7 @@ func (rb *RequestBody) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	rb.Reference = new(low.Reference)
+	rb.SetNodes(root, idx)
 	rb.Extensions = low.ExtractExtensions(root)
 
 	// handle content, if set.
@@ -65,23 +64,16 @@ func (rb *RequestBody) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (rb *RequestBody) Hash() [32]byte {
 	var f []string
 	if rb.Description.Value != "" {
-		f = append(f, rb.Description.Value)
+		f = append(f, low.HashField("description", rb.Description.Value))
 	}
 	if !rb.Required.IsEmpty() {
-		f = append(f, fmt.Sprint(rb.Required.Value))
+		f = append(f, low.HashField("required", fmt.Sprint(rb.Required.Value)))
 	}
 	for k := range rb.Content.Value {
-		f = append(f, low.GenerateHashString(rb.Content.Value[k].Value))
+		f = append(f, low.HashField("content", low.GenerateHashString(rb.Content.Value[k].Value)))
 	}
 
-	keys := make([]string, len(rb.Extensions))
-	z := 0
-	for k := range rb.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(rb.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashExtensions(rb.Extensions)...)
 
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
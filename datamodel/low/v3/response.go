@@ -4,14 +4,12 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Response represents a high-level OpenAPI 3+ Response object that is backed by a low-level one.
@@ -26,6 +24,7 @@ type Response struct {
 	Extensions  map[low.KeyReference[string]]low.ValueReference[any]
 	Links       low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*Link]]
 	*low.Reference
+	low.Nodes
 }
 
 // FindExtension will attempt to locate an extension using the supplied key
@@ -58,6 +57,7 @@ func (r *Response) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	r.Reference = new(low.Reference)
+	r.SetNodes(root, idx)
 	r.Extensions = low.ExtractExtensions(root)
 
 	//extract headers
@@ -104,7 +104,7 @@ func (r *Response) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (r *Response) Hash() [32]byte {
 	var f []string
 	if r.Description.Value != "" {
-		f = append(f, r.Description.Value)
+		f = append(f, low.HashField("description", r.Description.Value))
 	}
 	keys := make([]string, len(r.Headers.Value))
 	z := 0
@@ -113,7 +113,7 @@ func (r *Response) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("headers", keys)...)
 	keys = make([]string, len(r.Content.Value))
 	z = 0
 	for k := range r.Content.Value {
@@ -121,7 +121,7 @@ func (r *Response) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("content", keys)...)
 	keys = make([]string, len(r.Links.Value))
 	z = 0
 	for k := range r.Links.Value {
@@ -129,14 +129,7 @@ func (r *Response) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
-	keys = make([]string, len(r.Extensions))
-	z = 0
-	for k := range r.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(r.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashFields("links", keys)...)
+	f = append(f, low.HashExtensions(r.Extensions)...)
+	return low.HashStrings(f)
 }
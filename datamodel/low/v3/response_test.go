@@ -61,7 +61,7 @@ default:
 	assert.Equal(t, "a link", link.Value.Description.Value)
 
 	// check hash
-	assert.Equal(t, "c009b2046101bc03df802b4cf23f78176931137e6115bf7b445ca46856c06b51",
+	assert.Equal(t, "d262c5e814683ea88dd727be2cba381da4b8aeeac3f42fcb4046ad67ee68c52c",
 		low.GenerateHashString(&n))
 
 }
@@ -95,7 +95,7 @@ x-shoes: old`
 	err = n.Build(idxNode.Content[0], idx)
 
 	// check hash
-	assert.Equal(t, "54ab66e6cb8bd226940f421c2387e45215b84c946182435dfe2a3036043fa07c",
+	assert.Equal(t, "9b9adaf74bd7e85ed7c76a5184255deb4b7cd609ee41120729129faffcbab8f9",
 		low.GenerateHashString(&n))
 
 	assert.Len(t, n.FindResponseByCode("200").Value.GetExtensions(), 1)
@@ -351,3 +351,29 @@ links:
 //	assert.Equal(t, "a link", link.Value.Description.Value)
 //
 //}
+
+func TestResponses_Keys(t *testing.T) {
+
+	yml := `default:
+  description: whatever
+"404":
+  description: nope
+x-middle: 1
+"200":
+  description: ok`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Responses
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(idxNode.Content[0], idx)
+
+	var keys []string
+	for _, k := range n.Keys() {
+		keys = append(keys, k.Value)
+	}
+	assert.Equal(t, []string{"default", "404", "x-middle", "200"}, keys)
+	assert.Equal(t, 1, n.Keys()[0].KeyNode.Line)
+}
@@ -4,14 +4,10 @@
 package v3
 
 import (
-	"crypto/sha256"
-	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
-	"sort"
-	"strings"
 )
 
 // SecurityScheme represents a low-level OpenAPI 3+ SecurityScheme object.
@@ -35,6 +31,7 @@ type SecurityScheme struct {
 	OpenIdConnectUrl low.NodeReference[string]
 	Extensions       map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // FindExtension attempts to locate an extension using the supplied key.
@@ -52,6 +49,7 @@ func (ss *SecurityScheme) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	ss.Reference = new(low.Reference)
+	ss.SetNodes(root, idx)
 	ss.Extensions = low.ExtractExtensions(root)
 
 	oa, oaErr := low.ExtractObject[*OAuthFlows](OAuthFlowsLabel, root, idx)
@@ -68,36 +66,29 @@ func (ss *SecurityScheme) Build(root *yaml.Node, idx *index.SpecIndex) error {
 func (ss *SecurityScheme) Hash() [32]byte {
 	var f []string
 	if !ss.Type.IsEmpty() {
-		f = append(f, ss.Type.Value)
+		f = append(f, low.HashField("type", ss.Type.Value))
 	}
 	if !ss.Description.IsEmpty() {
-		f = append(f, ss.Description.Value)
+		f = append(f, low.HashField("description", ss.Description.Value))
 	}
 	if !ss.Name.IsEmpty() {
-		f = append(f, ss.Name.Value)
+		f = append(f, low.HashField("name", ss.Name.Value))
 	}
 	if !ss.In.IsEmpty() {
-		f = append(f, ss.In.Value)
+		f = append(f, low.HashField("in", ss.In.Value))
 	}
 	if !ss.Scheme.IsEmpty() {
-		f = append(f, ss.Scheme.Value)
+		f = append(f, low.HashField("scheme", ss.Scheme.Value))
 	}
 	if !ss.BearerFormat.IsEmpty() {
-		f = append(f, ss.BearerFormat.Value)
+		f = append(f, low.HashField("bearerFormat", ss.BearerFormat.Value))
 	}
 	if !ss.Flows.IsEmpty() {
-		f = append(f, low.GenerateHashString(ss.Flows.Value))
+		f = append(f, low.HashField("flows", low.GenerateHashString(ss.Flows.Value)))
 	}
 	if !ss.OpenIdConnectUrl.IsEmpty() {
-		f = append(f, ss.OpenIdConnectUrl.Value)
+		f = append(f, low.HashField("openIdConnectUrl", ss.OpenIdConnectUrl.Value))
 	}
-	keys := make([]string, len(ss.Extensions))
-	z := 0
-	for k := range ss.Extensions {
-		keys[z] = fmt.Sprintf("%s-%x", k.Value, sha256.Sum256([]byte(fmt.Sprint(ss.Extensions[k].Value))))
-		z++
-	}
-	sort.Strings(keys)
-	f = append(f, keys...)
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	f = append(f, low.HashExtensions(ss.Extensions)...)
+	return low.HashStrings(f)
 }
@@ -58,7 +58,7 @@ x-milk: please`
 	err = n.Build(idxNode.Content[0], idx)
 	assert.NoError(t, err)
 
-	assert.Equal(t, "0b5ee36519fdfc6383c7befd92294d77b5799cd115911ff8c3e194f345a8c103",
+	assert.Equal(t, "ed785dc030108f394fbada0d913326a8fe9d627e913f9387473ee23c51d6bb50",
 		low.GenerateHashString(&n))
 
 	assert.Equal(t, "tea", n.Type.Value)
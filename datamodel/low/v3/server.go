@@ -4,13 +4,11 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
-	"strings"
 )
 
 // Server represents a low-level OpenAPI 3+ Server object.
@@ -21,6 +19,7 @@ type Server struct {
 	Variables   low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*ServerVariable]]
 	Extensions  map[low.KeyReference[string]]low.ValueReference[any]
 	*low.Reference
+	low.Nodes
 }
 
 // GetExtensions returns all Paths extensions and satisfies the low.HasExtensions interface.
@@ -38,6 +37,7 @@ func (s *Server) Build(root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	s.Reference = new(low.Reference)
+	s.SetNodes(root, idx)
 	s.Extensions = low.ExtractExtensions(root)
 	kn, vars := utils.FindKeyNode(VariablesLabel, root.Content)
 	if vars == nil {
@@ -56,6 +56,8 @@ func (s *Server) Build(root *yaml.Node, idx *index.SpecIndex) error {
 			variable := ServerVariable{}
 			variable.Reference = new(low.Reference)
 			_ = low.BuildModel(varNode, &variable)
+			variable.SetNodes(varNode, idx)
+			variable.SetKeyNode(keyNode)
 			variablesMap[low.KeyReference[string]{
 				Value:   currentNode,
 				KeyNode: keyNode,
@@ -83,12 +85,12 @@ func (s *Server) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("variables", keys)...)
 	if !s.URL.IsEmpty() {
-		f = append(f, s.URL.Value)
+		f = append(f, low.HashField("url", s.URL.Value))
 	}
 	if !s.Description.IsEmpty() {
-		f = append(f, s.Description.Value)
+		f = append(f, low.HashField("description", s.Description.Value))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
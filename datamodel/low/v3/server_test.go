@@ -33,7 +33,7 @@ variables:
 	err = n.Build(idxNode.Content[0], idx)
 	assert.NoError(t, err)
 
-	assert.Equal(t, "ec69dfcf68ad8988f3804e170ee6c4a7ad2e4ac51084796eea93168820827546",
+	assert.Equal(t, "92f942d3b62f10595a7e5fc60ecc4f189e3dbf77863f2c86396ed7af03e855cd",
 		low.GenerateHashString(&n))
 
 	assert.Equal(t, "https://pb33f.io", n.URL.Value)
@@ -43,7 +43,7 @@ variables:
 
 	// test var hash
 	s := n.FindVariable("var1")
-	assert.Equal(t, "00eef99ee4a7b746be7b4ccdece59c5a96222c6206f846fafed782c9f3f9b46b",
+	assert.Equal(t, "1e8d4c253c96fcd4835821df1a72398085304da2d7d97d463485906803fd851d",
 		low.GenerateHashString(s.Value))
 
 	assert.Len(t, n.GetExtensions(), 1)
@@ -1,11 +1,9 @@
 package v3
 
 import (
-	"crypto/sha256"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"sort"
-	"strings"
 )
 
 // ServerVariable represents a low-level OpenAPI 3+ ServerVariable object.
@@ -20,6 +18,7 @@ type ServerVariable struct {
 	Default     low.NodeReference[string]
 	Description low.NodeReference[string]
 	*low.Reference
+	low.Nodes
 }
 
 // Hash will return a consistent SHA256 Hash of the ServerVariable object
@@ -32,12 +31,12 @@ func (s *ServerVariable) Hash() [32]byte {
 		z++
 	}
 	sort.Strings(keys)
-	f = append(f, keys...)
+	f = append(f, low.HashFields("enum", keys)...)
 	if !s.Default.IsEmpty() {
-		f = append(f, s.Default.Value)
+		f = append(f, low.HashField("default", s.Default.Value))
 	}
 	if !s.Description.IsEmpty() {
-		f = append(f, s.Description.Value)
+		f = append(f, low.HashField("description", s.Description.Value))
 	}
-	return sha256.Sum256([]byte(strings.Join(f, "|")))
+	return low.HashStrings(f)
 }
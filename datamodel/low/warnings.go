@@ -0,0 +1,56 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// WarnUnknownKeys will add a warning to the index for every key of a map node that is not one of the known keys,
+// and is not an extension.
+func WarnUnknownKeys(root *yaml.Node, idx *index.SpecIndex, known []string) {
+	if idx == nil || root == nil {
+		return
+	}
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		key := root.Content[i].Value
+		if isExtensionKey(key) || key == "<<" || isKnownKey(key, known) {
+			continue
+		}
+		idx.AddWarning(&index.Warning{
+			Type:    index.WarningUnknownKey,
+			Message: fmt.Sprintf("key '%s' is not defined by the specification", key),
+			Node:    root.Content[i],
+		})
+	}
+}
+
+// WarnDeprecatedKey will add a warning to the index if a map node contains a deprecated key.
+func WarnDeprecatedKey(root *yaml.Node, idx *index.SpecIndex, key, reason string) {
+	if idx == nil || root == nil {
+		return
+	}
+	for i := 0; i < len(root.Content)-1; i += 2 {
+		if root.Content[i].Value == key {
+			idx.AddWarning(&index.Warning{
+				Type:    index.WarningDeprecated,
+				Message: fmt.Sprintf("key '%s' is deprecated: %s", key, reason),
+				Node:    root.Content[i],
+			})
+			return
+		}
+	}
+}
+
+func isKnownKey(key string, known []string) bool {
+	for _, k := range known {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
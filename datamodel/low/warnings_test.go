@@ -0,0 +1,44 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWarnUnknownKeys(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`name: pizza
+x-pizza: extension
+toppings: cheese`), &root)
+
+	idx := index.NewSpecIndexWithConfig(&root, index.CreateOpenAPIIndexConfig())
+	WarnUnknownKeys(root.Content[0], idx, []string{"name"})
+	WarnUnknownKeys(root.Content[0], nil, []string{"name"})
+
+	warnings := idx.GetWarnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, index.WarningUnknownKey, warnings[0].Type)
+	assert.Equal(t, "key 'toppings' is not defined by the specification [3:1]", warnings[0].String())
+}
+
+func TestWarnDeprecatedKey(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`name: pizza
+allowEmptyValue: true`), &root)
+
+	idx := index.NewSpecIndexWithConfig(&root, index.CreateOpenAPIIndexConfig())
+	WarnDeprecatedKey(root.Content[0], idx, "name", "use a better name")
+	WarnDeprecatedKey(root.Content[0], idx, "nope", "not there")
+	WarnDeprecatedKey(nil, idx, "name", "no node")
+
+	warnings := idx.GetWarnings()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, index.WarningDeprecated, warnings[0].Type)
+	assert.Equal(t, "key 'name' is deprecated: use a better name", warnings[0].Message)
+}
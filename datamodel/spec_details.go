@@ -0,0 +1,190 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the version of a specification, parsed from its 'openapi', 'swagger' or 'asyncapi' value. Parts of the
+// version that are missing are zero, so '3.1' is 3.1.0.
+type Version struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+// ParseVersion parses a version like '3.1.0' into a Version. Anything after the numbers of a part (like the '-rc1'
+// of '3.2.0-rc1') is ignored. An error is returned if the major version is not a number.
+func ParseVersion(version string) (Version, error) {
+	var v Version
+	parts := strings.SplitN(strings.TrimSpace(version), ".", 3)
+	for i, p := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if i >= len(parts) {
+			break
+		}
+		digits := strings.IndexFunc(parts[i], func(r rune) bool { return r < '0' || r > '9' })
+		if digits < 0 {
+			digits = len(parts[i])
+		}
+		n, err := strconv.Atoi(parts[i][:digits])
+		if err != nil {
+			if i == 0 {
+				return Version{}, fmt.Errorf("unable to parse version: %s", version)
+			}
+			break
+		}
+		*p = n
+	}
+	return v, nil
+}
+
+// String returns the version as 'major.minor.patch'.
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Is returns true if the version is the major and minor version, with any patch, so a 3.1.1 version Is(3, 1).
+func (v Version) Is(major, minor int) bool {
+	return v.Major == major && v.Minor == minor
+}
+
+// AtLeast returns true if the version is the major and minor version, or later.
+func (v Version) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// FileFormat is the format a specification is written in.
+type FileFormat int
+
+const (
+	// YAMLFormat is a specification written in YAML.
+	YAMLFormat FileFormat = iota
+
+	// JSONFormat is a specification written in JSON.
+	JSONFormat
+)
+
+// String returns the file type of the format, JSONFileType or YAMLFileType.
+func (f FileFormat) String() string {
+	if f == JSONFormat {
+		return JSONFileType
+	}
+	return YAMLFileType
+}
+
+// IndentStyle is how a specification is indented, Width is the number of spaces (or tabs) used for each level.
+type IndentStyle struct {
+	Width int  `json:"width"`
+	Tabs  bool `json:"tabs"`
+}
+
+// Fragment is the kind of specification fragment a document is, when it is not a complete specification.
+type Fragment int
+
+const (
+	// NotFragment is a complete specification, or a document that isn't recognized as a fragment.
+	NotFragment Fragment = iota
+
+	// SchemaFragment is a document that contains a lone schema.
+	SchemaFragment
+
+	// PathItemFragment is a document that contains a lone path item.
+	PathItemFragment
+
+	// ComponentsFragment is a document that contains components (or a 'components' object) without the rest of a
+	// specification.
+	ComponentsFragment
+)
+
+var fragmentNames = map[Fragment]string{
+	NotFragment:        "none",
+	SchemaFragment:     "schema",
+	PathItemFragment:   "path item",
+	ComponentsFragment: "components",
+}
+
+// String returns the name of the fragment kind, like 'schema'.
+func (f Fragment) String() string {
+	return fragmentNames[f]
+}
+
+var pathItemKeys = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+var componentsKeys = []string{"components", "schemas", "responses", "parameters", "examples", "requestBodies",
+	"headers", "securitySchemes", "links", "callbacks", "pathItems"}
+
+var schemaKeys = []string{"$schema", "$ref", "$id", "type", "properties", "items", "allOf", "oneOf", "anyOf",
+	"not", "enum", "const", "additionalProperties", "required", "format"}
+
+// detectDetails sets the typed details of a specification (version, dialect, format, indentation and fragment) from
+// its parsed root node. spec is the UTF-8 bytes of the specification, or nil if they are not held onto.
+func (si *SpecInfo) detectDetails(root *yaml.Node, spec []byte) {
+	si.FileFormat = YAMLFormat
+	if si.SpecFileType == JSONFileType {
+		si.FileFormat = JSONFormat
+	}
+	si.Indentation = IndentStyle{Width: si.OriginalIndentation}
+	if spec != nil {
+		si.Indentation.Tabs = indentedWithTabs(spec)
+		if si.Indentation.Tabs {
+			si.Indentation.Width = 1
+		}
+	}
+	si.JSONSchemaDialect = index.RootDialect(root)
+	if root == nil || len(root.Content) == 0 || !utils.IsNodeMap(root.Content[0]) {
+		return
+	}
+	top := root.Content[0].Content
+	for _, key := range []string{utils.OpenApi3, utils.OpenApi2, utils.AsyncApi} {
+		if _, v := utils.FindKeyNodeTop(key, top); v != nil {
+			si.SpecVersion, _ = ParseVersion(v.Value)
+			return
+		}
+	}
+	si.Fragment = detectFragment(top)
+}
+
+// detectFragment returns the kind of fragment a document is, from the keys at the top of it.
+func detectFragment(top []*yaml.Node) Fragment {
+	has := func(keys []string) bool {
+		for _, k := range keys {
+			if _, v := utils.FindKeyNodeTop(k, top); v != nil {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has(pathItemKeys):
+		return PathItemFragment
+	case has(schemaKeys):
+		return SchemaFragment
+	case has(componentsKeys):
+		return ComponentsFragment
+	}
+	return NotFragment
+}
+
+// indentedWithTabs returns true if the first indented line of a specification is indented with a tab.
+func indentedWithTabs(spec []byte) bool {
+	for _, line := range strings.Split(string(spec), "\n") {
+		if len(line) == 0 || strings.TrimSpace(line) == "" {
+			continue
+		}
+		switch line[0] {
+		case '\t':
+			return true
+		case ' ':
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	v, err := ParseVersion("3.1.1")
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 3, Minor: 1, Patch: 1}, v)
+	assert.Equal(t, "3.1.1", v.String())
+	assert.True(t, v.Is(3, 1))
+	assert.True(t, v.AtLeast(3, 0))
+	assert.True(t, v.AtLeast(3, 1))
+	assert.False(t, v.AtLeast(3, 2))
+
+	v, err = ParseVersion("3.2")
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 3, Minor: 2}, v)
+
+	v, err = ParseVersion("3.2.0-rc1")
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 3, Minor: 2}, v)
+
+	_, err = ParseVersion("false")
+	assert.Error(t, err)
+}
+
+func TestExtractSpecInfo_Details(t *testing.T) {
+	info, err := ExtractSpecInfo([]byte("openapi: 3.1.1\ninfo:\n  title: pizza\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 3, Minor: 1, Patch: 1}, info.SpecVersion)
+	assert.Equal(t, OpenAPI31SchemaData, info.APISchema)
+	assert.Equal(t, index.DialectOpenAPI31Base, info.JSONSchemaDialect)
+	assert.Equal(t, YAMLFormat, info.FileFormat)
+	assert.Equal(t, IndentStyle{Width: 2}, info.Indentation)
+	assert.Equal(t, NotFragment, info.Fragment)
+
+	info, err = ExtractSpecInfo([]byte("openapi: 3.0.3\ninfo:\n    title: pizza\n"))
+	assert.NoError(t, err)
+	assert.True(t, info.SpecVersion.Is(3, 0))
+	assert.Equal(t, OpenAPI3SchemaData, info.APISchema)
+	assert.Equal(t, index.DialectOpenAPI30, info.JSONSchemaDialect)
+	assert.Equal(t, IndentStyle{Width: 4}, info.Indentation)
+
+	info, err = ExtractSpecInfo([]byte("{\n\t\"swagger\": \"2.0\",\n\t\"info\": {\n\t\t\"title\": \"pizza\"\n\t}\n}"))
+	assert.NoError(t, err)
+	assert.Equal(t, Version{Major: 2}, info.SpecVersion)
+	assert.Equal(t, index.DialectSwagger20, info.JSONSchemaDialect)
+	assert.Equal(t, JSONFormat, info.FileFormat)
+	assert.Equal(t, "json", info.FileFormat.String())
+	assert.Equal(t, IndentStyle{Width: 1, Tabs: true}, info.Indentation)
+}
+
+func TestExtractSpecInfo_Details_Dialect(t *testing.T) {
+	info, err := ExtractSpecInfo([]byte("openapi: 3.1.0\njsonSchemaDialect: " + index.DialectJSONSchema202012 + "\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, index.DialectJSONSchema202012, info.JSONSchemaDialect)
+}
+
+func TestExtractSpecInfo_Details_Fragments(t *testing.T) {
+	fragments := map[string]Fragment{
+		"type: object\nproperties:\n  name:\n    type: string\n":                  SchemaFragment,
+		"summary: pizza\nget:\n  responses:\n    '200':\n      description: ok\n": PathItemFragment,
+		"schemas:\n  Pizza:\n    type: object\n":                                  ComponentsFragment,
+		"components:\n  schemas:\n    Pizza:\n      type: object\n":               ComponentsFragment,
+		"name: kitty\n": NotFragment,
+	}
+	for spec, fragment := range fragments {
+		info, err := ExtractSpecInfo([]byte(spec))
+		assert.Error(t, err)
+		assert.Equal(t, fragment, info.Fragment, spec)
+		assert.Equal(t, Version{}, info.SpecVersion)
+	}
+	assert.Equal(t, "path item", PathItemFragment.String())
+}
+
+func TestExtractSpecInfoFromReader_Details(t *testing.T) {
+	info, err := ExtractSpecInfoFromReader(strings.NewReader("openapi: 3.2.0\ninfo:\n  title: pizza\n"), nil)
+	assert.NoError(t, err)
+	assert.True(t, info.SpecVersion.Is(3, 2))
+	assert.Equal(t, OpenAPI31SchemaData, info.APISchema)
+	assert.Equal(t, IndentStyle{Width: 2}, info.Indentation)
+
+	info, err = ExtractSpecInfoFromReader(strings.NewReader("type: string\n"), nil)
+	assert.Error(t, err)
+	assert.Equal(t, SchemaFragment, info.Fragment)
+}
@@ -4,11 +4,15 @@
 package datamodel
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
@@ -35,6 +39,13 @@ type SpecInfo struct {
 	Generated           time.Time               `json:"-"`
 	JsonParsingChannel  chan bool               `json:"-"`
 	OriginalIndentation int                     `json:"-"` // the original whitespace
+	Encoding            Encoding                `json:"-"` // the original encoding, SpecBytes are always UTF-8.
+
+	SpecVersion       Version     `json:"specVersion"`       // the parsed Version, zero for fragments.
+	JSONSchemaDialect string      `json:"jsonSchemaDialect"` // the default dialect of schemas in the spec.
+	FileFormat        FileFormat  `json:"fileFormat"`        // the typed equivalent of SpecFileType.
+	Indentation       IndentStyle `json:"indentation"`       // how the spec is indented.
+	Fragment          Fragment    `json:"fragment"`          // the kind of fragment, if the spec is not complete.
 }
 
 // GetJSONParsingChannel returns a channel that will close once async JSON parsing is completed.
@@ -57,6 +68,13 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 	specVersion := &SpecInfo{}
 	specVersion.JsonParsingChannel = make(chan bool)
 
+	// specifications are parsed as UTF-8, anything else (like UTF-16 from Windows tooling) is transcoded first.
+	spec, encoding, err := DecodeSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	specVersion.Encoding = encoding
+
 	// set original bytes
 	specVersion.SpecBytes = &spec
 
@@ -71,32 +89,19 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 		specVersion.SpecFileType = YAMLFileType
 	}
 
-	err := yaml.Unmarshal(spec, &parsedSpec)
+	err = yaml.Unmarshal(spec, &parsedSpec)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse specification: %s", err.Error())
 	}
 
 	specVersion.RootNode = &parsedSpec
 
-	_, openAPI3 := utils.FindKeyNode(utils.OpenApi3, parsedSpec.Content)
-	_, openAPI2 := utils.FindKeyNode(utils.OpenApi2, parsedSpec.Content)
-	_, asyncAPI := utils.FindKeyNode(utils.AsyncApi, parsedSpec.Content)
+	// detect the original whitespace indentation
+	specVersion.OriginalIndentation = utils.DetermineWhitespaceLength(string(spec))
+	specVersion.detectDetails(&parsedSpec, spec)
 
 	parseJSON := func(bytes []byte, spec *SpecInfo, parsedNode *yaml.Node) {
 		var jsonSpec map[string]interface{}
-
-		if spec.SpecType == utils.OpenApi3 {
-			switch spec.Version {
-			case "3.1.0", "3.1":
-				spec.APISchema = OpenAPI31SchemaData
-			default:
-				spec.APISchema = OpenAPI3SchemaData
-			}
-		}
-		if spec.SpecType == utils.OpenApi2 {
-			spec.APISchema = OpenAPI2SchemaData
-		}
-
 		if utils.IsYAML(string(bytes)) {
 			_ = parsedNode.Decode(&jsonSpec)
 			b, _ := json.Marshal(&jsonSpec)
@@ -111,70 +116,13 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 	}
 
 	if !bypass {
-		// check for specific keys
-		if openAPI3 != nil {
-			version, majorVersion, versionError := parseVersionTypeData(openAPI3.Value)
-			if versionError != nil {
-				return nil, versionError
-			}
-
-			specVersion.SpecType = utils.OpenApi3
-			specVersion.Version = version
-			specVersion.SpecFormat = OAS3
-
-			// parse JSON
+		// parse JSON once the type is known, even if the version turns out to be wrong for it.
+		if err = specVersion.detectSpecType(&parsedSpec, func() {
 			parseJSON(spec, specVersion, &parsedSpec)
-
-			// double check for the right version, people mix this up.
-			if majorVersion < 3 {
-				specVersion.Error = errors.New("spec is defined as an openapi spec, but is using a swagger (2.0), or unknown version")
-				return specVersion, specVersion.Error
-			}
+		}); err != nil {
+			return nil, err
 		}
-
-		if openAPI2 != nil {
-			version, majorVersion, versionError := parseVersionTypeData(openAPI2.Value)
-			if versionError != nil {
-				return nil, versionError
-			}
-
-			specVersion.SpecType = utils.OpenApi2
-			specVersion.Version = version
-			specVersion.SpecFormat = OAS2
-
-			// parse JSON
-			parseJSON(spec, specVersion, &parsedSpec)
-
-			// I am not certain this edge-case is very frequent, but let's make sure we handle it anyway.
-			if majorVersion > 2 {
-				specVersion.Error = errors.New("spec is defined as a swagger (openapi 2.0) spec, but is an openapi 3 or unknown version")
-				return specVersion, specVersion.Error
-			}
-		}
-		if asyncAPI != nil {
-			version, majorVersion, versionErr := parseVersionTypeData(asyncAPI.Value)
-			if versionErr != nil {
-				return nil, versionErr
-			}
-
-			specVersion.SpecType = utils.AsyncApi
-			specVersion.Version = version
-			// TODO: format for AsyncAPI.
-
-			// parse JSON
-			parseJSON(spec, specVersion, &parsedSpec)
-
-			// so far there is only 2 as a major release of AsyncAPI
-			if majorVersion > 2 {
-				specVersion.Error = errors.New("spec is defined as asyncapi, but has a major version that is invalid")
-				return specVersion, specVersion.Error
-			}
-		}
-
-		if specVersion.SpecType == "" {
-			// parse JSON
-			parseJSON(spec, specVersion, &parsedSpec)
-			specVersion.Error = errors.New("spec type not supported by libopenapi, sorry")
+		if specVersion.Error != nil {
 			return specVersion, specVersion.Error
 		}
 	} else {
@@ -192,9 +140,6 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 		close(specVersion.JsonParsingChannel) // this needs removing at some point
 	}
 
-	// detect the original whitespace indentation
-	specVersion.OriginalIndentation = utils.DetermineWhitespaceLength(string(spec))
-
 	return specVersion, nil
 
 }
@@ -208,6 +153,144 @@ func ExtractSpecInfo(spec []byte) (*SpecInfo, error) {
 	return ExtractSpecInfoWithDocumentCheck(spec, false)
 }
 
+// ExtractSpecInfoFromReader will parse a specification read from a reader, without keeping the bytes of the
+// specification. The whole specification is still parsed into a node tree, but the copies of it (the SpecBytes,
+// SpecJSONBytes and SpecJSON of the SpecInfo) are not set, so huge specifications are held in memory once rather
+// than several times over.
+func ExtractSpecInfoFromReader(reader io.Reader, config *DocumentConfiguration) (*SpecInfo, error) {
+	specVersion := &SpecInfo{JsonParsingChannel: make(chan bool)}
+	close(specVersion.JsonParsingChannel) // there is no JSON to parse.
+
+	r := bufio.NewReader(reader)
+	if mark, _ := r.Peek(4); len(mark) > 0 {
+		if encoding, bom := DetectEncoding(mark); encoding == UTF8BOM {
+			_, _ = r.Discard(len(byteOrderMarks[UTF8BOM]))
+			specVersion.Encoding = encoding
+		} else if encoding != UTF8 || bom {
+			// other encodings can't be decoded as they are read, so the specification is transcoded in one go.
+			spec, err := io.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			if spec, specVersion.Encoding, err = DecodeSpec(spec); err != nil {
+				return nil, err
+			}
+			r = bufio.NewReader(bytes.NewReader(spec))
+		}
+	}
+	first, err := firstNonSpace(r)
+	if err != nil {
+		return specVersion, errors.New("there is nothing in the spec, it's empty - so there is nothing to be done")
+	}
+	specVersion.SpecFileType = YAMLFileType
+	if first == '{' {
+		specVersion.SpecFileType = JSONFileType
+	}
+
+	var parsedSpec yaml.Node
+	if err = yaml.NewDecoder(r).Decode(&parsedSpec); err != nil {
+		return nil, fmt.Errorf("unable to parse specification: %s", err.Error())
+	}
+	specVersion.RootNode = &parsedSpec
+	specVersion.OriginalIndentation = nodeIndentation(&parsedSpec)
+	specVersion.detectDetails(&parsedSpec, nil)
+	if config != nil && config.BypassDocumentCheck {
+		return specVersion, nil
+	}
+
+	if err = specVersion.detectSpecType(&parsedSpec, nil); err != nil {
+		return nil, err
+	}
+	return specVersion, specVersion.Error
+}
+
+// specTypeChecks are the keys that identify each type of specification, and the major versions that are valid for it.
+var specTypeChecks = []struct {
+	key, format string
+	valid       func(major int) bool
+	invalid     string
+}{
+	{utils.OpenApi3, OAS3, func(major int) bool { return major >= 3 },
+		"spec is defined as an openapi spec, but is using a swagger (2.0), or unknown version"},
+	{utils.OpenApi2, OAS2, func(major int) bool { return major <= 2 },
+		"spec is defined as a swagger (openapi 2.0) spec, but is an openapi 3 or unknown version"},
+	{utils.AsyncApi, "", func(major int) bool { return major <= 2 }, // TODO: format for AsyncAPI.
+		"spec is defined as asyncapi, but has a major version that is invalid"},
+}
+
+// detectSpecType determines the type, version, format and schema of a specification from its root node, detected
+// is called (if not nil) as soon as the type is known. A version that can't be parsed is returned as an error, a
+// version that isn't valid for the type, or a type that isn't supported, is set as the Error of the SpecInfo.
+func (si *SpecInfo) detectSpecType(root *yaml.Node, detected func()) error {
+	for _, c := range specTypeChecks {
+		_, versionNode := utils.FindKeyNode(c.key, root.Content)
+		if versionNode == nil {
+			continue
+		}
+		version, majorVersion, versionError := parseVersionTypeData(versionNode.Value)
+		if versionError != nil {
+			return versionError
+		}
+		si.SpecType = c.key
+		si.Version = version
+		si.SpecFormat = c.format
+		switch {
+		case c.key == utils.OpenApi3 && si.SpecVersion.AtLeast(3, 1):
+			si.APISchema = OpenAPI31SchemaData
+		case c.key == utils.OpenApi3:
+			si.APISchema = OpenAPI3SchemaData
+		case c.key == utils.OpenApi2:
+			si.APISchema = OpenAPI2SchemaData
+		}
+		if detected != nil {
+			detected()
+		}
+		if !c.valid(majorVersion) {
+			si.Error = errors.New(c.invalid)
+		}
+		return nil
+	}
+	if detected != nil {
+		detected()
+	}
+	si.Error = errors.New("spec type not supported by libopenapi, sorry")
+	return nil
+}
+
+// firstNonSpace returns the first character that isn't whitespace, without reading it.
+func firstNonSpace(r *bufio.Reader) (byte, error) {
+	for n := 1; ; n++ {
+		b, err := r.Peek(n)
+		if len(b) < n {
+			if err == nil || errors.Is(err, bufio.ErrBufferFull) {
+				return 0, nil // all whitespace so far, give up looking.
+			}
+			return 0, err
+		}
+		if !unicode.IsSpace(rune(b[n-1])) {
+			return b[n-1], nil
+		}
+	}
+}
+
+// nodeIndentation returns the indentation used by the children of top level keys, the node equivalent of
+// utils.DetermineWhitespaceLength.
+func nodeIndentation(root *yaml.Node) int {
+	if len(root.Content) == 0 {
+		return 0
+	}
+	indent := 0
+	for _, n := range root.Content[0].Content {
+		if len(n.Content) == 0 || n.Style&yaml.FlowStyle != 0 {
+			continue
+		}
+		if c := n.Content[0].Column - 1; c > 0 && (indent == 0 || c < indent) {
+			indent = c
+		}
+	}
+	return indent
+}
+
 // extract version number from specification
 func parseVersionTypeData(d interface{}) (string, int, error) {
 	r := []rune(strings.TrimSpace(fmt.Sprintf("%v", d)))
@@ -6,6 +6,7 @@ package datamodel
 import (
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/pb33f/libopenapi/utils"
@@ -287,6 +288,81 @@ func TestExtractSpecInfo_BadVersion_AsyncAPI(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestExtractSpecInfoFromReader(t *testing.T) {
+	r, e := ExtractSpecInfoFromReader(strings.NewReader("\n\n"+OpenApi3Spec), nil)
+	assert.NoError(t, e)
+	assert.Equal(t, utils.OpenApi3, r.SpecType)
+	assert.Equal(t, OAS3, r.SpecFormat)
+	assert.Equal(t, YAMLFileType, r.SpecFileType)
+	assert.Equal(t, "3.0.1", r.Version)
+	assert.Equal(t, 2, r.OriginalIndentation)
+	assert.Contains(t, r.APISchema, "https://spec.openapis.org/oas/3.0/schema/2021-09-28")
+	assert.Nil(t, r.SpecBytes)
+	assert.Nil(t, r.SpecJSON)
+	<-r.GetJSONParsingChannel()
+
+	// line numbers are not changed by looking for the file type.
+	assert.Equal(t, 3, r.RootNode.Content[0].Content[0].Line)
+
+	r, e = ExtractSpecInfoFromReader(strings.NewReader(OpenApi31), nil)
+	assert.NoError(t, e)
+	assert.Contains(t, r.APISchema, "https://spec.openapis.org/oas/3.1/schema/2022-10-07")
+
+	r, e = ExtractSpecInfoFromReader(strings.NewReader(`{"swagger": "2.0"}`), nil)
+	assert.NoError(t, e)
+	assert.Equal(t, OAS2, r.SpecFormat)
+	assert.Equal(t, JSONFileType, r.SpecFileType)
+
+	r, e = ExtractSpecInfoFromReader(strings.NewReader(AsyncAPISpec), nil)
+	assert.NoError(t, e)
+	assert.Equal(t, utils.AsyncApi, r.SpecType)
+
+	r, e = ExtractSpecInfoFromReader(strings.NewReader(goodYAML), &DocumentConfiguration{BypassDocumentCheck: true})
+	assert.NoError(t, e)
+	assert.Empty(t, r.SpecType)
+	assert.NotNil(t, r.RootNode)
+}
+
+func TestExtractSpecInfoFromReader_Errors(t *testing.T) {
+	_, e := ExtractSpecInfoFromReader(strings.NewReader("  \n "), nil)
+	assert.Error(t, e)
+	_, e = ExtractSpecInfoFromReader(strings.NewReader(badYAML), nil)
+	assert.Error(t, e)
+	_, e = ExtractSpecInfoFromReader(strings.NewReader(goodYAML), nil)
+	assert.Equal(t, "spec type not supported by libopenapi, sorry", e.Error())
+	_, e = ExtractSpecInfoFromReader(strings.NewReader(OpenApiOne), nil)
+	assert.Error(t, e)
+	_, e = ExtractSpecInfoFromReader(strings.NewReader(OpenApi2SpecOdd), nil)
+	assert.Equal(t,
+		"spec is defined as a swagger (openapi 2.0) spec, but is an openapi 3 or unknown version", e.Error())
+	_, e = ExtractSpecInfoFromReader(strings.NewReader(AsyncAPISpecOdd), nil)
+	assert.Equal(t, "spec is defined as asyncapi, but has a major version that is invalid", e.Error())
+	_, e = ExtractSpecInfoFromReader(strings.NewReader("openapi:\n should: fail"), nil)
+	assert.Error(t, e)
+
+	// whitespace longer than the read buffer is read as YAML.
+	r, e := ExtractSpecInfoFromReader(strings.NewReader(strings.Repeat("\n", 5000)+OpenApi3Spec), nil)
+	assert.NoError(t, e)
+	assert.Equal(t, YAMLFileType, r.SpecFileType)
+}
+
+func TestExtractSpecInfo_SameAsReader(t *testing.T) {
+	for _, spec := range []string{OpenApi3Spec, OpenApi31, `{"swagger": "2.0"}`, AsyncAPISpec, goodYAML, OpenApiOne,
+		OpenApi2SpecOdd, AsyncAPISpecOdd, "openapi:\n should: fail"} {
+		fromBytes, bytesErr := ExtractSpecInfo([]byte(spec))
+		fromReader, readerErr := ExtractSpecInfoFromReader(strings.NewReader(spec), nil)
+		assert.Equal(t, bytesErr, readerErr, spec)
+		if fromBytes == nil || fromReader == nil {
+			assert.Equal(t, fromBytes == nil, fromReader == nil, spec)
+			continue
+		}
+		assert.Equal(t, fromBytes.SpecType, fromReader.SpecType, spec)
+		assert.Equal(t, fromBytes.Version, fromReader.Version, spec)
+		assert.Equal(t, fromBytes.SpecFormat, fromReader.SpecFormat, spec)
+		assert.Equal(t, fromBytes.APISchema, fromReader.APISchema, spec)
+	}
+}
+
 func ExampleExtractSpecInfo() {
 
 	// load bytes from openapi spec file.
@@ -0,0 +1,262 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package deprecation inventories the deprecated parts of a specification, for driving deprecation dashboards and
+// tracking when deprecated parts of an API will be removed.
+//
+// Operations, parameters, schemas and schema properties are deprecated when they are marked as 'deprecated', or
+// when they carry a deprecation extension (like 'x-deprecated-at' or 'x-sunset'), which is the only way to deprecate
+// some objects in Swagger documents. The extensions are configurable.
+package deprecation
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/libopenapi/walk"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the kind of object that is deprecated.
+type Kind string
+
+const (
+	// Operation is a deprecated operation.
+	Operation Kind = "operation"
+
+	// Parameter is a deprecated parameter.
+	Parameter Kind = "parameter"
+
+	// Schema is a deprecated schema component (or definition, for Swagger documents).
+	Schema Kind = "schema"
+
+	// Property is a deprecated property of a schema.
+	Property Kind = "property"
+)
+
+// Config configures which extensions are read when taking an inventory.
+type Config struct {
+	// DeprecatedAtExtensions are the extensions holding when an object was deprecated, the first one found is used.
+	// Defaults to 'x-deprecated-at' and 'x-deprecated-since'.
+	DeprecatedAtExtensions []string
+
+	// SunsetExtensions are the extensions holding when a deprecated object will be removed, the first one found is
+	// used. Defaults to 'x-sunset' and 'x-sunset-at'.
+	SunsetExtensions []string
+}
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		DeprecatedAtExtensions: []string{"x-deprecated-at", "x-deprecated-since"},
+		SunsetExtensions:       []string{"x-sunset", "x-sunset-at"},
+	}
+}
+
+// Deprecation is a single deprecated object.
+type Deprecation struct {
+	Kind Kind   `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"` // the operationId (or method and path), or the name of the object.
+
+	// Path and Method are the operation the object belongs to, empty for components.
+	Path    string `json:"path,omitempty" yaml:"path,omitempty"`
+	Method  string `json:"method,omitempty" yaml:"method,omitempty"`
+	Webhook bool   `json:"webhook,omitempty" yaml:"webhook,omitempty"` // the path is the name of a webhook.
+
+	// Deprecated is true when the object is marked as deprecated, it may only carry a deprecation extension.
+	Deprecated   bool   `json:"deprecated" yaml:"deprecated"`
+	DeprecatedAt string `json:"deprecatedAt,omitempty" yaml:"deprecatedAt,omitempty"`
+	Sunset       string `json:"sunset,omitempty" yaml:"sunset,omitempty"`
+
+	Pointer string     `json:"pointer" yaml:"pointer"` // JSON pointer of the object, like '#/paths/~1pets/get'.
+	Line    int        `json:"line,omitempty" yaml:"line,omitempty"`
+	Column  int        `json:"column,omitempty" yaml:"column,omitempty"`
+	Node    *walk.Node `json:"-" yaml:"-"`
+}
+
+// SunsetTime parses the sunset of the deprecation, as an RFC 3339 timestamp, a date (like '2024-06-30') or an
+// HTTP date (as used by the Sunset header). Returns false if there is no sunset, or it can't be parsed.
+func (d *Deprecation) SunsetTime() (time.Time, bool) {
+	return parseTime(d.Sunset)
+}
+
+// DeprecatedAtTime parses when the object was deprecated, the same way as SunsetTime.
+func (d *Deprecation) DeprecatedAtTime() (time.Time, bool) {
+	return parseTime(d.DeprecatedAt)
+}
+
+func parseTime(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02", http.TimeFormat} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Inventory is every deprecated object in a document, in the order they were defined (objects built without a
+// document come last).
+type Inventory struct {
+	Deprecations []*Deprecation `json:"deprecations" yaml:"deprecations"`
+}
+
+// OfKind returns the deprecations of a kind of object.
+func (i *Inventory) OfKind(kind Kind) []*Deprecation {
+	var found []*Deprecation
+	for _, d := range i.Deprecations {
+		if d.Kind == kind {
+			found = append(found, d)
+		}
+	}
+	return found
+}
+
+// SunsetBefore returns the deprecations with a sunset before the supplied time, like those that are overdue for
+// removal. Deprecations without a sunset (or one that can't be parsed) are not included.
+func (i *Inventory) SunsetBefore(t time.Time) []*Deprecation {
+	var found []*Deprecation
+	for _, d := range i.Deprecations {
+		if sunset, ok := d.SunsetTime(); ok && sunset.Before(t) {
+			found = append(found, d)
+		}
+	}
+	return found
+}
+
+// TakeInventory finds every deprecated operation, parameter, schema and schema property in a high-level model (like
+// a *v3.Document or *v2.Swagger). The config can be nil, to use the default configuration.
+func TakeInventory(root any, config *Config) *Inventory {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	inventory := new(Inventory)
+	walk.Walk(root, func(node *walk.Node) walk.Action {
+		if d := config.deprecation(node); d != nil {
+			d.Pointer = "#" + node.JSONPointer()
+			d.Node = node
+			if n := nodePosition(node); n != nil {
+				d.Line, d.Column = n.Line, n.Column
+			}
+			inventory.Deprecations = append(inventory.Deprecations, d)
+		}
+		return walk.Continue
+	})
+	sort.SliceStable(inventory.Deprecations, func(i, j int) bool {
+		a, b := inventory.Deprecations[i], inventory.Deprecations[j]
+		if (a.Line == 0) != (b.Line == 0) {
+			return b.Line == 0
+		}
+		return a.Line < b.Line
+	})
+	return inventory
+}
+
+// deprecation returns the deprecation of an object, or nil if it's not deprecated.
+func (c *Config) deprecation(node *walk.Node) *Deprecation {
+	var d *Deprecation
+	var extensions map[string]any
+	switch v := node.Value.(type) {
+	case *v3.Operation:
+		d = &Deprecation{Kind: Operation, Name: v.OperationId, Deprecated: v.Deprecated != nil && *v.Deprecated}
+		extensions = v.Extensions
+	case *v2.Operation:
+		d = &Deprecation{Kind: Operation, Name: v.OperationId, Deprecated: v.Deprecated}
+		extensions = v.Extensions
+	case *v3.Parameter:
+		d = &Deprecation{Kind: Parameter, Name: v.Name, Deprecated: v.Deprecated}
+		extensions = v.Extensions
+	case *v2.Parameter:
+		d = &Deprecation{Kind: Parameter, Name: v.Name}
+		extensions = v.Extensions
+	case *base.SchemaProxy:
+		d = schemaDeprecation(node)
+		if d == nil {
+			return nil
+		}
+		extensions = v.Schema().Extensions
+	default:
+		return nil
+	}
+	d.DeprecatedAt = firstExtension(node.ValueNode, extensions, c.DeprecatedAtExtensions)
+	d.Sunset = firstExtension(node.ValueNode, extensions, c.SunsetExtensions)
+	if !d.Deprecated && d.DeprecatedAt == "" && d.Sunset == "" {
+		return nil
+	}
+
+	// the operation (or path item) the object belongs to, if any.
+	if len(node.Path) >= 2 && (node.Path[0] == "paths" || node.Path[0] == "webhooks") {
+		d.Path, d.Webhook = node.Path[1], node.Path[0] == "webhooks"
+		if len(node.Path) >= 3 && methods[node.Path[2]] {
+			d.Method = node.Path[2]
+		}
+		if d.Kind == Operation && d.Name == "" {
+			d.Name = fmt.Sprintf("%s %s", d.Method, d.Path)
+		}
+	}
+	return d
+}
+
+var methods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true,
+}
+
+// schemaDeprecation returns an empty deprecation for a schema component or a property, or nil if the schema is
+// neither (or is a reference, the referenced schema is inventoried where it is defined).
+func schemaDeprecation(node *walk.Node) *Deprecation {
+	sp := node.Value.(*base.SchemaProxy)
+	if node.Reference != "" || node.Extension {
+		return nil
+	}
+	p := node.Path
+	var kind Kind
+	switch {
+	case len(p) == 3 && p[0] == "components" && p[1] == "schemas", len(p) == 2 && p[0] == "definitions":
+		kind = Schema
+	case len(p) >= 2 && p[len(p)-2] == "properties":
+		kind = Property
+	default:
+		return nil
+	}
+	sch := sp.Schema()
+	if sch == nil {
+		return nil
+	}
+	return &Deprecation{Kind: kind, Name: p[len(p)-1], Deprecated: sch.Deprecated != nil && *sch.Deprecated}
+}
+
+// firstExtension returns the value of the first extension found, as a string. Scalars are read from the yaml node
+// of the object when there is one, so values like timestamps are kept exactly as they were written.
+func firstExtension(valueNode *yaml.Node, extensions map[string]any, keys []string) string {
+	for _, key := range keys {
+		if valueNode != nil {
+			if _, v := utils.FindKeyNodeTop(key, valueNode.Content); v != nil && v.Kind == yaml.ScalarNode {
+				return v.Value
+			}
+		}
+		switch v := extensions[key].(type) {
+		case nil:
+			continue
+		case string:
+			return v
+		case time.Time:
+			return v.Format(time.RFC3339)
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// nodePosition returns the yaml node that locates an object, the key if there is one.
+func nodePosition(node *walk.Node) *yaml.Node {
+	if node.KeyNode != nil {
+		return node.KeyNode
+	}
+	return node.ValueNode
+}
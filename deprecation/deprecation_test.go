@@ -0,0 +1,133 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package deprecation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeInventory(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: deprecations
+  version: 1.0.0
+paths:
+  /pets:
+    parameters:
+      - name: debug
+        in: query
+        deprecated: true
+    get:
+      operationId: listPets
+      deprecated: true
+      x-sunset: 2024-06-30
+      parameters:
+        - name: limit
+          in: query
+          deprecated: true
+          x-deprecated-at: '2023-01-01T00:00:00Z'
+        - name: offset
+          in: query
+    post:
+      deprecated: true
+      x-sunset: 'Sun, 30 Jun 2030 00:00:00 GMT'
+webhooks:
+  newPet:
+    post:
+      operationId: newPet
+      x-deprecated-since: v2
+components:
+  schemas:
+    Pet:
+      type: object
+      deprecated: true
+      properties:
+        name:
+          type: string
+          deprecated: true
+        tag:
+          type: string
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	inventory := TakeInventory(&m.Model, nil)
+	assert.Len(t, inventory.Deprecations, 7)
+
+	ops := inventory.OfKind(Operation)
+	assert.Len(t, ops, 3)
+	assert.Equal(t, "listPets", ops[0].Name)
+	assert.Equal(t, "2024-06-30", ops[0].Sunset)
+	assert.Equal(t, "#/paths/~1pets/get", ops[0].Pointer)
+	assert.Equal(t, 11, ops[0].Line)
+	assert.Equal(t, "post /pets", ops[1].Name)
+	assert.True(t, ops[1].Deprecated)
+	assert.Equal(t, "newPet", ops[2].Name)
+	assert.True(t, ops[2].Webhook)
+	assert.False(t, ops[2].Deprecated)
+	assert.Equal(t, "v2", ops[2].DeprecatedAt)
+
+	params := inventory.OfKind(Parameter)
+	assert.Len(t, params, 2)
+	assert.Equal(t, "debug", params[0].Name)
+	assert.Equal(t, "/pets", params[0].Path)
+	assert.Empty(t, params[0].Method)
+	assert.Equal(t, "limit", params[1].Name)
+	assert.Equal(t, "get", params[1].Method)
+	at, ok := params[1].DeprecatedAtTime()
+	assert.True(t, ok)
+	assert.Equal(t, 2023, at.Year())
+
+	assert.Equal(t, "Pet", inventory.OfKind(Schema)[0].Name)
+	props := inventory.OfKind(Property)
+	assert.Len(t, props, 1)
+	assert.Equal(t, "#/components/schemas/Pet/properties/name", props[0].Pointer)
+
+	overdue := inventory.SunsetBefore(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, overdue, 1)
+	assert.Equal(t, "listPets", overdue[0].Name)
+	assert.Len(t, inventory.SunsetBefore(time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)), 2)
+}
+
+func TestTakeInventory_Swagger(t *testing.T) {
+	spec := `swagger: 2.0
+paths:
+  /pets:
+    get:
+      deprecated: true
+      parameters:
+        - name: limit
+          in: query
+          type: integer
+          x-removal: 2022-01-01
+definitions:
+  Pet:
+    properties:
+      name:
+        type: string
+        x-removal: 2022-01-01`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+
+	inventory := TakeInventory(&m.Model, &Config{SunsetExtensions: []string{"x-removal"}})
+	assert.Len(t, inventory.Deprecations, 3)
+	assert.Equal(t, "get /pets", inventory.Deprecations[0].Name)
+	assert.Equal(t, Parameter, inventory.Deprecations[1].Kind)
+	assert.Equal(t, "2022-01-01", inventory.Deprecations[1].Sunset)
+	assert.Equal(t, Property, inventory.Deprecations[2].Kind)
+	assert.Equal(t, "#/definitions/Pet/properties/name", inventory.Deprecations[2].Pointer)
+}
@@ -16,16 +16,23 @@ package libopenapi
 import (
 	"errors"
 	"fmt"
+	"io"
+	"path"
+	"sync"
+	"time"
 
 	"github.com/pb33f/libopenapi/index"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
 	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
 	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/resolver"
 	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/libopenapi/validation"
+	"github.com/pb33f/libopenapi/walk"
 	what_changed "github.com/pb33f/libopenapi/what-changed"
 	"github.com/pb33f/libopenapi/what-changed/model"
 	"gopkg.in/yaml.v3"
@@ -48,13 +55,21 @@ type Document interface {
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 2 specifications and will throw an error for
 	// any other types.
-	BuildV2Model() (*DocumentModel[v2high.Swagger], []error)
+	//
+	// Build options can be used to only build parts of the model, models built with options are not kept by the
+	// document, every call builds a new model. When build errors are tolerated (see TolerateBuildErrors), the model
+	// is returned along with the errors.
+	BuildV2Model(options ...BuildOption) (*DocumentModel[v2high.Swagger], []error)
 
 	// BuildV3Model will build out an OpenAPI (version 3+) model from the specification used to create the document
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 3 specifications and will throw an error for
 	// any other types.
-	BuildV3Model() (*DocumentModel[v3high.Document], []error)
+	//
+	// Build options can be used to only build parts of the model, models built with options are not kept by the
+	// document, every call builds a new model. When build errors are tolerated (see TolerateBuildErrors), the model
+	// is returned along with the errors.
+	BuildV3Model(options ...BuildOption) (*DocumentModel[v3high.Document], []error)
 
 	// RenderAndReload will render the high level model as it currently exists (including any mutations, additions
 	// and removals to and from any object in the tree). It will then reload the low level model with the new bytes
@@ -72,6 +87,38 @@ type Document interface {
 	// it's too old, so it should be motivation to upgrade to OpenAPI 3.
 	RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Document], []error)
 
+	// RenderJSON will render the high level model as it currently exists as JSON, using indent to indent each
+	// element (an empty indent renders compact JSON). Keys keep the order of the original specification, rather than
+	// being sorted. The model must have been built first (see BuildV3Model).
+	//
+	// **IMPORTANT** This method only supports OpenAPI Documents, for the same reasons as RenderAndReload.
+	RenderJSON(indent string) ([]byte, error)
+
+	// RenderWithOptions will render the high level model as it currently exists, formatted using the render options.
+	// Without options, the model is rendered in the same format (YAML or JSON) and with the same indentation as the
+	// original specification. The model is always rendered in the same encoding as the original specification
+	// (see datamodel.SpecInfo.Encoding). The model must have been built first (see BuildV3Model).
+	//
+	// **IMPORTANT** This method only supports OpenAPI Documents, for the same reasons as RenderAndReload.
+	RenderWithOptions(options ...RenderOption) ([]byte, error)
+
+	// RenderPreservingFormat will render the high level model as it currently exists, copying every part of the
+	// original specification that has not changed byte-for-byte (including comments and formatting), and only
+	// rendering the parts of the model that have changed, so diffs between the original specification and the
+	// rendered model are as small as possible. Render options are used to render the parts that have changed.
+	//
+	// Specifications written in JSON, or that use anchors, or documents created from a reader (which do not keep the
+	// original bytes) are rendered in full, the same as RenderWithOptions.
+	//
+	// **IMPORTANT** This method only supports OpenAPI Documents, for the same reasons as RenderAndReload.
+	RenderPreservingFormat(options ...RenderOption) ([]byte, error)
+
+	// Clone will return an independent copy of the document. The yaml nodes of the specification are deep copied,
+	// so the clone (and any model built from it) can be mutated without changing this document, and without parsing
+	// the specification again. Models are built from the copied nodes when they are requested, so changes made to the
+	// high level model of this document that have not been rendered (see RenderAndReload) are not cloned.
+	Clone() Document
+
 	// Serialize will re-render a Document back into a []byte slice. If any modifications have been made to the
 	// underlying data model using low level APIs, then those changes will be reflected in the serialized output.
 	//
@@ -89,6 +136,7 @@ type document struct {
 	config            *datamodel.DocumentConfiguration
 	highOpenAPI3Model *DocumentModel[v3high.Document]
 	highSwaggerModel  *DocumentModel[v2high.Swagger]
+	buildLock         sync.Mutex // models can be built from multiple goroutines, only the first builds.
 }
 
 // DocumentModel represents either a Swagger document (version 2) or an OpenAPI document (version 3) that is
@@ -98,6 +146,30 @@ type DocumentModel[T v2high.Swagger | v3high.Document] struct {
 	Index *index.SpecIndex // index created from the document.
 }
 
+// GetWarnings returns every warning found so far while indexing and building the model. Parts of the model that are
+// built lazily add their warnings when they are built.
+func (d *DocumentModel[T]) GetWarnings() []*index.Warning {
+	return d.Index.GetWarnings()
+}
+
+// QueryPointer will locate the high-level object in the model that the supplied JSON pointer points to, for example
+// '/paths/~1pets/get/responses/200'. The node returned contains the typed high-level object (e.g. *v3.Response)
+// and the low-level object and nodes backing it. Schema references are traversed, so a pointer can continue
+// into a schema that has been referenced with $ref.
+func (d *DocumentModel[T]) QueryPointer(pointer string) (*walk.Node, error) {
+	return walk.QueryPointer(&d.Model, pointer)
+}
+
+// QueryPath will evaluate a JSONPath expression against the document the model was built from, for example
+// "$.paths..responses['200']". Every matching yaml node is returned, along with the model object built from it,
+// if there is one.
+func (d *DocumentModel[T]) QueryPath(path string) ([]*walk.PathMatch, error) {
+	if d.Index == nil {
+		return nil, errors.New("unable to query path, the model has no index")
+	}
+	return walk.QueryPath(&d.Model, d.Index.GetRootNode(), path)
+}
+
 // NewDocument will create a new OpenAPI instance from an OpenAPI specification []byte array. If anything goes
 // wrong when parsing, reading or processing the OpenAPI specification, there will be no document returned, instead
 // a slice of errors will be returned that explain everything that failed.
@@ -143,6 +215,50 @@ func NewDocumentWithConfiguration(specByteArray []byte, configuration *datamodel
 	return d, err
 }
 
+// NewDocumentFromReader creates a new Document from a specification read from a reader, the specification is parsed
+// straight from the reader, so its bytes are not kept alongside the parsed node tree (which still holds the whole
+// specification). Because there are no bytes, the SpecBytes, SpecJSONBytes and SpecJSON of the SpecInfo are not set
+// (see datamodel.ExtractSpecInfoFromReader).
+func NewDocumentFromReader(reader io.Reader, configuration *datamodel.DocumentConfiguration) (Document, error) {
+	info, err := datamodel.ExtractSpecInfoFromReader(reader, configuration)
+	if err != nil {
+		return nil, err
+	}
+	d := new(document)
+	d.version = info.Version
+	d.info = info
+	d.SetConfiguration(configuration)
+	return d, nil
+}
+
+// NewDocumentFromArchive creates a new Document from a zip, tar or gzipped tar archive, that contains a root
+// specification along with the files it references. The root is the supplied path in the archive, if empty, the root
+// is read from a manifest, or located by its name (see datamodel.FindArchiveRoot).
+//
+// File references are resolved against the files in the archive only (see DocumentConfiguration.Files), relative to
+// the root specification, so file references are always allowed. The configuration is copied, and the files of the
+// archive are set on the copy. If no configuration is supplied, a closed configuration is used, so remote references
+// are not followed. Archives are limited in size and number of files (see datamodel.ExtractArchive).
+func NewDocumentFromArchive(archive []byte, root string, configuration *datamodel.DocumentConfiguration) (Document, error) {
+	files, err := datamodel.ExtractArchive(archive)
+	if err != nil {
+		return nil, err
+	}
+	root, err = datamodel.FindArchiveRoot(files, root)
+	if err != nil {
+		return nil, err
+	}
+	config := datamodel.NewClosedDocumentConfiguration()
+	if configuration != nil {
+		c := *configuration
+		config = &c
+	}
+	config.Files = files
+	config.BasePath = path.Dir(root)
+	config.AllowFileReferences = true
+	return NewDocumentWithConfiguration(files[root], config)
+}
+
 func (d *document) GetVersion() string {
 	return d.version
 }
@@ -203,8 +319,104 @@ func (d *document) RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Do
 	return newBytes, newDoc, model, nil
 }
 
-func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
-	if d.highSwaggerModel != nil {
+func (d *document) RenderJSON(indent string) ([]byte, error) {
+	if d.highSwaggerModel != nil && d.highOpenAPI3Model == nil {
+		return nil, errors.New("this method only supports OpenAPI 3 documents, not Swagger")
+	}
+	if d.highOpenAPI3Model == nil {
+		return nil, errors.New("unable to render JSON, the model has not yet been built")
+	}
+	return d.highOpenAPI3Model.Model.RenderJSON(indent), nil
+}
+
+func (d *document) RenderWithOptions(options ...RenderOption) ([]byte, error) {
+	return d.renderWithOptions(false, options)
+}
+
+func (d *document) RenderPreservingFormat(options ...RenderOption) ([]byte, error) {
+	return d.renderWithOptions(true, options)
+}
+
+func (d *document) renderWithOptions(preserveFormat bool, options []RenderOption) ([]byte, error) {
+	if d.highSwaggerModel != nil && d.highOpenAPI3Model == nil {
+		return nil, errors.New("this method only supports OpenAPI 3 documents, not Swagger")
+	}
+	if d.highOpenAPI3Model == nil {
+		return nil, errors.New("unable to render, the model has not yet been built")
+	}
+	renderOptions := &high.RenderOptions{Indent: d.info.OriginalIndentation}
+	if d.info.SpecFileType == datamodel.JSONFileType {
+		renderOptions.Format = high.RenderJSON
+	}
+	if preserveFormat && d.info.SpecBytes != nil {
+		renderOptions.Source = *d.info.SpecBytes
+	}
+	for _, option := range options {
+		option(renderOptions)
+	}
+	rendered, err := d.highOpenAPI3Model.Model.RenderWithOptions(renderOptions)
+	if err != nil {
+		return nil, err
+	}
+	return d.info.Encoding.Encode(rendered), nil // in the same encoding as the original specification.
+}
+
+func (d *document) Clone() Document {
+	c := &document{version: d.version}
+	if d.config != nil {
+		config := *d.config
+		c.config = &config
+	}
+	if d.info == nil {
+		return c
+	}
+	info := *d.info
+	info.RootNode = utils.CopyNode(d.info.RootNode)
+	if d.info.SpecBytes != nil {
+		b := append([]byte(nil), *d.info.SpecBytes...)
+		info.SpecBytes = &b
+	}
+	if d.info.SpecJSONBytes != nil {
+		b := append([]byte(nil), *d.info.SpecJSONBytes...)
+		info.SpecJSONBytes = &b
+	}
+	if d.info.SpecJSON != nil {
+		j, _ := copyJSONValue(*d.info.SpecJSON).(map[string]interface{})
+		info.SpecJSON = &j
+	}
+	c.info = &info
+	return c
+}
+
+// copyJSONValue returns a deep copy of a value decoded from JSON or YAML.
+func copyJSONValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = copyJSONValue(e)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(t))
+		for k, e := range t {
+			m[k] = copyJSONValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(t))
+		for i, e := range t {
+			l[i] = copyJSONValue(e)
+		}
+		return l
+	}
+	return v
+}
+
+func (d *document) BuildV2Model(options ...BuildOption) (*DocumentModel[v2high.Swagger], []error) {
+	d.buildLock.Lock()
+	defer d.buildLock.Unlock()
+	if d.highSwaggerModel != nil && len(options) == 0 {
 		return d.highSwaggerModel, nil
 	}
 	var errors []error
@@ -226,28 +438,33 @@ func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
 		}
 	}
 
-	lowDoc, errors = v2low.CreateDocumentFromConfig(d.info, d.config)
-	// Do not short-circuit on circular reference errors, so the client
-	// has the option of ignoring them.
-	for _, err := range errors {
-		if refErr, ok := err.(*resolver.ResolvingError); ok {
-			if refErr.CircularReference == nil {
-				return nil, errors
-			}
-		} else {
+	start := time.Now()
+	config := applyBuildOptions(d.config, options)
+	if config.ValidateSchema {
+		if errors = validateSchema(d.info); len(errors) > 0 {
 			return nil, errors
 		}
 	}
+	lowDoc, errors = v2low.CreateDocumentFromConfig(d.info, config)
+	d.logBuild(start, errors)
+	if buildFailed(config, errors) {
+		return nil, errors
+	}
 	highDoc := v2high.NewSwaggerDocument(lowDoc)
-	d.highSwaggerModel = &DocumentModel[v2high.Swagger]{
+	model := &DocumentModel[v2high.Swagger]{
 		Model: *highDoc,
 		Index: lowDoc.Index,
 	}
-	return d.highSwaggerModel, errors
+	if len(options) == 0 {
+		d.highSwaggerModel = model
+	}
+	return model, errors
 }
 
-func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
-	if d.highOpenAPI3Model != nil {
+func (d *document) BuildV3Model(options ...BuildOption) (*DocumentModel[v3high.Document], []error) {
+	d.buildLock.Lock()
+	defer d.buildLock.Unlock()
+	if d.highOpenAPI3Model != nil && len(options) == 0 {
 		return d.highOpenAPI3Model, nil
 	}
 	var errors []error
@@ -269,24 +486,69 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
 		}
 	}
 
-	lowDoc, errors = v3low.CreateDocumentFromConfig(d.info, d.config)
-	// Do not short-circuit on circular reference errors, so the client
-	// has the option of ignoring them.
-	for _, err := range errors {
-		if refErr, ok := err.(*resolver.ResolvingError); ok {
-			if refErr.CircularReference == nil {
-				return nil, errors
-			}
-		} else {
+	start := time.Now()
+	config := applyBuildOptions(d.config, options)
+	if config.ValidateSchema {
+		if errors = validateSchema(d.info); len(errors) > 0 {
 			return nil, errors
 		}
 	}
-	highDoc := v3high.NewDocument(lowDoc)
-	d.highOpenAPI3Model = &DocumentModel[v3high.Document]{
+	lowDoc, errors = v3low.CreateDocumentFromConfig(d.info, config)
+	d.logBuild(start, errors)
+	if buildFailed(config, errors) {
+		return nil, errors
+	}
+	var highDoc *v3high.Document
+	if d.config.BuildModelLazily {
+		highDoc = v3high.NewDocumentLazily(lowDoc)
+	} else {
+		highDoc = v3high.NewDocument(lowDoc)
+	}
+	model := &DocumentModel[v3high.Document]{
 		Model: *highDoc,
 		Index: lowDoc.Index,
 	}
-	return d.highOpenAPI3Model, errors
+	if len(options) == 0 {
+		d.highOpenAPI3Model = model
+	}
+	return model, errors
+}
+
+// validateSchema checks the specification against the OpenAPI meta-schema for its version, returning every
+// violation as an error.
+func validateSchema(info *datamodel.SpecInfo) []error {
+	violations, err := validation.ValidateDocument(info)
+	if err != nil {
+		return []error{err}
+	}
+	errs := make([]error, len(violations))
+	for i, v := range violations {
+		errs[i] = v
+	}
+	return errs
+}
+
+// buildFailed returns true if there is no model to return, because of the errors hit building the low level model.
+// Do not short-circuit on circular reference errors, so the client has the option of ignoring them. No errors
+// short-circuit when build errors are tolerated, as everything that could be built has been.
+func buildFailed(config *datamodel.DocumentConfiguration, errs []error) bool {
+	if config.TolerateBuildErrors {
+		return false
+	}
+	for _, err := range errs {
+		if refErr, ok := err.(*resolver.ResolvingError); !ok || refErr.CircularReference == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// logBuild logs how long it took to build the low level model, if the configuration has a logger.
+func (d *document) logBuild(start time.Time, errs []error) {
+	if d.config.Logger != nil {
+		d.config.Logger.Debug("built document", "version", d.info.Version, "errors", len(errs),
+			"duration", time.Since(start))
+	}
 }
 
 // CompareDocuments will accept a left and right Document implementing struct, build a model for the correct
@@ -329,3 +591,23 @@ func CompareDocuments(original, updated Document) (*model.DocumentChanges, []err
 	}
 	return nil, []error{fmt.Errorf("unable to compare documents, one or both documents are not of the same version")}
 }
+
+// CompareDocumentsThreeWay will compare a base Document with two documents made from it (left and right), like two
+// branches that modify the same specification. The changes made by each side are returned, along with the edits
+// that conflict (see model.FindConflicts).
+//
+// If there are any errors when building the models, those errors are returned with a nil pointer for the
+// model.ThreeWayChanges.
+func CompareDocumentsThreeWay(base, left, right Document) (*model.ThreeWayChanges, []error) {
+	leftChanges, errs := CompareDocuments(base, left)
+	rightChanges, rErrs := CompareDocuments(base, right)
+	errs = append(errs, rErrs...)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return &model.ThreeWayChanges{
+		Left:      leftChanges,
+		Right:     rightChanges,
+		Conflicts: model.FindConflicts(leftChanges, rightChanges),
+	}, nil
+}
@@ -321,7 +321,7 @@ func ExampleCompareDocuments_openAPI() {
 	// Print out some interesting stats about the OpenAPI document changes.
 	fmt.Printf("There are %d changes, of which %d are breaking. %v schemas have changes.",
 		documentChanges.TotalChanges(), documentChanges.TotalBreakingChanges(), len(schemaChanges))
-	//Output: There are 72 changes, of which 17 are breaking. 5 schemas have changes.
+	//Output: There are 71 changes, of which 16 are breaking. 5 schemas have changes.
 
 }
 
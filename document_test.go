@@ -3,14 +3,24 @@
 package libopenapi
 
 import (
+	"archive/zip"
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -220,10 +230,10 @@ func TestDocument_RenderAndReload_ChangeCheck_Asana(t *testing.T) {
 
 	assert.Nil(t, errs)
 	tc := compReport.TotalChanges()
-	assert.Equal(t, 21, tc)
+	assert.Equal(t, 17, tc)
 
 	// there are some properties re-rendered that trigger changes.
-	assert.Equal(t, 21, len(flatChanges))
+	assert.Equal(t, 17, len(flatChanges))
 
 }
 
@@ -689,3 +699,498 @@ paths:
 
 	assert.Equal(t, spec, strings.TrimSpace(string(rend)))
 }
+
+func TestDocumentModel_QueryPointer(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Len(t, errs, 0)
+
+	node, err := m.QueryPointer("/paths/~1pet~1{petId}/get")
+	assert.NoError(t, err)
+	assert.Equal(t, "getPetById", node.Value.(*v3high.Operation).OperationId)
+
+	node, err = m.QueryPointer("/paths/~1pet~1{petId}/get/responses/200/content/application~1json/schema/properties/category")
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/Category", node.Reference)
+
+	_, err = m.QueryPointer("/paths/~1nope")
+	assert.Error(t, err)
+}
+
+func TestDocumentModel_QueryPath(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Len(t, errs, 0)
+
+	matches, err := m.QueryPath("$.paths['/pet'].*")
+	assert.NoError(t, err)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "updatePet", matches[0].Object.Value.(*v3high.Operation).OperationId)
+
+	m.Index = nil
+	_, err = m.QueryPath("$")
+	assert.Error(t, err)
+}
+
+func createSpecArchive(t *testing.T, extra map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"first.yaml", "second.yaml", "third.yaml"} {
+		data, err := os.ReadFile("test_specs/" + name)
+		assert.NoError(t, err)
+		w, _ := zw.Create("bundle/specs/" + name)
+		_, _ = w.Write(data)
+	}
+	for name, data := range extra {
+		w, _ := zw.Create("bundle/" + name)
+		_, _ = w.Write([]byte(data))
+	}
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func TestNewDocumentFromArchive(t *testing.T) {
+	doc, err := NewDocumentFromArchive(createSpecArchive(t, nil), "specs/first.yaml", nil)
+	assert.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	schema := m.Model.Paths.PathItems["/items"].Get.Responses.Codes["200"].Content["application/json"].Schema.Schema()
+	assert.NotNil(t, schema.AdditionalProperties.A.Schema().Properties["second"])
+}
+
+func TestNewDocumentFromArchive_Manifest(t *testing.T) {
+	archive := createSpecArchive(t, map[string]string{"manifest.yaml": "root: specs/first.yaml"})
+	doc, err := NewDocumentFromArchive(archive, "", &datamodel.DocumentConfiguration{})
+	assert.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+}
+
+func TestNewDocumentFromArchive_NoRoot(t *testing.T) {
+	_, err := NewDocumentFromArchive(createSpecArchive(t, nil), "", nil)
+	assert.Error(t, err)
+
+	_, err = NewDocumentFromArchive([]byte("burgers"), "", nil)
+	assert.Error(t, err)
+}
+
+func TestNewDocumentFromArchive_NoRemoteReferences(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		_, _ = rw.Write([]byte("type: object"))
+	}))
+	defer server.Close()
+
+	spec := fmt.Sprintf(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: '%s/pet.yaml'`, server.URL)
+	archive := createSpecArchive(t, map[string]string{"openapi.yaml": spec})
+	doc, err := NewDocumentFromArchive(archive, "", nil)
+	assert.NoError(t, err)
+	_, _ = doc.BuildV3Model()
+	assert.Zero(t, requests)
+}
+
+func TestDocument_BuildModelLazily(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{BuildModelLazily: true})
+	assert.NoError(t, err)
+
+	// every goroutine gets the same model.
+	models := make(chan *DocumentModel[v3high.Document])
+	for i := 0; i < 5; i++ {
+		go func() {
+			m, _ := doc.BuildV3Model()
+			models <- m
+		}()
+	}
+	m := <-models
+	for i := 1; i < 5; i++ {
+		assert.Same(t, m, <-models)
+	}
+	assert.Empty(t, m.Model.Paths.PathItems)
+
+	burgers := m.Model.Paths.GetPathItem("/burgers")
+	assert.NotNil(t, burgers)
+	assert.Len(t, m.Model.Paths.PathItems, 1)
+	assert.Equal(t, "createBurger", burgers.Post.OperationId)
+
+	// the pointer walks lazy paths too.
+	node, err := m.QueryPointer("/paths/~1burgers~1{burgerId}/get")
+	assert.NoError(t, err)
+	assert.Equal(t, "locateBurger", node.Value.(*v3high.Operation).OperationId)
+	assert.NotNil(t, m.Model.FindOperationByID("locateBurger"))
+
+	// and renders the same as an eager model.
+	eager, _ := NewDocument(spec)
+	em, _ := eager.BuildV3Model()
+	lazyRender, _ := m.Model.Render()
+	eagerRender, _ := em.Model.Render()
+	var lazyDoc, eagerDoc map[string]any
+	assert.NoError(t, yaml.Unmarshal(lazyRender, &lazyDoc))
+	assert.NoError(t, yaml.Unmarshal(eagerRender, &eagerDoc))
+	assert.Equal(t, eagerDoc, lazyDoc)
+}
+
+func TestDocument_Clone(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(spec)
+	assert.NoError(t, err)
+	original, _ := doc.BuildV3Model()
+
+	clone := doc.Clone()
+	assert.Equal(t, doc.GetVersion(), clone.GetVersion())
+	assert.NotSame(t, doc.GetSpecInfo().RootNode, clone.GetSpecInfo().RootNode)
+	assert.Equal(t, *doc.GetSpecInfo().SpecJSON, *clone.GetSpecInfo().SpecJSON)
+
+	// mutate the clone, the original is untouched.
+	cloned, errs := clone.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.NotSame(t, original, cloned)
+	cloned.Model.Info.GoLow().Title.ValueNode.Value = "Pizza Shop"
+	(*clone.GetSpecInfo().SpecJSON)["openapi"] = "3.0.0"
+
+	assert.Equal(t, "Burger Shop", original.Model.Info.GoLow().Title.ValueNode.Value)
+	assert.Equal(t, "3.1.0", (*doc.GetSpecInfo().SpecJSON)["openapi"])
+	rendered, _ := yaml.Marshal(doc.GetSpecInfo().RootNode)
+	assert.NotContains(t, string(rendered), "Pizza Shop")
+
+	// the clone can be compared with the original.
+	cloned.Model.Info.Title = "Pizza Shop"
+	_, reloaded, _, errs := clone.RenderAndReload()
+	assert.Empty(t, errs)
+	changes, errs := CompareDocuments(doc, reloaded)
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, "Burger Shop", original.Model.Info.Title)
+}
+
+func TestDocument_Clone_Empty(t *testing.T) {
+	d := new(document)
+	c := d.Clone()
+	assert.Nil(t, c.GetSpecInfo())
+	_, errs := c.BuildV3Model()
+	assert.Len(t, errs, 1)
+}
+
+func TestDocument_InternStrings(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, _ := NewDocument(spec)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	interned, _ := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{InternStrings: true})
+	im, errs := interned.BuildV3Model()
+	assert.Empty(t, errs)
+
+	assert.Equal(t, len(m.Model.Paths.PathItems), len(im.Model.Paths.PathItems))
+	assert.Equal(t, m.Model.Components.Schemas["Burger"].Schema().Required,
+		im.Model.Components.Schemas["Burger"].Schema().Required)
+	assert.Less(t, im.MemoryFootprint().Nodes, m.MemoryFootprint().Nodes)
+
+	spec, _ = os.ReadFile("test_specs/petstorev2-complete.yaml")
+	interned, _ = NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{InternStrings: true})
+	v2, errs := interned.BuildV2Model()
+	assert.Empty(t, errs)
+	assert.NotNil(t, v2.Model.Definitions.Definitions["Pet"])
+}
+
+func TestNewDocumentFromReader(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocumentFromReader(bytes.NewReader(spec), &datamodel.DocumentConfiguration{InternStrings: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.Nil(t, doc.GetSpecInfo().SpecBytes)
+
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Len(t, m.Model.Paths.PathItems, 5)
+
+	// renders the same as a document created from bytes.
+	fromBytes, _ := NewDocument(spec)
+	bm, _ := fromBytes.BuildV3Model()
+	var rendered, expected map[string]any
+	r, _ := m.Model.Render()
+	e, _ := bm.Model.Render()
+	_ = yaml.Unmarshal(r, &rendered)
+	_ = yaml.Unmarshal(e, &expected)
+	assert.Equal(t, expected, rendered)
+
+	_, err = NewDocumentFromReader(strings.NewReader(""), nil)
+	assert.Error(t, err)
+}
+
+func TestDocument_Warnings(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+pizza: oven
+paths:
+  /pizza:
+    get:
+      topping: cheese
+      parameters:
+        - name: size
+          in: query
+          allowEmptyValue: true
+components:
+  schemas:
+    Pizza:
+      type: object
+      required: [kind]
+      properties:
+        kind:
+          type: string
+      discriminator:
+        propertyName: kind
+        mapping:
+          margherita: '#/components/schemas/Pizza'
+          calzone: '#/components/schemas/Calzone'`
+
+	var streamed []*index.Warning
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		WarningHandler: func(warning *index.Warning) {
+			streamed = append(streamed, warning)
+		},
+	})
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	// schemas are built when they are used.
+	assert.NotNil(t, m.Model.Components.Schemas["Pizza"].Schema())
+
+	warnings := m.GetWarnings()
+	assert.Equal(t, streamed, warnings)
+	found := make(map[index.WarningType][]string)
+	for _, w := range warnings {
+		found[w.Type] = append(found[w.Type], w.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"key 'pizza' is not defined by the specification [4:1]",
+		"key 'topping' is not defined by the specification [8:7]",
+	}, found[index.WarningUnknownKey])
+	assert.Equal(t, []string{"key 'allowEmptyValue' is deprecated: it is likely to be removed in a later " +
+		"version of OpenAPI [12:11]"}, found[index.WarningDeprecated])
+	assert.Equal(t, []string{"discriminator mapping 'calzone' points to '#/components/schemas/Calzone', " +
+		"which cannot be found [25:20]"}, found[index.WarningUnresolvedReference])
+
+	// swagger documents have warnings too.
+	v2, _ := NewDocument([]byte("swagger: 2.0\ninfo:\n  title: pizza\npizza: oven"))
+	v2m, errs := v2.BuildV2Model()
+	assert.Empty(t, errs)
+	assert.Len(t, v2m.GetWarnings(), 1)
+}
+
+type messageLogger struct {
+	lock     sync.Mutex
+	messages []string
+}
+
+func (m *messageLogger) Debug(msg string, _ ...any) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.messages = append(m.messages, msg)
+}
+
+func TestDocument_Logger(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	logger := new(messageLogger)
+	doc, _ := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{Logger: logger})
+	_, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Subset(t, logger.messages, []string{"extracted references", "looked up references", "built index",
+		"checked for circular references", "built section", "built document"})
+	assert.Equal(t, "built document", logger.messages[len(logger.messages)-1])
+
+	spec, _ = os.ReadFile("test_specs/petstorev2.json")
+	logger = new(messageLogger)
+	doc, _ = NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{Logger: logger})
+	_, errs = doc.BuildV2Model()
+	assert.Empty(t, errs)
+	assert.Subset(t, logger.messages, []string{"built index", "checked for circular references", "built document"})
+}
+
+func TestDocument_ProgressHandler(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	var paths []index.Progress
+	resolved := 0
+	doc, _ := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		ProgressHandler: func(progress index.Progress) {
+			switch progress.Stage {
+			case index.ProgressBuildingPaths:
+				paths = append(paths, progress)
+			case index.ProgressResolvingReferences:
+				resolved++
+			}
+		},
+	})
+	_, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Len(t, paths, 5)
+	assert.Equal(t, 100.0, paths[4].Percent())
+	assert.NotZero(t, resolved)
+}
+
+func TestDocument_TolerateBuildErrors(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          $ref: '#/components/responses/Missing'
+        '201':
+          description: made
+    post:
+      $ref: '#/missing/operation'
+  /burger:
+    $ref: '#/missing/path'
+  /fries:
+    get:
+      operationId: fries
+components:
+  parameters:
+    Missing:
+      $ref: '#/components/parameters/Nope'
+  responses:
+    Fine:
+      description: fine`
+
+	// without tolerating errors, there is no model.
+	doc, _ := NewDocument([]byte(spec))
+	built, errs := doc.BuildV3Model()
+	assert.Nil(t, built)
+	assert.NotEmpty(t, errs)
+
+	doc, _ = NewDocument([]byte(spec))
+	built, errs = doc.BuildV3Model(TolerateBuildErrors())
+	assert.NotNil(t, built)
+	assert.NotEmpty(t, errs)
+	assert.Len(t, built.Index.GetBuildErrors(), 4)
+
+	// everything that could be built has been, failures are recorded where they happened.
+	assert.Len(t, built.Model.Paths.PathItems, 3)
+	assert.Equal(t, "fries", built.Model.Paths.PathItems["/fries"].Get.OperationId)
+	assert.Equal(t, "made", built.Model.Paths.PathItems["/pizza"].Get.Responses.Codes["201"].Description)
+	assert.Equal(t, "fine", built.Model.Components.Responses["Fine"].Description)
+
+	lowPaths := built.Model.Paths.GoLow()
+	burger := lowPaths.FindPath("/burger")
+	var buildErr *low.BuildError
+	assert.True(t, errors.As(burger.Error, &buildErr))
+	assert.Equal(t, low.ErrorReferenceNotFound, buildErr.Code)
+	assert.Equal(t, "#/paths/~1burger/$ref", buildErr.Pointer)
+
+	pizza := lowPaths.FindPath("/pizza").Value
+	assert.Error(t, pizza.Post.Error)
+	assert.NoError(t, pizza.Get.Error)
+	assert.Error(t, pizza.Get.Value.Responses.Value.FindResponseByCode("200").Error)
+	assert.NoError(t, pizza.Get.Value.Responses.Value.FindResponseByCode("201").Error)
+
+	missing := built.Model.Components.GoLow().FindParameter("Missing")
+	assert.Error(t, missing.Error)
+}
+
+func TestDocument_RenderJSON(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  version: 1.0.0
+  title: pizza
+paths:
+  /zebra:
+    get:
+      operationId: zebra
+  /apple:
+    get:
+      operationId: apple`
+
+	doc, _ := NewDocument([]byte(spec))
+	_, err := doc.RenderJSON("")
+	assert.Error(t, err)
+
+	m, _ := doc.BuildV3Model()
+	m.Model.Paths.PathItems["/apple"].Get.OperationId = "banana"
+	rendered, err := doc.RenderJSON("")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.1.0","info":{"version":"1.0.0","title":"pizza"},`+
+		`"paths":{"/zebra":{"get":{"operationId":"zebra"}},"/apple":{"get":{"operationId":"banana"}}}}`,
+		string(rendered))
+
+	rendered, err = doc.RenderJSON("  ")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(rendered), "{\n  \"openapi\": \"3.1.0\",\n  \"info\": {"))
+
+	swagger, _ := NewDocument([]byte("swagger: 2.0"))
+	_, _ = swagger.BuildV2Model()
+	_, err = swagger.RenderJSON("")
+	assert.Error(t, err)
+}
+
+func TestCompareDocumentsThreeWay(t *testing.T) {
+	base := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      description: get a pizza`
+
+	left, _ := NewDocument([]byte(strings.Replace(base, "get a pizza", "get a hot pizza", 1)))
+	right, _ := NewDocument([]byte(strings.Replace(base, "get a pizza", "get a cold pizza", 1)))
+	baseDoc, _ := NewDocument([]byte(base))
+
+	changes, errs := CompareDocumentsThreeWay(baseDoc, left, right)
+	assert.Empty(t, errs)
+	assert.Len(t, changes.Conflicts, 1)
+	assert.Equal(t, "get a hot pizza", changes.Conflicts[0].Left.New)
+	assert.Equal(t, "get a cold pizza", changes.Conflicts[0].Right.New)
+
+	swagger, _ := NewDocument([]byte(`swagger: 2.0`))
+	changes, errs = CompareDocumentsThreeWay(baseDoc, left, swagger)
+	assert.Nil(t, changes)
+	assert.NotEmpty(t, errs)
+}
+
+// hashes generated by older versions of libopenapi, that legacy hashes must keep generating.
+func TestDocument_LegacyHashes(t *testing.T) {
+	low.UseLegacyHashes(true)
+	defer low.UseLegacyHashes(false)
+
+	hash := func(h [32]byte) string {
+		return fmt.Sprintf("%x", h)
+	}
+
+	burgers, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, _ := NewDocument(burgers)
+	m, _ := doc.BuildV3Model()
+	l := m.Model.GoLow()
+	assert.Equal(t, "f219c187ee453e6335827cee4846937643735374e4ab270dcaeb7ea4158fab72", hash(l.Paths.Value.Hash()))
+	assert.Equal(t, "464aa0e2e4b53f744b09ac7f8928db269ceda6a5a7ba70219d748341021d3754", hash(l.Components.Value.Hash()))
+	assert.Equal(t, "8fe5ebff3140f107f4a418b27ce08f7b4d803a06b42dc433f5c76566a7e0dcbc",
+		hash(l.Components.Value.FindSchema("Drink").Value.Schema().Hash()))
+	assert.Equal(t, "d40369c265fe17c0ac478bcaae0d3353f0ae08925471805b0bad6c0bcbae30fc",
+		hash(l.Components.Value.FindSchema("Dressing").Value.Schema().Hash()))
+
+	stripe, _ := os.ReadFile("test_specs/stripe.yaml")
+	doc, _ = NewDocument(stripe)
+	m, _ = doc.BuildV3Model()
+	l = m.Model.GoLow()
+	assert.Equal(t, "f6d7b873f404edf671eb37d986187051124806f66e362265b3a1a60d411feb4e", hash(l.Paths.Value.Hash()))
+	assert.Equal(t, "e13af54e754542ad49da809236a1d22354d7f0fd60fcfeec5cd0625fa6bbbfb4", hash(l.Components.Value.Hash()))
+
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, _ = NewDocument(petstore)
+	v2, _ := doc.BuildV2Model()
+	assert.Equal(t, "9a35f62d7eb013c014908b9bb6051373bf56f5949be2fa39e82f233a28335008",
+		hash(v2.Model.GoLow().Paths.Value.Hash()))
+}
@@ -0,0 +1,431 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package example
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Request is an example HTTP request for an operation, with every parameter serialized according to its style.
+type Request struct {
+	OperationId string      `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Path        string      `json:"path" yaml:"path"`     // the path template, like '/pets/{id}'.
+	Method      string      `json:"method" yaml:"method"` // upper case, like 'GET'.
+	URL         string      `json:"url" yaml:"url"`       // the server URL, path and query string.
+	Query       []*Pair     `json:"query,omitempty" yaml:"query,omitempty"`
+	Headers     http.Header `json:"headers,omitempty" yaml:"headers,omitempty"` // includes the 'Cookie' header.
+	Bodies      []*Body     `json:"bodies,omitempty" yaml:"bodies,omitempty"`   // one for each media type.
+}
+
+// Pair is a serialized query parameter, before it is URL encoded.
+type Pair struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// Body is an example request body for a single media type. Data holds the serialized body for JSON, form encoded
+// and text media types, and is nil for anything else.
+type Body struct {
+	MediaType string `json:"mediaType" yaml:"mediaType"`
+	Value     any    `json:"value,omitempty" yaml:"value,omitempty"`
+	Data      []byte `json:"-" yaml:"-"`
+}
+
+// Config changes how example requests are built.
+type Config struct {
+	// ServerURL is used instead of the servers of the document, like 'http://localhost:8080'.
+	ServerURL string
+
+	// RequiredOnly leaves out any parameter that is not required.
+	RequiredOnly bool
+}
+
+// Requests returns an example request for every operation of a document, in the order of the operation index.
+func Requests(doc *v3.Document, config *Config) []*Request {
+	var requests []*Request
+	for _, ref := range doc.GetOperationIndex().GetOperations() {
+		requests = append(requests, ForOperation(doc, ref, config))
+	}
+	return requests
+}
+
+// ForOperation returns an example request for a single operation of a document. The config can be nil.
+func ForOperation(doc *v3.Document, ref *v3.OperationRef, config *Config) *Request {
+	if config == nil {
+		config = new(Config)
+	}
+	op := ref.Operation
+	var pathItem *v3.PathItem
+	if doc.Paths != nil {
+		pathItem = doc.Paths.GetPathItem(ref.Path)
+	}
+
+	req := &Request{
+		OperationId: op.OperationId,
+		Path:        ref.Path,
+		Method:      strings.ToUpper(ref.Method),
+		Headers:     make(http.Header),
+	}
+
+	path := ref.Path
+	var cookies []string
+	for _, p := range parameters(pathItem, op) {
+		if config.RequiredOnly && !p.Required && p.In != "path" {
+			continue
+		}
+		value := parameterValue(p)
+		if value == nil {
+			continue
+		}
+		switch p.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", serializePath(p, value))
+		case "query":
+			req.Query = append(req.Query, serializeQuery(p, value)...)
+		case "header":
+			req.Headers.Set(p.Name, joinValue(value, ",", explode(p, false), false))
+		case "cookie":
+			cookies = append(cookies, serializeCookie(p, value)...)
+		}
+	}
+	if len(cookies) > 0 {
+		req.Headers.Set("Cookie", strings.Join(cookies, "; "))
+	}
+
+	server := config.ServerURL
+	if server == "" {
+		server = serverURL(doc, pathItem, op)
+	}
+	req.URL = strings.TrimSuffix(server, "/") + path
+	if len(req.Query) > 0 {
+		encoded := make([]string, len(req.Query))
+		for i, q := range req.Query {
+			encoded[i] = url.QueryEscape(q.Name) + "=" + url.QueryEscape(q.Value)
+		}
+		req.URL += "?" + strings.Join(encoded, "&")
+	}
+
+	if op.RequestBody != nil {
+		for _, mediaType := range sortedKeys(op.RequestBody.Content) {
+			mt := op.RequestBody.Content[mediaType]
+			if mt == nil {
+				continue
+			}
+			value := exampleValue(mt.Example, mt.Examples)
+			if value == nil {
+				value = FromSchema(mt.Schema, InRequest)
+			}
+			req.Bodies = append(req.Bodies, &Body{
+				MediaType: mediaType,
+				Value:     value,
+				Data:      serializeBody(mediaType, value),
+			})
+		}
+	}
+	return req
+}
+
+// HTTPRequest creates an *http.Request for the example, sending one of its bodies (which can be nil). Relative URLs
+// (when the document has no servers) need a Config.ServerURL to be sent.
+func (r *Request) HTTPRequest(body *Body) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body.Data)
+	}
+	req, err := http.NewRequest(r.Method, r.URL, reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the request for '%s %s': %w", r.Method, r.Path, err)
+	}
+	for k, v := range r.Headers {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", body.MediaType)
+	}
+	return req, nil
+}
+
+// parameters returns the parameters of an operation, along with those of its path item it does not override.
+func parameters(pathItem *v3.PathItem, op *v3.Operation) []*v3.Parameter {
+	var params []*v3.Parameter
+	index := make(map[string]int)
+	add := func(list []*v3.Parameter) {
+		for _, p := range list {
+			if p == nil {
+				continue
+			}
+			key := p.In + ":" + p.Name
+			if i, ok := index[key]; ok {
+				params[i] = p
+				continue
+			}
+			index[key] = len(params)
+			params = append(params, p)
+		}
+	}
+	if pathItem != nil {
+		add(pathItem.Parameters)
+	}
+	add(op.Parameters)
+	return params
+}
+
+// serverURL returns the URL of the first server of the operation, path item or document (in that order), with
+// variables set to their defaults.
+func serverURL(doc *v3.Document, pathItem *v3.PathItem, op *v3.Operation) string {
+	servers := op.Servers
+	if len(servers) == 0 && pathItem != nil {
+		servers = pathItem.Servers
+	}
+	if len(servers) == 0 {
+		servers = doc.Servers
+	}
+	if len(servers) == 0 || servers[0] == nil {
+		return ""
+	}
+	u := servers[0].URL
+	for name, v := range servers[0].Variables {
+		if v != nil {
+			u = strings.ReplaceAll(u, "{"+name+"}", v.Default)
+		}
+	}
+	return u
+}
+
+// exampleValue returns an example written in the document, preferring 'example' over the first of the 'examples'
+// (sorted by name).
+func exampleValue(example any, examples map[string]*base.Example) any {
+	if example != nil {
+		return example
+	}
+	for _, name := range sortedKeys(examples) {
+		if ex := examples[name]; ex != nil && ex.Value != nil {
+			return ex.Value
+		}
+	}
+	return nil
+}
+
+func parameterValue(p *v3.Parameter) any {
+	if v := exampleValue(p.Example, p.Examples); v != nil {
+		return v
+	}
+	if p.Schema != nil {
+		return FromSchema(p.Schema, InRequest)
+	}
+	// parameters with content are serialized using their media type.
+	for _, mediaType := range sortedKeys(p.Content) {
+		mt := p.Content[mediaType]
+		if mt == nil {
+			continue
+		}
+		value := exampleValue(mt.Example, mt.Examples)
+		if value == nil {
+			value = FromSchema(mt.Schema, InRequest)
+		}
+		if data := serializeBody(mediaType, value); data != nil {
+			return string(data)
+		}
+		return value
+	}
+	return nil
+}
+
+// explode returns if a parameter is exploded, which defaults to true for the 'form' style only.
+func explode(p *v3.Parameter, form bool) bool {
+	if p.Explode != nil {
+		return *p.Explode
+	}
+	return form
+}
+
+func serializePath(p *v3.Parameter, value any) string {
+	exploded := explode(p, false)
+	switch p.Style {
+	case "label":
+		sep := ","
+		if exploded {
+			sep = "."
+		}
+		return "." + joinValue(value, sep, exploded, true)
+	case "matrix":
+		if m, ok := value.(map[string]any); ok && exploded {
+			var parts []string
+			for _, k := range sortedKeys(m) {
+				parts = append(parts, ";"+url.PathEscape(k)+"="+url.PathEscape(primitive(m[k])))
+			}
+			return strings.Join(parts, "")
+		}
+		if a, ok := value.([]any); ok && exploded {
+			var parts []string
+			for _, v := range a {
+				parts = append(parts, ";"+p.Name+"="+url.PathEscape(primitive(v)))
+			}
+			return strings.Join(parts, "")
+		}
+		return ";" + p.Name + "=" + joinValue(value, ",", false, true)
+	}
+	// simple, the default style of path parameters.
+	return joinValue(value, ",", exploded, true)
+}
+
+func serializeQuery(p *v3.Parameter, value any) []*Pair {
+	switch p.Style {
+	case "deepObject":
+		if m, ok := value.(map[string]any); ok {
+			var pairs []*Pair
+			for _, k := range sortedKeys(m) {
+				pairs = append(pairs, &Pair{Name: p.Name + "[" + k + "]", Value: primitive(m[k])})
+			}
+			return pairs
+		}
+	case "spaceDelimited", "pipeDelimited":
+		if a, ok := value.([]any); ok && !explode(p, false) {
+			sep := " "
+			if p.Style == "pipeDelimited" {
+				sep = "|"
+			}
+			return []*Pair{{Name: p.Name, Value: joinValue(a, sep, false, false)}}
+		}
+	}
+
+	// form, the default style of query parameters.
+	if explode(p, true) {
+		switch v := value.(type) {
+		case []any:
+			pairs := make([]*Pair, len(v))
+			for i, item := range v {
+				pairs[i] = &Pair{Name: p.Name, Value: primitive(item)}
+			}
+			return pairs
+		case map[string]any:
+			var pairs []*Pair
+			for _, k := range sortedKeys(v) {
+				pairs = append(pairs, &Pair{Name: k, Value: primitive(v[k])})
+			}
+			return pairs
+		}
+	}
+	return []*Pair{{Name: p.Name, Value: joinValue(value, ",", false, false)}}
+}
+
+func serializeCookie(p *v3.Parameter, value any) []string {
+	if explode(p, true) {
+		switch v := value.(type) {
+		case []any:
+			cookies := make([]string, len(v))
+			for i, item := range v {
+				cookies[i] = p.Name + "=" + primitive(item)
+			}
+			return cookies
+		case map[string]any:
+			var cookies []string
+			for _, k := range sortedKeys(v) {
+				cookies = append(cookies, k+"="+primitive(v[k]))
+			}
+			return cookies
+		}
+	}
+	return []string{p.Name + "=" + joinValue(value, ",", false, false)}
+}
+
+// joinValue serializes arrays and objects by joining their values with a separator. Objects are written as 'k=v'
+// pairs when exploded, otherwise as 'k,v' pairs.
+func joinValue(value any, sep string, exploded, escape bool) string {
+	esc := func(s string) string {
+		if escape {
+			return url.PathEscape(s)
+		}
+		return s
+	}
+	switch v := value.(type) {
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = esc(primitive(item))
+		}
+		return strings.Join(parts, sep)
+	case map[string]any:
+		var parts []string
+		for _, k := range sortedKeys(v) {
+			if exploded {
+				parts = append(parts, esc(k)+"="+esc(primitive(v[k])))
+			} else {
+				parts = append(parts, esc(k), esc(primitive(v[k])))
+			}
+		}
+		return strings.Join(parts, sep)
+	}
+	return esc(primitive(value))
+}
+
+// primitive formats a single value, objects and arrays nested inside other values are written as JSON.
+func primitive(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case []any, map[string]any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
+
+// serializeBody serializes a body for JSON, form encoded and text media types. Returns nil for anything else.
+func serializeBody(mediaType string, value any) []byte {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	_, sub, _ := strings.Cut(mediaType, "/")
+	switch {
+	case sub == "json" || strings.HasSuffix(sub, "+json"):
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+		return data
+	case mediaType == "application/x-www-form-urlencoded":
+		form := make(url.Values)
+		if m, ok := value.(map[string]any); ok {
+			for k, v := range m {
+				if a, ok := v.([]any); ok {
+					for _, item := range a {
+						form.Add(k, primitive(item))
+					}
+					continue
+				}
+				form.Set(k, primitive(v))
+			}
+		}
+		return []byte(form.Encode())
+	case strings.HasPrefix(mediaType, "text/"):
+		return []byte(primitive(value))
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
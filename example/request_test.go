@@ -0,0 +1,179 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package example
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var petsSpec = `openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+servers:
+  - url: https://{host}/v1/
+    variables:
+      host:
+        default: example.com
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: integer
+          minimum: 7
+    put:
+      operationId: updatePet
+      parameters:
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+          example: [cat, dog]
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              color:
+                type: string
+                example: red
+        - name: ids
+          in: query
+          style: pipeDelimited
+          explode: false
+          example: [1, 2]
+        - name: X-Trace
+          in: header
+          required: true
+          examples:
+            b:
+              value: second
+            a:
+              value: first
+        - name: session
+          in: cookie
+          schema:
+            type: string
+            format: uuid
+        - name: where
+          in: query
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+          application/x-www-form-urlencoded:
+            example:
+              name: fluffy
+              tags: [a, b]
+          text/plain:
+            example: fluffy
+          application/xml:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '204':
+          description: updated
+  /pets/{id}/{key}:
+    get:
+      operationId: getPetKey
+      servers:
+        - url: http://localhost:8080
+      parameters:
+        - name: id
+          in: path
+          required: true
+          style: label
+          explode: true
+          example: [1, 2]
+        - name: key
+          in: path
+          required: true
+          style: matrix
+          example: {a: 1, b: x y}
+          explode: true
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string
+          example: fluffy`
+
+func TestRequests(t *testing.T) {
+	requests := Requests(buildModel(t, petsSpec), nil)
+	assert.Len(t, requests, 2)
+
+	update := requests[0]
+	assert.Equal(t, "updatePet", update.OperationId)
+	assert.Equal(t, "PUT", update.Method)
+	assert.Equal(t, "/pets/{id}", update.Path)
+	assert.Equal(t, "https://example.com/v1/pets/7?tags=cat&tags=dog&filter%5Bcolor%5D=red&ids=1%7C2&"+
+		"where=%7B%22name%22%3A%22string%22%7D", update.URL)
+	assert.Equal(t, "filter[color]", update.Query[2].Name)
+	assert.Equal(t, "first", update.Headers.Get("X-Trace"))
+	assert.Equal(t, "session=3fa85f64-5717-4562-b3fc-2c963f66afa6", update.Headers.Get("Cookie"))
+
+	assert.Len(t, update.Bodies, 4)
+	assert.Equal(t, "application/json", update.Bodies[0].MediaType)
+	assert.Equal(t, map[string]any{"name": "fluffy"}, update.Bodies[0].Value)
+	assert.Equal(t, `{"name":"fluffy"}`, string(update.Bodies[0].Data))
+	assert.Equal(t, "application/x-www-form-urlencoded", update.Bodies[1].MediaType)
+	assert.Equal(t, "name=fluffy&tags=a&tags=b", string(update.Bodies[1].Data))
+	assert.Equal(t, "application/xml", update.Bodies[2].MediaType)
+	assert.Nil(t, update.Bodies[2].Data)
+	assert.Equal(t, "fluffy", string(update.Bodies[3].Data))
+
+	get := requests[1]
+	assert.Equal(t, "http://localhost:8080/pets/.1.2/;a=1;b=x%20y", get.URL)
+	assert.Empty(t, get.Bodies)
+}
+
+func TestRequests_Config(t *testing.T) {
+	requests := Requests(buildModel(t, petsSpec), &Config{ServerURL: "http://127.0.0.1/", RequiredOnly: true})
+	assert.Equal(t, "http://127.0.0.1/pets/7", requests[0].URL)
+	assert.Empty(t, requests[0].Query)
+	assert.Equal(t, "first", requests[0].Headers.Get("X-Trace"))
+	assert.Empty(t, requests[0].Headers.Get("Cookie"))
+}
+
+func TestRequest_HTTPRequest(t *testing.T) {
+	update := Requests(buildModel(t, petsSpec), nil)[0]
+	req, err := update.HTTPRequest(update.Bodies[0])
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, req.Method)
+	assert.Equal(t, "example.com", req.URL.Host)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "first", req.Header.Get("X-Trace"))
+	body, _ := io.ReadAll(req.Body)
+	assert.Equal(t, `{"name":"fluffy"}`, string(body))
+
+	update.Method = "BAD METHOD"
+	_, err = update.HTTPRequest(nil)
+	assert.Error(t, err)
+}
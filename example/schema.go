@@ -0,0 +1,229 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package example synthesizes example values from schemas, and complete example HTTP requests for the operations
+// of a document, for documentation tools and smoke tests.
+//
+// Examples written in the document are always preferred ('example' and 'examples' of schemas, parameters and media
+// types, then 'default', 'const' and 'enum' of schemas). Anything else is generated from the type and format of the
+// schema.
+package example
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+)
+
+// maxDepth stops generating values for schemas nested deeper than this, which only happens for recursive schemas
+// that are not references.
+const maxDepth = 16
+
+// Direction is the direction a value is sent in, which decides if read-only or write-only properties are included.
+type Direction int
+
+const (
+	// InRequest values leave out read-only properties.
+	InRequest Direction = iota
+
+	// InResponse values leave out write-only properties.
+	InResponse
+)
+
+// formatExamples are the values generated for strings of common formats.
+var formatExamples = map[string]string{
+	"date":      "2023-01-01",
+	"date-time": "2023-01-01T00:00:00Z",
+	"time":      "00:00:00Z",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"email":     "user@example.com",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"byte":      "ZXhhbXBsZQ==",
+	"password":  "password",
+}
+
+// FromSchema returns an example value for a schema, like map[string]any for an object. Returns nil if there is no
+// schema, or it can't be built.
+func FromSchema(schema *base.SchemaProxy, direction Direction) any {
+	g := &generator{direction: direction, seen: make(map[*yaml.Node]bool)}
+	return g.proxy(schema, 0)
+}
+
+type generator struct {
+	direction Direction
+	seen      map[*yaml.Node]bool // the schemas being generated, to stop at recursive schemas.
+}
+
+func (g *generator) proxy(sp *base.SchemaProxy, depth int) any {
+	if sp == nil || depth > maxDepth {
+		return nil
+	}
+	// schemas are tracked by the node they are defined by, so recursion is found through references.
+	if origin := sp.GetOrigin(); origin != nil && origin.Node != nil {
+		if g.seen[origin.Node] {
+			return nil
+		}
+		g.seen[origin.Node] = true
+		defer delete(g.seen, origin.Node)
+	}
+	return g.schema(sp.Schema(), depth)
+}
+
+func (g *generator) schema(sch *base.Schema, depth int) any {
+	if sch == nil {
+		return nil
+	}
+	switch {
+	case sch.Example != nil:
+		return sch.Example
+	case len(sch.Examples) > 0:
+		return sch.Examples[0]
+	case sch.Default != nil:
+		return sch.Default
+	case sch.Const != nil:
+		return sch.Const
+	case len(sch.Enum) > 0:
+		return sch.Enum[0]
+	}
+
+	if len(sch.AllOf) > 0 {
+		return g.allOf(sch, depth)
+	}
+	for _, choices := range [][]*base.SchemaProxy{sch.OneOf, sch.AnyOf} {
+		if len(choices) > 0 {
+			return g.proxy(choices[0], depth+1)
+		}
+	}
+
+	switch schemaType(sch) {
+	case "object":
+		return g.object(sch, depth)
+	case "array":
+		var items []any
+		if sch.Items != nil && sch.Items.IsA() {
+			if item := g.proxy(sch.Items.A, depth+1); item != nil {
+				items = append(items, item)
+			}
+		}
+		for sch.MinItems != nil && int64(len(items)) < *sch.MinItems && len(items) > 0 {
+			items = append(items, items[0])
+		}
+		if items == nil {
+			items = []any{}
+		}
+		return items
+	case "integer":
+		return int64(number(sch))
+	case "number":
+		return number(sch)
+	case "boolean":
+		return true
+	case "null":
+		return nil
+	}
+	return str(sch)
+}
+
+// allOf merges the examples of every schema of an allOf, along with any properties of the schema itself.
+func (g *generator) allOf(sch *base.Schema, depth int) any {
+	merged := make(map[string]any)
+	var last any
+	for _, sp := range sch.AllOf {
+		v := g.proxy(sp, depth+1)
+		if m, ok := v.(map[string]any); ok {
+			for k, p := range m {
+				merged[k] = p
+			}
+			continue
+		}
+		if v != nil {
+			last = v
+		}
+	}
+	if len(sch.Properties) > 0 {
+		for k, p := range g.object(sch, depth).(map[string]any) {
+			merged[k] = p
+		}
+	}
+	if len(merged) == 0 && last != nil {
+		return last
+	}
+	return merged
+}
+
+func (g *generator) object(sch *base.Schema, depth int) any {
+	obj := make(map[string]any)
+	names := make([]string, 0, len(sch.Properties))
+	for name := range sch.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sp := sch.Properties[name]
+		if prop := sp.Schema(); prop != nil {
+			if (g.direction == InRequest && prop.ReadOnly) || (g.direction == InResponse && prop.WriteOnly) {
+				continue
+			}
+		}
+		// properties without an example (like recursive references) are left out.
+		if v := g.proxy(sp, depth+1); v != nil {
+			obj[name] = v
+		}
+	}
+	return obj
+}
+
+// schemaType returns the type of a schema, the first type that is not null when there is more than one. Schemas
+// without a type are objects if they have properties, otherwise strings.
+func schemaType(sch *base.Schema) string {
+	for _, t := range sch.Type {
+		if t != "null" {
+			return t
+		}
+	}
+	if len(sch.Type) > 0 {
+		return "null"
+	}
+	if len(sch.Properties) > 0 || sch.AdditionalProperties != nil {
+		return "object"
+	}
+	if sch.Items != nil {
+		return "array"
+	}
+	return "string"
+}
+
+func number(sch *base.Schema) float64 {
+	n := 0.0
+	if sch.Minimum != nil {
+		n = *sch.Minimum
+	} else if sch.ExclusiveMinimum != nil && sch.ExclusiveMinimum.IsB() {
+		n = sch.ExclusiveMinimum.B + 1
+	} else if sch.Maximum != nil && *sch.Maximum < 0 {
+		n = *sch.Maximum
+	}
+	if sch.ExclusiveMinimum != nil && sch.ExclusiveMinimum.IsA() && sch.ExclusiveMinimum.A && sch.Minimum != nil {
+		n++
+	}
+	return n
+}
+
+func str(sch *base.Schema) string {
+	s, ok := formatExamples[sch.Format]
+	if !ok {
+		s = "string"
+	}
+	if sch.MinLength != nil && int64(len(s)) < *sch.MinLength {
+		s += strings.Repeat("x", int(*sch.MinLength)-len(s))
+	}
+	if sch.MaxLength != nil && int64(len(s)) > *sch.MaxLength {
+		s = s[:*sch.MaxLength]
+	}
+	return s
+}
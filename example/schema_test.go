@@ -0,0 +1,90 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package example
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildModel(t *testing.T, spec string) *v3.Document {
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	return &m.Model
+}
+
+func TestFromSchema(t *testing.T) {
+	doc := buildModel(t, `openapi: 3.1.0
+info:
+  title: schemas
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+          readOnly: true
+          minimum: 1
+        name:
+          type: string
+          minLength: 10
+        password:
+          type: string
+          format: password
+          writeOnly: true
+        born:
+          type: string
+          format: date
+        tags:
+          type: array
+          minItems: 2
+          items:
+            type: string
+            enum: [cat, dog]
+        weight:
+          type: [number, 'null']
+          exclusiveMinimum: 2
+        parent:
+          $ref: '#/components/schemas/Pet'
+        owner:
+          allOf:
+            - type: object
+              properties:
+                name:
+                  type: string
+                  example: alice
+            - properties:
+                vip:
+                  type: boolean
+        choice:
+          oneOf:
+            - type: string
+              maxLength: 3
+            - type: integer`)
+
+	pet := doc.Components.Schemas["Pet"]
+	assert.Equal(t, map[string]any{
+		"name":     "stringxxxx",
+		"born":     "2023-01-01",
+		"tags":     []any{"cat", "cat"},
+		"weight":   3.0,
+		"owner":    map[string]any{"name": "alice", "vip": true},
+		"choice":   "str",
+		"password": "password",
+	}, FromSchema(pet, InRequest))
+
+	response := FromSchema(pet, InResponse).(map[string]any)
+	assert.Equal(t, int64(1), response["id"])
+	assert.NotContains(t, response, "password")
+	assert.NotContains(t, response, "parent")
+
+	assert.Nil(t, FromSchema(nil, InRequest))
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unsafe"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// Footprint is the approximate memory (in bytes) used by a document, or a section of it.
+type Footprint struct {
+	Nodes     int64 `json:"nodes"`     // the yaml node tree.
+	Index     int64 `json:"index"`     // the index, not including the nodes it points to.
+	LowModel  int64 `json:"lowModel"`  // the low-level model, not including nodes or the index.
+	HighModel int64 `json:"highModel"` // the high-level model, not including the low-level model.
+}
+
+// Total returns the total memory used.
+func (f Footprint) Total() int64 {
+	return f.Nodes + f.Index + f.LowModel + f.HighModel
+}
+
+// DocumentFootprint is the approximate memory used by a document, every document it references, and each section
+// of the root document.
+type DocumentFootprint struct {
+	Footprint
+
+	// Sections holds the memory used by each section of the root document, by key (for example 'paths',
+	// 'components' or 'webhooks'). The index is shared by every section, so it's only part of the document.
+	Sections map[string]Footprint `json:"sections"`
+}
+
+// MemoryFootprint returns the approximate memory used by the model, its index, and the yaml nodes of the
+// specification and every document it references. The memory is estimated by walking every object, so it can be
+// slow for huge documents, and should not be called while the model is being changed. Only the parts of a lazily
+// built model that have been built are measured.
+func (d *DocumentModel[T]) MemoryFootprint() *DocumentFootprint {
+	f := &DocumentFootprint{Sections: make(map[string]Footprint)}
+	high := reflect.ValueOf(&d.Model)
+	var low reflect.Value
+	if m := high.MethodByName("GoLow"); m.IsValid() {
+		low = m.Call(nil)[0]
+	}
+
+	// the whole document, every part is only counted once.
+	nodes, strs := make(map[*yaml.Node]bool), make(map[*byte]bool)
+	s := newFootprintSizer()
+	for _, root := range documentRoots(d.Index) {
+		f.Nodes += nodeFootprint(root, nodes, strs)
+	}
+	if d.Index != nil {
+		s.followIndex = true
+		f.Index = s.measure(reflect.ValueOf(d.Index))
+		s.followIndex = false
+	}
+	f.LowModel = s.measure(low)
+	s.skipLow = true
+	f.HighModel = s.measure(high)
+
+	// each section, on its own.
+	if d.Index == nil || d.Index.GetRootNode() == nil || len(d.Index.GetRootNode().Content) == 0 {
+		return f
+	}
+	root := d.Index.GetRootNode().Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		var section Footprint
+		key := root.Content[i].Value
+		section.Nodes = nodeFootprint(root.Content[i+1], make(map[*yaml.Node]bool), make(map[*byte]bool))
+		hf, lf := modelSection(high, low, key)
+		s = newFootprintSizer()
+		section.LowModel = s.measure(lf)
+		s.skipLow = true
+		section.HighModel = s.measure(hf)
+		f.Sections[key] = section
+	}
+	return f
+}
+
+// documentRoots returns the root node of the document of every index in the tree.
+func documentRoots(idx *index.SpecIndex) []*yaml.Node {
+	var roots []*yaml.Node
+	seen := make(map[*index.SpecIndex]bool)
+	var collect func(i *index.SpecIndex)
+	collect = func(i *index.SpecIndex) {
+		if i == nil || seen[i] {
+			return
+		}
+		seen[i] = true
+		if i.GetRootNode() != nil {
+			roots = append(roots, i.GetRootNode())
+		}
+		for _, c := range i.GetChildren() {
+			collect(c)
+		}
+		for _, e := range i.GetAllExternalIndexes() {
+			collect(e)
+		}
+	}
+	collect(idx)
+	return roots
+}
+
+// modelSection returns the high and low-level fields of a model holding a section of the document. High-level
+// fields are matched by their yaml name, low-level fields by the name of the high-level field.
+func modelSection(high, low reflect.Value, key string) (reflect.Value, reflect.Value) {
+	high = reflect.Indirect(high)
+	t := high.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if unicode.IsLower(rune(field.Name[0])) {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			name = string(unicode.ToLower(rune(field.Name[0]))) + field.Name[1:]
+		}
+		if name != key {
+			continue
+		}
+		var lf reflect.Value
+		if low.IsValid() && !low.IsNil() {
+			lf = low.Elem().FieldByName(field.Name)
+		}
+		return high.Field(i), lf
+	}
+	return reflect.Value{}, reflect.Value{}
+}
+
+var nodeSize = int64(unsafe.Sizeof(yaml.Node{}))
+
+// nodeFootprint returns the memory used by a node and every node below it. Strings shared by nodes (when strings
+// are interned) are only counted once.
+func nodeFootprint(node *yaml.Node, seen map[*yaml.Node]bool, strs map[*byte]bool) int64 {
+	if node == nil || seen[node] {
+		return 0
+	}
+	seen[node] = true
+	size := nodeSize + stringFootprint(node.Value, strs) + stringFootprint(node.Tag, strs) +
+		stringFootprint(node.Anchor, strs) + stringFootprint(node.HeadComment, strs) +
+		stringFootprint(node.LineComment, strs) + stringFootprint(node.FootComment, strs) +
+		int64(cap(node.Content))*int64(unsafe.Sizeof(node))
+	for _, c := range node.Content {
+		size += nodeFootprint(c, seen, strs)
+	}
+	return size + nodeFootprint(node.Alias, seen, strs)
+}
+
+func stringFootprint(str string, strs map[*byte]bool) int64 {
+	if str == "" || strs[unsafe.StringData(str)] {
+		return 0
+	}
+	strs[unsafe.StringData(str)] = true
+	return int64(len(str))
+}
+
+type footprintKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+var (
+	nodeType  = reflect.TypeOf(yaml.Node{})
+	indexType = reflect.TypeOf(index.SpecIndex{})
+)
+
+// footprintSizer estimates the memory used by an object graph. Everything it measures is remembered, so shared
+// objects are only counted once.
+type footprintSizer struct {
+	seen        map[footprintKey]bool
+	followIndex bool // only follow the index when measuring it.
+	skipLow     bool // skip the low-level model, when measuring the high-level model.
+}
+
+func newFootprintSizer() *footprintSizer {
+	return &footprintSizer{seen: make(map[footprintKey]bool)}
+}
+
+// measure returns the memory used by a value, including the value itself.
+func (s *footprintSizer) measure(v reflect.Value) int64 {
+	if !v.IsValid() {
+		return 0
+	}
+	return int64(v.Type().Size()) + s.size(v)
+}
+
+// size returns the memory a value points to, not including the value itself. Nodes are measured separately, so
+// they are never followed, and the index is only followed when it's being measured.
+func (s *footprintSizer) size(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || v.Elem().Type() == nodeType || s.skipType(v.Elem().Type()) {
+			return 0
+		}
+		if v.Elem().Type() == indexType && !s.followIndex {
+			return 0
+		}
+		if !s.visit(v.Pointer(), v.Type()) {
+			return 0
+		}
+		return s.measure(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		e := v.Elem()
+		if e.Kind() == reflect.Pointer {
+			return s.size(e)
+		}
+		return s.measure(e)
+	case reflect.String:
+		if v.Len() == 0 || !s.visit(uintptr(unsafe.Pointer(unsafe.StringData(v.String()))), v.Type()) {
+			return 0
+		}
+		return int64(v.Len())
+	case reflect.Slice:
+		if v.IsNil() || !s.visit(v.Pointer(), v.Type()) {
+			return 0
+		}
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			size += s.size(v.Index(i))
+		}
+		return size
+	case reflect.Array:
+		var size int64
+		for i := 0; i < v.Len(); i++ {
+			size += s.size(v.Index(i))
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || !s.visit(v.Pointer(), v.Type()) {
+			return 0
+		}
+		// buckets hold keys and values, along with some overhead.
+		size := int64(v.Len()) * int64(v.Type().Key().Size()+v.Type().Elem().Size()+1) * 5 / 4
+		iter := v.MapRange()
+		for iter.Next() {
+			size += s.size(iter.Key()) + s.size(iter.Value())
+		}
+		return size
+	case reflect.Struct:
+		var size int64
+		for i := 0; i < v.NumField(); i++ {
+			size += s.size(v.Field(i))
+		}
+		return size
+	}
+	return 0
+}
+
+func (s *footprintSizer) visit(ptr uintptr, t reflect.Type) bool {
+	k := footprintKey{ptr, t}
+	if s.seen[k] {
+		return false
+	}
+	s.seen[k] = true
+	return true
+}
+
+// skipType returns true for low-level types, when measuring the high-level model.
+func (s *footprintSizer) skipType(t reflect.Type) bool {
+	return s.skipLow && strings.HasPrefix(t.PkgPath(), "github.com/pb33f/libopenapi/datamodel/low")
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentModel_MemoryFootprint(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, _ := NewDocument(spec)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	f := m.MemoryFootprint()
+	assert.Greater(t, f.Nodes, int64(len(spec)))
+	assert.Greater(t, f.Index, int64(0))
+	assert.Greater(t, f.LowModel, int64(0))
+	assert.Greater(t, f.HighModel, int64(0))
+	assert.Equal(t, f.Nodes+f.Index+f.LowModel+f.HighModel, f.Total())
+
+	for _, key := range []string{"paths", "components", "webhooks", "info"} {
+		section := f.Sections[key]
+		assert.Greater(t, section.Nodes, int64(0), key)
+		assert.Greater(t, section.LowModel, int64(0), key)
+		assert.Greater(t, section.HighModel, int64(0), key)
+		assert.Zero(t, section.Index, key)
+		assert.Less(t, section.Total(), f.Total(), key)
+	}
+	assert.Greater(t, f.Sections["paths"].Nodes, f.Sections["info"].Nodes)
+
+	// measuring again gives the same result.
+	assert.Equal(t, f, m.MemoryFootprint())
+}
+
+func TestDocumentModel_MemoryFootprint_Swagger(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/petstorev2-complete.yaml")
+	doc, _ := NewDocument(spec)
+	m, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+
+	f := m.MemoryFootprint()
+	assert.Greater(t, f.Total(), int64(0))
+	assert.Greater(t, f.Sections["definitions"].HighModel, int64(0))
+	assert.Greater(t, f.Sections["definitions"].LowModel, int64(0))
+	assert.Greater(t, f.Sections["paths"].HighModel, int64(0))
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"github.com/pb33f/libopenapi/datamodel"
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+)
+
+// FragmentModel is a model built from a fragment of a specification (a file that is not a complete document, like a
+// shared schema), so fragments in multi-file repositories can be linted and analyzed on their own.
+type FragmentModel[T highbase.Schema | v3high.PathItem | v3high.Components] struct {
+	Model T
+	Index *index.SpecIndex    // index created from the fragment.
+	Info  *datamodel.SpecInfo // the fragment, see SpecInfo.Fragment for the kind of fragment it was detected as.
+}
+
+// NewSchemaFragment builds a Schema from a fragment that contains a lone schema. References in the schema are
+// indexed and resolved relative to the fragment, following the configuration (which may be nil, in which case file
+// and remote references are not allowed).
+func NewSchemaFragment(spec []byte, configuration *datamodel.DocumentConfiguration) (*FragmentModel[highbase.Schema], []error) {
+	return buildFragment(spec, configuration, v3low.CreateSchemaFragment,
+		func(s *base.Schema) *highbase.Schema { return highbase.NewSchema(s) })
+}
+
+// NewPathItemFragment builds a PathItem from a fragment that contains a lone path item, like the target of a $ref
+// from the paths of a specification (see NewSchemaFragment).
+func NewPathItemFragment(spec []byte, configuration *datamodel.DocumentConfiguration) (*FragmentModel[v3high.PathItem], []error) {
+	return buildFragment(spec, configuration, v3low.CreatePathItemFragment, v3high.NewPathItem)
+}
+
+// NewComponentsFragment builds Components from a fragment that contains shared components, either under a
+// 'components' key or at the top of the fragment (see NewSchemaFragment).
+func NewComponentsFragment(spec []byte, configuration *datamodel.DocumentConfiguration) (*FragmentModel[v3high.Components], []error) {
+	return buildFragment(spec, configuration, v3low.CreateComponentsFragment, v3high.NewComponents)
+}
+
+func buildFragment[L any, H highbase.Schema | v3high.PathItem | v3high.Components](spec []byte,
+	configuration *datamodel.DocumentConfiguration,
+	create func(*datamodel.SpecInfo, *datamodel.DocumentConfiguration) (*L, *index.SpecIndex, []error),
+	high func(*L) *H) (*FragmentModel[H], []error) {
+	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(spec, true)
+	if err != nil {
+		return nil, []error{err}
+	}
+	if configuration == nil {
+		configuration = &datamodel.DocumentConfiguration{}
+	}
+	low, idx, errs := create(info, configuration)
+	if low == nil || buildFailed(configuration, errs) {
+		return nil, errs
+	}
+	return &FragmentModel[H]{Model: *high(low), Index: idx, Info: info}, errs
+}
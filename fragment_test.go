@@ -0,0 +1,100 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSchemaFragment(t *testing.T) {
+	spec := `type: object
+required: [name]
+properties:
+  name:
+    type: string
+  topping:
+    $ref: '#/$defs/Topping'
+$defs:
+  Topping:
+    type: string
+    enum: [cheese, pepperoni]`
+
+	fragment, errs := NewSchemaFragment([]byte(spec), nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, datamodel.SchemaFragment, fragment.Info.Fragment)
+	assert.Equal(t, []string{"object"}, fragment.Model.Type)
+	assert.Equal(t, []string{"name"}, fragment.Model.Required)
+
+	topping := fragment.Model.Properties["topping"]
+	assert.True(t, topping.IsReference())
+	assert.Len(t, topping.Schema().Enum, 2)
+	assert.Len(t, fragment.Index.GetAllReferences(), 1)
+}
+
+func TestNewPathItemFragment(t *testing.T) {
+	spec := `summary: pizzas
+get:
+  operationId: listPizzas
+  responses:
+    '200':
+      $ref: '#/responses/Pizzas'
+responses:
+  Pizzas:
+    description: all the pizzas`
+
+	fragment, errs := NewPathItemFragment([]byte(spec), nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, datamodel.PathItemFragment, fragment.Info.Fragment)
+	assert.Equal(t, "pizzas", fragment.Model.Summary)
+	assert.Equal(t, "listPizzas", fragment.Model.Get.OperationId)
+	assert.Equal(t, "all the pizzas", fragment.Model.Get.Responses.Codes["200"].Description)
+}
+
+func TestNewComponentsFragment(t *testing.T) {
+	wrapped := `components:
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        topping:
+          $ref: '#/components/schemas/Topping'
+    Topping:
+      type: string`
+
+	fragment, errs := NewComponentsFragment([]byte(wrapped), nil)
+	assert.Empty(t, errs)
+	assert.Equal(t, datamodel.ComponentsFragment, fragment.Info.Fragment)
+	assert.Len(t, fragment.Model.Schemas, 2)
+	pizza := fragment.Model.Schemas["Pizza"].Schema()
+	assert.Equal(t, []string{"string"}, pizza.Properties["topping"].Schema().Type)
+
+	bare := `schemas:
+  Pizza:
+    type: object
+responses:
+  Pizza:
+    description: a pizza`
+
+	fragment, errs = NewComponentsFragment([]byte(bare), nil)
+	assert.Empty(t, errs)
+	assert.Len(t, fragment.Model.Schemas, 1)
+	assert.Len(t, fragment.Model.Responses, 1)
+}
+
+func TestNewSchemaFragment_Errors(t *testing.T) {
+	fragment, errs := NewSchemaFragment([]byte(""), nil)
+	assert.Nil(t, fragment)
+	assert.Len(t, errs, 1)
+
+	fragment, errs = NewSchemaFragment([]byte("- pizza"), nil)
+	assert.Nil(t, fragment)
+	assert.Len(t, errs, 1)
+
+	fragment, errs = NewSchemaFragment([]byte("$ref: '#/$defs/Missing'"), nil)
+	assert.Nil(t, fragment)
+	assert.NotEmpty(t, errs)
+}
@@ -0,0 +1,340 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
+	"gopkg.in/yaml.v3"
+)
+
+// plain name fragments, as defined by JSON Schema 2020-12 for $anchor and $dynamicAnchor.
+var anchorNameExp = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9._]*$`)
+
+// IsAnchorFragment returns true if the supplied fragment (the segment of a reference after the '#') is a plain
+// name anchor, rather than a JSON pointer. A leading '#' is ignored.
+func IsAnchorFragment(fragment string) bool {
+	return anchorNameExp.MatchString(strings.TrimPrefix(fragment, "#"))
+}
+
+// FindAnchor will locate a schema that declares a matching $anchor or $dynamicAnchor in this index,
+// returns nil if nothing is found. A leading '#' on the name is ignored. Anchors are unique within their schema
+// resource, those declared inside a schema with an '$id' are found using their absolute URI, like
+// 'https://example.com/tree#node'.
+func (index *SpecIndex) FindAnchor(name string) *Reference {
+	name = strings.TrimPrefix(name, "#")
+	if a := index.allAnchors[name]; a != nil {
+		return a
+	}
+	// a $dynamicAnchor also behaves as a regular anchor when referenced by $ref.
+	return index.allDynamicAnchors[name]
+}
+
+// GetReferenceDefinition returns the definition a '$ref' or '$dynamicRef' value found in node is indexed by (see
+// GetMappedReferences). Anchors referenced from inside a schema with an '$id' belong to that schema resource, so
+// they are indexed by their absolute URI, every other reference is indexed as it is.
+func (index *SpecIndex) GetReferenceDefinition(node *yaml.Node, ref string) string {
+	if !strings.HasPrefix(ref, "#") || !IsAnchorFragment(ref) {
+		return ref
+	}
+	return anchorDefinition(index.schemaResourceOf(node), ref)
+}
+
+// ResolveDynamicRef will resolve a $dynamicRef value as described by JSON Schema 2020-12, with no evaluation path
+// (see ResolveDynamicRefInScope), so the dynamic scope is made of the documents from the root document inwards.
+func (index *SpecIndex) ResolveDynamicRef(ref string) *Reference {
+	return index.ResolveDynamicRefInScope(ref, nil, nil)
+}
+
+// ResolveDynamicRefInScope will resolve a $dynamicRef value found in node, as described by JSON Schema 2020-12.
+//
+// The reference is first resolved like a regular $ref. If the located schema declares a $dynamicAnchor matching
+// the fragment, then the dynamic scope is searched from the outermost schema resource inwards, the first
+// $dynamicAnchor with the same name is returned. Otherwise, the initially resolved reference is returned as is.
+// Returns nil if the reference cannot be found.
+//
+// The dynamic scope is made of the schema resources entered on the evaluation path, the schemas (outermost first)
+// that were passed through to reach node. Schemas in OpenAPI documents are rarely resources of their own, so
+// schemas on the path that declare the $dynamicAnchor themselves are part of the scope too, and documents are entered
+// from the root document inwards.
+func (index *SpecIndex) ResolveDynamicRefInScope(ref string, node *yaml.Node, path []*yaml.Node) *Reference {
+	definition := index.GetReferenceDefinition(node, ref)
+	_, fragment, _ := strings.Cut(definition, "#")
+	var initial *Reference
+	if strings.HasPrefix(definition, "#") && IsAnchorFragment(fragment) {
+		initial = index.FindAnchor(fragment)
+	} else {
+		initial = index.FindComponent(definition, node)
+	}
+	if initial == nil {
+		return nil
+	}
+	if !IsAnchorFragment(fragment) {
+		return initial
+	}
+
+	// only a $dynamicAnchor at the initial target enables a dynamic lookup.
+	if !declaresDynamicAnchor(initial.Node, fragment) {
+		return initial
+	}
+	dynamic := func(a *Reference, owner *SpecIndex) *Reference {
+		return &Reference{
+			Definition: ref,
+			Name:       fragment,
+			Node:       a.Node,
+			Path:       a.Path,
+			IsRemote:   owner != index || initial.IsRemote,
+		}
+	}
+	resources := make(map[string]bool)
+	documents := false
+	for _, n := range path {
+		if resource := index.schemaResourceOf(n); resource != "" {
+			if !resources[resource] {
+				resources[resource] = true
+				if a, owner := index.findTreeDynamicAnchor(anchorKey(resource, fragment)); a != nil {
+					return dynamic(a, owner)
+				}
+			}
+			continue
+		}
+		if declaresDynamicAnchor(n, fragment) {
+			a, owner := index.findTreeDynamicAnchorNode(n)
+			if a == nil {
+				a, owner = &Reference{Node: n}, index
+			}
+			return dynamic(a, owner)
+		}
+		if !documents {
+			documents = true
+			if a, owner := index.findScopeDynamicAnchor(fragment); a != nil {
+				return dynamic(a, owner)
+			}
+		}
+	}
+	if !documents {
+		if a, owner := index.findScopeDynamicAnchor(fragment); a != nil {
+			return dynamic(a, owner)
+		}
+	}
+	return initial
+}
+
+// findScopeDynamicAnchor searches the documents from the root index down to this one for a $dynamicAnchor.
+func (index *SpecIndex) findScopeDynamicAnchor(name string) (*Reference, *SpecIndex) {
+	for _, scope := range index.dynamicScope() {
+		if a := scope.allDynamicAnchors[name]; a != nil {
+			return a, scope
+		}
+	}
+	return nil, nil
+}
+
+// findTreeDynamicAnchor searches every index in the tree for a $dynamicAnchor by its key.
+func (index *SpecIndex) findTreeDynamicAnchor(key string) (a *Reference, owner *SpecIndex) {
+	index.rootIndex().forEachIndex(make(map[*SpecIndex]bool), func(i *SpecIndex) bool {
+		a, owner = i.allDynamicAnchors[key], i
+		return a != nil
+	})
+	if a == nil {
+		return nil, nil
+	}
+	return a, owner
+}
+
+// findTreeDynamicAnchorNode searches every index in the tree for the $dynamicAnchor declared by node.
+func (index *SpecIndex) findTreeDynamicAnchorNode(node *yaml.Node) (found *Reference, owner *SpecIndex) {
+	index.rootIndex().forEachIndex(make(map[*SpecIndex]bool), func(i *SpecIndex) bool {
+		for _, a := range i.allDynamicAnchors {
+			if a.Node == node {
+				found, owner = a, i
+				return true
+			}
+		}
+		return false
+	})
+	return found, owner
+}
+
+// forEachIndex calls visit with this index and every index below it, until visit returns true.
+func (index *SpecIndex) forEachIndex(seen map[*SpecIndex]bool, visit func(i *SpecIndex) bool) bool {
+	if seen[index] {
+		return false
+	}
+	seen[index] = true
+	if visit(index) {
+		return true
+	}
+	for _, child := range index.GetChildren() {
+		if child.forEachIndex(seen, visit) {
+			return true
+		}
+	}
+	return false
+}
+
+// declaresDynamicAnchor returns true if node is a schema declaring a $dynamicAnchor with a name.
+func declaresDynamicAnchor(node *yaml.Node, name string) bool {
+	if node == nil {
+		return false
+	}
+	_, da := utils.FindKeyNodeTop("$dynamicAnchor", node.Content)
+	return da != nil && da.Value == name
+}
+
+// dynamicScope returns the chain of indexes from the root index down to this one.
+func (index *SpecIndex) dynamicScope() []*SpecIndex {
+	var scope []*SpecIndex
+	for i := index; i != nil; i = i.parentIndex {
+		scope = append([]*SpecIndex{i}, scope...)
+	}
+	return scope
+}
+
+// checkDynamicRefs will record an error for every $dynamicRef that cannot be resolved.
+func (index *SpecIndex) checkDynamicRefs() {
+	for _, ref := range index.allDynamicRefs {
+		if index.ResolveDynamicRefInScope(ref.Definition, ref.Node, nil) == nil {
+			index.errorLock.Lock()
+			index.refErrors = append(index.refErrors, &IndexingError{
+				Err:  fmt.Errorf("dynamic reference '%s' cannot be resolved", ref.Definition),
+				Node: ref.Node,
+				Path: ref.Path,
+			})
+			index.errorLock.Unlock()
+		}
+	}
+}
+
+// GetAllSchemaResources will return every schema that declares an $id, keyed by its absolute URI (3.1+). The $id
+// of the root of a document is its base URI (see GetBaseURI), rather than a schema resource.
+func (index *SpecIndex) GetAllSchemaResources() map[string]*Reference {
+	return index.allSchemaResources
+}
+
+// enterSchemaResource returns the absolute URI of the schema resource node is part of, recording it for node. A schema
+// with an '$id' starts a new resource, nodes that are part of the document itself return an empty string.
+func (index *SpecIndex) enterSchemaResource(node, parent *yaml.Node, seenPath []string) string {
+	resource := index.nodeResources[parent]
+	if utils.IsNodeMap(node) && parent != index.root {
+		if _, id := utils.FindKeyNodeTop("$id", node.Content); id != nil && utils.IsNodeStringValue(id) && id.Value != "" {
+			resource, _, _ = strings.Cut(index.resolveResourceURI(resource, id.Value), "#")
+			if index.allSchemaResources[resource] == nil {
+				index.allSchemaResources[resource] = &Reference{
+					Definition: resource,
+					Name:       resource,
+					Node:       node,
+					Path:       jsonPath(seenPath),
+				}
+			}
+		}
+	}
+	if resource != "" {
+		index.nodeResources[node] = resource
+	}
+	return resource
+}
+
+// schemaResourceOf returns the absolute URI of the schema resource a node of any document in the tree is part of,
+// or an empty string if it's part of a document.
+func (index *SpecIndex) schemaResourceOf(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+	if resource := index.nodeResources[node]; resource != "" {
+		return resource
+	}
+	var resource string
+	index.rootIndex().forEachIndex(make(map[*SpecIndex]bool), func(i *SpecIndex) bool {
+		resource = i.nodeResources[node]
+		return resource != ""
+	})
+	return resource
+}
+
+// resolveResourceURI resolves a reference against the URI of the schema resource it's in, or the base URI of the
+// document if it's not in one. The fragment is kept as it was written.
+func (index *SpecIndex) resolveResourceURI(resource, ref string) string {
+	base := resource
+	if base == "" {
+		if b := index.GetBaseURI(); b != nil {
+			base = b.String()
+		}
+	}
+	location, fragment, hasFragment := strings.Cut(ref, "#")
+	resolved := location
+	if base != "" {
+		b, bErr := url.Parse(base)
+		r, rErr := url.Parse(location)
+		if bErr == nil && rErr == nil {
+			resolved = b.ResolveReference(r).String()
+		}
+	}
+	if !hasFragment {
+		return resolved
+	}
+	return resolved + "#" + fragment
+}
+
+// findInSchemaResource locates a reference to a schema with an '$id' in this document, or to an anchor or JSON
+// pointer inside one. The reference is resolved against the schema resource parent is part of. Returns nil if the
+// reference is not to a schema resource of this document.
+func (index *SpecIndex) findInSchemaResource(componentId string, parent *yaml.Node) *Reference {
+	if len(index.allSchemaResources) == 0 {
+		return nil
+	}
+	uri, fragment, _ := strings.Cut(index.resolveResourceURI(index.nodeResources[parent], componentId), "#")
+	resource := index.allSchemaResources[uri]
+	if resource == nil {
+		return nil
+	}
+	var found *Reference
+	switch {
+	case fragment == "" || fragment == "/":
+		found = resource
+	case IsAnchorFragment(fragment):
+		found = index.FindAnchor(anchorKey(uri, fragment))
+	default:
+		_, friendly := utils.ConvertComponentIdIntoFriendlyPathSearch("#" + fragment)
+		if path, err := yamlpath.NewPath(friendly); err == nil {
+			if nodes, _ := path.Find(resource.Node); len(nodes) > 0 {
+				found = &Reference{Node: nodes[0], Path: resource.Path + strings.TrimPrefix(friendly, "$")}
+			}
+		}
+	}
+	if found == nil {
+		return nil
+	}
+	segs := strings.Split(componentId, "/")
+	return &Reference{
+		Definition:            componentId,
+		Name:                  segs[len(segs)-1],
+		Node:                  found.Node,
+		Path:                  found.Path,
+		RequiredRefProperties: index.extractDefinitionRequiredRefProperties(found.Node, map[string][]string{}),
+	}
+}
+
+// anchorKey returns the key of an anchor declared in a schema resource, anchors declared in the document itself are
+// keyed by their name.
+func anchorKey(resource, name string) string {
+	if resource == "" {
+		return name
+	}
+	return resource + "#" + name
+}
+
+// anchorDefinition returns the definition of a reference found in a schema resource, anchors are relative to the
+// resource they are referenced from.
+func anchorDefinition(resource, ref string) string {
+	if resource == "" || !strings.HasPrefix(ref, "#") || !IsAnchorFragment(ref) {
+		return ref
+	}
+	return resource + ref
+}
@@ -0,0 +1,306 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestIsAnchorFragment(t *testing.T) {
+	assert.True(t, IsAnchorFragment("#pizza"))
+	assert.True(t, IsAnchorFragment("pizza.cheese-1"))
+	assert.False(t, IsAnchorFragment("#/components/schemas/pizza"))
+	assert.False(t, IsAnchorFragment("/components/schemas/pizza"))
+	assert.False(t, IsAnchorFragment("#"))
+	assert.False(t, IsAnchorFragment("1pizza"))
+}
+
+func TestSpecIndex_AnchorReference(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pizza:
+      $anchor: pizza
+      type: object
+    Order:
+      type: object
+      properties:
+        pizza:
+          $ref: '#pizza'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.Len(t, index.GetAllAnchors(), 1)
+	assert.Equal(t, "$.components.schemas.Pizza", index.GetAllAnchors()["pizza"].Path)
+
+	mapped := index.GetMappedReferences()["#pizza"]
+	assert.NotNil(t, mapped)
+	assert.Equal(t, "pizza", mapped.Name)
+	assert.Equal(t, "$anchor", mapped.Node.Content[0].Value)
+}
+
+func TestSpecIndex_AnchorReference_Missing(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Order:
+      $ref: '#burger'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	assert.Len(t, index.GetReferenceIndexErrors(), 1)
+	assert.Nil(t, index.FindAnchor("burger"))
+}
+
+func TestSpecIndex_ResolveDynamicRef(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $dynamicRef: '#node'
+    Leaf:
+      $anchor: leaf
+      type: string
+    Other:
+      $dynamicRef: '#leaf'
+    Pointer:
+      $dynamicRef: '#/components/schemas/Leaf'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.Len(t, index.GetAllDynamicAnchors(), 1)
+	assert.Len(t, index.GetAllDynamicRefs(), 3)
+
+	res := index.ResolveDynamicRef("#node")
+	assert.NotNil(t, res)
+	assert.Equal(t, "$.components.schemas.Tree", res.Path)
+
+	// not a dynamic anchor, behaves like a regular $ref.
+	res = index.ResolveDynamicRef("#leaf")
+	assert.NotNil(t, res)
+	assert.Equal(t, "$.components.schemas.Leaf", res.Path)
+
+	res = index.ResolveDynamicRef("#/components/schemas/Leaf")
+	assert.NotNil(t, res)
+	assert.Equal(t, "type", res.Node.Content[2].Value)
+
+	assert.Nil(t, index.ResolveDynamicRef("#nothing"))
+}
+
+func TestSpecIndex_ResolveDynamicRef_Unresolved(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Tree:
+      items:
+        $dynamicRef: '#node'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	assert.Len(t, index.GetReferenceIndexErrors(), 1)
+	assert.Equal(t, "dynamic reference '#node' cannot be resolved", index.GetReferenceIndexErrors()[0].Error())
+}
+
+func TestSpecIndex_ResolveDynamicRef_CrossFile(t *testing.T) {
+	tree := `Tree:
+  $dynamicAnchor: node
+  type: object
+  properties:
+    children:
+      type: array
+      items:
+        $dynamicRef: '#node'`
+
+	tmp := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmp, "tree.yaml"), []byte(tree), 0o644)
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    StringTree:
+      $dynamicAnchor: node
+      $ref: 'tree.yaml#node'
+      type: string`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	config := CreateClosedAPIIndexConfig()
+	config.AllowFileLookup = true
+	config.BasePath = tmp
+
+	index := NewSpecIndexWithConfig(&rootNode, config)
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+
+	mapped := index.GetMappedReferences()["tree.yaml#node"]
+	assert.NotNil(t, mapped)
+	assert.True(t, mapped.IsRemote)
+
+	// the dynamic reference inside the external document resolves to the outermost dynamic anchor.
+	ext := index.GetAllExternalIndexes()["tree.yaml"]
+	assert.NotNil(t, ext)
+	res := ext.ResolveDynamicRef("#node")
+	assert.NotNil(t, res)
+	assert.True(t, res.IsRemote)
+	assert.Equal(t, "$.components.schemas.StringTree", res.Path)
+
+	// the root index resolves the same reference locally.
+	res = index.ResolveDynamicRef("tree.yaml#node")
+	assert.NotNil(t, res)
+	assert.Equal(t, "$.components.schemas.StringTree", res.Path)
+}
+
+func TestSpecIndex_AnchorsBySchemaResource(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Cat:
+      $id: https://example.com/cat
+      type: object
+      properties:
+        name:
+          $ref: '#name'
+      $defs:
+        name:
+          $anchor: name
+          type: string
+    Dog:
+      $id: https://example.com/dog
+      type: object
+      properties:
+        name:
+          $ref: '#name'
+        cat:
+          $ref: cat
+      $defs:
+        name:
+          $anchor: name
+          type: integer
+    Pet:
+      $anchor: name
+      type: boolean
+    Owner:
+      properties:
+        pet:
+          $ref: '#name'
+        dogName:
+          $ref: 'https://example.com/dog#name'
+        catName:
+          $ref: 'https://example.com/cat#/properties/name'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+
+	// the same anchor name can be declared once in every schema resource.
+	assert.Len(t, index.GetAllSchemaResources(), 2)
+	assert.Equal(t, "$.components.schemas.Cat", index.GetAllSchemaResources()["https://example.com/cat"].Path)
+	assert.Len(t, index.GetAllAnchors(), 3)
+	assert.Equal(t, "$.components.schemas.Pet", index.GetAllAnchors()["name"].Path)
+	assert.Equal(t, "$.components.schemas.Cat.$defs.name", index.FindAnchor("https://example.com/cat#name").Path)
+
+	typeOf := func(definition string) string {
+		mapped := index.GetMappedReferences()[definition]
+		if !assert.NotNil(t, mapped, definition) {
+			return ""
+		}
+		_, v := utils.FindKeyNodeTop("type", mapped.Node.Content)
+		if v == nil {
+			return ""
+		}
+		return v.Value
+	}
+	assert.Equal(t, "boolean", typeOf("#name"))
+	assert.Equal(t, "string", typeOf("https://example.com/cat#name"))
+	assert.Equal(t, "integer", typeOf("https://example.com/dog#name"))
+	assert.Equal(t, "object", typeOf("cat"))
+	pointer := index.GetMappedReferences()["https://example.com/cat#/properties/name"]
+	assert.Equal(t, "#name", pointer.Node.Content[1].Value)
+
+	// references are indexed by the definition of the resource they are in.
+	cat := index.GetAllSchemaResources()["https://example.com/cat"].Node
+	_, props := utils.FindKeyNodeTop("properties", cat.Content)
+	_, name := utils.FindKeyNodeTop("name", props.Content)
+	assert.Equal(t, "https://example.com/cat#name", index.GetReferenceDefinition(name, "#name"))
+	assert.Equal(t, "#name", index.GetReferenceDefinition(&rootNode, "#name"))
+}
+
+func TestSpecIndex_ResolveDynamicRefInScope(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    List:
+      $id: https://example.com/list
+      type: array
+      items:
+        $dynamicRef: '#item'
+      $defs:
+        item:
+          $dynamicAnchor: item
+          type: object
+    Strings:
+      $id: https://example.com/strings
+      $ref: list
+      $defs:
+        item:
+          $dynamicAnchor: item
+          type: string
+    Numbers:
+      $dynamicAnchor: item
+      type: number`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.Equal(t, "https://example.com/list#item", index.GetAllDynamicRefs()[0].Definition)
+
+	resources := index.GetAllSchemaResources()
+	list, strs := resources["https://example.com/list"].Node, resources["https://example.com/strings"].Node
+	_, items := utils.FindKeyNodeTop("items", list.Content)
+	numbers := index.GetAllDynamicAnchors()["item"].Node
+
+	typeOf := func(path ...*yaml.Node) string {
+		res := index.ResolveDynamicRefInScope("#item", items, path)
+		if !assert.NotNil(t, res) {
+			return ""
+		}
+		_, v := utils.FindKeyNodeTop("type", res.Node.Content)
+		return v.Value
+	}
+
+	// the outermost resource (or schema) on the evaluation path declaring the anchor wins.
+	assert.Equal(t, "object", typeOf(list, items))
+	assert.Equal(t, "string", typeOf(strs, list, items))
+	assert.Equal(t, "number", typeOf(numbers, strs, list, items))
+
+	// with no evaluation path, the document is the dynamic scope.
+	assert.Equal(t, "number", typeOf())
+}
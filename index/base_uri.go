@@ -0,0 +1,169 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// GetRetrievalURI returns the URI the document of this index was retrieved from. For the root document it's
+// SpecIndexConfig.SpecAbsolutePath (as a file:// URI for file paths) or the BaseURL, for documents retrieved by
+// following references it's the location of the reference, resolved against the base URI of the referencing document.
+// Returns nil if it's not known.
+func (index *SpecIndex) GetRetrievalURI() *url.URL {
+	if index.config == nil {
+		return nil
+	}
+	if index.config.retrievalURI != nil {
+		return index.config.retrievalURI
+	}
+	if index.config.ParentIndex == nil && index.parentIndex == nil {
+		if loc := index.GetSpecAbsolutePath(); loc != "" {
+			if isHttpURI(loc) {
+				u, _ := url.Parse(loc)
+				return u
+			}
+			return fileURI(loc)
+		}
+		return index.config.BaseURL
+	}
+	return nil
+}
+
+// GetBaseURI returns the base URI that relative references in the document of this index are resolved against.
+// A document can declare its own using '$self' (OpenAPI 3.2) or a root '$id' (JSON Schema), which is resolved
+// against the retrieval URI (RFC 3986, section 5.1). Otherwise, it's the retrieval URI. Returns nil if neither is
+// known. Every document has its own index, use GetAllExternalIndexes to get the base URI of each one.
+func (index *SpecIndex) GetBaseURI() *url.URL {
+	if index.baseURI != nil {
+		return index.baseURI
+	}
+	return index.GetRetrievalURI()
+}
+
+// GetSelfURI returns the URI the document declares for itself, using '$self' or '$id', resolved against the
+// retrieval URI. Returns nil if the document does not declare one.
+func (index *SpecIndex) GetSelfURI() *url.URL {
+	return index.baseURI
+}
+
+// determineBaseURI reads '$self' or '$id' from the root of the document, and resolves it against the retrieval URI.
+func (index *SpecIndex) determineBaseURI() *url.URL {
+	if index.root == nil || len(index.root.Content) == 0 || !utils.IsNodeMap(index.root.Content[0]) {
+		return nil
+	}
+	var self *yaml.Node
+	for _, key := range []string{"$self", "$id"} {
+		if _, self = utils.FindKeyNodeTop(key, index.root.Content[0].Content); self != nil {
+			break
+		}
+	}
+	if self == nil || !utils.IsNodeStringValue(self) || self.Value == "" {
+		return nil
+	}
+	u, err := url.Parse(self.Value)
+	if err != nil {
+		index.refErrors = append(index.refErrors, &IndexingError{Err: err, Node: self, Path: "$.$self"})
+		return nil
+	}
+	u.Fragment = ""
+	if u.IsAbs() {
+		return u
+	}
+	if retrieval := index.GetRetrievalURI(); retrieval != nil {
+		return retrieval.ResolveReference(u)
+	}
+	return nil
+}
+
+// rfcBaseURI returns the base URI, if relative references are resolved against it (RFC 3986) rather than the
+// BasePath and BaseURL. That's the case if the base is a URL that came from '$self', '$id' or retrieving the
+// document remotely.
+func (index *SpecIndex) rfcBaseURI() *url.URL {
+	base := index.baseURI
+	if base == nil && index.config != nil {
+		base = index.config.retrievalURI
+	}
+	if base == nil || !isHttpURI(base.String()) {
+		return nil
+	}
+	// local documents declaring a remote '$self', are resolved locally if remote lookups are not allowed.
+	remote := index.config != nil && index.config.retrievalURI != nil && isHttpURI(index.config.retrievalURI.String())
+	if !remote && (index.config == nil || !index.config.AllowRemoteLookup) {
+		return nil
+	}
+	return base
+}
+
+// resolveAgainstBaseURI resolves a relative reference against the base URI of the document, returns an empty string
+// if the reference is resolved against the BasePath and BaseURL as usual (see rfcBaseURI).
+func (index *SpecIndex) resolveAgainstBaseURI(ref string) string {
+	if strings.HasPrefix(ref, "#") || isHttpURI(ref) || index.getSchemeHandler(ref) != nil {
+		return ""
+	}
+	base := index.rfcBaseURI()
+	if base == nil {
+		return ""
+	}
+	location, fragment, hasFragment := strings.Cut(ref, "#")
+	r, err := url.Parse(location)
+	if err != nil || r.IsAbs() {
+		return ""
+	}
+	resolved := base.ResolveReference(r).String()
+	if !hasFragment {
+		return resolved
+	}
+	// keep the fragment as it was written, it's a JSON pointer, not a URI fragment.
+	return resolved + "#" + fragment
+}
+
+// resolveRetrievalURI returns the URI a document referenced by this index is retrieved from.
+func (index *SpecIndex) resolveRetrievalURI(location string) *url.URL {
+	if isHttpURI(location) || index.getSchemeHandler(location) != nil {
+		u, _ := url.Parse(location)
+		return u
+	}
+	if base := index.rfcBaseURI(); base != nil {
+		if r, err := url.Parse(location); err == nil {
+			return base.ResolveReference(r)
+		}
+	}
+	if location == "" {
+		return nil
+	}
+
+	// files that couldn't be read locally, were fetched using the BaseURL.
+	file := strings.TrimPrefix(location, "file:")
+	index.sourceLock.Lock()
+	_, local := index.seenLocalSources[file]
+	index.sourceLock.Unlock()
+	if !local && index.config != nil && index.config.BaseURL != nil && index.config.FSHandler == nil {
+		if u, err := url.Parse(GenerateCleanSpecConfigBaseURL(index.config.BaseURL, location, true)); err == nil {
+			return u
+		}
+	}
+	return fileURI(index.absoluteLocation(location))
+}
+
+func isHttpURI(location string) bool {
+	l := strings.ToLower(location)
+	return strings.HasPrefix(l, "http://") || strings.HasPrefix(l, "https://")
+}
+
+func fileURI(path string) *url.URL {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	p := filepath.ToSlash(path)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p // windows drive letters.
+	}
+	return &url.URL{Scheme: "file", Path: p}
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func baseURIServer(t *testing.T, files map[string]string) (*httptest.Server, func() []string) {
+	var lock sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		requested = append(requested, req.URL.Path)
+		lock.Unlock()
+		if f, ok := files[req.URL.Path]; ok {
+			_, _ = rw.Write([]byte(f))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return requested
+	}
+}
+
+func TestSpecIndex_BaseURI_Self(t *testing.T) {
+	server, _ := baseURIServer(t, map[string]string{
+		"/apis/v1/common/pet.yaml": "Pet:\n  type: object",
+	})
+
+	spec := fmt.Sprintf(`openapi: 3.2.0
+$self: %s/apis/v1/openapi.yaml
+components:
+  schemas:
+    Pet:
+      $ref: 'common/pet.yaml#/Pet'`, server.URL)
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.NotNil(t, idx.GetMappedReferences()["common/pet.yaml#/Pet"])
+	assert.Equal(t, server.URL+"/apis/v1/openapi.yaml", idx.GetBaseURI().String())
+	assert.Equal(t, server.URL+"/apis/v1/openapi.yaml", idx.GetSelfURI().String())
+
+	children := idx.GetChildren()
+	assert.Len(t, children, 1)
+	assert.Equal(t, server.URL+"/apis/v1/common/pet.yaml", children[0].GetRetrievalURI().String())
+	assert.Equal(t, server.URL+"/apis/v1/common/pet.yaml", children[0].GetBaseURI().String())
+	assert.Nil(t, children[0].GetSelfURI())
+}
+
+func TestSpecIndex_BaseURI_RemoteRelative(t *testing.T) {
+	server, requested := baseURIServer(t, map[string]string{
+		"/a/b/pet.yaml":        "Pet:\n  properties:\n    owner:\n      $ref: '../shared/owner.yaml#/Owner'",
+		"/a/shared/owner.yaml": "Owner:\n  type: string",
+	})
+
+	spec := fmt.Sprintf(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: '%s/a/b/pet.yaml#/Pet'`, server.URL)
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.ElementsMatch(t, []string{"/a/b/pet.yaml", "/a/shared/owner.yaml"}, requested())
+
+	children := idx.GetChildren()
+	assert.Len(t, children, 1)
+	assert.NotNil(t, children[0].GetMappedReferences()["../shared/owner.yaml#/Owner"])
+	assert.Equal(t, "../shared/owner.yaml#/Owner",
+		children[0].GetMappedReferences()["../shared/owner.yaml#/Owner"].Definition)
+	assert.Equal(t, server.URL+"/a/shared/owner.yaml", children[0].GetChildren()[0].GetBaseURI().String())
+}
+
+func TestSpecIndex_BaseURI_RelativeSelf(t *testing.T) {
+	spec := `openapi: 3.2.0
+$self: v2/openapi.yaml`
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.SpecAbsolutePath = "https://pb33f.io/specs/openapi.yaml"
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Equal(t, "https://pb33f.io/specs/openapi.yaml", idx.GetRetrievalURI().String())
+	assert.Equal(t, "https://pb33f.io/specs/v2/openapi.yaml", idx.GetBaseURI().String())
+}
+
+func TestSpecIndex_BaseURI_NoSelf(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0"), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.SpecAbsolutePath = filepath.Join(t.TempDir(), "openapi.yaml")
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Nil(t, idx.GetSelfURI())
+	assert.Equal(t, "file", idx.GetBaseURI().Scheme)
+	assert.Equal(t, filepath.ToSlash(c.SpecAbsolutePath), idx.GetBaseURI().Path)
+
+	assert.Nil(t, NewSpecIndexWithConfig(&rootNode, &SpecIndexConfig{}).GetBaseURI())
+}
+
+func TestSpecIndex_BaseURI_SelfLocalFallback(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("Pet:\n  type: object"), 0o644))
+
+	spec := `openapi: 3.2.0
+$self: https://pb33f.io/apis/openapi.yaml
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/Pet'`
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	// remote lookups are not allowed, so the reference is read from the local file system.
+	idx := NewSpecIndexWithConfig(&rootNode, &SpecIndexConfig{BasePath: dir, AllowFileLookup: true})
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.NotNil(t, idx.GetMappedReferences()["pet.yaml#/Pet"])
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+// TolerateBuildErrors returns true if models built using the index should carry on building when part of the model
+// fails to build. A nil index does not tolerate build errors.
+func (index *SpecIndex) TolerateBuildErrors() bool {
+	return index != nil && index.config != nil && index.config.TolerateBuildErrors
+}
+
+// AddBuildError will add an error for a part of a model that failed to build, where the build carried on without
+// it (see TolerateBuildErrors). Errors added to the index of a referenced document are added to the root index.
+func (index *SpecIndex) AddBuildError(err error) {
+	root := index.rootIndex()
+	if root == nil || err == nil {
+		return
+	}
+	root.buildErrorLock.Lock()
+	root.buildErrors = append(root.buildErrors, err)
+	root.buildErrorLock.Unlock()
+}
+
+// GetBuildErrors returns every error added to the index (and the indexes of referenced documents) by models that
+// carried on building after failing to build part of the model.
+func (index *SpecIndex) GetBuildErrors() []error {
+	root := index.rootIndex()
+	if root == nil {
+		return nil
+	}
+	root.buildErrorLock.Lock()
+	defer root.buildErrorLock.Unlock()
+	return append([]error(nil), root.buildErrors...)
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_AddBuildError(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'schemas/pet.yaml#/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = ""
+	c.LocalFS = localFS
+	c.TolerateBuildErrors = true
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.True(t, idx.TolerateBuildErrors())
+	assert.Empty(t, idx.GetBuildErrors())
+
+	// errors added to referenced documents end up in the root index.
+	idx.AddBuildError(errors.New("pizza"))
+	idx.AddBuildError(nil)
+	assert.NotEmpty(t, idx.GetChildren())
+	assert.True(t, idx.GetChildren()[0].TolerateBuildErrors())
+	idx.GetChildren()[0].AddBuildError(errors.New("burger"))
+	assert.Equal(t, []error{errors.New("pizza"), errors.New("burger")}, idx.GetBuildErrors())
+
+	var nilIndex *SpecIndex
+	assert.False(t, nilIndex.TolerateBuildErrors())
+	nilIndex.AddBuildError(errors.New("nowhere"))
+	assert.Nil(t, nilIndex.GetBuildErrors())
+}
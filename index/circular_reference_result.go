@@ -8,10 +8,72 @@ type CircularReferenceResult struct {
 	Start               *Reference
 	LoopIndex           int
 	LoopPoint           *Reference
-	IsPolymorphicResult bool // if this result comes from a polymorphic loop.
-	IsInfiniteLoop      bool // if all the definitions in the reference loop are marked as required, this is an infinite circular reference, thus is not allowed.
+	IsPolymorphicResult bool                  // if this result comes from a polymorphic loop.
+	IsInfiniteLoop      bool                  // if all the definitions in the reference loop are marked as required, this is an infinite circular reference, thus is not allowed.
+	Classification      CircularReferenceType // how (or if) the loop can end, set by the resolver.
 }
 
+// CircularReferenceType classifies a circular reference by how the loop ends.
+type CircularReferenceType int
+
+const (
+	// CircularOptional loops go through properties that are not required, so they end when a property is left out.
+	CircularOptional CircularReferenceType = iota
+
+	// CircularArray loops go through the items of an array, so they end with an empty array.
+	CircularArray
+
+	// CircularNullable loops go through a nullable schema, so they end with a null.
+	CircularNullable
+
+	// CircularPolymorphic loops go through allOf, oneOf or anyOf.
+	CircularPolymorphic
+
+	// CircularInfinite loops go through required properties only, they never end, so can't be valid.
+	CircularInfinite
+)
+
+func (c CircularReferenceType) String() string {
+	switch c {
+	case CircularArray:
+		return "array"
+	case CircularNullable:
+		return "nullable"
+	case CircularPolymorphic:
+		return "polymorphic"
+	case CircularInfinite:
+		return "infinite"
+	default:
+		return "optional"
+	}
+}
+
+// IsValidRecursion returns true if the loop goes through an array or a nullable schema, which is how recursive models
+// (trees, linked lists) are normally described.
+func (c *CircularReferenceResult) IsValidRecursion() bool {
+	return c.Classification == CircularArray || c.Classification == CircularNullable
+}
+
+// CircularReferencePolicy decides what the resolver does when it finds circular references.
+type CircularReferencePolicy int
+
+const (
+	// CircularReferencesAllow allows circular references, unless they are infinite (the default).
+	CircularReferencesAllow CircularReferencePolicy = iota
+
+	// CircularReferencesError reports every circular reference as an error.
+	CircularReferencesError
+
+	// CircularReferencesAllowValidRecursion only allows circular references that loop through an array or a
+	// nullable schema, every other circular reference is an error.
+	CircularReferencesAllowValidRecursion
+
+	// CircularReferencesStub allows every circular reference, when resolving, each loop is expanded
+	// SpecIndexConfig.CircularReferenceStubDepth times and then replaced with a stub, so the resolved tree is finite.
+	// Stubs are empty schemas with an 'x-circular-ref' extension pointing at the definition.
+	CircularReferencesStub
+)
+
 func (c *CircularReferenceResult) GenerateJourneyPath() string {
 	buf := strings.Builder{}
 	for i, ref := range c.Journey {
@@ -0,0 +1,77 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// componentLookupKey is a component reference normalized into the key used by the component lookup map.
+// Only references with two segments ('#/definitions/Pet') or three segments under components
+// ('#/components/schemas/Pet') can be looked up, anything else returns false.
+func componentLookupKey(componentId string) (string, bool) {
+	componentId = strings.TrimPrefix(componentId, "#")
+	if !strings.HasPrefix(componentId, "/") {
+		return "", false
+	}
+	segs := strings.Split(componentId[1:], "/")
+	if len(segs) != 2 && !(len(segs) == 3 && segs[0] == "components") {
+		return "", false
+	}
+	for i := range segs {
+		segs[i] = utils.UnescapeJSONPointerSegment(segs[i])
+	}
+	return "#" + utils.BuildJSONPointer(segs), true
+}
+
+// mapComponentLookup builds a map of every component (for example '#/components/schemas/Pet' or
+// '#/definitions/Pet') defined in the root of the document, to its node. The map is built once when indexing, so
+// looking up a component does not search the document.
+func (index *SpecIndex) mapComponentLookup() {
+	index.componentLookup = make(map[string]*yaml.Node)
+	if index.root == nil || len(index.root.Content) == 0 {
+		return
+	}
+	root := index.root
+	if root.Kind == yaml.DocumentNode {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+	add := func(segs []string, node *yaml.Node) {
+		key := "#" + utils.BuildJSONPointer(segs)
+		if _, ok := index.componentLookup[key]; !ok {
+			index.componentLookup[key] = node
+		}
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		section, sectionNode := root.Content[i].Value, root.Content[i+1]
+		if sectionNode.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(sectionNode.Content); j += 2 {
+			name, node := sectionNode.Content[j].Value, sectionNode.Content[j+1]
+			add([]string{section, name}, node)
+			if section == "components" && node.Kind == yaml.MappingNode {
+				for k := 0; k+1 < len(node.Content); k += 2 {
+					add([]string{section, name, node.Content[k].Value}, node.Content[k+1])
+				}
+			}
+		}
+	}
+}
+
+// lookupComponent returns the node of a component defined in the root of the document, nil if the reference
+// could not be located, or if it's not a component reference.
+func (index *SpecIndex) lookupComponent(componentId string) *yaml.Node {
+	key, ok := componentLookupKey(componentId)
+	if !ok {
+		return nil
+	}
+	return index.componentLookup[key]
+}
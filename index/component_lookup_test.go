@@ -0,0 +1,96 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestComponentLookupKey(t *testing.T) {
+	key, ok := componentLookupKey("#/components/schemas/Pet")
+	assert.True(t, ok)
+	assert.Equal(t, "#/components/schemas/Pet", key)
+
+	key, ok = componentLookupKey("/definitions/a~1b~0c")
+	assert.True(t, ok)
+	assert.Equal(t, "#/definitions/a~1b~0c", key)
+
+	_, ok = componentLookupKey("#/paths/~1pets/get")
+	assert.False(t, ok)
+	_, ok = componentLookupKey("#/components/schemas")
+	assert.True(t, ok)
+	_, ok = componentLookupKey("Pet")
+	assert.False(t, ok)
+	_, ok = componentLookupKey("")
+	assert.False(t, ok)
+}
+
+func TestSpecIndex_FindComponentInRoot_Lookup(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+    a/b:
+      type: string
+    "with space":
+      type: integer
+definitions:
+  Dog:
+    type: object`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	pet := idx.FindComponentInRoot("#/components/schemas/Pet")
+	assert.Equal(t, "Pet", pet.Name)
+	assert.Equal(t, "#/components/schemas/Pet", pet.Definition)
+	assert.Equal(t, "$.components.schemas.Pet", pet.Path)
+	assert.Equal(t, 5, pet.Node.Line)
+
+	assert.Equal(t, "a/b", idx.FindComponentInRoot("#/components/schemas/a~1b").Name)
+	assert.Equal(t, 9, idx.FindComponentInRoot("#/components/schemas/with%20space").Node.Line)
+	assert.Equal(t, 12, idx.FindComponentInRoot("#/definitions/Dog").Node.Line)
+	assert.Equal(t, 4, idx.FindComponentInRoot("#/components/schemas").Node.Line)
+	assert.Nil(t, idx.FindComponentInRoot("#/components/schemas/Cat"))
+
+	// paths that are not components are still searched.
+	assert.Equal(t, "object", idx.FindComponentInRoot("#/components/schemas/Pet/type").Node.Value)
+}
+
+func generateComponents(count int) *yaml.Node {
+	var b strings.Builder
+	b.WriteString("openapi: 3.1.0\ncomponents:\n  schemas:\n")
+	for i := 0; i < count; i++ {
+		b.WriteString(fmt.Sprintf("    Schema%d:\n      type: object\n", i))
+		if i > 0 {
+			b.WriteString(fmt.Sprintf("      properties:\n        previous:\n          $ref: '#/components/schemas/Schema%d'\n", i-1))
+		}
+	}
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(b.String()), &rootNode)
+	return &rootNode
+}
+
+func BenchmarkNewSpecIndex_10kComponents(b *testing.B) {
+	rootNode := generateComponents(10000)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		NewSpecIndexWithConfig(rootNode, CreateClosedAPIIndexConfig())
+	}
+}
+
+func BenchmarkFindComponentInRoot_10kComponents(b *testing.B) {
+	idx := NewSpecIndexWithConfig(generateComponents(10000), CreateClosedAPIIndexConfig())
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		idx.FindComponentInRoot(fmt.Sprintf("#/components/schemas/Schema%d", n%10000))
+	}
+}
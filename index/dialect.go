@@ -0,0 +1,99 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Well known JSON Schema dialects, used as values for '$schema' and 'jsonSchemaDialect'.
+const (
+	DialectOpenAPI31Base     = "https://spec.openapis.org/oas/3.1/dialect/base"
+	DialectJSONSchema202012  = "https://json-schema.org/draft/2020-12/schema"
+	DialectJSONSchema201909  = "https://json-schema.org/draft/2019-09/schema"
+	DialectJSONSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+	DialectJSONSchemaDraft06 = "http://json-schema.org/draft-06/schema#"
+	DialectJSONSchemaDraft04 = "http://json-schema.org/draft-04/schema#"
+
+	// OpenAPI 3.0 and Swagger schemas are not real dialects, they are extended subsets of JSON Schema draft 04
+	// these values are used when a document does not set a dialect, to make it clear where the schema came from.
+	DialectOpenAPI30 = "https://spec.openapis.org/oas/3.0/schema"
+	DialectSwagger20 = "http://swagger.io/v2/schema.json"
+)
+
+// SchemaDraft represents the JSON Schema draft a dialect is built on.
+type SchemaDraft int
+
+const (
+	DraftUnknown SchemaDraft = iota
+	Draft04
+	Draft06
+	Draft07
+	Draft201909
+	Draft202012
+)
+
+// DialectDraft will return the JSON Schema draft that the supplied dialect URI is based on. Unknown or
+// custom dialects return DraftUnknown.
+func DialectDraft(dialect string) SchemaDraft {
+	d := strings.TrimSuffix(strings.TrimSpace(dialect), "#")
+	d = strings.TrimPrefix(strings.TrimPrefix(d, "https://"), "http://")
+	switch {
+	case d == "":
+		return DraftUnknown
+	case strings.HasPrefix(d, "spec.openapis.org/oas/3.1/"):
+		return Draft202012
+	case strings.HasPrefix(d, "spec.openapis.org/oas/3.0/"), strings.HasPrefix(d, "swagger.io/v2/"):
+		return Draft04
+	case strings.HasPrefix(d, "json-schema.org/draft/2020-12/"):
+		return Draft202012
+	case strings.HasPrefix(d, "json-schema.org/draft/2019-09/"):
+		return Draft201909
+	case strings.HasPrefix(d, "json-schema.org/draft-07/"):
+		return Draft07
+	case strings.HasPrefix(d, "json-schema.org/draft-06/"):
+		return Draft06
+	case strings.HasPrefix(d, "json-schema.org/draft-04/"):
+		return Draft04
+	}
+	return DraftUnknown
+}
+
+// GetJSONSchemaDialect returns the default dialect for schemas contained in this document, this is the value of
+// 'jsonSchemaDialect' if it has been set (3.1). If not set, the default dialect for the spec version is returned.
+// An index for an external document that is neither OpenAPI nor Swagger will inherit the dialect from its parent.
+func (index *SpecIndex) GetJSONSchemaDialect() string {
+	if dialect := RootDialect(index.root); dialect != "" {
+		return dialect
+	}
+	if index.parentIndex != nil {
+		return index.parentIndex.GetJSONSchemaDialect()
+	}
+	return ""
+}
+
+// RootDialect returns the default dialect for schemas in a document, from its root node (see GetJSONSchemaDialect).
+// An empty string is returned for documents that are neither OpenAPI nor Swagger.
+func RootDialect(rootNode *yaml.Node) string {
+	if rootNode == nil || len(rootNode.Content) == 0 {
+		return ""
+	}
+	root := rootNode.Content[0].Content
+	if _, d := utils.FindKeyNodeTop("jsonSchemaDialect", root); d != nil && d.Value != "" {
+		return d.Value
+	}
+	if _, v := utils.FindKeyNodeTop(utils.OpenApi3, root); v != nil {
+		if strings.HasPrefix(v.Value, "3.0") {
+			return DialectOpenAPI30
+		}
+		return DialectOpenAPI31Base
+	}
+	if _, v := utils.FindKeyNodeTop(utils.OpenApi2, root); v != nil {
+		return DialectSwagger20
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDialectDraft(t *testing.T) {
+	assert.Equal(t, Draft202012, DialectDraft(DialectOpenAPI31Base))
+	assert.Equal(t, Draft202012, DialectDraft(DialectJSONSchema202012))
+	assert.Equal(t, Draft201909, DialectDraft(DialectJSONSchema201909))
+	assert.Equal(t, Draft07, DialectDraft(DialectJSONSchemaDraft07))
+	assert.Equal(t, Draft07, DialectDraft("https://json-schema.org/draft-07/schema"))
+	assert.Equal(t, Draft06, DialectDraft(DialectJSONSchemaDraft06))
+	assert.Equal(t, Draft04, DialectDraft(DialectJSONSchemaDraft04))
+	assert.Equal(t, Draft04, DialectDraft(DialectOpenAPI30))
+	assert.Equal(t, Draft04, DialectDraft(DialectSwagger20))
+	assert.Equal(t, DraftUnknown, DialectDraft("https://pb33f.io/my-dialect"))
+	assert.Equal(t, DraftUnknown, DialectDraft(""))
+}
+
+func TestSpecIndex_GetJSONSchemaDialect(t *testing.T) {
+	specs := map[string]string{
+		"openapi: 3.1.0\njsonSchemaDialect: https://pb33f.io/dialect": "https://pb33f.io/dialect",
+		"openapi: 3.1.0":   DialectOpenAPI31Base,
+		"openapi: 3.0.3":   DialectOpenAPI30,
+		"swagger: \"2.0\"": DialectSwagger20,
+		"type: object":     "",
+	}
+	for spec, dialect := range specs {
+		var rootNode yaml.Node
+		_ = yaml.Unmarshal([]byte(spec), &rootNode)
+		idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+		assert.Equal(t, dialect, idx.GetJSONSchemaDialect())
+	}
+}
+
+func TestSpecIndex_GetJSONSchemaDialect_Parent(t *testing.T) {
+	var parentNode, childNode yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0\njsonSchemaDialect: https://pb33f.io/dialect"), &parentNode)
+	_ = yaml.Unmarshal([]byte("type: object"), &childNode)
+
+	parent := NewSpecIndexWithConfig(&parentNode, CreateClosedAPIIndexConfig())
+	cfg := CreateClosedAPIIndexConfig()
+	cfg.ParentIndex = parent
+	child := NewSpecIndexWithConfig(&childNode, cfg)
+	assert.Equal(t, "https://pb33f.io/dialect", child.GetJSONSchemaDialect())
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fetchGroup is shared by every index in a tree, it limits the number of external documents being fetched at
+// once, and makes sure concurrent lookups of the same document share a single fetch.
+type fetchGroup struct {
+	lock      sync.Mutex
+	inflight  map[string]*fetchCall
+	slots     chan struct{} // nil if fetches are not limited.
+	documents map[string]bool
+	nodes     int
+	refs      int
+}
+
+type fetchCall struct {
+	done chan struct{}
+	node *yaml.Node
+	err  error
+}
+
+func newFetchGroup(limit int) *fetchGroup {
+	g := &fetchGroup{inflight: make(map[string]*fetchCall), documents: make(map[string]bool)}
+	if limit > 0 {
+		g.slots = make(chan struct{}, limit)
+	}
+	return g
+}
+
+// do runs fetch for a key, unless a fetch for the same key is already running, in which case it waits for that
+// fetch and returns its result.
+func (g *fetchGroup) do(key string, fetch func() (*yaml.Node, error)) (*yaml.Node, error) {
+	if g == nil {
+		return fetch()
+	}
+	g.lock.Lock()
+	if c, ok := g.inflight[key]; ok {
+		g.lock.Unlock()
+		<-c.done
+		return c.node, c.err
+	}
+	c := &fetchCall{done: make(chan struct{})}
+	g.inflight[key] = c
+	g.lock.Unlock()
+
+	c.node, c.err = fetch()
+	close(c.done)
+
+	g.lock.Lock()
+	delete(g.inflight, key)
+	g.lock.Unlock()
+	return c.node, c.err
+}
+
+// acquire waits for a free fetch slot, the returned function releases it.
+func (g *fetchGroup) acquire() func() {
+	if g == nil || g.slots == nil {
+		return func() {}
+	}
+	g.slots <- struct{}{}
+	return func() { <-g.slots }
+}
+
+// fetchRemoteDocument fetches and parses a remote document, returns nil if the document is empty. Concurrent fetches
+// of the same document (by any index in the tree) share a single fetch, and the number of documents fetched at once
+// is limited by SpecIndexConfig.MaxConcurrentFetches.
+func (index *SpecIndex) fetchRemoteDocument(uri string) (*yaml.Node, error) {
+	var group *fetchGroup
+	if index.config != nil {
+		group = index.config.fetches
+	}
+	return group.do(uri, func() (*yaml.Node, error) {
+		release := group.acquire()
+		defer release()
+
+		// it may have been fetched while waiting.
+		if seen, doc := index.CheckForSeenRemoteSource(uri); seen {
+			return doc, nil
+		}
+		start := time.Now()
+		body, err := index.readRemoteDocument(uri)
+		index.logDebug("fetched remote document", "uri", uri, "bytes", len(body),
+			"duration", time.Since(start), "error", err)
+		if err != nil || len(body) == 0 {
+			return nil, err
+		}
+		index.reportDocumentFetched(uri)
+		remoteDoc, err := index.parseDocument(body)
+		if err != nil {
+			return nil, err
+		}
+		if index.config != nil && index.config.seenRemoteSources != nil {
+			index.config.seenRemoteSources.Store(uri, remoteDoc)
+		}
+		return remoteDoc, nil
+	})
+}
+
+// readRemoteDocument reads the bytes of a remote document, using the handler for its scheme, the FSHandler, or the
+// remote URL handler, in that order. The sandbox (if configured) is checked first.
+func (index *SpecIndex) readRemoteDocument(uri string) ([]byte, error) {
+	sandbox := index.getSandbox()
+	if err := sandbox.checkRemote(uri); err != nil {
+		return nil, err
+	}
+	if err := index.countExternalFile(uri); err != nil {
+		return nil, err
+	}
+	if handler := index.getSchemeHandler(uri); handler != nil {
+		b, err := handler(uri)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch '%s': %s", uri, err)
+		}
+		return sandbox.read(uri, bytes.NewReader(b))
+	}
+	if index.config != nil && index.config.FSHandler != nil {
+		remoteFile, err := index.config.FSHandler.Open(uri)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open remote file: %s", err)
+		}
+		b, err := sandbox.read(uri, remoteFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read remote file bytes: %s", err)
+		}
+		return b, nil
+	}
+	getter := index.getRemoteURLHandler()
+	if sandbox != nil && sandbox.MaxFileSize > 0 {
+		// stop reading the body once it's too large.
+		unlimited := getter
+		getter = func(u string) (*http.Response, error) {
+			resp, err := unlimited(u)
+			if err == nil {
+				resp.Body = limitedBody{Reader: io.LimitReader(resp.Body, sandbox.MaxFileSize+1), Closer: resp.Body}
+			}
+			return resp, err
+		}
+	}
+	bc := make(chan []byte)
+	ec := make(chan error)
+	go getRemoteDoc(getter, uri, bc, ec)
+	select {
+	case b := <-bc:
+		return sandbox.read(uri, bytes.NewReader(b))
+	case err := <-ec:
+		return nil, err
+	}
+}
+
+type limitedBody struct {
+	io.Reader
+	io.Closer
+}
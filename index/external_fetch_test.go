@@ -0,0 +1,117 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_MaxConcurrentFetches(t *testing.T) {
+	var inflight, maxInflight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			m := maxInflight.Load()
+			if n <= m || maxInflight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	var spec strings.Builder
+	spec.WriteString("openapi: 3.1.0\ncomponents:\n  schemas:\n")
+	for i := 0; i < 8; i++ {
+		spec.WriteString(fmt.Sprintf("    Thing%d:\n      $ref: '%s/spec%d.yaml#/components/schemas/Pet'\n",
+			i, server.URL, i))
+	}
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec.String()), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.MaxConcurrentFetches = 2
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Len(t, idx.GetMappedReferences(), 8)
+	assert.LessOrEqual(t, maxInflight.Load(), int32(2))
+}
+
+func TestSpecIndex_ConcurrentFetches_SharedFetch(t *testing.T) {
+	var served atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		served.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = rw.Write([]byte(`components:
+  schemas:
+    Pet:
+      type: object
+    Owner:
+      type: string
+    Burger:
+      type: boolean`))
+	}))
+	defer server.Close()
+
+	spec := fmt.Sprintf(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: '%[1]s/spec.yaml#/components/schemas/Pet'
+    Owner:
+      $ref: '%[1]s/spec.yaml#/components/schemas/Owner'
+    Burger:
+      $ref: '%[1]s/spec.yaml#/components/schemas/Burger'`, server.URL)
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Len(t, idx.GetMappedReferences(), 3)
+	assert.Equal(t, int32(1), served.Load())
+}
+
+func TestFetchGroup(t *testing.T) {
+	g := newFetchGroup(1)
+	var calls atomic.Int32
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := g.do("pizza", func() (*yaml.Node, error) {
+				<-start
+				calls.Add(1)
+				return &yaml.Node{Value: "pizza"}, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "pizza", n.Value)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(start)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+
+	// a nil group just runs the fetch.
+	var nilGroup *fetchGroup
+	n, _ := nilGroup.do("burger", func() (*yaml.Node, error) {
+		return &yaml.Node{Value: "burger"}, nil
+	})
+	assert.Equal(t, "burger", n.Value)
+	nilGroup.acquire()()
+}
@@ -18,6 +18,10 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 		return nil
 	}
 	var found []*Reference
+	if utils.IsNodeMap(node) {
+		index.checkDuplicateKeys(node, seenPath)
+	}
+	resource := index.enterSchemaResource(node, parent, seenPath)
 	if len(node.Content) > 0 {
 		var prev, polyName string
 		for i, n := range node.Content {
@@ -47,7 +51,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 				}
 				ref := &Reference{
 					Node: node.Content[i+1],
-					Path: fmt.Sprintf("$.%s.schema", strings.Join(seenPath, ".")),
+					Path: jsonPath(seenPath, ".schema"),
 				}
 				index.allInlineSchemaDefinitions = append(index.allInlineSchemaDefinitions, ref)
 
@@ -80,7 +84,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 
 					ref := &Reference{
 						Node: prop,
-						Path: fmt.Sprintf("$.%s.properties.%s", strings.Join(seenPath, "."), label),
+						Path: jsonPath(seenPath, ".properties.", label),
 					}
 					index.allInlineSchemaDefinitions = append(index.allInlineSchemaDefinitions, ref)
 
@@ -95,6 +99,32 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 				}
 			}
 
+			// capture anchors and dynamic references (3.1), anchors can be referenced by name, rather than by
+			// a JSON pointer.
+			if i%2 == 0 && (n.Value == "$anchor" || n.Value == "$dynamicAnchor" || n.Value == "$dynamicRef") &&
+				utils.IsNodeMap(node) && i+1 < len(node.Content) && utils.IsNodeStringValue(node.Content[i+1]) {
+				value := node.Content[i+1].Value
+				ref := &Reference{
+					Definition: value,
+					Name:       value,
+					Node:       node,
+					Path:       jsonPath(seenPath),
+				}
+				switch n.Value {
+				case "$anchor":
+					if key := anchorKey(resource, value); index.allAnchors[key] == nil {
+						index.allAnchors[key] = ref
+					}
+				case "$dynamicAnchor":
+					if key := anchorKey(resource, value); index.allDynamicAnchors[key] == nil {
+						index.allDynamicAnchors[key] = ref
+					}
+				case "$dynamicRef":
+					ref.Definition = anchorDefinition(resource, value)
+					index.allDynamicRefs = append(index.allDynamicRefs, ref)
+				}
+			}
+
 			if i%2 == 0 && n.Value == "$ref" {
 
 				// only look at scalar values, not maps (looking at you k8s)
@@ -104,12 +134,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 
 				index.linesWithRefs[n.Line] = true
 
-				fp := make([]string, len(seenPath))
-				for x, foundPathNode := range seenPath {
-					fp[x] = foundPathNode
-				}
-
-				value := node.Content[i+1].Value
+				value := anchorDefinition(resource, node.Content[i+1].Value)
 
 				segs := strings.Split(value, "/")
 				name := segs[len(segs)-1]
@@ -175,7 +200,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 
 				if value == "" {
 
-					completedPath := fmt.Sprintf("$.%s", strings.Join(fp, "."))
+					completedPath := jsonPath(seenPath)
 
 					indexError := &IndexingError{
 						Err:  errors.New("schema reference is empty and cannot be processed"),
@@ -194,7 +219,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 
 			if i%2 == 0 && n.Value != "$ref" && n.Value != "" {
 
-				nodePath := fmt.Sprintf("$.%s", strings.Join(seenPath, "."))
+				nodePath := jsonPath(seenPath)
 
 				// capture descriptions and summaries
 				if n.Value == "description" {
@@ -365,6 +390,9 @@ func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Referenc
 
 	locate := func(ref *Reference, refIndex int, sequence []*ReferenceMapped) {
 		located := index.FindComponent(ref.Definition, ref.Node)
+		if logger := index.GetLogger(); logger != nil {
+			logger.Debug("looked up reference", "reference", ref.Definition, "found", located != nil)
+		}
 		if located != nil {
 			index.refLock.Lock()
 			if index.allMappedRefs[ref.Definition] == nil {
@@ -421,6 +449,8 @@ func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Referenc
 		select {
 		case <-c:
 			completedRefs++
+			index.ReportProgress(Progress{Stage: ProgressResolvingReferences, Document: index.documentName(),
+				Done: completedRefs, Total: len(refsToCheck)})
 		}
 	}
 	for m := range mappedRefsInSequence {
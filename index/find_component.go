@@ -8,7 +8,6 @@ import (
     "io"
     "net/http"
     "net/url"
-    "os"
     "path/filepath"
     "strings"
     "time"
@@ -26,6 +25,11 @@ func (index *SpecIndex) FindComponent(componentId string, parent *yaml.Node) *Re
         return nil
     }
 
+    // references to schemas with an '$id' in this document (or anchors inside them) are found locally. (3.1)
+    if ref := index.findInSchemaResource(componentId, parent); ref != nil {
+        return ref
+    }
+
     remoteLookup := func(id string) (*yaml.Node, *yaml.Node, error) {
         if index.config.AllowRemoteLookup {
             return index.lookupRemoteReference(id)
@@ -36,6 +40,10 @@ func (index *SpecIndex) FindComponent(componentId string, parent *yaml.Node) *Re
     }
 
     fileLookup := func(id string) (*yaml.Node, *yaml.Node, error) {
+        // relative references in documents fetched using a scheme handler, are fetched using the same handler.
+        if remoteRef := index.resolveSchemeReference(id); remoteRef != "" {
+            return index.lookupRemoteReference(remoteRef)
+        }
         if index.config.AllowFileLookup {
             return index.lookupFileReference(id)
         } else {
@@ -44,6 +52,31 @@ func (index *SpecIndex) FindComponent(componentId string, parent *yaml.Node) *Re
         }
     }
 
+    // relative references are resolved against the base URI (RFC 3986), if the document declares one using
+    // $self or $id, or if it was retrieved remotely.
+    if target := index.resolveAgainstBaseURI(componentId); target != "" {
+        uri := strings.Split(target, "#")
+        if len(uri) == 1 {
+            target = fmt.Sprintf("%s#", target)
+            uri = append(uri, "")
+        }
+        ref := index.performExternalLookup(uri, target, remoteLookup, parent)
+        if ref != nil {
+            ref.Definition = componentId
+        }
+        return ref
+    }
+
+    // references using a scheme with a handler are always external, looked up using the handler.
+    if index.getSchemeHandler(componentId) != nil {
+        uri := strings.Split(componentId, "#")
+        if len(uri) == 1 {
+            componentId = fmt.Sprintf("%s#", componentId)
+            uri = append(uri, "")
+        }
+        return index.performExternalLookup(uri, componentId, index.lookupRemoteReference, parent)
+    }
+
     switch DetermineReferenceResolveType(componentId) {
     case LocalResolve: // ideally, every single ref in every single spec is local. however, this is not the case.
         return index.FindComponentInRoot(componentId)
@@ -98,6 +131,13 @@ func getRemoteDoc(g RemoteURLHandler, u string, d chan []byte, e chan error) {
         close(d)
         return
     }
+    defer resp.Body.Close()
+    if resp.StatusCode >= 400 {
+        e <- fmt.Errorf("unable to fetch remote document '%s': %s", u, resp.Status)
+        close(e)
+        close(d)
+        return
+    }
     var body []byte
     body, _ = io.ReadAll(resp.Body)
     d <- body
@@ -116,70 +156,15 @@ func (index *SpecIndex) lookupRemoteReference(ref string) (*yaml.Node, *yaml.Nod
     if alreadySeen {
         parsedRemoteDocument = foundDocument
     } else {
-
-        d := make(chan bool)
-        var body []byte
         var err error
-
-        go func(uri string) {
-            bc := make(chan []byte)
-            ec := make(chan error)
-            var getter RemoteURLHandler = httpClient.Get
-            if index.config != nil && index.config.RemoteURLHandler != nil {
-                getter = index.config.RemoteURLHandler
-            }
-
-            // if we have a remote handler, use it instead of the default.
-            if index.config != nil && index.config.FSHandler != nil {
-                go func() {
-                    remoteFS := index.config.FSHandler
-                    remoteFile, rErr := remoteFS.Open(uri)
-                    if rErr != nil {
-                        e := fmt.Errorf("unable to open remote file: %s", rErr)
-                        ec <- e
-                        return
-                    }
-                    b, ioErr := io.ReadAll(remoteFile)
-                    if ioErr != nil {
-                        e := fmt.Errorf("unable to read remote file bytes: %s", ioErr)
-                        ec <- e
-                        return
-                    }
-                    bc <- b
-                }()
-            } else {
-                go getRemoteDoc(getter, uri, bc, ec)
-            }
-            select {
-            case v := <-bc:
-                body = v
-                break
-            case er := <-ec:
-                err = er
-                break
-            }
-            if len(body) > 0 {
-                var remoteDoc yaml.Node
-                er := yaml.Unmarshal(body, &remoteDoc)
-                if er != nil {
-                    err = er
-                    d <- true
-                    return
-                }
-                parsedRemoteDocument = &remoteDoc
-                if index.config != nil {
-                    index.config.seenRemoteSources.Store(uri, &remoteDoc)
-                }
-            }
-            d <- true
-        }(uri[0])
-
-        // wait for double go fun.
-        <-d
+        parsedRemoteDocument, err = index.fetchRemoteDocument(uri[0])
         if err != nil {
             // no bueno.
             return nil, nil, err
         }
+        if parsedRemoteDocument == nil {
+            return nil, nil, fmt.Errorf("remote document '%s' is empty", uri[0])
+        }
     }
 
     // lookup item from reference by using a path query.
@@ -227,16 +212,20 @@ func (index *SpecIndex) lookupFileReference(ref string) (*yaml.Node, *yaml.Node,
         fileToRead := filepath.Join(base, filePath, fileName)
         var body []byte
         var err error
+        start := time.Now()
 
         // if we have an FS handler, use it instead of the default behavior
         if index.config != nil && index.config.FSHandler != nil {
             remoteFS := index.config.FSHandler
+            if cErr := index.countExternalFile(fileToRead); cErr != nil {
+                return nil, nil, cErr
+            }
             remoteFile, rErr := remoteFS.Open(fileToRead)
             if rErr != nil {
                 e := fmt.Errorf("unable to open file: %s", rErr)
                 return nil, nil, e
             }
-            body, err = io.ReadAll(remoteFile)
+            body, err = index.getSandbox().read(fileToRead, remoteFile)
             if err != nil {
                 e := fmt.Errorf("unable to read file bytes: %s", err)
                 return nil, nil, e
@@ -244,9 +233,9 @@ func (index *SpecIndex) lookupFileReference(ref string) (*yaml.Node, *yaml.Node,
 
         } else {
 
-            // try and read the file off the local file system, if it fails
+            // try and read the file off the local file system (or LocalFS), if it fails
             // check for a baseURL and then ask our remote lookup function to go try and get it.
-            body, err = os.ReadFile(fileToRead)
+            body, err = index.readLocalFile(fileToRead)
 
             if err != nil {
 
@@ -268,15 +257,15 @@ func (index *SpecIndex) lookupFileReference(ref string) (*yaml.Node, *yaml.Node,
                 }
             }
         }
-        var remoteDoc yaml.Node
-        err = yaml.Unmarshal(body, &remoteDoc)
+        index.logDebug("read file", "file", fileToRead, "bytes", len(body), "duration", time.Since(start))
+        index.reportDocumentFetched(fileToRead)
+        parsedRemoteDocument, err = index.parseDocument(body)
         if err != nil {
             return nil, nil, err
         }
-        parsedRemoteDocument = &remoteDoc
         if index.seenLocalSources != nil {
             index.sourceLock.Lock()
-            index.seenLocalSources[file] = &remoteDoc
+            index.seenLocalSources[file] = parsedRemoteDocument
             index.sourceLock.Unlock()
         }
     }
@@ -313,6 +302,20 @@ func (index *SpecIndex) lookupFileReference(ref string) (*yaml.Node, *yaml.Node,
 func (index *SpecIndex) FindComponentInRoot(componentId string) *Reference {
     if index.root != nil {
 
+        // plain name fragments are anchors (3.1), not paths.
+        if IsAnchorFragment(componentId) {
+            if a := index.FindAnchor(componentId); a != nil {
+                return &Reference{
+                    Definition:            componentId,
+                    Name:                  a.Name,
+                    Node:                  a.Node,
+                    Path:                  a.Path,
+                    RequiredRefProperties: index.extractDefinitionRequiredRefProperties(a.Node, map[string][]string{}),
+                }
+            }
+            return nil
+        }
+
         // check component for url encoding.
         if strings.Contains(componentId, "%") {
             // decode the url.
@@ -320,6 +323,18 @@ func (index *SpecIndex) FindComponentInRoot(componentId string) *Reference {
         }
 
         name, friendlySearch := utils.ConvertComponentIdIntoFriendlyPathSearch(componentId)
+
+        // components are mapped when indexing, so check the map before searching the document.
+        if resNode := index.lookupComponent(componentId); resNode != nil {
+            return &Reference{
+                Definition:            componentId,
+                Name:                  name,
+                Node:                  resNode,
+                Path:                  friendlySearch,
+                RequiredRefProperties: index.extractDefinitionRequiredRefProperties(resNode, map[string][]string{}),
+            }
+        }
+
         path, err := yamlpath.NewPath(friendlySearch)
         if path == nil || err != nil {
             return nil // no component found
@@ -353,7 +368,12 @@ func (index *SpecIndex) performExternalLookup(uri []string, componentId string,
         index.externalLock.RUnlock()
 
         if externalSpecIndex == nil {
-            _, newRoot, err := lookupFunction(componentId)
+            lookupId := componentId
+            if len(uri) >= 2 && IsAnchorFragment(uri[1]) {
+                // anchors (3.1) are not paths, pull in the entire document and let the new index locate the anchor.
+                lookupId = fmt.Sprintf("%s#", uri[0])
+            }
+            _, newRoot, err := lookupFunction(lookupId)
             if err != nil {
                 indexError := &IndexingError{
                     Err:  err,
@@ -376,6 +396,8 @@ func (index *SpecIndex) performExternalLookup(uri []string, componentId string,
             }
             if index.config.BasePath != "" {
                 bd = index.config.BasePath
+            } else if index.config.LocalFS != nil {
+                bd = "." // the root of the file system.
             }
 
             var path, newBasePath string
@@ -407,14 +429,34 @@ func (index *SpecIndex) performExternalLookup(uri []string, componentId string,
 
             if newUrl != nil || newBasePath != "" {
                 newConfig := &SpecIndexConfig{
-                    BaseURL:           newUrl,
-                    BasePath:          newBasePath,
-                    AllowRemoteLookup: index.config.AllowRemoteLookup,
-                    AllowFileLookup:   index.config.AllowFileLookup,
-                    ParentIndex:       index,
-                    seenRemoteSources: index.config.seenRemoteSources,
-                    remoteLock:        index.config.remoteLock,
-                    uri:               uri,
+                    BaseURL:              newUrl,
+                    BasePath:             newBasePath,
+                    AllowRemoteLookup:    index.config.AllowRemoteLookup,
+                    AllowFileLookup:      index.config.AllowFileLookup,
+                    RemoteURLHandler:     index.config.RemoteURLHandler,
+                    RemoteHTTPClient:     index.config.RemoteHTTPClient,
+                    RemoteHeaders:        index.config.RemoteHeaders,
+                    RemoteTimeout:        index.config.RemoteTimeout,
+                    RemoteCheckRedirect:  index.config.RemoteCheckRedirect,
+                    RemoteCache:          index.config.RemoteCache,
+                    MaxConcurrentFetches: index.config.MaxConcurrentFetches,
+                    SchemeHandlers:       index.config.SchemeHandlers,
+                    LocalFS:              index.config.LocalFS,
+                    ParentIndex:          index,
+                    seenRemoteSources:    index.config.seenRemoteSources,
+                    remoteLock:           index.config.remoteLock,
+                    fetches:              index.config.fetches,
+                    Sandbox:              index.config.Sandbox,
+                    ParsedDocumentCache:  index.config.ParsedDocumentCache,
+                    InternStrings:        index.config.InternStrings,
+                    SkipExamples:         index.config.SkipExamples,
+                    TolerateBuildErrors:  index.config.TolerateBuildErrors,
+                    WarningHandler:       index.config.WarningHandler,
+                    Logger:               index.config.Logger,
+                    ProgressHandler:      index.config.ProgressHandler,
+                    interner:             index.config.interner,
+                    retrievalURI:         index.resolveRetrievalURI(uri[0]),
+                    uri:                  uri,
                 }
 
                 var newIndex *SpecIndex
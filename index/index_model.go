@@ -9,7 +9,10 @@ import (
 	"net/url"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/pb33f/libopenapi/utils"
 	"golang.org/x/sync/syncmap"
 	"gopkg.in/yaml.v3"
 )
@@ -67,6 +70,54 @@ type SpecIndexConfig struct {
 	// Resolves [#132]: https://github.com/pb33f/libopenapi/issues/132
 	RemoteURLHandler func(url string) (*http.Response, error)
 
+	// RemoteHTTPClient is the client used to fetch remote documents, if no RemoteURLHandler is set. If not set, a
+	// default client with a 60 second timeout is used.
+	RemoteHTTPClient *http.Client
+
+	// RemoteHeaders are headers added to every request for a remote document, by host (for example 'pb33f.io' or
+	// 'localhost:8080'). Useful for passing API tokens to private specification registries. Headers are only sent
+	// to the host they are configured for, they are removed if a request is redirected to another host.
+	RemoteHeaders map[string]http.Header
+
+	// RemoteTimeout overrides the timeout of the client used to fetch remote documents.
+	RemoteTimeout time.Duration
+
+	// RemoteCheckRedirect overrides the redirect policy of the client used to fetch remote documents, it works
+	// exactly the same as http.Client.CheckRedirect.
+	RemoteCheckRedirect func(req *http.Request, via []*http.Request) error
+
+	// RemoteCache holds remote documents between builds, so they aren't downloaded again (see NewMemoryRemoteCache
+	// and NewDirectoryRemoteCache). Cached documents are revalidated using their ETag or Last-Modified validators.
+	RemoteCache RemoteCache
+
+	// ParsedDocumentCache holds parsed external documents by the hash of their content, so the same files are not
+	// parsed again by every build (see NewMemoryParsedDocumentCache). Every index gets its own copy of a cached
+	// document, so resolving can't change it.
+	ParsedDocumentCache ParsedDocumentCache
+
+	// MaxConcurrentFetches limits the number of external documents (remote or local files) that are fetched and
+	// parsed at once, across the index and all of its children. Zero (the default) means no limit. Lookups of the
+	// same remote document share a single fetch, regardless of the limit.
+	MaxConcurrentFetches int
+
+	// Sandbox restricts the hosts, files and sizes of documents that can be read when resolving references, use it
+	// when indexing untrusted specifications. It's shared with every child index.
+	Sandbox *ReferenceSandbox
+
+	// CircularReferencePolicy decides if circular references found by the resolver are errors, and if they are
+	// stubbed when resolving. The default allows every circular reference that isn't infinite.
+	CircularReferencePolicy CircularReferencePolicy
+
+	// CircularReferenceStubDepth is the number of times a loop is expanded, before being stubbed, when using the
+	// CircularReferencesStub policy. Zero stubs the loop where it's found.
+	CircularReferenceStubDepth int
+
+	// SchemeHandlers are used to fetch documents referenced using non-http schemes, by scheme (for example 's3',
+	// 'git' or 'registry'). Each handler is passed the full URI of the document (without the fragment), and returns
+	// its bytes. This makes it possible to resolve references against spec registries, without downloading them
+	// first. Schemes with a handler are resolved even if AllowRemoteLookup is false.
+	SchemeHandlers map[string]SchemeHandler
+
 	// FSHandler is an entity that implements the `fs.FS` interface that will be used to fetch local or remote documents.
 	// This is useful if you want to use a custom file system handler, or if you want to use a custom http client or
 	// custom network implementation for a lookup.
@@ -81,9 +132,21 @@ type SpecIndexConfig struct {
 	// Resolves[#85] https://github.com/pb33f/libopenapi/issues/85
 	FSHandler fs.FS
 
+	// LocalFS is the file system file references are read from, instead of the OS file system. Any fs.FS can be
+	// used, for example an embed.FS or a fstest.MapFS, which allows multi-file specifications to be resolved without
+	// touching the disk.
+	//
+	// When set, BasePath is a path within LocalFS (use '.' or leave empty for the root of the file system). Unlike
+	// the FSHandler, LocalFS is only used for file references, and is passed valid fs.FS paths.
+	LocalFS fs.FS
+
 	// If resolving locally, the BasePath will be the root from which relative references will be resolved from
 	BasePath string // set the Base Path for resolving relative references if the spec is exploded.
 
+	// SpecAbsolutePath is the location (a file path or URL) of the root document being indexed. It's used to report
+	// where nodes originated from (see FindNodeOrigin), relative paths are resolved against the working directory.
+	SpecAbsolutePath string
+
 	// In an earlier version of libopenapi (pre 0.6.0) the index would automatically resolve all references
 	// They could have been local, or they could have been remote. This was a problem because it meant
 	// There was a potential for a remote exploit if a remote reference was malicious. There aren't any known
@@ -104,10 +167,39 @@ type SpecIndexConfig struct {
 	// Use the `BuildIndex()` method on the index to build it out once resolved/ready.
 	AvoidBuildIndex bool
 
+	// InternStrings will make map keys and reference values that are repeated throughout the specification (and
+	// every document it references) share a single copy, before the index is built. This reduces memory use on
+	// large specifications where the same names, media types and references repeat across many operations.
+	InternStrings bool
+
+	// SkipExamples will stop models built using the index from building any examples (example and examples values
+	// of schemas, parameters, headers, media types and responses). The examples are still indexed.
+	SkipExamples bool
+
+	// TolerateBuildErrors will make models built using the index carry on building when part of the model fails to
+	// build, instead of giving up on the object containing it. Failures are recorded on the reference the failed
+	// value is held by, and are collected by the index (see SpecIndex.GetBuildErrors).
+	TolerateBuildErrors bool
+
+	// WarningHandler is called with every warning as it is added to the index (see SpecIndex.AddWarning), warnings
+	// are passed in the order they are found, one at a time. Warnings are collected by the index either way.
+	WarningHandler func(warning *Warning)
+
+	// Logger will receive debug events while indexing, like files and remote documents being fetched and how long
+	// indexing took. Nothing is logged by default.
+	Logger Logger
+
+	// ProgressHandler is called with the progress of indexing (documents fetched and references resolved), and of
+	// models built using the index (paths built), so long builds can show progress. Not called by default.
+	ProgressHandler func(progress Progress)
+
 	// private fields
 	seenRemoteSources *syncmap.Map
 	remoteLock        *sync.Mutex
+	fetches           *fetchGroup
+	retrievalURI      *url.URL // where the document was retrieved from, for documents found by following references.
 	uri               []string
+	interner          *utils.StringInterner // shared by every index in the tree, when interning strings.
 }
 
 // CreateOpenAPIIndexConfig is a helper function to create a new SpecIndexConfig with the AllowRemoteLookup and
@@ -215,7 +307,16 @@ type SpecIndex struct {
 	allLinks                            map[string]*Reference                         // all links
 	callbacksNode                       *yaml.Node                                    // components/callbacks node
 	allCallbacks                        map[string]*Reference                         // all components examples
+	pathItemsNode                       *yaml.Node                                    // components/pathItems node (3.1)
+	allComponentPathItems               map[string]*Reference                         // all components path items (3.1)
+	webhooksNode                        *yaml.Node                                    // webhooks node (3.1)
+	allWebhooks                         map[string]*Reference                         // all webhooks (3.1)
 	allExternalDocuments                map[string]*Reference                         // all external documents
+	allAnchors                          map[string]*Reference                         // all schemas declaring an $anchor (3.1)
+	allDynamicAnchors                   map[string]*Reference                         // all schemas declaring a $dynamicAnchor (3.1)
+	allDynamicRefs                      []*Reference                                  // all $dynamicRef values found, in sequence (3.1)
+	allSchemaResources                  map[string]*Reference                         // all schemas declaring an $id, by absolute URI (3.1)
+	nodeResources                       map[*yaml.Node]string                         // the $id of the schema resource holding a node, if any (3.1)
 	externalSpecIndex                   map[string]*SpecIndex                         // create a primary index of all external specs and componentIds
 	refErrors                           []error                                       // errors when indexing references
 	operationParamErrors                []error                                       // errors when indexing parameters
@@ -231,11 +332,30 @@ type SpecIndex struct {
 	refLock                             sync.Mutex
 	sourceLock                          sync.Mutex
 	componentLock                       sync.RWMutex
+	spanLock                            sync.Mutex
+	nodeSpans                           map[*yaml.Node]NodeSpan // spans of every node looked up, calculated once.
+	componentLookup                     map[string]*yaml.Node   // every component in the root of the document, by reference.
+	originLock                          sync.Mutex
+	nodeOrigins                         map[*yaml.Node]*NodeOrigin // origins of every node in every known document.
+	originSources                       int                        // number of documents known when origins were mapped.
+	nodePaths                           map[*yaml.Node][]string    // paths of every node in every known document.
+	pathSources                         int                        // number of documents known when paths were mapped.
+	nodeParents                         map[*yaml.Node]*yaml.Node  // parent of every node, only mapped when refreshing.
+	graphLock                           sync.Mutex
+	refGraph                            *referenceGraph // every $ref and node location, built by FindReferencesTo.
 	externalLock                        sync.RWMutex
 	errorLock                           sync.RWMutex
+	warningLock                         sync.Mutex
+	warnings                            []*Warning
+	seenWarnings                        map[warningKey]bool
+	progressLock                        sync.Mutex
+	buildErrorLock                      sync.Mutex
+	buildErrors                         []error
+	documentsFetched                    int
 	circularReferences                  []*CircularReferenceResult // only available when the resolver has been used.
 	allowCircularReferences             bool                       // decide if you want to error out, or allow circular references, default is false.
 	relativePath                        string                     // relative path of the spec file.
+	baseURI                             *url.URL                   // the URI declared using $self or $id, if any.
 	config                              *SpecIndexConfig           // configuration for the index
 	httpClient                          *http.Client
 	componentIndexChan                  chan bool
@@ -247,6 +367,25 @@ type SpecIndex struct {
 	parentIndex *SpecIndex
 	uri         []string
 	children    []*SpecIndex
+
+	// hashes of models built from this index, while a comparison is caching them (see SetHashCache).
+	hashCache atomic.Pointer[sync.Map]
+}
+
+// SetHashCache attaches a cache of model hashes to the index, used by comparisons so every model built from the
+// index is only hashed once. It returns false (and attaches nothing) if another cache is already attached.
+func (index *SpecIndex) SetHashCache(cache *sync.Map) bool {
+	return index.hashCache.CompareAndSwap(nil, cache)
+}
+
+// ClearHashCache detaches cache from the index, if it's the cache that is attached.
+func (index *SpecIndex) ClearHashCache(cache *sync.Map) {
+	index.hashCache.CompareAndSwap(cache, nil)
+}
+
+// GetHashCache returns the cache of model hashes attached to the index, or nil if hashes are not being cached.
+func (index *SpecIndex) GetHashCache() *sync.Map {
+	return index.hashCache.Load()
 }
 
 func (index *SpecIndex) AddChild(child *SpecIndex) {
@@ -273,6 +412,11 @@ func (i *IndexingError) Error() string {
 	return i.Err.Error()
 }
 
+// Unwrap returns the underlying error.
+func (i *IndexingError) Unwrap() error {
+	return i.Err
+}
+
 // DescriptionReference holds data about a description that was found and where it was found.
 type DescriptionReference struct {
 	Content   string
@@ -78,7 +78,13 @@ func boostrapIndexCollections(rootNode *yaml.Node, index *SpecIndex) {
 	index.allExamples = make(map[string]*Reference)
 	index.allLinks = make(map[string]*Reference)
 	index.allCallbacks = make(map[string]*Reference)
+	index.allComponentPathItems = make(map[string]*Reference)
+	index.allWebhooks = make(map[string]*Reference)
 	index.allExternalDocuments = make(map[string]*Reference)
+	index.allAnchors = make(map[string]*Reference)
+	index.allDynamicAnchors = make(map[string]*Reference)
+	index.allSchemaResources = make(map[string]*Reference)
+	index.nodeResources = make(map[*yaml.Node]string)
 	index.securityRequirementRefs = make(map[string]map[string][]*Reference)
 	index.polymorphicRefs = make(map[string]*Reference)
 	index.refsWithSiblings = make(map[string]Reference)
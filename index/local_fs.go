@@ -0,0 +1,167 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// readLocalFile reads a file referenced by the document, from LocalFS if configured, otherwise from the OS file
+// system. The sandbox (if configured) is checked first.
+func (index *SpecIndex) readLocalFile(file string) ([]byte, error) {
+	var f io.Reader
+	sandbox := index.getSandbox()
+	if index.config != nil {
+		release := index.config.fetches.acquire()
+		defer release()
+	}
+	if index.config == nil || index.config.LocalFS == nil {
+		if err := sandbox.checkFile(file); err != nil {
+			return nil, err
+		}
+		if err := index.countExternalFile(file); err != nil {
+			return nil, err
+		}
+		osFile, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer osFile.Close()
+		f = osFile
+	} else {
+		if err := index.countExternalFile(toFSPath(file)); err != nil {
+			return nil, err
+		}
+		fsFile, err := index.config.LocalFS.Open(toFSPath(file))
+		if err != nil {
+			return nil, err
+		}
+		defer fsFile.Close()
+		f = fsFile
+	}
+	return sandbox.read(file, f)
+}
+
+// toFSPath converts a file path into a path that is valid for an fs.FS, which are always slash separated and
+// unrooted. Paths that escape the root of the file system remain invalid.
+func toFSPath(file string) string {
+	p := strings.TrimPrefix(path.Clean(filepath.ToSlash(file)), "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+// NewVirtualFS creates a read-only file system from a map of paths to file contents, it's the file universe of a
+// multi-file specification that isn't on disk (for example, one received over an API). Paths are slash separated and
+// relative to the root of the file system, leading slashes and './' are ignored. Set it as the LocalFS of a config to
+// resolve file references against it, any file that isn't in the map doesn't exist. Directories are implied by the
+// paths of the files in them, so the file system can be walked (with fs.WalkDir, for example).
+func NewVirtualFS(files map[string][]byte) fs.FS {
+	v := make(virtualFS, len(files))
+	for name, data := range files {
+		v[toFSPath(name)] = data
+	}
+	return v
+}
+
+type virtualFS map[string][]byte
+
+func (v virtualFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if data, ok := v[name]; ok {
+		return &virtualFile{name: name, size: int64(len(data)), Reader: bytes.NewReader(data)}, nil
+	}
+	entries := v.readDir(name)
+	if entries == nil && name != "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &virtualDir{name: name, entries: entries}, nil
+}
+
+// readDir returns the entries of a directory, sorted by name, directories are implied by the paths of the files
+// in them. Returns nil if there is no such directory.
+func (v virtualFS) readDir(name string) []fs.DirEntry {
+	prefix := name + "/"
+	if name == "." {
+		prefix = ""
+	}
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for file, data := range v {
+		if !strings.HasPrefix(file, prefix) {
+			continue
+		}
+		child, _, dir := strings.Cut(file[len(prefix):], "/")
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		var info fs.FileInfo = &virtualFile{name: path.Join(name, child), size: int64(len(data))}
+		if dir {
+			info = &virtualDir{name: path.Join(name, child)}
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries
+}
+
+type virtualFile struct {
+	*bytes.Reader
+	name string
+	size int64
+}
+
+func (f *virtualFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *virtualFile) Close() error               { return nil }
+func (f *virtualFile) Name() string               { return path.Base(f.name) }
+func (f *virtualFile) Size() int64                { return f.size }
+func (f *virtualFile) Mode() fs.FileMode          { return 0o444 }
+func (f *virtualFile) ModTime() time.Time         { return time.Time{} }
+func (f *virtualFile) IsDir() bool                { return false }
+func (f *virtualFile) Sys() any                   { return nil }
+
+type virtualDir struct {
+	name    string
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *virtualDir) Stat() (fs.FileInfo, error) { return d, nil }
+func (d *virtualDir) Close() error               { return nil }
+func (d *virtualDir) Name() string               { return path.Base(d.name) }
+func (d *virtualDir) Size() int64                { return 0 }
+func (d *virtualDir) Mode() fs.FileMode          { return fs.ModeDir | 0o555 }
+func (d *virtualDir) ModTime() time.Time         { return time.Time{} }
+func (d *virtualDir) IsDir() bool                { return true }
+func (d *virtualDir) Sys() any                   { return nil }
+
+func (d *virtualDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+// ReadDir reads the entries of the directory, following the rules of fs.ReadDirFile.
+func (d *virtualDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n > 0 && len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(remaining) {
+		remaining = remaining[:n]
+	}
+	d.offset += len(remaining)
+	return append([]fs.DirEntry(nil), remaining...), nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var localFS = fstest.MapFS{
+	"schemas/pet.yaml": {Data: []byte(`Pet:
+  type: object
+  properties:
+    owner:
+      $ref: 'owner.yaml#/Owner'`)},
+	"schemas/owner.yaml": {Data: []byte(`Owner:
+  type: string`)},
+}
+
+func TestSpecIndex_LocalFS(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'schemas/pet.yaml#/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = ""
+	c.LocalFS = localFS
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	ref := idx.GetMappedReferences()["schemas/pet.yaml#/Pet"]
+	assert.NotNil(t, ref)
+	assert.Equal(t, "object", ref.Node.Content[1].Value)
+
+	// the relative reference in pet.yaml is read from the same file system.
+	assert.Len(t, idx.GetChildren(), 1)
+	child := idx.GetChildren()[0]
+	assert.Empty(t, child.GetReferenceIndexErrors())
+	owner := child.GetMappedReferences()["owner.yaml#/Owner"]
+	assert.NotNil(t, owner)
+	assert.Equal(t, "string", owner.Node.Content[1].Value)
+}
+
+func TestSpecIndex_LocalFS_BasePath(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'pet.yaml#/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = "schemas"
+	c.LocalFS = localFS
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.NotNil(t, idx.GetMappedReferences()["pet.yaml#/Pet"])
+}
+
+func TestSpecIndex_LocalFS_Missing(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'burgers.yaml#/Burger'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = ""
+	c.LocalFS = localFS
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	errs := idx.GetReferenceIndexErrors()
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "open burgers.yaml: file does not exist", errs[0].Error())
+}
+
+func TestToFSPath(t *testing.T) {
+	assert.Equal(t, "schemas/pet.yaml", toFSPath("schemas/pet.yaml"))
+	assert.Equal(t, "schemas/pet.yaml", toFSPath("./schemas/../schemas/pet.yaml"))
+	assert.Equal(t, "schemas/pet.yaml", toFSPath("/schemas/pet.yaml"))
+	assert.Equal(t, ".", toFSPath(""))
+	assert.Equal(t, "../pet.yaml", toFSPath("../pet.yaml"))
+}
+
+func TestNewVirtualFS(t *testing.T) {
+	vfs := NewVirtualFS(map[string][]byte{
+		"./schemas/pet.yaml": []byte("Pet:\n  type: object"),
+		"/owner.yaml":        []byte("Owner:\n  type: string"),
+	})
+
+	b, err := fs.ReadFile(vfs, "schemas/pet.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "Pet:\n  type: object", string(b))
+
+	f, err := vfs.Open("owner.yaml")
+	assert.NoError(t, err)
+	info, _ := f.Stat()
+	assert.Equal(t, "owner.yaml", info.Name())
+	assert.Equal(t, int64(21), info.Size())
+	assert.False(t, info.IsDir())
+	assert.NoError(t, f.Close())
+
+	_, err = vfs.Open("burgers.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = vfs.Open("../owner.yaml")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+}
+
+func TestNewVirtualFS_Directories(t *testing.T) {
+	vfs := NewVirtualFS(map[string][]byte{
+		"openapi.yaml":              []byte("openapi: 3.1.0"),
+		"schemas/pet.yaml":          []byte("type: object"),
+		"schemas/people/owner.yaml": []byte("type: string"),
+	})
+	assert.NoError(t, fstest.TestFS(vfs, "openapi.yaml", "schemas/pet.yaml", "schemas/people/owner.yaml"))
+
+	var walked []string
+	err := fs.WalkDir(vfs, ".", func(path string, d fs.DirEntry, err error) error {
+		walked = append(walked, path)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".", "openapi.yaml", "schemas", "schemas/people", "schemas/people/owner.yaml",
+		"schemas/pet.yaml"}, walked)
+
+	entries, err := fs.ReadDir(vfs, "schemas")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.True(t, entries[0].IsDir())
+	assert.Equal(t, "people", entries[0].Name())
+
+	_, err = fs.ReadDir(vfs, "burgers")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = fs.ReadDir(NewVirtualFS(nil), ".")
+	assert.NoError(t, err)
+}
+
+func TestSpecIndex_VirtualFS(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'schemas/pet.yaml#/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = ""
+	c.LocalFS = NewVirtualFS(map[string][]byte{
+		"schemas/pet.yaml":   localFS["schemas/pet.yaml"].Data,
+		"schemas/owner.yaml": localFS["schemas/owner.yaml"].Data,
+	})
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.NotNil(t, idx.GetMappedReferences()["schemas/pet.yaml#/Pet"])
+	assert.Empty(t, idx.GetChildren()[0].GetReferenceIndexErrors())
+}
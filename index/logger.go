@@ -0,0 +1,40 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+// Logger is used to log debug events while indexing, resolving and building; files and remote documents that are
+// fetched, references that are resolved (or not), and how long each phase takes. A *slog.Logger is a Logger, other
+// loggers can be used by wrapping them.
+//
+// Events are logged with a message, followed by alternating keys and values, the same as slog.
+type Logger interface {
+	Debug(msg string, args ...any)
+}
+
+// GetLogger returns the logger configured for the index, or nil if there isn't one.
+func (index *SpecIndex) GetLogger() Logger {
+	if index == nil || index.config == nil {
+		return nil
+	}
+	return index.config.Logger
+}
+
+// logDebug logs an event if the index has a logger, callers on hot paths should check GetLogger first, so the
+// arguments are not allocated when nothing is logged.
+func (index *SpecIndex) logDebug(msg string, args ...any) {
+	if logger := index.GetLogger(); logger != nil {
+		logger.Debug(msg, args...)
+	}
+}
+
+// documentName returns a name for the document that has been indexed, used when logging.
+func (index *SpecIndex) documentName() string {
+	switch {
+	case len(index.uri) > 0 && index.uri[0] != "":
+		return index.uri[0]
+	case index.config != nil && index.config.SpecAbsolutePath != "":
+		return index.config.SpecAbsolutePath
+	}
+	return "root"
+}
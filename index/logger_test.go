@@ -0,0 +1,71 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type recordingLogger struct {
+	lock   sync.Mutex
+	events map[string][]map[string]any
+}
+
+func (r *recordingLogger) Debug(msg string, args ...any) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.events == nil {
+		r.events = make(map[string][]map[string]any)
+	}
+	attrs := make(map[string]any)
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[args[i].(string)] = args[i+1]
+	}
+	r.events[msg] = append(r.events[msg], attrs)
+}
+
+func TestSpecIndex_Logger(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'schemas/pet.yaml#/Pet'
+    Nothing:
+      $ref: '#/components/schemas/Missing'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	logger := new(recordingLogger)
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = ""
+	c.LocalFS = localFS
+	c.Logger = logger
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Equal(t, logger, idx.GetLogger())
+
+	assert.Len(t, logger.events["read file"], 2)
+	assert.Equal(t, "schemas/pet.yaml", logger.events["read file"][0]["file"])
+
+	// the root document and both files are indexed.
+	assert.Len(t, logger.events["built index"], 3)
+	root := logger.events["built index"][2] // files are indexed while the root document is.
+	assert.Equal(t, "root", root["document"])
+	assert.Equal(t, 1, root["errors"])
+
+	found := make(map[string]bool)
+	for _, e := range logger.events["looked up reference"] {
+		found[e["reference"].(string)] = e["found"].(bool)
+	}
+	assert.False(t, found["#/components/schemas/Missing"])
+	assert.True(t, found["schemas/pet.yaml#/Pet"])
+
+	// nothing is logged without a logger.
+	var nilIndex *SpecIndex
+	assert.Nil(t, nilIndex.GetLogger())
+	nilIndex.logDebug("nothing")
+}
@@ -0,0 +1,265 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// NodeOrigin describes where a yaml node originated from: the document (file or URL) it was read from, and its
+// position in that document. Nodes located by following a $ref into another document, originate from that document.
+type NodeOrigin struct {
+	Node             *yaml.Node // the node that was looked up.
+	Line             int        // the line of the node in the document it was found in.
+	Column           int        // the column of the node in the document it was found in.
+	AbsoluteLocation string     // the absolute path or URL of the document, may be empty for the root document.
+	RootDocument     bool       // the node was found in the root document of the index.
+}
+
+// GetSpecAbsolutePath returns the absolute path (or URL) of the root document of this index, if it is known.
+func (index *SpecIndex) GetSpecAbsolutePath() string {
+	if index.config == nil || index.config.SpecAbsolutePath == "" {
+		return ""
+	}
+	loc := index.config.SpecAbsolutePath
+	if strings.HasPrefix(loc, "http://") || strings.HasPrefix(loc, "https://") || filepath.IsAbs(loc) {
+		return loc
+	}
+	if abs, err := filepath.Abs(loc); err == nil {
+		return abs
+	}
+	return loc
+}
+
+// FindNodeOrigin will locate the document a node originated from, searching the root document and every local
+// and remote document that has been loaded by this index (or any of its parents and children). Returns nil if the
+// node could not be found in any known document.
+func (index *SpecIndex) FindNodeOrigin(node *yaml.Node) *NodeOrigin {
+	if node == nil {
+		return nil
+	}
+	root := index
+	for root.parentIndex != nil {
+		root = root.parentIndex
+	}
+	root.originLock.Lock()
+	defer root.originLock.Unlock()
+
+	o, ok := root.nodeOrigins[node]
+	if !ok {
+		// documents may have been loaded since the origins were last mapped.
+		if count := root.countSources(); count != root.originSources {
+			root.nodeOrigins = make(map[*yaml.Node]*NodeOrigin)
+			root.originSources = count
+			root.mapOrigins(root.nodeOrigins, make(map[*SpecIndex]bool))
+			o, ok = root.nodeOrigins[node]
+		}
+	}
+	if !ok {
+		return nil
+	}
+	return &NodeOrigin{
+		Node:             node,
+		Line:             node.Line,
+		Column:           node.Column,
+		AbsoluteLocation: o.AbsoluteLocation,
+		RootDocument:     o.RootDocument,
+	}
+}
+
+// countSources returns the number of documents known to this index and its children.
+func (index *SpecIndex) countSources() int {
+	count := 1
+	index.sourceLock.Lock()
+	count += len(index.seenLocalSources)
+	index.sourceLock.Unlock()
+	if index.config != nil && index.config.seenRemoteSources != nil {
+		index.config.seenRemoteSources.Range(func(_, _ any) bool {
+			count++
+			return true
+		})
+	}
+	index.externalLock.RLock()
+	for _, ext := range index.externalSpecIndex {
+		if ext != index {
+			count += ext.countSources()
+		}
+	}
+	index.externalLock.RUnlock()
+	return count
+}
+
+// mapOrigins records the origin of every node of every document known to this index and its children.
+func (index *SpecIndex) mapOrigins(origins map[*yaml.Node]*NodeOrigin, seen map[*SpecIndex]bool) {
+	if seen[index] {
+		return
+	}
+	seen[index] = true
+
+	location := index.GetSpecAbsolutePath()
+	if location == "" && index.relativePath != "" {
+		location = index.absoluteLocation(index.relativePath)
+	}
+	mapNodeOrigins(index.root, &NodeOrigin{AbsoluteLocation: location, RootDocument: index.parentIndex == nil},
+		origins)
+
+	index.sourceLock.Lock()
+	for file, n := range index.seenLocalSources {
+		mapNodeOrigins(n, &NodeOrigin{AbsoluteLocation: index.absoluteLocation(file)}, origins)
+	}
+	index.sourceLock.Unlock()
+	if index.config != nil && index.config.seenRemoteSources != nil {
+		index.config.seenRemoteSources.Range(func(k, v any) bool {
+			mapNodeOrigins(v.(*yaml.Node), &NodeOrigin{AbsoluteLocation: k.(string)}, origins)
+			return true
+		})
+	}
+
+	index.externalLock.RLock()
+	externals := make([]*SpecIndex, 0, len(index.externalSpecIndex))
+	for _, ext := range index.externalSpecIndex {
+		externals = append(externals, ext)
+	}
+	index.externalLock.RUnlock()
+	for _, ext := range externals {
+		ext.mapOrigins(origins, seen)
+	}
+}
+
+// absoluteLocation converts a file location relative to the base path of the index, into an absolute one.
+// URLs are returned as they are.
+func (index *SpecIndex) absoluteLocation(file string) string {
+	if strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://") {
+		return file
+	}
+	file = strings.TrimPrefix(file, "file:")
+	if !filepath.IsAbs(file) && index.config != nil && index.config.BasePath != "" {
+		file = filepath.Join(index.config.BasePath, file)
+	}
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+	return file
+}
+
+// mapNodeOrigins records the origin for a node and all of its descendants, nodes that have already been mapped
+// keep their first origin.
+func mapNodeOrigins(node *yaml.Node, origin *NodeOrigin, origins map[*yaml.Node]*NodeOrigin) {
+	if node == nil {
+		return
+	}
+	if _, ok := origins[node]; ok {
+		return
+	}
+	origins[node] = origin
+	for _, c := range node.Content {
+		mapNodeOrigins(c, origin, origins)
+	}
+}
+
+// FindNodePointer will locate a node in the document it originated from (see FindNodeOrigin), and return the JSON
+// pointer to it in that document, for example '#/paths/~1pets/get'. The pointer to a mapping key is the pointer to
+// its value. Returns an empty string if the node could not be found in any known document.
+func (index *SpecIndex) FindNodePointer(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+	root := index.rootIndex()
+	root.originLock.Lock()
+	defer root.originLock.Unlock()
+
+	segments, ok := root.nodePaths[node]
+	if !ok {
+		// documents may have been loaded since the paths were last mapped.
+		if count := root.countSources(); count != root.pathSources {
+			root.nodePaths = make(map[*yaml.Node][]string)
+			root.pathSources = count
+			root.forEachDocument(make(map[*SpecIndex]bool), func(doc *yaml.Node) bool {
+				mapNodePaths(doc, nil, root.nodePaths)
+				return false
+			})
+			segments, ok = root.nodePaths[node]
+		}
+	}
+	if !ok {
+		return ""
+	}
+	return "#" + utils.BuildJSONPointer(segments)
+}
+
+// forEachDocument calls visit with the root node of every document known to this index and its children, until
+// visit returns true.
+func (index *SpecIndex) forEachDocument(seen map[*SpecIndex]bool, visit func(doc *yaml.Node) bool) bool {
+	if seen[index] {
+		return false
+	}
+	seen[index] = true
+	if visit(index.root) {
+		return true
+	}
+	index.sourceLock.Lock()
+	docs := make([]*yaml.Node, 0, len(index.seenLocalSources))
+	for _, n := range index.seenLocalSources {
+		docs = append(docs, n)
+	}
+	index.sourceLock.Unlock()
+	if index.config != nil && index.config.seenRemoteSources != nil {
+		index.config.seenRemoteSources.Range(func(_, v any) bool {
+			docs = append(docs, v.(*yaml.Node))
+			return true
+		})
+	}
+	for _, doc := range docs {
+		if visit(doc) {
+			return true
+		}
+	}
+	index.externalLock.RLock()
+	externals := make([]*SpecIndex, 0, len(index.externalSpecIndex))
+	for _, ext := range index.externalSpecIndex {
+		externals = append(externals, ext)
+	}
+	index.externalLock.RUnlock()
+	for _, ext := range externals {
+		if ext.forEachDocument(seen, visit) {
+			return true
+		}
+	}
+	return false
+}
+
+// mapNodePaths records the path (from the document root) of a node and all of its descendants, nodes that have
+// already been mapped keep their first path. Mapping keys share the path of their value.
+func mapNodePaths(node *yaml.Node, path []string, paths map[*yaml.Node][]string) {
+	if node == nil {
+		return
+	}
+	if _, ok := paths[node]; ok {
+		return
+	}
+	paths[node] = path
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, c := range node.Content {
+			mapNodePaths(c, path, paths)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			p := utils.AppendPathSegment(path, node.Content[i].Value)
+			if _, ok := paths[node.Content[i]]; !ok {
+				paths[node.Content[i]] = p
+			}
+			mapNodePaths(node.Content[i+1], p, paths)
+		}
+	case yaml.SequenceNode:
+		for i, c := range node.Content {
+			mapNodePaths(c, utils.AppendPathSegment(path, strconv.Itoa(i)), paths)
+		}
+	}
+}
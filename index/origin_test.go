@@ -0,0 +1,97 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_FindNodeOrigin(t *testing.T) {
+	yml, _ := os.ReadFile("../test_specs/first.yaml")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(yml, &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = "../test_specs"
+	c.SpecAbsolutePath = "../test_specs/first.yaml"
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+
+	first, _ := filepath.Abs("../test_specs/first.yaml")
+	origin := idx.FindNodeOrigin(rootNode.Content[0])
+	assert.Equal(t, first, origin.AbsoluteLocation)
+	assert.True(t, origin.RootDocument)
+	assert.Equal(t, 1, origin.Line)
+
+	second, _ := filepath.Abs("../test_specs/second.yaml")
+	ref := idx.SearchIndexForReference("second.yaml#/properties/property2")
+	origin = idx.FindNodeOrigin(ref[0].Node)
+	assert.Equal(t, second, origin.AbsoluteLocation)
+	assert.False(t, origin.RootDocument)
+	assert.Equal(t, 23, origin.Line)
+	assert.Equal(t, 5, origin.Column)
+
+	// a document located by a child index, looked up from the child.
+	third, _ := filepath.Abs("../test_specs/third.yaml")
+	ref = idx.GetChildren()[0].SearchIndexForReference("third.yaml")
+	origin = idx.GetChildren()[0].FindNodeOrigin(ref[0].Node)
+	assert.Equal(t, third, origin.AbsoluteLocation)
+
+	assert.Nil(t, idx.FindNodeOrigin(&yaml.Node{}))
+	assert.Nil(t, idx.FindNodeOrigin(nil))
+}
+
+func TestSpecIndex_FindNodeOrigin_LateSource(t *testing.T) {
+	idx := positionIndex()
+	origin := idx.FindNodeOrigin(idx.GetRootNode().Content[0])
+	assert.Empty(t, origin.AbsoluteLocation)
+	assert.True(t, origin.RootDocument)
+
+	// documents loaded after the origins were mapped are picked up.
+	var remote yaml.Node
+	_ = yaml.Unmarshal([]byte("Pet:\n  type: string"), &remote)
+	idx.seenLocalSources["https://pb33f.io/pet.yaml"] = &remote
+	origin = idx.FindNodeOrigin(remote.Content[0].Content[1])
+	assert.Equal(t, "https://pb33f.io/pet.yaml", origin.AbsoluteLocation)
+	assert.Equal(t, 2, origin.Line)
+}
+
+func TestSpecIndex_GetSpecAbsolutePath(t *testing.T) {
+	idx := positionIndex()
+	assert.Empty(t, idx.GetSpecAbsolutePath())
+
+	idx.config.SpecAbsolutePath = "https://pb33f.io/openapi.yaml"
+	assert.Equal(t, "https://pb33f.io/openapi.yaml", idx.GetSpecAbsolutePath())
+
+	idx.config.SpecAbsolutePath = "openapi.yaml"
+	abs, _ := filepath.Abs("openapi.yaml")
+	assert.Equal(t, abs, idx.GetSpecAbsolutePath())
+}
+
+func TestSpecIndex_FindNodePointer(t *testing.T) {
+	idx := positionIndex()
+	root := idx.GetRootNode().Content[0]
+	assert.Equal(t, "#", idx.FindNodePointer(root))
+
+	// the pointer to a key and its value are the same.
+	_, paths := utils.FindKeyNode("paths", root.Content)
+	assert.Equal(t, "#/paths", idx.FindNodePointer(paths))
+	assert.Equal(t, "#/paths/~1pets", idx.FindNodePointer(paths.Content[0]))
+	assert.Equal(t, "#/paths/~1pets", idx.FindNodePointer(paths.Content[1]))
+
+	// documents loaded by the index have their own pointers.
+	var remote yaml.Node
+	_ = yaml.Unmarshal([]byte("Pet:\n  enum: [a, b]"), &remote)
+	idx.seenLocalSources["https://pb33f.io/pet.yaml"] = &remote
+	enum := remote.Content[0].Content[1].Content[1]
+	assert.Equal(t, "#/Pet/enum/1", idx.FindNodePointer(enum.Content[1]))
+
+	assert.Empty(t, idx.FindNodePointer(&yaml.Node{}))
+	assert.Empty(t, idx.FindNodePointer(nil))
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GetLoadedFiles returns the absolute path of every local file that was loaded while indexing, by this index or
+// any of its children (because a reference reachable from the root document pointed to it). The root document is
+// not included. If files are read from a LocalFS, the paths are paths within LocalFS instead.
+func (index *SpecIndex) GetLoadedFiles() []string {
+	root := index
+	for root.parentIndex != nil {
+		root = root.parentIndex
+	}
+	loaded := make(map[string]bool)
+	root.collectLoadedFiles(loaded, make(map[*SpecIndex]bool))
+	files := make([]string, 0, len(loaded))
+	for f := range loaded {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func (index *SpecIndex) collectLoadedFiles(loaded map[string]bool, seen map[*SpecIndex]bool) {
+	if seen[index] {
+		return
+	}
+	seen[index] = true
+	index.sourceLock.Lock()
+	for file := range index.seenLocalSources {
+		if loc := index.loadedLocation(file); !strings.HasPrefix(loc, "http://") &&
+			!strings.HasPrefix(loc, "https://") {
+			loaded[loc] = true
+		}
+	}
+	index.sourceLock.Unlock()
+	index.externalLock.RLock()
+	externals := make([]*SpecIndex, 0, len(index.externalSpecIndex))
+	for _, ext := range index.externalSpecIndex {
+		externals = append(externals, ext)
+	}
+	index.externalLock.RUnlock()
+	for _, ext := range externals {
+		ext.collectLoadedFiles(loaded, seen)
+	}
+}
+
+// loadedLocation returns the location of a file loaded by the index, the absolute path of the file, or its path
+// within LocalFS if one is configured.
+func (index *SpecIndex) loadedLocation(file string) string {
+	if index.config == nil || index.config.LocalFS == nil ||
+		strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://") {
+		return index.absoluteLocation(file)
+	}
+	return toFSPath(filepath.Join(index.config.BasePath, strings.TrimPrefix(file, "file:")))
+}
+
+// GetOrphanedFiles returns the absolute path of every specification file (.yaml, .yml or .json) found under the
+// base path of the index, that was never loaded. These files are not referenced by anything reachable from the root
+// document, so they are likely dead fragments. Hidden directories are skipped.
+//
+// If files are read from a LocalFS, the files under the base path within LocalFS are checked instead, and the paths
+// returned are paths within LocalFS.
+//
+// The root document is only excluded if its location is known (see SpecIndexConfig.SpecAbsolutePath). Returns
+// nil if there is no base path configured, or an error if the base path could not be read.
+func (index *SpecIndex) GetOrphanedFiles() ([]string, error) {
+	root := index
+	for root.parentIndex != nil {
+		root = root.parentIndex
+	}
+	if root.config == nil || (root.config.BasePath == "" && root.config.LocalFS == nil) {
+		return nil, nil
+	}
+
+	loaded := make(map[string]bool)
+	root.collectLoadedFiles(loaded, make(map[*SpecIndex]bool))
+
+	var orphans []string
+	walk := func(base string, ext func(string) string) fs.WalkDirFunc {
+		return func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != base && strings.HasPrefix(d.Name(), ".") {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			switch strings.ToLower(ext(path)) {
+			case ".yaml", ".yml", ".json":
+				if !loaded[path] {
+					orphans = append(orphans, path)
+				}
+			}
+			return nil
+		}
+	}
+
+	var err error
+	if root.config.LocalFS != nil {
+		if spec := root.config.SpecAbsolutePath; spec != "" {
+			loaded[toFSPath(spec)] = true
+		}
+		base := toFSPath(root.config.BasePath)
+		err = fs.WalkDir(root.config.LocalFS, base, walk(base, path.Ext))
+	} else {
+		if spec := root.GetSpecAbsolutePath(); spec != "" {
+			loaded[filepath.Clean(spec)] = true
+		}
+		var base string
+		if base, err = filepath.Abs(root.config.BasePath); err == nil {
+			err = filepath.WalkDir(base, walk(base, filepath.Ext))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(orphans)
+	return orphans, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func writeOrphanSpec(t *testing.T, dir string) {
+	files := map[string]string{
+		"openapi.yaml": `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`,
+		"pet.yaml": `type: object
+properties:
+  owner:
+    $ref: 'people/owner.yaml'`,
+		"people/owner.yaml": `type: string`,
+		"people/old.json":   `{"type": "string"}`,
+		"unused.yml":        `type: integer`,
+		".git/config.yaml":  `hidden: true`,
+		"README.md":         `not a spec`,
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		assert.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+}
+
+func TestSpecIndex_GetOrphanedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeOrphanSpec(t, dir)
+
+	spec, _ := os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(spec, &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = dir
+	c.SpecAbsolutePath = filepath.Join(dir, "openapi.yaml")
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+
+	assert.Equal(t, []string{
+		filepath.Join(dir, "people", "owner.yaml"),
+		filepath.Join(dir, "pet.yaml"),
+	}, idx.GetLoadedFiles())
+
+	orphans, err := idx.GetOrphanedFiles()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "people", "old.json"),
+		filepath.Join(dir, "unused.yml"),
+	}, orphans)
+
+	// the same answer, when asked from a child index.
+	orphans, _ = idx.GetChildren()[0].GetOrphanedFiles()
+	assert.Len(t, orphans, 2)
+}
+
+func TestSpecIndex_GetOrphanedFiles_LocalFS(t *testing.T) {
+	files := fstest.MapFS{
+		"specs/openapi.yaml": {Data: []byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`)},
+		"specs/pet.yaml": {Data: []byte(`type: object
+properties:
+  owner:
+    $ref: 'people/owner.yaml'`)},
+		"specs/people/owner.yaml": {Data: []byte(`type: string`)},
+		"specs/people/old.json":   {Data: []byte(`{"type": "string"}`)},
+		"specs/.git/config.yaml":  {Data: []byte(`hidden: true`)},
+		"other/unused.yaml":       {Data: []byte(`type: integer`)},
+	}
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(files["specs/openapi.yaml"].Data, &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.LocalFS = files
+	c.BasePath = "specs"
+	c.SpecAbsolutePath = "specs/openapi.yaml"
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+
+	assert.Equal(t, []string{"specs/people/owner.yaml", "specs/pet.yaml"}, idx.GetLoadedFiles())
+	orphans, err := idx.GetOrphanedFiles()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"specs/people/old.json"}, orphans)
+
+	// the whole file system is checked, when there is no base path.
+	c.BasePath = ""
+	c.SpecAbsolutePath = ""
+	_ = yaml.Unmarshal(files["specs/openapi.yaml"].Data, &rootNode)
+	orphans, err = NewSpecIndexWithConfig(&rootNode, c).GetOrphanedFiles()
+	assert.NoError(t, err)
+	assert.Contains(t, orphans, "other/unused.yaml")
+	assert.Contains(t, orphans, "specs/openapi.yaml")
+}
+
+func TestSpecIndex_GetOrphanedFiles_NoBasePath(t *testing.T) {
+	orphans, err := positionIndex().GetOrphanedFiles()
+	assert.NoError(t, err)
+	assert.Nil(t, orphans)
+
+	c := CreateClosedAPIIndexConfig()
+	c.BasePath = filepath.Join(t.TempDir(), "missing")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0"), &rootNode)
+	_, err = NewSpecIndexWithConfig(&rootNode, c).GetOrphanedFiles()
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ParsedDocumentCache holds parsed external documents (local files and remote documents) by the SHA-256 hash of
+// their content, so the same content is only parsed once, no matter how many documents (or builds) reference it.
+// Documents that change have a different hash, so they are always parsed again.
+//
+// Cached documents are never handed out directly, every index that reads the same content gets its own copy, so
+// resolving (which rewrites nodes in place) can't change the documents held by the cache.
+type ParsedDocumentCache interface {
+	// Get returns the document parsed from content with a hash, or nil if there isn't one.
+	Get(hash [32]byte) *yaml.Node
+
+	// Put caches a document parsed from content with a hash.
+	Put(hash [32]byte, doc *yaml.Node)
+}
+
+type parsedDocument struct {
+	hash [32]byte
+	doc  *yaml.Node
+}
+
+// memoryParsedDocumentCache is a ParsedDocumentCache that holds a limited number of documents, the least recently
+// used document is removed when full.
+type memoryParsedDocumentCache struct {
+	lock       sync.Mutex
+	maxEntries int
+	entries    map[[32]byte]*list.Element
+	order      *list.List
+}
+
+// NewMemoryParsedDocumentCache creates a ParsedDocumentCache that holds up to maxEntries documents in memory, least
+// recently used documents are removed first. If maxEntries is zero or less, the cache is unbounded.
+func NewMemoryParsedDocumentCache(maxEntries int) ParsedDocumentCache {
+	return &memoryParsedDocumentCache{
+		maxEntries: maxEntries,
+		entries:    make(map[[32]byte]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (m *memoryParsedDocumentCache) Get(hash [32]byte) *yaml.Node {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if e, ok := m.entries[hash]; ok {
+		m.order.MoveToFront(e)
+		return e.Value.(*parsedDocument).doc
+	}
+	return nil
+}
+
+func (m *memoryParsedDocumentCache) Put(hash [32]byte, doc *yaml.Node) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if e, ok := m.entries[hash]; ok {
+		e.Value.(*parsedDocument).doc = doc
+		m.order.MoveToFront(e)
+		return
+	}
+	m.entries[hash] = m.order.PushFront(&parsedDocument{hash: hash, doc: doc})
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*parsedDocument).hash)
+	}
+}
+
+// parseDocument parses the content of an external document, using the ParsedDocumentCache (if configured) to
+// avoid parsing the same content twice. Documents found in the cache are copied, so they can be mutated.
+func (index *SpecIndex) parseDocument(body []byte) (*yaml.Node, error) {
+	var cache ParsedDocumentCache
+	if index.config != nil {
+		cache = index.config.ParsedDocumentCache
+	}
+	var hash [32]byte
+	if cache != nil {
+		hash = sha256.Sum256(body)
+		if doc := cache.Get(hash); doc != nil {
+			return utils.CopyNode(doc), nil
+		}
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cache.Put(hash, utils.CopyNode(&doc))
+	}
+	return &doc, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMemoryParsedDocumentCache(t *testing.T) {
+	c := NewMemoryParsedDocumentCache(2)
+	a, b, d := sha256.Sum256([]byte("a")), sha256.Sum256([]byte("b")), sha256.Sum256([]byte("d"))
+	assert.Nil(t, c.Get(a))
+
+	na, nb, nd := &yaml.Node{Value: "a"}, &yaml.Node{Value: "b"}, &yaml.Node{Value: "d"}
+	c.Put(a, na)
+	c.Put(b, nb)
+	assert.Same(t, na, c.Get(a))
+
+	// b is the least recently used.
+	c.Put(d, nd)
+	assert.Nil(t, c.Get(b))
+	assert.Same(t, na, c.Get(a))
+	assert.Same(t, nd, c.Get(d))
+
+	c.Put(d, nb)
+	assert.Same(t, nb, c.Get(d))
+}
+
+func TestSpecIndex_ParsedDocumentCache(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'schemas/pet.yaml#/Pet'`
+
+	cache := NewMemoryParsedDocumentCache(0)
+	build := func() *SpecIndex {
+		var rootNode yaml.Node
+		_ = yaml.Unmarshal([]byte(spec), &rootNode)
+		c := CreateOpenAPIIndexConfig()
+		c.BasePath = ""
+		c.LocalFS = localFS
+		c.ParsedDocumentCache = cache
+		idx := NewSpecIndexWithConfig(&rootNode, c)
+		assert.Empty(t, idx.GetReferenceIndexErrors())
+		return idx
+	}
+
+	// both files are parsed once, and each index gets its own copy.
+	first := build()
+	assert.Len(t, first.GetChildren(), 1)
+	cached := cache.Get(sha256.Sum256(localFS["schemas/pet.yaml"].Data))
+	assert.NotNil(t, cached)
+	assert.NotNil(t, cache.Get(sha256.Sum256(localFS["schemas/owner.yaml"].Data)))
+	assert.NotSame(t, cached, first.GetChildren()[0].GetRootNode())
+
+	// mutating a document (like the resolver does) doesn't change the cached one, or the next index.
+	firstRoot := first.GetChildren()[0].GetRootNode()
+	want, _ := yaml.Marshal(firstRoot)
+	firstRoot.Content[0].Content = nil
+	second := build()
+	assert.Len(t, second.GetChildren(), 1)
+	got, _ := yaml.Marshal(second.GetChildren()[0].GetRootNode())
+	assert.Equal(t, string(want), string(got))
+	assert.NotSame(t, firstRoot, second.GetChildren()[0].GetRootNode())
+
+	// content that can't be parsed is not cached.
+	idx := NewSpecIndexWithConfig(nil, &SpecIndexConfig{ParsedDocumentCache: cache})
+	_, err := idx.parseDocument([]byte("not: [valid"))
+	assert.Error(t, err)
+	assert.Nil(t, cache.Get(sha256.Sum256([]byte("not: [valid"))))
+}
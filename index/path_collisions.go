@@ -0,0 +1,112 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PathCollisionKind is how two colliding paths are alike.
+type PathCollisionKind string
+
+const (
+	// PathDuplicate paths are defined more than once.
+	PathDuplicate PathCollisionKind = "duplicate"
+
+	// PathTemplateCollision paths are the same, apart from the names of their template parameters, like
+	// '/pets/{id}' and '/pets/{petId}'.
+	PathTemplateCollision PathCollisionKind = "template"
+
+	// PathTrailingSlashCollision paths only differ by a trailing slash, like '/pets' and '/pets/'.
+	PathTrailingSlashCollision PathCollisionKind = "trailing-slash"
+
+	// PathCaseCollision paths only differ by case, like '/pets' and '/Pets'.
+	PathCaseCollision PathCollisionKind = "case"
+)
+
+var pathTemplateExp = regexp.MustCompile(`\{[^}/]*}`)
+
+// CollidingPath is a path that collides with another path, and where it is defined.
+type CollidingPath struct {
+	Path    string
+	KeyNode *yaml.Node
+	Line    int
+	Column  int
+}
+
+// PathCollision is a pair of paths that routers are likely to treat as the same path, or to match ambiguously.
+type PathCollision struct {
+	Kind  PathCollisionKind
+	Paths [2]*CollidingPath // in the order they are defined.
+}
+
+// FindPathCollisions returns every pair of paths that collide. Paths collide when they are duplicated, or would be
+// the same once the names of their template parameters are ignored, or once a trailing slash is removed, or once
+// case is ignored. The kind of a collision is the first of those that makes the paths the same.
+//
+// Collisions are returned in the order the paths are defined.
+func (index *SpecIndex) FindPathCollisions() []*PathCollision {
+	if index.pathsNode == nil || index.pathsNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	var paths []*CollidingPath
+	for i := 0; i+1 < len(index.pathsNode.Content); i += 2 {
+		k := index.pathsNode.Content[i]
+		if strings.HasPrefix(k.Value, "x-") {
+			continue
+		}
+		paths = append(paths, &CollidingPath{Path: k.Value, KeyNode: k, Line: k.Line, Column: k.Column})
+	}
+
+	// group paths that collide in any way, then work out how each pair collides.
+	groups := make(map[string][]*CollidingPath)
+	var order []string
+	for _, p := range paths {
+		key := strings.ToLower(trimTrailingSlash(normalizePathTemplate(p.Path)))
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], p)
+	}
+	var collisions []*PathCollision
+	for _, key := range order {
+		group := groups[key]
+		for i := 0; i < len(group); i++ {
+			for j := i + 1; j < len(group); j++ {
+				collisions = append(collisions, &PathCollision{
+					Kind:  pathCollisionKind(group[i].Path, group[j].Path),
+					Paths: [2]*CollidingPath{group[i], group[j]},
+				})
+			}
+		}
+	}
+	return collisions
+}
+
+func pathCollisionKind(a, b string) PathCollisionKind {
+	switch {
+	case a == b:
+		return PathDuplicate
+	case normalizePathTemplate(a) == normalizePathTemplate(b):
+		return PathTemplateCollision
+	case trimTrailingSlash(normalizePathTemplate(a)) == trimTrailingSlash(normalizePathTemplate(b)):
+		return PathTrailingSlashCollision
+	}
+	return PathCaseCollision
+}
+
+// normalizePathTemplate removes the names of template parameters, '/pets/{id}' becomes '/pets/{}'.
+func normalizePathTemplate(path string) string {
+	return pathTemplateExp.ReplaceAllString(path, "{}")
+}
+
+func trimTrailingSlash(path string) string {
+	if len(path) > 1 {
+		return strings.TrimSuffix(path, "/")
+	}
+	return path
+}
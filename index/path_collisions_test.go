@@ -0,0 +1,84 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_FindPathCollisions(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets/{id}:
+    get:
+      description: get a pet
+  /pets/{petId}:
+    delete:
+      description: delete a pet
+  /owners:
+    get:
+      description: list owners
+  /owners/:
+    post:
+      description: add an owner
+  /Toys:
+    get:
+      description: list toys
+  /toys:
+    get:
+      description: list more toys
+  /toys:
+    post:
+      description: add a toy
+  /food:
+    get:
+      description: list food
+  x-owners/:
+    description: not a path`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	collisions := idx.FindPathCollisions()
+	assert.Len(t, collisions, 5)
+
+	assert.Equal(t, PathTemplateCollision, collisions[0].Kind)
+	assert.Equal(t, "/pets/{id}", collisions[0].Paths[0].Path)
+	assert.Equal(t, "/pets/{petId}", collisions[0].Paths[1].Path)
+	assert.Equal(t, 3, collisions[0].Paths[0].Line)
+	assert.Equal(t, 6, collisions[0].Paths[1].Line)
+
+	assert.Equal(t, PathTrailingSlashCollision, collisions[1].Kind)
+	assert.Equal(t, "/owners/", collisions[1].Paths[1].Path)
+
+	assert.Equal(t, PathCaseCollision, collisions[2].Kind)
+	assert.Equal(t, "/Toys", collisions[2].Paths[0].Path)
+	assert.Equal(t, PathCaseCollision, collisions[3].Kind)
+	assert.Equal(t, PathDuplicate, collisions[4].Kind)
+	assert.Equal(t, 18, collisions[4].Paths[0].Line)
+	assert.Equal(t, 21, collisions[4].Paths[1].Line)
+}
+
+func TestSpecIndex_FindPathCollisions_None(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0
+paths:
+  /:
+    get:
+      description: root
+  /pets/{id}:
+    get:
+      description: a pet
+  /pets/{id}/toys:
+    get:
+      description: toys`), &rootNode)
+	assert.Empty(t, NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig()).FindPathCollisions())
+
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0`), &rootNode)
+	assert.Nil(t, NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig()).FindPathCollisions())
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+var pathParameterExp = regexp.MustCompile(`\{([^}/]+)}`)
+
+// pathParameter is a parameter declared by a path item or operation, with any $ref resolved.
+type pathParameter struct {
+	name, in string
+	required bool
+	node     *yaml.Node // the parameter as declared (the $ref, if it's a reference).
+	path     string
+}
+
+// ValidatePathParameters checks that the template parameters of every path (like '{id}' in '/pets/{id}') match the
+// path parameters declared for each operation, either by the operation or by its path item. Every template
+// parameter must be declared as a required path parameter, and every path parameter must be in the template.
+// Parameters declared twice with the same name and location in the same list are reported as well.
+//
+// Each error points at the node of the parameter in question, or the key of the path when a declaration is
+// missing. Returns nil if every path is consistent.
+func (index *SpecIndex) ValidatePathParameters() []*IndexingError {
+	if index.pathsNode == nil || index.pathsNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	var errs []*IndexingError
+	for i := 0; i+1 < len(index.pathsNode.Content); i += 2 {
+		keyNode, pathItem := index.pathsNode.Content[i], index.pathsNode.Content[i+1]
+		if pathItem.Kind != yaml.MappingNode || strings.HasPrefix(keyNode.Value, "x-") {
+			continue
+		}
+		errs = append(errs, index.validatePathItemParameters(keyNode, index.resolveRefNode(pathItem))...)
+	}
+	return errs
+}
+
+func (index *SpecIndex) validatePathItemParameters(keyNode, pathItem *yaml.Node) []*IndexingError {
+	path := keyNode.Value
+	template := make(map[string]bool)
+	var templateNames []string
+	for _, m := range pathParameterExp.FindAllStringSubmatch(path, -1) {
+		if !template[m[1]] {
+			template[m[1]] = true
+			templateNames = append(templateNames, m[1])
+		}
+	}
+
+	var errs []*IndexingError
+	_, paramsNode := utils.FindKeyNodeTop("parameters", pathItem.Content)
+	topParams, dupes := index.collectPathParameters(paramsNode, fmt.Sprintf("$.paths.%s", path))
+	errs = append(errs, dupes...)
+	for _, p := range topParams {
+		errs = append(errs, checkPathParameter(p, path, template)...)
+	}
+	missing := func(declared map[string]bool, err func(name string) *IndexingError) {
+		for _, p := range topParams {
+			if p.in == "path" {
+				declared[p.name] = true
+			}
+		}
+		for _, name := range templateNames {
+			if !declared[name] {
+				errs = append(errs, err(name))
+			}
+		}
+	}
+
+	operations := 0
+	for i := 0; i+1 < len(pathItem.Content); i += 2 {
+		method := pathItem.Content[i].Value
+		if !isPathItemMethod(method) {
+			continue
+		}
+		operations++
+		op := pathItem.Content[i+1]
+		_, opParamsNode := utils.FindKeyNodeTop("parameters", op.Content)
+		opParams, dupes := index.collectPathParameters(opParamsNode, fmt.Sprintf("$.paths.%s.%s", path, method))
+		errs = append(errs, dupes...)
+
+		declared := make(map[string]bool)
+		for _, p := range opParams {
+			errs = append(errs, checkPathParameter(p, path, template)...)
+			if p.in == "path" {
+				declared[p.name] = true
+			}
+		}
+		missing(declared, func(name string) *IndexingError {
+			return &IndexingError{
+				Err: fmt.Errorf("the `%s` operation at path `%s` does not declare the `%s` path parameter",
+					method, path, name),
+				Node: keyNode,
+				Path: fmt.Sprintf("$.paths.%s.%s", path, method),
+			}
+		})
+	}
+
+	// without operations, the path item must declare the template parameters itself.
+	if operations == 0 {
+		missing(make(map[string]bool), func(name string) *IndexingError {
+			return &IndexingError{
+				Err:  fmt.Errorf("the path `%s` does not declare the `%s` path parameter", path, name),
+				Node: keyNode,
+				Path: fmt.Sprintf("$.paths.%s", path),
+			}
+		})
+	}
+	return errs
+}
+
+// checkPathParameter checks a path parameter is in the template of the path, and is required.
+func checkPathParameter(p *pathParameter, path string, template map[string]bool) []*IndexingError {
+	if p.in != "path" {
+		return nil
+	}
+	var errs []*IndexingError
+	if !template[p.name] {
+		errs = append(errs, &IndexingError{
+			Err:  fmt.Errorf("the `%s` path parameter is not in the template of path `%s`", p.name, path),
+			Node: p.node,
+			Path: p.path,
+		})
+	}
+	if !p.required {
+		errs = append(errs, &IndexingError{
+			Err:  fmt.Errorf("the `%s` path parameter at path `%s` must be required", p.name, path),
+			Node: p.node,
+			Path: p.path,
+		})
+	}
+	return errs
+}
+
+// collectPathParameters resolves every parameter in a list of parameters, and reports any declared twice.
+func (index *SpecIndex) collectPathParameters(params *yaml.Node, parent string) ([]*pathParameter, []*IndexingError) {
+	if params == nil || params.Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+	var found []*pathParameter
+	var errs []*IndexingError
+	seen := make(map[string]bool)
+	for i, node := range params.Content {
+		param := index.resolveRefNode(node)
+		_, name := utils.FindKeyNodeTop("name", param.Content)
+		_, in := utils.FindKeyNodeTop("in", param.Content)
+		if name == nil || in == nil {
+			continue
+		}
+		p := &pathParameter{
+			name: name.Value,
+			in:   in.Value,
+			node: node,
+			path: fmt.Sprintf("%s.parameters[%d]", parent, i),
+		}
+		if _, required := utils.FindKeyNodeTop("required", param.Content); required != nil {
+			p.required = required.Value == "true"
+		}
+		if seen[p.in+":"+p.name] {
+			errs = append(errs, &IndexingError{
+				Err:  fmt.Errorf("the `%s` %s parameter is declared more than once at `%s`", p.name, p.in, parent),
+				Node: node,
+				Path: p.path,
+			})
+			continue
+		}
+		seen[p.in+":"+p.name] = true
+		found = append(found, p)
+	}
+	return found, errs
+}
+
+// resolveRefNode returns the node a $ref points to, or the node itself if it's not a reference (or the reference
+// can't be found).
+func (index *SpecIndex) resolveRefNode(node *yaml.Node) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "$ref" {
+			if found := index.SearchIndexForReference(node.Content[i+1].Value); len(found) > 0 &&
+				found[0].Node != nil {
+				return found[0].Node
+			}
+			break
+		}
+	}
+	return node
+}
+
+func isPathItemMethod(key string) bool {
+	for _, m := range methodTypes {
+		if m == key {
+			return true
+		}
+	}
+	return key == "trace"
+}
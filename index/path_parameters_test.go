@@ -0,0 +1,86 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_ValidatePathParameters(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets/{id}:
+    parameters:
+      - $ref: '#/components/parameters/Id'
+    get:
+      description: get a pet
+    delete:
+      parameters:
+        - name: id
+          in: path
+          required: true
+  /owners/{ownerId}/pets:
+    get:
+      parameters:
+        - name: ownerId
+          in: path
+    post:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+        - name: limit
+          in: query
+        - name: limit
+          in: query
+  /toys/{toyId}:
+    parameters:
+      - name: toyId
+        in: path
+        required: true
+  /food/{foodId}:
+    summary: no operations
+  x-ignored/{nope}:
+    summary: not a path
+components:
+  parameters:
+    Id:
+      name: id
+      in: path
+      required: true`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	errs := idx.ValidatePathParameters()
+	assert.Len(t, errs, 5)
+
+	assert.Equal(t, "the `ownerId` path parameter at path `/owners/{ownerId}/pets` must be required", errs[0].Error())
+	assert.Equal(t, "$.paths./owners/{ownerId}/pets.get.parameters[0]", errs[0].Path)
+	assert.Equal(t, 16, errs[0].Node.Line)
+
+	assert.Equal(t, "the `limit` query parameter is declared more than once at `$.paths./owners/{ownerId}/pets.post`",
+		errs[1].Error())
+	assert.Equal(t, 25, errs[1].Node.Line)
+
+	assert.Equal(t, "the `petId` path parameter is not in the template of path `/owners/{ownerId}/pets`",
+		errs[2].Error())
+	assert.Equal(t, 20, errs[2].Node.Line)
+
+	assert.Equal(t, "the `post` operation at path `/owners/{ownerId}/pets` does not declare the `ownerId` path parameter",
+		errs[3].Error())
+	assert.Equal(t, 13, errs[3].Node.Line)
+
+	assert.Equal(t, "the path `/food/{foodId}` does not declare the `foodId` path parameter", errs[4].Error())
+}
+
+func TestSpecIndex_ValidatePathParameters_NoPaths(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0`), &rootNode)
+	assert.Nil(t, NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig()).ValidatePathParameters())
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// NodeSpan represents the range a yaml node covers in a document, from the first character of the node, to the last
+// character of its last descendant. Lines and columns start at 1, the end column is inclusive.
+type NodeSpan struct {
+	StartLine   int
+	StartColumn int
+	EndLine     int
+	EndColumn   int
+}
+
+// Contains returns true if the supplied line and column are within the span.
+func (s NodeSpan) Contains(line, col int) bool {
+	if line < s.StartLine || line > s.EndLine {
+		return false
+	}
+	if line == s.StartLine && col < s.StartColumn {
+		return false
+	}
+	if line == s.EndLine && col > s.EndColumn {
+		return false
+	}
+	return true
+}
+
+// NodeLocation is the result of looking up a position in a document. It describes the most specific node
+// covering a position, and where that node sits in the document.
+type NodeLocation struct {
+	File        string     // the file the node was found in, empty for the root document.
+	KeyNode     *yaml.Node // the key of the node in its parent mapping, nil for sequence items and the root.
+	ValueNode   *yaml.Node // the node covering the position.
+	Path        []string   // the (unescaped) segments of the JSON pointer to the node.
+	JSONPointer string     // the JSON pointer to the node, for example '#/paths/~1pets/get'.
+	Span        NodeSpan   // the span of the node, starting from the key if there is one.
+}
+
+// CalculateNodeSpan will calculate the span of a yaml node, including all of its descendants.
+func CalculateNodeSpan(node *yaml.Node) NodeSpan {
+	return calculateNodeSpan(node, nil)
+}
+
+func calculateNodeSpan(node *yaml.Node, spans map[*yaml.Node]NodeSpan) NodeSpan {
+	if node == nil {
+		return NodeSpan{}
+	}
+	if s, ok := spans[node]; ok {
+		return s
+	}
+	span := scalarSpan(node)
+	for _, c := range node.Content {
+		cs := calculateNodeSpan(c, spans)
+		if span.StartLine == 0 || (cs.StartLine != 0 && (cs.StartLine < span.StartLine ||
+			(cs.StartLine == span.StartLine && cs.StartColumn < span.StartColumn))) {
+			span.StartLine, span.StartColumn = cs.StartLine, cs.StartColumn
+		}
+		if cs.EndLine > span.EndLine || (cs.EndLine == span.EndLine && cs.EndColumn > span.EndColumn) {
+			span.EndLine, span.EndColumn = cs.EndLine, cs.EndColumn
+		}
+	}
+	if spans != nil {
+		spans[node] = span
+	}
+	return span
+}
+
+// scalarSpan calculates the span of a single node, without looking at its descendants.
+func scalarSpan(node *yaml.Node) NodeSpan {
+	span := NodeSpan{StartLine: node.Line, StartColumn: node.Column, EndLine: node.Line, EndColumn: node.Column}
+	if node.Kind != yaml.ScalarNode && node.Kind != yaml.AliasNode {
+		return span
+	}
+	value := node.Value
+	if node.Kind == yaml.AliasNode {
+		value = "*" + value
+	}
+	switch node.Style {
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		// block scalars start on the line after the indicator, the value does not contain indentation.
+		lines := strings.Split(strings.TrimSuffix(value, "\n"), "\n")
+		span.EndLine = node.Line + len(lines)
+		span.EndColumn = 1<<31 - 1
+		return span
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		value = "'" + value + "'"
+	}
+	lines := strings.Split(value, "\n")
+	span.EndLine = node.Line + len(lines) - 1
+	if len(lines) == 1 {
+		span.EndColumn = node.Column + len(value) - 1
+	} else {
+		span.EndColumn = len(lines[len(lines)-1])
+	}
+	if span.EndColumn < node.Column && span.EndLine == node.Line {
+		span.EndColumn = node.Column
+	}
+	return span
+}
+
+// GetNodeSpan returns the span of a yaml node found in this index (or any of the documents it has loaded), spans
+// are calculated once per document and retained by the index.
+func (index *SpecIndex) GetNodeSpan(node *yaml.Node) NodeSpan {
+	index.spanLock.Lock()
+	defer index.spanLock.Unlock()
+	if index.nodeSpans == nil {
+		index.nodeSpans = make(map[*yaml.Node]NodeSpan)
+	}
+	return calculateNodeSpan(node, index.nodeSpans)
+}
+
+// ObjectAtPosition will locate the most specific node that covers the supplied line and column in a file,
+// along with its JSON pointer. An empty file (or the path of the root document) will search the root document,
+// otherwise local and remote documents that have been loaded while indexing are searched.
+// Returns nil if the file is not known, or if nothing covers the position.
+func (index *SpecIndex) ObjectAtPosition(file string, line, col int) *NodeLocation {
+	root := index.findDocumentRoot(file)
+	if root == nil {
+		return nil
+	}
+	doc := root
+	if doc.Kind == yaml.DocumentNode {
+		if len(doc.Content) == 0 {
+			return nil
+		}
+		doc = doc.Content[0]
+	}
+	if !index.GetNodeSpan(doc).Contains(line, col) {
+		return nil
+	}
+	if file == index.relativePath {
+		file = ""
+	}
+	loc := &NodeLocation{File: file, ValueNode: doc, Span: index.GetNodeSpan(doc)}
+	for {
+		next := index.childAtPosition(loc, line, col)
+		if next == nil {
+			break
+		}
+		loc = next
+	}
+	loc.JSONPointer = "#" + utils.BuildJSONPointer(loc.Path)
+	return loc
+}
+
+// childAtPosition returns the child of the location that covers the position, or nil if there isn't one.
+func (index *SpecIndex) childAtPosition(loc *NodeLocation, line, col int) *NodeLocation {
+	n := loc.ValueNode
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			ks, vs := index.GetNodeSpan(k), index.GetNodeSpan(v)
+			span := NodeSpan{StartLine: ks.StartLine, StartColumn: ks.StartColumn, EndLine: vs.EndLine, EndColumn: vs.EndColumn}
+			if vs.EndLine < ks.EndLine || (vs.EndLine == ks.EndLine && vs.EndColumn < ks.EndColumn) {
+				span.EndLine, span.EndColumn = ks.EndLine, ks.EndColumn
+			}
+			if span.Contains(line, col) {
+				return &NodeLocation{File: loc.File, KeyNode: k, ValueNode: v,
+					Path: utils.AppendPathSegment(loc.Path, k.Value), Span: span}
+			}
+		}
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			if s := index.GetNodeSpan(c); s.Contains(line, col) {
+				return &NodeLocation{File: loc.File, ValueNode: c,
+					Path: utils.AppendPathSegment(loc.Path, strconv.Itoa(i)), Span: s}
+			}
+		}
+	}
+	return nil
+}
+
+// findDocumentRoot locates the root node of a file known to this index, or any of its parents.
+func (index *SpecIndex) findDocumentRoot(file string) *yaml.Node {
+	if file == "" || file == index.relativePath {
+		return index.root
+	}
+	index.sourceLock.Lock()
+	n := index.seenLocalSources[file]
+	index.sourceLock.Unlock()
+	if n != nil {
+		return n
+	}
+	if n = index.seenRemoteSources[file]; n != nil {
+		return n
+	}
+	index.externalLock.RLock()
+	ext := index.externalSpecIndex[file]
+	index.externalLock.RUnlock()
+	if ext != nil && ext != index {
+		return ext.root
+	}
+	if index.parentIndex != nil {
+		return index.parentIndex.findDocumentRoot(file)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var positionSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      description: |
+        line one
+        line two
+      tags:
+        - pets
+        - "animals"
+components:
+  schemas:
+    Pet:
+      type: object`
+
+func positionIndex() *SpecIndex {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(positionSpec), &rootNode)
+	return NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+}
+
+func TestCalculateNodeSpan(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(positionSpec), &rootNode)
+
+	span := CalculateNodeSpan(rootNode.Content[0])
+	assert.Equal(t, 1, span.StartLine)
+	assert.Equal(t, 1, span.StartColumn)
+	assert.Equal(t, 15, span.EndLine)
+	assert.Equal(t, 18, span.EndColumn)
+
+	// the value of 'openapi'
+	span = CalculateNodeSpan(rootNode.Content[0].Content[1])
+	assert.Equal(t, NodeSpan{StartLine: 1, StartColumn: 10, EndLine: 1, EndColumn: 14}, span)
+	assert.True(t, span.Contains(1, 14))
+	assert.False(t, span.Contains(1, 15))
+	assert.False(t, span.Contains(1, 9))
+	assert.False(t, span.Contains(2, 1))
+	assert.Equal(t, NodeSpan{}, CalculateNodeSpan(nil))
+}
+
+func TestSpecIndex_ObjectAtPosition(t *testing.T) {
+	idx := positionIndex()
+
+	loc := idx.ObjectAtPosition("", 5, 20)
+	assert.Equal(t, "#/paths/~1pets/get/operationId", loc.JSONPointer)
+	assert.Equal(t, "operationId", loc.KeyNode.Value)
+	assert.Equal(t, "listPets", loc.ValueNode.Value)
+	assert.Empty(t, loc.File)
+
+	// on the key, is the same as the value.
+	loc = idx.ObjectAtPosition("", 5, 7)
+	assert.Equal(t, "#/paths/~1pets/get/operationId", loc.JSONPointer)
+
+	// inside a block scalar.
+	loc = idx.ObjectAtPosition("", 8, 9)
+	assert.Equal(t, "#/paths/~1pets/get/description", loc.JSONPointer)
+
+	loc = idx.ObjectAtPosition("", 11, 12)
+	assert.Equal(t, "#/paths/~1pets/get/tags/1", loc.JSONPointer)
+	assert.Equal(t, []string{"paths", "/pets", "get", "tags", "1"}, loc.Path)
+
+	// past the end of a line, the most specific object is the one containing the line.
+	loc = idx.ObjectAtPosition("", 5, 60)
+	assert.Equal(t, "#/paths/~1pets/get", loc.JSONPointer)
+
+	loc = idx.ObjectAtPosition("", 15, 13)
+	assert.Equal(t, "#/components/schemas/Pet/type", loc.JSONPointer)
+	assert.Equal(t, NodeSpan{StartLine: 15, StartColumn: 7, EndLine: 15, EndColumn: 18}, loc.Span)
+
+	assert.Nil(t, idx.ObjectAtPosition("", 100, 1))
+	assert.Nil(t, idx.ObjectAtPosition("nope.yaml", 1, 1))
+}
+
+func TestSpecIndex_ObjectAtPosition_LocalFile(t *testing.T) {
+	idx := positionIndex()
+	var remote yaml.Node
+	_ = yaml.Unmarshal([]byte("Pet:\n  type: string"), &remote)
+	idx.seenLocalSources["pet.yaml"] = &remote
+
+	loc := idx.ObjectAtPosition("pet.yaml", 2, 10)
+	assert.Equal(t, "pet.yaml", loc.File)
+	assert.Equal(t, "#/Pet/type", loc.JSONPointer)
+}
+
+func TestSpecIndex_GetNodeSpan(t *testing.T) {
+	idx := positionIndex()
+	root := idx.GetRootNode().Content[0]
+	span := idx.GetNodeSpan(root)
+	assert.Equal(t, span, idx.nodeSpans[root])
+	assert.Equal(t, CalculateNodeSpan(root), span)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+// ProgressStage describes what is being worked on when progress is reported.
+type ProgressStage string
+
+const (
+	// ProgressFetchingDocuments is reported every time a file or remote document has been fetched. The total is
+	// not known up front, as documents can reference other documents.
+	ProgressFetchingDocuments ProgressStage = "fetching-documents"
+
+	// ProgressResolvingReferences is reported every time a reference in a document has been looked up.
+	ProgressResolvingReferences ProgressStage = "resolving-references"
+
+	// ProgressBuildingPaths is reported every time a path item of an OpenAPI 3+ document has been built.
+	ProgressBuildingPaths ProgressStage = "building-paths"
+)
+
+// Progress is reported while a document is indexed and built, so a long build can show how far along it is.
+type Progress struct {
+	Stage    ProgressStage
+	Document string // the document being worked on if known, "root" for the specification itself.
+	Done     int    // how many things are done (documents, references or paths).
+	Total    int    // how many things there are to do, zero if it's not known.
+}
+
+// Percent returns how far along the stage is as a percentage, or -1 if the total is not known.
+func (p Progress) Percent() float64 {
+	if p.Total <= 0 {
+		return -1
+	}
+	return float64(p.Done) / float64(p.Total) * 100
+}
+
+// ReportProgress passes progress to the ProgressHandler of the configuration (if set). Progress from the indexes of
+// referenced documents is reported by the root index, the handler is never called by more than one index at once.
+func (index *SpecIndex) ReportProgress(progress Progress) {
+	root := index.rootIndex()
+	if root == nil || root.config == nil || root.config.ProgressHandler == nil {
+		return
+	}
+	root.progressLock.Lock()
+	defer root.progressLock.Unlock()
+	root.config.ProgressHandler(progress)
+}
+
+// reportDocumentFetched reports that another file or remote document has been fetched.
+func (index *SpecIndex) reportDocumentFetched(name string) {
+	root := index.rootIndex()
+	if root == nil || root.config == nil || root.config.ProgressHandler == nil {
+		return
+	}
+	root.progressLock.Lock()
+	defer root.progressLock.Unlock()
+	root.documentsFetched++
+	root.config.ProgressHandler(Progress{Stage: ProgressFetchingDocuments, Document: name, Done: root.documentsFetched})
+}
+
+// rootIndex returns the index at the top of the tree the index belongs to.
+func (index *SpecIndex) rootIndex() *SpecIndex {
+	if index == nil {
+		return nil
+	}
+	root := index
+	for root.parentIndex != nil {
+		root = root.parentIndex
+	}
+	return root
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestProgress_Percent(t *testing.T) {
+	assert.Equal(t, 50.0, Progress{Done: 1, Total: 2}.Percent())
+	assert.Equal(t, -1.0, Progress{Done: 1}.Percent())
+}
+
+func TestSpecIndex_ProgressHandler(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'schemas/pet.yaml#/Pet'
+    Other:
+      $ref: '#/components/schemas/Thing'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	progress := make(map[ProgressStage][]Progress)
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = ""
+	c.LocalFS = localFS
+	c.ProgressHandler = func(p Progress) {
+		progress[p.Stage] = append(progress[p.Stage], p)
+	}
+	NewSpecIndexWithConfig(&rootNode, c)
+
+	assert.Equal(t, []Progress{
+		{Stage: ProgressFetchingDocuments, Document: "schemas/pet.yaml", Done: 1},
+		{Stage: ProgressFetchingDocuments, Document: "schemas/owner.yaml", Done: 2},
+	}, progress[ProgressFetchingDocuments])
+
+	// the last references resolved are those of the root document.
+	resolved := progress[ProgressResolvingReferences]
+	assert.Equal(t, Progress{Stage: ProgressResolvingReferences, Document: "root", Done: 2, Total: 2},
+		resolved[len(resolved)-1])
+
+	// nothing happens without a handler.
+	NewSpecIndexWithConfig(nil, CreateOpenAPIIndexConfig()).ReportProgress(Progress{})
+	var idx *SpecIndex
+	idx.ReportProgress(Progress{})
+	idx.reportDocumentFetched("nope")
+}
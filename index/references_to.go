@@ -0,0 +1,275 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ReferenceLocation describes a $ref that points to a component, and where that $ref lives.
+type ReferenceLocation struct {
+	Reference   *Reference         // the reference found, the node is the object containing the $ref.
+	File        string             // absolute location of the document containing the $ref, may be empty for the root document.
+	JSONPointer string             // JSON pointer of the object containing the $ref in its document, for example '#/paths/~1pets/get'.
+	Line        int                // the line of the $ref.
+	Column      int                // the column of the $ref.
+	Transitive  bool               // the component is referenced indirectly, through another object that references it.
+	Via         *ReferenceLocation // for transitive references, the $ref that leads (eventually) to the component.
+}
+
+// referencingEntry is a $ref found in any known document, along with the index it was found by.
+type referencingEntry struct {
+	ref    *Reference
+	idx    *SpecIndex
+	target *yaml.Node
+}
+
+// FindReferencesTo returns every location (in every known document) that references a component, for example
+// '#/components/schemas/Pet'. Transitive references are included, a $ref to an object that itself references the
+// component (or contains a reference to it) is a transitive reference. Locations are ordered by file and line,
+// direct references are returned before transitive ones.
+//
+// Returns nil if the component is not referenced anywhere.
+func (index *SpecIndex) FindReferencesTo(definition string) []*ReferenceLocation {
+	root := index
+	for root.parentIndex != nil {
+		root = root.parentIndex
+	}
+
+	graph := root.referenceGraph()
+	parents, paths, targets, entries := graph.parents, graph.paths, graph.targets, graph.entries
+
+	var component *yaml.Node
+	if found := index.SearchIndexForReference(definition); len(found) > 0 {
+		component = found[0].Node
+	}
+
+	locate := func(e *referencingEntry, via *ReferenceLocation) *ReferenceLocation {
+		loc := &ReferenceLocation{
+			Reference:   e.ref,
+			JSONPointer: "#" + utils.BuildJSONPointer(paths[e.ref.Node]),
+			Line:        e.ref.Node.Line,
+			Column:      e.ref.Node.Column,
+			Transitive:  via != nil,
+			Via:         via,
+		}
+		for i := 0; i+1 < len(e.ref.Node.Content); i += 2 {
+			if e.ref.Node.Content[i].Value == "$ref" {
+				loc.Line = e.ref.Node.Content[i].Line
+				loc.Column = e.ref.Node.Content[i].Column
+				break
+			}
+		}
+		if o := root.FindNodeOrigin(e.ref.Node); o != nil {
+			loc.File = o.AbsoluteLocation
+		}
+		return loc
+	}
+
+	var locations []*ReferenceLocation
+	if component == nil {
+		// the component can't be located, so only direct references by name can be found.
+		for _, e := range entries {
+			if e.ref.Definition == definition {
+				locations = append(locations, locate(e, nil))
+			}
+		}
+		sortReferenceLocations(locations)
+		return locations
+	}
+
+	// walk outwards from the component, every object that contains a $ref to something already found,
+	// becomes a new target.
+	type pending struct {
+		node *yaml.Node
+		via  *ReferenceLocation
+	}
+	queue := []pending{{node: component}}
+	seenTargets := map[*yaml.Node]bool{component: true}
+	seenLocations := make(map[*yaml.Node]bool)
+	var direct, transitive []*ReferenceLocation
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, e := range targets[p.node] {
+			if seenLocations[e.ref.Node] {
+				continue
+			}
+			seenLocations[e.ref.Node] = true
+			loc := locate(e, p.via)
+			if loc.Transitive {
+				transitive = append(transitive, loc)
+			} else {
+				direct = append(direct, loc)
+			}
+			for n := e.ref.Node; n != nil; n = parents[n] {
+				if _, ok := targets[n]; ok && !seenTargets[n] {
+					seenTargets[n] = true
+					queue = append(queue, pending{node: n, via: loc})
+				}
+			}
+		}
+	}
+	sortReferenceLocations(direct)
+	sortReferenceLocations(transitive)
+	return append(direct, transitive...)
+}
+
+// referenceGraph holds every $ref found by an index and its children, along with the parent and location of every
+// node in every known document. It's built once, and shared by every call to FindReferencesTo.
+type referenceGraph struct {
+	entries []*referencingEntry
+	targets map[*yaml.Node][]*referencingEntry // the references to each node that is the target of a $ref.
+	parents map[*yaml.Node]*yaml.Node
+	paths   map[*yaml.Node][]string
+	sources int // number of documents known when the graph was built.
+}
+
+// referenceGraph returns the reference graph of this (root) index, building it if no graph has been built yet, or
+// documents have been loaded since it was.
+func (index *SpecIndex) referenceGraph() *referenceGraph {
+	index.graphLock.Lock()
+	defer index.graphLock.Unlock()
+	sources := index.countSources()
+	if index.refGraph != nil && index.refGraph.sources == sources {
+		return index.refGraph
+	}
+
+	// collect every reference found, resolved to the node it points to.
+	graph := &referenceGraph{
+		targets: make(map[*yaml.Node][]*referencingEntry),
+		parents: make(map[*yaml.Node]*yaml.Node),
+		paths:   make(map[*yaml.Node][]string),
+		sources: sources,
+	}
+	index.collectReferencingEntries(&graph.entries, make(map[*yaml.Node]bool), make(map[*SpecIndex]bool))
+	for _, e := range graph.entries {
+		if e.target != nil {
+			graph.targets[e.target] = append(graph.targets[e.target], e)
+		}
+	}
+
+	// map every node in every document to its parent, and its location in the document.
+	for _, doc := range index.documentRoots() {
+		mapNodeParents(doc, nil, nil, graph.parents, graph.paths)
+	}
+	index.refGraph = graph
+	return graph
+}
+
+// collectReferencingEntries collects every reference found by this index and its children, resolving each one.
+func (index *SpecIndex) collectReferencingEntries(entries *[]*referencingEntry, seenRefs map[*yaml.Node]bool,
+	seen map[*SpecIndex]bool,
+) {
+	if seen[index] {
+		return
+	}
+	seen[index] = true
+	for _, ref := range index.rawSequencedRefs {
+		if ref.Node == nil || seenRefs[ref.Node] {
+			continue
+		}
+		seenRefs[ref.Node] = true
+		e := &referencingEntry{ref: ref, idx: index}
+		if found := index.SearchIndexForReference(ref.Definition); len(found) > 0 {
+			e.target = found[0].Node
+		}
+		*entries = append(*entries, e)
+	}
+	index.externalLock.RLock()
+	externals := make([]*SpecIndex, 0, len(index.externalSpecIndex))
+	for _, ext := range index.externalSpecIndex {
+		externals = append(externals, ext)
+	}
+	index.externalLock.RUnlock()
+	for _, ext := range externals {
+		ext.collectReferencingEntries(entries, seenRefs, seen)
+	}
+}
+
+// documentRoots returns the root node of every document known to this index and its children.
+func (index *SpecIndex) documentRoots() []*yaml.Node {
+	var roots []*yaml.Node
+	seen := make(map[*yaml.Node]bool)
+	add := func(n *yaml.Node) {
+		if n != nil && !seen[n] {
+			seen[n] = true
+			roots = append(roots, n)
+		}
+	}
+	var collect func(i *SpecIndex, seenIndexes map[*SpecIndex]bool)
+	collect = func(i *SpecIndex, seenIndexes map[*SpecIndex]bool) {
+		if seenIndexes[i] {
+			return
+		}
+		seenIndexes[i] = true
+		add(i.root)
+		i.sourceLock.Lock()
+		for _, n := range i.seenLocalSources {
+			add(n)
+		}
+		i.sourceLock.Unlock()
+		if i.config != nil && i.config.seenRemoteSources != nil {
+			i.config.seenRemoteSources.Range(func(_, v any) bool {
+				add(v.(*yaml.Node))
+				return true
+			})
+		}
+		i.externalLock.RLock()
+		externals := make([]*SpecIndex, 0, len(i.externalSpecIndex))
+		for _, ext := range i.externalSpecIndex {
+			externals = append(externals, ext)
+		}
+		i.externalLock.RUnlock()
+		for _, ext := range externals {
+			collect(ext, seenIndexes)
+		}
+	}
+	collect(index, make(map[*SpecIndex]bool))
+	return roots
+}
+
+// mapNodeParents records the parent and path (from the document root) of a node and all of its descendants.
+func mapNodeParents(node, parent *yaml.Node, path []string, parents map[*yaml.Node]*yaml.Node,
+	paths map[*yaml.Node][]string,
+) {
+	if node == nil {
+		return
+	}
+	if _, ok := paths[node]; ok {
+		return
+	}
+	parents[node] = parent
+	paths[node] = path
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, c := range node.Content {
+			mapNodeParents(c, node, path, parents, paths)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			mapNodeParents(node.Content[i+1], node, utils.AppendPathSegment(path, node.Content[i].Value), parents, paths)
+		}
+	case yaml.SequenceNode:
+		for i, c := range node.Content {
+			mapNodeParents(c, node, utils.AppendPathSegment(path, strconv.Itoa(i)), parents, paths)
+		}
+	}
+}
+
+func sortReferenceLocations(locations []*ReferenceLocation) {
+	sort.SliceStable(locations, func(i, j int) bool {
+		if locations[i].File != locations[j].File {
+			return locations[i].File < locations[j].File
+		}
+		if locations[i].Line != locations[j].Line {
+			return locations[i].Line < locations[j].Line
+		}
+		return locations[i].Column < locations[j].Column
+	})
+}
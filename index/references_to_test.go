@@ -0,0 +1,124 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var referencesToSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /owners:
+    get:
+      responses:
+        "200":
+          $ref: '#/components/responses/Owner'
+components:
+  responses:
+    Owner:
+      description: an owner
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Owner'
+  schemas:
+    Pet:
+      type: object
+    Dog:
+      $ref: '#/components/schemas/Pet'
+    Owner:
+      properties:
+        dog:
+          $ref: '#/components/schemas/Dog'
+        cat:
+          type: string
+    Nobody:
+      type: string`
+
+func TestSpecIndex_FindReferencesTo(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(referencesToSpec), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	locations := idx.FindReferencesTo("#/components/schemas/Pet")
+	assert.Len(t, locations, 5)
+
+	// direct references first.
+	assert.False(t, locations[0].Transitive)
+	assert.Equal(t, "#/paths/~1pets/get/responses/200/content/application~1json/schema", locations[0].JSONPointer)
+	assert.Equal(t, 10, locations[0].Line)
+	assert.Equal(t, 17, locations[0].Column)
+	assert.Equal(t, "#/components/schemas/Pet", locations[0].Reference.Definition)
+	assert.Empty(t, locations[0].File)
+	assert.False(t, locations[1].Transitive)
+	assert.Equal(t, "#/components/schemas/Dog", locations[1].JSONPointer)
+
+	// Owner references Dog, which references Pet.
+	assert.True(t, locations[2].Transitive)
+	assert.Equal(t, "#/paths/~1owners/get/responses/200", locations[2].JSONPointer)
+	assert.Equal(t, "#/components/responses/Owner/content/application~1json/schema", locations[2].Via.JSONPointer)
+	assert.Equal(t, "#/components/schemas/Owner/properties/dog", locations[2].Via.Via.JSONPointer)
+	assert.Equal(t, "#/components/responses/Owner/content/application~1json/schema", locations[3].JSONPointer)
+	assert.Equal(t, "#/components/schemas/Owner/properties/dog", locations[4].JSONPointer)
+	assert.Equal(t, "#/components/schemas/Dog", locations[4].Via.JSONPointer)
+	assert.Nil(t, locations[4].Via.Via)
+
+	assert.Len(t, idx.FindReferencesTo("#/components/schemas/Owner"), 2)
+	assert.Nil(t, idx.FindReferencesTo("#/components/schemas/Nobody"))
+	assert.Nil(t, idx.FindReferencesTo("#/components/schemas/Missing"))
+}
+
+func TestSpecIndex_FindReferencesTo_Missing(t *testing.T) {
+	yml := `paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          $ref: '#/components/responses/Missing'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	// the component does not exist, but references to it are still found.
+	locations := idx.FindReferencesTo("#/components/responses/Missing")
+	assert.Len(t, locations, 1)
+	assert.Equal(t, "#/paths/~1pets/get/responses/200", locations[0].JSONPointer)
+}
+
+func TestSpecIndex_FindReferencesTo_MultiFile(t *testing.T) {
+	yml, _ := os.ReadFile("../test_specs/first.yaml")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(yml, &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = "../test_specs"
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+
+	// third.yaml is referenced by second.yaml, which is referenced by first.yaml
+	third := idx.GetChildren()[0].FindReferencesTo("third.yaml")
+	assert.Len(t, third, 2)
+
+	second, _ := filepath.Abs("../test_specs/second.yaml")
+	assert.Equal(t, second, third[0].File)
+	assert.Equal(t, "#/properties/property1/items/properties/details", third[0].JSONPointer)
+	assert.False(t, third[0].Transitive)
+
+	assert.True(t, third[1].Transitive)
+	assert.Equal(t, "#/paths/~1items/get/responses/200/content/application~1json/schema/additionalProperties/properties/second",
+		third[1].JSONPointer)
+}
@@ -0,0 +1,434 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Refresh re-indexes the part of the document at a JSON pointer (for example '/components/schemas/Pet'), after it
+// has been changed in place. It's designed for long-lived processes (like editors and language servers) that mutate
+// the document, so they don't need to rebuild the entire index after every change.
+//
+// References in the changed node are extracted again, and only references that are new, or that point into the
+// changed node, are looked up. Everything else (including external documents) is kept. If the node has been removed,
+// pass its pointer anyway, the nearest remaining parent is refreshed. Changes that add, remove or rename components,
+// paths or tags also refresh the component and operation collections, which are re-built from the document without
+// looking anything up. Use an empty pointer to refresh the whole document.
+//
+// Circular references found by the resolver are cleared, as they may no longer be valid. Refresh must not be run
+// concurrently with other methods of the index, or after the specification has been resolved.
+func (index *SpecIndex) Refresh(changedPointer string) error {
+	if index.root == nil || len(index.root.Content) == 0 {
+		return fmt.Errorf("unable to refresh '%s', the index has no document", changedPointer)
+	}
+	segs, err := splitPointer(changedPointer)
+	if err != nil {
+		return err
+	}
+	if index.nodeParents == nil {
+		index.nodeParents = make(map[*yaml.Node]*yaml.Node)
+		mapRefreshParents(index.root, index.nodeParents)
+	}
+
+	// find the changed node, or the nearest parent that still exists.
+	target, parent, segs := index.locateRefreshNode(segs)
+	if target.Kind == yaml.ScalarNode || target.Kind == yaml.AliasNode {
+		target, segs = parent, segs[:len(segs)-1]
+		parent = index.nodeParents[target]
+	}
+
+	changed := make(map[*yaml.Node]bool)
+	mapChangedNodes(target, changed, index.nodeParents)
+	attached := make(map[*yaml.Node]bool)
+	stale := func(n *yaml.Node) bool {
+		return n == nil || changed[n] || !index.isNodeAttached(n, attached)
+	}
+
+	index.removeStaleReferences(stale)
+
+	// extract everything in the changed node again.
+	poly, polyName := false, ""
+	var seenPath []string
+	for _, s := range segs {
+		if isPoly, name := index.checkPolymorphicNode(s); isPoly {
+			poly, polyName = true, name
+		}
+		if _, nErr := strconv.Atoi(s); nErr != nil {
+			seenPath = append(seenPath, s)
+		}
+	}
+	known := len(index.rawSequencedRefs)
+	found := index.ExtractRefs(target, parent, seenPath, len(segs), poly, polyName)
+	index.refreshReferenceLines()
+
+	index.mapComponentLookup()
+	lookups := index.staleMappedReferences(append(found, index.rawSequencedRefs[known:]...), stale,
+		utils.BuildJSONPointer(segs))
+
+	// drop errors for anything that's been removed, or is about to be looked up again.
+	errorNodes := make(map[*yaml.Node]bool)
+	for _, ref := range lookups {
+		errorNodes[ref.Node] = true
+	}
+	for _, ref := range index.allDynamicRefs {
+		errorNodes[ref.Node] = true
+	}
+	var refErrors []error
+	for _, e := range index.refErrors {
+		if ie, ok := e.(*IndexingError); ok && ie.Node != nil && (errorNodes[ie.Node] || stale(ie.Node)) {
+			continue
+		}
+		refErrors = append(refErrors, e)
+	}
+	index.refErrors = refErrors
+
+	index.ExtractComponentsFromRefs(lookups)
+	index.checkDynamicRefs()
+
+	if isStructuralChange(segs) {
+		index.resetBuiltCollections()
+		index.GetPathCount()
+		index.ExtractExternalDocuments(index.root)
+		index.BuildIndex()
+	}
+
+	index.circularReferences = nil
+	index.spanLock.Lock()
+	index.nodeSpans = nil
+	index.spanLock.Unlock()
+	root := index
+	for root.parentIndex != nil {
+		root = root.parentIndex
+	}
+	root.originLock.Lock()
+	root.nodeOrigins, root.originSources = nil, 0
+	root.nodePaths, root.pathSources = nil, 0
+	root.originLock.Unlock()
+	root.graphLock.Lock()
+	root.refGraph = nil
+	root.graphLock.Unlock()
+	return nil
+}
+
+// splitPointer splits a JSON pointer into its (unescaped) segments.
+func splitPointer(pointer string) ([]string, error) {
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer == "" || pointer == "/" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("'%s' is not a valid JSON pointer, it must start with '/'", pointer)
+	}
+	segs := strings.Split(pointer[1:], "/")
+	for i := range segs {
+		segs[i] = utils.UnescapeJSONPointerSegment(segs[i])
+	}
+	return segs, nil
+}
+
+// locateRefreshNode walks a JSON pointer as far as it exists, returning the node it ends at, its parent, and the
+// segments walked. The parents of every node on the way are updated, in case any of them were replaced.
+func (index *SpecIndex) locateRefreshNode(segs []string) (*yaml.Node, *yaml.Node, []string) {
+	parent, node := index.root, index.root.Content[0]
+	index.nodeParents[node] = parent
+	for i, s := range segs {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for j := 0; j+1 < len(node.Content); j += 2 {
+				if node.Content[j].Value == s {
+					next = node.Content[j+1]
+					index.nodeParents[node.Content[j]] = node
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if n, err := strconv.Atoi(s); err == nil && n >= 0 && n < len(node.Content) {
+				next = node.Content[n]
+			}
+		}
+		if next == nil {
+			return node, parent, segs[:i]
+		}
+		index.nodeParents[next] = node
+		parent, node = node, next
+	}
+	return node, parent, segs
+}
+
+// mapRefreshParents records the parent of every node (including keys) below a node.
+func mapRefreshParents(node *yaml.Node, parents map[*yaml.Node]*yaml.Node) {
+	for _, c := range node.Content {
+		if _, ok := parents[c]; ok {
+			continue // shared by the resolver.
+		}
+		parents[c] = node
+		mapRefreshParents(c, parents)
+	}
+}
+
+// mapChangedNodes records every node below (and including) a changed node, and updates their parents.
+func mapChangedNodes(node *yaml.Node, changed map[*yaml.Node]bool, parents map[*yaml.Node]*yaml.Node) {
+	changed[node] = true
+	for _, c := range node.Content {
+		if changed[c] {
+			continue
+		}
+		parents[c] = node
+		mapChangedNodes(c, changed, parents)
+	}
+}
+
+// isNodeAttached returns true if a node can still be reached from the root of the document.
+func (index *SpecIndex) isNodeAttached(node *yaml.Node, attached map[*yaml.Node]bool) bool {
+	if node == index.root {
+		return true
+	}
+	if a, ok := attached[node]; ok {
+		return a
+	}
+	a := false
+	if p, ok := index.nodeParents[node]; ok {
+		for _, c := range p.Content {
+			if c == node {
+				a = index.isNodeAttached(p, attached)
+				break
+			}
+		}
+	}
+	attached[node] = a
+	return a
+}
+
+func filterStale[T any](items []T, node func(T) *yaml.Node, stale func(*yaml.Node) bool) []T {
+	var kept []T
+	for _, item := range items {
+		if !stale(node(item)) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// removeStaleReferences removes everything extracted from nodes that have changed, or have been removed.
+func (index *SpecIndex) removeStaleReferences(stale func(*yaml.Node) bool) {
+	refNode := func(r *Reference) *yaml.Node { return r.Node }
+	index.rawSequencedRefs = filterStale(index.rawSequencedRefs, refNode, stale)
+	index.polymorphicAllOfRefs = filterStale(index.polymorphicAllOfRefs, refNode, stale)
+	index.polymorphicOneOfRefs = filterStale(index.polymorphicOneOfRefs, refNode, stale)
+	index.polymorphicAnyOfRefs = filterStale(index.polymorphicAnyOfRefs, refNode, stale)
+	index.allInlineSchemaDefinitions = filterStale(index.allInlineSchemaDefinitions, refNode, stale)
+	index.allInlineSchemaObjectDefinitions = filterStale(index.allInlineSchemaObjectDefinitions, refNode, stale)
+	index.allDynamicRefs = filterStale(index.allDynamicRefs, refNode, stale)
+
+	descNode := func(d *DescriptionReference) *yaml.Node { return d.Node }
+	index.allDescriptions = filterStale(index.allDescriptions, descNode, stale)
+	index.allSummaries = filterStale(index.allSummaries, descNode, stale)
+	index.descriptionCount, index.summaryCount = len(index.allDescriptions), len(index.allSummaries)
+	index.allEnums = filterStale(index.allEnums, func(e *EnumReference) *yaml.Node { return e.Node }, stale)
+	index.enumCount = len(index.allEnums)
+	index.allObjectsWithProperties = filterStale(index.allObjectsWithProperties,
+		func(o *ObjectReference) *yaml.Node { return o.Node }, stale)
+
+	for _, anchors := range []map[string]*Reference{index.allAnchors, index.allDynamicAnchors,
+		index.allSchemaResources} {
+		for k, a := range anchors {
+			if stale(a.Node) {
+				delete(anchors, k)
+			}
+		}
+	}
+	for n := range index.nodeResources {
+		if stale(n) {
+			delete(index.nodeResources, n)
+		}
+	}
+	for key, refMap := range index.securityRequirementRefs {
+		for scope, refs := range refMap {
+			if refs = filterStale(refs, refNode, stale); len(refs) > 0 {
+				refMap[scope] = refs
+			} else {
+				delete(refMap, scope)
+			}
+		}
+		if len(refMap) == 0 {
+			delete(index.securityRequirementRefs, key)
+		}
+	}
+
+	// rebuild the unique references, from the references that are left.
+	poly := make(map[*Reference]bool)
+	index.polymorphicRefs = make(map[string]*Reference)
+	for _, refs := range [][]*Reference{index.polymorphicAllOfRefs, index.polymorphicOneOfRefs,
+		index.polymorphicAnyOfRefs} {
+		for _, r := range refs {
+			poly[r] = true
+			index.polymorphicRefs[r.Definition] = r
+		}
+	}
+	index.allRefs = make(map[string]*Reference)
+	for _, r := range index.rawSequencedRefs {
+		if !poly[r] && r.Definition != "" && index.allRefs[r.Definition] == nil {
+			index.allRefs[r.Definition] = r
+		}
+	}
+	index.refCount = len(index.allRefs)
+}
+
+// refreshReferenceLines rebuilds the line numbers and siblings of references, from the references that are left.
+func (index *SpecIndex) refreshReferenceLines() {
+	index.linesWithRefs = make(map[int]bool)
+	index.refsByLine = make(map[string]map[int]bool)
+	index.refsWithSiblings = make(map[string]Reference)
+	for _, ref := range index.rawSequencedRefs {
+		keyNode, _ := utils.FindKeyNodeTop("$ref", ref.Node.Content)
+		if keyNode == nil {
+			continue
+		}
+		index.linesWithRefs[keyNode.Line] = true
+		name := ref.Definition[strings.LastIndex(ref.Definition, "/")+1:]
+		if index.refsByLine[name] == nil {
+			index.refsByLine[name] = make(map[int]bool)
+		}
+		index.refsByLine[name][keyNode.Line] = true
+		if len(ref.Node.Content) > 2 {
+			copiedNode := *ref.Node
+			index.refsWithSiblings[ref.Definition] = Reference{
+				Definition: ref.Definition,
+				Name:       ref.Name,
+				Node:       &copiedNode,
+				Path:       ref.Path,
+			}
+		}
+	}
+}
+
+// staleMappedReferences removes mapped references that are no longer referenced, or that need to be looked up
+// again, because they point into a changed node (or a component that's been renamed). It returns the references to
+// look up again, which includes the new references extracted.
+func (index *SpecIndex) staleMappedReferences(extracted []*Reference, stale func(*yaml.Node) bool,
+	changedPointer string,
+) []*Reference {
+	first := make(map[string]*Reference)
+	for _, r := range index.rawSequencedRefs {
+		if r.Definition != "" && first[r.Definition] == nil {
+			first[r.Definition] = r
+		}
+	}
+
+	relookup := make(map[string]bool)
+	for def, mapped := range index.allMappedRefs {
+		switch {
+		case first[def] == nil:
+			delete(index.allMappedRefs, def)
+		case !strings.HasPrefix(def, "#"):
+			// external documents have not changed.
+		case stale(mapped.Node) || pointsInto(def, changedPointer):
+			relookup[def] = true
+			delete(index.allMappedRefs, def)
+		default:
+			if key, ok := componentLookupKey(def); ok && index.componentLookup[key] != mapped.Node {
+				relookup[def] = true // renamed, or replaced.
+				delete(index.allMappedRefs, def)
+			}
+		}
+	}
+	var sequenced []*ReferenceMapped
+	for _, m := range index.allMappedRefsSequenced {
+		if index.allMappedRefs[m.Definition] != nil {
+			sequenced = append(sequenced, m)
+		}
+	}
+	index.allMappedRefsSequenced = sequenced
+
+	var lookups []*Reference
+	seen := make(map[string]bool)
+	add := func(r *Reference) {
+		if r != nil && r.Definition != "" && !seen[r.Definition] && index.allMappedRefs[r.Definition] == nil {
+			seen[r.Definition] = true
+			lookups = append(lookups, r)
+		}
+	}
+	for def := range relookup {
+		add(first[def])
+	}
+	for _, r := range extracted {
+		add(r)
+	}
+	return lookups
+}
+
+// pointsInto returns true if a local reference points at, or inside, the node at a JSON pointer.
+func pointsInto(definition, pointer string) bool {
+	if !strings.HasPrefix(definition, "#/") {
+		return false
+	}
+	def := definition[1:]
+	return pointer == "" || def == pointer || strings.HasPrefix(def, pointer+"/")
+}
+
+// isStructuralChange returns true if a change may have added, removed or renamed components, paths, operations or
+// tags. Changes inside a schema do not, the collections only hold the schema node.
+func isStructuralChange(segs []string) bool {
+	if len(segs) == 0 {
+		return true
+	}
+	switch segs[0] {
+	case "components":
+		return len(segs) < 4 || segs[1] != "schemas"
+	case "definitions":
+		return len(segs) < 3
+	case "paths", "webhooks", "parameters", "responses", "securityDefinitions", "tags", "servers", "security",
+		"externalDocs":
+		return true
+	}
+	return false
+}
+
+// resetBuiltCollections empties everything created by BuildIndex, so it can be run again.
+func (index *SpecIndex) resetBuiltCollections() {
+	index.pathCount, index.pathsNode = 0, nil
+	index.externalDocumentsRef, index.externalDocumentsCount = nil, 0
+	index.globalTagsCount, index.tagsNode, index.globalTagRefs = 0, nil, make(map[string]*Reference)
+	index.operationTagsCount, index.totalTagsCount = 0, 0
+	index.globalCallbacksCount, index.callbacksRefs = 0, make(map[string]map[string][]*Reference)
+	index.globalLinksCount, index.linksRefs = 0, make(map[string]map[string][]*Reference)
+	index.schemaCount = 0
+	index.serversRefs, index.rootServersNode = nil, nil
+	index.rootSecurity, index.rootSecurityNode = nil, nil
+	index.schemasNode, index.parametersNode, index.securitySchemesNode = nil, nil, nil
+	index.requestBodiesNode, index.responsesNode, index.headersNode = nil, nil, nil
+	index.examplesNode, index.linksNode, index.callbacksNode = nil, nil, nil
+	index.pathItemsNode, index.webhooksNode = nil, nil
+	index.allComponentSchemaDefinitions = make(map[string]*Reference)
+	index.allParameters = make(map[string]*Reference)
+	index.allSecuritySchemes = make(map[string]*Reference)
+	index.allRequestBodies = make(map[string]*Reference)
+	index.allResponses = make(map[string]*Reference)
+	index.allHeaders = make(map[string]*Reference)
+	index.allExamples = make(map[string]*Reference)
+	index.allLinks = make(map[string]*Reference)
+	index.allCallbacks = make(map[string]*Reference)
+	index.allComponentPathItems = make(map[string]*Reference)
+	index.allWebhooks = make(map[string]*Reference)
+	index.allExternalDocuments = make(map[string]*Reference)
+	index.componentParamCount, index.operationParamCount, index.operationCount = 0, 0, 0
+	index.componentsInlineParamDuplicateCount, index.componentsInlineParamUniqueCount = 0, 0
+	index.paramCompRefs = make(map[string]*Reference)
+	index.paramAllRefs = make(map[string]*Reference)
+	index.paramInlineDuplicateNames = make(map[string][]*Reference)
+	index.paramOpRefs = make(map[string]map[string]map[string][]*Reference)
+	index.pathRefs = make(map[string]map[string]*Reference)
+	index.operationTagsRefs = make(map[string]map[string][]*Reference)
+	index.operationDescriptionRefs = make(map[string]map[string]*Reference)
+	index.operationSummaryRefs = make(map[string]map[string]*Reference)
+	index.opServersRefs = make(map[string]map[string][]*Reference)
+	index.operationParamErrors = nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var refreshSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      description: an owner
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Pet'`
+
+func refreshIndex(t *testing.T) (*SpecIndex, *yaml.Node) {
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(refreshSpec), &rootNode))
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	return idx, &rootNode
+}
+
+func schemaNode(root *yaml.Node, name string) *yaml.Node {
+	_, components := utils.FindKeyNodeTop("components", root.Content[0].Content)
+	_, schemas := utils.FindKeyNodeTop("schemas", components.Content)
+	_, schema := utils.FindKeyNodeTop(name, schemas.Content)
+	return schema
+}
+
+func parseNode(t *testing.T, yml string) *yaml.Node {
+	var n yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(yml), &n))
+	return n.Content[0]
+}
+
+func TestSpecIndex_Refresh_AddReference(t *testing.T) {
+	idx, root := refreshIndex(t)
+	assert.Len(t, idx.GetMappedReferences(), 2)
+
+	owner := schemaNode(root, "Owner")
+	owner.Content = append(owner.Content, parseNode(t, `properties:
+  pet:
+    $ref: '#/components/schemas/Pet'
+  best:
+    $ref: '#/components/schemas/Cat'`).Content...)
+	assert.NoError(t, idx.Refresh("/components/schemas/Owner"))
+
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Len(t, idx.GetMappedReferences(), 3)
+	assert.Equal(t, schemaNode(root, "Cat"), idx.GetMappedReferences()["#/components/schemas/Cat"].Node)
+	assert.Len(t, idx.GetAllSequencedReferences(), 5)
+	assert.Len(t, idx.GetAllReferences(), 3)
+	assert.Len(t, idx.GetMappedReferencesSequenced(), 3)
+}
+
+func TestSpecIndex_Refresh_RemoveReference(t *testing.T) {
+	idx, root := refreshIndex(t)
+
+	// remove the owner property, nothing references the owner anymore.
+	pet := schemaNode(root, "Pet")
+	pet.Content = pet.Content[:2]
+	assert.NoError(t, idx.Refresh("/components/schemas/Pet/properties/owner"))
+
+	assert.Len(t, idx.GetMappedReferences(), 1)
+	assert.Nil(t, idx.GetMappedReferences()["#/components/schemas/Owner"])
+	assert.Len(t, idx.GetAllSequencedReferences(), 2)
+	assert.Len(t, idx.GetRefsByLine()["Owner"], 0)
+	assert.Len(t, idx.GetAllReferences(), 1)
+}
+
+func TestSpecIndex_Refresh_RenameComponent(t *testing.T) {
+	idx, root := refreshIndex(t)
+
+	_, components := utils.FindKeyNodeTop("components", root.Content[0].Content)
+	_, schemas := utils.FindKeyNodeTop("schemas", components.Content)
+	key, _ := utils.FindKeyNodeTop("Owner", schemas.Content)
+	key.Value = "Person"
+	assert.NoError(t, idx.Refresh("/components/schemas"))
+
+	errs := idx.GetReferenceIndexErrors()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "#/components/schemas/Owner")
+	assert.Nil(t, idx.GetMappedReferences()["#/components/schemas/Owner"])
+	assert.NotNil(t, idx.GetAllComponentSchemas()["#/components/schemas/Person"])
+	assert.Nil(t, idx.GetAllComponentSchemas()["#/components/schemas/Owner"])
+
+	// and back again.
+	key.Value = "Owner"
+	assert.NoError(t, idx.Refresh("/components/schemas"))
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Equal(t, schemaNode(root, "Owner"), idx.GetMappedReferences()["#/components/schemas/Owner"].Node)
+}
+
+func TestSpecIndex_Refresh_ReplaceComponent(t *testing.T) {
+	idx, root := refreshIndex(t)
+
+	_, components := utils.FindKeyNodeTop("components", root.Content[0].Content)
+	_, schemas := utils.FindKeyNodeTop("schemas", components.Content)
+	replaced := parseNode(t, `type: string
+description: a name`)
+	for i := 0; i < len(schemas.Content); i += 2 {
+		if schemas.Content[i].Value == "Owner" {
+			schemas.Content[i+1] = replaced
+		}
+	}
+	assert.NoError(t, idx.Refresh("/components/schemas/Owner"))
+
+	assert.Equal(t, replaced, idx.GetMappedReferences()["#/components/schemas/Owner"].Node)
+	assert.Len(t, idx.GetAllDescriptions(), 2)
+	assert.Equal(t, "a name", idx.GetAllDescriptions()[1].Content)
+}
+
+func TestSpecIndex_Refresh_RemovedPath(t *testing.T) {
+	idx, root := refreshIndex(t)
+	assert.Equal(t, 1, idx.GetPathCount())
+	assert.Equal(t, 1, idx.GetOperationCount())
+
+	root.Content[0].Content[3] = parseNode(t, `/cats:
+  get:
+    description: cats
+  post:
+    description: a cat`)
+
+	// the original path is gone, the nearest parent is refreshed.
+	assert.NoError(t, idx.Refresh("/paths/~1pets/get"))
+	assert.Equal(t, 1, idx.GetPathCount())
+	assert.Equal(t, 2, idx.GetOperationCount())
+	assert.Len(t, idx.GetAllReferences(), 1) // Pet is only referenced by Cat (polymorphic).
+	assert.Len(t, idx.GetAllSequencedReferences(), 2)
+	assert.NotNil(t, idx.GetAllComponentSchemas()["#/components/schemas/Pet"])
+	assert.NotNil(t, idx.GetAllPaths()["/cats"])
+	assert.Nil(t, idx.GetAllPaths()["/pets"])
+}
+
+func TestSpecIndex_Refresh_Document(t *testing.T) {
+	idx, _ := refreshIndex(t)
+	mapped := len(idx.GetMappedReferences())
+	assert.NoError(t, idx.Refresh(""))
+	assert.Len(t, idx.GetMappedReferences(), mapped)
+	assert.Len(t, idx.GetAllSequencedReferences(), 3)
+	assert.Equal(t, 1, idx.GetOperationCount())
+	assert.Len(t, idx.GetPolyAllOfReferences(), 1)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+}
+
+func TestSpecIndex_Refresh_Errors(t *testing.T) {
+	idx := NewSpecIndexWithConfig(nil, CreateOpenAPIIndexConfig())
+	assert.Error(t, idx.Refresh("/paths"))
+
+	idx, _ = refreshIndex(t)
+	assert.Error(t, idx.Refresh("paths"))
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CachedRemoteDocument is a remote document held by a RemoteCache, along with the validators returned by the server
+// that served it. Hash is the hex encoded SHA-256 hash of the body.
+type CachedRemoteDocument struct {
+	URL          string `json:"url"`
+	Hash         string `json:"hash"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Body         []byte `json:"-"`
+}
+
+// RemoteCache stores remote documents between builds, so the same documents are not downloaded over and over (for
+// example in CI, or when watching a specification for changes). Documents are keyed by URL.
+//
+// Cached documents with an ETag or Last-Modified validator are revalidated with the server before use, and only
+// downloaded again if they have changed. If a RemoteURLHandler is configured, requests can't be made conditional, so
+// cached documents are used as is.
+type RemoteCache interface {
+	// Get returns the document cached for a URL, or nil if there isn't one.
+	Get(url string) *CachedRemoteDocument
+
+	// Put caches a document, replacing any document cached for the same URL.
+	Put(doc *CachedRemoteDocument) error
+}
+
+// memoryRemoteCache is a RemoteCache that holds a limited number of documents in memory, the least recently used
+// document is removed when full.
+type memoryRemoteCache struct {
+	lock       sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+// NewMemoryRemoteCache creates a RemoteCache that holds up to maxEntries documents in memory, least recently used
+// documents are removed first. If maxEntries is zero or less, the cache is unbounded.
+func NewMemoryRemoteCache(maxEntries int) RemoteCache {
+	return &memoryRemoteCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (m *memoryRemoteCache) Get(url string) *CachedRemoteDocument {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if e, ok := m.entries[url]; ok {
+		m.order.MoveToFront(e)
+		return e.Value.(*CachedRemoteDocument)
+	}
+	return nil
+}
+
+func (m *memoryRemoteCache) Put(doc *CachedRemoteDocument) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if e, ok := m.entries[doc.URL]; ok {
+		e.Value = doc
+		m.order.MoveToFront(e)
+		return nil
+	}
+	m.entries[doc.URL] = m.order.PushFront(doc)
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*CachedRemoteDocument).URL)
+	}
+	return nil
+}
+
+// directoryRemoteCache is a RemoteCache that holds documents in a directory on disk. Bodies are stored by their
+// hash, and are checked against it when read.
+type directoryRemoteCache struct {
+	dir string
+}
+
+// NewDirectoryRemoteCache creates a RemoteCache that holds documents in a directory on disk, so they are kept between
+// runs. The directory is created if it doesn't exist.
+func NewDirectoryRemoteCache(dir string) RemoteCache {
+	return &directoryRemoteCache{dir: dir}
+}
+
+func (d *directoryRemoteCache) metaPath(url string) string {
+	h := sha256.Sum256([]byte(url))
+	return filepath.Join(d.dir, hex.EncodeToString(h[:])+".json")
+}
+
+func (d *directoryRemoteCache) Get(url string) *CachedRemoteDocument {
+	meta, err := os.ReadFile(d.metaPath(url))
+	if err != nil {
+		return nil
+	}
+	var doc CachedRemoteDocument
+	if json.Unmarshal(meta, &doc) != nil || doc.URL != url || doc.Hash == "" {
+		return nil
+	}
+	body, err := os.ReadFile(filepath.Join(d.dir, doc.Hash))
+	if err != nil || hashRemoteDocument(body) != doc.Hash {
+		return nil
+	}
+	doc.Body = body
+	return &doc
+}
+
+func (d *directoryRemoteCache) Put(doc *CachedRemoteDocument) error {
+	if doc.Hash == "" {
+		return errors.New("unable to cache remote document, it has no hash")
+	}
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(d.dir, doc.Hash), doc.Body, 0o644); err != nil {
+		return err
+	}
+	meta, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(d.metaPath(doc.URL), meta, 0o644)
+}
+
+func hashRemoteDocument(body []byte) string {
+	h := sha256.Sum256(body)
+	return hex.EncodeToString(h[:])
+}
+
+// cachedRemoteURLHandler wraps a handler for remote documents with a cache. If do is set, it's used to make
+// conditional requests to revalidate cached documents, otherwise cached documents are returned without a request.
+func cachedRemoteURLHandler(cache RemoteCache, handler RemoteURLHandler,
+	do func(req *http.Request) (*http.Response, error),
+) RemoteURLHandler {
+	return func(u string) (*http.Response, error) {
+		cached := cache.Get(u)
+		var resp *http.Response
+		var err error
+		if do == nil {
+			if cached != nil {
+				return cachedResponse(cached), nil
+			}
+			resp, err = handler(u)
+		} else {
+			req, rErr := http.NewRequest(http.MethodGet, u, nil)
+			if rErr != nil {
+				return nil, rErr
+			}
+			if cached != nil {
+				if cached.ETag != "" {
+					req.Header.Set("If-None-Match", cached.ETag)
+				}
+				if cached.LastModified != "" {
+					req.Header.Set("If-Modified-Since", cached.LastModified)
+				}
+			}
+			resp, err = do(req)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			_ = resp.Body.Close()
+			return cachedResponse(cached), nil
+		}
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return resp, nil
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		_ = cache.Put(&CachedRemoteDocument{
+			URL:          u,
+			Hash:         hashRemoteDocument(body),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+}
+
+func cachedResponse(doc *CachedRemoteDocument) *http.Response {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(bytes.NewReader(doc.Body)),
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRemoteCache(t *testing.T) {
+	cache := NewMemoryRemoteCache(2)
+	assert.Nil(t, cache.Get("https://pb33f.io/a.yaml"))
+
+	_ = cache.Put(&CachedRemoteDocument{URL: "https://pb33f.io/a.yaml", Body: []byte("a")})
+	_ = cache.Put(&CachedRemoteDocument{URL: "https://pb33f.io/b.yaml", Body: []byte("b")})
+	assert.Equal(t, "a", string(cache.Get("https://pb33f.io/a.yaml").Body))
+
+	// b is the least recently used, so it goes.
+	_ = cache.Put(&CachedRemoteDocument{URL: "https://pb33f.io/c.yaml", Body: []byte("c")})
+	assert.Nil(t, cache.Get("https://pb33f.io/b.yaml"))
+	assert.NotNil(t, cache.Get("https://pb33f.io/a.yaml"))
+	assert.NotNil(t, cache.Get("https://pb33f.io/c.yaml"))
+
+	_ = cache.Put(&CachedRemoteDocument{URL: "https://pb33f.io/c.yaml", Body: []byte("new c")})
+	assert.Equal(t, "new c", string(cache.Get("https://pb33f.io/c.yaml").Body))
+}
+
+func TestDirectoryRemoteCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache := NewDirectoryRemoteCache(dir)
+	assert.Nil(t, cache.Get("https://pb33f.io/a.yaml"))
+
+	body := []byte("openapi: 3.1.0")
+	assert.Error(t, cache.Put(&CachedRemoteDocument{URL: "https://pb33f.io/a.yaml", Body: body}))
+	assert.NoError(t, cache.Put(&CachedRemoteDocument{
+		URL:  "https://pb33f.io/a.yaml",
+		Hash: hashRemoteDocument(body),
+		ETag: `"burgers"`,
+		Body: body,
+	}))
+
+	// a new cache on the same directory, finds the document.
+	doc := NewDirectoryRemoteCache(dir).Get("https://pb33f.io/a.yaml")
+	assert.NotNil(t, doc)
+	assert.Equal(t, body, doc.Body)
+	assert.Equal(t, `"burgers"`, doc.ETag)
+
+	// a corrupted body is a miss.
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, doc.Hash), []byte("pizza"), 0o644))
+	assert.Nil(t, cache.Get("https://pb33f.io/a.yaml"))
+}
+
+func TestSpecIndex_RemoteCache_Revalidate(t *testing.T) {
+	var served, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		served.Add(1)
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryRemoteCache(10)
+	for i := 0; i < 3; i++ {
+		c := CreateOpenAPIIndexConfig()
+		c.RemoteCache = cache
+		idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+		assert.Empty(t, idx.GetReferenceIndexErrors())
+		assert.NotNil(t, idx.GetMappedReferences()[server.URL+"/spec.yaml#/components/schemas/Pet"])
+	}
+	assert.Equal(t, int32(1), served.Load())
+	assert.Equal(t, int32(2), notModified.Load())
+}
+
+func TestSpecIndex_RemoteCache_NoValidators(t *testing.T) {
+	var served atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		served.Add(1)
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryRemoteCache(10)
+	for i := 0; i < 2; i++ {
+		c := CreateOpenAPIIndexConfig()
+		c.RemoteCache = cache
+		idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+		assert.Empty(t, idx.GetReferenceIndexErrors())
+	}
+
+	// without a validator, the document has to be downloaded again.
+	assert.Equal(t, int32(2), served.Load())
+}
+
+func TestSpecIndex_RemoteCache_RemoteURLHandler(t *testing.T) {
+	var served atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		served.Add(1)
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	cache := NewDirectoryRemoteCache(t.TempDir())
+	for i := 0; i < 2; i++ {
+		c := CreateOpenAPIIndexConfig()
+		c.RemoteCache = cache
+		c.RemoteURLHandler = http.Get
+		idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+		assert.Empty(t, idx.GetReferenceIndexErrors())
+		assert.NotNil(t, idx.GetMappedReferences()[server.URL+"/spec.yaml#/components/schemas/Pet"])
+	}
+	assert.Equal(t, int32(1), served.Load())
+}
+
+func TestSpecIndex_RemoteCache_ErrorNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cache := NewMemoryRemoteCache(10)
+	c := CreateOpenAPIIndexConfig()
+	c.RemoteCache = cache
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+	assert.Nil(t, cache.Get(server.URL+"/spec.yaml"))
+}
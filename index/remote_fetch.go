@@ -0,0 +1,114 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// getRemoteURLHandler returns the function used to fetch remote documents. A configured RemoteURLHandler always
+// wins, otherwise a handler is built from the remote client, headers, timeout and redirect policy of the config.
+// If a RemoteCache is configured, the handler is wrapped with it.
+func (index *SpecIndex) getRemoteURLHandler() RemoteURLHandler {
+	handler, do := index.getRemoteRequester()
+	if index.config != nil && index.config.RemoteCache != nil {
+		return cachedRemoteURLHandler(index.config.RemoteCache, handler, do)
+	}
+	return handler
+}
+
+// getRemoteRequester returns the handler used to fetch remote documents, and the function used to make requests
+// for remote documents. The request function is nil if a RemoteURLHandler is configured.
+func (index *SpecIndex) getRemoteRequester() (RemoteURLHandler, func(req *http.Request) (*http.Response, error)) {
+	c := index.config
+	if c != nil && c.RemoteURLHandler != nil {
+		return c.RemoteURLHandler, nil
+	}
+	if c == nil || (c.RemoteHTTPClient == nil && len(c.RemoteHeaders) == 0 && c.RemoteTimeout <= 0 &&
+		c.RemoteCheckRedirect == nil && c.Sandbox == nil) {
+		return httpClient.Get, httpClient.Do
+	}
+	client := newRemoteClient(c)
+	headers := c.RemoteHeaders
+	do := func(req *http.Request) (*http.Response, error) {
+		addRemoteHeaders(req, headers)
+		return client.Do(req)
+	}
+	return func(u string) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		return do(req)
+	}, do
+}
+
+// newRemoteClient creates a copy of the configured (or default) client, with the timeout and redirect policy of
+// the config applied. Headers configured for a host are removed when a request is redirected away from it, and
+// redirects to hosts the sandbox doesn't allow are refused.
+func newRemoteClient(c *SpecIndexConfig) *http.Client {
+	client := *httpClient
+	if c.RemoteHTTPClient != nil {
+		client = *c.RemoteHTTPClient
+	}
+	if c.RemoteTimeout > 0 {
+		client.Timeout = c.RemoteTimeout
+	}
+	checkRedirect := client.CheckRedirect
+	if c.RemoteCheckRedirect != nil {
+		checkRedirect = c.RemoteCheckRedirect
+	}
+	headers := c.RemoteHeaders
+	sandbox := c.Sandbox
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := sandbox.checkHost(req.URL); err != nil {
+			return err
+		}
+		if len(headers) > 0 && len(via) > 0 {
+			if prev := via[len(via)-1]; prev.URL.Host != req.URL.Host {
+				for key := range remoteHeadersForHost(headers, prev.URL) {
+					req.Header.Del(key)
+				}
+			}
+			addRemoteHeaders(req, headers)
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		// same as the default policy of http.Client
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	return &client
+}
+
+// addRemoteHeaders adds the headers configured for the host of a request, to the request.
+func addRemoteHeaders(req *http.Request, headers map[string]http.Header) {
+	for key, values := range remoteHeadersForHost(headers, req.URL) {
+		req.Header.Del(key)
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+}
+
+// remoteHeadersForHost returns the headers configured for a URL. Headers can be configured for the host and port
+// ('localhost:8080') or just the host ('localhost'), if both match, the host and port wins.
+func remoteHeadersForHost(headers map[string]http.Header, u *url.URL) http.Header {
+	var byHostname http.Header
+	for host, h := range headers {
+		if strings.EqualFold(host, u.Host) {
+			return h
+		}
+		if strings.EqualFold(host, u.Hostname()) {
+			byHostname = h
+		}
+	}
+	return byHostname
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var remoteFetchDoc = `components:
+  schemas:
+    Pet:
+      type: object`
+
+func remoteFetchSpec(u string) *yaml.Node {
+	spec := fmt.Sprintf(`openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: '%s/spec.yaml#/components/schemas/Pet'`, u)
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	return &rootNode
+}
+
+func remoteHost(t *testing.T, s *httptest.Server) string {
+	u, err := url.Parse(s.URL)
+	assert.NoError(t, err)
+	return u.Host
+}
+
+func TestSpecIndex_RemoteHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Registry-Token") != "burgers" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.RemoteHeaders = map[string]http.Header{
+		remoteHost(t, server): {"X-Registry-Token": []string{"burgers"}},
+	}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	ref := idx.GetMappedReferences()[server.URL+"/spec.yaml#/components/schemas/Pet"]
+	assert.NotNil(t, ref)
+	assert.Equal(t, "object", ref.Node.Content[1].Value)
+}
+
+func TestSpecIndex_RemoteHeaders_NoHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Registry-Token") != "burgers" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.RemoteHeaders = map[string]http.Header{
+		"pb33f.io": {"X-Registry-Token": []string{"burgers"}},
+	}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+}
+
+func TestSpecIndex_RemoteHeaders_Redirect(t *testing.T) {
+	var leaked atomic.Bool
+	other := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("X-Registry-Token") != "" {
+			leaked.Store(true)
+		}
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Redirect(rw, req, other.URL+req.URL.Path, http.StatusFound)
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.RemoteHeaders = map[string]http.Header{
+		remoteHost(t, server): {"X-Registry-Token": []string{"burgers"}},
+	}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.False(t, leaked.Load())
+}
+
+func TestSpecIndex_RemoteCheckRedirect(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Redirect(rw, req, other.URL+req.URL.Path, http.StatusFound)
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.RemoteCheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return errors.New("no redirects")
+	}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+}
+
+func TestSpecIndex_RemoteTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.RemoteTimeout = 10 * time.Millisecond
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+}
+
+type countingTransport struct {
+	count atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.count.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSpecIndex_RemoteHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	c := CreateOpenAPIIndexConfig()
+	c.RemoteHTTPClient = &http.Client{Transport: transport}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Equal(t, int32(1), transport.count.Load())
+}
+
+func TestSpecIndex_RemoteURLHandler_Wins(t *testing.T) {
+	transport := &countingTransport{}
+	c := &SpecIndexConfig{
+		RemoteURLHandler: http.Get,
+		RemoteHTTPClient: &http.Client{Transport: transport},
+	}
+	idx := &SpecIndex{config: c}
+	assert.NotNil(t, idx.getRemoteURLHandler())
+	_, _ = idx.getRemoteURLHandler()("http://localhost:0")
+	assert.Equal(t, int32(0), transport.count.Load())
+}
+
+func TestRemoteHeadersForHost(t *testing.T) {
+	headers := map[string]http.Header{
+		"localhost":      {"A": []string{"host"}},
+		"LOCALHOST:8080": {"A": []string{"host and port"}},
+	}
+	u, _ := url.Parse("http://localhost:8080/spec.yaml")
+	assert.Equal(t, "host and port", remoteHeadersForHost(headers, u).Get("A"))
+	u, _ = url.Parse("http://localhost:9090/spec.yaml")
+	assert.Equal(t, "host", remoteHeadersForHost(headers, u).Get("A"))
+	u, _ = url.Parse("http://pb33f.io/spec.yaml")
+	assert.Nil(t, remoteHeadersForHost(headers, u))
+}
@@ -0,0 +1,231 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReferenceSandbox restricts what can be read when resolving references. It's designed for servers that parse
+// untrusted specifications, so a specification can't be used to make requests to internal hosts, or read arbitrary
+// files (or very large ones).
+//
+// Hosts are matched by name (with or without a port), a leading '*.' matches any subdomain, for example
+// '*.pb33f.io'. Host lists apply to every remote document, including redirects when libopenapi makes the request
+// (they can't be applied to redirects followed by a RemoteURLHandler).
+type ReferenceSandbox struct {
+	// AllowedHosts are the only hosts remote documents can be fetched from, if set.
+	AllowedHosts []string
+
+	// DeniedHosts are hosts remote documents can never be fetched from, they win over AllowedHosts.
+	DeniedHosts []string
+
+	// AllowedFileRoots are the only directories (and their children) local files can be read from, if set.
+	// Symbolic links are followed before checking.
+	AllowedFileRoots []string
+
+	// ForbidNetwork prevents any remote document being fetched, regardless of AllowRemoteLookup.
+	ForbidNetwork bool
+
+	// MaxFileSize is the maximum size (in bytes) of any external document, zero means no limit.
+	MaxFileSize int64
+
+	// MaxExternalFiles is the maximum number of external documents (remote or local) that can be read when
+	// indexing a specification, zero means no limit.
+	MaxExternalFiles int
+
+	// MaxNodes is the maximum number of YAML nodes, across every document indexed. Aliases are counted as the nodes
+	// they expand to, so 'billion laughs' style documents are caught. Zero means no limit.
+	MaxNodes int
+
+	// MaxReferences is the maximum number of references, across every document indexed. Zero means no limit.
+	MaxReferences int
+
+	// MaxResolutionDepth is the maximum number of references that can be followed from one reference to another,
+	// when resolving. Zero means no limit.
+	MaxResolutionDepth int
+}
+
+// ErrLimitExceeded is wrapped by the errors returned when a limit of the sandbox has been exceeded.
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// checkRemote returns an error if the sandbox does not allow the remote document to be fetched.
+func (s *ReferenceSandbox) checkRemote(uri string) error {
+	if s == nil {
+		return nil
+	}
+	if s.ForbidNetwork {
+		return fmt.Errorf("unable to fetch '%s', network access is forbidden", uri)
+	}
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	return s.checkHost(u)
+}
+
+// checkHost returns an error if the sandbox does not allow documents to be fetched from the host of a URL.
+func (s *ReferenceSandbox) checkHost(u *url.URL) error {
+	if s == nil {
+		return nil
+	}
+	for _, h := range s.DeniedHosts {
+		if matchSandboxHost(h, u) {
+			return fmt.Errorf("unable to fetch '%s', host '%s' is denied", u.String(), u.Host)
+		}
+	}
+	if len(s.AllowedHosts) == 0 {
+		return nil
+	}
+	for _, h := range s.AllowedHosts {
+		if matchSandboxHost(h, u) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unable to fetch '%s', host '%s' is not allowed", u.String(), u.Host)
+}
+
+func matchSandboxHost(pattern string, u *url.URL) bool {
+	pattern = strings.ToLower(pattern)
+	host, hostname := strings.ToLower(u.Host), strings.ToLower(u.Hostname())
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:]) || strings.HasSuffix(hostname, pattern[1:])
+	}
+	return pattern == host || pattern == hostname
+}
+
+// checkFile returns an error if the sandbox does not allow a local file to be read.
+func (s *ReferenceSandbox) checkFile(file string) error {
+	if s == nil || len(s.AllowedFileRoots) == 0 {
+		return nil
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	if real, rErr := filepath.EvalSymlinks(abs); rErr == nil {
+		abs = real
+	}
+	for _, root := range s.AllowedFileRoots {
+		r, rErr := filepath.Abs(root)
+		if rErr != nil {
+			continue
+		}
+		if real, eErr := filepath.EvalSymlinks(r); eErr == nil {
+			r = real
+		}
+		if rel, relErr := filepath.Rel(r, abs); relErr == nil && rel != ".." &&
+			!strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unable to read '%s', it's outside of the allowed file roots", file)
+}
+
+// read reads all of r, returning an error if it's larger than the maximum file size.
+func (s *ReferenceSandbox) read(location string, r io.Reader) ([]byte, error) {
+	if s == nil || s.MaxFileSize <= 0 {
+		return io.ReadAll(r)
+	}
+	b, err := io.ReadAll(io.LimitReader(r, s.MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > s.MaxFileSize {
+		return nil, fmt.Errorf("%w: unable to read '%s', it's larger than the maximum file size of %d bytes",
+			ErrLimitExceeded, location, s.MaxFileSize)
+	}
+	return b, nil
+}
+
+// countExternalFile records that an external document is being read, returning an error if the maximum number of
+// external documents has been reached. Documents are counted once, no matter how many times they are read.
+func (index *SpecIndex) countExternalFile(location string) error {
+	if index.config == nil || index.config.Sandbox == nil || index.config.Sandbox.MaxExternalFiles <= 0 ||
+		index.config.fetches == nil {
+		return nil
+	}
+	g := index.config.fetches
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.documents[location] {
+		return nil
+	}
+	if len(g.documents) >= index.config.Sandbox.MaxExternalFiles {
+		return fmt.Errorf("%w: unable to read '%s', the maximum number of external files (%d) has been reached",
+			ErrLimitExceeded, location, index.config.Sandbox.MaxExternalFiles)
+	}
+	g.documents[location] = true
+	return nil
+}
+
+func (index *SpecIndex) getSandbox() *ReferenceSandbox {
+	if index.config == nil {
+		return nil
+	}
+	return index.config.Sandbox
+}
+
+// countNodes adds the nodes of a document to the total indexed, returning an error if there are too many.
+func (index *SpecIndex) countNodes(root *yaml.Node) error {
+	sandbox := index.getSandbox()
+	if sandbox == nil || sandbox.MaxNodes <= 0 || index.config.fetches == nil {
+		return nil
+	}
+	g := index.config.fetches
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	remaining := sandbox.MaxNodes - g.nodes
+	n := countNodesUpTo(root, remaining+1)
+	g.nodes += n
+	if n > remaining {
+		return fmt.Errorf("%w: the maximum number of nodes (%d) has been exceeded", ErrLimitExceeded, sandbox.MaxNodes)
+	}
+	return nil
+}
+
+// countNodesUpTo counts the nodes in a tree (expanding aliases), it stops once max nodes have been counted.
+func countNodesUpTo(root *yaml.Node, max int) int {
+	count := 0
+	stack := []*yaml.Node{root}
+	for len(stack) > 0 && count < max {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == nil {
+			continue
+		}
+		count++
+		if n.Kind == yaml.AliasNode {
+			stack = append(stack, n.Alias)
+			continue
+		}
+		stack = append(stack, n.Content...)
+	}
+	return count
+}
+
+// countReferences adds the references found in a document to the total indexed, returning an error if there are
+// too many.
+func (index *SpecIndex) countReferences(refs int) error {
+	sandbox := index.getSandbox()
+	if sandbox == nil || sandbox.MaxReferences <= 0 || index.config.fetches == nil {
+		return nil
+	}
+	g := index.config.fetches
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.refs += refs
+	if g.refs > sandbox.MaxReferences {
+		return fmt.Errorf("%w: the maximum number of references (%d) has been exceeded", ErrLimitExceeded,
+			sandbox.MaxReferences)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_Sandbox_ForbidNetwork(t *testing.T) {
+	var served atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		served.Store(true)
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{ForbidNetwork: true}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	errs := idx.GetReferenceIndexErrors()
+	assert.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "network access is forbidden")
+	assert.False(t, served.Load())
+}
+
+func TestSpecIndex_Sandbox_Hosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{AllowedHosts: []string{"pb33f.io"}}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	errs := idx.GetReferenceIndexErrors()
+	assert.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "is not allowed")
+
+	c = CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{AllowedHosts: []string{"127.0.0.1"}}
+	idx = NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+
+	c = CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{AllowedHosts: []string{"127.0.0.1"}, DeniedHosts: []string{remoteHost(t, server)}}
+	idx = NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	errs = idx.GetReferenceIndexErrors()
+	assert.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "is denied")
+}
+
+func TestSpecIndex_Sandbox_Redirect(t *testing.T) {
+	var served atomic.Bool
+	internal := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		served.Store(true)
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer internal.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Redirect(rw, req, internal.URL+req.URL.Path, http.StatusFound)
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{DeniedHosts: []string{remoteHost(t, internal)}}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.NotEmpty(t, idx.GetReferenceIndexErrors())
+	assert.False(t, served.Load())
+}
+
+func TestSpecIndex_Sandbox_MaxFileSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	c := CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{MaxFileSize: 10}
+	idx := NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	errs := idx.GetReferenceIndexErrors()
+	assert.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "larger than the maximum file size of 10 bytes")
+
+	c = CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{MaxFileSize: int64(len(remoteFetchDoc))}
+	idx = NewSpecIndexWithConfig(remoteFetchSpec(server.URL), c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+}
+
+func TestSpecIndex_Sandbox_MaxExternalFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(remoteFetchDoc))
+	}))
+	defer server.Close()
+
+	var spec strings.Builder
+	spec.WriteString("openapi: 3.1.0\ncomponents:\n  schemas:\n")
+	for i := 0; i < 4; i++ {
+		spec.WriteString(fmt.Sprintf("    Thing%d:\n      $ref: '%s/spec%d.yaml#/components/schemas/Pet'\n",
+			i, server.URL, i))
+	}
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec.String()), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{MaxExternalFiles: 3}
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Len(t, idx.GetMappedReferences(), 3)
+	var maxed int
+	for _, e := range idx.GetReferenceIndexErrors() {
+		if strings.Contains(e.Error(), "maximum number of external files (3)") {
+			maxed++
+		}
+	}
+	assert.Equal(t, 1, maxed)
+}
+
+func TestSpecIndex_Sandbox_MaxExternalFiles_Chained(t *testing.T) {
+	// a.yaml references b.yaml, which references c.yaml, every file read counts towards the same limit.
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"),
+		[]byte("A:\n  properties:\n    b:\n      $ref: 'b.yaml#/B'"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"),
+		[]byte("B:\n  properties:\n    c:\n      $ref: 'c.yaml#/C'"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "c.yaml"), []byte("C:\n  type: string"), 0o644))
+
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    A:
+      $ref: 'a.yaml#/A'`
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	read := func(max int) (files int, maxed bool) {
+		c := CreateOpenAPIIndexConfig()
+		c.BasePath = dir
+		c.Sandbox = &ReferenceSandbox{MaxExternalFiles: max}
+		idx := NewSpecIndexWithConfig(&rootNode, c)
+		var visit func(i *SpecIndex)
+		visit = func(i *SpecIndex) {
+			for _, e := range i.GetReferenceIndexErrors() {
+				if errors.Is(e, ErrLimitExceeded) {
+					maxed = true
+				}
+			}
+			for _, child := range i.GetChildren() {
+				files++
+				visit(child)
+			}
+		}
+		visit(idx)
+		return files, maxed
+	}
+
+	files, maxed := read(1)
+	assert.Equal(t, 1, files)
+	assert.True(t, maxed)
+
+	files, maxed = read(3)
+	assert.Equal(t, 3, files)
+	assert.False(t, maxed)
+}
+
+func TestSpecIndex_Sandbox_FileRoots(t *testing.T) {
+	dir := t.TempDir()
+	specs := filepath.Join(dir, "specs")
+	assert.NoError(t, os.MkdirAll(specs, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(specs, "pet.yaml"), []byte("Pet:\n  type: object"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "secret.yaml"), []byte("Secret:\n  type: string"), 0o644))
+
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/Pet'
+    Secret:
+      $ref: '../secret.yaml#/Secret'`
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = specs
+	c.Sandbox = &ReferenceSandbox{AllowedFileRoots: []string{specs}}
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.NotNil(t, idx.GetMappedReferences()["pet.yaml#/Pet"])
+	assert.Nil(t, idx.GetMappedReferences()["../secret.yaml#/Secret"])
+	errs := idx.GetReferenceIndexErrors()
+	assert.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "outside of the allowed file roots")
+}
+
+func TestReferenceSandbox_checkFile_Symlink(t *testing.T) {
+	dir := t.TempDir()
+	specs := filepath.Join(dir, "specs")
+	assert.NoError(t, os.MkdirAll(specs, 0o755))
+	secret := filepath.Join(dir, "secret.yaml")
+	assert.NoError(t, os.WriteFile(secret, []byte("secret"), 0o644))
+	if err := os.Symlink(secret, filepath.Join(specs, "link.yaml")); err != nil {
+		t.Skip("symbolic links are not supported")
+	}
+
+	s := &ReferenceSandbox{AllowedFileRoots: []string{specs}}
+	assert.Error(t, s.checkFile(filepath.Join(specs, "link.yaml")))
+	assert.NoError(t, s.checkFile(filepath.Join(specs, "pet.yaml")))
+	assert.NoError(t, (*ReferenceSandbox)(nil).checkFile(secret))
+}
+
+func TestMatchSandboxHost(t *testing.T) {
+	u, _ := url.Parse("https://api.pb33f.io:8443/spec.yaml")
+	assert.True(t, matchSandboxHost("api.pb33f.io", u))
+	assert.True(t, matchSandboxHost("API.pb33f.io:8443", u))
+	assert.True(t, matchSandboxHost("*.pb33f.io", u))
+	assert.False(t, matchSandboxHost("pb33f.io", u))
+	assert.False(t, matchSandboxHost("*.quobix.com", u))
+}
+
+func TestSpecIndex_Sandbox_MaxNodes(t *testing.T) {
+	// every alias expands into the one before it, ten times.
+	spec := `openapi: 3.1.0
+a: &a [x, x, x, x, x, x, x, x, x, x]
+b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a, *a]
+c: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b, *b]
+d: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c, *c]
+e: [*d, *d, *d, *d, *d, *d, *d, *d, *d, *d]`
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{MaxNodes: 10000}
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	errs := idx.GetReferenceIndexErrors()
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], ErrLimitExceeded))
+	assert.Contains(t, errs[0].Error(), "maximum number of nodes (10000)")
+
+	c = CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{MaxNodes: 200000}
+	idx = NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+}
+
+func TestSpecIndex_Sandbox_MaxReferences(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+        friend:
+          $ref: '#/components/schemas/Owner'
+        food:
+          $ref: '#/components/schemas/Food'
+    Owner:
+      type: string
+    Food:
+      type: string`
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{MaxReferences: 2}
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	errs := idx.GetReferenceIndexErrors()
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], ErrLimitExceeded))
+	assert.Contains(t, errs[0].Error(), "maximum number of references (2)")
+	assert.Empty(t, idx.GetMappedReferences())
+
+	c = CreateOpenAPIIndexConfig()
+	c.Sandbox = &ReferenceSandbox{MaxReferences: 3}
+	idx = NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Len(t, idx.GetMappedReferences(), 2)
+}
+
+func TestCountNodesUpTo(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte("a: &a [1, 2]\nb: *a"), &rootNode)
+	assert.Equal(t, 11, countNodesUpTo(&rootNode, 100))
+	assert.Equal(t, 3, countNodesUpTo(&rootNode, 3))
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SchemeHandler fetches the document at a URI, for example 's3://bucket/specs/pet.yaml', and returns its bytes.
+type SchemeHandler = func(uri string) ([]byte, error)
+
+// getSchemeHandler returns the handler configured for the scheme of a reference, or nil if the reference has no
+// scheme, or there is no handler for it. Schemes are matched regardless of case.
+func (index *SpecIndex) getSchemeHandler(ref string) SchemeHandler {
+	if index.config == nil || len(index.config.SchemeHandlers) == 0 {
+		return nil
+	}
+	scheme, _, found := strings.Cut(ref, "://")
+	if !found || scheme == "" || strings.ContainsAny(scheme, "#/") {
+		return nil
+	}
+	for s, handler := range index.config.SchemeHandlers {
+		if strings.EqualFold(s, scheme) {
+			return handler
+		}
+	}
+	return nil
+}
+
+// resolveSchemeReference resolves a relative reference against the base URL of the index, if the scheme of the
+// base URL has a handler. Returns an empty string otherwise.
+func (index *SpecIndex) resolveSchemeReference(ref string) string {
+	if index.config == nil || index.config.BaseURL == nil ||
+		index.getSchemeHandler(index.config.BaseURL.String()) == nil {
+		return ""
+	}
+	rel, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	base := *index.config.BaseURL
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	return base.ResolveReference(rel).String()
+}
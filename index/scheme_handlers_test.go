@@ -0,0 +1,139 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type registry struct {
+	lock    sync.Mutex
+	docs    map[string]string
+	fetched []string
+}
+
+func (r *registry) fetch(uri string) ([]byte, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.fetched = append(r.fetched, uri)
+	if d, ok := r.docs[uri]; ok {
+		return []byte(d), nil
+	}
+	return nil, errors.New("not in registry")
+}
+
+func TestSpecIndex_SchemeHandlers(t *testing.T) {
+	reg := &registry{docs: map[string]string{
+		"registry://pets/pet.yaml": `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: 'owner.yaml#/Owner'`,
+		"registry://pets/owner.yaml": `Owner:
+  type: string`,
+	}}
+
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'registry://pets/pet.yaml#/components/schemas/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.SchemeHandlers = map[string]SchemeHandler{"registry": reg.fetch}
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	ref := idx.GetMappedReferences()["registry://pets/pet.yaml#/components/schemas/Pet"]
+	assert.NotNil(t, ref)
+	assert.True(t, ref.IsRemote)
+	assert.Equal(t, "object", ref.Node.Content[1].Value)
+	assert.Contains(t, reg.fetched, "registry://pets/pet.yaml")
+
+	// relative references in the fetched document are fetched with the same handler.
+	assert.Contains(t, reg.fetched, "registry://pets/owner.yaml")
+}
+
+func TestSpecIndex_SchemeHandlers_Error(t *testing.T) {
+	reg := &registry{}
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 's3://bucket/pet.yaml#/components/schemas/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.SchemeHandlers = map[string]SchemeHandler{"S3": reg.fetch}
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+
+	errs := idx.GetReferenceIndexErrors()
+	assert.NotEmpty(t, errs)
+	assert.Equal(t, "unable to fetch 's3://bucket/pet.yaml': not in registry", errs[0].Error())
+	assert.Equal(t, []string{"s3://bucket/pet.yaml"}, reg.fetched)
+}
+
+func TestSpecIndex_SchemeHandlers_WholeDocument(t *testing.T) {
+	reg := &registry{docs: map[string]string{
+		"vault://specs/pet.yaml": `type: object`,
+	}}
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+      $ref: 'vault://specs/pet.yaml'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.SchemeHandlers = map[string]SchemeHandler{"vault": reg.fetch}
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Len(t, idx.GetMappedReferences(), 1)
+}
+
+func TestSpecIndex_getSchemeHandler(t *testing.T) {
+	handler := func(uri string) ([]byte, error) {
+		return nil, fmt.Errorf("nope")
+	}
+	idx := &SpecIndex{config: &SpecIndexConfig{SchemeHandlers: map[string]SchemeHandler{"git": handler}}}
+	assert.NotNil(t, idx.getSchemeHandler("git://github.com/pb33f/libopenapi.yaml#/Pet"))
+	assert.NotNil(t, idx.getSchemeHandler("GIT://github.com/pb33f/libopenapi.yaml"))
+	assert.Nil(t, idx.getSchemeHandler("https://pb33f.io/pet.yaml"))
+	assert.Nil(t, idx.getSchemeHandler("pet.yaml#/Pet"))
+	assert.Nil(t, idx.getSchemeHandler("#/components/schemas/git://"))
+	assert.Nil(t, (&SpecIndex{}).getSchemeHandler("git://github.com/pb33f/libopenapi.yaml"))
+}
+
+func TestSpecIndex_resolveSchemeReference(t *testing.T) {
+	handler := func(uri string) ([]byte, error) {
+		return nil, nil
+	}
+	base, _ := url.Parse("git://github.com/pb33f/specs")
+	idx := &SpecIndex{config: &SpecIndexConfig{
+		BaseURL:        base,
+		SchemeHandlers: map[string]SchemeHandler{"git": handler},
+	}}
+	assert.Equal(t, "git://github.com/pb33f/specs/pet.yaml#/Pet", idx.resolveSchemeReference("pet.yaml#/Pet"))
+	assert.Equal(t, "git://github.com/pb33f/owner.yaml", idx.resolveSchemeReference("../owner.yaml"))
+
+	base, _ = url.Parse("https://pb33f.io/specs")
+	idx.config.BaseURL = base
+	assert.Empty(t, idx.resolveSchemeReference("pet.yaml#/Pet"))
+}
@@ -0,0 +1,267 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SecurityFinding is a single concern raised by a security audit, and where it was found.
+type SecurityFinding struct {
+	Scheme  string     `json:"scheme,omitempty" yaml:"scheme,omitempty"`   // the name of the security scheme, if any.
+	Scope   string     `json:"scope,omitempty" yaml:"scope,omitempty"`     // the scope in question, if any.
+	Path    string     `json:"path,omitempty" yaml:"path,omitempty"`       // the path of the operation, if any.
+	Method  string     `json:"method,omitempty" yaml:"method,omitempty"`   // the method of the operation, if any.
+	Webhook bool       `json:"webhook,omitempty" yaml:"webhook,omitempty"` // the operation is a webhook.
+	Message string     `json:"message" yaml:"message"`
+	Line    int        `json:"line,omitempty" yaml:"line,omitempty"`
+	Column  int        `json:"column,omitempty" yaml:"column,omitempty"`
+	Node    *yaml.Node `json:"-" yaml:"-"`
+}
+
+// SecurityAudit is a report of the security configuration of a document, intended for security reviews. Every
+// finding is in the order it was found in the document.
+type SecurityAudit struct {
+	// UnsecuredOperations are operations that can be called without any credentials, because they have no security
+	// requirements (of their own, or from the document), or one of their requirements is empty ('{}').
+	UnsecuredOperations []*SecurityFinding `json:"unsecuredOperations,omitempty" yaml:"unsecuredOperations,omitempty"`
+
+	// UndefinedSchemes are security requirements that name a security scheme that is not defined.
+	UndefinedSchemes []*SecurityFinding `json:"undefinedSchemes,omitempty" yaml:"undefinedSchemes,omitempty"`
+
+	// UnusedSchemes are security schemes that are defined, but never required.
+	UnusedSchemes []*SecurityFinding `json:"unusedSchemes,omitempty" yaml:"unusedSchemes,omitempty"`
+
+	// BasicAuthSchemes are security schemes that use HTTP basic authentication, which sends credentials with
+	// every request.
+	BasicAuthSchemes []*SecurityFinding `json:"basicAuthSchemes,omitempty" yaml:"basicAuthSchemes,omitempty"`
+
+	// QueryAPIKeySchemes are API key security schemes that send the key in the query, where it is likely to be
+	// logged by servers and proxies.
+	QueryAPIKeySchemes []*SecurityFinding `json:"queryApiKeySchemes,omitempty" yaml:"queryApiKeySchemes,omitempty"`
+
+	// UndefinedScopes are scopes required of an OAuth2 security scheme, that the scheme does not define.
+	UndefinedScopes []*SecurityFinding `json:"undefinedScopes,omitempty" yaml:"undefinedScopes,omitempty"`
+}
+
+// Total returns the number of findings in the audit.
+func (a *SecurityAudit) Total() int {
+	return len(a.UnsecuredOperations) + len(a.UndefinedSchemes) + len(a.UnusedSchemes) + len(a.BasicAuthSchemes) +
+		len(a.QueryAPIKeySchemes) + len(a.UndefinedScopes)
+}
+
+// securityScheme is a security scheme defined by the document, with any $ref resolved.
+type securityScheme struct {
+	name    string
+	keyNode *yaml.Node
+	node    *yaml.Node
+	oauth2  bool
+	scopes  map[string]bool
+	used    bool
+}
+
+// AuditSecurity reports on the security configuration of the document. It finds operations (of paths and webhooks)
+// without security, requirements of security schemes that don't exist, security schemes that are never required,
+// HTTP basic authentication, API keys sent in the query, and OAuth2 scopes that are required but never defined.
+//
+// Both OpenAPI (components.securitySchemes) and Swagger (securityDefinitions) documents are supported.
+func (index *SpecIndex) AuditSecurity() *SecurityAudit {
+	audit := new(SecurityAudit)
+	if index.root == nil || len(index.root.Content) == 0 {
+		return audit
+	}
+	schemes, order := index.collectSecuritySchemes(audit)
+	root := index.root.Content[0]
+
+	var rootReqs *yaml.Node
+	if _, n := utils.FindKeyNodeTop("security", root.Content); n != nil && n.Kind == yaml.SequenceNode {
+		rootReqs = n
+		index.auditSecurityRequirements(audit, schemes, n, "", "", false)
+	}
+
+	for _, parent := range []string{"paths", "webhooks"} {
+		_, items := utils.FindKeyNodeTop(parent, root.Content)
+		if items == nil || items.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(items.Content); i += 2 {
+			name := items.Content[i].Value
+			if strings.HasPrefix(name, "x-") {
+				continue
+			}
+			pathItem := index.resolveRefNode(items.Content[i+1])
+			for j := 0; j+1 < len(pathItem.Content); j += 2 {
+				methodNode := pathItem.Content[j]
+				if !isPathItemMethod(methodNode.Value) {
+					continue
+				}
+				webhook := parent == "webhooks"
+				reqs := rootReqs
+				if _, n := utils.FindKeyNodeTop("security", pathItem.Content[j+1].Content); n != nil {
+					reqs = n
+					index.auditSecurityRequirements(audit, schemes, n, name, methodNode.Value, webhook)
+				}
+				if reqs == nil || len(reqs.Content) == 0 || hasEmptyRequirement(reqs) {
+					audit.UnsecuredOperations = append(audit.UnsecuredOperations, &SecurityFinding{
+						Path:    name,
+						Method:  methodNode.Value,
+						Webhook: webhook,
+						Message: fmt.Sprintf("the `%s` operation at `%s` does not require any security",
+							methodNode.Value, name),
+						Line:   methodNode.Line,
+						Column: methodNode.Column,
+						Node:   methodNode,
+					})
+				}
+			}
+		}
+	}
+
+	for _, s := range order {
+		if !s.used {
+			audit.UnusedSchemes = append(audit.UnusedSchemes, &SecurityFinding{
+				Scheme:  s.name,
+				Message: fmt.Sprintf("the `%s` security scheme is not required by anything", s.name),
+				Line:    s.keyNode.Line,
+				Column:  s.keyNode.Column,
+				Node:    s.keyNode,
+			})
+		}
+	}
+	return audit
+}
+
+// collectSecuritySchemes reads every security scheme defined by the document, and reports the schemes that
+// use basic authentication or send API keys in the query.
+func (index *SpecIndex) collectSecuritySchemes(audit *SecurityAudit) (map[string]*securityScheme, []*securityScheme) {
+	schemes := make(map[string]*securityScheme)
+	var order []*securityScheme
+	if index.securitySchemesNode == nil || index.securitySchemesNode.Kind != yaml.MappingNode {
+		return schemes, order
+	}
+	for i := 0; i+1 < len(index.securitySchemesNode.Content); i += 2 {
+		keyNode := index.securitySchemesNode.Content[i]
+		node := index.resolveRefNode(index.securitySchemesNode.Content[i+1])
+		s := &securityScheme{name: keyNode.Value, keyNode: keyNode, node: node, scopes: make(map[string]bool)}
+		schemes[s.name] = s
+		order = append(order, s)
+
+		finding := func(message string) *SecurityFinding {
+			return &SecurityFinding{
+				Scheme:  s.name,
+				Message: fmt.Sprintf(message, s.name),
+				Line:    keyNode.Line,
+				Column:  keyNode.Column,
+				Node:    keyNode,
+			}
+		}
+		_, typ := utils.FindKeyNodeTop("type", node.Content)
+		if typ == nil {
+			continue
+		}
+		switch typ.Value {
+		case "basic":
+			audit.BasicAuthSchemes = append(audit.BasicAuthSchemes,
+				finding("the `%s` security scheme uses basic authentication"))
+		case "http":
+			if _, sch := utils.FindKeyNodeTop("scheme", node.Content); sch != nil &&
+				strings.EqualFold(sch.Value, "basic") {
+				audit.BasicAuthSchemes = append(audit.BasicAuthSchemes,
+					finding("the `%s` security scheme uses basic authentication"))
+			}
+		case "apiKey":
+			if _, in := utils.FindKeyNodeTop("in", node.Content); in != nil && in.Value == "query" {
+				audit.QueryAPIKeySchemes = append(audit.QueryAPIKeySchemes,
+					finding("the `%s` security scheme sends an API key in the query"))
+			}
+		case "oauth2":
+			s.oauth2 = true
+			// swagger defines scopes on the scheme, openapi defines them for each flow.
+			_, scopes := utils.FindKeyNodeTop("scopes", node.Content)
+			addScopes(s.scopes, scopes)
+			if _, flows := utils.FindKeyNodeTop("flows", node.Content); flows != nil {
+				for j := 1; j < len(flows.Content); j += 2 {
+					_, scopes = utils.FindKeyNodeTop("scopes", flows.Content[j].Content)
+					addScopes(s.scopes, scopes)
+				}
+			}
+		}
+	}
+	return schemes, order
+}
+
+func addScopes(scopes map[string]bool, node *yaml.Node) {
+	if node == nil {
+		return
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		scopes[node.Content[i].Value] = true
+	}
+}
+
+// auditSecurityRequirements checks a list of security requirements name schemes that exist, and only require
+// scopes those schemes define. The path and method are empty for the requirements of the document.
+func (index *SpecIndex) auditSecurityRequirements(audit *SecurityAudit, schemes map[string]*securityScheme,
+	reqs *yaml.Node, path, method string, webhook bool,
+) {
+	where := "the document"
+	if method != "" {
+		where = fmt.Sprintf("the `%s` operation at `%s`", method, path)
+	}
+	for _, req := range reqs.Content {
+		for i := 0; i+1 < len(req.Content); i += 2 {
+			keyNode, scopes := req.Content[i], req.Content[i+1]
+			s := schemes[keyNode.Value]
+			if s == nil {
+				audit.UndefinedSchemes = append(audit.UndefinedSchemes, &SecurityFinding{
+					Scheme:  keyNode.Value,
+					Path:    path,
+					Method:  method,
+					Webhook: webhook,
+					Message: fmt.Sprintf("%s requires the `%s` security scheme, which is not defined",
+						where, keyNode.Value),
+					Line:   keyNode.Line,
+					Column: keyNode.Column,
+					Node:   keyNode,
+				})
+				continue
+			}
+			s.used = true
+			if !s.oauth2 {
+				continue
+			}
+			for _, scope := range scopes.Content {
+				if !s.scopes[scope.Value] {
+					audit.UndefinedScopes = append(audit.UndefinedScopes, &SecurityFinding{
+						Scheme:  s.name,
+						Scope:   scope.Value,
+						Path:    path,
+						Method:  method,
+						Webhook: webhook,
+						Message: fmt.Sprintf("%s requires the `%s` scope, which the `%s` security scheme does not define",
+							where, scope.Value, s.name),
+						Line:   scope.Line,
+						Column: scope.Column,
+						Node:   scope,
+					})
+				}
+			}
+		}
+	}
+}
+
+// hasEmptyRequirement returns true if a list of security requirements has an empty requirement, which makes
+// security optional.
+func hasEmptyRequirement(reqs *yaml.Node) bool {
+	for _, req := range reqs.Content {
+		if req.Kind == yaml.MappingNode && len(req.Content) == 0 {
+			return true
+		}
+	}
+	return false
+}
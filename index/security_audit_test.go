@@ -0,0 +1,144 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_AuditSecurity(t *testing.T) {
+	spec := `openapi: 3.1.0
+security:
+  - OAuth: [read]
+paths:
+  /pets:
+    get:
+      description: uses the document security
+    post:
+      security:
+        - OAuth: [write, admin]
+        - Missing: []
+  /health:
+    get:
+      security: []
+    head:
+      security:
+        - {}
+        - Key: []
+webhooks:
+  newPet:
+    post:
+      security:
+        - Basic: []
+components:
+  securitySchemes:
+    OAuth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://example.com/auth
+          scopes:
+            read: read things
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes:
+            write: write things
+    Basic:
+      type: http
+      scheme: Basic
+    Key:
+      $ref: '#/components/x-schemes/Key'
+    Unused:
+      type: http
+      scheme: bearer
+  x-schemes:
+    Key:
+      type: apiKey
+      name: key
+      in: query`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	audit := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig()).AuditSecurity()
+
+	assert.Len(t, audit.UnsecuredOperations, 2)
+	assert.Equal(t, "/health", audit.UnsecuredOperations[0].Path)
+	assert.Equal(t, "get", audit.UnsecuredOperations[0].Method)
+	assert.Equal(t, "head", audit.UnsecuredOperations[1].Method)
+	assert.Equal(t, 15, audit.UnsecuredOperations[1].Line)
+
+	assert.Len(t, audit.UndefinedSchemes, 1)
+	assert.Equal(t, "Missing", audit.UndefinedSchemes[0].Scheme)
+	assert.Equal(t, "post", audit.UndefinedSchemes[0].Method)
+	assert.Equal(t, "the `post` operation at `/pets` requires the `Missing` security scheme, which is not defined",
+		audit.UndefinedSchemes[0].Message)
+
+	assert.Len(t, audit.UnusedSchemes, 1)
+	assert.Equal(t, "Unused", audit.UnusedSchemes[0].Scheme)
+
+	assert.Len(t, audit.BasicAuthSchemes, 1)
+	assert.Equal(t, "Basic", audit.BasicAuthSchemes[0].Scheme)
+
+	assert.Len(t, audit.QueryAPIKeySchemes, 1)
+	assert.Equal(t, "Key", audit.QueryAPIKeySchemes[0].Scheme)
+
+	assert.Len(t, audit.UndefinedScopes, 1)
+	assert.Equal(t, "admin", audit.UndefinedScopes[0].Scope)
+	assert.Equal(t, "OAuth", audit.UndefinedScopes[0].Scheme)
+	assert.Equal(t, 10, audit.UndefinedScopes[0].Line)
+
+	assert.Equal(t, 7, audit.Total())
+}
+
+func TestSpecIndex_AuditSecurity_Swagger(t *testing.T) {
+	spec := `swagger: 2.0
+paths:
+  /pets:
+    get:
+      security:
+        - petstore_auth: [read:pets, write:all]
+    put:
+      description: no security
+securityDefinitions:
+  petstore_auth:
+    type: oauth2
+    flow: implicit
+    authorizationUrl: https://example.com/auth
+    scopes:
+      read:pets: read pets
+  basic:
+    type: basic`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	audit := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig()).AuditSecurity()
+
+	assert.Len(t, audit.UnsecuredOperations, 1)
+	assert.Equal(t, "put", audit.UnsecuredOperations[0].Method)
+	assert.Len(t, audit.UndefinedScopes, 1)
+	assert.Equal(t, "write:all", audit.UndefinedScopes[0].Scope)
+	assert.Len(t, audit.BasicAuthSchemes, 1)
+	assert.Len(t, audit.UnusedSchemes, 1)
+	assert.Equal(t, "basic", audit.UnusedSchemes[0].Scheme)
+	assert.Empty(t, audit.UndefinedSchemes)
+}
+
+func TestSpecIndex_AuditSecurity_Petstore(t *testing.T) {
+	spec, _ := os.ReadFile("../test_specs/petstorev3.json")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(spec, &rootNode)
+	audit := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig()).AuditSecurity()
+
+	assert.Empty(t, audit.UndefinedSchemes)
+	assert.Empty(t, audit.UndefinedScopes)
+	assert.NotEmpty(t, audit.UnsecuredOperations)
+}
+
+func TestSpecIndex_AuditSecurity_Empty(t *testing.T) {
+	assert.Equal(t, 0, new(SpecIndex).AuditSecurity().Total())
+}
@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
@@ -32,12 +33,21 @@ func NewSpecIndexWithConfig(rootNode *yaml.Node, config *SpecIndexConfig) *SpecI
 		config.seenRemoteSources = &syncmap.Map{}
 	}
 	config.remoteLock = &sync.Mutex{}
+	if config.fetches == nil {
+		config.fetches = newFetchGroup(config.MaxConcurrentFetches)
+	}
 	index.config = config
 	index.parentIndex = config.ParentIndex
 	index.uri = config.uri
 	if rootNode == nil || len(rootNode.Content) <= 0 {
 		return index
 	}
+	if config.InternStrings {
+		if config.interner == nil {
+			config.interner = utils.NewStringInterner()
+		}
+		config.interner.InternNodes(rootNode)
+	}
 	boostrapIndexCollections(rootNode, index)
 	return createNewIndex(rootNode, index, config.AvoidBuildIndex)
 }
@@ -64,8 +74,30 @@ func createNewIndex(rootNode *yaml.Node, index *SpecIndex, avoidBuildOut bool) *
 		return index
 	}
 
+	start := time.Now()
+
+	// stop before doing anything if the document is too large.
+	if err := index.countNodes(rootNode); err != nil {
+		index.refErrors = append(index.refErrors, &IndexingError{Err: err, Node: rootNode, Path: "$"})
+		return index
+	}
+
+	// relative references are resolved against the URI the document declares for itself, if it has one.
+	index.baseURI = index.determineBaseURI()
+
+	// map every component, so they can be looked up without searching the document.
+	index.mapComponentLookup()
+
 	// boot index.
 	results := index.ExtractRefs(index.root.Content[0], index.root, []string{}, 0, false, "")
+	index.logDebug("extracted references", "document", index.documentName(),
+		"references", len(results), "duration", time.Since(start))
+
+	// stop before looking up references, if there are too many.
+	if err := index.countReferences(len(index.rawSequencedRefs)); err != nil {
+		index.refErrors = append(index.refErrors, &IndexingError{Err: err, Node: rootNode, Path: "$"})
+		return index
+	}
 
 	// map poly refs
 	poly := make([]*Reference, len(index.polymorphicRefs))
@@ -76,8 +108,12 @@ func createNewIndex(rootNode *yaml.Node, index *SpecIndex, avoidBuildOut bool) *
 	}
 
 	// pull out references
+	lookupStart := time.Now()
 	index.ExtractComponentsFromRefs(results)
 	index.ExtractComponentsFromRefs(poly)
+	index.checkDynamicRefs()
+	index.logDebug("looked up references", "document", index.documentName(),
+		"references", len(results)+len(poly), "duration", time.Since(lookupStart))
 
 	index.ExtractExternalDocuments(index.root)
 	index.GetPathCount()
@@ -92,6 +128,8 @@ func createNewIndex(rootNode *yaml.Node, index *SpecIndex, avoidBuildOut bool) *
 		index.seenRemoteSources[k.(string)] = v.(*yaml.Node)
 		return true
 	})
+	index.logDebug("built index", "document", index.documentName(),
+		"errors", len(index.refErrors), "duration", time.Since(start))
 	return index
 }
 
@@ -323,6 +361,36 @@ func (index *SpecIndex) GetAllCallbacks() map[string]*Reference {
 	return index.allCallbacks
 }
 
+// GetAllComponentPathItems will return all path items found in the document (under components, 3.1+)
+func (index *SpecIndex) GetAllComponentPathItems() map[string]*Reference {
+	return index.allComponentPathItems
+}
+
+// GetAllWebhooks will return all webhooks found in the document (3.1+)
+func (index *SpecIndex) GetAllWebhooks() map[string]*Reference {
+	return index.allWebhooks
+}
+
+// GetAllAnchors will return every schema that declares an $anchor, keyed by the anchor name (3.1+)
+func (index *SpecIndex) GetAllAnchors() map[string]*Reference {
+	return index.allAnchors
+}
+
+// GetAllDynamicAnchors will return every schema that declares a $dynamicAnchor, keyed by the anchor name (3.1+)
+func (index *SpecIndex) GetAllDynamicAnchors() map[string]*Reference {
+	return index.allDynamicAnchors
+}
+
+// GetAllDynamicRefs will return every $dynamicRef found in the document, in the order they were found (3.1+)
+func (index *SpecIndex) GetAllDynamicRefs() []*Reference {
+	return index.allDynamicRefs
+}
+
+// GetWebhooksNode will return the root webhooks node found in the document (3.1+)
+func (index *SpecIndex) GetWebhooksNode() *yaml.Node {
+	return index.webhooksNode
+}
+
 // GetInlineOperationDuplicateParameters will return a map of duplicates located in operation parameters.
 func (index *SpecIndex) GetInlineOperationDuplicateParameters() map[string][]*Reference {
 	return index.paramInlineDuplicateNames
@@ -353,6 +421,11 @@ func (index *SpecIndex) GetParametersNode() *yaml.Node {
 	return index.parametersNode
 }
 
+// GetConfig returns the configuration the index was created with.
+func (index *SpecIndex) GetConfig() *SpecIndexConfig {
+	return index.config
+}
+
 // GetReferenceIndexErrors will return any errors that occurred when indexing references
 func (index *SpecIndex) GetReferenceIndexErrors() []error {
 	return index.refErrors
@@ -424,6 +497,11 @@ func (index *SpecIndex) AllowCircularReferenceResolving() bool {
 	return index.allowCircularReferences
 }
 
+// SkipExamples returns true if models built using the index should not build examples. A nil index builds examples.
+func (index *SpecIndex) SkipExamples() bool {
+	return index != nil && index.config != nil && index.config.SkipExamples
+}
+
 func (index *SpecIndex) checkPolymorphicNode(name string) (bool, string) {
 	switch name {
 	case "anyOf":
@@ -751,6 +829,7 @@ func (index *SpecIndex) GetComponentSchemaCount() int {
 				_, examplesNode := utils.FindKeyNode("examples", index.root.Content[0].Content[i+1].Content)
 				_, linksNode := utils.FindKeyNode("links", index.root.Content[0].Content[i+1].Content)
 				_, callbacksNode := utils.FindKeyNode("callbacks", index.root.Content[0].Content[i+1].Content)
+				_, pathItemsNode := utils.FindKeyNode("pathItems", index.root.Content[0].Content[i+1].Content)
 
 				// extract schemas
 				if schemasNode != nil {
@@ -809,6 +888,21 @@ func (index *SpecIndex) GetComponentSchemaCount() int {
 					index.callbacksNode = callbacksNode
 				}
 
+				// extract path items (3.1)
+				if pathItemsNode != nil {
+					index.extractComponentPathItems(pathItemsNode, "#/components/pathItems/")
+					index.pathItemsNode = pathItemsNode
+				}
+
+			}
+
+			// webhooks (3.1)
+			if n.Value == "webhooks" {
+				webhooksNode := index.root.Content[0].Content[i+1]
+				if webhooksNode != nil {
+					index.extractWebhooks(webhooksNode, "#/webhooks/")
+					index.webhooksNode = webhooksNode
+				}
 			}
 
 			// swagger
@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
@@ -266,6 +267,51 @@ func TestSpecIndex_PetstoreV3(t *testing.T) {
 
 var mappedRefs = 15
 
+func TestSpecIndex_Webhooks(t *testing.T) {
+	yml := `openapi: 3.1.0
+webhooks:
+  newBurger:
+    post:
+      description: a new burger has arrived
+  oldBurger:
+    $ref: '#/webhooks/newBurger'
+  x-not-a-hook: nope`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	assert.NotNil(t, index.GetWebhooksNode())
+	assert.Len(t, index.GetAllWebhooks(), 2)
+	assert.Equal(t, "newBurger", index.GetAllWebhooks()["#/webhooks/newBurger"].Name)
+	assert.Equal(t, "$.webhooks.newBurger", index.GetAllWebhooks()["#/webhooks/newBurger"].Path)
+	assert.NotNil(t, index.GetMappedReferences()["#/webhooks/newBurger"])
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+}
+
+func TestSpecIndex_ComponentPathItems(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /burgers:
+    $ref: '#/components/pathItems/burgers'
+components:
+  pathItems:
+    burgers:
+      get:
+        description: get some burgers`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	assert.Len(t, index.GetAllComponentPathItems(), 1)
+	assert.Equal(t, "burgers", index.GetAllComponentPathItems()["#/components/pathItems/burgers"].Name)
+	assert.NotNil(t, index.GetMappedReferences()["#/components/pathItems/burgers"])
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+}
+
 func TestSpecIndex_BurgerShop(t *testing.T) {
 	burgershop, _ := ioutil.ReadFile("../test_specs/burgershop.openapi.yaml")
 	var rootNode yaml.Node
@@ -1143,3 +1189,39 @@ paths:
 	assert.Equal(t, "$.paths./test2.put", paths["/test2"]["put"].Path)
 	assert.Equal(t, 22, paths["/test2"]["put"].ParentNode.Line)
 }
+
+func TestSpecIndex_InternStrings(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Local:
+      type: object
+    Thing:
+      $ref: 'schemas/pet.yaml#/Pet'
+    Other:
+      $ref: 'schemas/pet.yaml#/Pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	c := CreateOpenAPIIndexConfig()
+	c.BasePath = ""
+	c.LocalFS = localFS
+	c.InternStrings = true
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+
+	sameStorage := func(a, b string) bool {
+		return unsafe.StringData(a) == unsafe.StringData(b)
+	}
+	schemas := rootNode.Content[0].Content[3].Content[1]
+	localType := schemas.Content[1].Content[0]
+	assert.Equal(t, "type", localType.Value)
+	assert.True(t, sameStorage(schemas.Content[3].Content[1].Value, schemas.Content[5].Content[1].Value))
+
+	// keys in referenced documents share storage with the root document.
+	assert.Len(t, idx.GetChildren(), 1)
+	pet := idx.GetChildren()[0].GetRootNode().Content[0].Content[1]
+	assert.Equal(t, "type", pet.Content[0].Value)
+	assert.True(t, sameStorage(localType.Value, pet.Content[0].Value))
+}
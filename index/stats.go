@@ -0,0 +1,79 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+// IndexStats is a summary of the size and shape of an indexed specification. It only holds plain values, so it
+// can be serialized as JSON or YAML as is (for dashboards and reports).
+type IndexStats struct {
+	Paths               int            `json:"paths" yaml:"paths"`
+	Operations          int            `json:"operations" yaml:"operations"`
+	OperationsByMethod  map[string]int `json:"operationsByMethod" yaml:"operationsByMethod"`
+	ComponentSchemas    int            `json:"componentSchemas" yaml:"componentSchemas"`
+	InlineSchemas       int            `json:"inlineSchemas" yaml:"inlineSchemas"`
+	ComponentParameters int            `json:"componentParameters" yaml:"componentParameters"`
+	OperationParameters int            `json:"operationParameters" yaml:"operationParameters"`
+	SecuritySchemes     int            `json:"securitySchemes" yaml:"securitySchemes"`
+	References          ReferenceStats `json:"references" yaml:"references"`
+	CircularReferences  int            `json:"circularReferences" yaml:"circularReferences"`
+}
+
+// ReferenceStats holds counts of the references found in a specification. Local, File and Remote count unique
+// references by the type of lookup required to resolve them.
+type ReferenceStats struct {
+	Total  int `json:"total" yaml:"total"`   // every $ref found, including duplicates.
+	Unique int `json:"unique" yaml:"unique"` // every unique $ref value found.
+	Local  int `json:"local" yaml:"local"`   // references to the same document, for example '#/components/schemas/Pet'.
+	File   int `json:"file" yaml:"file"`     // references to local files, for example 'pet.yaml#/Pet'.
+	Remote int `json:"remote" yaml:"remote"` // references to remote documents, for example 'https://pb33f.io/pet.yaml'.
+}
+
+// GetStats returns a summary of the size and shape of the specification held by this index. Circular references
+// are only counted if the resolver has been run against the index.
+func (index *SpecIndex) GetStats() *IndexStats {
+	stats := &IndexStats{OperationsByMethod: make(map[string]int)}
+	if index.root == nil {
+		return stats
+	}
+	positive := func(n int) int {
+		if n < 0 {
+			return 0
+		}
+		return n
+	}
+	stats.Paths = positive(index.GetPathCount())
+	stats.Operations = positive(index.GetOperationCount())
+	index.pathRefsLock.Lock()
+	for _, methods := range index.pathRefs {
+		for method := range methods {
+			stats.OperationsByMethod[method]++
+		}
+	}
+	index.pathRefsLock.Unlock()
+
+	stats.ComponentSchemas = len(index.allComponentSchemaDefinitions)
+	stats.InlineSchemas = len(index.allInlineSchemaDefinitions)
+	stats.ComponentParameters = positive(index.GetComponentParameterCount())
+	stats.OperationParameters = positive(index.GetOperationsParameterCount())
+	stats.SecuritySchemes = len(index.allSecuritySchemes)
+
+	unique := make(map[string]bool)
+	for _, ref := range index.rawSequencedRefs {
+		stats.References.Total++
+		if unique[ref.Definition] {
+			continue
+		}
+		unique[ref.Definition] = true
+		switch DetermineReferenceResolveType(ref.Definition) {
+		case LocalResolve:
+			stats.References.Local++
+		case FileResolve:
+			stats.References.File++
+		case HttpResolve:
+			stats.References.Remote++
+		}
+	}
+	stats.References.Unique = len(unique)
+	stats.CircularReferences = len(index.circularReferences)
+	return stats
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_GetStats(t *testing.T) {
+	petstore, _ := os.ReadFile("../test_specs/petstorev3.json")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(petstore, &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	stats := idx.GetStats()
+	assert.Equal(t, 13, stats.Paths)
+	assert.Equal(t, 19, stats.Operations)
+	assert.Equal(t, 8, stats.OperationsByMethod["get"])
+	assert.Equal(t, 6, stats.OperationsByMethod["post"])
+	assert.Equal(t, 8, stats.ComponentSchemas)
+	assert.Equal(t, 0, stats.ComponentParameters)
+	assert.Equal(t, 9, stats.OperationParameters)
+	assert.Equal(t, 2, stats.SecuritySchemes)
+	assert.Equal(t, 7, stats.References.Unique)
+	assert.Equal(t, 7, stats.References.Local)
+	assert.Zero(t, stats.References.File)
+	assert.Zero(t, stats.References.Remote)
+	assert.Equal(t, len(idx.GetAllSequencedReferences()), stats.References.Total)
+	assert.Zero(t, stats.CircularReferences)
+
+	m, err := json.Marshal(stats)
+	assert.NoError(t, err)
+	assert.Contains(t, string(m), `"operationsByMethod":{`)
+}
+
+func TestSpecIndex_GetStats_References(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          $ref: '#/components/responses/Pets'
+        "201":
+          $ref: '#/components/responses/Pets'
+        "400":
+          $ref: 'errors.yaml#/BadRequest'
+        "500":
+          $ref: 'https://pb33f.io/errors.yaml#/Error'
+components:
+  responses:
+    Pets:
+      description: pets`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+
+	stats := idx.GetStats()
+	assert.Equal(t, 1, stats.Paths)
+	assert.Equal(t, map[string]int{"get": 1}, stats.OperationsByMethod)
+	assert.Equal(t, ReferenceStats{Total: 4, Unique: 3, Local: 1, File: 1, Remote: 1}, stats.References)
+
+	assert.Equal(t, &IndexStats{OperationsByMethod: map[string]int{}}, new(SpecIndex).GetStats())
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// TagFinding is a single concern raised by a tag audit, and where it was found.
+type TagFinding struct {
+	Tag     string     `json:"tag,omitempty" yaml:"tag,omitempty"`         // the name of the tag, if any.
+	Path    string     `json:"path,omitempty" yaml:"path,omitempty"`       // the path of the operation, if any.
+	Method  string     `json:"method,omitempty" yaml:"method,omitempty"`   // the method of the operation, if any.
+	Webhook bool       `json:"webhook,omitempty" yaml:"webhook,omitempty"` // the operation is a webhook.
+	Message string     `json:"message" yaml:"message"`
+	Line    int        `json:"line,omitempty" yaml:"line,omitempty"`
+	Column  int        `json:"column,omitempty" yaml:"column,omitempty"`
+	Node    *yaml.Node `json:"-" yaml:"-"`
+}
+
+// TagCoverage is the number of operations that use a tag. Line and Column are where the tag is declared, or first
+// used if it's not declared.
+type TagCoverage struct {
+	Name       string     `json:"name" yaml:"name"`
+	Declared   bool       `json:"declared" yaml:"declared"`
+	Operations int        `json:"operations" yaml:"operations"`
+	Line       int        `json:"line,omitempty" yaml:"line,omitempty"`
+	Column     int        `json:"column,omitempty" yaml:"column,omitempty"`
+	Node       *yaml.Node `json:"-" yaml:"-"`
+}
+
+// TagAudit is a report of how the tags of a document are declared and used by operations, for enforcing tagging
+// policies. Every finding is in the order it was found in the document.
+type TagAudit struct {
+	// Tags holds every tag declared or used, declared tags first (in the order of the document), then tags that
+	// are used but not declared (in the order they are first used).
+	Tags []*TagCoverage `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// UnusedTags are tags declared by the document, that no operation uses.
+	UnusedTags []*TagFinding `json:"unusedTags,omitempty" yaml:"unusedTags,omitempty"`
+
+	// UndeclaredTags are tags used by an operation, that the document does not declare. Every use is reported.
+	UndeclaredTags []*TagFinding `json:"undeclaredTags,omitempty" yaml:"undeclaredTags,omitempty"`
+
+	// UntaggedOperations are operations without any tags.
+	UntaggedOperations []*TagFinding `json:"untaggedOperations,omitempty" yaml:"untaggedOperations,omitempty"`
+}
+
+// Total returns the number of findings in the audit.
+func (a *TagAudit) Total() int {
+	return len(a.UnusedTags) + len(a.UndeclaredTags) + len(a.UntaggedOperations)
+}
+
+// Coverage returns the coverage of a tag by name, or nil if the tag is not declared or used.
+func (a *TagAudit) Coverage(name string) *TagCoverage {
+	for _, t := range a.Tags {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// AuditTags reports on the tags of the document. It counts the operations (of paths and webhooks) using each tag,
+// and finds tags that are declared but unused, tags that are used but not declared, and operations without tags.
+func (index *SpecIndex) AuditTags() *TagAudit {
+	audit := new(TagAudit)
+	if index.root == nil || len(index.root.Content) == 0 {
+		return audit
+	}
+	root := index.root.Content[0]
+	tags := make(map[string]*TagCoverage)
+
+	if _, declared := utils.FindKeyNodeTop("tags", root.Content); declared != nil {
+		for _, tagNode := range declared.Content {
+			_, name := utils.FindKeyNodeTop("name", tagNode.Content)
+			if name == nil || tags[name.Value] != nil {
+				continue
+			}
+			t := &TagCoverage{Name: name.Value, Declared: true, Line: name.Line, Column: name.Column, Node: name}
+			tags[t.Name] = t
+			audit.Tags = append(audit.Tags, t)
+		}
+	}
+
+	for _, parent := range []string{"paths", "webhooks"} {
+		_, items := utils.FindKeyNodeTop(parent, root.Content)
+		if items == nil || items.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(items.Content); i += 2 {
+			name := items.Content[i].Value
+			if strings.HasPrefix(name, "x-") {
+				continue
+			}
+			pathItem := index.resolveRefNode(items.Content[i+1])
+			for j := 0; j+1 < len(pathItem.Content); j += 2 {
+				methodNode := pathItem.Content[j]
+				if !isPathItemMethod(methodNode.Value) {
+					continue
+				}
+				webhook := parent == "webhooks"
+				_, opTags := utils.FindKeyNodeTop("tags", pathItem.Content[j+1].Content)
+				if opTags == nil || len(opTags.Content) == 0 {
+					audit.UntaggedOperations = append(audit.UntaggedOperations, &TagFinding{
+						Path:    name,
+						Method:  methodNode.Value,
+						Webhook: webhook,
+						Message: fmt.Sprintf("the `%s` operation at `%s` has no tags", methodNode.Value, name),
+						Line:    methodNode.Line,
+						Column:  methodNode.Column,
+						Node:    methodNode,
+					})
+					continue
+				}
+				counted := make(map[string]bool)
+				for _, tagNode := range opTags.Content {
+					t := tags[tagNode.Value]
+					if t == nil {
+						t = &TagCoverage{Name: tagNode.Value, Line: tagNode.Line, Column: tagNode.Column, Node: tagNode}
+						tags[t.Name] = t
+						audit.Tags = append(audit.Tags, t)
+					}
+					if !t.Declared {
+						audit.UndeclaredTags = append(audit.UndeclaredTags, &TagFinding{
+							Tag:     tagNode.Value,
+							Path:    name,
+							Method:  methodNode.Value,
+							Webhook: webhook,
+							Message: fmt.Sprintf("the `%s` operation at `%s` uses the `%s` tag, which is not declared",
+								methodNode.Value, name, tagNode.Value),
+							Line:   tagNode.Line,
+							Column: tagNode.Column,
+							Node:   tagNode,
+						})
+					}
+					// an operation using a tag twice is only counted once.
+					if !counted[t.Name] {
+						counted[t.Name] = true
+						t.Operations++
+					}
+				}
+			}
+		}
+	}
+
+	for _, t := range audit.Tags {
+		if t.Declared && t.Operations == 0 {
+			audit.UnusedTags = append(audit.UnusedTags, &TagFinding{
+				Tag:     t.Name,
+				Message: fmt.Sprintf("the `%s` tag is not used by any operation", t.Name),
+				Line:    t.Line,
+				Column:  t.Column,
+				Node:    t.Node,
+			})
+		}
+	}
+	return audit
+}
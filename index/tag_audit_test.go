@@ -0,0 +1,83 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_AuditTags(t *testing.T) {
+	spec := `openapi: 3.1.0
+tags:
+  - name: pets
+  - name: owners
+  - name: unused
+paths:
+  /pets:
+    get:
+      tags: [pets, pets]
+    post:
+      tags: [pets, store]
+  /owners:
+    get:
+      tags: [owners]
+    delete:
+      tags: []
+  /health:
+    get:
+      description: untagged
+webhooks:
+  newPet:
+    post:
+      tags: [store]`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	audit := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig()).AuditTags()
+
+	assert.Len(t, audit.Tags, 4)
+	assert.Equal(t, 2, audit.Coverage("pets").Operations)
+	assert.Equal(t, 1, audit.Coverage("owners").Operations)
+	assert.Equal(t, 0, audit.Coverage("unused").Operations)
+	store := audit.Coverage("store")
+	assert.False(t, store.Declared)
+	assert.Equal(t, 2, store.Operations)
+	assert.Equal(t, 11, store.Line)
+	assert.Nil(t, audit.Coverage("nope"))
+
+	assert.Len(t, audit.UnusedTags, 1)
+	assert.Equal(t, "unused", audit.UnusedTags[0].Tag)
+	assert.Equal(t, 5, audit.UnusedTags[0].Line)
+
+	assert.Len(t, audit.UndeclaredTags, 2)
+	assert.Equal(t, "the `post` operation at `/pets` uses the `store` tag, which is not declared",
+		audit.UndeclaredTags[0].Message)
+	assert.True(t, audit.UndeclaredTags[1].Webhook)
+
+	assert.Len(t, audit.UntaggedOperations, 2)
+	assert.Equal(t, "delete", audit.UntaggedOperations[0].Method)
+	assert.Equal(t, "/health", audit.UntaggedOperations[1].Path)
+	assert.Equal(t, 18, audit.UntaggedOperations[1].Line)
+
+	assert.Equal(t, 5, audit.Total())
+}
+
+func TestSpecIndex_AuditTags_Petstore(t *testing.T) {
+	spec, _ := os.ReadFile("../test_specs/petstorev3.json")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(spec, &rootNode)
+	audit := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig()).AuditTags()
+
+	assert.Empty(t, audit.UndeclaredTags)
+	assert.Empty(t, audit.UntaggedOperations)
+	assert.Equal(t, 8, audit.Coverage("pet").Operations)
+}
+
+func TestSpecIndex_AuditTags_Empty(t *testing.T) {
+	assert.Equal(t, 0, new(SpecIndex).AuditTags().Total())
+}
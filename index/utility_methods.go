@@ -204,6 +204,45 @@ func (index *SpecIndex) extractComponentCallbacks(callbacksNode *yaml.Node, path
 	}
 }
 
+func (index *SpecIndex) extractComponentPathItems(pathItemsNode *yaml.Node, pathPrefix string) {
+	var name string
+	for i, pathItem := range pathItemsNode.Content {
+		if i%2 == 0 {
+			name = pathItem.Value
+			continue
+		}
+		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		ref := &Reference{
+			Definition: def,
+			Name:       name,
+			Node:       pathItem,
+		}
+		index.allComponentPathItems[def] = ref
+	}
+}
+
+func (index *SpecIndex) extractWebhooks(webhooksNode *yaml.Node, pathPrefix string) {
+	var name string
+	for i, hook := range webhooksNode.Content {
+		if i%2 == 0 {
+			name = hook.Value
+			continue
+		}
+		if strings.HasPrefix(name, "x-") {
+			continue
+		}
+		def := fmt.Sprintf("%s%s", pathPrefix, name)
+		ref := &Reference{
+			Definition: def,
+			Name:       name,
+			Node:       hook,
+			ParentNode: webhooksNode,
+			Path:       fmt.Sprintf("$.webhooks.%s", name),
+		}
+		index.allWebhooks[def] = ref
+	}
+}
+
 func (index *SpecIndex) extractComponentLinks(linksNode *yaml.Node, pathPrefix string) {
 	var name string
 	for i, link := range linksNode.Content {
@@ -447,3 +486,20 @@ func GenerateCleanSpecConfigBaseURL(baseURL *url.URL, dir string, includeFile bo
 	return p
 
 }
+
+// jsonPath builds a JSONPath from the segments of a path (joined by '.') and a suffix, using a pooled buffer.
+func jsonPath(seenPath []string, suffix ...string) string {
+	b := utils.GetBuffer()
+	defer utils.PutBuffer(b)
+	*b = append(*b, "$."...)
+	for i, seg := range seenPath {
+		if i > 0 {
+			*b = append(*b, '.')
+		}
+		*b = append(*b, seg...)
+	}
+	for _, s := range suffix {
+		*b = append(*b, s...)
+	}
+	return string(*b)
+}
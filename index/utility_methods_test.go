@@ -53,3 +53,10 @@ func TestSpecIndex_extractDefinitionRequiredRefProperties(t *testing.T) {
 	idx := NewSpecIndexWithConfig(nil, c)
 	assert.Nil(t, idx.extractDefinitionRequiredRefProperties(nil, nil))
 }
+
+func TestJsonPath(t *testing.T) {
+	assert.Equal(t, "$.", jsonPath(nil))
+	assert.Equal(t, "$.paths./pets.get", jsonPath([]string{"paths", "/pets", "get"}))
+	assert.Equal(t, "$.components.schemas.properties.name",
+		jsonPath([]string{"components", "schemas"}, ".properties.", "name"))
+}
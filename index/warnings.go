@@ -0,0 +1,125 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WarningType describes what kind of issue a Warning is about.
+type WarningType string
+
+const (
+	// WarningUnknownKey is used for keys that are not defined by the specification (and are not extensions).
+	WarningUnknownKey WarningType = "unknown-key"
+
+	// WarningDuplicateKey is used for keys that are defined more than once in the same object, only one of the
+	// values is used.
+	WarningDuplicateKey WarningType = "duplicate-key"
+
+	// WarningUnresolvedReference is used for references that cannot be resolved, where the reference is optional
+	// (for example a discriminator mapping), so building can carry on without it.
+	WarningUnresolvedReference WarningType = "unresolved-reference"
+
+	// WarningDeprecated is used for constructs that the specification has deprecated.
+	WarningDeprecated WarningType = "deprecated"
+)
+
+// Warning holds data about something that is not right with a specification, but doesn't stop it from being
+// indexed or built. Unlike errors, warnings can be ignored.
+type Warning struct {
+	Type    WarningType
+	Message string
+	Node    *yaml.Node // the node the warning is about.
+	Path    string     // the JSON path to the node, if known.
+}
+
+func (w *Warning) String() string {
+	if w.Node == nil {
+		return w.Message
+	}
+	return fmt.Sprintf("%s [%d:%d]", w.Message, w.Node.Line, w.Node.Column)
+}
+
+type warningKey struct {
+	warningType WarningType
+	node        *yaml.Node
+	message     string
+}
+
+// AddWarning will add a warning to the index, and pass it to the WarningHandler of the configuration (if set).
+// Warnings added to the index of a referenced document are added to the root index. The same warning for the
+// same node is only added once, no matter how many times the node is built.
+func (index *SpecIndex) AddWarning(warning *Warning) {
+	root := index.rootIndex()
+	if root == nil {
+		return
+	}
+	root.warningLock.Lock()
+	defer root.warningLock.Unlock()
+	key := warningKey{warningType: warning.Type, node: warning.Node, message: warning.Message}
+	if root.seenWarnings == nil {
+		root.seenWarnings = make(map[warningKey]bool)
+	}
+	if root.seenWarnings[key] {
+		return
+	}
+	root.seenWarnings[key] = true
+	root.warnings = append(root.warnings, warning)
+	if root.config != nil && root.config.WarningHandler != nil {
+		root.config.WarningHandler(warning)
+	}
+}
+
+// GetWarnings returns every warning added to the index (and the indexes of referenced documents) so far, in the
+// order they were added.
+func (index *SpecIndex) GetWarnings() []*Warning {
+	root := index.rootIndex()
+	if root == nil {
+		return nil
+	}
+	root.warningLock.Lock()
+	defer root.warningLock.Unlock()
+	return append([]*Warning(nil), root.warnings...)
+}
+
+// checkDuplicateKeys adds a warning for every key that is defined more than once in a map node.
+func (index *SpecIndex) checkDuplicateKeys(node *yaml.Node, seenPath []string) {
+	if len(node.Content) < 4 {
+		return
+	}
+	var seen map[string]bool
+	if len(node.Content) > 32 {
+		seen = make(map[string]bool, len(node.Content)/2)
+	}
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		key := node.Content[i].Value
+		if key == "<<" {
+			continue // merge keys can be repeated.
+		}
+		duplicate := false
+		if seen != nil {
+			duplicate = seen[key]
+			seen[key] = true
+		} else {
+			for j := 0; j < i && !duplicate; j += 2 {
+				duplicate = node.Content[j].Value == key
+			}
+		}
+		if duplicate {
+			path := "$"
+			if len(seenPath) > 0 {
+				path = jsonPath(seenPath)
+			}
+			index.AddWarning(&Warning{
+				Type:    WarningDuplicateKey,
+				Message: fmt.Sprintf("key '%s' is defined more than once", key),
+				Node:    node.Content[i],
+				Path:    path,
+			})
+		}
+	}
+}
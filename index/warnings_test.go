@@ -0,0 +1,80 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_Warnings_DuplicateKeys(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: one
+  title: two
+paths:
+  /pizza:
+    get:
+      description: one
+    get:
+      description: two`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+
+	var handled []*Warning
+	c := CreateOpenAPIIndexConfig()
+	c.WarningHandler = func(warning *Warning) {
+		handled = append(handled, warning)
+	}
+	idx := NewSpecIndexWithConfig(&rootNode, c)
+
+	warnings := idx.GetWarnings()
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, warnings, handled)
+	assert.Equal(t, WarningDuplicateKey, warnings[0].Type)
+	assert.Equal(t, "key 'title' is defined more than once [4:3]", warnings[0].String())
+	assert.Equal(t, "$.info", warnings[0].Path)
+	assert.Equal(t, "key 'get' is defined more than once [9:5]", warnings[1].String())
+
+	// the same warning is only added once.
+	idx.AddWarning(&Warning{Type: WarningDuplicateKey, Message: warnings[0].Message, Node: warnings[0].Node})
+	assert.Len(t, idx.GetWarnings(), 2)
+	assert.Len(t, handled, 2)
+}
+
+func TestSpecIndex_Warnings_LargeMap(t *testing.T) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i < 20; i++ {
+		key := string(rune('a' + i))
+		if i == 19 {
+			key = "a"
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "value"})
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "<<"},
+		&yaml.Node{Kind: yaml.MappingNode}, &yaml.Node{Kind: yaml.ScalarNode, Value: "<<"},
+		&yaml.Node{Kind: yaml.MappingNode})
+
+	idx := NewSpecIndexWithConfig(nil, CreateOpenAPIIndexConfig())
+	idx.checkDuplicateKeys(node, nil)
+	assert.Len(t, idx.GetWarnings(), 1)
+	assert.Equal(t, "$", idx.GetWarnings()[0].Path)
+}
+
+func TestSpecIndex_Warnings_Children(t *testing.T) {
+	parent := NewSpecIndexWithConfig(nil, CreateOpenAPIIndexConfig())
+	child := NewSpecIndexWithConfig(nil, &SpecIndexConfig{ParentIndex: parent})
+	child.AddWarning(&Warning{Type: WarningUnknownKey, Message: "nope"})
+	assert.Len(t, parent.GetWarnings(), 1)
+	assert.Len(t, child.GetWarnings(), 1)
+	assert.Equal(t, "nope", parent.GetWarnings()[0].String())
+
+	var idx *SpecIndex
+	idx.AddWarning(&Warning{})
+	assert.Nil(t, idx.GetWarnings())
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package lint provides an integration point for checking the quality of specifications with rules.
+//
+// A Rule is given every object in a high-level model (see the walk package), along with its JSON pointer and
+// low-level nodes, and reports findings about them. A Runner executes every registered rule in a single pass over
+// the document. A handful of built-in rules are provided (see DefaultRules), mostly as examples of writing rules.
+// Rules that check the responses of operations are provided as well (see ResponseRules), they are configured by
+// their fields.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pb33f/libopenapi/walk"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how serious a finding is.
+type Severity int
+
+const (
+	// Error is a problem that should be fixed.
+	Error Severity = iota
+
+	// Warning is a problem that should probably be fixed.
+	Warning
+
+	// Info is something worth knowing about, that may not be a problem.
+	Info
+
+	// Hint is a suggestion for improving the specification.
+	Hint
+)
+
+var severityNames = []string{"error", "warning", "info", "hint"}
+
+// String returns the name of the severity, like 'warning'.
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// MarshalText renders the severity by its name, when serialized to JSON or YAML.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Finding is a single problem reported by a rule.
+type Finding struct {
+	Rule     string     `json:"rule" yaml:"rule"` // the ID of the rule that reported the finding.
+	Severity Severity   `json:"severity" yaml:"severity"`
+	Message  string     `json:"message" yaml:"message"`
+	Pointer  string     `json:"pointer" yaml:"pointer"` // JSON pointer of the object, like '#/paths/~1pets/get'.
+	Line     int        `json:"line,omitempty" yaml:"line,omitempty"`
+	Column   int        `json:"column,omitempty" yaml:"column,omitempty"`
+	Node     *walk.Node `json:"-" yaml:"-"` // the object the finding was reported for, nil if there isn't one.
+}
+
+// Rule checks every object in a document, and reports findings about them.
+type Rule interface {
+	// ID is a stable identifier for the rule, like 'operation-operation-id'.
+	ID() string
+
+	// Visit is called for every object in the document, in the order they were defined.
+	Visit(node *walk.Node, ctx *Context)
+}
+
+// FinishingRule is a Rule that reports findings once every object in the document has been visited, like a rule
+// that reports tags that are never used.
+type FinishingRule interface {
+	Rule
+
+	// Finish is called once every object in the document has been visited.
+	Finish(ctx *Context)
+}
+
+// Context is given to a rule while it checks a document. Every rule has its own context for each run.
+type Context struct {
+	// Root is the high-level model being checked, like a *v3.Document.
+	Root any
+
+	// State is free for the rule to use, it's kept for the whole run.
+	State any
+
+	rule     Rule
+	findings *[]*Finding
+}
+
+// Report adds a finding for an object, made by the rule. The node can be nil for findings that aren't about a
+// single object.
+func (c *Context) Report(node *walk.Node, severity Severity, message string, args ...any) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	f := &Finding{Rule: c.rule.ID(), Severity: severity, Message: message, Pointer: "#", Node: node}
+	if node != nil {
+		f.Pointer = "#" + node.JSONPointer()
+		if n := nodePosition(node); n != nil {
+			f.Line, f.Column = n.Line, n.Column
+		}
+	}
+	*c.findings = append(*c.findings, f)
+}
+
+// nodePosition returns the yaml node that locates an object, the key if there is one.
+func nodePosition(node *walk.Node) *yaml.Node {
+	if node.KeyNode != nil {
+		return node.KeyNode
+	}
+	return node.ValueNode
+}
+
+// Runner executes rules against documents.
+type Runner struct {
+	rules []Rule
+}
+
+// NewRunner creates a Runner that executes the supplied rules.
+func NewRunner(rules ...Rule) *Runner {
+	return &Runner{rules: rules}
+}
+
+// Register adds rules to the runner.
+func (r *Runner) Register(rules ...Rule) {
+	r.rules = append(r.rules, rules...)
+}
+
+// Rules returns the rules registered with the runner.
+func (r *Runner) Rules() []Rule {
+	return r.rules
+}
+
+// Run executes every rule against a high-level model (like a *v3.Document or *v2.Swagger), walking the model
+// once. Findings are ordered by their position in the document, findings without a position come last.
+func (r *Runner) Run(root any) []*Finding {
+	var findings []*Finding
+	contexts := make([]*Context, len(r.rules))
+	for i, rule := range r.rules {
+		contexts[i] = &Context{Root: root, rule: rule, findings: &findings}
+	}
+	walk.Walk(root, func(node *walk.Node) walk.Action {
+		for i, rule := range r.rules {
+			rule.Visit(node, contexts[i])
+		}
+		return walk.Continue
+	})
+	for i, rule := range r.rules {
+		if f, ok := rule.(FinishingRule); ok {
+			f.Finish(contexts[i])
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if (a.Line == 0) != (b.Line == 0) {
+			return b.Line == 0
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/walk"
+	"github.com/stretchr/testify/assert"
+)
+
+var lintSpec = `openapi: 3.1.0
+info:
+  title: lint
+  version: 1.0.0
+tags:
+  - name: pizza
+  - name: burger
+paths:
+  /pizza:
+    get:
+      operationId: getPizza
+      description: get a pizza
+      tags: [pizza]
+    post:
+      summary: make a pizza
+      tags: [pizza]
+  /burger:
+    get:
+      operationId: getBurger`
+
+func buildLintModel(t *testing.T, spec string) *v3.Document {
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	return &m.Model
+}
+
+func TestRunner_DefaultRules(t *testing.T) {
+	findings := NewRunner(DefaultRules()...).Run(buildLintModel(t, lintSpec))
+	assert.Len(t, findings, 3)
+
+	assert.Equal(t, "unused-tag", findings[0].Rule)
+	assert.Equal(t, Info, findings[0].Severity)
+	assert.Equal(t, "the 'burger' tag is not used by any operation", findings[0].Message)
+	assert.Equal(t, "#/tags/1", findings[0].Pointer)
+	assert.Equal(t, 7, findings[0].Line)
+
+	assert.Equal(t, "operation-operation-id", findings[1].Rule)
+	assert.Equal(t, "#/paths/~1pizza/post", findings[1].Pointer)
+	assert.Equal(t, 14, findings[1].Line)
+
+	assert.Equal(t, "operation-description", findings[2].Rule)
+	assert.Equal(t, Warning, findings[2].Severity)
+	assert.Equal(t, "the 'get' operation has no description or summary", findings[2].Message)
+	assert.Equal(t, "#/paths/~1burger/get", findings[2].Pointer)
+
+	b, err := json.Marshal(findings[2])
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"severity":"warning"`)
+}
+
+type infoRule struct{}
+
+func (r *infoRule) ID() string {
+	return "info-title"
+}
+
+func (r *infoRule) Visit(node *walk.Node, ctx *Context) {
+	if _, ok := ctx.Root.(*v3.Document); ok && node.Depth == 0 {
+		ctx.Report(nil, Hint, "the document has %d paths", len(ctx.Root.(*v3.Document).Paths.GetPathItems()))
+	}
+}
+
+func TestRunner_Register(t *testing.T) {
+	runner := NewRunner()
+	runner.Register(&infoRule{})
+	assert.Len(t, runner.Rules(), 1)
+
+	findings := runner.Run(buildLintModel(t, lintSpec))
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "the document has 2 paths", findings[0].Message)
+	assert.Equal(t, "#", findings[0].Pointer)
+	assert.Equal(t, "hint", findings[0].Severity.String())
+	assert.Equal(t, "Severity(9)", Severity(9).String())
+}
+
+func TestRunner_Swagger(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(`swagger: 2.0
+tags:
+  - name: pizza
+paths:
+  /pizza:
+    get:
+      tags: [pizza]
+      description: get a pizza`))
+	m, _ := doc.BuildV2Model()
+	findings := NewRunner(DefaultRules()...).Run(&m.Model)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "operation-operation-id", findings[0].Rule)
+}
+
+func TestLinkRule(t *testing.T) {
+	m := buildLintModel(t, `openapi: 3.1.0
+paths:
+  /pizza/{id}:
+    get:
+      operationId: getPizza
+      responses:
+        "200":
+          description: ok
+          links:
+            self:
+              operationId: getPizza
+              parameters:
+                id: $request.path.id
+            topping:
+              operationId: getTopping
+            eat:
+              operationRef: '#/paths/~1pizza~1{id}/get'
+              parameters:
+                id: $response.body#id`)
+	findings := NewRunner(&LinkRule{}).Run(m)
+	assert.Len(t, findings, 2)
+	assert.Equal(t, "link-operation", findings[0].Rule)
+	assert.Equal(t, Error, findings[0].Severity)
+	assert.Equal(t, "link operationId 'getTopping' does not exist", findings[0].Message)
+	assert.Equal(t, "#/paths/~1pizza~1{id}/get/responses/200/links/topping", findings[0].Pointer)
+	assert.Contains(t, findings[1].Message, "link parameter 'id' is invalid")
+}
+
+func TestHeaderRule(t *testing.T) {
+	m := buildLintModel(t, `openapi: 3.1.0
+components:
+  headers:
+    RateLimit:
+      name: X-Rate-Limit
+      in: header
+      schema:
+        type: integer
+    Fine:
+      schema:
+        type: string`)
+	findings := NewRunner(&HeaderRule{}).Run(m)
+	assert.Len(t, findings, 2)
+	assert.Equal(t, "header-fields", findings[0].Rule)
+	assert.Equal(t, "#/components/headers/RateLimit", findings[0].Pointer)
+	assert.Equal(t, "the 'name' field is not allowed in a header (line 5, column 7)", findings[0].Message)
+	assert.Contains(t, findings[1].Message, "the 'in' field is not allowed")
+}
+
+func TestParameterRule(t *testing.T) {
+	m := buildLintModel(t, `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      parameters:
+        - name: size
+          in: query
+          schema:
+            type: string
+        - name: filter
+          in: query
+          schema:
+            type: object
+          content:
+            application/json:
+              schema:
+                type: object`)
+	findings := NewRunner(&ParameterRule{}).Run(m)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "parameter-schema", findings[0].Rule)
+	assert.Equal(t, "#/paths/~1pizza/get/parameters/1", findings[0].Pointer)
+	assert.Equal(t, "parameter has both a schema and content, only one is allowed", findings[0].Message)
+}
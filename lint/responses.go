@@ -0,0 +1,290 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"mime"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/walk"
+)
+
+// ResponseRules returns the rules that check the responses of operations, with their default configuration.
+func ResponseRules() []Rule {
+	return []Rule{
+		&SuccessResponseRule{},
+		&ErrorResponseRule{},
+		&EmptyResponseRule{},
+		&ResponseContentTypeRule{},
+	}
+}
+
+// operationResponses holds what the response rules need to know about OpenAPI and Swagger operations.
+type operationResponses struct {
+	codes          []string // every status code, including 'default'.
+	requiredParams bool
+	secured        bool
+}
+
+func asOperationResponses(node *walk.Node, root any) *operationResponses {
+	r := new(operationResponses)
+	var security []*base.SecurityRequirement
+	switch op := node.Value.(type) {
+	case *v3.Operation:
+		if op.Responses != nil {
+			r.codes = responseCodes(op.Responses.Codes, op.Responses.Default != nil)
+		}
+		params := [][]*v3.Parameter{op.Parameters}
+		if node.Parent != nil {
+			if pi, ok := node.Parent.Value.(*v3.PathItem); ok {
+				params = append(params, pi.Parameters)
+			}
+		}
+		for _, list := range params {
+			for _, p := range list {
+				r.requiredParams = r.requiredParams || (p != nil && p.Required)
+			}
+		}
+		if op.RequestBody != nil && op.RequestBody.Required != nil {
+			r.requiredParams = r.requiredParams || *op.RequestBody.Required
+		}
+		security = op.Security
+		if security == nil {
+			if doc, ok := root.(*v3.Document); ok {
+				security = doc.Security
+			}
+		}
+	case *v2.Operation:
+		if op.Responses != nil {
+			r.codes = responseCodes(op.Responses.Codes, op.Responses.Default != nil)
+		}
+		params := [][]*v2.Parameter{op.Parameters}
+		if node.Parent != nil {
+			if pi, ok := node.Parent.Value.(*v2.PathItem); ok {
+				params = append(params, pi.Parameters)
+			}
+		}
+		for _, list := range params {
+			for _, p := range list {
+				r.requiredParams = r.requiredParams || (p != nil && p.Required != nil && *p.Required)
+			}
+		}
+		security = op.Security
+		if security == nil {
+			if doc, ok := root.(*v2.Swagger); ok {
+				security = doc.Security
+			}
+		}
+	default:
+		return nil
+	}
+	for _, req := range security {
+		if req != nil && len(req.Requirements) > 0 {
+			r.secured = true
+		}
+	}
+	return r
+}
+
+func responseCodes[T any](codes map[string]T, hasDefault bool) []string {
+	found := make([]string, 0, len(codes)+1)
+	for code := range codes {
+		found = append(found, code)
+	}
+	sort.Strings(found)
+	if hasDefault {
+		found = append(found, "default")
+	}
+	return found
+}
+
+// hasResponseClass returns true if one of the codes is in one of the classes, like '2' for 2xx responses. Ranges
+// (like '2XX') count as well. The 'd' class is the default response.
+func hasResponseClass(codes []string, classes ...byte) bool {
+	for _, code := range codes {
+		for _, class := range classes {
+			if (len(code) == 3 && code[0] == class) || (class == 'd' && code == "default") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SuccessResponseRule reports operations without a successful (2xx or 3xx) response.
+type SuccessResponseRule struct {
+	// AllowDefault treats a 'default' response as a successful response.
+	AllowDefault bool
+}
+
+// ID returns 'operation-success-response'.
+func (r *SuccessResponseRule) ID() string {
+	return "operation-success-response"
+}
+
+// Visit checks operations for a successful response.
+func (r *SuccessResponseRule) Visit(node *walk.Node, ctx *Context) {
+	op := asOperationResponses(node, ctx.Root)
+	if op == nil || hasResponseClass(op.codes, '2', '3') {
+		return
+	}
+	if r.AllowDefault && hasResponseClass(op.codes, 'd') {
+		return
+	}
+	ctx.Report(node, Warning, "the '%s' operation has no successful (2xx or 3xx) response", node.Key)
+}
+
+// ErrorResponseRule reports operations that can fail because of the client, but do not describe any error (4xx,
+// 5xx or default) response. Operations can fail when they have required parameters (or a required request body), or
+// require security.
+type ErrorResponseRule struct {
+	// Always requires an error response for every operation.
+	Always bool
+}
+
+// ID returns 'operation-error-response'.
+func (r *ErrorResponseRule) ID() string {
+	return "operation-error-response"
+}
+
+// Visit checks operations for an error response.
+func (r *ErrorResponseRule) Visit(node *walk.Node, ctx *Context) {
+	op := asOperationResponses(node, ctx.Root)
+	if op == nil || hasResponseClass(op.codes, '4', '5', 'd') {
+		return
+	}
+	switch {
+	case op.secured:
+		ctx.Report(node, Warning, "the '%s' operation requires security, but has no error response", node.Key)
+	case op.requiredParams:
+		ctx.Report(node, Warning, "the '%s' operation has required parameters, but has no error response", node.Key)
+	case r.Always:
+		ctx.Report(node, Warning, "the '%s' operation has no error response", node.Key)
+	}
+}
+
+// EmptyResponseRule reports operations with no responses, and responses with nothing in them (no description,
+// content, schema or headers).
+type EmptyResponseRule struct{}
+
+// ID returns 'response-empty'.
+func (r *EmptyResponseRule) ID() string {
+	return "response-empty"
+}
+
+// Visit checks responses are not empty.
+func (r *EmptyResponseRule) Visit(node *walk.Node, ctx *Context) {
+	switch v := node.Value.(type) {
+	case *v3.Responses:
+		if len(v.Codes) == 0 && v.Default == nil && node.Parent != nil {
+			ctx.Report(node, Warning, "the '%s' operation has no responses", node.Parent.Key)
+		}
+	case *v2.Responses:
+		if len(v.Codes) == 0 && v.Default == nil && node.Parent != nil {
+			ctx.Report(node, Warning, "the '%s' operation has no responses", node.Parent.Key)
+		}
+	case *v3.Response:
+		if v.Description == "" && len(v.Content) == 0 && len(v.Headers) == 0 && len(v.Links) == 0 {
+			ctx.Report(node, Warning, "the '%s' response is empty", node.Key)
+		}
+	case *v2.Response:
+		if v.Description == "" && v.Schema == nil && len(v.Headers) == 0 && v.Examples == nil {
+			ctx.Report(node, Warning, "the '%s' response is empty", node.Key)
+		}
+	}
+}
+
+// ResponseContentTypeRule reports responses with content types that are not listed as produced. For Swagger
+// documents, the content types of examples are checked against 'produces' (of the operation, or the document).
+// OpenAPI documents have no equivalent, so content types are only checked when Allowed is set. Nothing is checked
+// when no content types are produced or allowed.
+type ResponseContentTypeRule struct {
+	// Allowed are the content types every response may use, on top of any 'produces'. Wildcards like
+	// 'application/*' are supported.
+	Allowed []string
+}
+
+// ID returns 'response-content-type'.
+func (r *ResponseContentTypeRule) ID() string {
+	return "response-content-type"
+}
+
+// Visit checks the content types of responses.
+func (r *ResponseContentTypeRule) Visit(node *walk.Node, ctx *Context) {
+	var types []string
+	var produces []string
+	switch v := node.Value.(type) {
+	case *v3.Response:
+		if len(r.Allowed) == 0 {
+			return
+		}
+		for t := range v.Content {
+			types = append(types, t)
+		}
+	case *v2.Response:
+		if v.Examples == nil {
+			return
+		}
+		for t := range v.Examples.Values {
+			types = append(types, t)
+		}
+		produces = swaggerProduces(node, ctx.Root)
+	default:
+		return
+	}
+	sort.Strings(types)
+	allowed := append(append([]string{}, produces...), r.Allowed...)
+	if len(allowed) == 0 {
+		return
+	}
+	for _, t := range types {
+		if !mediaTypeAllowed(t, allowed) {
+			ctx.Report(node, Warning, "the '%s' response has content of type '%s', which is not produced",
+				node.Key, t)
+		}
+	}
+}
+
+// swaggerProduces returns the content types produced by the operation a response belongs to, or the document.
+func swaggerProduces(node *walk.Node, root any) []string {
+	doc, _ := root.(*v2.Swagger)
+	for n := node.Parent; n != nil; n = n.Parent {
+		if op, ok := n.Value.(*v2.Operation); ok {
+			if doc != nil {
+				return doc.EffectiveProduces(op)
+			}
+			return op.Produces
+		}
+	}
+	if doc != nil {
+		return doc.Produces
+	}
+	return nil
+}
+
+// mediaTypeAllowed returns true if a media type matches one of the allowed types, ignoring any parameters (like
+// 'charset'). Allowed types can be wildcards, like 'application/*' or '*/*'.
+func mediaTypeAllowed(mediaType string, allowed []string) bool {
+	parse := func(t string) string {
+		if parsed, _, err := mime.ParseMediaType(t); err == nil {
+			return parsed
+		}
+		return strings.ToLower(strings.TrimSpace(t))
+	}
+	t := parse(mediaType)
+	for _, a := range allowed {
+		a = parse(a)
+		if a == t || a == "*/*" {
+			return true
+		}
+		if strings.HasSuffix(a, "/*") && strings.HasPrefix(t, strings.TrimSuffix(a, "*")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var responsesSpec = `openapi: 3.1.0
+info:
+  title: responses
+  version: 1.0.0
+paths:
+  /pizza/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json: {}
+            text/html: {}
+    delete:
+      security:
+        - key: []
+      responses:
+        '204': {}
+  /menu:
+    get:
+      responses:
+        default:
+          description: the menu, or an error
+    post:
+      responses: {}
+components:
+  securitySchemes:
+    key:
+      type: apiKey
+      in: header
+      name: key`
+
+func TestResponseRules(t *testing.T) {
+	findings := NewRunner(ResponseRules()...).Run(buildLintModel(t, responsesSpec))
+	messages := make([]string, len(findings))
+	for i, f := range findings {
+		messages[i] = f.Rule + ": " + f.Message
+	}
+	assert.Equal(t, []string{
+		"operation-error-response: the 'get' operation has required parameters, but has no error response",
+		"operation-error-response: the 'delete' operation requires security, but has no error response",
+		"response-empty: the '204' response is empty",
+		"operation-success-response: the 'get' operation has no successful (2xx or 3xx) response",
+		"operation-success-response: the 'post' operation has no successful (2xx or 3xx) response",
+		"response-empty: the 'post' operation has no responses",
+	}, messages)
+	assert.Equal(t, "#/paths/~1pizza~1{id}/delete/responses/204", findings[2].Pointer)
+}
+
+func TestResponseRules_Configured(t *testing.T) {
+	findings := NewRunner(
+		&SuccessResponseRule{AllowDefault: true},
+		&ErrorResponseRule{Always: true},
+		&ResponseContentTypeRule{Allowed: []string{"application/*"}},
+	).Run(buildLintModel(t, responsesSpec))
+	messages := make([]string, len(findings))
+	for i, f := range findings {
+		messages[i] = f.Rule + ": " + f.Message
+	}
+	assert.Equal(t, []string{
+		"operation-error-response: the 'get' operation has required parameters, but has no error response",
+		"response-content-type: the '200' response has content of type 'text/html', which is not produced",
+		"operation-error-response: the 'delete' operation requires security, but has no error response",
+		"operation-success-response: the 'post' operation has no successful (2xx or 3xx) response",
+		"operation-error-response: the 'post' operation has no error response",
+	}, messages)
+}
+
+func TestResponseRules_Swagger(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(`swagger: 2.0
+produces: [application/json]
+security:
+  - key: []
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          examples:
+            application/json; charset=utf-8: {}
+            application/xml: {}
+    post:
+      produces: [application/xml]
+      parameters:
+        - name: body
+          in: body
+          required: true
+      responses:
+        '201':
+          description: made a pizza
+          examples:
+            application/xml: {}
+        '400':
+          description: bad pizza
+securityDefinitions:
+  key:
+    type: apiKey
+    in: header
+    name: key`))
+	m, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+
+	findings := NewRunner(ResponseRules()...).Run(&m.Model)
+	assert.Len(t, findings, 2)
+	assert.Equal(t, "the 'get' operation requires security, but has no error response", findings[0].Message)
+	assert.Equal(t, "the '200' response has content of type 'application/xml', which is not produced",
+		findings[1].Message)
+}
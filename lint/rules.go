@@ -0,0 +1,171 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/walk"
+)
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		&OperationIdRule{},
+		&OperationDescriptionRule{},
+		&UnusedTagsRule{},
+		&LinkRule{},
+		&HeaderRule{},
+		&ParameterRule{},
+	}
+}
+
+// operation holds the properties shared by OpenAPI and Swagger operations.
+type operation struct {
+	operationId, summary, description string
+	tags                              []string
+}
+
+func asOperation(value any) *operation {
+	switch op := value.(type) {
+	case *v3.Operation:
+		return &operation{op.OperationId, op.Summary, op.Description, op.Tags}
+	case *v2.Operation:
+		return &operation{op.OperationId, op.Summary, op.Description, op.Tags}
+	}
+	return nil
+}
+
+// OperationIdRule reports operations without an operationId, which code generators need to name methods.
+type OperationIdRule struct{}
+
+// ID returns 'operation-operation-id'.
+func (r *OperationIdRule) ID() string {
+	return "operation-operation-id"
+}
+
+// Visit checks operations for an operationId.
+func (r *OperationIdRule) Visit(node *walk.Node, ctx *Context) {
+	if op := asOperation(node.Value); op != nil && op.operationId == "" {
+		ctx.Report(node, Warning, "the '%s' operation has no operationId", node.Key)
+	}
+}
+
+// OperationDescriptionRule reports operations without a description or a summary.
+type OperationDescriptionRule struct{}
+
+// ID returns 'operation-description'.
+func (r *OperationDescriptionRule) ID() string {
+	return "operation-description"
+}
+
+// Visit checks operations for a description or summary.
+func (r *OperationDescriptionRule) Visit(node *walk.Node, ctx *Context) {
+	if op := asOperation(node.Value); op != nil && op.description == "" && op.summary == "" {
+		ctx.Report(node, Warning, "the '%s' operation has no description or summary", node.Key)
+	}
+}
+
+// UnusedTagsRule reports tags that are defined by the document, but not used by any operation.
+type UnusedTagsRule struct{}
+
+// ID returns 'unused-tag'.
+func (r *UnusedTagsRule) ID() string {
+	return "unused-tag"
+}
+
+type tagUsage struct {
+	defined []*walk.Node
+	used    map[string]bool
+}
+
+// Visit collects the tags that are defined, and the tags used by operations.
+func (r *UnusedTagsRule) Visit(node *walk.Node, ctx *Context) {
+	if ctx.State == nil {
+		ctx.State = &tagUsage{used: make(map[string]bool)}
+	}
+	usage := ctx.State.(*tagUsage)
+	if _, ok := node.Value.(*base.Tag); ok && len(node.Path) == 2 && node.Path[0] == "tags" {
+		usage.defined = append(usage.defined, node)
+	}
+	if op := asOperation(node.Value); op != nil {
+		for _, tag := range op.tags {
+			usage.used[tag] = true
+		}
+	}
+}
+
+// Finish reports the tags that were never used.
+func (r *UnusedTagsRule) Finish(ctx *Context) {
+	usage, ok := ctx.State.(*tagUsage)
+	if !ok {
+		return
+	}
+	for _, node := range usage.defined {
+		name := node.Value.(*base.Tag).Name
+		if !usage.used[name] {
+			ctx.Report(node, Info, "the '%s' tag is not used by any operation", name)
+		}
+	}
+}
+
+// LinkRule reports links to operations that don't exist, and link parameters (or request bodies) written as runtime
+// expressions that are not valid ones.
+type LinkRule struct{}
+
+// ID returns 'link-operation'.
+func (r *LinkRule) ID() string {
+	return "link-operation"
+}
+
+// Visit checks links against the operations of the document.
+func (r *LinkRule) Visit(node *walk.Node, ctx *Context) {
+	link, ok := node.Value.(*v3.Link)
+	if !ok {
+		return
+	}
+	var operations *v3.OperationIndex
+	if doc, ok := ctx.Root.(*v3.Document); ok {
+		operations = doc.GetOperationIndex()
+	}
+	for _, err := range link.Validate(operations) {
+		ctx.Report(node, Error, "%s", err.Error())
+	}
+}
+
+// HeaderRule reports headers that are not valid, like headers with a 'name' or 'in' (which are only allowed in
+// parameters).
+type HeaderRule struct{}
+
+// ID returns 'header-fields'.
+func (r *HeaderRule) ID() string {
+	return "header-fields"
+}
+
+// Visit checks headers are valid.
+func (r *HeaderRule) Visit(node *walk.Node, ctx *Context) {
+	if header, ok := node.Value.(*v3.Header); ok {
+		for _, err := range header.Validate() {
+			ctx.Report(node, Error, "%s", err.Error())
+		}
+	}
+}
+
+// ParameterRule reports parameters that are not valid, like parameters with both a schema and content.
+type ParameterRule struct{}
+
+// ID returns 'parameter-schema'.
+func (r *ParameterRule) ID() string {
+	return "parameter-schema"
+}
+
+// Visit checks parameters are valid.
+func (r *ParameterRule) Visit(node *walk.Node, ctx *Context) {
+	if param, ok := node.Value.(*v3.Parameter); ok {
+		for _, err := range param.Validate() {
+			ctx.Report(node, Error, "%s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentSelector picks a document out of a YAML stream that contains more than one document (see
+// NewDocumentFromStream). It's called with the position of each document in the stream and its parsed root node,
+// until it returns true.
+type DocumentSelector func(index int, root *yaml.Node) bool
+
+// SelectDocumentAt selects the document at a position in a YAML stream, the first document is at zero.
+func SelectDocumentAt(position int) DocumentSelector {
+	return func(index int, _ *yaml.Node) bool {
+		return index == position
+	}
+}
+
+// SelectSpecification selects the first document in a YAML stream that is an OpenAPI, Swagger or AsyncAPI
+// specification, skipping anything else in the stream (like overlays, or other kinds of resources).
+func SelectSpecification() DocumentSelector {
+	return func(_ int, root *yaml.Node) bool {
+		for _, key := range []string{utils.OpenApi3, utils.OpenApi2, utils.AsyncApi} {
+			if _, v := utils.FindKeyNode(key, root.Content); v != nil {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NewDocumentFromStream creates a new Document from a single document in a YAML stream that contains more than one
+// document, separated by '---' (like a specification bundled with an overlay). The document used is the first one
+// the selector returns true for. Line numbers in the document are relative to the start of the document in the
+// stream (see datamodel.SplitYAMLDocuments).
+//
+// NewDocument only reads the first document of a stream.
+func NewDocumentFromStream(spec []byte, selector DocumentSelector,
+	configuration *datamodel.DocumentConfiguration) (Document, error) {
+	documents := datamodel.SplitYAMLDocuments(spec)
+	for i, document := range documents {
+		var root yaml.Node
+		if err := yaml.Unmarshal(document, &root); err != nil {
+			return nil, fmt.Errorf("unable to parse document %d in stream: %w", i, err)
+		}
+		if selector(i, &root) {
+			return NewDocumentWithConfiguration(document, configuration)
+		}
+	}
+	return nil, fmt.Errorf("no document selected, out of %d documents in stream", len(documents))
+}
+
+// NewDocumentsFromStream creates a new Document for every document in a YAML stream, separated by '---', in the
+// order they appear in the stream. Every document must be a specification, unless the document check is bypassed
+// by the configuration (see datamodel.DocumentConfiguration.BypassDocumentCheck).
+func NewDocumentsFromStream(spec []byte, configuration *datamodel.DocumentConfiguration) ([]Document, error) {
+	documents := datamodel.SplitYAMLDocuments(spec)
+	docs := make([]Document, 0, len(documents))
+	for i, document := range documents {
+		doc, err := NewDocumentWithConfiguration(document, configuration)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create document %d in stream: %w", i, err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
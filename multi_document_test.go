@@ -0,0 +1,67 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var testStream = `overlay: 1.0.0
+info:
+  title: pizza overlay
+---
+openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+---
+swagger: "2.0"
+info:
+  title: burger
+`
+
+func TestNewDocumentFromStream(t *testing.T) {
+	doc, err := NewDocumentFromStream([]byte(testStream), SelectSpecification(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Equal(t, "pizza", model.Model.Info.Title)
+	assert.Equal(t, 4, model.Model.GoLow().Info.ValueNode.Content[1].Line) // the document starts at its marker.
+
+	doc, err = NewDocumentFromStream([]byte(testStream), SelectDocumentAt(2), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0", doc.GetVersion())
+
+	doc, err = NewDocumentFromStream([]byte(testStream), func(index int, root *yaml.Node) bool {
+		return root.Content[0].Content[0].Value == "overlay"
+	}, &datamodel.DocumentConfiguration{BypassDocumentCheck: true})
+	assert.NoError(t, err)
+	assert.Contains(t, string(*doc.GetSpecInfo().SpecBytes), "pizza overlay")
+
+	_, err = NewDocumentFromStream([]byte(testStream), SelectDocumentAt(3), nil)
+	assert.EqualError(t, err, "no document selected, out of 3 documents in stream")
+
+	_, err = NewDocumentFromStream([]byte("openapi: 3.1.0\n---\n{{{"), SelectDocumentAt(1), nil)
+	assert.Error(t, err)
+}
+
+func TestNewDocumentsFromStream(t *testing.T) {
+	_, err := NewDocumentsFromStream([]byte(testStream), nil)
+	assert.Error(t, err)
+
+	docs, err := NewDocumentsFromStream([]byte(testStream), &datamodel.DocumentConfiguration{BypassDocumentCheck: true})
+	assert.NoError(t, err)
+	assert.Len(t, docs, 3)
+
+	docs, err = NewDocumentsFromStream([]byte(testStream[len("overlay: 1.0.0\ninfo:\n  title: pizza overlay\n"):]), nil)
+	assert.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, "3.1.0", docs[0].GetVersion())
+	assert.Equal(t, "2.0", docs[1].GetVersion())
+}
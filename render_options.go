@@ -0,0 +1,53 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+)
+
+// RenderOption changes how Document.RenderWithOptions formats the rendered model.
+type RenderOption func(options *high.RenderOptions)
+
+// AsJSON will render the model as JSON.
+func AsJSON() RenderOption {
+	return func(options *high.RenderOptions) {
+		options.Format = high.RenderJSON
+	}
+}
+
+// AsYAML will render the model as YAML.
+func AsYAML() RenderOption {
+	return func(options *high.RenderOptions) {
+		options.Format = high.RenderYAML
+	}
+}
+
+// WithIndent will indent each level of the rendered model by a number of spaces. Zero renders compact JSON.
+func WithIndent(spaces int) RenderOption {
+	return func(options *high.RenderOptions) {
+		options.Indent = spaces
+	}
+}
+
+// WithLineWidth will wrap long strings at width when rendering YAML (see high.RenderOptions.LineWidth).
+func WithLineWidth(width int) RenderOption {
+	return func(options *high.RenderOptions) {
+		options.LineWidth = width
+	}
+}
+
+// WithQuoteStyle will quote string values using style when rendering YAML.
+func WithQuoteStyle(style high.QuoteStyle) RenderOption {
+	return func(options *high.RenderOptions) {
+		options.QuoteStyle = style
+	}
+}
+
+// WithKeyQuoteStyle will quote keys using style when rendering YAML.
+func WithKeyQuoteStyle(style high.QuoteStyle) RenderOption {
+	return func(options *high.RenderOptions) {
+		options.KeyQuoteStyle = style
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_RenderWithOptions(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: 'pizza'
+  description: a spec about pizza, which is long enough to wrap
+  version: "1.0"`
+
+	doc, _ := NewDocument([]byte(spec))
+	_, err := doc.RenderWithOptions()
+	assert.Error(t, err)
+
+	_, _ = doc.BuildV3Model()
+
+	// without options, the document is rendered as it was written.
+	rendered, err := doc.RenderWithOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, spec+"\n", string(rendered))
+
+	rendered, err = doc.RenderWithOptions(WithIndent(4), WithLineWidth(40), WithQuoteStyle(high.QuoteDouble),
+		WithKeyQuoteStyle(high.QuoteSingle))
+	assert.NoError(t, err)
+	assert.Equal(t, `'openapi': "3.1.0"
+'info':
+    'title': "pizza"
+    'description': >-
+        a spec about pizza, which is
+        long enough to wrap
+    'version': "1.0"
+`, string(rendered))
+
+	rendered, err = doc.RenderWithOptions(AsJSON())
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "openapi": "3.1.0",
+  "info": {
+    "title": "pizza",
+    "description": "a spec about pizza, which is long enough to wrap",
+    "version": "1.0"
+  }
+}`, string(rendered))
+
+	// JSON documents are rendered as JSON, unless asked for YAML.
+	doc, _ = NewDocument([]byte(`{"openapi": "3.1.0", "info": {"title": "pizza"}}`))
+	_, _ = doc.BuildV3Model()
+	rendered, err = doc.RenderWithOptions(WithIndent(0))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.1.0","info":{"title":"pizza"}}`, string(rendered))
+	rendered, err = doc.RenderWithOptions(AsYAML(), WithIndent(2), WithQuoteStyle(high.QuoteMinimal))
+	assert.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0\ninfo:\n  title: pizza\n", string(rendered))
+
+	swagger, _ := NewDocument([]byte("swagger: 2.0"))
+	_, _ = swagger.BuildV2Model()
+	_, err = swagger.RenderWithOptions()
+	assert.Error(t, err)
+}
+
+func TestDocument_RenderPreservingFormat(t *testing.T) {
+	spec := `openapi: 3.1.0
+# all about pizza
+info:
+  title:   'pizza'   # the name
+  version: "1.0"
+paths:
+  /pizza:
+    get:
+      operationId: getPizza   # keep this
+      description: "it's pizza"
+    post:
+      operationId: makePizza
+`
+	doc, _ := NewDocument([]byte(spec))
+	_, err := doc.RenderPreservingFormat()
+	assert.Error(t, err)
+
+	m, _ := doc.BuildV3Model()
+	rendered, err := doc.RenderPreservingFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, spec, string(rendered))
+
+	m.Model.Paths.PathItems["/pizza"].Post.OperationId = "bakePizza"
+	rendered, err = doc.RenderPreservingFormat()
+	assert.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+# all about pizza
+info:
+  title:   'pizza'   # the name
+  version: "1.0"
+paths:
+  /pizza:
+    get:
+      operationId: getPizza   # keep this
+      description: "it's pizza"
+    post:
+      operationId: bakePizza
+`, string(rendered))
+
+	// JSON specifications are rendered in full.
+	doc, _ = NewDocument([]byte(`{"openapi": "3.1.0", "info": {"title": "pizza"}}`))
+	_, _ = doc.BuildV3Model()
+	rendered, err = doc.RenderPreservingFormat(WithIndent(0))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.1.0","info":{"title":"pizza"}}`, string(rendered))
+}
+
+func TestDocument_RenderPreservingFormat_RoundTrip(t *testing.T) {
+	for _, spec := range []string{"stripe.yaml", "single-definition.yaml", "burgershop.openapi.yaml"} {
+		t.Run(spec, func(t *testing.T) {
+			source, _ := os.ReadFile("test_specs/" + spec)
+			doc, _ := NewDocument(source)
+			_, _ = doc.BuildV3Model() // stripe has circular references, they are reported but still built.
+
+			rendered, err := doc.RenderPreservingFormat()
+			assert.NoError(t, err)
+			if spec != "burgershop.openapi.yaml" {
+				assert.Equal(t, string(source), string(rendered))
+				return
+			}
+			// the burgershop model reads the example of the items of an array schema into the array schema, on
+			// line 477, everything above it is kept as it is.
+			lines := strings.SplitAfterN(string(rendered), "\n", 477)
+			assert.True(t, strings.HasPrefix(string(source), strings.Join(lines[:476], "")))
+		})
+	}
+}
+
+func TestDocument_RenderWithOptions_Encoding(t *testing.T) {
+	spec := "openapi: 3.1.0\ninfo:\n  title: pizza\n"
+	doc, err := NewDocument(datamodel.UTF16BE.Encode([]byte(spec)))
+	assert.NoError(t, err)
+	assert.Equal(t, datamodel.UTF16BE, doc.GetSpecInfo().Encoding)
+
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Equal(t, "pizza", model.Model.Info.Title)
+
+	rendered, err := doc.RenderWithOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, datamodel.UTF16BE.Encode([]byte(spec)), rendered)
+}
@@ -0,0 +1,227 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// circularRefStubExtension marks the stubs that replace circular references, when using the CircularReferencesStub
+// policy. Its value is the definition the stub replaced.
+const circularRefStubExtension = "x-circular-ref"
+
+func (resolver *Resolver) circularReferencePolicy() (index.CircularReferencePolicy, int) {
+	if c := resolver.specIndex.GetConfig(); c != nil {
+		return c.CircularReferencePolicy, c.CircularReferenceStubDepth
+	}
+	return index.CircularReferencesAllow, 0
+}
+
+// classifyCircularReference decides how a loop ends, by looking at how each definition in the loop references the
+// next one.
+func classifyCircularReference(c *index.CircularReferenceResult) index.CircularReferenceType {
+	if c.IsInfiniteLoop {
+		return index.CircularInfinite
+	}
+	class := index.CircularOptional
+	if c.IsPolymorphicResult {
+		class = index.CircularPolymorphic
+	}
+	if len(c.Journey) < 2 || c.Journey[len(c.Journey)-1] == nil {
+		return class
+	}
+
+	// the loop starts where the last definition was first seen.
+	last := len(c.Journey) - 1
+	start := last
+	for k := 0; k < last; k++ {
+		if c.Journey[k] != nil && c.Journey[k].Definition == c.Journey[last].Definition {
+			start = k
+			break
+		}
+	}
+	for k := start; k < last; k++ {
+		if c.Journey[k] == nil || c.Journey[k+1] == nil {
+			continue
+		}
+		array, nullable := classifyCircularHop(c.Journey[k].Node, c.Journey[k+1].Definition, false, false)
+		if array {
+			return index.CircularArray
+		}
+		if nullable {
+			class = index.CircularNullable
+		}
+	}
+	return class
+}
+
+// classifyCircularHop looks for references to a definition in a node, and returns true if any of them are
+// inside an array, or a nullable schema.
+func classifyCircularHop(node *yaml.Node, definition string, array, nullable bool) (bool, bool) {
+	if node == nil {
+		return false, false
+	}
+	var foundArray, foundNullable bool
+	switch node.Kind {
+	case yaml.MappingNode:
+		nullable = nullable || isNullableSchema(node)
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if k.Value == "$ref" && v.Value == definition {
+				foundArray, foundNullable = foundArray || array, foundNullable || nullable
+				continue
+			}
+			childArray := array || k.Value == "items" || k.Value == "prefixItems"
+			childNullable := nullable || ((k.Value == "oneOf" || k.Value == "anyOf") && hasNullSchema(v))
+			a, n := classifyCircularHop(v, definition, childArray, childNullable)
+			foundArray, foundNullable = foundArray || a, foundNullable || n
+		}
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			a, nl := classifyCircularHop(n, definition, array, nullable)
+			foundArray, foundNullable = foundArray || a, foundNullable || nl
+		}
+	}
+	return foundArray, foundNullable
+}
+
+// isNullableSchema returns true if a schema is marked nullable (3.0), or has a 'null' type (3.1).
+func isNullableSchema(node *yaml.Node) bool {
+	_, n := utils.FindKeyNodeTop("nullable", node.Content)
+	if n != nil && n.Value == "true" {
+		return true
+	}
+	_, t := utils.FindKeyNodeTop("type", node.Content)
+	if t == nil {
+		return false
+	}
+	if t.Value == "null" {
+		return true
+	}
+	for _, v := range t.Content {
+		if v.Value == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNullSchema returns true if one of a list of (oneOf or anyOf) schemas is nullable.
+func hasNullSchema(node *yaml.Node) bool {
+	if !utils.IsNodeArray(node) {
+		return false
+	}
+	for _, n := range node.Content {
+		if utils.IsNodeMap(n) && isNullableSchema(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// circularReferenceError returns an error for a circular reference, if the policy does not allow it. Infinite
+// circular references are never allowed.
+func (resolver *Resolver) circularReferenceError(c *index.CircularReferenceResult) *ResolvingError {
+	policy, _ := resolver.circularReferencePolicy()
+	var err error
+	switch {
+	case c.IsInfiniteLoop:
+		err = fmt.Errorf("Infinite circular reference detected: %s", c.Start.Name)
+	case policy == index.CircularReferencesError:
+		err = fmt.Errorf("circular reference detected (%s): %s", c.Classification, c.Start.Name)
+	case policy == index.CircularReferencesAllowValidRecursion && !c.IsValidRecursion():
+		err = fmt.Errorf("circular reference detected (%s), it does not loop through an array or a nullable "+
+			"schema: %s", c.Classification, c.Start.Name)
+	default:
+		return nil
+	}
+	return &ResolvingError{
+		ErrorRef:          err,
+		Node:              c.LoopPoint.Node,
+		Path:              c.GenerateJourneyPath(),
+		CircularReference: c,
+	}
+}
+
+// stubCircularReferences replaces every loop found when resolving with a copy of the definition it points to,
+// expanded to the stub depth and ending with a stub. References to circular definitions outside of loops are
+// expanded the same way, so the resolved tree is complete, and finite.
+func (resolver *Resolver) stubCircularReferences(depth int) {
+	points := make(map[*yaml.Node]string)
+	var order []*yaml.Node
+	add := func(n *yaml.Node, definition string) {
+		if _, ok := points[n]; n != nil && !ok {
+			points[n] = definition
+			order = append(order, n)
+		}
+	}
+	for _, lp := range resolver.loopPoints {
+		add(lp.Node, lp.Definition)
+	}
+	collectCircularReferences(resolver.specIndex, add)
+
+	// expand everything before replacing anything, so every copy is made from the same tree.
+	expanded := make([][]*yaml.Node, len(order))
+	for i, n := range order {
+		expanded[i] = resolver.expandCircularReference(points[n], depth, points)
+	}
+	for i, n := range order {
+		n.Content = expanded[i]
+	}
+}
+
+func collectCircularReferences(idx *index.SpecIndex, add func(n *yaml.Node, definition string)) {
+	mappedIndex := idx.GetMappedReferences()
+	for _, sequenced := range idx.GetAllSequencedReferences() {
+		if locatedDef := mappedIndex[sequenced.Definition]; locatedDef != nil && locatedDef.Circular {
+			add(sequenced.Node, sequenced.Definition)
+		}
+	}
+	for _, c := range idx.GetChildren() {
+		collectCircularReferences(c, add)
+	}
+}
+
+// expandCircularReference returns a copy of the resolved content of a definition, with the circular references
+// inside it expanded again, until the depth runs out and they're replaced with a stub.
+func (resolver *Resolver) expandCircularReference(definition string, depth int,
+	points map[*yaml.Node]string) []*yaml.Node {
+	if depth <= 0 {
+		return circularStub(definition)
+	}
+	refs := resolver.specIndex.SearchIndexForReference(definition)
+	if len(refs) == 0 || refs[0].Node == nil {
+		return circularStub(definition)
+	}
+	return resolver.copyCircularNode(refs[0].Node, depth, points).Content
+}
+
+func (resolver *Resolver) copyCircularNode(node *yaml.Node, depth int, points map[*yaml.Node]string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	c := *node
+	if definition, ok := points[node]; ok {
+		c.Content = resolver.expandCircularReference(definition, depth-1, points)
+		return &c
+	}
+	if len(node.Content) > 0 {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i, n := range node.Content {
+			c.Content[i] = resolver.copyCircularNode(n, depth, points)
+		}
+	}
+	return &c
+}
+
+func circularStub(definition string) []*yaml.Node {
+	return []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: circularRefStubExtension},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: definition},
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var circularPolicySpec = `openapi: 3.1.0
+components:
+  schemas:
+    Tree:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tree'
+    LinkedList:
+      type: object
+      properties:
+        next:
+          anyOf:
+            - $ref: '#/components/schemas/LinkedList'
+            - type: 'null'
+    Person:
+      type: object
+      properties:
+        friend:
+          $ref: '#/components/schemas/Person'`
+
+func circularPolicyResolver(policy index.CircularReferencePolicy, depth int) *Resolver {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(circularPolicySpec), &rootNode)
+	c := index.CreateOpenAPIIndexConfig()
+	c.CircularReferencePolicy = policy
+	c.CircularReferenceStubDepth = depth
+	return NewResolver(index.NewSpecIndexWithConfig(&rootNode, c))
+}
+
+func TestResolver_CircularReferenceClassification(t *testing.T) {
+	resolver := circularPolicyResolver(index.CircularReferencesAllow, 0)
+	assert.Empty(t, resolver.CheckForCircularReferences())
+
+	classes := make(map[string]index.CircularReferenceType)
+	for _, c := range resolver.GetCircularErrors() {
+		classes[c.Start.Name] = c.Classification
+		assert.Equal(t, c.Start.Name+" -> "+c.Start.Name, c.GenerateJourneyPath())
+	}
+	assert.Len(t, classes, 3)
+	assert.Equal(t, index.CircularArray, classes["Tree"])
+	assert.Equal(t, index.CircularNullable, classes["LinkedList"])
+	assert.Equal(t, index.CircularOptional, classes["Person"])
+	assert.Equal(t, "nullable", classes["LinkedList"].String())
+}
+
+func TestResolver_CircularReferencesError(t *testing.T) {
+	resolver := circularPolicyResolver(index.CircularReferencesError, 0)
+	errs := resolver.CheckForCircularReferences()
+	assert.Len(t, errs, 3)
+	for _, e := range errs {
+		assert.NotNil(t, e.CircularReference)
+		assert.Contains(t, e.Error(), "circular reference detected")
+	}
+}
+
+func TestResolver_CircularReferencesAllowValidRecursion(t *testing.T) {
+	resolver := circularPolicyResolver(index.CircularReferencesAllowValidRecursion, 0)
+	errs := resolver.CheckForCircularReferences()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "Person", errs[0].CircularReference.Start.Name)
+	assert.Contains(t, errs[0].Error(), "circular reference detected (optional)")
+}
+
+func TestResolver_CircularReferencesStub(t *testing.T) {
+	resolver := circularPolicyResolver(index.CircularReferencesStub, 1)
+	assert.Empty(t, resolver.Resolve())
+
+	var resolved map[string]any
+	assert.NoError(t, resolver.resolvedRoot.Decode(&resolved))
+	schemas := resolved["components"].(map[string]any)["schemas"].(map[string]any)
+
+	// one level of the tree is expanded, then stubbed.
+	items := schemas["Tree"].(map[string]any)["properties"].(map[string]any)["children"].(map[string]any)["items"]
+	expanded := items.(map[string]any)
+	assert.Equal(t, "object", expanded["type"])
+	stub := expanded["properties"].(map[string]any)["children"].(map[string]any)["items"].(map[string]any)
+	assert.Equal(t, map[string]any{"x-circular-ref": "#/components/schemas/Tree"}, stub)
+
+	friend := schemas["Person"].(map[string]any)["properties"].(map[string]any)["friend"].(map[string]any)
+	assert.Equal(t, "object", friend["type"])
+	assert.NotContains(t, friend, "$ref")
+}
+
+func TestResolver_CircularReferencesStub_NoDepth(t *testing.T) {
+	resolver := circularPolicyResolver(index.CircularReferencesStub, 0)
+	assert.Empty(t, resolver.Resolve())
+
+	var resolved map[string]any
+	assert.NoError(t, resolver.resolvedRoot.Decode(&resolved))
+	person := resolved["components"].(map[string]any)["schemas"].(map[string]any)["Person"].(map[string]any)
+	assert.Equal(t, map[string]any{"x-circular-ref": "#/components/schemas/Person"},
+		person["properties"].(map[string]any)["friend"])
+}
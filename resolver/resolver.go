@@ -5,6 +5,7 @@ package resolver
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
@@ -42,6 +43,7 @@ type Resolver struct {
 	indexesVisited     int
 	journeysTaken      int
 	relativesSeen      int
+	loopPoints         []*index.Reference // references that close a loop, only collected when stubbing.
 }
 
 // NewResolver will create a new resolver from a *index.SpecIndex
@@ -121,46 +123,47 @@ func (resolver *Resolver) GetRelativesSeen() int {
 // re-organize the node tree. Make sure you have copied your original tree before running this (if you want to preserve
 // original data)
 func (resolver *Resolver) Resolve() []*ResolvingError {
-
+	start := time.Now()
 	visitIndex(resolver, resolver.specIndex)
 
+	if policy, depth := resolver.circularReferencePolicy(); policy == index.CircularReferencesStub {
+		resolver.stubCircularReferences(depth)
+	}
+
 	for _, circRef := range resolver.circularReferences {
-		// If the circular reference is not required, we can ignore it, as it's a terminable loop rather than an infinite one
-		if !circRef.IsInfiniteLoop {
-			continue
+		// the policy decides which circular references are errors, infinite loops always are.
+		if err := resolver.circularReferenceError(circRef); err != nil {
+			resolver.resolvingErrors = append(resolver.resolvingErrors, err)
 		}
-
-		resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{
-			ErrorRef: fmt.Errorf("Infinite circular reference detected: %s", circRef.Start.Name),
-			Node:     circRef.LoopPoint.Node,
-			Path:     circRef.GenerateJourneyPath(),
-		})
 	}
-
+	resolver.logDebug("resolved references", start)
 	return resolver.resolvingErrors
 }
 
 // CheckForCircularReferences Check for circular references, without resolving, a non-destructive run.
 func (resolver *Resolver) CheckForCircularReferences() []*ResolvingError {
+	start := time.Now()
 	visitIndexWithoutDamagingIt(resolver, resolver.specIndex)
 	for _, circRef := range resolver.circularReferences {
-		// If the circular reference is not required, we can ignore it, as it's a terminable loop rather than an infinite one
-		if !circRef.IsInfiniteLoop {
-			continue
+		// the policy decides which circular references are errors, infinite loops always are.
+		if err := resolver.circularReferenceError(circRef); err != nil {
+			resolver.resolvingErrors = append(resolver.resolvingErrors, err)
 		}
-
-		resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{
-			ErrorRef:          fmt.Errorf("Infinite circular reference detected: %s", circRef.Start.Name),
-			Node:              circRef.LoopPoint.Node,
-			Path:              circRef.GenerateJourneyPath(),
-			CircularReference: circRef,
-		})
 	}
 	// update our index with any circular refs we found.
 	resolver.specIndex.SetCircularReferences(resolver.circularReferences)
+	resolver.logDebug("checked for circular references", start)
 	return resolver.resolvingErrors
 }
 
+// logDebug logs how a run of the resolver went, if the index has a logger.
+func (resolver *Resolver) logDebug(msg string, start time.Time) {
+	if logger := resolver.specIndex.GetLogger(); logger != nil {
+		logger.Debug(msg, "journeys", resolver.journeysTaken, "circular", len(resolver.circularReferences),
+			"errors", len(resolver.resolvingErrors), "duration", time.Since(start))
+	}
+}
+
 func visitIndexWithoutDamagingIt(res *Resolver, idx *index.SpecIndex) {
 	mapped := idx.GetMappedReferencesSequenced()
 	mappedIndex := idx.GetMappedReferences()
@@ -223,6 +226,13 @@ func visitIndex(res *Resolver, idx *index.SpecIndex) {
 	}
 }
 
+func (resolver *Resolver) maxResolutionDepth() int {
+	if c := resolver.specIndex.GetConfig(); c != nil && c.Sandbox != nil {
+		return c.Sandbox.MaxResolutionDepth
+	}
+	return 0
+}
+
 // VisitReference will visit a reference as part of a journey and will return resolved nodes.
 func (resolver *Resolver) VisitReference(ref *index.Reference, seen map[string]bool, journey []*index.Reference, resolve bool) []*yaml.Node {
 	resolver.referencesVisited++
@@ -231,6 +241,15 @@ func (resolver *Resolver) VisitReference(ref *index.Reference, seen map[string]b
 	}
 
 	journey = append(journey, ref)
+	if max := resolver.maxResolutionDepth(); max > 0 && len(journey) > max {
+		resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{
+			ErrorRef: fmt.Errorf("%w: the maximum resolution depth (%d) has been exceeded resolving `%s`",
+				index.ErrLimitExceeded, max, ref.Definition),
+			Node: ref.Node,
+			Path: journey[0].Definition,
+		})
+		return ref.Node.Content
+	}
 	relatives := resolver.extractRelatives(ref.Node, seen, journey, resolve)
 
 	seen = make(map[string]bool)
@@ -261,6 +280,7 @@ func (resolver *Resolver) VisitReference(ref *index.Reference, seen map[string]b
 						LoopPoint:      foundDup,
 						IsInfiniteLoop: isInfiniteLoop,
 					}
+					circRef.Classification = classifyCircularReference(circRef)
 					resolver.circularReferences = append(resolver.circularReferences, circRef)
 
 					foundDup.Seen = true
@@ -269,6 +289,11 @@ func (resolver *Resolver) VisitReference(ref *index.Reference, seen map[string]b
 				skip = true
 			}
 		}
+		if skip && resolve {
+			if policy, _ := resolver.circularReferencePolicy(); policy == index.CircularReferencesStub {
+				resolver.loopPoints = append(resolver.loopPoints, r)
+			}
+		}
 
 		if !skip {
 			var original *index.Reference
@@ -400,6 +425,7 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 												LoopPoint:           ref,
 												IsPolymorphicResult: true,
 											}
+											circRef.Classification = classifyCircularReference(circRef)
 
 											ref.Seen = true
 											ref.Circular = true
@@ -437,6 +463,7 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 												LoopPoint:           ref,
 												IsPolymorphicResult: true,
 											}
+											circRef.Classification = classifyCircularReference(circRef)
 
 											ref.Seen = true
 											ref.Circular = true
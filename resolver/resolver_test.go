@@ -339,3 +339,41 @@ func ExampleResolvingError() {
 	fmt.Printf("%s", re.Error())
 	// Output: Je suis une erreur: #/definitions/JeSuisUneErreur [5:21]
 }
+
+func TestResolver_MaxResolutionDepth(t *testing.T) {
+	d := `openapi: 3.1.0
+components:
+  schemas:
+    One:
+      properties:
+        two:
+          $ref: '#/components/schemas/Two'
+    Two:
+      properties:
+        three:
+          $ref: '#/components/schemas/Three'
+    Three:
+      properties:
+        four:
+          $ref: '#/components/schemas/Four'
+    Four:
+      type: string`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(d), &rootNode)
+
+	c := index.CreateOpenAPIIndexConfig()
+	c.Sandbox = &index.ReferenceSandbox{MaxResolutionDepth: 2}
+	idx := index.NewSpecIndexWithConfig(&rootNode, c)
+
+	resolver := NewResolver(idx)
+	errs := resolver.Resolve()
+	assert.NotEmpty(t, errs)
+	assert.True(t, errors.Is(errs[0].ErrorRef, index.ErrLimitExceeded))
+	assert.Contains(t, errs[0].Error(), "maximum resolution depth (2)")
+
+	_ = yaml.Unmarshal([]byte(d), &rootNode)
+	c.Sandbox = &index.ReferenceSandbox{MaxResolutionDepth: 3}
+	idx = index.NewSpecIndexWithConfig(&rootNode, c)
+	assert.Empty(t, NewResolver(idx).Resolve())
+}
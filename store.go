@@ -0,0 +1,124 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
+)
+
+// DocumentStore caches documents by the SHA-256 hash of their specification, so a service asked to analyze the
+// same specification over and over only parses and builds it once. Models are built once per document, and every
+// caller asking for the same specification gets the same model, so cached models must not be mutated (use
+// Document.Clone for a copy that can be).
+//
+// External documents (files and remote documents) are cached by the hash of their content too, so files referenced
+// by many documents, and unchanged files, are only parsed once (each document gets its own copy). Cached documents
+// are not rebuilt when only a file they reference changes, Remove them (or use WatchDocument) to pick up changes.
+//
+// A DocumentStore is safe to use from multiple goroutines.
+type DocumentStore struct {
+	config       datamodel.DocumentConfiguration
+	lock         sync.Mutex
+	maxDocuments int
+	documents    map[[32]byte]*list.Element
+	order        *list.List
+}
+
+type storedDocument struct {
+	hash [32]byte
+	once sync.Once
+	doc  Document
+	err  error
+}
+
+// NewDocumentStore creates a DocumentStore that builds documents using a configuration (nil for the default
+// configuration), and holds up to maxDocuments documents. The least recently used documents are removed first, if
+// maxDocuments is zero or less, the store is unbounded.
+//
+// If the configuration has no ParsedDocumentCache, one is created for the store, holding up to maxDocuments
+// external documents for every document.
+func NewDocumentStore(config *datamodel.DocumentConfiguration, maxDocuments int) *DocumentStore {
+	s := &DocumentStore{
+		maxDocuments: maxDocuments,
+		documents:    make(map[[32]byte]*list.Element),
+		order:        list.New(),
+	}
+	if config != nil {
+		s.config = *config
+	}
+	if s.config.ParsedDocumentCache == nil {
+		s.config.ParsedDocumentCache = index.NewMemoryParsedDocumentCache(maxDocuments * 10)
+	}
+	return s
+}
+
+// Document returns the document for a specification, creating it if the specification has not been seen before.
+// Concurrent calls for the same specification wait for a single document to be created.
+func (s *DocumentStore) Document(spec []byte) (Document, error) {
+	hash := sha256.Sum256(spec)
+	s.lock.Lock()
+	var stored *storedDocument
+	if e, ok := s.documents[hash]; ok {
+		s.order.MoveToFront(e)
+		stored = e.Value.(*storedDocument)
+	} else {
+		stored = &storedDocument{hash: hash}
+		s.documents[hash] = s.order.PushFront(stored)
+		if s.maxDocuments > 0 && s.order.Len() > s.maxDocuments {
+			oldest := s.order.Back()
+			s.order.Remove(oldest)
+			delete(s.documents, oldest.Value.(*storedDocument).hash)
+		}
+	}
+	s.lock.Unlock()
+
+	stored.once.Do(func() {
+		config := s.config
+		stored.doc, stored.err = NewDocumentWithConfiguration(spec, &config)
+	})
+	return stored.doc, stored.err
+}
+
+// BuildV3Model returns the OpenAPI 3+ model of a specification, building it if it has not been built before.
+func (s *DocumentStore) BuildV3Model(spec []byte) (*DocumentModel[v3high.Document], []error) {
+	doc, err := s.Document(spec)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return doc.BuildV3Model()
+}
+
+// BuildV2Model returns the Swagger model of a specification, building it if it has not been built before.
+func (s *DocumentStore) BuildV2Model(spec []byte) (*DocumentModel[v2high.Swagger], []error) {
+	doc, err := s.Document(spec)
+	if err != nil {
+		return nil, []error{err}
+	}
+	return doc.BuildV2Model()
+}
+
+// Remove removes the document for a specification from the store, so it's created again next time.
+func (s *DocumentStore) Remove(spec []byte) {
+	hash := sha256.Sum256(spec)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if e, ok := s.documents[hash]; ok {
+		s.order.Remove(e)
+		delete(s.documents, hash)
+	}
+}
+
+// Len returns the number of documents in the store.
+func (s *DocumentStore) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.order.Len()
+}
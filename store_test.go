@@ -0,0 +1,104 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentStore(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	store := NewDocumentStore(nil, 2)
+
+	// every caller gets the same model.
+	models := make([]*DocumentModel[v3high.Document], 5)
+	var wg sync.WaitGroup
+	for i := range models {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			models[i], _ = store.BuildV3Model(spec)
+		}(i)
+	}
+	wg.Wait()
+	assert.NotNil(t, models[0])
+	for _, m := range models[1:] {
+		assert.Same(t, models[0], m)
+	}
+	assert.Equal(t, 1, store.Len())
+
+	// the least recently used document is removed when full.
+	v2spec, _ := os.ReadFile("test_specs/petstorev2.json")
+	m, errs := store.BuildV2Model(v2spec)
+	assert.Empty(t, errs)
+	assert.NotNil(t, m)
+	_, err := store.Document([]byte("openapi: 3.1.0\ninfo:\n  title: pizza"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, store.Len())
+	m3, _ := store.BuildV3Model(spec)
+	assert.NotSame(t, models[0], m3)
+
+	store.Remove(spec)
+	store.Remove(spec)
+	assert.Equal(t, 1, store.Len())
+
+	// bad specifications fail every time.
+	_, errs = store.BuildV3Model([]byte("not a spec"))
+	assert.NotEmpty(t, errs)
+	_, errs = store.BuildV2Model([]byte("not a spec"))
+	assert.NotEmpty(t, errs)
+}
+
+func TestDocumentStore_SharedFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: object"), 0o644))
+	store := NewDocumentStore(&datamodel.DocumentConfiguration{AllowFileReferences: true, BasePath: dir}, 0)
+
+	// the same file, referenced by two different specifications, is only parsed once.
+	first, errs := store.BuildV3Model([]byte(`openapi: 3.1.0
+info:
+  title: first
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`))
+	assert.Empty(t, errs)
+	second, errs := store.BuildV3Model([]byte(`openapi: 3.1.0
+info:
+  title: second
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`))
+	assert.Empty(t, errs)
+	assert.NotSame(t, first, second)
+	assert.NotNil(t, store.config.ParsedDocumentCache.Get(sha256.Sum256([]byte("type: object"))))
+
+	// each document gets its own copy of the parsed file.
+	firstType := first.Model.Components.Schemas["Pet"].Schema().GoLow().Type.ValueNode
+	secondType := second.Model.Components.Schemas["Pet"].Schema().GoLow().Type.ValueNode
+	assert.NotSame(t, firstType, secondType)
+	assert.Equal(t, firstType.Value, secondType.Value)
+
+	// a changed file is parsed again when a new document is built.
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: string"), 0o644))
+	third, errs := store.BuildV3Model([]byte(`openapi: 3.1.0
+info:
+  title: third
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`))
+	assert.Empty(t, errs)
+	assert.Equal(t, "string", third.Model.Components.Schemas["Pet"].Schema().Type[0])
+	assert.Equal(t, "object", first.Model.Components.Schemas["Pet"].Schema().Type[0])
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// StreamV3Paths builds an OpenAPI 3+ model from a specification read from a reader, then builds each path item in
+// turn and calls fn with it. Path items are not kept by the model, so each one (low and high level) can be released
+// as soon as fn returns, which keeps memory down when analyzing huge documents. The model returned has everything
+// except the path items.
+//
+// Only the models of path items are built one at a time, the whole specification is still parsed into a node tree
+// up front, as it's needed by the index (its bytes are not kept, see NewDocumentFromReader). Path items are built in
+// the order they appear in the specification. A path item that fails to build is skipped and its error returned, if
+// fn returns an error no more path items are built, and the error is returned.
+func StreamV3Paths(reader io.Reader, configuration *datamodel.DocumentConfiguration,
+	fn func(path string, pathItem *v3high.PathItem) error) (*DocumentModel[v3high.Document], []error) {
+	var config datamodel.DocumentConfiguration
+	if configuration != nil {
+		config = *configuration
+	}
+	config.AvoidPathItemBuild = true
+	doc, err := NewDocumentFromReader(reader, &config)
+	if err != nil {
+		return nil, []error{err}
+	}
+	model, errs := doc.BuildV3Model()
+	if model == nil {
+		return nil, errs
+	}
+	paths := model.Model.GoLow().Paths
+	if paths.Value == nil {
+		return model, errs
+	}
+	root := utils.NodeAlias(paths.ValueNode)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i]
+		if strings.HasPrefix(strings.ToLower(key.Value), "x-") {
+			continue
+		}
+		_, item, bErr := v3low.BuildPathItem(key, root.Content[i+1], model.Index)
+		if bErr != nil {
+			errs = append(errs, bErr)
+			continue
+		}
+		if err = fn(key.Value, v3high.NewPathItem(item.Value)); err != nil {
+			return model, append(errs, err)
+		}
+	}
+	return model, errs
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamV3Paths(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, _ := NewDocument(spec)
+	built, _ := doc.BuildV3Model()
+
+	var paths []string
+	operations := make(map[string]string)
+	m, errs := StreamV3Paths(bytes.NewReader(spec), nil, func(path string, pathItem *v3high.PathItem) error {
+		paths = append(paths, path)
+		for method, op := range pathItem.GetOperations() {
+			operations[path+" "+method] = op.OperationId
+		}
+		return nil
+	})
+	assert.Empty(t, errs)
+	assert.Len(t, paths, len(built.Model.Paths.PathItems))
+	assert.Equal(t, "/burgers", paths[0])
+	for path, item := range built.Model.Paths.PathItems {
+		for method, op := range item.GetOperations() {
+			assert.Equal(t, op.OperationId, operations[path+" "+method])
+		}
+	}
+
+	// the model has everything, except the path items.
+	assert.Empty(t, m.Model.Paths.PathItems)
+	assert.Len(t, m.Model.Components.Schemas, len(built.Model.Components.Schemas))
+	assert.Equal(t, built.Model.Info.Title, m.Model.Info.Title)
+}
+
+func TestStreamV3Paths_Stop(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	stop := errors.New("stop")
+	count := 0
+	_, errs := StreamV3Paths(bytes.NewReader(spec), nil, func(path string, pathItem *v3high.PathItem) error {
+		count++
+		return stop
+	})
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []error{stop}, errs)
+}
+
+func TestStreamV3Paths_Errors(t *testing.T) {
+	noop := func(path string, pathItem *v3high.PathItem) error { return nil }
+	_, errs := StreamV3Paths(strings.NewReader(""), nil, noop)
+	assert.Len(t, errs, 1)
+
+	_, errs = StreamV3Paths(strings.NewReader("swagger: 2.0"), nil, noop)
+	assert.Len(t, errs, 1)
+
+	m, errs := StreamV3Paths(strings.NewReader("openapi: 3.1.0\ninfo:\n  title: nothing"), nil, noop)
+	assert.Empty(t, errs)
+	assert.Equal(t, "nothing", m.Model.Info.Title)
+
+	// extensions are not paths.
+	var paths []string
+	_, errs = StreamV3Paths(strings.NewReader(`openapi: 3.1.0
+paths:
+  x-thing: nothing
+  /pizza:
+    get:
+      operationId: pizza`), nil, func(path string, pathItem *v3high.PathItem) error {
+		paths = append(paths, path)
+		assert.Equal(t, "pizza", pathItem.Get.OperationId)
+		return nil
+	})
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"/pizza"}, paths)
+
+	// documents that can't be built are not streamed.
+	_, errs = StreamV3Paths(strings.NewReader(`openapi: 3.1.0
+paths:
+  /missing:
+    $ref: '#/components/pathItems/missing'`), nil, func(path string, pathItem *v3high.PathItem) error {
+		assert.Fail(t, "nothing should be streamed")
+		return nil
+	})
+	assert.NotEmpty(t, errs)
+}
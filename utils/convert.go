@@ -0,0 +1,193 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// jsonNumber matches numbers that are written the same way in JSON as they are in YAML.
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+// ConvertYAMLtoOrderedJSON will convert YAML into JSON, keeping keys in the same order as they are in the YAML.
+// Numbers are written as they are in the YAML (so 2.0 stays 2.0 and large integers are not rounded), and strings
+// stay strings (so '2.0' stays "2.0"). An empty indent will render compact JSON.
+//
+// Unlike ConvertYAMLtoJSON, the YAML does not need to be a map.
+func ConvertYAMLtoOrderedJSON(yamlData []byte, indent string) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(yamlData, &node); err != nil {
+		return nil, err
+	}
+	return ConvertYAMLNodeToJSON(&node, indent)
+}
+
+// ConvertJSONtoYAML will convert JSON into YAML, keeping keys in the same order as they are in the JSON. Numbers
+// are written as they are in the JSON, and strings that look like numbers or booleans are quoted so they stay
+// strings. Each level is indented by indent spaces (four, if indent is zero).
+func ConvertJSONtoYAML(jsonData []byte, indent int) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonData, &node); err != nil {
+		return nil, err
+	}
+	clearNodeStyles(&node)
+	if indent <= 0 {
+		indent = 4
+	}
+	var buf bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&buf)
+	yamlEncoder.SetIndent(indent)
+	if err := yamlEncoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// clearNodeStyles removes the (JSON) flow and quoting styles of node and everything below it, so it renders as
+// block YAML. Strings are still quoted when they need to be.
+func clearNodeStyles(node *yaml.Node) {
+	node.Style = 0
+	for _, n := range node.Content {
+		clearNodeStyles(n)
+	}
+}
+
+// ConvertYAMLNodeToJSON will serialize a YAML node into JSON, keeping keys in the same order as the node. An empty
+// indent will render compact JSON, otherwise each element is indented by indent. Aliases and merge keys are resolved.
+// Numbers are written as they are in the YAML where JSON allows it (see ConvertYAMLtoOrderedJSON).
+func ConvertYAMLNodeToJSON(node *yaml.Node, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeNodeAsJSON(&buf, node); err != nil {
+		return nil, err
+	}
+	if indent == "" {
+		return buf.Bytes(), nil
+	}
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, buf.Bytes(), "", indent); err != nil {
+		return nil, err
+	}
+	return pretty.Bytes(), nil
+}
+
+func writeNodeAsJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	if node == nil {
+		buf.WriteString("null")
+		return nil
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeNodeAsJSON(buf, node.Content[0])
+	case yaml.AliasNode:
+		return writeNodeAsJSON(buf, node.Alias)
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, n := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeNodeAsJSON(buf, n); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		written := 0
+		if err := writeMappingAsJSON(buf, node, &written); err != nil {
+			return err
+		}
+		buf.WriteByte('}')
+		return nil
+	default:
+		return writeScalarAsJSON(buf, node)
+	}
+}
+
+// writeScalarAsJSON writes a scalar as a JSON value. Numbers are written as they are written in YAML, unless they
+// use YAML only notation (like 0x1F, or 1_000), in which case they are converted without losing precision.
+func writeScalarAsJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	value := node.Value
+	switch node.ShortTag() {
+	case "!!null":
+		buf.WriteString("null")
+		return nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return err
+		}
+		buf.WriteString(strconv.FormatBool(b))
+		return nil
+	case "!!int":
+		if jsonNumber.MatchString(value) {
+			buf.WriteString(value)
+			return nil
+		}
+		i, ok := new(big.Int).SetString(strings.ReplaceAll(value, "_", ""), 0)
+		if !ok {
+			return fmt.Errorf("cannot convert integer '%s' to JSON, line %d, column %d", value, node.Line, node.Column)
+		}
+		buf.WriteString(i.String())
+		return nil
+	case "!!float":
+		if jsonNumber.MatchString(value) {
+			buf.WriteString(value)
+			return nil
+		}
+		f, err := strconv.ParseFloat(strings.ReplaceAll(value, "_", ""), 64)
+		if err != nil || !jsonNumber.MatchString(strconv.FormatFloat(f, 'g', -1, 64)) {
+			return fmt.Errorf("cannot convert number '%s' to JSON, line %d, column %d", value, node.Line, node.Column)
+		}
+		buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+		return nil
+	}
+	dat, _ := json.Marshal(value)
+	buf.Write(dat)
+	return nil
+}
+
+// writeMappingAsJSON writes the key/value pairs of a mapping node (without braces), merge keys write the pairs of
+// the mappings they merge in place.
+func writeMappingAsJSON(buf *bytes.Buffer, node *yaml.Node, written *int) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		if k.Tag == "!!merge" {
+			merged := v
+			if merged.Kind == yaml.AliasNode {
+				merged = merged.Alias
+			}
+			if merged.Kind == yaml.MappingNode {
+				if err := writeMappingAsJSON(buf, merged, written); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if *written > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(k.Value)
+		buf.Write(key)
+		buf.WriteByte(':')
+		if err := writeNodeAsJSON(buf, v); err != nil {
+			return err
+		}
+		*written++
+	}
+	return nil
+}
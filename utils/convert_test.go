@@ -0,0 +1,96 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConvertYAMLNodeToJSON(t *testing.T) {
+	yml := `zebra: 1
+apple:
+  - true
+  - ~
+  - 1.5
+base: &base
+  mango: "12"
+merged:
+  <<: *base
+  kiwi: yes`
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	str, err := ConvertYAMLNodeToJSON(&node, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"zebra":1,"apple":[true,null,1.5],"base":{"mango":"12"},"merged":{"mango":"12","kiwi":"yes"}}`,
+		string(str))
+
+	str, err = ConvertYAMLNodeToJSON(node.Content[0].Content[3], "  ")
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n  true,\n  null,\n  1.5\n]", string(str))
+
+	str, err = ConvertYAMLNodeToJSON(nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(str))
+}
+
+func TestConvertYAMLtoOrderedJSON(t *testing.T) {
+	yml := `swagger: 2.0
+version: '2.0'
+big: 123456789012345678901234567890
+precise: 0.1000000000000000055511151231257827
+hex: 0x1F
+octal: 0o17
+under: 1_000
+plus: +1.5
+dot: .5
+when: 2001-12-14
+yes: no`
+
+	str, err := ConvertYAMLtoOrderedJSON([]byte(yml), "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"swagger":2.0,"version":"2.0","big":123456789012345678901234567890,`+
+		`"precise":0.1000000000000000055511151231257827,"hex":31,"octal":15,"under":1000,"plus":1.5,"dot":0.5,`+
+		`"when":"2001-12-14","yes":"no"}`, string(str))
+
+	str, err = ConvertYAMLtoOrderedJSON([]byte("- 1\n- two"), "  ")
+	assert.NoError(t, err)
+	assert.Equal(t, "[\n  1,\n  \"two\"\n]", string(str))
+
+	_, err = ConvertYAMLtoOrderedJSON([]byte("infinity: .inf"), "")
+	assert.Error(t, err)
+
+	_, err = ConvertYAMLtoOrderedJSON([]byte("gonna: break: you:\nyeah:yeah:yeah"), "")
+	assert.Error(t, err)
+}
+
+func TestConvertJSONtoYAML(t *testing.T) {
+	jsonData := `{"swagger": 2.0, "version": "2.0", "big": 123456789012345678901234567890, "enabled": "true",
+		"zebra": {"b": [1, "x"], "a": null}}`
+
+	str, err := ConvertJSONtoYAML([]byte(jsonData), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, `swagger: 2.0
+version: "2.0"
+big: 123456789012345678901234567890
+enabled: "true"
+zebra:
+  b:
+    - 1
+    - x
+  a: null
+`, string(str))
+
+	// and back again.
+	back, err := ConvertYAMLtoOrderedJSON(str, "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"swagger":2.0,"version":"2.0","big":123456789012345678901234567890,"enabled":"true",`+
+		`"zebra":{"b":[1,"x"],"a":null}}`, string(back))
+
+	_, err = ConvertJSONtoYAML([]byte(`{"broken": [}`), 0)
+	assert.Error(t, err)
+}
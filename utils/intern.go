@@ -0,0 +1,76 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StringInterner makes identical strings share the same storage. Every node parsed from YAML holds its own copy of
+// its value, so keys repeated thousands of times (property names, media types, status codes) and references to the
+// same component each take up memory of their own. Interning them keeps a single copy of each.
+//
+// A StringInterner is safe to use from multiple goroutines.
+type StringInterner struct {
+	lock    sync.Mutex
+	strings map[string]string
+}
+
+// NewStringInterner creates a new, empty StringInterner.
+func NewStringInterner() *StringInterner {
+	return &StringInterner{strings: make(map[string]string)}
+}
+
+// Intern returns the shared copy of a string, the first copy seen becomes the shared copy.
+func (s *StringInterner) Intern(str string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.intern(str)
+}
+
+// InternNodes interns the keys of every map, and the value of every $ref, in a node tree.
+func (s *StringInterner) InternNodes(node *yaml.Node) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.internNodes(node)
+}
+
+// Len returns the number of distinct strings interned.
+func (s *StringInterner) Len() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.strings)
+}
+
+func (s *StringInterner) intern(str string) string {
+	if str == "" {
+		return str
+	}
+	if shared, ok := s.strings[str]; ok {
+		return shared
+	}
+	s.strings[str] = str
+	return str
+}
+
+func (s *StringInterner) internNodes(node *yaml.Node) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			key.Value = s.intern(key.Value)
+			if key.Value == "$ref" && value.Kind == yaml.ScalarNode {
+				value.Value = s.intern(value.Value)
+			}
+		}
+	}
+	// aliases point to nodes that are already in the tree, so they are not followed.
+	for _, n := range node.Content {
+		s.internNodes(n)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func sameStorage(a, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestStringInterner_Intern(t *testing.T) {
+	s := NewStringInterner()
+	a := string([]byte("application/json"))
+	b := string([]byte("application/json"))
+	assert.False(t, sameStorage(a, b))
+
+	assert.True(t, sameStorage(a, s.Intern(a)))
+	assert.True(t, sameStorage(a, s.Intern(b)))
+	assert.Equal(t, "", s.Intern(""))
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestStringInterner_InternNodes(t *testing.T) {
+	yml := `paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /cats:
+    get:
+      responses:
+        "200":
+          description: cats
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+	s := NewStringInterner()
+	s.InternNodes(&root)
+	s.InternNodes(nil)
+
+	var media, refs []*yaml.Node
+	var collect func(n *yaml.Node)
+	collect = func(n *yaml.Node) {
+		for i, c := range n.Content {
+			if c.Value == "application/json" {
+				media = append(media, c)
+			}
+			if c.Value == "$ref" {
+				refs = append(refs, n.Content[i+1])
+			}
+			collect(c)
+		}
+	}
+	collect(&root)
+	assert.Len(t, media, 2)
+	assert.True(t, sameStorage(media[0].Value, media[1].Value))
+	assert.Len(t, refs, 2)
+	assert.True(t, sameStorage(refs[0].Value, refs[1].Value))
+
+	// values that are not references are left alone.
+	assert.Equal(t, 12, s.Len())
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import "strings"
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// EscapeJSONPointerSegment escapes a single JSON pointer (RFC 6901) segment, '~' becomes '~0' and '/' becomes '~1'.
+func EscapeJSONPointerSegment(segment string) string {
+	return pointerEscaper.Replace(segment)
+}
+
+// UnescapeJSONPointerSegment reverses EscapeJSONPointerSegment, '~1' becomes '/' and '~0' becomes '~'.
+func UnescapeJSONPointerSegment(segment string) string {
+	return pointerUnescaper.Replace(segment)
+}
+
+// BuildJSONPointer creates a JSON pointer (RFC 6901) from a slice of unescaped segments, like '/paths/~1pets/get'.
+func BuildJSONPointer(segments []string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteString("/")
+		b.WriteString(EscapeJSONPointerSegment(s))
+	}
+	return b.String()
+}
+
+// AppendPathSegment returns a copy of path with segment added to the end, so paths that share a parent never
+// share (and overwrite) the same backing array.
+func AppendPathSegment(path []string, segment string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, segment)
+}
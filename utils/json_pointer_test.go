@@ -0,0 +1,38 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeJSONPointerSegment(t *testing.T) {
+	assert.Equal(t, "~1pets~1{id}", EscapeJSONPointerSegment("/pets/{id}"))
+	assert.Equal(t, "a~0~1b", EscapeJSONPointerSegment("a~/b"))
+	assert.Equal(t, "~01", EscapeJSONPointerSegment("~1"))
+	assert.Equal(t, "pets", EscapeJSONPointerSegment("pets"))
+}
+
+func TestUnescapeJSONPointerSegment(t *testing.T) {
+	assert.Equal(t, "/pets/{id}", UnescapeJSONPointerSegment("~1pets~1{id}"))
+	assert.Equal(t, "a~/b", UnescapeJSONPointerSegment("a~0~1b"))
+	assert.Equal(t, "~1", UnescapeJSONPointerSegment("~01"))
+}
+
+func TestBuildJSONPointer(t *testing.T) {
+	assert.Equal(t, "/paths/~1pets/get", BuildJSONPointer([]string{"paths", "/pets", "get"}))
+	assert.Equal(t, "", BuildJSONPointer(nil))
+}
+
+func TestAppendPathSegment(t *testing.T) {
+	parent := make([]string, 1, 4)
+	parent[0] = "paths"
+	a := AppendPathSegment(parent, "a")
+	b := AppendPathSegment(parent, "b")
+	assert.Equal(t, []string{"paths", "a"}, a)
+	assert.Equal(t, []string{"paths", "b"}, b)
+	assert.Equal(t, []string{"paths"}, parent)
+}
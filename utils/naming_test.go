@@ -0,0 +1,20 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToPascalCase(t *testing.T) {
+	assert.Equal(t, "PetID", ToPascalCase("pet_id"))
+	assert.Equal(t, "PetID", ToPascalCase("petId"))
+	assert.Equal(t, "PetID", ToPascalCase("pet-id"))
+	assert.Equal(t, "HTTPServer", ToPascalCase("HTTPServer"))
+	assert.Equal(t, "PetsID", ToPascalCase("/pets/{id}"))
+	assert.Equal(t, "2fa", ToPascalCase("2fa"))
+	assert.Equal(t, "", ToPascalCase("$"))
+}
@@ -66,3 +66,28 @@ func CreateFloatNode(str string) *yaml.Node {
 	}
 	return n
 }
+
+// CopyNode returns a deep copy of a node, and every node below it. Nodes that appear more than once (like anchors
+// and the aliases pointing to them) are copied once, so the copy has the same shape as the original.
+func CopyNode(node *yaml.Node) *yaml.Node {
+	return copyNode(node, make(map[*yaml.Node]*yaml.Node))
+}
+
+func copyNode(node *yaml.Node, copied map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if c, ok := copied[node]; ok {
+		return c
+	}
+	c := *node
+	copied[node] = &c
+	if node.Content != nil {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i, n := range node.Content {
+			c.Content[i] = copyNode(n, copied)
+		}
+	}
+	c.Alias = copyNode(node.Alias, copied)
+	return &c
+}
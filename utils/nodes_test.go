@@ -5,6 +5,7 @@ package utils
 
 import (
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 	"testing"
 )
 
@@ -47,3 +48,27 @@ func TestCreateRefNode(t *testing.T) {
 	assert.Equal(t, "!!str", r.Content[1].Tag)
 	assert.Equal(t, "#/components/schemas/MySchema", r.Content[1].Value)
 }
+
+func TestCopyNode(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`base: &base
+  name: pizza
+copy: *base
+list: [a, b]`), &root)
+
+	c := CopyNode(&root)
+	assert.NotSame(t, &root, c)
+	orig, _ := yaml.Marshal(&root)
+	cp, _ := yaml.Marshal(c)
+	assert.Equal(t, string(orig), string(cp))
+
+	// aliases point to the copied anchor.
+	mapping := c.Content[0]
+	assert.Same(t, mapping.Content[1], mapping.Content[3].Alias)
+	assert.NotSame(t, root.Content[0].Content[1], mapping.Content[1])
+
+	// changing the copy does not change the original.
+	mapping.Content[5].Content[0].Value = "c"
+	assert.Equal(t, "a", root.Content[0].Content[5].Content[0].Value)
+	assert.Nil(t, CopyNode(nil))
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// poolingDisabled is false by default, so pooling is enabled.
+var poolingDisabled atomic.Bool
+
+// SetPooling enables or disables the reuse of transient structures (buffers and slices used while building models
+// and indexes). Pooling is enabled by default, disabling it allocates everything fresh every time, which can help
+// when debugging something holding onto a pooled structure for longer than it should.
+func SetPooling(enabled bool) {
+	poolingDisabled.Store(!enabled)
+}
+
+// PoolingEnabled returns true if transient structures are being reused.
+func PoolingEnabled() bool {
+	return !poolingDisabled.Load()
+}
+
+// Pool is a typed sync.Pool, that resets values before they are reused. When pooling is disabled (see SetPooling)
+// every Get creates a new value, and Put does nothing.
+type Pool[T any] struct {
+	pool  sync.Pool
+	new   func() T
+	reset func(T) bool
+}
+
+// NewPool creates a new Pool. The reset function prepares a value to be reused, returning false drops the value
+// instead (for example, a buffer that has grown too large to keep around).
+func NewPool[T any](newFn func() T, reset func(T) bool) *Pool[T] {
+	return &Pool[T]{new: newFn, reset: reset}
+}
+
+// Get returns a value from the pool, or a new one.
+func (p *Pool[T]) Get() T {
+	if !PoolingEnabled() {
+		return p.new()
+	}
+	if v, ok := p.pool.Get().(T); ok {
+		return v
+	}
+	return p.new()
+}
+
+// Put returns a value to the pool, the value must not be used afterwards.
+func (p *Pool[T]) Put(v T) {
+	if !PoolingEnabled() || (p.reset != nil && !p.reset(v)) {
+		return
+	}
+	p.pool.Put(v)
+}
+
+// maxPooledBuffer is the capacity above which buffers are dropped, rather than kept in the pool.
+const maxPooledBuffer = 64 * 1024
+
+var bufferPool = NewPool(func() *[]byte {
+	b := make([]byte, 0, 256)
+	return &b
+}, func(b *[]byte) bool {
+	*b = (*b)[:0]
+	return cap(*b) <= maxPooledBuffer
+})
+
+// GetBuffer returns an empty byte buffer for temporary use, return it with PutBuffer when done.
+func GetBuffer() *[]byte {
+	return bufferPool.Get()
+}
+
+// PutBuffer returns a buffer from GetBuffer to the pool, the buffer must not be used afterwards.
+func PutBuffer(b *[]byte) {
+	bufferPool.Put(b)
+}
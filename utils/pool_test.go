@@ -0,0 +1,60 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	created := 0
+	p := NewPool(func() *[]string {
+		created++
+		return new([]string)
+	}, func(s *[]string) bool {
+		*s = (*s)[:0]
+		return cap(*s) < 100
+	})
+
+	s := p.Get()
+	*s = append(*s, "pizza")
+	p.Put(s)
+	assert.Equal(t, 1, created)
+
+	// a value too large to keep is dropped.
+	big := make([]string, 0, 100)
+	p.Put(&big)
+
+	// pooled values may be dropped by the GC at any time, so only check they come back reset.
+	assert.Empty(t, *p.Get())
+}
+
+func TestPool_Disabled(t *testing.T) {
+	SetPooling(false)
+	defer SetPooling(true)
+	assert.False(t, PoolingEnabled())
+
+	created := 0
+	p := NewPool(func() *int {
+		created++
+		return new(int)
+	}, nil)
+	for i := 0; i < 3; i++ {
+		p.Put(p.Get())
+	}
+	assert.Equal(t, 3, created)
+}
+
+func TestGetBuffer(t *testing.T) {
+	b := GetBuffer()
+	*b = append(*b, "burgers"...)
+	PutBuffer(b)
+	assert.Empty(t, *GetBuffer())
+
+	big := make([]byte, 0, maxPooledBuffer+1)
+	PutBuffer(&big)
+	assert.LessOrEqual(t, cap(*GetBuffer()), maxPooledBuffer)
+}
@@ -563,6 +563,9 @@ func IsHttpVerb(verb string) bool {
 // define bracket name expression
 var bracketNameExp = regexp.MustCompile("^(\\w+)\\[(\\w+)\\]$")
 
+// characters in a path segment that require the segment to be wrapped when searching.
+var pathCharExp = regexp.MustCompile("[%=;~.]")
+
 func ConvertComponentIdIntoFriendlyPathSearch(id string) (string, string) {
 	segs := strings.Split(id, "/")
 	name, _ := url.QueryUnescape(strings.ReplaceAll(segs[len(segs)-1], "~1", "/"))
@@ -570,8 +573,7 @@ func ConvertComponentIdIntoFriendlyPathSearch(id string) (string, string) {
 
 	// check for strange spaces, chars and if found, wrap them up, clean them and create a new cleaned path.
 	for i := range segs {
-		pathCharExp, _ := regexp.MatchString("[%=;~.]", segs[i])
-		if pathCharExp {
+		if pathCharExp.MatchString(segs[i]) {
 			segs[i], _ = url.QueryUnescape(strings.ReplaceAll(segs[i], "~1", "/"))
 			segs[i] = fmt.Sprintf("['%s']", segs[i])
 			if len(cleaned) > 0 {
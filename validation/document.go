@@ -0,0 +1,46 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// documentSchemas are the compiled meta-schemas, by the schema they were compiled from.
+var documentSchemas sync.Map
+
+// ValidateDocument checks a specification against the official OpenAPI meta-schema for its version (2.0, 3.0 or
+// 3.1, see datamodel.SpecInfo.APISchema), before a model is built from it. Every violation is located by the
+// line and column of the value in the specification, and its JSON pointer.
+//
+// Returns an error if the specification is not an OpenAPI or Swagger document (like a fragment), and so can't be
+// validated.
+func ValidateDocument(info *datamodel.SpecInfo) ([]*Violation, error) {
+	if info == nil || info.RootNode == nil {
+		return nil, errors.New("unable to validate document, no specification has been loaded")
+	}
+	if info.APISchema == "" {
+		return nil, errors.New("unable to validate document, it is not an OpenAPI or Swagger document")
+	}
+	schema, err := documentSchema(info.APISchema)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Validate(info.RootNode), nil
+}
+
+func documentSchema(data string) (*Schema, error) {
+	if schema, ok := documentSchemas.Load(data); ok {
+		return schema.(*Schema), nil
+	}
+	schema, err := CompileSchema(data, datamodel.JSONSchemaDraft04Data)
+	if err != nil {
+		return nil, err
+	}
+	documentSchemas.Store(data, schema)
+	return schema, nil
+}
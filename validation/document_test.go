@@ -0,0 +1,115 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func validateSpec(t *testing.T, spec string) []*Violation {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	assert.NoError(t, err)
+	violations, err := ValidateDocument(info)
+	assert.NoError(t, err)
+	return violations
+}
+
+func TestValidateDocument_OpenAPI3(t *testing.T) {
+	violations := validateSpec(t, `openapi: 3.0.3
+info:
+  title: pizza
+paths:
+  /pizza:
+    get:
+      parameters:
+        - name: size
+          in: body
+      responses:
+        '200':
+          description: a pizza
+    fetch:
+      description: not a method`)
+
+	assert.Len(t, violations, 4)
+	assert.Equal(t, "missing required property 'version'", violations[0].Message)
+	assert.Equal(t, "#/info", violations[0].Pointer)
+	assert.Equal(t, 3, violations[0].Line)
+
+	// parameters need a schema or content.
+	assert.Equal(t, "missing required property 'schema'", violations[1].Message)
+	assert.Equal(t, "#/paths/~1pizza/get/parameters/0", violations[1].Pointer)
+	assert.Equal(t, 8, violations[1].Line)
+
+	// every location is reported, although each is allowed by a different schema.
+	assert.Equal(t, "'body' is not one of 'path', 'query', 'header', 'cookie'", violations[2].Message)
+	assert.Equal(t, "#/paths/~1pizza/get/parameters/0/in", violations[2].Pointer)
+
+	assert.Equal(t, "property 'fetch' is not allowed", violations[3].Message)
+	assert.Equal(t, "#/paths/~1pizza/fetch", violations[3].Pointer)
+	assert.Equal(t, "#/paths/~1pizza/fetch: property 'fetch' is not allowed (line 13, column 5)",
+		violations[3].Error())
+}
+
+func TestValidateDocument_OpenAPI31(t *testing.T) {
+	violations := validateSpec(t, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          colour: red
+components:
+  securitySchemes:
+    key:
+      type: apiKey
+      in: header`)
+
+	assert.Len(t, violations, 2)
+	assert.Equal(t, "property 'colour' is not allowed", violations[0].Message)
+	assert.Equal(t, "#/paths/~1pizza/get/responses/200/colour", violations[0].Pointer)
+	assert.Equal(t, "missing required property 'name'", violations[1].Message)
+	assert.Equal(t, "#/components/securitySchemes/key", violations[1].Pointer)
+}
+
+func TestValidateDocument_Swagger(t *testing.T) {
+	violations := validateSpec(t, `swagger: "2.0"
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+schemes: [ftp]`)
+
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "'ftp' is not one of 'http', 'https', 'ws', 'wss'", violations[0].Message)
+	assert.Equal(t, "#/schemes/0", violations[0].Pointer)
+}
+
+func TestValidateDocument_Valid(t *testing.T) {
+	for _, spec := range []string{"../test_specs/petstorev3.json", "../test_specs/petstorev2.json"} {
+		b, _ := os.ReadFile(spec)
+		assert.Empty(t, validateSpec(t, string(b)), spec)
+	}
+}
+
+func TestValidateDocument_NotADocument(t *testing.T) {
+	_, err := ValidateDocument(nil)
+	assert.Error(t, err)
+
+	info, _ := datamodel.ExtractSpecInfoWithDocumentCheck([]byte(`type: string`), true)
+	_, err = ValidateDocument(info)
+	assert.EqualError(t, err, "unable to validate document, it is not an OpenAPI or Swagger document")
+}
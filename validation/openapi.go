@@ -0,0 +1,60 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentValidator validates values (like the bodies of requests and responses) against the schemas defined in an
+// OpenAPI document. References to other schemas in the document (like '#/components/schemas/Pet') are followed,
+// references to other documents are not, and are reported as violations.
+//
+// Schemas of OpenAPI 3.1 documents are JSON Schema 2020-12, otherwise they are treated as JSON Schema draft 4
+// (along with 'nullable'). It is safe to use from multiple goroutines.
+type DocumentValidator struct {
+	schema  *Schema
+	schemas sync.Map // the value of every schema node validated so far, by node.
+}
+
+// NewDocumentValidator creates a DocumentValidator for the schemas of a document, from its root node.
+func NewDocumentValidator(root *yaml.Node) *DocumentValidator {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	r := &resource{anchors: make(map[string]any), draft4: true}
+	if root != nil {
+		r.root = nodeValue(root)
+		if _, v := utils.FindKeyNodeTop("openapi", root.Content); v != nil && strings.HasPrefix(v.Value, "3.1") {
+			r.draft4 = false
+		}
+	}
+	collectAnchors(r.root, r.anchors)
+	return &DocumentValidator{schema: &Schema{root: r, resources: make(map[string]*resource)}}
+}
+
+// Validate checks a value against a schema of the document, the schema is the yaml node it is defined by. The
+// pointers of violations are relative to the value, and their schema pointers are relative to the schema (until
+// they pass through a reference).
+func (d *DocumentValidator) Validate(schema, value *yaml.Node) []*Violation {
+	if schema == nil || value == nil {
+		return nil
+	}
+	if value.Kind == yaml.DocumentNode {
+		if len(value.Content) == 0 {
+			return nil
+		}
+		value = value.Content[0]
+	}
+	sch, ok := d.schemas.Load(schema)
+	if !ok {
+		sch, _ = d.schemas.LoadOrStore(schema, nodeValue(schema))
+	}
+	violations, _ := d.schema.evaluate(d.schema.root, sch, "#", value, nil)
+	return uniqueViolations(violations)
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDocumentValidator_Validate(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tags:
+          type: [array, 'null']
+          items:
+            $ref: '#/components/schemas/Tag'
+    Tag:
+      type: string
+      minLength: 2`), &root)
+
+	_, components := utils.FindKeyNodeTop("components", root.Content[0].Content)
+	_, schemas := utils.FindKeyNodeTop("schemas", components.Content)
+	_, pet := utils.FindKeyNodeTop("Pet", schemas.Content)
+
+	validator := NewDocumentValidator(&root)
+	var value yaml.Node
+	_ = yaml.Unmarshal([]byte(`{"tags": ["a", "bb"]}`), &value)
+	violations := validator.Validate(pet, &value)
+	assert.Len(t, violations, 2)
+	assert.Equal(t, "missing required property 'name'", violations[0].Message)
+	assert.Equal(t, "'a' is shorter than 2 characters", violations[1].Message)
+	assert.Equal(t, "#/tags/0", violations[1].Pointer)
+	assert.Equal(t, "#/components/schemas/Tag/minLength", violations[1].SchemaPointer)
+
+	_ = yaml.Unmarshal([]byte(`{"name": "fluffy", "tags": null}`), &value)
+	assert.Empty(t, validator.Validate(pet, &value))
+	assert.Nil(t, validator.Validate(nil, &value))
+}
+
+func TestDocumentValidator_Nullable(t *testing.T) {
+	var root, schema, value yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.0.3`), &root)
+	_ = yaml.Unmarshal([]byte(`{"type": "string", "nullable": true}`), &schema)
+	_ = yaml.Unmarshal([]byte(`null`), &value)
+
+	validator := NewDocumentValidator(&root)
+	assert.Empty(t, validator.Validate(schema.Content[0], &value))
+	_ = yaml.Unmarshal([]byte(`12`), &value)
+	assert.Len(t, validator.Validate(schema.Content[0], &value), 1)
+}
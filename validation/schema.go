@@ -0,0 +1,790 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package validation checks documents against JSON schemas, like the OpenAPI meta-schemas.
+//
+// Documents are validated as yaml nodes, rather than as JSON, so every violation found can be mapped back to the
+// line and column of the value that caused it. The schemas supported are JSON Schema draft 4 (used by the OpenAPI
+// 2.0 and 3.0 schemas) and the parts of JSON Schema 2020-12 used by the OpenAPI 3.1 schema. Formats are not checked.
+//
+// The values declared by the schemas of a document (enum, default and const) can be checked against their own
+// schemas too, see ValidateSchemaValues.
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Violation is a value in a document that does not conform to a schema.
+type Violation struct {
+	Message       string     // what is wrong, like "missing required property 'info'".
+	Pointer       string     // JSON pointer of the value in the document, like '#/paths/~1pets/get'.
+	SchemaPointer string     // location of the keyword that failed in the schema, like '#/definitions/Info/required'.
+	Line          int        // the line of the value (or the key, for properties that are not allowed).
+	Column        int        // the column of the value (or the key, for properties that are not allowed).
+	Node          *yaml.Node `json:"-" yaml:"-"`
+
+	allowed []any // the values allowed by an enum or const that failed.
+}
+
+// Error returns the message of the violation, along with where it is.
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s: %s (line %d, column %d)", v.Pointer, v.Message, v.Line, v.Column)
+}
+
+// Schema is a compiled JSON schema, safe to use from multiple goroutines.
+type Schema struct {
+	root      *resource
+	resources map[string]*resource
+	patterns  sync.Map
+}
+
+// resource is a schema document that can be referenced, by its id.
+type resource struct {
+	id      string
+	root    any
+	draft4  bool
+	anchors map[string]any
+}
+
+// CompileSchema compiles a JSON schema. Any other schemas it refers to (by their id) are supplied as resources.
+func CompileSchema(schema string, resources ...string) (*Schema, error) {
+	s := &Schema{resources: make(map[string]*resource)}
+	for i, data := range append([]string{schema}, resources...) {
+		var root any
+		if err := json.Unmarshal([]byte(data), &root); err != nil {
+			return nil, fmt.Errorf("unable to compile schema: %w", err)
+		}
+		r := &resource{root: root, anchors: make(map[string]any)}
+		if m, ok := root.(map[string]any); ok {
+			r.id, _ = m["$id"].(string)
+			if id, ok := m["id"].(string); ok && r.id == "" {
+				r.id = id
+			}
+			dialect, _ := m["$schema"].(string)
+			r.draft4 = strings.Contains(dialect, "draft-04")
+		}
+		r.id = strings.TrimSuffix(r.id, "#")
+		collectAnchors(root, r.anchors)
+		if i == 0 {
+			s.root = r
+		}
+		if r.id != "" {
+			s.resources[r.id] = r
+		}
+	}
+	return s, nil
+}
+
+func collectAnchors(schema any, anchors map[string]any) {
+	switch v := schema.(type) {
+	case map[string]any:
+		for _, key := range []string{"$anchor", "$dynamicAnchor"} {
+			if name, ok := v[key].(string); ok {
+				anchors[name] = v
+			}
+		}
+		for _, child := range v {
+			collectAnchors(child, anchors)
+		}
+	case []any:
+		for _, child := range v {
+			collectAnchors(child, anchors)
+		}
+	}
+}
+
+// Validate checks a yaml node (a document node, or any value) against the schema. Violations are ordered by their
+// position in the document, nil is returned if the node conforms to the schema.
+func (s *Schema) Validate(node *yaml.Node) []*Violation {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	violations, _ := s.evaluate(s.root, s.root.root, "#", node, nil)
+	return uniqueViolations(violations)
+}
+
+// uniqueViolations removes duplicate violations, and orders them by their position in the document.
+func uniqueViolations(violations []*Violation) []*Violation {
+	seen := make(map[string]bool)
+	var unique []*Violation
+	for _, v := range violations {
+		if key := v.Pointer + "|" + v.Message; !seen[key] {
+			seen[key] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		if unique[i].Line != unique[j].Line {
+			return unique[i].Line < unique[j].Line
+		}
+		return unique[i].Column < unique[j].Column
+	})
+	return unique
+}
+
+// evaluate checks a node against a schema, at returns the location of the schema. The properties of the node that
+// were evaluated successfully (used by unevaluatedProperties) are returned along with any violations.
+func (s *Schema) evaluate(res *resource, schema any, at string, node *yaml.Node, path []string) ([]*Violation,
+	map[string]bool,
+) {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	sch, ok := schema.(map[string]any)
+	if !ok {
+		if allowed, isBool := schema.(bool); isBool && !allowed {
+			return []*Violation{violation(node, path, at, "no value is allowed here")}, nil
+		}
+		return nil, nil
+	}
+
+	var violations []*Violation
+	evaluated := make(map[string]bool)
+	fail := func(v ...*Violation) {
+		violations = append(violations, v...)
+	}
+	merge := func(props map[string]bool) {
+		for k := range props {
+			evaluated[k] = true
+		}
+	}
+
+	for _, keyword := range []string{"$ref", "$dynamicRef"} {
+		ref, ok := sch[keyword].(string)
+		if !ok {
+			continue
+		}
+		target, targetRes, targetAt, found := s.resolve(res, ref)
+		if !found {
+			fail(violation(node, path, at+"/"+keyword, fmt.Sprintf("unable to resolve schema reference '%s'", ref)))
+			continue
+		}
+		v, props := s.evaluate(targetRes, target, targetAt, node, path)
+		fail(v...)
+		merge(props)
+	}
+	if _, ok := sch["$ref"]; ok && res.draft4 {
+		// draft 4 ignores everything next to a $ref.
+		return violations, evaluated
+	}
+
+	// 'nullable' is not a JSON schema keyword, it's used by OpenAPI 3.0 schemas to allow null.
+	nullable, _ := sch["nullable"].(bool)
+	if t, ok := sch["type"]; ok && !matchesType(node, t) && !(nullable && instanceType(node) == "null") {
+		// nothing else is worth checking if the type is wrong.
+		fail(violation(node, path, at+"/type",
+			fmt.Sprintf("expected %s, found %s", describeType(t), instanceType(node))))
+		return violations, evaluated
+	}
+	if enum, ok := sch["enum"].([]any); ok && !containsValue(enum, nodeValue(node)) {
+		fail(notAllowedValue(node, path, at+"/enum", enum))
+	}
+	if c, ok := sch["const"]; ok && !reflect.DeepEqual(c, nodeValue(node)) {
+		fail(notAllowedValue(node, path, at+"/const", []any{c}))
+	}
+
+	switch instanceType(node) {
+	case "string":
+		fail(s.checkString(sch, at, node, path)...)
+	case "integer", "number":
+		fail(checkNumber(sch, at, node, path)...)
+	case "object":
+		v, props := s.checkObject(res, sch, at, node, path)
+		fail(v...)
+		merge(props)
+	case "array":
+		fail(s.checkArray(res, sch, at, node, path)...)
+	}
+
+	// applicators.
+	if all, ok := sch["allOf"].([]any); ok {
+		for i, sub := range all {
+			v, props := s.evaluate(res, sub, fmt.Sprintf("%s/allOf/%d", at, i), node, path)
+			fail(v...)
+			merge(props)
+		}
+	}
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		branches, ok := sch[keyword].([]any)
+		if !ok {
+			continue
+		}
+		var failures [][]*Violation
+		passed := 0
+		for i, sub := range branches {
+			v, props := s.evaluate(res, sub, fmt.Sprintf("%s/%s/%d", at, keyword, i), node, path)
+			if len(v) > 0 {
+				failures = append(failures, v)
+				continue
+			}
+			passed++
+			merge(props)
+		}
+		switch {
+		case passed == 0:
+			fail(closestBranch(node, failures)...)
+		case passed > 1 && keyword == "oneOf":
+			fail(violation(node, path, at+"/oneOf", describeSchema(sch,
+				fmt.Sprintf("matches %d of the schemas allowed, only one is allowed", passed))))
+		}
+	}
+	if not, ok := sch["not"]; ok {
+		if v, _ := s.evaluate(res, not, at+"/not", node, path); len(v) == 0 {
+			fail(violation(node, path, at+"/not", describeSchema(sch, describeSchema(not, "matches a schema that is not allowed"))))
+		}
+	}
+	if cond, ok := sch["if"]; ok {
+		v, props := s.evaluate(res, cond, at+"/if", node, path)
+		branch := "else"
+		if len(v) == 0 {
+			merge(props)
+			branch = "then"
+		}
+		if sub, ok := sch[branch]; ok {
+			v, props = s.evaluate(res, sub, at+"/"+branch, node, path)
+			fail(v...)
+			merge(props)
+		}
+	}
+
+	// unevaluated properties are checked once every other keyword has been.
+	if unevaluated, ok := sch["unevaluatedProperties"]; ok && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if evaluated[key] {
+				continue
+			}
+			if allowed, isBool := unevaluated.(bool); isBool && !allowed {
+				fail(propertyViolation(node.Content[i], path, key, at+"/unevaluatedProperties"))
+				continue
+			}
+			v, _ := s.evaluate(res, unevaluated, at+"/unevaluatedProperties", node.Content[i+1],
+				utils.AppendPathSegment(path, key))
+			fail(v...)
+			evaluated[key] = true
+		}
+	}
+	return violations, evaluated
+}
+
+func (s *Schema) checkString(sch map[string]any, at string, node *yaml.Node, path []string) []*Violation {
+	var violations []*Violation
+	if pattern, ok := sch["pattern"].(string); ok {
+		if exp := s.pattern(pattern); exp != nil && !exp.MatchString(node.Value) {
+			violations = append(violations, violation(node, path, at+"/pattern",
+				fmt.Sprintf("%s does not match the pattern '%s'", describeValue(node), pattern)))
+		}
+	}
+	length := len([]rune(node.Value))
+	if min, ok := sch["minLength"].(float64); ok && float64(length) < min {
+		violations = append(violations, violation(node, path, at+"/minLength",
+			fmt.Sprintf("%s is shorter than %v characters", describeValue(node), min)))
+	}
+	if max, ok := sch["maxLength"].(float64); ok && float64(length) > max {
+		violations = append(violations, violation(node, path, at+"/maxLength",
+			fmt.Sprintf("%s is longer than %v characters", describeValue(node), max)))
+	}
+	return violations
+}
+
+func checkNumber(sch map[string]any, at string, node *yaml.Node, path []string) []*Violation {
+	n, ok := nodeValue(node).(float64)
+	if !ok {
+		return nil
+	}
+	var violations []*Violation
+	check := func(keyword string, limit float64, ok bool, exclusive bool, below bool) {
+		if !ok {
+			return
+		}
+		if (below && (n < limit || (exclusive && n == limit))) || (!below && (n > limit || (exclusive && n == limit))) {
+			relation := "at least"
+			if below && exclusive {
+				relation = "more than"
+			} else if !below {
+				relation = "at most"
+				if exclusive {
+					relation = "less than"
+				}
+			}
+			violations = append(violations, violation(node, path, at+"/"+keyword,
+				fmt.Sprintf("%s must be %s %v", node.Value, relation, limit)))
+		}
+	}
+	min, hasMin := sch["minimum"].(float64)
+	max, hasMax := sch["maximum"].(float64)
+	exclusiveMin, _ := sch["exclusiveMinimum"].(bool)
+	exclusiveMax, _ := sch["exclusiveMaximum"].(bool)
+	check("minimum", min, hasMin, exclusiveMin, true)
+	check("maximum", max, hasMax, exclusiveMax, false)
+	if limit, ok := sch["exclusiveMinimum"].(float64); ok {
+		check("exclusiveMinimum", limit, true, true, true)
+	}
+	if limit, ok := sch["exclusiveMaximum"].(float64); ok {
+		check("exclusiveMaximum", limit, true, true, false)
+	}
+	if m, ok := sch["multipleOf"].(float64); ok && m > 0 {
+		if q := n / m; math.Abs(q-math.Round(q)) > 1e-9 {
+			violations = append(violations, violation(node, path, at+"/multipleOf",
+				fmt.Sprintf("%s is not a multiple of %v", node.Value, m)))
+		}
+	}
+	return violations
+}
+
+func (s *Schema) checkObject(res *resource, sch map[string]any, at string, node *yaml.Node,
+	path []string,
+) ([]*Violation, map[string]bool) {
+	var violations []*Violation
+	evaluated := make(map[string]bool)
+	present := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		present[node.Content[i].Value] = true
+	}
+	requireAll := func(required []any, keyword string) {
+		for _, r := range required {
+			if name, ok := r.(string); ok && !present[name] {
+				violations = append(violations, violation(node, path, at+"/"+keyword,
+					fmt.Sprintf("missing required property '%s'", name)))
+			}
+		}
+	}
+	if required, ok := sch["required"].([]any); ok {
+		requireAll(required, "required")
+	}
+	if min, ok := sch["minProperties"].(float64); ok && float64(len(present)) < min {
+		violations = append(violations, violation(node, path, at+"/minProperties",
+			fmt.Sprintf("must have at least %v properties", min)))
+	}
+	if max, ok := sch["maxProperties"].(float64); ok && float64(len(present)) > max {
+		violations = append(violations, violation(node, path, at+"/maxProperties",
+			fmt.Sprintf("must have at most %v properties", max)))
+	}
+
+	properties, _ := sch["properties"].(map[string]any)
+	patterns, _ := sch["patternProperties"].(map[string]any)
+	additional, hasAdditional := sch["additionalProperties"]
+	names, hasNames := sch["propertyNames"]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		childPath := utils.AppendPathSegment(path, key)
+		matched := false
+		if sub, ok := properties[key]; ok {
+			matched = true
+			v, _ := s.evaluate(res, sub, at+"/properties/"+utils.EscapeJSONPointerSegment(key), value, childPath)
+			violations = append(violations, v...)
+		}
+		for pattern, sub := range patterns {
+			if exp := s.pattern(pattern); exp != nil && exp.MatchString(key) {
+				matched = true
+				v, _ := s.evaluate(res, sub, at+"/patternProperties/"+utils.EscapeJSONPointerSegment(pattern), value,
+					childPath)
+				violations = append(violations, v...)
+			}
+		}
+		if !matched && hasAdditional {
+			matched = true
+			if allowed, isBool := additional.(bool); isBool && !allowed {
+				violations = append(violations, propertyViolation(keyNode, path, key, at+"/additionalProperties"))
+			} else {
+				v, _ := s.evaluate(res, additional, at+"/additionalProperties", value, childPath)
+				violations = append(violations, v...)
+			}
+		}
+		if matched {
+			evaluated[key] = true
+		}
+		if hasNames {
+			v, _ := s.evaluate(res, names, at+"/propertyNames", keyNode, childPath)
+			violations = append(violations, v...)
+		}
+	}
+
+	// dependencies (draft 4), dependentRequired and dependentSchemas.
+	for _, keyword := range []string{"dependencies", "dependentRequired", "dependentSchemas"} {
+		deps, ok := sch[keyword].(map[string]any)
+		if !ok {
+			continue
+		}
+		for name, dep := range deps {
+			if !present[name] {
+				continue
+			}
+			if required, ok := dep.([]any); ok {
+				requireAll(required, keyword)
+				continue
+			}
+			v, props := s.evaluate(res, dep, at+"/"+keyword+"/"+utils.EscapeJSONPointerSegment(name), node, path)
+			violations = append(violations, v...)
+			if len(v) == 0 {
+				for k := range props {
+					evaluated[k] = true
+				}
+			}
+		}
+	}
+	return violations, evaluated
+}
+
+func (s *Schema) checkArray(res *resource, sch map[string]any, at string, node *yaml.Node,
+	path []string,
+) []*Violation {
+	var violations []*Violation
+	items := node.Content
+	if min, ok := sch["minItems"].(float64); ok && float64(len(items)) < min {
+		violations = append(violations, violation(node, path, at+"/minItems",
+			fmt.Sprintf("must have at least %v items", min)))
+	}
+	if max, ok := sch["maxItems"].(float64); ok && float64(len(items)) > max {
+		violations = append(violations, violation(node, path, at+"/maxItems",
+			fmt.Sprintf("must have at most %v items", max)))
+	}
+	if unique, ok := sch["uniqueItems"].(bool); ok && unique {
+		values := make([]any, len(items))
+		for i, item := range items {
+			values[i] = nodeValue(item)
+			for j := 0; j < i; j++ {
+				if reflect.DeepEqual(values[i], values[j]) {
+					violations = append(violations, violation(item, utils.AppendPathSegment(path, strconv.Itoa(i)),
+						at+"/uniqueItems", fmt.Sprintf("duplicates item %d", j)))
+					break
+				}
+			}
+		}
+	}
+
+	// a list of schemas (prefixItems, or items in draft 4) check items by position, the rest are checked by
+	// additionalItems (draft 4) or items.
+	prefix, rest, restKeyword := sch["prefixItems"], sch["items"], "items"
+	if tuple, ok := rest.([]any); ok {
+		prefix, rest, restKeyword = tuple, sch["additionalItems"], "additionalItems"
+	}
+	prefixKeyword := "prefixItems"
+	if res.draft4 {
+		prefixKeyword = "items"
+	}
+	tuple, _ := prefix.([]any)
+	for i, item := range items {
+		itemPath := utils.AppendPathSegment(path, strconv.Itoa(i))
+		if i < len(tuple) {
+			v, _ := s.evaluate(res, tuple[i], fmt.Sprintf("%s/%s/%d", at, prefixKeyword, i), item, itemPath)
+			violations = append(violations, v...)
+			continue
+		}
+		if rest == nil {
+			continue
+		}
+		v, _ := s.evaluate(res, rest, at+"/"+restKeyword, item, itemPath)
+		violations = append(violations, v...)
+	}
+	return violations
+}
+
+// resolve locates the schema a reference points to, either a JSON pointer or an anchor, in the resource the
+// reference is in, or in another resource.
+func (s *Schema) resolve(res *resource, ref string) (any, *resource, string, bool) {
+	base, fragment, _ := strings.Cut(ref, "#")
+	if base != "" {
+		target, ok := s.resources[strings.TrimSuffix(base, "#")]
+		if !ok {
+			return nil, nil, "", false
+		}
+		res = target
+	}
+	at := res.id + "#" + fragment
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		schema, ok := res.anchors[fragment]
+		return schema, res, at, ok
+	}
+	schema := res.root
+	if fragment == "" {
+		return schema, res, at, true
+	}
+	for _, segment := range strings.Split(fragment[1:], "/") {
+		segment = utils.UnescapeJSONPointerSegment(segment)
+		switch v := schema.(type) {
+		case map[string]any:
+			child, ok := v[segment]
+			if !ok {
+				return nil, nil, "", false
+			}
+			schema = child
+		case []any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, nil, "", false
+			}
+			schema = v[i]
+		default:
+			return nil, nil, "", false
+		}
+	}
+	return schema, res, at, true
+}
+
+// pattern compiles a regular expression once, patterns that don't compile are ignored.
+func (s *Schema) pattern(pattern string) *regexp.Regexp {
+	if exp, ok := s.patterns.Load(pattern); ok {
+		return exp.(*regexp.Regexp)
+	}
+	exp, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	s.patterns.Store(pattern, exp)
+	return exp
+}
+
+// closestBranch picks the violations of the anyOf or oneOf branch the node most likely meant to match. A branch
+// that needs a $ref the node doesn't have is a reference, which the node is not trying to be. Otherwise the branch
+// that got the deepest into the node before failing is the closest, then the branch with the fewest violations.
+func closestBranch(node *yaml.Node, branches [][]*Violation) []*Violation {
+	if len(branches) == 0 {
+		return nil
+	}
+	wantsRef := func(violations []*Violation) bool {
+		for _, v := range violations {
+			if v.Message == "missing required property '$ref'" && v.Node == node {
+				return true
+			}
+		}
+		return false
+	}
+	depth := func(violations []*Violation) int {
+		d := 0
+		for _, v := range violations {
+			if n := strings.Count(v.Pointer, "/"); n > d {
+				d = n
+			}
+		}
+		return d
+	}
+	best := branches[0]
+	for _, b := range branches[1:] {
+		switch {
+		case wantsRef(best) != wantsRef(b):
+			if wantsRef(best) {
+				best = b
+			}
+		case depth(b) != depth(best):
+			if depth(b) > depth(best) {
+				best = b
+			}
+		case len(b) < len(best):
+			best = b
+		}
+	}
+
+	// branches that each allow different values for the same value (like the locations of parameters) are merged,
+	// so every value allowed is reported.
+	var merged []*Violation
+	for _, v := range best {
+		if v.allowed == nil {
+			merged = append(merged, v)
+			continue
+		}
+		var allowed []any
+		for _, b := range branches {
+			for _, other := range b {
+				if other.Node == v.Node && other.allowed != nil {
+					for _, a := range other.allowed {
+						if !containsValue(allowed, a) {
+							allowed = append(allowed, a)
+						}
+					}
+				}
+			}
+		}
+		m := notAllowedValue(v.Node, nil, v.SchemaPointer, allowed)
+		m.Pointer = v.Pointer
+		merged = append(merged, m)
+	}
+	return merged
+}
+
+func violation(node *yaml.Node, path []string, at, message string) *Violation {
+	return &Violation{
+		Message:       message,
+		Pointer:       "#" + utils.BuildJSONPointer(path),
+		SchemaPointer: at,
+		Line:          node.Line,
+		Column:        node.Column,
+		Node:          node,
+	}
+}
+
+// notAllowedValue is a value that is not one of the values allowed by an enum or const.
+func notAllowedValue(node *yaml.Node, path []string, at string, allowed []any) *Violation {
+	v := violation(node, path, at, fmt.Sprintf("%s is not one of %s", describeValue(node), describeValues(allowed)))
+	if len(allowed) == 1 {
+		v.Message = fmt.Sprintf("%s must be %s", describeValue(node), describeValues(allowed))
+	}
+	v.allowed = allowed
+	return v
+}
+
+// propertyViolation is a property that is not allowed, located at its key.
+func propertyViolation(keyNode *yaml.Node, path []string, key, at string) *Violation {
+	return violation(keyNode, utils.AppendPathSegment(path, key), at, fmt.Sprintf("property '%s' is not allowed", key))
+}
+
+// instanceType returns the JSON type of a node.
+func instanceType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			return instanceType(node.Alias)
+		}
+	}
+	switch node.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+func matchesType(node *yaml.Node, t any) bool {
+	actual := instanceType(node)
+	matches := func(expected string) bool {
+		switch {
+		case expected == actual:
+			return true
+		case expected == "number" && actual == "integer":
+			return true
+		case expected == "integer" && actual == "number":
+			n, ok := nodeValue(node).(float64)
+			return ok && n == math.Trunc(n)
+		}
+		return false
+	}
+	switch v := t.(type) {
+	case string:
+		return matches(v)
+	case []any:
+		for _, e := range v {
+			if s, ok := e.(string); ok && matches(s) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// nodeValue converts a node into the value it would have once decoded from JSON.
+func nodeValue(node *yaml.Node) any {
+	switch node.Kind {
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			return nodeValue(node.Alias)
+		}
+		return nil
+	case yaml.MappingNode:
+		m := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			m[node.Content[i].Value] = nodeValue(node.Content[i+1])
+		}
+		return m
+	case yaml.SequenceNode:
+		s := make([]any, len(node.Content))
+		for i, item := range node.Content {
+			s[i] = nodeValue(item)
+		}
+		return s
+	}
+	switch instanceType(node) {
+	case "integer", "number", "boolean":
+		var v any
+		if err := node.Decode(&v); err != nil {
+			return node.Value
+		}
+		switch n := v.(type) {
+		case int:
+			return float64(n)
+		case int64:
+			return float64(n)
+		case uint64:
+			return float64(n)
+		}
+		return v
+	case "null":
+		return nil
+	}
+	return node.Value
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeValue(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		return "the " + instanceType(node)
+	}
+	return "'" + node.Value + "'"
+}
+
+func describeValues(values []any) string {
+	described := make([]string, len(values))
+	for i, v := range values {
+		b, _ := json.Marshal(v)
+		described[i] = strings.ReplaceAll(string(b), `"`, "'")
+	}
+	return strings.Join(described, ", ")
+}
+
+func describeType(t any) string {
+	if types, ok := t.([]any); ok {
+		described := make([]string, len(types))
+		for i, v := range types {
+			described[i] = fmt.Sprint(v)
+		}
+		return strings.Join(described, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// describeSchema returns the description of a schema, which usually explains why it failed, or the fallback.
+func describeSchema(schema any, fallback string) string {
+	if m, ok := schema.(map[string]any); ok {
+		if d, ok := m["description"].(string); ok && d != "" {
+			return d
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func validateYAML(t *testing.T, schema *Schema, value string) []string {
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(value), &node))
+	var messages []string
+	for _, v := range schema.Validate(&node) {
+		messages = append(messages, v.Pointer+": "+v.Message)
+	}
+	return messages
+}
+
+func TestSchema_Draft4(t *testing.T) {
+	schema, err := CompileSchema(`{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string", "pattern": "^[a-z]+$", "minLength": 2, "maxLength": 5},
+    "size": {"type": "integer", "minimum": 1, "maximum": 10, "exclusiveMaximum": true},
+    "ratio": {"type": "number", "multipleOf": 0.5},
+    "tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true, "minItems": 1, "maxItems": 3},
+    "pair": {"type": "array", "items": [{"type": "string"}, {"type": "integer"}], "additionalItems": false},
+    "kind": {"enum": ["pizza", "burger"]},
+    "choice": {"$ref": "#/definitions/choice", "type": "string"}
+  },
+  "patternProperties": {"^x-": {}},
+  "additionalProperties": false,
+  "dependencies": {"size": ["kind"]},
+  "definitions": {
+    "choice": {"oneOf": [{"type": "integer"}, {"minimum": 0}]}
+  }
+}`)
+	assert.NoError(t, err)
+
+	assert.Empty(t, validateYAML(t, schema, `name: abc
+size: 3
+kind: pizza
+ratio: 1.5
+tags: [a, b]
+pair: [a, 1]
+x-anything: true
+choice: -1`))
+
+	assert.Equal(t, []string{
+		"#/name: 'Abcdef' does not match the pattern '^[a-z]+$'",
+		"#/name: 'Abcdef' is longer than 5 characters",
+		"#/size: 10 must be less than 10",
+		"#/ratio: 1.2 is not a multiple of 0.5",
+		"#/tags/1: duplicates item 0",
+		"#/pair/0: expected string, found integer",
+		"#/pair/2: no value is allowed here",
+		"#/kind: 'salad' is not one of 'pizza', 'burger'",
+		"#/choice: matches 2 of the schemas allowed, only one is allowed",
+		"#/colour: property 'colour' is not allowed",
+	}, validateYAML(t, schema, `name: Abcdef
+size: 10
+ratio: 1.2
+tags: [a, a]
+pair: [1, 1, 1]
+kind: salad
+choice: 1
+colour: red`))
+
+	assert.Equal(t, []string{"#: missing required property 'kind'"}, validateYAML(t, schema, `name: ab
+size: 2`))
+	assert.Equal(t, []string{"#: expected object, found array"}, validateYAML(t, schema, `[]`))
+}
+
+func TestSchema_2020(t *testing.T) {
+	schema, err := CompileSchema(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/pizza",
+  "type": "object",
+  "properties": {"type": {"enum": ["pizza", "burger"]}},
+  "if": {"properties": {"type": {"const": "pizza"}}},
+  "then": {"properties": {"topping": {"type": "string"}}, "required": ["topping"]},
+  "else": {"properties": {"bun": {"$dynamicRef": "#bun"}}},
+  "dependentSchemas": {"size": {"properties": {"size": {"type": "integer"}}}},
+  "propertyNames": {"maxLength": 7},
+  "not": {"required": ["poison"], "description": "poison is not allowed"},
+  "unevaluatedProperties": false,
+  "$defs": {"bun": {"$dynamicAnchor": "bun", "const": "brioche"}}
+}`)
+	assert.NoError(t, err)
+
+	assert.Empty(t, validateYAML(t, schema, `type: pizza
+topping: cheese
+size: 12`))
+	assert.Empty(t, validateYAML(t, schema, `type: burger
+bun: brioche`))
+
+	assert.Equal(t, []string{
+		"#: poison is not allowed",
+		"#: missing required property 'topping'",
+		"#/bun: property 'bun' is not allowed",
+		"#/poison: property 'poison' is not allowed",
+	}, validateYAML(t, schema, `type: pizza
+bun: brioche
+poison: true`))
+
+	assert.Equal(t, []string{
+		"#/bun: 'sesame' must be 'brioche'",
+		"#/dressing: 'dressing' is longer than 7 characters",
+		"#/dressing: property 'dressing' is not allowed",
+	}, validateYAML(t, schema, `type: burger
+bun: sesame
+dressing: ranch`))
+}
+
+func TestSchema_References(t *testing.T) {
+	schema, err := CompileSchema(`{"$ref": "https://example.com/other#/$defs/name"}`,
+		`{"$id": "https://example.com/other", "$defs": {"name": {"type": "string"}}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"#: expected string, found boolean"}, validateYAML(t, schema, `true`))
+
+	schema, _ = CompileSchema(`{"$ref": "#/$defs/missing"}`)
+	assert.Equal(t, []string{"#: unable to resolve schema reference '#/$defs/missing'"},
+		validateYAML(t, schema, `true`))
+
+	_, err = CompileSchema(`{`)
+	assert.Error(t, err)
+	assert.Nil(t, schema.Validate(nil))
+}
+
+func TestSchema_Aliases(t *testing.T) {
+	schema, _ := CompileSchema(`{"type": "object", "additionalProperties": {"type": "object", "required": ["name"]}}`)
+	assert.Equal(t, []string{"#/b: missing required property 'name'"}, validateYAML(t, schema, `a: &pizza
+  name: pizza
+b: &burger
+  size: 1
+c: *pizza`))
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/libopenapi/walk"
+	"gopkg.in/yaml.v3"
+)
+
+var uuidExp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidateSchemaValues checks the values declared by every schema in a high-level model (like a *v3.Document or
+// *v2.Swagger) conform to the type and format of the schema. The members of 'enum', along with 'default' and
+// 'const', are checked. A 'default' that is not one of the members of 'enum' is reported as well.
+//
+// The formats checked are 'int32', 'int64', 'date', 'date-time' and 'uuid'. Values of schemas without a type are
+// not checked. Violations are located at the offending value, and their SchemaPointer is the keyword that
+// declares the value. The model must have been built from a document, values of schemas created in code are not
+// checked.
+func ValidateSchemaValues(root any) []*Violation {
+	var violations []*Violation
+	walk.Walk(root, func(node *walk.Node) walk.Action {
+		sp, ok := node.Value.(*base.SchemaProxy)
+		if !ok || node.Reference != "" || node.Extension {
+			return walk.Continue
+		}
+		sch := sp.Schema()
+		if sch == nil || sch.GoLow() == nil {
+			return walk.Continue
+		}
+		violations = append(violations, checkSchemaValues(sch, node.Path)...)
+		return walk.Continue
+	})
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Line != violations[j].Line {
+			return violations[i].Line < violations[j].Line
+		}
+		return violations[i].Column < violations[j].Column
+	})
+	return violations
+}
+
+func checkSchemaValues(sch *base.Schema, path []string) []*Violation {
+	low := sch.GoLow()
+	types := make([]any, 0, len(sch.Type)+1)
+	for _, t := range sch.Type {
+		types = append(types, t)
+	}
+	if sch.Nullable != nil && *sch.Nullable {
+		types = append(types, "null")
+	}
+
+	var violations []*Violation
+	check := func(keyword string, node *yaml.Node, valuePath []string) {
+		if node == nil {
+			return
+		}
+		at := "#" + utils.BuildJSONPointer(utils.AppendPathSegment(path, keyword))
+		if len(sch.Type) > 0 && !matchesType(node, types) {
+			violations = append(violations, violation(node, valuePath, at,
+				fmt.Sprintf("%s value %s is not of type %s", keyword, describeValue(node), describeType(types))))
+			return
+		}
+		if msg := checkFormat(node, sch.Format); msg != "" {
+			violations = append(violations, violation(node, valuePath, at,
+				fmt.Sprintf("%s value %s %s", keyword, describeValue(node), msg)))
+		}
+	}
+
+	var enum []any
+	if n := low.Enum.ValueNode; n != nil && n.Kind == yaml.SequenceNode {
+		for i, member := range n.Content {
+			check("enum", member, utils.AppendPathSegment(utils.AppendPathSegment(path, "enum"), strconv.Itoa(i)))
+			enum = append(enum, nodeValue(member))
+		}
+	}
+	if n := low.Default.ValueNode; n != nil {
+		check("default", n, utils.AppendPathSegment(path, "default"))
+		if enum != nil && !containsValue(enum, nodeValue(n)) {
+			violations = append(violations, violation(n, utils.AppendPathSegment(path, "default"),
+				"#"+utils.BuildJSONPointer(utils.AppendPathSegment(path, "enum")),
+				fmt.Sprintf("default value %s is not one of %s", describeValue(n), describeValues(enum))))
+		}
+	}
+	if n := low.Const.ValueNode; n != nil {
+		check("const", n, utils.AppendPathSegment(path, "const"))
+	}
+	return violations
+}
+
+// checkFormat returns why a value does not conform to a format, or an empty string if it does (or the format
+// is not checked).
+func checkFormat(node *yaml.Node, format string) string {
+	switch instanceType(node) {
+	case "integer", "number":
+		// integers are parsed as well, to catch those too big to decode, or for a float64 to tell apart.
+		_, err := strconv.ParseInt(node.Value, 0, 64)
+		tooBig := err != nil && instanceType(node) == "integer"
+		n, _ := nodeValue(node).(float64)
+		switch format {
+		case "int32":
+			if tooBig || n != math.Trunc(n) || n < math.MinInt32 || n > math.MaxInt32 {
+				return "is not a valid int32"
+			}
+		case "int64":
+			if tooBig || n != math.Trunc(n) {
+				return "is not a valid int64"
+			}
+		}
+	case "string":
+		var err error
+		switch format {
+		case "date":
+			_, err = time.Parse("2006-01-02", node.Value)
+		case "date-time":
+			_, err = time.Parse(time.RFC3339, node.Value)
+		case "uuid":
+			if !uuidExp.MatchString(node.Value) {
+				return "is not a valid uuid"
+			}
+		}
+		if err != nil {
+			return "is not a valid " + format
+		}
+	}
+	return ""
+}
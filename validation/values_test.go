@@ -0,0 +1,96 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchemaValues(t *testing.T) {
+	info, _ := datamodel.ExtractSpecInfo([]byte(`openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            format: int32
+            default: 3000000000
+components:
+  schemas:
+    Size:
+      type: integer
+      enum: [1, 2, 'three', 4.5]
+      default: 5
+    Status:
+      type: string
+      nullable: true
+      enum: [available, sold, null]
+      default: available
+    Pet:
+      type: object
+      properties:
+        id:
+          type: string
+          format: uuid
+          const: not-a-uuid
+        born:
+          type: string
+          format: date
+          default: 2020-02-30
+        tags:
+          type: array
+          default: none
+        anything:
+          enum: [1, 'one']`))
+	doc, errs := v3low.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	assert.Empty(t, errs)
+
+	violations := ValidateSchemaValues(v3high.NewDocument(doc))
+	assert.Len(t, violations, 7)
+
+	assert.Equal(t, "default value '3000000000' is not a valid int32", violations[0].Message)
+	assert.Equal(t, "#/paths/~1pets/get/parameters/0/schema/default", violations[0].Pointer)
+	assert.Equal(t, 11, violations[0].Line)
+
+	assert.Equal(t, "enum value 'three' is not of type integer", violations[1].Message)
+	assert.Equal(t, "#/components/schemas/Size/enum/2", violations[1].Pointer)
+	assert.Equal(t, "#/components/schemas/Size/enum", violations[1].SchemaPointer)
+	assert.Equal(t, "enum value '4.5' is not of type integer", violations[2].Message)
+	assert.Equal(t, "default value '5' is not one of 1, 2, 'three', 4.5", violations[3].Message)
+	assert.Equal(t, "const value 'not-a-uuid' is not a valid uuid", violations[4].Message)
+	assert.Equal(t, "default value '2020-02-30' is not a valid date", violations[5].Message)
+	assert.Equal(t, "default value 'none' is not of type array", violations[6].Message)
+}
+
+func TestValidateSchemaValues_Swagger(t *testing.T) {
+	info, _ := datamodel.ExtractSpecInfo([]byte(`swagger: 2.0
+definitions:
+  Pet:
+    type: object
+    properties:
+      count:
+        type: integer
+        format: int64
+        enum: [1, 9223372036854775808]
+      name:
+        type: string
+        default: 12`))
+	doc, errs := v2low.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	assert.Empty(t, errs)
+
+	violations := ValidateSchemaValues(v2high.NewSwaggerDocument(doc))
+	assert.Len(t, violations, 2)
+	assert.Equal(t, "enum value '9223372036854775808' is not a valid int64", violations[0].Message)
+	assert.Equal(t, "default value '12' is not of type string", violations[1].Message)
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package walk
+
+import (
+	"strconv"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PathMatch is a single result of a JSONPath query.
+type PathMatch struct {
+	KeyNode     *yaml.Node // the key of the matched node in its parent mapping, nil for sequence items and the root.
+	ValueNode   *yaml.Node // the matched node.
+	Path        []string   // the (unescaped) JSON pointer segments to the matched node.
+	JSONPointer string     // the JSON pointer to the matched node, for example '/paths/~1pets/get'.
+	Object      *Node      // the model object built from the matched node, nil if the node is not an object.
+}
+
+// QueryPath evaluates a JSONPath expression (for example "$.paths..responses['200']") against the yaml node tree
+// the model was built from (rootNode), returning every matching yaml node along with the model object it maps to.
+//
+// Matched nodes that are not objects in the model (scalars, or mappings that have no model object of their own,
+// like the 'schemas' mapping in components) will have a nil Object.
+func QueryPath(root any, rootNode *yaml.Node, path string) ([]*PathMatch, error) {
+	found, err := utils.FindNodesWithoutDeserializing(rootNode, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	// map every yaml node back to its position in the document.
+	type position struct {
+		key  *yaml.Node
+		path []string
+	}
+	positions := make(map[*yaml.Node]position)
+	var locate func(n *yaml.Node, key *yaml.Node, path []string)
+	locate = func(n *yaml.Node, key *yaml.Node, path []string) {
+		if _, ok := positions[n]; ok {
+			return
+		}
+		positions[n] = position{key: key, path: path}
+		switch n.Kind {
+		case yaml.DocumentNode:
+			for _, c := range n.Content {
+				locate(c, nil, path)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				locate(n.Content[i+1], n.Content[i], utils.AppendPathSegment(path, n.Content[i].Value))
+			}
+		case yaml.SequenceNode:
+			for i, c := range n.Content {
+				locate(c, nil, utils.AppendPathSegment(path, strconv.Itoa(i)))
+			}
+		}
+	}
+	locate(rootNode, nil, nil)
+
+	// map every yaml node back to the model objects built from it, references can share a node with the
+	// object they reference, so the object with the same pointer as the node is preferred.
+	objects := make(map[*yaml.Node][]*Node)
+	var rootObject *Node
+	Walk(root, func(node *Node) Action {
+		if node.Parent == nil {
+			rootObject = node
+		}
+		if node.ValueNode != nil {
+			objects[node.ValueNode] = append(objects[node.ValueNode], node)
+		}
+		return Continue
+	})
+
+	matches := make([]*PathMatch, 0, len(found))
+	for _, n := range found {
+		p := positions[n]
+		m := &PathMatch{KeyNode: p.key, ValueNode: n, Path: p.path, JSONPointer: BuildJSONPointer(p.path)}
+		if len(p.path) == 0 && n.Kind != yaml.DocumentNode {
+			m.Object = rootObject
+		}
+		for _, o := range objects[n] {
+			if m.Object == nil || (o.JSONPointer() == m.JSONPointer && m.Object.JSONPointer() != m.JSONPointer) {
+				m.Object = o
+			}
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
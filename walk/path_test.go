@@ -0,0 +1,58 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package walk
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryPath(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	matches, err := QueryPath(doc, doc.Index.GetRootNode(), "$.paths..responses['200']")
+	assert.NoError(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "/paths/~1pets~1{id}/get/responses/200", matches[0].JSONPointer)
+	assert.Equal(t, "200", matches[0].KeyNode.Value)
+	assert.IsType(t, &v3.Response{}, matches[0].Object.Value)
+
+	matches, err = QueryPath(doc, doc.Index.GetRootNode(), "$..properties.*")
+	assert.NoError(t, err)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "/components/schemas/Pet/properties/name", matches[0].JSONPointer)
+	assert.IsType(t, &base.SchemaProxy{}, matches[0].Object.Value)
+}
+
+func TestQueryPath_NotObjects(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	// scalars, and mappings that are not objects in the model have no object.
+	matches, err := QueryPath(doc, doc.Index.GetRootNode(), "$.info.title")
+	assert.NoError(t, err)
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "walk", matches[0].ValueNode.Value)
+	assert.Nil(t, matches[0].Object)
+
+	matches, _ = QueryPath(doc, doc.Index.GetRootNode(), "$.components.schemas")
+	assert.Nil(t, matches[0].Object)
+
+	// the root is the document.
+	matches, _ = QueryPath(doc, doc.Index.GetRootNode(), "$")
+	assert.Equal(t, doc, matches[len(matches)-1].Object.Value)
+}
+
+func TestQueryPath_Errors(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	matches, err := QueryPath(doc, doc.Index.GetRootNode(), "$.nope")
+	assert.NoError(t, err)
+	assert.Nil(t, matches)
+
+	_, err = QueryPath(doc, doc.Index.GetRootNode(), "$.paths[")
+	assert.Error(t, err)
+}
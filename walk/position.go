@@ -0,0 +1,38 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package walk
+
+import (
+	"github.com/pb33f/libopenapi/index"
+)
+
+// ObjectAtPosition returns the most specific high-level object in the model that covers the supplied line and
+// column of the document the model was built from, along with its JSON pointer (see Node.JSONPointer). This is
+// intended for editor and language server integrations. Returns nil if nothing in the model covers the position,
+// or the model was not built from a document.
+//
+// Schemas that are references are never returned, the position belongs to the schema they reference, or the $ref
+// itself. Use index.SpecIndex.ObjectAtPosition to look up positions in other files.
+func ObjectAtPosition(root any, line, col int) *Node {
+	var found *Node
+	Walk(root, func(node *Node) Action {
+		if node.ValueNode == nil {
+			// no way to know, the children might be covering the position.
+			return Continue
+		}
+		span := index.CalculateNodeSpan(node.ValueNode)
+		if node.KeyNode != nil {
+			ks := index.CalculateNodeSpan(node.KeyNode)
+			span.StartLine, span.StartColumn = ks.StartLine, ks.StartColumn
+		}
+		if node.Reference != "" || !span.Contains(line, col) {
+			return SkipChildren
+		}
+		if found == nil || node.Depth > found.Depth {
+			found = node
+		}
+		return Continue
+	})
+	return found
+}
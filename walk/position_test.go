@@ -0,0 +1,43 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package walk
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectAtPosition(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	// operationId: getPet
+	node := ObjectAtPosition(doc, 9, 20)
+	assert.IsType(t, &v3.Operation{}, node.Value)
+	assert.Equal(t, "/paths/~1pets~1{id}/get", node.JSONPointer())
+
+	// in: path
+	node = ObjectAtPosition(doc, 12, 15)
+	assert.IsType(t, &v3.Parameter{}, node.Value)
+	assert.Equal(t, "/paths/~1pets~1{id}/get/parameters/0", node.JSONPointer())
+
+	// type: string of the parameter schema.
+	node = ObjectAtPosition(doc, 14, 20)
+	assert.IsType(t, &base.SchemaProxy{}, node.Value)
+
+	// the $ref belongs to the media type, not the schema it references.
+	node = ObjectAtPosition(doc, 21, 25)
+	assert.IsType(t, &v3.MediaType{}, node.Value)
+
+	// on the key of a component schema.
+	node = ObjectAtPosition(doc, 24, 6)
+	assert.Equal(t, "/components/schemas/Pet", node.JSONPointer())
+
+	node = ObjectAtPosition(doc, 5, 5)
+	assert.True(t, node.Extension)
+
+	assert.Nil(t, ObjectAtPosition(doc, 1000, 1))
+}
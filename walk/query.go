@@ -0,0 +1,69 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package walk
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// ParseJSONPointer splits a JSON pointer (RFC 6901) into unescaped segments. A leading '#' (URI fragment form)
+// is allowed, an empty pointer represents the root.
+func ParseJSONPointer(pointer string) ([]string, error) {
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer '%s', it must start with '/'", pointer)
+	}
+	segments := strings.Split(pointer[1:], "/")
+	for i := range segments {
+		segments[i] = utils.UnescapeJSONPointerSegment(segments[i])
+	}
+	return segments, nil
+}
+
+// QueryPointer locates the high-level object a JSON pointer points to, starting from the supplied root, for
+// example '/paths/~1pets/get/responses/200'. The Node returned holds the typed high-level object, along with
+// its low-level object and yaml nodes.
+//
+// Schemas that are references are traversed, so a pointer can continue through a $ref into the schema it
+// references. Pointers must point to an object (or an extension), scalar values are not supported.
+func QueryPointer(root any, pointer string) (*Node, error) {
+	target, err := ParseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	var found *Node
+	WalkWithConfig(root, func(node *Node) Action {
+		if !hasPathPrefix(target, node.Path) {
+			return SkipChildren
+		}
+		if len(node.Path) == len(target) {
+			found = node
+			return Stop
+		}
+		return Continue
+	}, &Config{FollowReferences: true, descendCircular: true})
+	if found == nil {
+		return nil, fmt.Errorf("unable to locate an object at '%s'", pointer)
+	}
+	return found, nil
+}
+
+// hasPathPrefix checks if the prefix is the start of (or the same as) the path.
+func hasPathPrefix(path, prefix []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
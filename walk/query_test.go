@@ -0,0 +1,72 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package walk
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSONPointer(t *testing.T) {
+	segments, err := ParseJSONPointer("#/paths/~1pets~1{id}/x~0y")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"paths", "/pets/{id}", "x~y"}, segments)
+
+	segments, err = ParseJSONPointer("")
+	assert.NoError(t, err)
+	assert.Nil(t, segments)
+
+	_, err = ParseJSONPointer("paths")
+	assert.Error(t, err)
+}
+
+func TestQueryPointer(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	node, err := QueryPointer(doc, "/paths/~1pets~1{id}/get/responses/200")
+	assert.NoError(t, err)
+	resp := node.Value.(*v3.Response)
+	assert.Equal(t, "ok", resp.Description)
+	assert.Equal(t, resp.GoLow(), node.Low)
+	assert.Equal(t, "200", node.KeyNode.Value)
+
+	node, err = QueryPointer(doc, "#/components/schemas/Pet")
+	assert.NoError(t, err)
+	assert.IsType(t, &base.SchemaProxy{}, node.Value)
+
+	node, err = QueryPointer(doc, "")
+	assert.NoError(t, err)
+	assert.Equal(t, doc, node.Value)
+}
+
+func TestQueryPointer_References(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	// through the $ref to Pet.
+	node, err := QueryPointer(doc, "/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/properties/name")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"string"}, node.Value.(*base.SchemaProxy).Schema().Type)
+
+	// and through the circular reference back to Pet.
+	node, err = QueryPointer(doc, "/components/schemas/Pet/properties/children/items/properties/children/items/properties/name")
+	assert.NoError(t, err)
+	assert.Equal(t, "name", node.Key)
+}
+
+func TestQueryPointer_Errors(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	_, err := QueryPointer(doc, "/paths/~1nope")
+	assert.EqualError(t, err, "unable to locate an object at '/paths/~1nope'")
+
+	// scalars are not objects.
+	_, err = QueryPointer(doc, "/info/title")
+	assert.Error(t, err)
+
+	_, err = QueryPointer(doc, "info")
+	assert.Error(t, err)
+}
@@ -0,0 +1,487 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package walk provides a visitor that traverses every object in a high-level model, depth first.
+//
+// The walker works with any high-level object (a v3 Document, a v2 Swagger document, or any object contained
+// within them). Every object is visited along with its JSON pointer path, its parent and the low-level object and
+// yaml nodes (when the model was built from a document). Extensions are visited as well.
+//
+// Schemas that are references are visited, but not descended into by default, because the schema they reference
+// is visited where it is defined (components or definitions). Set Config.FollowReferences to descend into them.
+package walk
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Action is returned by a Visitor to control how the walker continues.
+type Action int
+
+const (
+	// Continue walking, descending into the children of the current node.
+	Continue Action = iota
+
+	// SkipChildren continues walking, without descending into the children of the current node.
+	SkipChildren
+
+	// Stop walking immediately.
+	Stop
+)
+
+// Visitor is called for every node found by the walker.
+type Visitor func(node *Node) Action
+
+// Config is used to configure the walker.
+type Config struct {
+	// FollowReferences will descend into schemas that are references to other schemas. Circular references are
+	// detected and not descended into.
+	FollowReferences bool
+
+	// descend into circular references, only safe when the walk is guided by something finite (like a pointer).
+	descendCircular bool
+}
+
+// Node represents a single object visited by the walker.
+type Node struct {
+	Value     any        // the high-level object, for example *v3.Operation or *base.SchemaProxy.
+	Low       any        // the low-level object backing the high-level object, nil if there isn't one.
+	Parent    *Node      // the node this node was found in, nil for the root.
+	Key       string     // the key (or index) of this node in its parent, empty for the root.
+	Path      []string   // the (unescaped) JSON pointer segments from the root to this node.
+	KeyNode   *yaml.Node // the yaml node of the key, nil if not available.
+	ValueNode *yaml.Node // the yaml node of the value, nil if not available.
+	Depth     int        // the root is at depth 0.
+	Extension bool       // the node is an extension (the value is the extension value).
+	Reference string     // the $ref of a schema that is a reference.
+	Circular  bool       // the schema is a reference back to a schema already being walked (FollowReferences only).
+}
+
+// JSONPointer returns the JSON pointer (RFC 6901) to this node from the root, for example '/paths/~1pets/get'.
+// The root is an empty string.
+func (n *Node) JSONPointer() string {
+	return BuildJSONPointer(n.Path)
+}
+
+// BuildJSONPointer creates a JSON pointer (RFC 6901) from a slice of unescaped segments.
+func BuildJSONPointer(segments []string) string {
+	return utils.BuildJSONPointer(segments)
+}
+
+// EscapeJSONPointerSegment escapes a single JSON pointer segment, '~' becomes '~0' and '/' becomes '~1'.
+func EscapeJSONPointerSegment(segment string) string {
+	return utils.EscapeJSONPointerSegment(segment)
+}
+
+// Walk will traverse every object in the supplied high-level model, depth first, calling the visitor for each.
+func Walk(root any, visitor Visitor) {
+	WalkWithConfig(root, visitor, nil)
+}
+
+// WalkWithConfig operates the same way as Walk, using the supplied configuration.
+func WalkWithConfig(root any, visitor Visitor, config *Config) {
+	if config == nil {
+		config = &Config{}
+	}
+	w := &walker{visitor: visitor, config: config, onPath: make(map[any]bool)}
+	v := reflect.ValueOf(root)
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return
+	}
+	w.visit(&Node{Value: root, Low: goLow(v)}, v)
+}
+
+// inline maps are rendered as the object that holds them, so they don't add a segment to the path.
+var inlineFields = map[string]bool{
+	"PathItems":    true,
+	"Codes":        true,
+	"Expression":   true,
+	"Definitions":  true,
+	"Values":       true,
+	"Requirements": true,
+}
+
+type walker struct {
+	visitor Visitor
+	config  *Config
+	onPath  map[any]bool
+	stopped bool
+}
+
+// visit calls the visitor for the node, then walks its children. Returns false if walking should stop.
+func (w *walker) visit(node *Node, v reflect.Value) bool {
+	if w.stopped {
+		return false
+	}
+
+	// schemas are built as they are walked, references are only followed if configured.
+	var descend reflect.Value
+	if sp, ok := node.Value.(*base.SchemaProxy); ok {
+		id := proxyIdentity(sp)
+		if sp.IsReference() {
+			node.Reference = sp.GetReference()
+			node.Circular = w.onPath[id]
+		}
+		switch w.visitor(node) {
+		case Stop:
+			w.stopped = true
+			return false
+		case SkipChildren:
+			return true
+		}
+		if (sp.IsReference() && (!w.config.FollowReferences || sp.GoLow() == nil)) ||
+			(node.Circular && !w.config.descendCircular) {
+			return true
+		}
+		sch := sp.Schema()
+		if sch == nil {
+			return true
+		}
+		w.onPath[id] = true
+		defer delete(w.onPath, id)
+		node.Low = sch.GoLow()
+		descend = reflect.ValueOf(sch)
+	} else {
+		switch w.visitor(node) {
+		case Stop:
+			w.stopped = true
+			return false
+		case SkipChildren:
+			return true
+		}
+		descend = v
+	}
+	return w.walkChildren(node, descend)
+}
+
+func (w *walker) walkChildren(parent *Node, v reflect.Value) bool {
+	// lazily built paths are built before they're walked.
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		if build := v.MethodByName("GetPathItems"); build.IsValid() && build.Type().NumIn() == 0 {
+			build.Call(nil)
+		}
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return w.walkStruct(parent, v)
+	case reflect.Map:
+		return w.walkMap(parent, v, nil, parent.Path)
+	case reflect.Slice:
+		return w.walkSlice(parent, v, nil, parent.Path)
+	}
+	return true
+}
+
+func (w *walker) walkStruct(parent *Node, v reflect.Value) bool {
+	t := v.Type()
+	lowValue := reflect.ValueOf(parent.Low)
+	for lowValue.Kind() == reflect.Pointer && !lowValue.IsNil() {
+		lowValue = lowValue.Elem()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if unicode.IsLower(rune(field.Name[0])) || field.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		var lowField reflect.Value
+		if lowValue.Kind() == reflect.Struct {
+			lowField = lowValue.FieldByName(field.Name)
+		}
+
+		if field.Name == "Extensions" && fv.Kind() == reflect.Map {
+			if !w.walkExtensions(parent, fv) {
+				return false
+			}
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		name := strings.Split(tag, ",")[0]
+		inline := inlineFields[field.Name] && fv.Kind() == reflect.Map
+		if tag == "-" && !inline {
+			continue
+		}
+		if name == "" {
+			name = string(unicode.ToLower(rune(field.Name[0]))) + field.Name[1:]
+		}
+		if !isWalkable(fv.Type()) {
+			continue
+		}
+
+		path := parent.Path
+		keyNode, valueNode, lowInner := lowNodes(lowField)
+		switch fv.Kind() {
+		case reflect.Map:
+			if !inline {
+				path = utils.AppendPathSegment(parent.Path, name)
+			}
+			if !w.walkMap(parent, fv, lowInner, path) {
+				return false
+			}
+		case reflect.Slice:
+			if !w.walkSlice(parent, fv, lowInner, utils.AppendPathSegment(parent.Path, name)) {
+				return false
+			}
+		default:
+			if !w.walkValue(parent, fv, name, utils.AppendPathSegment(parent.Path, name), keyNode, valueNode, lowInner) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (w *walker) walkMap(parent *Node, v reflect.Value, lowMap any, path []string) bool {
+	if v.IsNil() || !isWalkable(v.Type().Elem()) {
+		return true
+	}
+	entries := lowMapEntries(lowMap)
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sortByLine(keys, entries)
+	for _, k := range keys {
+		e := entries[k]
+		if !w.walkValue(parent, v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())), k,
+			utils.AppendPathSegment(path, k), e.keyNode, e.valueNode, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *walker) walkSlice(parent *Node, v reflect.Value, lowSlice any, path []string) bool {
+	if v.IsNil() || !isWalkable(v.Type().Elem()) {
+		return true
+	}
+	entries := lowSliceEntries(lowSlice)
+	for i := 0; i < v.Len(); i++ {
+		var e lowEntry
+		if i < len(entries) {
+			e = entries[i]
+		}
+		k := strconv.Itoa(i)
+		if !w.walkValue(parent, v.Index(i), k, utils.AppendPathSegment(path, k), nil, e.valueNode, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *walker) walkExtensions(parent *Node, v reflect.Value) bool {
+	var entries map[string]lowEntry
+	if ext, ok := parent.Low.(low.HasExtensionsUntyped); ok && !reflect.ValueOf(parent.Low).IsNil() {
+		entries = make(map[string]lowEntry)
+		for k, e := range ext.GetExtensions() {
+			entries[k.Value] = lowEntry{keyNode: k.KeyNode, valueNode: e.ValueNode, value: e.Value}
+		}
+	}
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sortByLine(keys, entries)
+	for _, k := range keys {
+		e := entries[k]
+		node := &Node{
+			Value:     v.MapIndex(reflect.ValueOf(k)).Interface(),
+			Parent:    parent,
+			Key:       k,
+			Path:      utils.AppendPathSegment(parent.Path, k),
+			KeyNode:   e.keyNode,
+			ValueNode: e.valueNode,
+			Depth:     parent.Depth + 1,
+			Extension: true,
+		}
+		if w.visitor(node) == Stop {
+			w.stopped = true
+			return false
+		}
+	}
+	return true
+}
+
+func (w *walker) walkValue(parent *Node, v reflect.Value, key string, path []string,
+	keyNode, valueNode *yaml.Node, lowValue any) bool {
+	for v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.Elem().Kind() != reflect.Struct {
+			return true
+		}
+		// dynamic values hold one of two values, only the one in use is walked, as if it were the field.
+		if _, ok := v.Interface().(interface{ IsA() bool }); ok {
+			field := "A"
+			if v.Elem().FieldByName("N").Int() == 1 {
+				field = "B"
+			}
+			return w.walkValue(parent, v.Elem().FieldByName(field), key, path, keyNode, valueNode, nil)
+		}
+		if _, ok := v.Interface().(*base.SchemaProxy); !ok {
+			if w.onPath[v.Interface()] {
+				return true
+			}
+			w.onPath[v.Interface()] = true
+			defer delete(w.onPath, v.Interface())
+		}
+		node := &Node{
+			Value:     v.Interface(),
+			Low:       goLow(v),
+			Parent:    parent,
+			Key:       key,
+			Path:      path,
+			KeyNode:   keyNode,
+			ValueNode: valueNode,
+			Depth:     parent.Depth + 1,
+		}
+		if node.Low == nil {
+			node.Low = lowValue
+		}
+		return w.visit(node, v)
+	case reflect.Map:
+		return w.walkMap(parent, v, lowValue, path)
+	case reflect.Slice:
+		return w.walkSlice(parent, v, lowValue, path)
+	}
+	return true
+}
+
+// isWalkable checks if a type can contain high-level objects.
+func isWalkable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return t.Elem().Kind() == reflect.Struct && t.Elem().PkgPath() != "gopkg.in/yaml.v3" &&
+			!strings.HasSuffix(t.Elem().PkgPath(), "/index")
+	case reflect.Map, reflect.Slice:
+		return isWalkable(t.Elem())
+	}
+	return false
+}
+
+// goLow calls GoLowUntyped (or GoLow) on a high-level object.
+func goLow(v reflect.Value) any {
+	for _, name := range []string{"GoLowUntyped", "GoLow"} {
+		m := v.MethodByName(name)
+		if m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+			r := m.Call(nil)[0]
+			if (r.Kind() == reflect.Pointer || r.Kind() == reflect.Interface) && r.IsNil() {
+				return nil
+			}
+			return r.Interface()
+		}
+	}
+	return nil
+}
+
+type lowEntry struct {
+	keyNode   *yaml.Node
+	valueNode *yaml.Node
+	value     any
+}
+
+// lowNodes extracts the key and value nodes from a low-level field (a NodeReference), along with its value.
+func lowNodes(field reflect.Value) (*yaml.Node, *yaml.Node, any) {
+	if !field.IsValid() || !field.CanInterface() {
+		return nil, nil, nil
+	}
+	f := field.Interface()
+	var keyNode, valueNode *yaml.Node
+	if k, ok := f.(low.HasKeyNode); ok {
+		keyNode = k.GetKeyNode()
+	}
+	if v, ok := f.(low.HasValueUnTyped); ok {
+		return keyNode, v.GetValueNode(), v.GetValueUntyped()
+	}
+	return keyNode, valueNode, f
+}
+
+func lowMapEntries(m any) map[string]lowEntry {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return nil
+	}
+	entries := make(map[string]lowEntry, v.Len())
+	for _, k := range v.MapKeys() {
+		var e lowEntry
+		key := k.Interface()
+		if kn, ok := key.(low.HasKeyNode); ok {
+			e.keyNode = kn.GetKeyNode()
+		}
+		if val, ok := v.MapIndex(k).Interface().(low.HasValueUnTyped); ok {
+			e.valueNode = val.GetValueNode()
+			e.value = val.GetValueUntyped()
+		}
+		if kv, ok := key.(interface{ GetValueUntyped() any }); ok {
+			entries[fmt.Sprint(kv.GetValueUntyped())] = e
+		} else {
+			entries[fmt.Sprint(key)] = e
+		}
+	}
+	return entries
+}
+
+func lowSliceEntries(s any) []lowEntry {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+	entries := make([]lowEntry, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if val, ok := v.Index(i).Interface().(low.HasValueUnTyped); ok {
+			entries[i] = lowEntry{valueNode: val.GetValueNode(), value: val.GetValueUntyped()}
+		}
+	}
+	return entries
+}
+
+// sortByLine sorts keys in the order they were defined in the document, keys without a line are sorted by name.
+func sortByLine(keys []string, entries map[string]lowEntry) {
+	line := func(k string) int {
+		if e, ok := entries[k]; ok && e.keyNode != nil {
+			return e.keyNode.Line
+		}
+		return int(^uint(0) >> 1)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if line(keys[i]) != line(keys[j]) {
+			return line(keys[i]) < line(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+}
+
+// proxyIdentity identifies the schema behind a proxy, references to the same schema share an identity.
+func proxyIdentity(sp *base.SchemaProxy) any {
+	if l := sp.GoLow(); l != nil {
+		if vn := l.GetValueNode(); vn != nil {
+			return vn
+		}
+	}
+	return sp
+}
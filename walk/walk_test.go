@@ -0,0 +1,224 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package walk
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	lowv2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var walkSpec = `openapi: 3.1.0
+info:
+  title: walk
+  version: 1.0.0
+  x-info: yes
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'`
+
+func buildWalkDocument(t *testing.T) *v3.Document {
+	info, err := datamodel.ExtractSpecInfo([]byte(walkSpec))
+	assert.NoError(t, err)
+	lowDoc, errs := lowv3.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	assert.Len(t, errs, 0)
+	return v3.NewDocument(lowDoc)
+}
+
+func TestWalk(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	visited := make(map[string]*Node)
+	Walk(doc, func(node *Node) Action {
+		visited[node.JSONPointer()] = node
+		return Continue
+	})
+
+	root := visited[""]
+	assert.Equal(t, doc, root.Value)
+	assert.NotNil(t, root.Low)
+
+	op := visited["/paths/~1pets~1{id}/get"]
+	assert.NotNil(t, op)
+	assert.IsType(t, &v3.Operation{}, op.Value)
+	assert.Equal(t, "get", op.Key)
+	assert.Equal(t, 3, op.Depth)
+	assert.Equal(t, 8, op.KeyNode.Line)
+	assert.Equal(t, 9, op.ValueNode.Line)
+	assert.IsType(t, &v3.PathItem{}, op.Parent.Value)
+	assert.NotNil(t, op.Low)
+
+	param := visited["/paths/~1pets~1{id}/get/parameters/0"]
+	assert.IsType(t, &v3.Parameter{}, param.Value)
+	assert.Equal(t, 11, param.ValueNode.Line)
+	assert.NotNil(t, visited["/paths/~1pets~1{id}/get/parameters/0/schema"])
+
+	ref := visited["/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema"]
+	assert.Equal(t, "#/components/schemas/Pet", ref.Reference)
+
+	// references are not followed by default.
+	assert.Nil(t, visited["/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/properties/name"])
+
+	pet := visited["/components/schemas/Pet"]
+	assert.IsType(t, &base.SchemaProxy{}, pet.Value)
+	assert.Empty(t, pet.Reference)
+	assert.Equal(t, 24, pet.KeyNode.Line)
+	assert.NotNil(t, visited["/components/schemas/Pet/properties/name"])
+
+	// dynamic values don't have a node of their own.
+	items := visited["/components/schemas/Pet/properties/children/items"]
+	assert.IsType(t, &base.SchemaProxy{}, items.Value)
+	assert.Equal(t, "#/components/schemas/Pet", items.Reference)
+
+	ext := visited["/info/x-info"]
+	assert.True(t, ext.Extension)
+	assert.Equal(t, "yes", ext.Value)
+	assert.Equal(t, 5, ext.KeyNode.Line)
+}
+
+func TestWalk_Order(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	var pointers []string
+	Walk(doc, func(node *Node) Action {
+		pointers = append(pointers, node.JSONPointer())
+		return Continue
+	})
+	assert.Equal(t, []string{
+		"",
+		"/info",
+		"/info/x-info",
+		"/paths",
+		"/paths/~1pets~1{id}",
+		"/paths/~1pets~1{id}/get",
+		"/paths/~1pets~1{id}/get/parameters/0",
+		"/paths/~1pets~1{id}/get/parameters/0/schema",
+		"/paths/~1pets~1{id}/get/responses",
+		"/paths/~1pets~1{id}/get/responses/200",
+		"/paths/~1pets~1{id}/get/responses/200/content/application~1json",
+		"/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema",
+		"/components",
+		"/components/schemas/Pet",
+		"/components/schemas/Pet/properties/name",
+		"/components/schemas/Pet/properties/children",
+		"/components/schemas/Pet/properties/children/items",
+	}, pointers)
+}
+
+func TestWalk_SkipAndStop(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	var pointers []string
+	Walk(doc, func(node *Node) Action {
+		pointers = append(pointers, node.JSONPointer())
+		if node.JSONPointer() == "/paths" {
+			return SkipChildren
+		}
+		if _, ok := node.Value.(*v3.Components); ok {
+			return Stop
+		}
+		return Continue
+	})
+	assert.Equal(t, []string{"", "/info", "/info/x-info", "/paths", "/components"}, pointers)
+
+	pointers = nil
+	Walk(doc, func(node *Node) Action {
+		pointers = append(pointers, node.JSONPointer())
+		if node.Extension {
+			return Stop
+		}
+		return Continue
+	})
+	assert.Len(t, pointers, 3)
+}
+
+func TestWalkWithConfig_FollowReferences(t *testing.T) {
+	doc := buildWalkDocument(t)
+
+	visited := make(map[string]*Node)
+	WalkWithConfig(doc.Paths, func(node *Node) Action {
+		visited[node.JSONPointer()] = node
+		return Continue
+	}, &Config{FollowReferences: true})
+
+	// the path is relative to the root supplied.
+	schema := "/~1pets~1{id}/get/responses/200/content/application~1json/schema"
+	assert.NotNil(t, visited[schema+"/properties/name"])
+
+	// Pet -> children -> items is circular.
+	items := visited[schema+"/properties/children/items"]
+	assert.True(t, items.Circular)
+	assert.Nil(t, visited[schema+"/properties/children/items/properties/name"])
+}
+
+func TestWalk_Swagger(t *testing.T) {
+	spec, _ := os.ReadFile("../test_specs/petstorev2.json")
+	info, err := datamodel.ExtractSpecInfo(spec)
+	assert.NoError(t, err)
+	lowDoc, errs := lowv2.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	assert.Len(t, errs, 0)
+
+	visited := make(map[string]*Node)
+	Walk(v2.NewSwaggerDocument(lowDoc), func(node *Node) Action {
+		visited[node.JSONPointer()] = node
+		return Continue
+	})
+
+	op := visited["/paths/~1pet~1{petId}/get"]
+	assert.IsType(t, &v2.Operation{}, op.Value)
+	assert.NotNil(t, op.KeyNode)
+	assert.IsType(t, &base.SchemaProxy{}, visited["/definitions/Pet"].Value)
+	assert.NotNil(t, visited["/definitions/Pet/properties/tags"])
+	assert.IsType(t, &v2.SecurityScheme{}, visited["/securityDefinitions/api_key"].Value)
+}
+
+func TestWalk_Nil(t *testing.T) {
+	var called bool
+	Walk(nil, func(node *Node) Action {
+		called = true
+		return Continue
+	})
+	var doc *v3.Document
+	Walk(doc, func(node *Node) Action {
+		called = true
+		return Continue
+	})
+	assert.False(t, called)
+}
+
+func TestBuildJSONPointer(t *testing.T) {
+	assert.Equal(t, "", BuildJSONPointer(nil))
+	assert.Equal(t, "/paths/~1a~0b", BuildJSONPointer([]string{"paths", "/a~b"}))
+}
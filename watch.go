@@ -0,0 +1,300 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// FileWatcher watches files for changes, and sends the path of a file on the Events channel every time it changes.
+// PollingFileWatcher is used by default, implement FileWatcher to use something else (like fsnotify).
+type FileWatcher interface {
+	// Add starts watching a file.
+	Add(file string) error
+
+	// Events returns the channel that changed files are sent on.
+	Events() <-chan string
+
+	// Close stops watching every file.
+	Close() error
+}
+
+// WatchConfiguration configures a DocumentWatcher.
+type WatchConfiguration struct {
+	// Files belonging to the document. The first file is the root specification, the rest are files it references.
+	Files []string
+
+	// Configuration used to create the document, the BasePath and SpecFilePath default to those of the root
+	// specification. Defaults to a configuration that only allows file references.
+	Configuration *datamodel.DocumentConfiguration
+
+	// Debounce is how long to wait for more changes, before rebuilding. Defaults to 100 milliseconds.
+	Debounce time.Duration
+
+	// Watcher watches the files, defaults to a PollingFileWatcher checking every 500 milliseconds.
+	Watcher FileWatcher
+}
+
+// DocumentChange is sent every time a watched document is rebuilt.
+type DocumentChange struct {
+	Files         []string                        // the files that changed, empty for the first build.
+	Document      Document                        // the document that was rebuilt, nil if it could not be read.
+	V3Model       *DocumentModel[v3high.Document] // set for OpenAPI 3+ specifications.
+	V2Model       *DocumentModel[v2high.Swagger]  // set for Swagger specifications.
+	Errors        []error                         // every error reading, parsing or building the document.
+	RebuildNumber int                             // number of times the document has been rebuilt.
+}
+
+// DocumentWatcher rebuilds a document every time any of its files change.
+type DocumentWatcher struct {
+	config   WatchConfiguration
+	rebuilt  func(change *DocumentChange)
+	watcher  FileWatcher
+	lock     sync.Mutex
+	latest   *DocumentChange
+	rebuilds int
+	calling  bool // true while the rebuilt function is being called.
+	done     chan struct{}
+	stopped  chan struct{}
+	once     sync.Once
+}
+
+// WatchDocument builds a document from the files in the configuration, and rebuilds it every time any of the files
+// change. The rebuilt function is called with the first build before WatchDocument returns, then with every rebuild.
+// Changes are debounced, so changes to several files at once (like saving all files in an editor) cause a single
+// rebuild. Call Close to stop watching.
+func WatchDocument(config *WatchConfiguration, rebuilt func(change *DocumentChange)) (*DocumentWatcher, error) {
+	if config == nil || len(config.Files) == 0 {
+		return nil, errors.New("unable to watch document, no files have been supplied")
+	}
+	if rebuilt == nil {
+		return nil, errors.New("unable to watch document, no rebuilt function has been supplied")
+	}
+	w := &DocumentWatcher{
+		config:  *config,
+		rebuilt: rebuilt,
+		watcher: config.Watcher,
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	if w.config.Debounce <= 0 {
+		w.config.Debounce = 100 * time.Millisecond
+	}
+	if w.watcher == nil {
+		w.watcher = NewPollingFileWatcher(500 * time.Millisecond)
+	}
+	for _, f := range config.Files {
+		if err := w.watcher.Add(f); err != nil {
+			_ = w.watcher.Close()
+			return nil, fmt.Errorf("unable to watch '%s': %w", f, err)
+		}
+	}
+	w.rebuild(nil)
+	go w.watch()
+	return w, nil
+}
+
+// Add starts watching another file belonging to the document.
+func (w *DocumentWatcher) Add(file string) error {
+	return w.watcher.Add(file)
+}
+
+// Latest returns the most recent build of the document.
+func (w *DocumentWatcher) Latest() *DocumentChange {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.latest
+}
+
+// Close stops watching the files of the document, no rebuilds are started once it has been called. Close waits for
+// a rebuild in progress to finish, unless the rebuilt function is being called, so Close can be called from the
+// rebuilt function (in which case the rebuilt function is not called again).
+func (w *DocumentWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = w.watcher.Close()
+	})
+	w.lock.Lock()
+	calling := w.calling
+	w.lock.Unlock()
+	if !calling {
+		<-w.stopped
+	}
+	return err
+}
+
+func (w *DocumentWatcher) watch() {
+	defer close(w.stopped)
+	events := w.watcher.Events()
+	changed := make(map[string]bool)
+	var order []string
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-w.done:
+			return
+		case f, ok := <-events:
+			if !ok {
+				return
+			}
+			if !changed[f] {
+				changed[f] = true
+				order = append(order, f)
+			}
+			debounce = time.After(w.config.Debounce)
+		case <-debounce:
+			select {
+			case <-w.done:
+				return
+			default:
+			}
+			w.rebuild(order)
+			changed, order, debounce = make(map[string]bool), nil, nil
+		}
+	}
+}
+
+// rebuild reads the root specification again, and builds a new model from it.
+func (w *DocumentWatcher) rebuild(files []string) {
+	change := &DocumentChange{Files: files}
+	root := w.config.Files[0]
+	spec, err := os.ReadFile(root)
+	if err != nil {
+		change.Errors = append(change.Errors, fmt.Errorf("unable to read '%s': %w", root, err))
+	} else {
+		config := &datamodel.DocumentConfiguration{AllowFileReferences: true}
+		if w.config.Configuration != nil {
+			c := *w.config.Configuration
+			config = &c
+		}
+		if config.BasePath == "" {
+			config.BasePath = filepath.Dir(root)
+		}
+		if config.SpecFilePath == "" {
+			config.SpecFilePath = root
+		}
+		change.Document, err = NewDocumentWithConfiguration(spec, config)
+		if err != nil {
+			change.Errors = append(change.Errors, err)
+		} else if change.Document.GetSpecInfo().SpecFormat == datamodel.OAS2 {
+			change.V2Model, change.Errors = change.Document.BuildV2Model()
+		} else {
+			change.V3Model, change.Errors = change.Document.BuildV3Model()
+		}
+	}
+
+	w.lock.Lock()
+	w.rebuilds++
+	change.RebuildNumber = w.rebuilds
+	w.latest = change
+	w.calling = true
+	w.lock.Unlock()
+	w.rebuilt(change)
+	w.lock.Lock()
+	w.calling = false
+	w.lock.Unlock()
+}
+
+// PollingFileWatcher is a FileWatcher that checks the modification time and size of every file at an interval. It
+// needs no dependencies, and works on every platform.
+type PollingFileWatcher struct {
+	interval time.Duration
+	lock     sync.Mutex
+	files    map[string]fileState
+	events   chan string
+	done     chan struct{}
+	once     sync.Once
+}
+
+type fileState struct {
+	modified time.Time
+	size     int64
+	exists   bool
+}
+
+// NewPollingFileWatcher creates a PollingFileWatcher that checks files at an interval.
+func NewPollingFileWatcher(interval time.Duration) *PollingFileWatcher {
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	p := &PollingFileWatcher{
+		interval: interval,
+		files:    make(map[string]fileState),
+		events:   make(chan string, 16),
+		done:     make(chan struct{}),
+	}
+	go p.poll()
+	return p
+}
+
+// Add starts watching a file, the file does not need to exist yet.
+func (p *PollingFileWatcher) Add(file string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if _, ok := p.files[file]; !ok {
+		p.files[file] = statFile(file)
+	}
+	return nil
+}
+
+// Events returns the channel that changed files are sent on.
+func (p *PollingFileWatcher) Events() <-chan string {
+	return p.events
+}
+
+// Close stops watching every file.
+func (p *PollingFileWatcher) Close() error {
+	p.once.Do(func() { close(p.done) })
+	return nil
+}
+
+func (p *PollingFileWatcher) poll() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+		var changed []string
+		p.lock.Lock()
+		for f, s := range p.files {
+			if n := statFile(f); n.changed(s) {
+				p.files[f] = n
+				changed = append(changed, f)
+			}
+		}
+		p.lock.Unlock()
+		for _, f := range changed {
+			select {
+			case p.events <- f:
+			case <-p.done:
+				return
+			}
+		}
+	}
+}
+
+func (s fileState) changed(previous fileState) bool {
+	return s.exists != previous.exists || s.size != previous.size || !s.modified.Equal(previous.modified)
+}
+
+func statFile(file string) fileState {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{modified: info.ModTime(), size: info.Size(), exists: true}
+}
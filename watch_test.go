@@ -0,0 +1,166 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFileWatcher struct {
+	files  []string
+	events chan string
+	closed bool
+}
+
+func (t *testFileWatcher) Add(file string) error {
+	t.files = append(t.files, file)
+	return nil
+}
+
+func (t *testFileWatcher) Events() <-chan string {
+	return t.events
+}
+
+func (t *testFileWatcher) Close() error {
+	t.closed = true
+	return nil
+}
+
+var watchSpec = `openapi: 3.1.0
+info:
+  title: %s
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`
+
+func writeWatchSpec(t *testing.T, dir, title string) {
+	spec := fmt.Sprintf(watchSpec, title)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(spec), 0o644))
+}
+
+func TestWatchDocument(t *testing.T) {
+	dir := t.TempDir()
+	writeWatchSpec(t, dir, "first")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: object"), 0o644))
+
+	fw := &testFileWatcher{events: make(chan string)}
+	changes := make(chan *DocumentChange, 4)
+	w, err := WatchDocument(&WatchConfiguration{
+		Files:    []string{filepath.Join(dir, "openapi.yaml"), filepath.Join(dir, "pet.yaml")},
+		Debounce: 20 * time.Millisecond,
+		Watcher:  fw,
+	}, func(change *DocumentChange) {
+		changes <- change
+	})
+	assert.NoError(t, err)
+	assert.Len(t, fw.files, 2)
+
+	first := <-changes
+	assert.Empty(t, first.Errors)
+	assert.Empty(t, first.Files)
+	assert.Equal(t, 1, first.RebuildNumber)
+	assert.Equal(t, "first", first.V3Model.Model.Info.Title)
+	assert.Equal(t, "object", first.V3Model.Model.Components.Schemas["Pet"].Schema().Type[0])
+
+	// both changes are rebuilt at once.
+	writeWatchSpec(t, dir, "second")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: string"), 0o644))
+	fw.events <- filepath.Join(dir, "openapi.yaml")
+	fw.events <- filepath.Join(dir, "pet.yaml")
+	fw.events <- filepath.Join(dir, "openapi.yaml")
+
+	second := <-changes
+	assert.Empty(t, second.Errors)
+	assert.Equal(t, []string{filepath.Join(dir, "openapi.yaml"), filepath.Join(dir, "pet.yaml")}, second.Files)
+	assert.Equal(t, 2, second.RebuildNumber)
+	assert.Equal(t, "second", second.V3Model.Model.Info.Title)
+	assert.Equal(t, "string", second.V3Model.Model.Components.Schemas["Pet"].Schema().Type[0])
+	assert.Equal(t, second, w.Latest())
+
+	// errors are sent too.
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte("not: [a spec"), 0o644))
+	fw.events <- filepath.Join(dir, "openapi.yaml")
+	third := <-changes
+	assert.NotEmpty(t, third.Errors)
+	assert.Nil(t, third.V3Model)
+
+	assert.NoError(t, w.Close())
+	assert.True(t, fw.closed)
+	assert.NoError(t, w.Close())
+}
+
+func TestWatchDocument_CloseFromRebuilt(t *testing.T) {
+	dir := t.TempDir()
+	writeWatchSpec(t, dir, "first")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: object"), 0o644))
+
+	fw := &testFileWatcher{events: make(chan string)}
+	watchers := make(chan *DocumentWatcher, 1)
+	closed := make(chan error, 1)
+	w, err := WatchDocument(&WatchConfiguration{
+		Files:    []string{filepath.Join(dir, "openapi.yaml")},
+		Debounce: 5 * time.Millisecond,
+		Watcher:  fw,
+	}, func(change *DocumentChange) {
+		if change.RebuildNumber > 1 {
+			closed <- (<-watchers).Close()
+		}
+	})
+	assert.NoError(t, err)
+	watchers <- w
+
+	fw.events <- filepath.Join(dir, "openapi.yaml")
+	select {
+	case err = <-closed:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "closing from the rebuilt function did not return")
+	}
+	assert.NoError(t, w.Close())
+	assert.True(t, fw.closed)
+}
+
+func TestWatchDocument_Errors(t *testing.T) {
+	_, err := WatchDocument(nil, func(change *DocumentChange) {})
+	assert.Error(t, err)
+
+	_, err = WatchDocument(&WatchConfiguration{Files: []string{"openapi.yaml"}}, nil)
+	assert.Error(t, err)
+
+	fw := &testFileWatcher{events: make(chan string)}
+	var change *DocumentChange
+	w, err := WatchDocument(&WatchConfiguration{Files: []string{"missing/openapi.yaml"}, Watcher: fw},
+		func(c *DocumentChange) {
+			change = c
+		})
+	assert.NoError(t, err)
+	assert.Len(t, change.Errors, 1)
+	assert.Nil(t, change.Document)
+	assert.NoError(t, w.Close())
+}
+
+func TestPollingFileWatcher(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "openapi.yaml")
+
+	p := NewPollingFileWatcher(5 * time.Millisecond)
+	assert.NoError(t, p.Add(file))
+	assert.NoError(t, os.WriteFile(file, []byte("openapi: 3.1.0"), 0o644))
+
+	select {
+	case f := <-p.Events():
+		assert.Equal(t, file, f)
+	case <-time.After(2 * time.Second):
+		assert.Fail(t, "file change was not seen")
+	}
+	assert.NoError(t, p.Close())
+	assert.NoError(t, p.Close())
+}
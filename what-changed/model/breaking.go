@@ -0,0 +1,307 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// Classification is how a change affects the consumers of a specification.
+type Classification int
+
+const (
+	// NonBreaking changes can be made without affecting consumers.
+	NonBreaking Classification = iota
+
+	// PotentiallyBreaking changes may affect consumers, depending on how they use the specification (like a new
+	// value of an enum in a response, that a client may not expect).
+	PotentiallyBreaking
+
+	// Breaking changes will affect consumers (like a removed path).
+	Breaking
+)
+
+var classificationNames = map[Classification]string{
+	NonBreaking:         "non-breaking",
+	PotentiallyBreaking: "potentially-breaking",
+	Breaking:            "breaking",
+}
+
+// String returns the name of the classification, like 'potentially-breaking'.
+func (c Classification) String() string {
+	return classificationNames[c]
+}
+
+// MarshalText renders the classification as its name.
+func (c Classification) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// ChangeLocation is where a change was found in a tree of changes (like DocumentChanges), it's used by a
+// BreakingRule to decide if a change matches.
+type ChangeLocation struct {
+	// Path is the names of the properties (and map keys) leading to the change, from the top of the tree.
+	Path []string
+
+	// Object is the kind of object the change was made to, the name of its changes type without 'Changes', for
+	// example 'Schema' for a change in SchemaChanges.
+	Object string
+
+	// InRequest is true when the change is in a request body or parameter.
+	InRequest bool
+
+	// InResponse is true when the change is in a response.
+	InResponse bool
+}
+
+// BreakingRule classifies the changes it matches.
+type BreakingRule struct {
+	// ID identifies the rule, so its classification can be overridden (see ClassifyChanges).
+	ID string
+
+	// Description explains what the rule matches.
+	Description string
+
+	// Classification is the classification of the changes the rule matches, unless it is overridden.
+	Classification Classification
+
+	// Matches returns true if the rule applies to a change.
+	Matches func(change *Change, location *ChangeLocation) bool
+}
+
+// DefaultBreakingRules returns the rules used by ClassifyChanges. Rules are checked in order, the first rule that
+// matches a change classifies it.
+func DefaultBreakingRules() []*BreakingRule {
+	return []*BreakingRule{
+		{
+			ID:             "path-removed",
+			Description:    "a path was removed",
+			Classification: Breaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Paths" && c.Property == v3.PathLabel && c.ChangeType == ObjectRemoved
+			},
+		},
+		{
+			ID:             "path-moved",
+			Description:    "a path was moved, clients will still call the original path",
+			Classification: Breaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Paths" && c.Property == v3.PathLabel && c.ChangeType == Renamed
+			},
+		},
+		{
+			ID:             "component-renamed",
+			Description:    "a component was renamed without being changed, generated code may use its name",
+			Classification: PotentiallyBreaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Components" && c.ChangeType == Renamed
+			},
+		},
+		{
+			ID:             "operation-removed",
+			Description:    "an operation was removed from a path",
+			Classification: Breaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "PathItem" && isOperationLabel(c.Property) && isRemoval(c)
+			},
+		},
+		{
+			ID:             "response-code-removed",
+			Description:    "a response code (or the default response) was removed from an operation",
+			Classification: Breaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Responses" && (c.Property == v3.CodesLabel || c.Property == v3.DefaultLabel) &&
+					isRemoval(c)
+			},
+		},
+		{
+			ID:             "type-changed",
+			Description:    "the type of a schema was changed",
+			Classification: Breaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Schema" && c.Property == v3.TypeLabel && c.ChangeType == Modified
+			},
+		},
+		{
+			ID:             "response-enum-widened",
+			Description:    "a value was added to an enum in a response, clients may not expect it",
+			Classification: PotentiallyBreaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Schema" && c.Property == v3.EnumLabel && l.InResponse && isAddition(c)
+			},
+		},
+		{
+			ID:             "response-enum-narrowed",
+			Description:    "a value was removed from an enum in a response",
+			Classification: NonBreaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Schema" && c.Property == v3.EnumLabel && l.InResponse && isRemoval(c)
+			},
+		},
+		{
+			ID:             "enum-narrowed",
+			Description:    "a value was removed from an enum",
+			Classification: Breaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Schema" && c.Property == v3.EnumLabel && isRemoval(c)
+			},
+		},
+		{
+			ID:             "response-required-property-added",
+			Description:    "a property was made required in a response",
+			Classification: NonBreaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Schema" && c.Property == v3.RequiredLabel && l.InResponse && isAddition(c)
+			},
+		},
+		{
+			ID:             "required-property-added",
+			Description:    "a property was made required",
+			Classification: Breaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Schema" && c.Property == v3.RequiredLabel && isAddition(c)
+			},
+		},
+		{
+			ID:             "request-required-property-removed",
+			Description:    "a property in a request is no longer required",
+			Classification: NonBreaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Schema" && c.Property == v3.RequiredLabel && l.InRequest && isRemoval(c)
+			},
+		},
+		{
+			ID:             "required-property-removed",
+			Description:    "a property is no longer required, clients may rely on it being there",
+			Classification: PotentiallyBreaking,
+			Matches: func(c *Change, l *ChangeLocation) bool {
+				return l.Object == "Schema" && c.Property == v3.RequiredLabel && isRemoval(c)
+			},
+		},
+	}
+}
+
+// ClassifyChanges classifies every change in a tree of changes (like DocumentChanges, or PathsChanges) using the
+// DefaultBreakingRules. The classification of a rule can be overridden by its ID, for example, to treat removed
+// response codes as potentially breaking:
+//
+//	ClassifyChanges(changes, map[string]Classification{"response-code-removed": PotentiallyBreaking})
+//
+// Changes that no rule matches are Breaking if they were reported as breaking, otherwise NonBreaking. The Breaking
+// flag of every change is updated to match its classification, so breaking change totals include the overrides.
+func ClassifyChanges(changes any, overrides map[string]Classification) {
+	ClassifyChangesWithRules(changes, DefaultBreakingRules(), overrides)
+}
+
+// ClassifyChangesWithRules is the same as ClassifyChanges, except the rules are supplied by the caller.
+func ClassifyChangesWithRules(changes any, rules []*BreakingRule, overrides map[string]Classification) {
+	WalkChanges(changes, func(change *Change, location *ChangeLocation) {
+		change.Classification, change.Rule = NonBreaking, ""
+		if change.Breaking {
+			change.Classification = Breaking
+		}
+		for _, rule := range rules {
+			if rule.Matches(change, location) {
+				change.Classification, change.Rule = rule.Classification, rule.ID
+				break
+			}
+		}
+		if c, ok := overrides[change.Rule]; ok && change.Rule != "" {
+			change.Classification = c
+		}
+		change.Breaking = change.Classification == Breaking
+	})
+}
+
+// CountClassifiedChanges counts the changes that have a classification.
+func CountClassifiedChanges(changes []*Change, classification Classification) int {
+	count := 0
+	for _, c := range changes {
+		if c.Classification == classification {
+			count++
+		}
+	}
+	return count
+}
+
+var propertyChangesType = reflect.TypeOf(PropertyChanges{})
+
+// WalkChanges calls visit with every change in a tree of changes (like DocumentChanges), along with where it was
+// found in the tree.
+func WalkChanges(changes any, visit func(change *Change, location *ChangeLocation)) {
+	walkChanges(reflect.ValueOf(changes), &ChangeLocation{}, visit)
+}
+
+func walkChanges(v reflect.Value, location *ChangeLocation, visit func(*Change, *ChangeLocation)) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if !v.IsNil() {
+			walkChanges(v.Elem(), location, visit)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkChanges(v.Index(i), location, visit)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			key := location.at(iter.Key().String())
+			walkChanges(iter.Value(), &key, visit)
+		}
+	case reflect.Struct:
+		if v.Type() == propertyChangesType {
+			for _, c := range v.Interface().(PropertyChanges).Changes {
+				visit(c, location)
+			}
+			return
+		}
+		object := *location
+		object.Object = strings.TrimSuffix(v.Type().Name(), "Changes")
+		switch object.Object {
+		case "RequestBody", "Parameter":
+			object.InRequest = true
+		case "Responses", "Response":
+			object.InResponse = true
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.Anonymous {
+				walkChanges(v.Field(i), &object, visit)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			child := object.at(name)
+			walkChanges(v.Field(i), &child, visit)
+		}
+	}
+}
+
+// at returns a copy of the location, with a name added to its path.
+func (l ChangeLocation) at(name string) ChangeLocation {
+	l.Path = append(append([]string(nil), l.Path...), name)
+	return l
+}
+
+func isOperationLabel(label string) bool {
+	switch label {
+	case v3.GetLabel, v3.PutLabel, v3.PostLabel, v3.DeleteLabel, v3.OptionsLabel, v3.HeadLabel, v3.PatchLabel,
+		v3.TraceLabel:
+		return true
+	}
+	return false
+}
+
+func isAddition(c *Change) bool {
+	return c.ChangeType == PropertyAdded || c.ChangeType == ObjectAdded
+}
+
+func isRemoval(c *Change) bool {
+	return c.ChangeType == PropertyRemoved || c.ChangeType == ObjectRemoved
+}
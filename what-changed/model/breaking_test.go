@@ -0,0 +1,221 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var breakingLeft = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  size:
+                    type: string
+                    enum: [small, large]
+        '404':
+          description: no pizza
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                crust:
+                  type: string
+                  enum: [thin, thick]
+      responses:
+        '201':
+          description: made a pizza
+    delete:
+      responses:
+        '204':
+          description: gone
+  /burger:
+    get:
+      responses:
+        '200':
+          description: a burger`
+
+var breakingRight = `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name, size]
+                properties:
+                  size:
+                    type: string
+                    enum: [small, large, massive]
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: integer
+                crust:
+                  type: string
+                  enum: [thin]
+      responses:
+        '201':
+          description: made a pizza`
+
+func compareForBreaking(t *testing.T) *DocumentChanges {
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(breakingLeft))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(breakingRight))
+	lDoc, _ := v3.CreateDocumentFromConfig(lInfo, datamodel.NewClosedDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(rInfo, datamodel.NewClosedDocumentConfiguration())
+	changes := CompareDocuments(lDoc, rDoc)
+	assert.NotNil(t, changes)
+	return changes
+}
+
+func changeByRule(changes []*Change, rule string) *Change {
+	for _, c := range changes {
+		if c.Rule == rule {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestClassifyChanges(t *testing.T) {
+	changes := compareForBreaking(t)
+	ClassifyChanges(changes, nil)
+	all := changes.GetAllChanges()
+
+	expected := map[string]Classification{
+		"path-removed":                     Breaking,
+		"operation-removed":                Breaking,
+		"response-code-removed":            Breaking,
+		"type-changed":                     Breaking,
+		"response-enum-widened":            PotentiallyBreaking,
+		"enum-narrowed":                    Breaking,
+		"response-required-property-added": NonBreaking,
+		"required-property-added":          Breaking,
+	}
+	for rule, classification := range expected {
+		c := changeByRule(all, rule)
+		if assert.NotNil(t, c, rule) {
+			assert.Equal(t, classification, c.Classification, rule)
+			assert.Equal(t, classification == Breaking, c.Breaking, rule)
+		}
+	}
+	assert.Equal(t, "/burger", changeByRule(all, "path-removed").Original)
+	assert.Equal(t, 1, CountClassifiedChanges(all, PotentiallyBreaking))
+	assert.Equal(t, CountClassifiedChanges(all, Breaking), changes.TotalBreakingChanges())
+}
+
+func TestClassifyChanges_Overrides(t *testing.T) {
+	changes := compareForBreaking(t)
+	breaking := changes.TotalBreakingChanges()
+	ClassifyChanges(changes, map[string]Classification{
+		"response-code-removed": PotentiallyBreaking,
+		"path-removed":          NonBreaking,
+	})
+	all := changes.GetAllChanges()
+
+	c := changeByRule(all, "response-code-removed")
+	assert.Equal(t, PotentiallyBreaking, c.Classification)
+	assert.False(t, c.Breaking)
+	assert.Equal(t, NonBreaking, changeByRule(all, "path-removed").Classification)
+	// the removed path and response code, and the required property added to a response.
+	assert.Equal(t, breaking-3, changes.TotalBreakingChanges())
+}
+
+func TestClassifyChangesWithRules(t *testing.T) {
+	changes := compareForBreaking(t)
+	rules := []*BreakingRule{{
+		ID:             "pizza-only",
+		Classification: PotentiallyBreaking,
+		Matches: func(c *Change, l *ChangeLocation) bool {
+			return len(l.Path) > 2 && l.Path[2] == "/pizza"
+		},
+	}}
+	ClassifyChangesWithRules(changes, rules, nil)
+	for _, c := range changes.GetAllChanges() {
+		if c.Rule == "" {
+			assert.NotEqual(t, PotentiallyBreaking, c.Classification)
+		}
+	}
+	assert.NotZero(t, CountClassifiedChanges(changes.GetAllChanges(), PotentiallyBreaking))
+}
+
+func TestClassification_String(t *testing.T) {
+	assert.Equal(t, "potentially-breaking", PotentiallyBreaking.String())
+	b, _ := Breaking.MarshalText()
+	assert.Equal(t, "breaking", string(b))
+}
+
+func TestChange_MarshalJSON_NonBreaking(t *testing.T) {
+	b, _ := json.Marshal(&Change{Property: "description", Classification: NonBreaking})
+	assert.Contains(t, string(b), `"classification":"non-breaking"`)
+}
+
+func TestClassifyChanges_Renamed(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      description: a pizza
+components:
+  schemas:
+    Pizza:
+      type: object`
+
+	right := `openapi: 3.1.0
+paths:
+  /pizzas:
+    get:
+      description: a pizza
+components:
+  schemas:
+    PizzaPie:
+      type: object`
+
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v3.CreateDocumentFromConfig(lInfo, datamodel.NewClosedDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(rInfo, datamodel.NewClosedDocumentConfiguration())
+	changes := CompareDocuments(lDoc, rDoc)
+	ClassifyChanges(changes, nil)
+	all := changes.GetAllChanges()
+	assert.Len(t, all, 2)
+
+	moved := changeByRule(all, "path-moved")
+	assert.Equal(t, Breaking, moved.Classification)
+	assert.Equal(t, "/pizza", moved.Original)
+	assert.Equal(t, "/pizzas", moved.New)
+
+	renamed := changeByRule(all, "component-renamed")
+	assert.Equal(t, PotentiallyBreaking, renamed.Classification)
+	assert.Equal(t, "PizzaPie", renamed.New)
+}
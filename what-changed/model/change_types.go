@@ -24,6 +24,9 @@ const (
 
 	// PropertyRemoved means that a property of an object was removed
 	PropertyRemoved
+
+	// Renamed means that an object was renamed or moved (like a component, or a path), without being changed
+	Renamed
 )
 
 // WhatChanged is a summary object that contains a high level summary of everything changed.
@@ -76,6 +79,10 @@ type Change struct {
 	// Property is the property name key being changed.
 	Property string `json:"property,omitempty" yaml:"property,omitempty"`
 
+	// ValuePath is the path to the nested value that changed inside a free-form value of the property (like an
+	// extension, or an example), for example 'routes/0/timeout'. Empty if the whole property changed.
+	ValuePath string `json:"valuePath,omitempty" yaml:"valuePath,omitempty"`
+
 	// Original is the original value represented as a string.
 	Original string `json:"original,omitempty" yaml:"original,omitempty"`
 
@@ -85,11 +92,23 @@ type Change struct {
 	// Breaking determines if the change is a breaking one or not.
 	Breaking bool `json:"breaking" yaml:"breaking"`
 
+	// Classification is how the change affects consumers, set by ClassifyChanges.
+	Classification Classification `json:"classification" yaml:"classification"`
+
+	// Rule is the ID of the BreakingRule that classified the change, empty if no rule matched.
+	Rule string `json:"rule,omitempty" yaml:"rule,omitempty"`
+
 	// OriginalObject represents the original object that was changed.
 	OriginalObject any `json:"-" yaml:"-"`
 
 	// NewObject represents the new object that has been modified.
 	NewObject any `json:"-" yaml:"-"`
+
+	// OriginalNode is the yaml node of the original value, if there was one.
+	OriginalNode *yaml.Node `json:"-" yaml:"-"`
+
+	// NewNode is the yaml node of the new value, if there is one.
+	NewNode *yaml.Node `json:"-" yaml:"-"`
 }
 
 // PropertyChanges holds a slice of Change pointers
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/pb33f/libopenapi/utils"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -31,6 +32,9 @@ func CreateChange(changes *[]*Change, changeType int, property string, leftValue
 		ChangeType: changeType,
 		Property:   property,
 		Breaking:   breaking,
+
+		OriginalNode: leftValueNode,
+		NewNode:      rightValueNode,
 	}
 	// if the left is not nil, we have an original value
 	if leftValueNode != nil && leftValueNode.Value != "" {
@@ -261,6 +265,13 @@ func CheckMapForAdditionRemoval[T any](expLeft, expRight map[low.KeyReference[st
 // bit determines if the comparison should be run or not.
 func CheckMapForChangesWithComp[T any, R any](expLeft, expRight map[low.KeyReference[string]]low.ValueReference[T],
 	changes *[]*Change, label string, compareFunc func(l, r T) R, compare bool) map[string]R {
+	return checkMapForChanges(expLeft, expRight, changes, label, compareFunc, compare, false)
+}
+
+// checkMapForChanges is CheckMapForChangesWithComp, when renames is true, identical values that were removed and added
+// under a new key are reported as Renamed, rather than removed and added (see MatchRenamed).
+func checkMapForChanges[T any, R any](expLeft, expRight map[low.KeyReference[string]]low.ValueReference[T],
+	changes *[]*Change, label string, compareFunc func(l, r T) R, compare, renames bool) map[string]R {
 
 	// stop concurrent threads screwing up changes.
 	var chLock sync.Mutex
@@ -282,6 +293,15 @@ func CheckMapForChangesWithComp[T any, R any](expLeft, expRight map[low.KeyRefer
 
 	expChanges := make(map[string]R)
 
+	if renames {
+		for o, n := range MatchRenamed(lHashes, rHashes) {
+			CreateRenamedChange(changes, label, lValues[o].GetValueNode(), rValues[n].GetValueNode(), false,
+				o, n, lValues[o].GetValue(), rValues[n].GetValue())
+			delete(lHashes, o)
+			delete(rHashes, n)
+		}
+	}
+
 	checkLeft := func(k string, doneChan chan bool, f, g map[string]string, p, h map[string]low.ValueReference[T]) {
 		rhash := g[k]
 		if rhash == "" {
@@ -300,16 +320,16 @@ func CheckMapForChangesWithComp[T any, R any](expLeft, expRight map[low.KeyRefer
 			doneChan <- true
 			return
 		}
-		// run comparison.
+		// run comparison, values are compared at the same time, only the results need locking.
 		if compare {
-			chLock.Lock()
 			ch := compareFunc(p[k].Value, h[k].Value)
 			// incorrect map results were being generated causing panics.
 			// https://github.com/pb33f/libopenapi/issues/61
 			if !reflect.ValueOf(&ch).Elem().IsZero() {
+				chLock.Lock()
 				expChanges[k] = ch
+				chLock.Unlock()
 			}
-			chLock.Unlock()
 		}
 		doneChan <- true
 	}
@@ -340,6 +360,45 @@ func CheckMapForChangesWithComp[T any, R any](expLeft, expRight map[low.KeyRefer
 	return expChanges
 }
 
+// MatchRenamed pairs the keys that were removed from a map with the keys that were added to it, when their values are
+// identical (the hashes match), returning the original key of every renamed value mapped to its new key. Keys are
+// matched in order, and each key is only matched once.
+func MatchRenamed(lHashes, rHashes map[string]string) map[string]string {
+	added := make(map[string][]string)
+	for k, h := range rHashes {
+		if _, ok := lHashes[k]; !ok {
+			added[h] = append(added[h], k)
+		}
+	}
+	var removed []string
+	for k := range lHashes {
+		if _, ok := rHashes[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	renamed := make(map[string]string)
+	for _, k := range removed {
+		candidates := added[lHashes[k]]
+		if len(candidates) == 0 {
+			continue
+		}
+		sort.Strings(candidates)
+		renamed[k] = candidates[0]
+		added[lHashes[k]] = candidates[1:]
+	}
+	return renamed
+}
+
+// CreateRenamedChange creates a Renamed change for an object that was renamed (or moved) from one key to another,
+// the original and new keys are the Original and New of the change.
+func CreateRenamedChange(changes *[]*Change, property string, leftValueNode, rightValueNode *yaml.Node,
+	breaking bool, originalKey, newKey string, originalObject, newObject any) {
+	c := (*CreateChange(changes, Renamed, property, leftValueNode, rightValueNode, breaking,
+		originalObject, newObject))[len(*changes)-1]
+	c.Original, c.New = originalKey, newKey
+}
+
 func checkRightValue[T any](k string, doneChan chan bool, f map[string]string, p map[string]low.ValueReference[T],
 	changes *[]*Change, label string, lock *sync.Mutex) {
 
@@ -430,3 +489,77 @@ func ExtractRawValueSliceChanges(lParam, rParam []low.ValueReference[any],
 		}
 	}
 }
+
+// CompareValueNodes compares two free-form values structurally (like the values of extensions, or examples),
+// creating a change for every nested value that was modified, added or removed, rather than a single change for
+// the whole value. The path to each nested value (like 'routes/0/timeout') is set as the ValuePath of its change,
+// values that are not scalars are set as JSON in the Original and New of a change.
+func CompareValueNodes(changes *[]*Change, label string, l, r *yaml.Node, breaking bool, original, new any) {
+	compareValueNodes(changes, label, "", utils.NodeAlias(l), utils.NodeAlias(r), breaking, original, new)
+}
+
+func compareValueNodes(changes *[]*Change, label, path string, l, r *yaml.Node, breaking bool, original, new any) {
+	change := func(changeType int) {
+		c := (*CreateChange(changes, changeType, label, l, r, breaking, original, new))[len(*changes)-1]
+		c.ValuePath = path
+		c.Original, c.New = renderValueNode(l), renderValueNode(r)
+	}
+	switch {
+	case l == nil && r == nil:
+		return
+	case l == nil:
+		change(PropertyAdded)
+	case r == nil:
+		change(PropertyRemoved)
+	case l.Kind != r.Kind:
+		change(Modified)
+	case l.Kind == yaml.MappingNode:
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(l.Content); i += 2 {
+			key := l.Content[i].Value
+			seen[key] = true
+			_, rv := utils.FindKeyNodeTop(key, r.Content)
+			compareValueNodes(changes, label, joinValuePath(path, key), utils.NodeAlias(l.Content[i+1]),
+				utils.NodeAlias(rv), breaking, original, new)
+		}
+		for i := 0; i+1 < len(r.Content); i += 2 {
+			if key := r.Content[i].Value; !seen[key] {
+				compareValueNodes(changes, label, joinValuePath(path, key), nil, utils.NodeAlias(r.Content[i+1]),
+					breaking, original, new)
+			}
+		}
+	case l.Kind == yaml.SequenceNode:
+		for i := 0; i < len(l.Content) || i < len(r.Content); i++ {
+			var lv, rv *yaml.Node
+			if i < len(l.Content) {
+				lv = utils.NodeAlias(l.Content[i])
+			}
+			if i < len(r.Content) {
+				rv = utils.NodeAlias(r.Content[i])
+			}
+			compareValueNodes(changes, label, joinValuePath(path, fmt.Sprint(i)), lv, rv, breaking, original, new)
+		}
+	case l.Value != r.Value || l.ShortTag() != r.ShortTag():
+		change(Modified)
+	}
+}
+
+func joinValuePath(path, key string) string {
+	key = utils.EscapeJSONPointerSegment(key)
+	if path == "" {
+		return key
+	}
+	return path + "/" + key
+}
+
+// renderValueNode renders a value as a string, scalars are rendered as they are, everything else as JSON.
+func renderValueNode(n *yaml.Node) string {
+	if n == nil {
+		return ""
+	}
+	if n.Kind == yaml.ScalarNode {
+		return n.Value
+	}
+	rendered, _ := utils.ConvertYAMLNodeToJSON(n, "")
+	return string(rendered)
+}
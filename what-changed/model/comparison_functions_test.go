@@ -186,3 +186,10 @@ func Test_CheckMapsAddition(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchRenamed(t *testing.T) {
+	renamed := MatchRenamed(
+		map[string]string{"kept": "a", "b": "x", "a": "x", "gone": "y"},
+		map[string]string{"kept": "a", "d": "x", "c": "x", "new": "z"})
+	assert.Equal(t, map[string]string{"a": "c", "b": "d"}, renamed)
+}
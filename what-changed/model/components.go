@@ -62,7 +62,7 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if rDef != nil {
 			b = rDef.Definitions
 		}
-		CheckMapForAdditionRemoval(a, b, &changes, v3.ParametersLabel)
+		checkMapForChanges(a, b, &changes, v3.ParametersLabel, skipComparison[*v2.Parameter], false, true)
 	}
 
 	// Swagger Responses
@@ -77,7 +77,7 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if rDef != nil {
 			b = rDef.Definitions
 		}
-		CheckMapForAdditionRemoval(a, b, &changes, v3.ResponsesLabel)
+		checkMapForChanges(a, b, &changes, v3.ResponsesLabel, skipComparison[*v2.Response], false, true)
 	}
 
 	// Swagger Schemas
@@ -92,7 +92,7 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if rDef != nil {
 			b = rDef.Schemas
 		}
-		cc.SchemaChanges = CheckMapForChanges(a, b, &changes, v2.DefinitionsLabel, CompareSchemas)
+		cc.SchemaChanges = checkMapForChanges(a, b, &changes, v2.DefinitionsLabel, CompareSchemas, true, true)
 	}
 
 	// Swagger Security Definitions
@@ -107,8 +107,8 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if rDef != nil {
 			b = rDef.Definitions
 		}
-		cc.SecuritySchemeChanges = CheckMapForChanges(a, b, &changes,
-			v3.SecurityDefinitionLabel, CompareSecuritySchemesV2)
+		cc.SecuritySchemeChanges = checkMapForChanges(a, b, &changes,
+			v3.SecurityDefinitionLabel, CompareSecuritySchemesV2, true, true)
 	}
 
 	// OpenAPI Components
@@ -129,55 +129,61 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if !lComponents.Schemas.IsEmpty() || !rComponents.Schemas.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Schemas.Value, rComponents.Schemas.Value,
-				&changes, v3.SchemasLabel, CompareSchemas, doneChan)
+				v3.SchemasLabel, CompareSchemas, doneChan)
 		}
 
 		if !lComponents.Responses.IsEmpty() || !rComponents.Responses.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Responses.Value, rComponents.Responses.Value,
-				&changes, v3.ResponsesLabel, CompareResponseV3, doneChan)
+				v3.ResponsesLabel, CompareResponseV3, doneChan)
 		}
 
 		if !lComponents.Parameters.IsEmpty() || !rComponents.Parameters.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Parameters.Value, rComponents.Parameters.Value,
-				&changes, v3.ParametersLabel, CompareParametersV3, doneChan)
+				v3.ParametersLabel, CompareParametersV3, doneChan)
 		}
 
 		if !lComponents.Examples.IsEmpty() || !rComponents.Examples.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Examples.Value, rComponents.Examples.Value,
-				&changes, v3.ExamplesLabel, CompareExamples, doneChan)
+				v3.ExamplesLabel, CompareExamples, doneChan)
 		}
 
 		if !lComponents.RequestBodies.IsEmpty() || !rComponents.RequestBodies.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.RequestBodies.Value, rComponents.RequestBodies.Value,
-				&changes, v3.RequestBodiesLabel, CompareRequestBodies, doneChan)
+				v3.RequestBodiesLabel, CompareRequestBodies, doneChan)
 		}
 
 		if !lComponents.Headers.IsEmpty() || !rComponents.Headers.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Headers.Value, rComponents.Headers.Value,
-				&changes, v3.HeadersLabel, CompareHeadersV3, doneChan)
+				v3.HeadersLabel, CompareHeadersV3, doneChan)
 		}
 
 		if !lComponents.SecuritySchemes.IsEmpty() || !rComponents.SecuritySchemes.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.SecuritySchemes.Value, rComponents.SecuritySchemes.Value,
-				&changes, v3.SecuritySchemesLabel, CompareSecuritySchemesV3, doneChan)
+				v3.SecuritySchemesLabel, CompareSecuritySchemesV3, doneChan)
 		}
 
 		if !lComponents.Links.IsEmpty() || !rComponents.Links.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Links.Value, rComponents.Links.Value,
-				&changes, v3.LinksLabel, CompareLinks, doneChan)
+				v3.LinksLabel, CompareLinks, doneChan)
 		}
 
 		if !lComponents.Callbacks.IsEmpty() || !rComponents.Callbacks.IsEmpty() {
 			comparisons++
 			go runComparison(lComponents.Callbacks.Value, rComponents.Callbacks.Value,
-				&changes, v3.CallbacksLabel, CompareCallback, doneChan)
+				v3.CallbacksLabel, CompareCallback, doneChan)
+		}
+
+		if !lComponents.PathItems.IsEmpty() || !rComponents.PathItems.IsEmpty() {
+			comparisons++
+			go runComparison(lComponents.PathItems.Value, rComponents.PathItems.Value,
+				v3.PathItemsLabel, ComparePathItemsV3, doneChan)
 		}
 
 		cc.ExtensionChanges = CompareExtensions(lComponents.Extensions, rComponents.Extensions)
@@ -186,6 +192,7 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		for completedComponents < comparisons {
 			select {
 			case res := <-doneChan:
+				changes = append(changes, res.changes...)
 				switch res.prop {
 				case v3.SchemasLabel:
 					completedComponents++
@@ -196,7 +203,7 @@ func CompareComponents(l, r any) *ComponentsChanges {
 					cc.SecuritySchemeChanges = res.result.(map[string]*SecuritySchemeChanges)
 					break
 				case v3.ResponsesLabel, v3.ParametersLabel, v3.ExamplesLabel, v3.RequestBodiesLabel, v3.HeadersLabel,
-					v3.LinksLabel, v3.CallbacksLabel:
+					v3.LinksLabel, v3.CallbacksLabel, v3.PathItemsLabel:
 					completedComponents++
 					break
 				}
@@ -212,29 +219,41 @@ func CompareComponents(l, r any) *ComponentsChanges {
 }
 
 type componentComparison struct {
-	prop   string
-	result any
+	prop    string
+	result  any
+	changes []*Change
 }
 
-// run a generic comparison in a thread which in turn splits checks into further threads.
+// run a generic comparison in a thread which in turn splits checks into further threads. Each comparison collects
+// its own changes, so comparisons running at the same time don't share them.
 func runComparison[T any, R any](l, r map[low.KeyReference[string]]low.ValueReference[T],
-	changes *[]*Change, label string, compareFunc func(l, r T) R, doneChan chan componentComparison) {
+	label string, compareFunc func(l, r T) R, doneChan chan componentComparison) {
+
+	var changes []*Change
 
 	// for schemas
 	if label == v3.SchemasLabel || label == v2.DefinitionsLabel || label == v3.SecuritySchemesLabel {
+		result := checkMapForChanges(l, r, &changes, label, compareFunc, true, true)
 		doneChan <- componentComparison{
-			prop:   label,
-			result: CheckMapForChanges(l, r, changes, label, compareFunc),
+			prop:    label,
+			result:  result,
+			changes: changes,
 		}
 		return
 	} else {
+		checkMapForChanges(l, r, &changes, label, skipComparison[T], false, true)
 		doneChan <- componentComparison{
-			prop:   label,
-			result: CheckMapForAdditionRemoval(l, r, changes, label),
+			prop:    label,
+			changes: changes,
 		}
 	}
 }
 
+// skipComparison is used to only check components for additions, removals and renames.
+func skipComparison[T any](l, r T) any {
+	return nil
+}
+
 // GetAllChanges returns a slice of all changes made between Callback objects
 func (c *ComponentsChanges) GetAllChanges() []*Change {
 	var changes []*Change
@@ -1098,3 +1098,40 @@ func TestCompareComponents_OpenAPI_Extensions_Modified(t *testing.T) {
 	assert.Equal(t, 1, extChanges.TotalChanges())
 	assert.Len(t, extChanges.GetAllChanges(), 1)
 }
+
+func TestCompareComponents_OpenAPI_Schemas_Renamed(t *testing.T) {
+
+	left := `schemas:
+  coffee:
+    description: tasty
+  tv:
+    description: mostly boring.`
+
+	right := `schemas:
+  coffee:
+    description: tasty
+  television:
+    description: mostly boring.`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Components
+	var rDoc v3.Components
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareComponents(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+	assert.Equal(t, Renamed, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.SchemasLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, "tv", extChanges.Changes[0].Original)
+	assert.Equal(t, "television", extChanges.Changes[0].New)
+	assert.Equal(t, 5, *extChanges.Changes[0].Context.NewLine)
+}
@@ -12,11 +12,13 @@ package model
 
 import (
 	"reflect"
+	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
 )
 
 // DocumentChanges represents all the changes made to an OpenAPI document.
@@ -139,6 +141,21 @@ func CompareDocuments(l, r any) *DocumentChanges {
 
 	dc := new(DocumentChanges)
 
+	// every level of a comparison hashes everything below it, only hash each model once (for this comparison).
+	var indexes []*index.SpecIndex
+	for _, doc := range []any{l, r} {
+		if hi, ok := doc.(low.HasIndex); ok {
+			indexes = append(indexes, hi.GetIndex())
+		}
+	}
+	defer low.NewHashCache().Attach(indexes...)()
+
+	// hashes are only used to skip models that haven't changed, fields ignored by hash options may have.
+	defer low.SuspendHashOptions()()
+
+	// paths are compared while everything else is.
+	var pathsDone sync.WaitGroup
+
 	if reflect.TypeOf(&v2.Swagger{}) == reflect.TypeOf(l) && reflect.TypeOf(&v2.Swagger{}) == reflect.TypeOf(r) {
 		lDoc := l.(*v2.Swagger)
 		rDoc := r.(*v2.Swagger)
@@ -176,7 +193,11 @@ func CompareDocuments(l, r any) *DocumentChanges {
 
 		// paths
 		if !lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty() {
-			dc.PathsChanges = ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+			pathsDone.Add(1)
+			go func() {
+				defer pathsDone.Done()
+				dc.PathsChanges = ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+			}()
 		}
 
 		// external docs
@@ -231,7 +252,11 @@ func CompareDocuments(l, r any) *DocumentChanges {
 
 		// paths
 		if !lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty() {
-			dc.PathsChanges = ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+			pathsDone.Add(1)
+			go func() {
+				defer pathsDone.Done()
+				dc.PathsChanges = ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+			}()
 		}
 
 		// external docs
@@ -273,6 +298,7 @@ func CompareDocuments(l, r any) *DocumentChanges {
 		dc.ExtensionChanges = CompareExtensions(lDoc.Extensions, rDoc.Extensions)
 	}
 
+	pathsDone.Wait()
 	CheckProperties(props)
 	dc.PropertyChanges = NewPropertyChanges(changes)
 	if dc.TotalChanges() <= 0 {
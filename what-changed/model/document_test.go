@@ -1100,6 +1100,64 @@ webhooks:
 	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
 }
 
+func TestCompareDocuments_OpenAPI_AddRemoveWebhooks(t *testing.T) {
+
+	left := `openapi: 3.1
+webhooks:
+  bHook:
+    get:
+      description: coffee`
+
+	right := `openapi: 3.1
+webhooks:
+  aHook:
+    get:
+      description: jazz`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+
+	lDoc, _ := v3.CreateDocument(siLeft)
+	rDoc, _ := v3.CreateDocument(siRight)
+
+	// compare.
+	extChanges := CompareDocuments(lDoc, rDoc)
+
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 2)
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareDocuments_OpenAPI_AddRemoveComponentPathItems(t *testing.T) {
+
+	left := `openapi: 3.1
+components:
+  pathItems:
+    coffee:
+      get:
+        description: coffee`
+
+	right := `openapi: 3.1
+components:
+  pathItems:
+    jazz:
+      get:
+        description: jazz`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+
+	lDoc, _ := v3.CreateDocument(siLeft)
+	rDoc, _ := v3.CreateDocument(siRight)
+
+	// compare.
+	extChanges := CompareDocuments(lDoc, rDoc)
+
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 2)
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+}
+
 func TestCompareDocuments_OpenAPIExampleMapChanges(t *testing.T) {
 	left := `openapi: 3.0.0
 paths:
@@ -1183,3 +1241,53 @@ paths:
 	extChanges := CompareDocuments(lDoc, rDoc)
 	assert.Nil(t, extChanges)
 }
+
+func TestCompareDocuments_OpenAPI_HashOptions(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  topping:
+                    type: string
+                    description: the topping`
+
+	right := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  topping:
+                    type: string
+                    description: the only topping`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+
+	lDoc, _ := v3.CreateDocument(siLeft)
+	rDoc, _ := v3.CreateDocument(siRight)
+
+	// ignored fields still change, the documents only hash the same.
+	defer low.SetHashOptions(nil)
+	low.SetHashOptions(&low.HashOptions{IgnoreDescriptions: true})
+	assert.Equal(t, lDoc.Paths.Value.Hash(), rDoc.Paths.Value.Hash())
+
+	extChanges := CompareDocuments(lDoc, rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, "the only topping", extChanges.GetAllChanges()[0].New)
+	assert.Equal(t, lDoc.Paths.Value.Hash(), rDoc.Paths.Value.Hash())
+}
@@ -4,15 +4,8 @@
 package model
 
 import (
-	"crypto/sha256"
-	"fmt"
-	"sort"
-
-	"gopkg.in/yaml.v3"
-
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
-	"github.com/pb33f/libopenapi/utils"
 )
 
 // ExampleChanges represent changes to an Example object, part of an OpenAPI specification.
@@ -77,120 +70,8 @@ func CompareExamples(l, r *base.Example) *ExampleChanges {
 	})
 
 	// Value
-	if utils.IsNodeMap(l.Value.ValueNode) && utils.IsNodeMap(r.Value.ValueNode) {
-		lKeys := make([]string, len(l.Value.ValueNode.Content)/2)
-		rKeys := make([]string, len(r.Value.ValueNode.Content)/2)
-		z := 0
-		for k := range l.Value.ValueNode.Content {
-			if k%2 == 0 {
-				// if there is no value (value is another map or something else), render the node into yaml and hash it.
-				// https://github.com/pb33f/libopenapi/issues/61
-				val := l.Value.ValueNode.Content[k+1].Value
-				if val == "" {
-					yaml, _ := yaml.Marshal(l.Value.ValueNode.Content[k+1].Content)
-					val = fmt.Sprint(sha256.Sum256(yaml))
-				}
-				lKeys[z] = fmt.Sprintf("%v-%v-%v",
-					l.Value.ValueNode.Content[k].Value,
-					l.Value.ValueNode.Content[k+1].Tag,
-					fmt.Sprintf("%x", val))
-				z++
-			} else {
-				continue
-			}
-		}
-		z = 0
-		for k := range r.Value.ValueNode.Content {
-			if k%2 == 0 {
-				// if there is no value (value is another map or something else), render the node into yaml and hash it.
-				// https://github.com/pb33f/libopenapi/issues/61
-				val := r.Value.ValueNode.Content[k+1].Value
-				if val == "" {
-					yaml, _ := yaml.Marshal(r.Value.ValueNode.Content[k+1].Content)
-					val = fmt.Sprint(sha256.Sum256(yaml))
-				}
-				rKeys[z] = fmt.Sprintf("%v-%v-%v",
-					r.Value.ValueNode.Content[k].Value,
-					r.Value.ValueNode.Content[k+1].Tag,
-					fmt.Sprintf("%x", val))
-				z++
-			} else {
-				continue
-			}
-		}
-		sort.Strings(lKeys)
-		sort.Strings(rKeys)
-		//if (len(lKeys) > len(rKeys)) || (len(rKeys) > len(lKeys)) {
-		//    CreateChange(&changes, Modified, v3.ValueLabel,
-		//        l.Value.GetValueNode(), r.Value.GetValueNode(), false, l.Value.GetValue(), r.Value.GetValue())
-		//}
-		for k := range lKeys {
-			if k < len(rKeys) && lKeys[k] != rKeys[k] {
-
-				if utils.IsNodeMap(l.Value.ValueNode) || utils.IsNodeArray(l.Value.ValueNode) {
-					// render down object
-					rendered, _ := yaml.Marshal(l.Value.ValueNode)
-					l.Value.ValueNode.Value = string(rendered)
-				}
-
-				if utils.IsNodeMap(r.Value.ValueNode) || utils.IsNodeArray(r.Value.ValueNode) {
-					// render down object
-					rendered, _ := yaml.Marshal(r.Value.ValueNode)
-					r.Value.ValueNode.Value = string(rendered)
-				}
-
-				CreateChange(&changes, Modified, v3.ValueLabel,
-					l.Value.GetValueNode(), r.Value.GetValueNode(), false, l.Value.GetValue(), r.Value.GetValue())
-				continue
-			}
-			if k >= len(rKeys) {
-
-				if utils.IsNodeMap(l.Value.ValueNode) || utils.IsNodeArray(l.Value.ValueNode) {
-					// render down object
-					rendered, _ := yaml.Marshal(l.Value.ValueNode)
-					l.Value.ValueNode.Value = string(rendered)
-				}
+	CompareValueNodes(&changes, v3.ValueLabel, l.Value.ValueNode, r.Value.ValueNode, false, l, r)
 
-				if utils.IsNodeMap(r.Value.ValueNode) || utils.IsNodeArray(r.Value.ValueNode) {
-					// render down object
-					rendered, _ := yaml.Marshal(r.Value.ValueNode)
-					r.Value.ValueNode.Value = string(rendered)
-				}
-
-				CreateChange(&changes, PropertyRemoved, v3.ValueLabel,
-					l.Value.ValueNode, r.Value.ValueNode, false, l.Value.Value, r.Value.Value)
-			}
-		}
-		for k := range rKeys {
-			if k >= len(lKeys) {
-
-				if utils.IsNodeMap(l.Value.ValueNode) || utils.IsNodeArray(l.Value.ValueNode) {
-					// render down object
-					rendered, _ := yaml.Marshal(l.Value.ValueNode)
-					l.Value.ValueNode.Value = string(rendered)
-				}
-
-				if utils.IsNodeMap(r.Value.ValueNode) || utils.IsNodeArray(r.Value.ValueNode) {
-					// render down object
-					rendered, _ := yaml.Marshal(r.Value.ValueNode)
-					r.Value.ValueNode.Value = string(rendered)
-				}
-
-				CreateChange(&changes, PropertyAdded, v3.ValueLabel,
-					l.Value.ValueNode, r.Value.ValueNode, false, l.Value.Value, r.Value.Value)
-			}
-		}
-	} else {
-		props = append(props, &PropertyCheck{
-			LeftNode:  l.Value.ValueNode,
-			RightNode: r.Value.ValueNode,
-			Label:     v3.ValueLabel,
-			Changes:   &changes,
-			Breaking:  false,
-			Original:  l,
-			New:       r,
-		})
-	}
 	// ExternalValue
 	props = append(props, &PropertyCheck{
 		LeftNode:  l.ExternalValue.ValueNode,
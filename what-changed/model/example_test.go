@@ -227,3 +227,40 @@ func TestCompareExamples_Date(t *testing.T) {
 
 	assert.Equal(t, 1, changes.TotalChanges())
 }
+
+func TestCompareExamples_NestedValue(t *testing.T) {
+
+	left := `value:
+  pet:
+    name: chicken
+    tags: [small, loud]`
+
+	right := `value:
+  pet:
+    name: chicken
+    tags: [small, quiet]
+    owner/name: dave`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc base.Example
+	var rDoc base.Example
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	extChanges := CompareExamples(&lDoc, &rDoc)
+
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.ValueLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, "pet/tags/1", extChanges.Changes[0].ValuePath)
+	assert.Equal(t, "loud", extChanges.Changes[0].Original)
+	assert.Equal(t, "quiet", extChanges.Changes[0].New)
+	assert.Equal(t, PropertyAdded, extChanges.Changes[1].ChangeType)
+	assert.Equal(t, "pet/owner~1name", extChanges.Changes[1].ValuePath)
+}
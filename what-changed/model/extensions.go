@@ -29,11 +29,8 @@ func (e *ExtensionChanges) TotalBreakingChanges() int {
 }
 
 // CompareExtensions will compare a left and right map of Tag/ValueReference models for any changes to
-// anything. This function does not try and cast the value of an extension to perform checks, it
-// will perform a basic value check.
-//
-// A current limitation relates to extensions being objects and a property of the object changes,
-// there is currently no support for knowing anything changed - so it is ignored.
+// anything. Extension values are compared structurally, so a change to a nested property of an extension object
+// is reported as a change to that property (see CompareValueNodes).
 func CompareExtensions(l, r map[low.KeyReference[string]]low.ValueReference[any]) *ExtensionChanges {
 
 	// look at the original and then look through the new.
@@ -54,20 +51,8 @@ func CompareExtensions(l, r map[low.KeyReference[string]]low.ValueReference[any]
 		CheckForObjectAdditionOrRemoval[any](seenLeft, seenRight, i, &changes, false, true)
 
 		if seenRight[i] != nil {
-			var props []*PropertyCheck
-
-			props = append(props, &PropertyCheck{
-				LeftNode:  seenLeft[i].ValueNode,
-				RightNode: seenRight[i].ValueNode,
-				Label:     i,
-				Changes:   &changes,
-				Breaking:  false,
-				Original:  seenLeft[i].Value,
-				New:       seenRight[i].Value,
-			})
-
-			// check properties
-			CheckProperties(props)
+			CompareValueNodes(&changes, i, seenLeft[i].ValueNode, seenRight[i].ValueNode, false,
+				seenLeft[i].Value, seenRight[i].Value)
 		}
 	}
 	for i := range seenRight {
@@ -100,3 +100,69 @@ func TestCompareExtensions_Identical(t *testing.T) {
 
 	assert.Nil(t, extChanges)
 }
+
+func TestCompareExtensions_NestedValue(t *testing.T) {
+
+	left := `x-gateway:
+  routes:
+    - path: /pets
+      timeout: 30
+  cors: true`
+
+	right := `x-gateway:
+  routes:
+    - path: /pets
+      timeout: 60
+    - path: /owners/{id}
+  cache: false`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	lExt := low.ExtractExtensions(lNode.Content[0])
+	rExt := low.ExtractExtensions(rNode.Content[0])
+
+	extChanges := CompareExtensions(lExt, rExt)
+
+	assert.Equal(t, 4, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, "x-gateway", extChanges.Changes[0].Property)
+	assert.Equal(t, "routes/0/timeout", extChanges.Changes[0].ValuePath)
+	assert.Equal(t, "30", extChanges.Changes[0].Original)
+	assert.Equal(t, "60", extChanges.Changes[0].New)
+	assert.Equal(t, 4, *extChanges.Changes[0].Context.NewLine)
+
+	assert.Equal(t, PropertyAdded, extChanges.Changes[1].ChangeType)
+	assert.Equal(t, "routes/1", extChanges.Changes[1].ValuePath)
+	assert.Equal(t, `{"path":"/owners/{id}"}`, extChanges.Changes[1].New)
+
+	assert.Equal(t, PropertyRemoved, extChanges.Changes[2].ChangeType)
+	assert.Equal(t, "cors", extChanges.Changes[2].ValuePath)
+	assert.Equal(t, "true", extChanges.Changes[2].Original)
+
+	assert.Equal(t, PropertyAdded, extChanges.Changes[3].ChangeType)
+	assert.Equal(t, "cache", extChanges.Changes[3].ValuePath)
+}
+
+func TestCompareExtensions_NestedValueIdentical(t *testing.T) {
+
+	left := `x-gateway:
+  routes: [/pets, /owners]`
+
+	right := `x-gateway:
+  routes:
+    - /pets
+    - /owners`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	lExt := low.ExtractExtensions(lNode.Content[0])
+	rExt := low.ExtractExtensions(rNode.Content[0])
+
+	assert.Nil(t, CompareExtensions(lExt, rExt))
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"path"
+	"reflect"
+	"strings"
+
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// IgnoreRules are changes that should be left out of a comparison (see IgnoreChanges).
+type IgnoreRules struct {
+	// Descriptions ignores changes to descriptions.
+	Descriptions bool
+
+	// Examples ignores changes to examples.
+	Examples bool
+
+	// Extensions ignores changes to the extensions that match, patterns are matched with path.Match, for example
+	// 'x-internal-*'.
+	Extensions []string
+
+	// Paths ignores changes to the paths that match, patterns are matched with path.Match. A pattern also matches
+	// every path below the paths it matches, so '/internal' ignores '/internal/users' as well.
+	Paths []string
+}
+
+// Ignores returns true if a change should be ignored.
+func (i *IgnoreRules) Ignores(change *Change, location *ChangeLocation) bool {
+	switch {
+	case i.Descriptions && change.Property == v3.DescriptionLabel:
+		return true
+	case i.Examples && (change.Property == v3.ExampleLabel || change.Property == v3.ExamplesLabel ||
+		location.Object == "Example" || location.Object == "Examples"):
+		return true
+	case strings.HasPrefix(change.Property, "x-") && matchesAny(i.Extensions, change.Property, false):
+		return true
+	}
+	if p := changedPath(change, location); p != "" && matchesAny(i.Paths, p, true) {
+		return true
+	}
+	return false
+}
+
+// ChangeScope limits a comparison to a single path, operation or component (see ScopeChanges).
+type ChangeScope struct {
+	// Path limits changes to a single path, like '/pets'.
+	Path string
+
+	// Method limits changes to a single operation of the Path, like 'get'.
+	Method string
+
+	// Component limits changes to a single component (or Swagger definition), as its type and name, like
+	// 'schemas/Pet' or 'definitions/Pet'.
+	Component string
+}
+
+// Contains returns true if a change is in the scope.
+func (s *ChangeScope) Contains(change *Change, location *ChangeLocation) bool {
+	if s.Component != "" {
+		kind, name, _ := strings.Cut(s.Component, "/")
+		if location.Object == "Components" && change.Property == kind {
+			return change.Original == name || change.New == name
+		}
+		if kind == v2.DefinitionsLabel {
+			kind = v3.SchemasLabel
+		}
+		return len(location.Path) >= 3 && location.Path[0] == v3.ComponentsLabel && location.Path[1] == kind &&
+			location.Path[2] == name
+	}
+	if s.Path == "" {
+		return true
+	}
+	if changedPath(change, location) != s.Path {
+		return false
+	}
+	if s.Method == "" {
+		return true
+	}
+	method := strings.ToLower(s.Method)
+	if location.Object == "PathItem" && len(location.Path) == 3 {
+		return change.Property == method // the operation was added or removed.
+	}
+	return len(location.Path) >= 4 && location.Path[3] == method
+}
+
+// IgnoreChanges removes every change the rules ignore from a tree of changes (like DocumentChanges), returning the
+// number of changes that are left. Objects in the tree that are left without changes are removed.
+func IgnoreChanges(changes any, rules *IgnoreRules) int {
+	return FilterChanges(changes, func(change *Change, location *ChangeLocation) bool {
+		return !rules.Ignores(change, location)
+	})
+}
+
+// ScopeChanges removes every change that is not in the scope from a tree of changes (like DocumentChanges),
+// returning the number of changes that are left, so only a single path, operation or component is compared.
+func ScopeChanges(changes any, scope *ChangeScope) int {
+	return FilterChanges(changes, scope.Contains)
+}
+
+// FilterChanges removes every change that keep returns false for from a tree of changes, returning the number of
+// changes that are left. Objects in the tree that are left without changes are removed.
+func FilterChanges(changes any, keep func(change *Change, location *ChangeLocation) bool) int {
+	removed := make(map[*Change]bool)
+	WalkChanges(changes, func(change *Change, location *ChangeLocation) {
+		if !keep(change, location) {
+			removed[change] = true
+		}
+	})
+	return pruneChanges(reflect.ValueOf(changes), removed)
+}
+
+// pruneChanges removes changes from a tree of changes, and any objects left without changes. Returns the number of
+// changes left.
+func pruneChanges(v reflect.Value, removed map[*Change]bool) int {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return 0
+		}
+		return pruneChanges(v.Elem(), removed)
+	case reflect.Slice:
+		kept := reflect.MakeSlice(v.Type(), 0, v.Len())
+		count := 0
+		for i := 0; i < v.Len(); i++ {
+			if c := pruneChanges(v.Index(i), removed); c > 0 {
+				kept = reflect.Append(kept, v.Index(i))
+				count += c
+			}
+		}
+		if v.CanSet() {
+			v.Set(kept)
+		}
+		return count
+	case reflect.Map:
+		count := 0
+		for _, k := range v.MapKeys() {
+			if c := pruneChanges(v.MapIndex(k), removed); c > 0 {
+				count += c
+			} else {
+				v.SetMapIndex(k, reflect.Value{})
+			}
+		}
+		return count
+	case reflect.Struct:
+		if v.Type() == propertyChangesType {
+			changes := v.Addr().Interface().(*PropertyChanges)
+			kept := changes.Changes[:0:0]
+			for _, c := range changes.Changes {
+				if !removed[c] {
+					kept = append(kept, c)
+				}
+			}
+			changes.Changes = kept
+			return len(kept)
+		}
+		count := 0
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			c := pruneChanges(v.Field(i), removed)
+			if c == 0 && !field.Anonymous && v.Field(i).CanSet() && isChangesKind(field.Type.Kind()) {
+				v.Field(i).Set(reflect.Zero(field.Type))
+			}
+			count += c
+		}
+		return count
+	}
+	return 0
+}
+
+// isChangesKind returns true for the kinds of fields that hold changes.
+func isChangesKind(kind reflect.Kind) bool {
+	return kind == reflect.Pointer || kind == reflect.Map || kind == reflect.Slice || kind == reflect.Interface
+}
+
+// changedPath returns the path a change was made to, if it was made to a path.
+func changedPath(change *Change, location *ChangeLocation) string {
+	if location.Object == "Paths" && change.Property == v3.PathLabel {
+		if change.Original != "" {
+			return change.Original
+		}
+		return change.New
+	}
+	if len(location.Path) >= 3 && location.Path[0] == v3.PathsLabel {
+		return location.Path[2]
+	}
+	return ""
+}
+
+// matchesAny returns true if a name matches one of the patterns. If parents is true, a pattern that matches a
+// parent of the name (split by '/') matches too.
+func matchesAny(patterns []string, name string, parents bool) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+		if !parents {
+			continue
+		}
+		for i := len(name) - 1; i > 0; i-- {
+			if name[i] != '/' {
+				continue
+			}
+			if ok, _ := path.Match(p, name[:i]); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
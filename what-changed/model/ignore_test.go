@@ -0,0 +1,159 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var ignoreLeft = `openapi: 3.1.0
+x-team: pizza
+x-internal-id: 1
+paths:
+  /pizza:
+    get:
+      description: get a pizza
+      responses:
+        '200':
+          description: a pizza
+    post:
+      responses:
+        '201':
+          description: made a pizza
+  /internal/oven:
+    get:
+      responses:
+        '200':
+          description: hot
+components:
+  schemas:
+    Pizza:
+      type: object
+      description: a pizza
+      example: {name: margherita}
+    Burger:
+      type: string
+  responses:
+    Gone:
+      description: gone`
+
+var ignoreRight = `openapi: 3.1.0
+x-team: burger
+x-internal-id: 2
+paths:
+  /pizza:
+    get:
+      description: get a hot pizza
+      responses:
+        '200':
+          description: a hot pizza
+    post:
+      responses:
+        '202':
+          description: making a pizza
+  /internal/oven:
+    get:
+      responses:
+        '200':
+          description: very hot
+  /internal/oven/fire:
+    get:
+      responses:
+        '200':
+          description: fire
+components:
+  schemas:
+    Pizza:
+      type: object
+      description: a tasty pizza
+      example: {name: pepperoni}
+    Burger:
+      type: integer`
+
+func compareForIgnore(t *testing.T) *DocumentChanges {
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(ignoreLeft))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(ignoreRight))
+	lDoc, _ := v3.CreateDocumentFromConfig(lInfo, datamodel.NewClosedDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(rInfo, datamodel.NewClosedDocumentConfiguration())
+	changes := CompareDocuments(lDoc, rDoc)
+	assert.NotNil(t, changes)
+	return changes
+}
+
+func changedProperties(changes any) map[string]int {
+	properties := make(map[string]int)
+	WalkChanges(changes, func(change *Change, _ *ChangeLocation) {
+		properties[change.Property]++
+	})
+	return properties
+}
+
+func TestIgnoreChanges_Descriptions(t *testing.T) {
+	changes := compareForIgnore(t)
+	before := changes.TotalChanges()
+	descriptions := changedProperties(changes)[v3.DescriptionLabel]
+	assert.NotZero(t, descriptions)
+
+	left := IgnoreChanges(changes, &IgnoreRules{Descriptions: true})
+	assert.Equal(t, before-descriptions, left)
+	assert.Equal(t, left, changes.TotalChanges())
+	assert.Zero(t, changedProperties(changes)[v3.DescriptionLabel])
+}
+
+func TestIgnoreChanges_ExamplesAndExtensions(t *testing.T) {
+	changes := compareForIgnore(t)
+	IgnoreChanges(changes, &IgnoreRules{Examples: true, Extensions: []string{"x-internal-*"}})
+	properties := changedProperties(changes)
+	assert.Zero(t, properties[v3.ExampleLabel])
+	assert.Zero(t, properties["x-internal-id"])
+	assert.Equal(t, 1, properties["x-team"])
+}
+
+func TestIgnoreChanges_Paths(t *testing.T) {
+	changes := compareForIgnore(t)
+	IgnoreChanges(changes, &IgnoreRules{Paths: []string{"/internal"}})
+	for path := range changes.PathsChanges.PathItemsChanges {
+		assert.Equal(t, "/pizza", path)
+	}
+	for _, c := range changes.PathsChanges.Changes {
+		assert.NotEqual(t, "/internal/oven/fire", c.New)
+	}
+}
+
+func TestIgnoreChanges_RemovesEmptyObjects(t *testing.T) {
+	changes := compareForIgnore(t)
+	IgnoreChanges(changes, &IgnoreRules{Paths: []string{"/*"}})
+	assert.Nil(t, changes.PathsChanges)
+	assert.NotNil(t, changes.ComponentsChanges)
+}
+
+func TestScopeChanges_Operation(t *testing.T) {
+	changes := compareForIgnore(t)
+	left := ScopeChanges(changes, &ChangeScope{Path: "/pizza", Method: "GET"})
+	assert.Equal(t, 2, left)
+	assert.Nil(t, changes.ComponentsChanges)
+	assert.Nil(t, changes.ExtensionChanges)
+	assert.Len(t, changes.PathsChanges.PathItemsChanges, 1)
+	assert.Nil(t, changes.PathsChanges.PathItemsChanges["/pizza"].PostChanges)
+}
+
+func TestScopeChanges_Path(t *testing.T) {
+	changes := compareForIgnore(t)
+	assert.Equal(t, 1, ScopeChanges(changes, &ChangeScope{Path: "/internal/oven/fire"}))
+	assert.Equal(t, "/internal/oven/fire", changes.PathsChanges.Changes[0].New)
+}
+
+func TestScopeChanges_Component(t *testing.T) {
+	changes := compareForIgnore(t)
+	assert.Equal(t, 1, ScopeChanges(changes, &ChangeScope{Component: "schemas/Burger"}))
+	assert.Len(t, changes.ComponentsChanges.SchemaChanges, 1)
+
+	changes = compareForIgnore(t)
+	assert.Equal(t, 1, ScopeChanges(changes, &ChangeScope{Component: "responses/Gone"}))
+	assert.Equal(t, ObjectRemoved, changes.ComponentsChanges.Changes[0].ChangeType)
+}
@@ -401,3 +401,40 @@ license:
 	extChanges := CompareInfo(&lDoc, &rDoc)
 	assert.Nil(t, extChanges)
 }
+
+func TestCompareInfo_SummaryModified(t *testing.T) {
+
+	left := `title: a nice spec
+summary: a short spec
+version: '1.2.3'
+license:
+  name: MIT
+  identifier: MIT`
+
+	right := `title: a nice spec
+summary: a much longer spec
+version: '1.2.3'
+license:
+  name: MIT
+  identifier: Apache-2.0`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc base.Info
+	var rDoc base.Info
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareInfo(&lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Len(t, extChanges.Changes, 1)
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.SummaryLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, v3.IdentifierLabel, extChanges.LicenseChanges.Changes[0].Property)
+}
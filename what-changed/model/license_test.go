@@ -197,3 +197,60 @@ url: https://pb33f.io`
 	extChanges := CompareLicense(&lDoc, &rDoc)
 	assert.Nil(t, extChanges)
 }
+
+func TestCompareLicense_IdentifierModified(t *testing.T) {
+
+	left := `name: buckaroo
+identifier: MIT`
+
+	right := `name: buckaroo
+identifier: Apache-2.0`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc lowbase.License
+	var rDoc lowbase.License
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareLicense(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, "identifier", extChanges.Changes[0].Property)
+	assert.Equal(t, "Apache-2.0", extChanges.Changes[0].New)
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareLicense_URLReplacedWithIdentifier(t *testing.T) {
+
+	left := `name: buckaroo
+url: https://pb33f.io`
+
+	right := `name: buckaroo
+identifier: MIT`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc lowbase.License
+	var rDoc lowbase.License
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareLicense(&lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, PropertyRemoved, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, PropertyAdded, extChanges.Changes[1].ChangeType)
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+}
@@ -90,17 +90,22 @@ func CompareMediaTypes(l, r *v3.MediaType) *MediaTypeChanges {
 
 	// Example
 	if !l.Example.IsEmpty() && !r.Example.IsEmpty() {
-		if (utils.IsNodeMap(l.Example.ValueNode) && utils.IsNodeMap(r.Example.ValueNode)) ||
-			(utils.IsNodeArray(l.Example.ValueNode) && utils.IsNodeArray(r.Example.ValueNode)) {
-			render, _ := yaml.Marshal(l.Example.ValueNode)
-			render, _ = utils.ConvertYAMLtoJSON(render)
-			l.Example.ValueNode.Value = string(render)
-			render, _ = yaml.Marshal(r.Example.ValueNode)
-			render, _ = utils.ConvertYAMLtoJSON(render)
-			r.Example.ValueNode.Value = string(render)
+		if utils.IsNodeMap(l.Example.ValueNode) && utils.IsNodeMap(r.Example.ValueNode) {
+			// objects are compared structurally, reporting the nested fields that changed.
+			CompareValueNodes(&changes, v3.ExampleLabel, l.Example.ValueNode, r.Example.ValueNode, false,
+				l.Example.Value, r.Example.Value)
+		} else {
+			if utils.IsNodeArray(l.Example.ValueNode) && utils.IsNodeArray(r.Example.ValueNode) {
+				render, _ := yaml.Marshal(l.Example.ValueNode)
+				render, _ = utils.ConvertYAMLtoJSON(render)
+				l.Example.ValueNode.Value = string(render)
+				render, _ = yaml.Marshal(r.Example.ValueNode)
+				render, _ = utils.ConvertYAMLtoJSON(render)
+				r.Example.ValueNode.Value = string(render)
+			}
+			addPropertyCheck(&props, l.Example.ValueNode, r.Example.ValueNode,
+				l.Example.Value, r.Example.Value, &changes, v3.ExampleLabel, false)
 		}
-		addPropertyCheck(&props, l.Example.ValueNode, r.Example.ValueNode,
-			l.Example.Value, r.Example.Value, &changes, v3.ExampleLabel, false)
 
 	} else {
 
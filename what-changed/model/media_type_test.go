@@ -121,8 +121,10 @@ example:
 	assert.Equal(t, 1, extChanges.TotalChanges())
 	assert.Len(t, extChanges.GetAllChanges(), 1)
 	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
-	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, PropertyAdded, extChanges.Changes[0].ChangeType)
 	assert.Equal(t, v3.ExampleLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, "pipe", extChanges.Changes[0].ValuePath)
+	assert.Equal(t, "pipe and a crepe?", extChanges.Changes[0].New)
 }
 
 func TestCompareMediaTypes_ExampleChangedToMap(t *testing.T) {
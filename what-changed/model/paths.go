@@ -96,7 +96,11 @@ func ComparePaths(l, r any) *PathsChanges {
 		doneChan := make(chan bool)
 		pathsChecked := 0
 
+		moved := checkMovedPaths(lKeys, rKeys, &changes)
 		for k := range lKeys {
+			if _, ok := moved[k]; ok {
+				continue
+			}
 			if _, ok := rKeys[k]; ok {
 				go compare(k, pathChanges, lKeys[k].Value, rKeys[k].Value, doneChan)
 				pathsChecked++
@@ -109,7 +113,7 @@ func ComparePaths(l, r any) *PathsChanges {
 		}
 
 		for k := range rKeys {
-			if _, ok := lKeys[k]; !ok {
+			if _, ok := lKeys[k]; !ok && !movedTo(moved, k) {
 				g, p := rPath.FindPathAndKey(k)
 				CreateChange(&changes, ObjectAdded, v3.PathLabel,
 					nil, g.KeyNode, false,
@@ -167,7 +171,11 @@ func ComparePaths(l, r any) *PathsChanges {
 		doneChan := make(chan bool)
 		pathsChecked := 0
 
+		moved := checkMovedPaths(lKeys, rKeys, &changes)
 		for k := range lKeys {
+			if _, ok := moved[k]; ok {
+				continue
+			}
 			if _, ok := rKeys[k]; ok {
 				go compare(k, pathChanges, lKeys[k].Value, rKeys[k].Value, doneChan)
 				pathsChecked++
@@ -180,7 +188,7 @@ func ComparePaths(l, r any) *PathsChanges {
 		}
 
 		for k := range rKeys {
-			if _, ok := lKeys[k]; !ok {
+			if _, ok := lKeys[k]; !ok && !movedTo(moved, k) {
 				g, p := rPath.FindPathAndKey(k)
 				CreateChange(&changes, ObjectAdded, v3.PathLabel,
 					nil, g.KeyNode, false,
@@ -204,3 +212,35 @@ func ComparePaths(l, r any) *PathsChanges {
 	pc.PropertyChanges = NewPropertyChanges(changes)
 	return pc
 }
+
+// checkMovedPaths reports every path that was moved (the same path item is now at a different path) as Renamed,
+// returning the moved paths (original to new), so they are not reported as removed and added.
+func checkMovedPaths[T any](lKeys, rKeys map[string]low.ValueReference[T], changes *[]*Change) map[string]string {
+	lHashes := make(map[string]string)
+	rHashes := make(map[string]string)
+	for k := range lKeys {
+		if _, ok := rKeys[k]; !ok {
+			lHashes[k] = low.GenerateHashString(lKeys[k].Value)
+		}
+	}
+	for k := range rKeys {
+		if _, ok := lKeys[k]; !ok {
+			rHashes[k] = low.GenerateHashString(rKeys[k].Value)
+		}
+	}
+	moved := MatchRenamed(lHashes, rHashes)
+	for o, n := range moved {
+		CreateRenamedChange(changes, v3.PathLabel, lKeys[o].ValueNode, rKeys[n].ValueNode, true,
+			o, n, lKeys[o].Value, rKeys[n].Value)
+	}
+	return moved
+}
+
+func movedTo(moved map[string]string, path string) bool {
+	for _, n := range moved {
+		if n == path {
+			return true
+		}
+	}
+	return false
+}
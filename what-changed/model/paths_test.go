@@ -344,3 +344,87 @@ x-windows: dirty`
 	assert.Equal(t, ObjectRemoved, extChanges.Changes[0].ChangeType)
 	assert.Equal(t, "/mushy/peas", extChanges.Changes[0].Original)
 }
+
+func TestComparePaths_v2_MovePath(t *testing.T) {
+
+	left := `/fresh/cake:
+  get:
+    description: a thing?
+/battered/fish:
+  post:
+    description: a thong?`
+
+	right := `/fresh/cake:
+  get:
+    description: a thing?
+/fish/battered:
+  post:
+    description: a thong?`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Paths
+	var rDoc v2.Paths
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := ComparePaths(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, Renamed, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, "/battered/fish", extChanges.Changes[0].Original)
+	assert.Equal(t, "/fish/battered", extChanges.Changes[0].New)
+}
+
+func TestComparePaths_v3_MovePath(t *testing.T) {
+
+	left := `/fresh/cake:
+  get:
+    description: a thing?
+/battered/fish:
+  post:
+    description: a thong?`
+
+	right := `/fresh/cake:
+  get:
+    description: a thing?
+/fish/battered:
+  post:
+    description: a thong?
+/crispy/chips:
+  post:
+    description: a thong, but different?`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Paths
+	var rDoc v3.Paths
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(lNode.Content[0], nil)
+	_ = rDoc.Build(rNode.Content[0], nil)
+
+	// compare.
+	extChanges := ComparePaths(&lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	for _, c := range extChanges.Changes {
+		switch c.ChangeType {
+		case Renamed:
+			assert.Equal(t, "/battered/fish", c.Original)
+			assert.Equal(t, "/fish/battered", c.New)
+		default:
+			assert.Equal(t, ObjectAdded, c.ChangeType)
+			assert.Equal(t, "/crispy/chips", c.New)
+		}
+	}
+}
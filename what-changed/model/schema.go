@@ -11,6 +11,7 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sort"
+	"strings"
 	"sync"
 )
 
@@ -22,16 +23,17 @@ import (
 // PropertyChanges.Changes, and not in the AnyOfChanges property.
 type SchemaChanges struct {
 	*PropertyChanges
-	DiscriminatorChanges  *DiscriminatorChanges     `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
-	AllOfChanges          []*SchemaChanges          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
-	AnyOfChanges          []*SchemaChanges          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
-	OneOfChanges          []*SchemaChanges          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
-	NotChanges            *SchemaChanges            `json:"not,omitempty" yaml:"not,omitempty"`
-	ItemsChanges          *SchemaChanges            `json:"items,omitempty" yaml:"items,omitempty"`
-	SchemaPropertyChanges map[string]*SchemaChanges `json:"properties,omitempty" yaml:"properties,omitempty"`
-	ExternalDocChanges    *ExternalDocChanges       `json:"externalDoc,omitempty" yaml:"externalDoc,omitempty"`
-	XMLChanges            *XMLChanges               `json:"xml,omitempty" yaml:"xml,omitempty"`
-	ExtensionChanges      *ExtensionChanges         `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	DiscriminatorChanges        *DiscriminatorChanges     `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
+	AllOfChanges                []*SchemaChanges          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+	AnyOfChanges                []*SchemaChanges          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	OneOfChanges                []*SchemaChanges          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	NotChanges                  *SchemaChanges            `json:"not,omitempty" yaml:"not,omitempty"`
+	ItemsChanges                *SchemaChanges            `json:"items,omitempty" yaml:"items,omitempty"`
+	AdditionalPropertiesChanges *SchemaChanges            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	SchemaPropertyChanges       map[string]*SchemaChanges `json:"properties,omitempty" yaml:"properties,omitempty"`
+	ExternalDocChanges          *ExternalDocChanges       `json:"externalDoc,omitempty" yaml:"externalDoc,omitempty"`
+	XMLChanges                  *XMLChanges               `json:"xml,omitempty" yaml:"xml,omitempty"`
+	ExtensionChanges            *ExtensionChanges         `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 
 	// 3.1 specifics
 	IfChanges                    *SchemaChanges            `json:"if,omitempty" yaml:"if,omitempty"`
@@ -43,6 +45,8 @@ type SchemaChanges struct {
 	UnevaluatedPropertiesChanges *SchemaChanges            `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
 	DependentSchemasChanges      map[string]*SchemaChanges `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
 	PatternPropertiesChanges     map[string]*SchemaChanges `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
+	PrefixItemsChanges           []*SchemaChanges          `json:"prefixItems,omitempty" yaml:"prefixItems,omitempty"`
+	ContentSchemaChanges         *SchemaChanges            `json:"contentSchema,omitempty" yaml:"contentSchema,omitempty"`
 }
 
 // GetAllChanges returns a slice of all changes made between Responses objects
@@ -79,6 +83,9 @@ func (s *SchemaChanges) GetAllChanges() []*Change {
 	if s.ItemsChanges != nil {
 		changes = append(changes, s.ItemsChanges.GetAllChanges()...)
 	}
+	if s.AdditionalPropertiesChanges != nil {
+		changes = append(changes, s.AdditionalPropertiesChanges.GetAllChanges()...)
+	}
 	if s.IfChanges != nil {
 		changes = append(changes, s.IfChanges.GetAllChanges()...)
 	}
@@ -100,6 +107,16 @@ func (s *SchemaChanges) GetAllChanges() []*Change {
 	if s.UnevaluatedPropertiesChanges != nil {
 		changes = append(changes, s.UnevaluatedPropertiesChanges.GetAllChanges()...)
 	}
+	if s.ContentSchemaChanges != nil {
+		changes = append(changes, s.ContentSchemaChanges.GetAllChanges()...)
+	}
+	if len(s.PrefixItemsChanges) > 0 {
+		for n := range s.PrefixItemsChanges {
+			if s.PrefixItemsChanges[n] != nil {
+				changes = append(changes, s.PrefixItemsChanges[n].GetAllChanges()...)
+			}
+		}
+	}
 	if s.SchemaPropertyChanges != nil {
 		for n := range s.SchemaPropertyChanges {
 			if s.SchemaPropertyChanges[n] != nil {
@@ -162,6 +179,9 @@ func (s *SchemaChanges) TotalChanges() int {
 	if s.ItemsChanges != nil {
 		t += s.ItemsChanges.TotalChanges()
 	}
+	if s.AdditionalPropertiesChanges != nil {
+		t += s.AdditionalPropertiesChanges.TotalChanges()
+	}
 	if s.IfChanges != nil {
 		t += s.IfChanges.TotalChanges()
 	}
@@ -183,6 +203,16 @@ func (s *SchemaChanges) TotalChanges() int {
 	if s.UnevaluatedPropertiesChanges != nil {
 		t += s.UnevaluatedPropertiesChanges.TotalChanges()
 	}
+	if s.ContentSchemaChanges != nil {
+		t += s.ContentSchemaChanges.TotalChanges()
+	}
+	if len(s.PrefixItemsChanges) > 0 {
+		for n := range s.PrefixItemsChanges {
+			if s.PrefixItemsChanges[n] != nil {
+				t += s.PrefixItemsChanges[n].TotalChanges()
+			}
+		}
+	}
 	if s.SchemaPropertyChanges != nil {
 		for n := range s.SchemaPropertyChanges {
 			if s.SchemaPropertyChanges[n] != nil {
@@ -244,6 +274,9 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
 	if s.ItemsChanges != nil {
 		t += s.ItemsChanges.TotalBreakingChanges()
 	}
+	if s.AdditionalPropertiesChanges != nil {
+		t += s.AdditionalPropertiesChanges.TotalBreakingChanges()
+	}
 	if s.IfChanges != nil {
 		t += s.IfChanges.TotalBreakingChanges()
 	}
@@ -265,6 +298,16 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
 	if s.UnevaluatedPropertiesChanges != nil {
 		t += s.UnevaluatedPropertiesChanges.TotalBreakingChanges()
 	}
+	if s.ContentSchemaChanges != nil {
+		t += s.ContentSchemaChanges.TotalBreakingChanges()
+	}
+	if len(s.PrefixItemsChanges) > 0 {
+		for n := range s.PrefixItemsChanges {
+			if s.PrefixItemsChanges[n] != nil {
+				t += s.PrefixItemsChanges[n].TotalBreakingChanges()
+			}
+		}
+	}
 	if s.DependentSchemasChanges != nil {
 		for n := range s.DependentSchemasChanges {
 			t += s.DependentSchemasChanges[n].TotalBreakingChanges()
@@ -379,7 +422,10 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 		go extractSchemaChanges(lSchema.AnyOf.Value, rSchema.AnyOf.Value, v3.AnyOfLabel,
 			&sc.AnyOfChanges, &changes, doneChan)
 
-		totalChecks := totalProperties + depsTotal + patternsTotal + 3
+		go extractSchemaChanges(lSchema.PrefixItems.Value, rSchema.PrefixItems.Value, v3.PrefixItemsLabel,
+			&sc.PrefixItemsChanges, &changes, doneChan)
+
+		totalChecks := totalProperties + depsTotal + patternsTotal + 4
 		completedChecks := 0
 		for completedChecks < totalChecks {
 			select {
@@ -718,20 +764,6 @@ func checkSchemaPropertyChanges(
 		New:       rSchema,
 	})
 
-	// AdditionalProperties (only if not an object)
-	if !utils.IsNodeMap(lSchema.AdditionalProperties.ValueNode) &&
-		!utils.IsNodeMap(rSchema.AdditionalProperties.ValueNode) {
-		props = append(props, &PropertyCheck{
-			LeftNode:  lSchema.AdditionalProperties.ValueNode,
-			RightNode: rSchema.AdditionalProperties.ValueNode,
-			Label:     v3.AdditionalPropertiesLabel,
-			Changes:   changes,
-			Breaking:  false,
-			Original:  lSchema,
-			New:       rSchema,
-		})
-	}
-
 	//Description
 	props = append(props, &PropertyCheck{
 		LeftNode:  lSchema.Description.ValueNode,
@@ -765,6 +797,72 @@ func checkSchemaPropertyChanges(
 		New:       rSchema,
 	})
 
+	// MinContains
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.MinContains.ValueNode,
+		RightNode: rSchema.MinContains.ValueNode,
+		Label:     v3.MinContainsLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
+	// MaxContains
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.MaxContains.ValueNode,
+		RightNode: rSchema.MaxContains.ValueNode,
+		Label:     v3.MaxContainsLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
+	// Anchor
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.Anchor.ValueNode,
+		RightNode: rSchema.Anchor.ValueNode,
+		Label:     v3.AnchorLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
+	// DynamicAnchor
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.DynamicAnchor.ValueNode,
+		RightNode: rSchema.DynamicAnchor.ValueNode,
+		Label:     v3.DynamicAnchorLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
+	// DynamicRef
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.DynamicRef.ValueNode,
+		RightNode: rSchema.DynamicRef.ValueNode,
+		Label:     v3.DynamicRefLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
+	// Const
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.Const.ValueNode,
+		RightNode: rSchema.Const.ValueNode,
+		Label:     v3.ConstLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
 	// Default
 	props = append(props, &PropertyCheck{
 		LeftNode:  lSchema.Default.ValueNode,
@@ -810,15 +908,21 @@ func checkSchemaPropertyChanges(
 	})
 
 	// Example
-	props = append(props, &PropertyCheck{
-		LeftNode:  lSchema.Example.ValueNode,
-		RightNode: rSchema.Example.ValueNode,
-		Label:     v3.ExampleLabel,
-		Changes:   changes,
-		Breaking:  false,
-		Original:  lSchema,
-		New:       rSchema,
-	})
+	// objects are compared structurally, arrays are compared as a whole.
+	if utils.IsNodeMap(lSchema.Example.ValueNode) && utils.IsNodeMap(rSchema.Example.ValueNode) {
+		CompareValueNodes(changes, v3.ExampleLabel, lSchema.Example.ValueNode, rSchema.Example.ValueNode, false,
+			lSchema, rSchema)
+	} else {
+		props = append(props, &PropertyCheck{
+			LeftNode:  lSchema.Example.ValueNode,
+			RightNode: rSchema.Example.ValueNode,
+			Label:     v3.ExampleLabel,
+			Changes:   changes,
+			Breaking:  false,
+			Original:  lSchema,
+			New:       rSchema,
+		})
+	}
 
 	// Deprecated
 	props = append(props, &PropertyCheck{
@@ -879,6 +983,9 @@ func checkSchemaPropertyChanges(
 		}
 	}
 
+	// DependentRequired
+	checkDependentRequired(lSchema, rSchema, changes)
+
 	// Discriminator
 	if lSchema.Discriminator.Value != nil && rSchema.Discriminator.Value != nil {
 		// check if hash matches, if not then compare.
@@ -996,6 +1103,22 @@ func checkSchemaPropertyChanges(
 		CreateChange(changes, ObjectRemoved, v3.ContainsLabel,
 			lSchema.Contains.ValueNode, nil, true, lSchema.Contains.Value, nil)
 	}
+	// ContentSchema
+	if lSchema.ContentSchema.Value != nil && rSchema.ContentSchema.Value != nil {
+		if !low.AreEqual(lSchema.ContentSchema.Value, rSchema.ContentSchema.Value) {
+			sc.ContentSchemaChanges = CompareSchemas(lSchema.ContentSchema.Value, rSchema.ContentSchema.Value)
+		}
+	}
+	// added ContentSchema
+	if lSchema.ContentSchema.Value == nil && rSchema.ContentSchema.Value != nil {
+		CreateChange(changes, ObjectAdded, v3.ContentSchemaLabel,
+			nil, rSchema.ContentSchema.ValueNode, true, nil, rSchema.ContentSchema.Value)
+	}
+	// removed ContentSchema
+	if lSchema.ContentSchema.Value != nil && rSchema.ContentSchema.Value == nil {
+		CreateChange(changes, ObjectRemoved, v3.ContentSchemaLabel,
+			lSchema.ContentSchema.ValueNode, nil, true, lSchema.ContentSchema.Value, nil)
+	}
 	// UnevaluatedItems
 	if lSchema.UnevaluatedItems.Value != nil && rSchema.UnevaluatedItems.Value != nil {
 		if !low.AreEqual(lSchema.UnevaluatedItems.Value, rSchema.UnevaluatedItems.Value) {
@@ -1068,9 +1191,9 @@ func checkSchemaPropertyChanges(
 			if !low.AreEqual(lSchema.Items.Value.A, rSchema.Items.Value.A) {
 				sc.ItemsChanges = CompareSchemas(lSchema.Items.Value.A, rSchema.Items.Value.A)
 			}
-		} else {
+		} else if lSchema.Items.Value.N != rSchema.Items.Value.N || lSchema.Items.Value.B != rSchema.Items.Value.B {
 			CreateChange(changes, Modified, v3.ItemsLabel,
-				lSchema.Items.ValueNode, rSchema.Items.ValueNode, true, lSchema.Items.Value.B, rSchema.Items.Value.B)
+				lSchema.Items.ValueNode, rSchema.Items.ValueNode, true, lSchema.Items.Value, rSchema.Items.Value)
 		}
 	}
 	// added Items
@@ -1087,19 +1210,32 @@ func checkSchemaPropertyChanges(
 	// check extensions
 	sc.ExtensionChanges = CompareExtensions(lSchema.Extensions, rSchema.Extensions)
 
-	// if additional properties is an object, then hash it
-	// AdditionalProperties (only if not an object)
-	if utils.IsNodeMap(lSchema.AdditionalProperties.ValueNode) ||
-		utils.IsNodeMap(rSchema.AdditionalProperties.ValueNode) {
-
-		lHash := low.GenerateHashString(lSchema.AdditionalProperties.ValueNode)
-		rHash := low.GenerateHashString(rSchema.AdditionalProperties.ValueNode)
-		if lHash != rHash {
+	// additionalProperties, a schema is compared as a schema, switching between a schema and a boolean
+	// (or flipping the boolean) is a modification. Values that are neither are compared as they were read.
+	if lSchema.AdditionalProperties.Value != nil && rSchema.AdditionalProperties.Value != nil {
+		lAdd, rAdd := lSchema.AdditionalProperties.Value, rSchema.AdditionalProperties.Value
+		if lAdd.IsA() && rAdd.IsA() {
+			if !low.AreEqual(lAdd.A, rAdd.A) {
+				sc.AdditionalPropertiesChanges = CompareSchemas(lAdd.A, rAdd.A)
+			}
+		} else if lAdd.N == 2 && rAdd.N == 2 {
+			CheckForModification(lSchema.AdditionalProperties.ValueNode, rSchema.AdditionalProperties.ValueNode,
+				v3.AdditionalPropertiesLabel, changes, false, lAdd, rAdd)
+		} else if lAdd.N != rAdd.N || lAdd.B != rAdd.B {
 			CreateChange(changes, Modified, v3.AdditionalPropertiesLabel,
-				lSchema.AdditionalProperties.ValueNode, rSchema.AdditionalProperties.ValueNode, false,
-				lSchema.AdditionalProperties.Value, rSchema.AdditionalProperties.Value)
+				lSchema.AdditionalProperties.ValueNode, rSchema.AdditionalProperties.ValueNode, false, lAdd, rAdd)
 		}
 	}
+	// added additionalProperties
+	if lSchema.AdditionalProperties.Value == nil && rSchema.AdditionalProperties.Value != nil {
+		CreateChange(changes, ObjectAdded, v3.AdditionalPropertiesLabel,
+			nil, rSchema.AdditionalProperties.ValueNode, false, nil, rSchema.AdditionalProperties.Value)
+	}
+	// removed additionalProperties
+	if lSchema.AdditionalProperties.Value != nil && rSchema.AdditionalProperties.Value == nil {
+		CreateChange(changes, ObjectRemoved, v3.AdditionalPropertiesLabel,
+			lSchema.AdditionalProperties.ValueNode, nil, false, lSchema.AdditionalProperties.Value, nil)
+	}
 
 	// check core properties
 	CheckProperties(props)
@@ -1175,6 +1311,51 @@ func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Chang
 	}
 }
 
+// checkDependentRequired compares 'dependentRequired' maps (3.1). Adding a dependency, or adding a required property
+// to an existing dependency is a breaking change, removing them is not.
+func checkDependentRequired(lSchema, rSchema *base.Schema, changes *[]*Change) {
+	lDeps := make(map[string]low.ValueReference[[]string])
+	rDeps := make(map[string]low.ValueReference[[]string])
+	for k, v := range lSchema.DependentRequired.Value {
+		lDeps[k.Value] = v
+	}
+	for k, v := range rSchema.DependentRequired.Value {
+		rDeps[k.Value] = v
+	}
+	for k := range rDeps {
+		if _, ok := lDeps[k]; !ok {
+			CreateChange(changes, PropertyAdded, v3.DependentRequiredLabel,
+				nil, rDeps[k].ValueNode, true, nil, k)
+			continue
+		}
+		if strings.Join(lDeps[k].Value, ",") != strings.Join(rDeps[k].Value, ",") {
+			CreateChange(changes, Modified, v3.DependentRequiredLabel,
+				lDeps[k].ValueNode, rDeps[k].ValueNode, isDependentRequiredBreaking(lDeps[k].Value, rDeps[k].Value),
+				lDeps[k].Value, rDeps[k].Value)
+		}
+	}
+	for k := range lDeps {
+		if _, ok := rDeps[k]; !ok {
+			CreateChange(changes, PropertyRemoved, v3.DependentRequiredLabel,
+				lDeps[k].ValueNode, nil, false, k, nil)
+		}
+	}
+}
+
+// a change to a dependency is breaking if the right side requires something the left side did not.
+func isDependentRequiredBreaking(l, r []string) bool {
+	seen := make(map[string]bool, len(l))
+	for i := range l {
+		seen[l[i]] = true
+	}
+	for i := range r {
+		if !seen[r[i]] {
+			return true
+		}
+	}
+	return false
+}
+
 func extractSchemaChanges(
 	lSchema []low.ValueReference[*base.SchemaProxy],
 	rSchema []low.ValueReference[*base.SchemaProxy],
@@ -1210,24 +1391,20 @@ func extractSchemaChanges(
 
 	}
 
-	// sort slices so that like for like is all sequenced.
-	sort.Strings(lKeys)
-	sort.Strings(rKeys)
+	// schemas found on both sides have not changed, the rest are compared in the order they are found.
+	lKeys, rKeys = unmatchedHashes(lKeys, rKeys)
 
 	// check for identical lengths
 	if len(lKeys) == len(rKeys) {
 		for w := range lKeys {
-			// keys are different, which means there are changes.
-			if lKeys[w] != rKeys[w] {
-				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
-			}
+			*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
 		}
 	}
 
 	// things were removed
 	if len(lKeys) > len(rKeys) {
 		for w := range lKeys {
-			if w < len(rKeys) && lKeys[w] != rKeys[w] {
+			if w < len(rKeys) {
 				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
 			}
 			if w >= len(rKeys) {
@@ -1240,7 +1417,7 @@ func extractSchemaChanges(
 	// things were added
 	if len(rKeys) > len(lKeys) {
 		for w := range rKeys {
-			if w < len(lKeys) && rKeys[w] != lKeys[w] {
+			if w < len(lKeys) {
 				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
 			}
 			if w >= len(lKeys) {
@@ -1251,3 +1428,33 @@ func extractSchemaChanges(
 	}
 	done <- true
 }
+
+// unmatchedHashes removes the hashes found in both left and right (as many times as they are found in both),
+// keeping the order of the rest.
+func unmatchedHashes(left, right []string) ([]string, []string) {
+	counts := make(map[string]int, len(right))
+	for _, r := range right {
+		counts[r]++
+	}
+	var l []string
+	for _, h := range left {
+		if counts[h] > 0 {
+			counts[h]--
+			continue
+		}
+		l = append(l, h)
+	}
+	counts = make(map[string]int, len(left))
+	for _, h := range left {
+		counts[h]++
+	}
+	var r []string
+	for _, h := range right {
+		if counts[h] > 0 {
+			counts[h]--
+			continue
+		}
+		r = append(r, h)
+	}
+	return l, r
+}
@@ -1846,7 +1846,7 @@ components:
 	assert.Equal(t, 1, changes.TotalBreakingChanges())
 	assert.Equal(t, v3.DiscriminatorLabel, changes.Changes[0].Property)
 	assert.Equal(t, ObjectAdded, changes.Changes[0].ChangeType)
-	assert.Equal(t, "0e563831440581c713657dd857a0ec3af1bd7308a43bd3cae9184f61d61b288f",
+	assert.Equal(t, "2422304ad1716b439402e65f20afabba9d09e66091f7ff5b9bd867d0d0fe69cb",
 		low.HashToString(changes.Changes[0].NewObject.(*base.Discriminator).Hash()))
 
 }
@@ -1879,7 +1879,7 @@ components:
 	assert.Equal(t, 1, changes.TotalBreakingChanges())
 	assert.Equal(t, v3.DiscriminatorLabel, changes.Changes[0].Property)
 	assert.Equal(t, ObjectRemoved, changes.Changes[0].ChangeType)
-	assert.Equal(t, "0e563831440581c713657dd857a0ec3af1bd7308a43bd3cae9184f61d61b288f",
+	assert.Equal(t, "2422304ad1716b439402e65f20afabba9d09e66091f7ff5b9bd867d0d0fe69cb",
 		low.HashToString(changes.Changes[0].OriginalObject.(*base.Discriminator).Hash()))
 
 }
@@ -1946,7 +1946,7 @@ components:
 	assert.Equal(t, 0, changes.TotalBreakingChanges())
 	assert.Equal(t, v3.ExternalDocsLabel, changes.Changes[0].Property)
 	assert.Equal(t, ObjectAdded, changes.Changes[0].ChangeType)
-	assert.Equal(t, "2b7adf30f2ea3a7617ccf429a099617a9c03e8b5f3a23a89dba4b90f760010d7",
+	assert.Equal(t, "5b7f4a4cb4fdee8ba88d14deb759b696fcb3af153deea9d538a8b27cfcd54965",
 		low.HashToString(changes.Changes[0].NewObject.(*base.ExternalDoc).Hash()))
 
 }
@@ -1979,7 +1979,7 @@ components:
 	assert.Equal(t, 0, changes.TotalBreakingChanges())
 	assert.Equal(t, v3.ExternalDocsLabel, changes.Changes[0].Property)
 	assert.Equal(t, ObjectRemoved, changes.Changes[0].ChangeType)
-	assert.Equal(t, "2b7adf30f2ea3a7617ccf429a099617a9c03e8b5f3a23a89dba4b90f760010d7",
+	assert.Equal(t, "5b7f4a4cb4fdee8ba88d14deb759b696fcb3af153deea9d538a8b27cfcd54965",
 		low.HashToString(changes.Changes[0].OriginalObject.(*base.ExternalDoc).Hash()))
 
 }
@@ -2663,3 +2663,350 @@ components:
 	assert.Equal(t, 0, changes.TotalBreakingChanges())
 
 }
+
+func TestCompareSchemas_PrefixItems(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      prefixItems:
+        - type: string
+        - type: int`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      prefixItems:
+        - type: string
+        - type: bool`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Len(t, changes.GetAllChanges(), 1)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Len(t, changes.PrefixItemsChanges, 1)
+}
+
+func TestCompareSchemas_ContainsConstAnchor(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $anchor: pizza
+      const: cheese
+      minContains: 1
+      maxContains: 2`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $anchor: burger
+      const: onions
+      minContains: 2
+      maxContains: 3`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 4, changes.TotalChanges())
+	assert.Equal(t, 4, changes.TotalBreakingChanges())
+}
+
+func TestCompareSchemas_DependentRequired(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      dependentRequired:
+        cheese: [tomato]
+        pepperoni: [cheese, tomato]
+        onions: [garlic]`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      dependentRequired:
+        cheese: [tomato, dough]
+        pepperoni: [cheese]
+        ham: [pineapple]`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 4, changes.TotalChanges())
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+}
+
+func TestCompareSchemas_ContentSchema(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      contentSchema:
+        type: string`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      contentSchema:
+        type: int`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, 1, changes.ContentSchemaChanges.PropertyChanges.TotalChanges())
+}
+
+func TestCompareSchemas_ContentSchema_Removed(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: string
+      contentSchema:
+        type: string`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: string`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Equal(t, ObjectRemoved, changes.Changes[0].ChangeType)
+	assert.Equal(t, v3.ContentSchemaLabel, changes.Changes[0].Property)
+}
+
+func TestCompareSchemas_DynamicAnchorRef(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $dynamicAnchor: node
+      items:
+        $dynamicRef: '#node'`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $dynamicAnchor: leaf
+      items:
+        $dynamicRef: '#leaf'`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+	assert.Equal(t, v3.DynamicAnchorLabel, changes.Changes[0].Property)
+	assert.Equal(t, v3.DynamicRefLabel, changes.ItemsChanges.Changes[0].Property)
+}
+
+func TestCompareSchemas_AdditionalPropertiesBoolToSchema(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: object
+      additionalProperties: true`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: object
+      additionalProperties:
+        type: string`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, Modified, changes.Changes[0].ChangeType)
+	assert.Equal(t, v3.AdditionalPropertiesLabel, changes.Changes[0].Property)
+
+	// and back again.
+	changes = CompareSchemas(rSchemaProxy, lSchemaProxy)
+	assert.Equal(t, 1, changes.TotalChanges())
+}
+
+func TestCompareSchemas_AdditionalPropertiesSchemaModified(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: object
+      additionalProperties:
+        type: string`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: object
+      additionalProperties:
+        type: integer`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Equal(t, v3.TypeLabel, changes.AdditionalPropertiesChanges.Changes[0].Property)
+}
+
+func TestCompareSchemas_AdditionalPropertiesBoolAddedRemoved(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: object
+      items: false`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: object
+      items: false
+      additionalProperties: false`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, ObjectAdded, changes.Changes[0].ChangeType)
+
+	changes = CompareSchemas(rSchemaProxy, lSchemaProxy)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, ObjectRemoved, changes.Changes[0].ChangeType)
+}
+
+func TestCompareSchemas_OneOfModifiedInOrder(t *testing.T) {
+	left := `openapi: 3.0
+components:
+  schemas:
+    OK:
+      oneOf:
+        - type: string
+          description: cheese
+        - type: integer
+        - type: boolean
+          description: tomato`
+
+	right := `openapi: 3.0
+components:
+  schemas:
+    OK:
+      oneOf:
+        - type: string
+          description: pepperoni
+        - type: integer
+        - type: boolean
+          description: basil`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	// unchanged schemas are skipped, changed schemas are compared in the order they are found.
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Len(t, changes.OneOfChanges, 2)
+	assert.Equal(t, v3.DescriptionLabel, changes.OneOfChanges[0].Changes[0].Property)
+	assert.Equal(t, "cheese", changes.OneOfChanges[0].Changes[0].Original)
+	assert.Equal(t, "pepperoni", changes.OneOfChanges[0].Changes[0].New)
+	assert.Equal(t, "tomato", changes.OneOfChanges[1].Changes[0].Original)
+	assert.Equal(t, "basil", changes.OneOfChanges[1].Changes[0].New)
+}
+
+func TestUnmatchedHashes(t *testing.T) {
+	l, r := unmatchedHashes([]string{"c", "a", "b", "a"}, []string{"a", "d", "b", "e"})
+	assert.Equal(t, []string{"c", "a"}, l)
+	assert.Equal(t, []string{"d", "e"}, r)
+
+	l, r = unmatchedHashes([]string{"a", "b"}, []string{"b", "a"})
+	assert.Empty(t, l)
+	assert.Empty(t, r)
+}
+
+func TestCompareSchemas_AdditionalPropertiesRawModified(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: object
+      additionalProperties:
+        - one
+        - two`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: object
+      additionalProperties:
+        - one
+        - three`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, Modified, changes.Changes[0].ChangeType)
+	assert.Equal(t, v3.AdditionalPropertiesLabel, changes.Changes[0].Property)
+}
@@ -0,0 +1,217 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+)
+
+// Conflict is an edit made to the same part of a document by both sides of a three-way comparison, that can't be
+// merged without choosing one of them.
+type Conflict struct {
+	// Path is where the conflicting edits were made (see ChangeLocation).
+	Path []string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// Object is the kind of object the edits were made to, like 'Schema'.
+	Object string `json:"object,omitempty" yaml:"object,omitempty"`
+
+	// Property is the property both sides edited.
+	Property string `json:"property,omitempty" yaml:"property,omitempty"`
+
+	// Left is the edit made by the left side.
+	Left *Change `json:"left,omitempty" yaml:"left,omitempty"`
+
+	// Right is the edit made by the right side.
+	Right *Change `json:"right,omitempty" yaml:"right,omitempty"`
+}
+
+// ThreeWayChanges are the changes made to a base document by two other documents (left and right) that were both
+// made from it, like two branches that modify the same specification, and the edits that conflict.
+type ThreeWayChanges struct {
+	Left      *DocumentChanges `json:"left,omitempty" yaml:"left,omitempty"`
+	Right     *DocumentChanges `json:"right,omitempty" yaml:"right,omitempty"`
+	Conflicts []*Conflict      `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+}
+
+// HasConflicts returns true if the left and right documents can't be merged without choosing between their edits.
+func (t *ThreeWayChanges) HasConflicts() bool {
+	return len(t.Conflicts) > 0
+}
+
+// CompareThreeWay compares a base document with two documents made from it (left and right), and finds the edits
+// that conflict (see FindConflicts). Accepts OpenAPI or Swagger low-level documents, like CompareDocuments.
+func CompareThreeWay(base, left, right any) *ThreeWayChanges {
+	changes := &ThreeWayChanges{
+		Left:  CompareDocuments(base, left),
+		Right: CompareDocuments(base, right),
+	}
+	changes.Conflicts = FindConflicts(changes.Left, changes.Right)
+	return changes
+}
+
+// FindConflicts finds the edits in two trees of changes made to the same base (like two DocumentChanges) that
+// conflict. Edits conflict when both sides change the same property of the same object differently, like two
+// different descriptions, or both add the same path with different operations. Making the same edit on both sides
+// is not a conflict, and neither is adding different entries to the same map or list (like two new paths, or two
+// new enum values).
+//
+// Removing an object on one side while the other side changes something inside it is a conflict too.
+func FindConflicts(left, right any) []*Conflict {
+	leftEdits, rightEdits := collectEdits(left), collectEdits(right)
+	var conflicts []*Conflict
+	for key, l := range leftEdits {
+		r, ok := rightEdits[key]
+		if !ok || sameEdit(l.change, r.change) {
+			continue
+		}
+		conflicts = append(conflicts, newConflict(l, l.change, r.change))
+	}
+	// removals on one side, with changes inside the removed object on the other side.
+	for _, l := range leftEdits {
+		if removesObject(l.change) {
+			if r := editInside(l, rightEdits); r != nil {
+				conflicts = append(conflicts, newConflict(l, l.change, r.change))
+			}
+		}
+	}
+	for _, r := range rightEdits {
+		if removesObject(r.change) {
+			if l := editInside(r, leftEdits); l != nil {
+				conflicts = append(conflicts, newConflict(r, l.change, r.change))
+			}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].sortKey() < conflicts[j].sortKey()
+	})
+	return conflicts
+}
+
+func (c *Conflict) sortKey() string {
+	identity := changeIdentity(c.Left)
+	if identity == "" {
+		identity = changeIdentity(c.Right)
+	}
+	return strings.Join([]string{strings.Join(c.Path, "/"), c.Property, identity}, "|")
+}
+
+// edit is a change and where it was made.
+type edit struct {
+	change   *Change
+	location ChangeLocation
+	identity string
+}
+
+func newConflict(at *edit, l, r *Change) *Conflict {
+	return &Conflict{
+		Path:     at.location.Path,
+		Object:   at.location.Object,
+		Property: at.change.Property,
+		Left:     l,
+		Right:    r,
+	}
+}
+
+// collectEdits collects every change in a tree of changes, keyed by what was edited.
+func collectEdits(changes any) map[string]*edit {
+	edits := make(map[string]*edit)
+	WalkChanges(changes, func(change *Change, location *ChangeLocation) {
+		e := &edit{change: change, location: *location, identity: changeIdentity(change)}
+		key := strings.Join([]string{strings.Join(location.Path, "/"), location.Object, change.Property,
+			change.ValuePath, e.identity}, "|")
+		edits[key] = e
+	})
+	return edits
+}
+
+// changeIdentity returns what a change was made to inside its property: the key of a map entry, or the value of a
+// list item (like an enum value). Empty when the property itself was changed.
+func changeIdentity(change *Change) string {
+	switch change.ChangeType {
+	case ObjectAdded, ObjectRemoved, Renamed:
+		if change.Original != "" {
+			return change.Original
+		}
+		return change.New
+	case PropertyAdded:
+		if change.ValuePath == "" && !isModelObject(change.NewObject) {
+			return change.New
+		}
+	case PropertyRemoved:
+		if change.ValuePath == "" && !isModelObject(change.OriginalObject) {
+			return change.Original
+		}
+	}
+	return ""
+}
+
+// removesObject returns true if a change removed an object, either an entry of a map (like a path), or a property
+// that holds a model (like an operation of a path).
+func removesObject(change *Change) bool {
+	return change.ChangeType == ObjectRemoved ||
+		(change.ChangeType == PropertyRemoved && isModelObject(change.OriginalObject))
+}
+
+// isModelObject returns true if an object is a low-level model, rather than a value.
+func isModelObject(object any) bool {
+	if _, ok := object.(low.Hashable); ok {
+		return true
+	}
+	v := reflect.ValueOf(object)
+	return v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct
+}
+
+// sameEdit returns true if both sides made the same edit. Objects that were added on both sides are compared by
+// their hashes.
+func sameEdit(l, r *Change) bool {
+	if l.ChangeType != r.ChangeType || l.New != r.New {
+		return false
+	}
+	if l.ChangeType != ObjectAdded {
+		return true
+	}
+	lh, lok := l.NewObject.(low.Hashable)
+	rh, rok := r.NewObject.(low.Hashable)
+	if lok && rok && !reflect.ValueOf(lh).IsNil() && !reflect.ValueOf(rh).IsNil() {
+		return low.AreEqual(lh, rh)
+	}
+	return true
+}
+
+// editInside returns an edit made inside the object a removal removed.
+func editInside(removal *edit, edits map[string]*edit) *edit {
+	removed := removal.location.Path
+	name := removal.identity
+	if name == "" {
+		name = removal.change.Property
+	}
+	var found *edit
+	for _, e := range edits {
+		p := e.location.Path
+		if len(p) < len(removed)+1 || !hasPathPrefix(p, removed) {
+			continue
+		}
+		// the object is either a property (like an operation), or an entry of a map (like a path).
+		if (removal.identity == "" && p[len(removed)] == name) ||
+			(removal.identity != "" && len(p) > len(removed)+1 && p[len(removed)+1] == name) {
+			if found == nil || strings.Join(p, "/") < strings.Join(found.location.Path, "/") {
+				found = e
+			}
+		}
+	}
+	return found
+}
+
+func hasPathPrefix(path, prefix []string) bool {
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
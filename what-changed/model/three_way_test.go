@@ -0,0 +1,244 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildThreeWayDoc(spec string) *v3.Document {
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+	doc, _ := v3.CreateDocumentFromConfig(info, datamodel.NewClosedDocumentConfiguration())
+	return doc
+}
+
+var threeWayBase = `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      description: get a pizza
+      responses:
+        '200':
+          description: a pizza
+  /burger:
+    get:
+      description: get a burger
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, large]`
+
+func TestCompareThreeWay_NoConflicts(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      description: get a hot pizza
+      responses:
+        '200':
+          description: a pizza
+  /burger:
+    get:
+      description: get a burger
+  /chips:
+    get:
+      description: get some chips
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, large, medium]`
+
+	right := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      description: get a hot pizza
+      responses:
+        '200':
+          description: a pizza
+  /burger:
+    get:
+      description: get a burger
+  /salad:
+    get:
+      description: get a salad
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, large, massive]`
+
+	changes := CompareThreeWay(buildThreeWayDoc(threeWayBase), buildThreeWayDoc(left), buildThreeWayDoc(right))
+	assert.NotNil(t, changes.Left)
+	assert.NotNil(t, changes.Right)
+	assert.False(t, changes.HasConflicts())
+}
+
+func TestCompareThreeWay_Conflicts(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.1.0
+paths:
+  /pizza:
+    get:
+      description: get a hot pizza
+      responses:
+        '200':
+          description: a pizza
+  /chips:
+    get:
+      description: get some chips
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, large]`
+
+	right := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.2.0
+paths:
+  /pizza:
+    get:
+      description: get a cold pizza
+      responses:
+        '200':
+          description: a pizza
+  /burger:
+    get:
+      description: get a cheeseburger
+  /chips:
+    get:
+      description: get some fries
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, large]`
+
+	changes := CompareThreeWay(buildThreeWayDoc(threeWayBase), buildThreeWayDoc(left), buildThreeWayDoc(right))
+	assert.True(t, changes.HasConflicts())
+	assert.Len(t, changes.Conflicts, 4)
+
+	// info.version was changed differently.
+	version := changes.Conflicts[0]
+	assert.Equal(t, "Info", version.Object)
+	assert.Equal(t, v3.VersionLabel, version.Property)
+	assert.Equal(t, "1.1.0", version.Left.New)
+	assert.Equal(t, "1.2.0", version.Right.New)
+
+	// both changed the same description differently.
+	description := changes.Conflicts[1]
+	assert.Equal(t, []string{"paths", "pathItems", "/pizza", "get"}, description.Path)
+	assert.Equal(t, v3.DescriptionLabel, description.Property)
+	assert.Equal(t, "get a hot pizza", description.Left.New)
+	assert.Equal(t, "get a cold pizza", description.Right.New)
+
+	// the left removed a path the right changed.
+	burger := changes.Conflicts[2]
+	assert.Equal(t, "Paths", burger.Object)
+	assert.Equal(t, ObjectRemoved, burger.Left.ChangeType)
+	assert.Equal(t, "get a cheeseburger", burger.Right.New)
+
+	// the same path was added with different operations.
+	chips := changes.Conflicts[3]
+	assert.Equal(t, "Paths", chips.Object)
+	assert.Equal(t, ObjectAdded, chips.Left.ChangeType)
+	assert.Equal(t, "/chips", chips.Right.New)
+}
+
+func TestFindConflicts_SameEdit(t *testing.T) {
+	edited := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      description: get a hot pizza
+      responses:
+        '200':
+          description: a pizza
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, large]`
+
+	base := buildThreeWayDoc(threeWayBase)
+	left := CompareDocuments(base, buildThreeWayDoc(edited))
+	right := CompareDocuments(base, buildThreeWayDoc(edited))
+	assert.Empty(t, FindConflicts(left, right))
+	assert.Empty(t, FindConflicts(left, nil))
+}
+
+func TestFindConflicts_PropertyRemoved(t *testing.T) {
+	// the left removes the get operation of /pizza, the right changes it.
+	removed := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    post:
+      description: make a pizza
+  /burger:
+    get:
+      description: get a burger
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, large]`
+
+	edited := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      description: get a hot pizza
+      responses:
+        '200':
+          description: a pizza
+  /burger:
+    get:
+      description: get a burger
+components:
+  schemas:
+    Size:
+      type: string
+      enum: [small, large]`
+
+	changes := CompareThreeWay(buildThreeWayDoc(threeWayBase), buildThreeWayDoc(removed), buildThreeWayDoc(edited))
+	assert.True(t, changes.HasConflicts())
+	assert.Len(t, changes.Conflicts, 1)
+	assert.Equal(t, PropertyRemoved, changes.Conflicts[0].Left.ChangeType)
+	assert.Equal(t, "get", changes.Conflicts[0].Left.Property)
+	assert.Equal(t, "get a hot pizza", changes.Conflicts[0].Right.New)
+
+	// and the other way around.
+	changes = CompareThreeWay(buildThreeWayDoc(threeWayBase), buildThreeWayDoc(edited), buildThreeWayDoc(removed))
+	assert.Len(t, changes.Conflicts, 1)
+	assert.Equal(t, "get a hot pizza", changes.Conflicts[0].Left.New)
+	assert.Equal(t, PropertyRemoved, changes.Conflicts[0].Right.ChangeType)
+}
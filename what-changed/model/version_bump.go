@@ -0,0 +1,172 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// VersionBump is the part of a semantic version that should be bumped for a set of changes.
+type VersionBump int
+
+const (
+	// NoBump means nothing changed, so the version does not need to be bumped.
+	NoBump VersionBump = iota
+
+	// PatchBump is for editorial changes, like descriptions, summaries and examples.
+	PatchBump
+
+	// MinorBump is for changes that are not breaking, like a new path, operation or property.
+	MinorBump
+
+	// MajorBump is for breaking (or potentially breaking) changes.
+	MajorBump
+)
+
+var versionBumpNames = map[VersionBump]string{
+	NoBump:    "none",
+	PatchBump: "patch",
+	MinorBump: "minor",
+	MajorBump: "major",
+}
+
+// String returns the name of the bump, like 'minor'.
+func (b VersionBump) String() string {
+	return versionBumpNames[b]
+}
+
+// MarshalText renders the bump as its name.
+func (b VersionBump) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// Next returns the version that follows a version after the bump, like '1.3.0' for a MinorBump of '1.2.4'. A 'v'
+// prefix is kept, anything after the patch number (like '-rc1') is dropped.
+func (b VersionBump) Next(version string) (string, error) {
+	v, err := parseSemanticVersion(version)
+	if err != nil {
+		return "", err
+	}
+	switch b {
+	case MajorBump:
+		v = datamodel.Version{Major: v.Major + 1}
+	case MinorBump:
+		v = datamodel.Version{Major: v.Major, Minor: v.Minor + 1}
+	case PatchBump:
+		v.Patch++
+	}
+	if strings.HasPrefix(strings.TrimSpace(version), "v") {
+		return "v" + v.String(), nil
+	}
+	return v.String(), nil
+}
+
+// RecommendVersionBump recommends how the version of a specification should be bumped, following semantic
+// versioning, for a tree of changes (like DocumentChanges) that has been classified (see ClassifyChanges).
+//
+// Breaking and potentially breaking changes are a MajorBump, other changes are a MinorBump, unless every change is
+// editorial (descriptions, summaries, titles, examples, external docs, tags and the info object), which is a
+// PatchBump. A change to info.version itself is ignored.
+func RecommendVersionBump(changes any) VersionBump {
+	bump := NoBump
+	WalkChanges(changes, func(change *Change, location *ChangeLocation) {
+		var b VersionBump
+		switch {
+		case isVersionChange(change, location):
+			return
+		case change.Breaking || change.Classification != NonBreaking:
+			b = MajorBump
+		case isEditorialChange(change, location):
+			b = PatchBump
+		default:
+			b = MinorBump
+		}
+		if b > bump {
+			bump = b
+		}
+	})
+	return bump
+}
+
+// CheckVersionBump recommends how the version should be bumped for a classified tree of changes (see
+// RecommendVersionBump), and checks that info.version was bumped at least that much. An error is returned if the
+// version was not changed (when it should have been), cannot be parsed, or was not bumped enough.
+//
+// While the major version is zero, anything may change, so a minor bump is enough for breaking changes.
+func CheckVersionBump(changes *DocumentChanges) (VersionBump, error) {
+	bump := RecommendVersionBump(changes)
+	var version *Change
+	if changes != nil && changes.InfoChanges != nil {
+		for _, c := range changes.InfoChanges.Changes {
+			if c.Property == v3.VersionLabel && c.ChangeType == Modified {
+				version = c
+			}
+		}
+	}
+	if version == nil {
+		if bump == NoBump {
+			return bump, nil
+		}
+		return bump, fmt.Errorf("info.version was not changed, a %s version bump is recommended", bump)
+	}
+	original, err := parseSemanticVersion(version.Original)
+	if err != nil {
+		return bump, err
+	}
+	updated, err := parseSemanticVersion(version.New)
+	if err != nil {
+		return bump, err
+	}
+	required := bump
+	if original.Major == 0 && required == MajorBump {
+		required = MinorBump
+	}
+	if actual := versionBumpBetween(original, updated); actual < required {
+		return bump, fmt.Errorf("info.version was changed from '%s' to '%s' (a %s version bump), "+
+			"a %s version bump is recommended", version.Original, version.New, actual, bump)
+	}
+	return bump, nil
+}
+
+// versionBumpBetween returns the part of a version that was bumped, NoBump if the version was not increased.
+func versionBumpBetween(original, updated datamodel.Version) VersionBump {
+	switch {
+	case updated.Major != original.Major:
+		if updated.Major > original.Major {
+			return MajorBump
+		}
+	case updated.Minor != original.Minor:
+		if updated.Minor > original.Minor {
+			return MinorBump
+		}
+	case updated.Patch > original.Patch:
+		return PatchBump
+	}
+	return NoBump
+}
+
+func parseSemanticVersion(version string) (datamodel.Version, error) {
+	return datamodel.ParseVersion(strings.TrimPrefix(strings.TrimSpace(version), "v"))
+}
+
+func isVersionChange(change *Change, location *ChangeLocation) bool {
+	return location.Object == "Info" && change.Property == v3.VersionLabel
+}
+
+func isEditorialChange(change *Change, location *ChangeLocation) bool {
+	switch location.Object {
+	case "Info", "Contact", "License", "ExternalDoc", "Example", "Examples", "Tag":
+		return true
+	}
+	switch change.Property {
+	case v3.DescriptionLabel, v3.SummaryLabel, v3.TitleLabel, v3.ExampleLabel, v3.ExamplesLabel,
+		v3.ExternalDocsLabel, v3.TagsLabel:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func compareVersions(left, right string) *DocumentChanges {
+	lInfo, _ := datamodel.ExtractSpecInfo([]byte(left))
+	rInfo, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v3.CreateDocumentFromConfig(lInfo, datamodel.NewClosedDocumentConfiguration())
+	rDoc, _ := v3.CreateDocumentFromConfig(rInfo, datamodel.NewClosedDocumentConfiguration())
+	changes := CompareDocuments(lDoc, rDoc)
+	ClassifyChanges(changes, nil)
+	return changes
+}
+
+var versionLeft = `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.2.4
+paths:
+  /pizza:
+    get:
+      description: a pizza
+      responses:
+        '200':
+          description: a pizza`
+
+func TestRecommendVersionBump(t *testing.T) {
+	patch := compareVersions(versionLeft, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.2.5
+paths:
+  /pizza:
+    get:
+      description: a hot pizza
+      responses:
+        '200':
+          description: a pizza`)
+	assert.Equal(t, PatchBump, RecommendVersionBump(patch))
+
+	minor := compareVersions(versionLeft, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.2.4
+paths:
+  /pizza:
+    get:
+      description: a pizza
+      responses:
+        '200':
+          description: a pizza
+  /burger:
+    get:
+      description: a burger`)
+	assert.Equal(t, MinorBump, RecommendVersionBump(minor))
+
+	major := compareVersions(versionLeft, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.3.0
+paths: {}`)
+	assert.Equal(t, MajorBump, RecommendVersionBump(major))
+
+	assert.Equal(t, NoBump, RecommendVersionBump(compareVersions(versionLeft, versionLeft)))
+}
+
+func TestCheckVersionBump(t *testing.T) {
+	bump, err := CheckVersionBump(compareVersions(versionLeft, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.2.5
+paths:
+  /pizza:
+    get:
+      description: a hot pizza
+      responses:
+        '200':
+          description: a pizza`))
+	assert.Equal(t, PatchBump, bump)
+	assert.NoError(t, err)
+
+	bump, err = CheckVersionBump(compareVersions(versionLeft, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.3.0
+paths: {}`))
+	assert.Equal(t, MajorBump, bump)
+	assert.EqualError(t, err, "info.version was changed from '1.2.4' to '1.3.0' (a minor version bump), "+
+		"a major version bump is recommended")
+
+	_, err = CheckVersionBump(compareVersions(versionLeft, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.2.4
+paths:
+  /pizza:
+    get:
+      description: a hot pizza
+      responses:
+        '200':
+          description: a pizza`))
+	assert.EqualError(t, err, "info.version was not changed, a patch version bump is recommended")
+
+	bump, err = CheckVersionBump(nil)
+	assert.Equal(t, NoBump, bump)
+	assert.NoError(t, err)
+}
+
+func TestCheckVersionBump_Unstable(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 0.4.0
+paths:
+  /pizza:
+    get:
+      description: a pizza`
+
+	_, err := CheckVersionBump(compareVersions(left, `openapi: 3.1.0
+info:
+  title: pizza
+  version: 0.5.0
+paths: {}`))
+	assert.NoError(t, err)
+}
+
+func TestVersionBump_Next(t *testing.T) {
+	next, err := MinorBump.Next("1.2.4")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.0", next)
+
+	next, _ = MajorBump.Next("v1.2.4")
+	assert.Equal(t, "v2.0.0", next)
+
+	next, _ = PatchBump.Next("1.2.4-rc1")
+	assert.Equal(t, "1.2.5", next)
+
+	_, err = PatchBump.Next("latest")
+	assert.Error(t, err)
+	assert.Equal(t, "minor", MinorBump.String())
+}
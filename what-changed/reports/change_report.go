@@ -0,0 +1,140 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"gopkg.in/yaml.v3"
+)
+
+var changeTypeNames = map[int]string{
+	model.Modified:        "modified",
+	model.PropertyAdded:   "property-added",
+	model.ObjectAdded:     "object-added",
+	model.ObjectRemoved:   "object-removed",
+	model.PropertyRemoved: "property-removed",
+	model.Renamed:         "renamed",
+}
+
+// ChangeReport is a machine-readable report of every change between two documents, that can be serialized to JSON
+// (or YAML) for CI gates and dashboards.
+type ChangeReport struct {
+	Total               int               `json:"totalChanges" yaml:"totalChanges"`
+	Breaking            int               `json:"breakingChanges" yaml:"breakingChanges"`
+	PotentiallyBreaking int               `json:"potentiallyBreakingChanges" yaml:"potentiallyBreakingChanges"`
+	Changes             []*ReportedChange `json:"changes" yaml:"changes"`
+
+	// ImpactedOperations are the operations affected by the changes (see FindImpactedOperations).
+	ImpactedOperations []*ImpactedOperation `json:"impactedOperations,omitempty" yaml:"impactedOperations,omitempty"`
+}
+
+// ReportedChange is a single change in a ChangeReport.
+type ReportedChange struct {
+	// Rule is a stable identifier for the kind of change. It's the ID of the model.BreakingRule that classified the
+	// change, or the kind of object, the property and the type of change, like 'schema-max-length-modified'.
+	Rule           string               `json:"rule" yaml:"rule"`
+	Property       string               `json:"property" yaml:"property"`
+	ValuePath      string               `json:"valuePath,omitempty" yaml:"valuePath,omitempty"`
+	Type           string               `json:"type" yaml:"type"`
+	Classification model.Classification `json:"classification" yaml:"classification"`
+	Breaking       bool                 `json:"breaking" yaml:"breaking"`
+	Original       *ChangeSide          `json:"original,omitempty" yaml:"original,omitempty"`
+	New            *ChangeSide          `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// ChangeSide is where a change was made in the original or the new document, and the value on that side.
+type ChangeSide struct {
+	Pointer string `json:"pointer,omitempty" yaml:"pointer,omitempty"` // JSON pointer, like '#/paths/~1pets/get'.
+	Value   string `json:"value,omitempty" yaml:"value,omitempty"`
+	File    string `json:"file,omitempty" yaml:"file,omitempty"` // empty for the root document, if its path isn't known.
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Column  int    `json:"column,omitempty" yaml:"column,omitempty"`
+}
+
+// CreateChangeReport will create a report of every change in a tree of changes (like model.DocumentChanges). The
+// indexes of the original and updated documents are used to locate the JSON pointer and file of each side of a
+// change, either can be nil, in which case only the lines and values are reported.
+//
+// Changes keep the classification they have been given by model.ClassifyChanges, changes that have not been
+// classified are Breaking if they were reported as breaking. Changes are ordered by their pointers. The indexes are
+// also used to find the operations impacted by the changes.
+func CreateChangeReport(changes any, original, updated *index.SpecIndex) *ChangeReport {
+	report := &ChangeReport{Changes: []*ReportedChange{}}
+	model.WalkChanges(changes, func(change *model.Change, location *model.ChangeLocation) {
+		reported := &ReportedChange{
+			Rule:           change.Rule,
+			Property:       change.Property,
+			ValuePath:      change.ValuePath,
+			Type:           changeTypeNames[change.ChangeType],
+			Classification: change.Classification,
+			Breaking:       change.Breaking,
+			Original:       createChangeSide(change.OriginalNode, change.Original, original),
+			New:            createChangeSide(change.NewNode, change.New, updated),
+		}
+		if reported.Rule == "" {
+			reported.Rule = strings.Join([]string{kebab(location.Object), kebab(change.Property), reported.Type}, "-")
+			if change.Breaking {
+				reported.Classification = model.Breaking
+			}
+		}
+		switch reported.Classification {
+		case model.Breaking:
+			report.Breaking++
+		case model.PotentiallyBreaking:
+			report.PotentiallyBreaking++
+		}
+		report.Changes = append(report.Changes, reported)
+	})
+	report.Total = len(report.Changes)
+	sort.SliceStable(report.Changes, func(i, j int) bool {
+		return report.Changes[i].pointer() < report.Changes[j].pointer()
+	})
+	report.ImpactedOperations = FindImpactedOperations(changes, original, updated)
+	return report
+}
+
+// pointer returns the pointer of the change, on the new side if there is one.
+func (r *ReportedChange) pointer() string {
+	if r.New != nil && r.New.Pointer != "" {
+		return r.New.Pointer
+	}
+	if r.Original != nil {
+		return r.Original.Pointer
+	}
+	return ""
+}
+
+func createChangeSide(node *yaml.Node, value string, idx *index.SpecIndex) *ChangeSide {
+	if node == nil {
+		return nil
+	}
+	side := &ChangeSide{Value: value, Line: node.Line, Column: node.Column}
+	if idx != nil {
+		side.Pointer = idx.FindNodePointer(node)
+		if origin := idx.FindNodeOrigin(node); origin != nil {
+			side.File = origin.AbsoluteLocation
+		}
+	}
+	return side
+}
+
+// kebab converts a name like 'PathItem' or 'maxLength' into 'path-item' or 'max-length'.
+func kebab(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateChangeReport(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  size:
+                    type: string
+                    maxLength: 10
+        '404':
+          description: no pizza`
+
+	right := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  size:
+                    type: integer
+                    maxLength: 12`
+
+	lDoc, _ := libopenapi.NewDocument([]byte(left))
+	rDoc, _ := libopenapi.NewDocument([]byte(right))
+	lModel, _ := lDoc.BuildV3Model()
+	rModel, _ := rDoc.BuildV3Model()
+	changes, _ := libopenapi.CompareDocuments(lDoc, rDoc)
+	model.ClassifyChanges(changes, nil)
+
+	report := CreateChangeReport(changes, lModel.Index, rModel.Index)
+	assert.Equal(t, 3, report.Total)
+	assert.Len(t, report.Changes, 3)
+	assert.Equal(t, 3, report.Breaking)
+
+	codeRemoved := report.Changes[2]
+	assert.Equal(t, "response-code-removed", codeRemoved.Rule)
+	assert.Equal(t, "object-removed", codeRemoved.Type)
+	assert.Equal(t, "#/paths/~1pizza/get/responses/404", codeRemoved.Original.Pointer)
+	assert.Nil(t, codeRemoved.New)
+
+	maxLength := report.Changes[0]
+	assert.Equal(t, "schema-max-length-modified", maxLength.Rule)
+	assert.Equal(t, "10", maxLength.Original.Value)
+	assert.Equal(t, "12", maxLength.New.Value)
+	assert.Equal(t, 15, maxLength.New.Line)
+	assert.Equal(t, "#/paths/~1pizza/get/responses/200/content/application~1json/schema/properties/size/maxLength",
+		maxLength.New.Pointer)
+
+	typeChanged := report.Changes[1]
+	assert.Equal(t, "type-changed", typeChanged.Rule)
+	assert.Equal(t, model.Breaking, typeChanged.Classification)
+
+	b, err := json.Marshal(report)
+	assert.NoError(t, err)
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, float64(3), decoded["breakingChanges"])
+	last := decoded["changes"].([]any)[2].(map[string]any)
+	assert.Equal(t, "breaking", last["classification"])
+	assert.Equal(t, "#/paths/~1pizza/get/responses/404", last["original"].(map[string]any)["pointer"])
+}
+
+func TestCreateChangeReport_Stripe(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/stripe.yaml")
+	modified := bytes.ReplaceAll(original, []byte("maxLength: 5000"), []byte("maxLength: 4000"))
+
+	lDoc, _ := libopenapi.NewDocument(original)
+	rDoc, _ := libopenapi.NewDocument(modified)
+	lModel, _ := lDoc.BuildV3Model()
+	rModel, _ := rDoc.BuildV3Model()
+	changes, _ := libopenapi.CompareDocuments(lDoc, rDoc)
+
+	// every change is located in both documents.
+	report := CreateChangeReport(changes, lModel.Index, rModel.Index)
+	assert.Equal(t, len(changes.GetAllChanges()), report.Total)
+	for _, c := range report.Changes {
+		assert.True(t, strings.HasSuffix(c.Original.Pointer, "/maxLength"))
+		assert.Equal(t, c.Original.Pointer, c.New.Pointer)
+	}
+}
+
+func TestCreateChangeReport_NoIndexes(t *testing.T) {
+	changes := createDiff()
+	report := CreateChangeReport(changes, nil, nil)
+	assert.Equal(t, len(changes.GetAllChanges()), report.Total)
+	assert.Equal(t, model.CountBreakingChanges(changes.GetAllChanges()), report.Breaking)
+	for _, c := range report.Changes {
+		assert.NotEmpty(t, c.Rule)
+		assert.NotEmpty(t, c.Type)
+	}
+}
+
+func TestCreateChangeReport_NestedExtension(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      x-gateway:
+        routes:
+          - timeout: 30`
+
+	right := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      x-gateway:
+        routes:
+          - timeout: 60`
+
+	lDoc, _ := libopenapi.NewDocument([]byte(left))
+	rDoc, _ := libopenapi.NewDocument([]byte(right))
+	changes, _ := libopenapi.CompareDocuments(lDoc, rDoc)
+
+	report := CreateChangeReport(changes, nil, nil)
+	assert.Len(t, report.Changes, 1)
+	assert.Equal(t, "x-gateway", report.Changes[0].Property)
+	assert.Equal(t, "routes/0/timeout", report.Changes[0].ValuePath)
+	assert.Equal(t, 10, report.Changes[0].New.Line)
+
+	markdown := string(RenderMarkdownChangelog(report, ""))
+	assert.Contains(t, markdown, "`x-gateway/routes/0/timeout` changed from `30` to `60`")
+}
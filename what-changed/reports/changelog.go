@@ -0,0 +1,213 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// changelogGroup is the changes made to a single operation, path or section of a document.
+type changelogGroup struct {
+	title    string
+	order    string
+	breaking int
+	changes  []*ReportedChange
+}
+
+// RenderMarkdownChangelog renders a change report (see CreateChangeReport) as a Markdown changelog, ready to be used
+// as release notes. Changes are grouped by operation (or path, or the section of the document outside of paths),
+// groups with breaking changes come first, and breaking changes come first in each group.
+func RenderMarkdownChangelog(report *ChangeReport, title string) []byte {
+	var b strings.Builder
+	if title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", title)
+	}
+	fmt.Fprintf(&b, "%s.\n", summarizeReport(report))
+	for _, group := range groupChangelog(report) {
+		fmt.Fprintf(&b, "\n## %s\n\n", group.title)
+		for _, c := range group.changes {
+			b.WriteString("- ")
+			if label := classificationLabel(c.Classification); label != "" {
+				fmt.Fprintf(&b, "**%s**: ", label)
+			}
+			b.WriteString(describeChange(c, func(s string) string { return "`" + s + "`" }))
+			if at := relativePointer(c, group); at != "" {
+				fmt.Fprintf(&b, " (`%s`)", at)
+			}
+			b.WriteByte('\n')
+		}
+	}
+	return []byte(b.String())
+}
+
+// RenderHTMLChangelog renders a change report as an HTML changelog fragment, the same way as
+// RenderMarkdownChangelog. Each change is a list item with a class of its classification (like 'breaking'), so
+// it can be styled.
+func RenderHTMLChangelog(report *ChangeReport, title string) []byte {
+	var b strings.Builder
+	b.WriteString("<div class=\"changelog\">\n")
+	if title != "" {
+		fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(title))
+	}
+	fmt.Fprintf(&b, "<p>%s.</p>\n", html.EscapeString(summarizeReport(report)))
+	for _, group := range groupChangelog(report) {
+		fmt.Fprintf(&b, "<h2>%s</h2>\n<ul>\n", html.EscapeString(group.title))
+		for _, c := range group.changes {
+			fmt.Fprintf(&b, "<li class=\"%s\">", c.Classification)
+			if label := classificationLabel(c.Classification); label != "" {
+				fmt.Fprintf(&b, "<strong>%s</strong>: ", label)
+			}
+			b.WriteString(describeChange(c, func(s string) string {
+				return "<code>" + html.EscapeString(s) + "</code>"
+			}))
+			if at := relativePointer(c, group); at != "" {
+				fmt.Fprintf(&b, " (<code>%s</code>)", html.EscapeString(at))
+			}
+			b.WriteString("</li>\n")
+		}
+		b.WriteString("</ul>\n")
+	}
+	b.WriteString("</div>\n")
+	return []byte(b.String())
+}
+
+func summarizeReport(report *ChangeReport) string {
+	if report.Total == 0 {
+		return "No changes"
+	}
+	summary := fmt.Sprintf("%d changes, %d breaking", report.Total, report.Breaking)
+	if report.PotentiallyBreaking > 0 {
+		summary += fmt.Sprintf(", %d potentially breaking", report.PotentiallyBreaking)
+	}
+	return summary
+}
+
+func classificationLabel(c model.Classification) string {
+	switch c {
+	case model.Breaking:
+		return "Breaking"
+	case model.PotentiallyBreaking:
+		return "Potentially breaking"
+	}
+	return ""
+}
+
+// describeChange describes a change in a sentence, code formats property names and values.
+func describeChange(c *ReportedChange, code func(string) string) string {
+	var original, updated string
+	if c.Original != nil {
+		original = c.Original.Value
+	}
+	if c.New != nil {
+		updated = c.New.Value
+	}
+	property := c.Property
+	if c.ValuePath != "" {
+		property += "/" + c.ValuePath
+	}
+	switch {
+	case c.Type == "renamed":
+		return fmt.Sprintf("%s renamed from %s to %s", code(c.Property), code(original), code(updated))
+	case c.Type == "modified" && original != "" && updated != "":
+		return fmt.Sprintf("%s changed from %s to %s", code(property), code(original), code(updated))
+	case c.Type == "modified":
+		return fmt.Sprintf("%s changed", code(property))
+	case strings.HasSuffix(c.Type, "added") && updated != "":
+		return fmt.Sprintf("%s added: %s", code(property), code(updated))
+	case strings.HasSuffix(c.Type, "added"):
+		return fmt.Sprintf("%s added", code(property))
+	case original != "":
+		return fmt.Sprintf("%s removed: %s", code(property), code(original))
+	}
+	return fmt.Sprintf("%s removed", code(property))
+}
+
+// groupChangelog groups the changes of a report by operation, path or section.
+func groupChangelog(report *ChangeReport) []*changelogGroup {
+	groups := make(map[string]*changelogGroup)
+	for _, c := range report.Changes {
+		title, order := changelogGroupTitle(c.pointer())
+		g, ok := groups[title]
+		if !ok {
+			g = &changelogGroup{title: title, order: order}
+			groups[title] = g
+		}
+		if c.Classification == model.Breaking {
+			g.breaking++
+		}
+		g.changes = append(g.changes, c)
+	}
+	sorted := make([]*changelogGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.SliceStable(g.changes, func(i, j int) bool {
+			return g.changes[i].Classification > g.changes[j].Classification
+		})
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if (sorted[i].breaking > 0) != (sorted[j].breaking > 0) {
+			return sorted[i].breaking > 0
+		}
+		return sorted[i].order < sorted[j].order
+	})
+	return sorted
+}
+
+// changelogGroupTitle returns the title of the group a change at a pointer belongs to, like 'GET /pets', along
+// with the pointer prefix of the group (used to order groups, and to make pointers relative to the group).
+func changelogGroupTitle(pointer string) (string, string) {
+	segments := pointerSegments(pointer)
+	switch {
+	case len(segments) == 0:
+		return "Document", ""
+	case (segments[0] == "paths" || segments[0] == "webhooks") && len(segments) >= 3 && isHTTPMethod(segments[2]):
+		title := strings.ToUpper(segments[2]) + " " + segments[1]
+		if segments[0] == "webhooks" {
+			title = "Webhook " + title
+		}
+		return title, utils.BuildJSONPointer(segments[:3])
+	case (segments[0] == "paths" || segments[0] == "webhooks") && len(segments) >= 2:
+		if segments[0] == "webhooks" {
+			return "Webhook " + segments[1], utils.BuildJSONPointer(segments[:2])
+		}
+		return segments[1], utils.BuildJSONPointer(segments[:2])
+	case segments[0] == "components" && len(segments) >= 3:
+		return "Component " + segments[1] + "/" + segments[2], utils.BuildJSONPointer(segments[:3])
+	case segments[0] == "definitions" && len(segments) >= 2:
+		return "Definition " + segments[1], utils.BuildJSONPointer(segments[:2])
+	}
+	return segments[0], utils.BuildJSONPointer(segments[:1])
+}
+
+// relativePointer returns where a change was made, relative to its group (with JSON pointer escapes removed).
+func relativePointer(c *ReportedChange, group *changelogGroup) string {
+	p := strings.TrimPrefix(strings.TrimPrefix(c.pointer(), "#"), group.order)
+	return strings.Join(pointerSegments(p), "/")
+}
+
+func pointerSegments(pointer string) []string {
+	pointer = strings.Trim(strings.TrimPrefix(pointer, "#"), "/")
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(pointer, "/")
+	for i, s := range segments {
+		segments[i] = utils.UnescapeJSONPointerSegment(s)
+	}
+	return segments
+}
+
+func isHTTPMethod(s string) bool {
+	switch s {
+	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func createChangelogReport(t *testing.T) *ChangeReport {
+	left := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      description: get a pizza
+      responses:
+        '200':
+          description: a pizza
+        '404':
+          description: no pizza
+  /burger:
+    post:
+      description: make a burger
+      responses:
+        '201':
+          description: a burger`
+
+	right := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.1.0
+paths:
+  /pizza:
+    get:
+      description: get a <hot> pizza
+      responses:
+        '200':
+          description: a pizza
+  /burger:
+    post:
+      description: make a burger, fast
+      responses:
+        '201':
+          description: a burger`
+
+	lDoc, _ := libopenapi.NewDocument([]byte(left))
+	rDoc, _ := libopenapi.NewDocument([]byte(right))
+	lModel, _ := lDoc.BuildV3Model()
+	rModel, _ := rDoc.BuildV3Model()
+	changes, _ := libopenapi.CompareDocuments(lDoc, rDoc)
+	model.ClassifyChanges(changes, nil)
+	return CreateChangeReport(changes, lModel.Index, rModel.Index)
+}
+
+func TestRenderMarkdownChangelog(t *testing.T) {
+	markdown := string(RenderMarkdownChangelog(createChangelogReport(t), "Pizza API 1.1.0"))
+
+	assert.Equal(t, `# Pizza API 1.1.0
+
+4 changes, 1 breaking.
+
+## GET /pizza
+
+- **Breaking**: `+"`codes`"+` removed: `+"`404`"+` (`+"`responses/404`"+`)
+- `+"`description`"+` changed from `+"`get a pizza`"+` to `+"`get a <hot> pizza`"+` (`+"`description`"+`)
+
+## info
+
+- `+"`version`"+` changed from `+"`1.0.0`"+` to `+"`1.1.0`"+` (`+"`version`"+`)
+
+## POST /burger
+
+- `+"`description`"+` changed from `+"`make a burger`"+` to `+"`make a burger, fast`"+` (`+"`description`"+`)
+`, markdown)
+}
+
+func TestRenderHTMLChangelog(t *testing.T) {
+	page := string(RenderHTMLChangelog(createChangelogReport(t), "Pizza <API>"))
+
+	assert.True(t, strings.HasPrefix(page, "<div class=\"changelog\">\n<h1>Pizza &lt;API&gt;</h1>\n"))
+	assert.Contains(t, page, "<p>4 changes, 1 breaking.</p>")
+	assert.Contains(t, page, "<h2>GET /pizza</h2>\n<ul>\n<li class=\"breaking\"><strong>Breaking</strong>: "+
+		"<code>codes</code> removed: <code>404</code> (<code>responses/404</code>)</li>\n")
+	assert.Contains(t, page, "<code>get a &lt;hot&gt; pizza</code>")
+	assert.Less(t, strings.Index(page, "GET /pizza"), strings.Index(page, "POST /burger"))
+	assert.True(t, strings.HasSuffix(page, "</ul>\n</div>\n"))
+}
+
+func TestRenderMarkdownChangelog_NoChanges(t *testing.T) {
+	assert.Equal(t, "No changes.\n", string(RenderMarkdownChangelog(&ChangeReport{}, "")))
+}
+
+func TestChangelogGroupTitle(t *testing.T) {
+	title, order := changelogGroupTitle("#/paths/~1pets~1{id}/delete/responses/200")
+	assert.Equal(t, "DELETE /pets/{id}", title)
+	assert.Equal(t, "/paths/~1pets~1{id}/delete", order)
+
+	title, _ = changelogGroupTitle("#/paths/~1pets/parameters/0")
+	assert.Equal(t, "/pets", title)
+
+	title, _ = changelogGroupTitle("#/webhooks/newPet/post/requestBody")
+	assert.Equal(t, "Webhook POST newPet", title)
+
+	title, _ = changelogGroupTitle("#/components/schemas/Pet/properties/name")
+	assert.Equal(t, "Component schemas/Pet", title)
+
+	title, _ = changelogGroupTitle("#/definitions/Pet/type")
+	assert.Equal(t, "Definition Pet", title)
+
+	title, _ = changelogGroupTitle("")
+	assert.Equal(t, "Document", title)
+}
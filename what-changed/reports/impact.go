@@ -0,0 +1,247 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"sort"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// operationMethods are the methods of a path item, in the order impacted operations are reported.
+var operationMethods = []string{v3.GetLabel, v3.PutLabel, v3.PostLabel, v3.DeleteLabel, v3.OptionsLabel,
+	v3.HeadLabel, v3.PatchLabel, v3.TraceLabel}
+
+// swaggerComponents are the Swagger locations of the components reported under model.ComponentsChanges.
+var swaggerComponents = map[string]string{
+	v3.SchemasLabel:         "definitions",
+	v3.ParametersLabel:      "parameters",
+	v3.ResponsesLabel:       "responses",
+	v3.SecuritySchemesLabel: "securityDefinitions",
+}
+
+// ImpactedOperation is an operation affected by changes, either because the operation itself was changed, or because
+// it references (directly, or through other components) a component that was changed. Clients of an impacted
+// operation should be retested.
+type ImpactedOperation struct {
+	Path    string `json:"path" yaml:"path"` // the path of the operation, or the name of its webhook.
+	Method  string `json:"method" yaml:"method"`
+	Webhook bool   `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+
+	// Direct is true when the operation (or the path item it belongs to) was changed.
+	Direct bool `json:"direct" yaml:"direct"`
+
+	// Components are the changed components the operation references, like '#/components/schemas/Pet'.
+	Components []string `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// FindImpactedOperations finds every operation affected by a tree of changes (like model.DocumentChanges). Changes
+// made inside an operation impact that operation, changes made to a path item impact all of its operations, and
+// changes made to a component impact every operation that references it, directly or transitively (see
+// index.SpecIndex.FindReferencesTo). Operations that were added or removed are impacted too.
+//
+// The indexes of the original and updated documents are used to find references, either can be nil, in which case
+// only operations that were changed directly are found. Components that are referenced by name rather than by a
+// $ref (like security schemes) are not followed.
+//
+// Operations are ordered by path and method, webhooks come last.
+func FindImpactedOperations(changes any, original, updated *index.SpecIndex) []*ImpactedOperation {
+	impact := &impactAnalysis{
+		operations: make(map[impactKey]*ImpactedOperation),
+		indexes:    []*index.SpecIndex{original, updated},
+		components: make(map[componentKey]bool),
+	}
+	model.WalkChanges(changes, func(change *model.Change, location *model.ChangeLocation) {
+		impact.add(change, location.Path)
+	})
+
+	// many changes are usually made to the same component, references are only looked up once for each.
+	for _, c := range impact.changed {
+		impact.changedComponent(c.kind, c.name, c.indexes...)
+	}
+	operations := make([]*ImpactedOperation, 0, len(impact.operations))
+	for _, op := range impact.operations {
+		sort.Strings(op.Components)
+		operations = append(operations, op)
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		a, b := operations[i], operations[j]
+		if a.Webhook != b.Webhook {
+			return b.Webhook
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return methodOrder(a.Method) < methodOrder(b.Method)
+	})
+	return operations
+}
+
+type impactKey struct {
+	path, method string
+	webhook      bool
+}
+
+// componentKey is a changed component, and whether it's only looked up in the original document.
+type componentKey struct {
+	kind, name   string
+	originalOnly bool
+}
+
+type impactedComponent struct {
+	kind, name string
+	indexes    []*index.SpecIndex
+}
+
+type impactAnalysis struct {
+	operations map[impactKey]*ImpactedOperation
+	indexes    []*index.SpecIndex
+	components map[componentKey]bool
+	changed    []impactedComponent // changed components, in the order they were found.
+}
+
+// add records the operations impacted by a single change, made at a location in a tree of changes.
+func (a *impactAnalysis) add(change *model.Change, path []string) {
+	name := change.New
+	if change.Original != "" {
+		name = change.Original
+	}
+	switch {
+	case len(path) >= 3 && path[0] == v3.PathsLabel && path[1] == "pathItems":
+		a.changedPathItem(path[2], false, path[3:], change)
+	case len(path) >= 2 && path[0] == v3.WebhooksLabel:
+		a.changedPathItem(path[1], true, path[2:], change)
+	case len(path) == 1 && path[0] == v3.PathsLabel && change.Property == v3.PathLabel:
+		a.allOperations(name, false, "", a.indexes...)
+	case len(path) == 0 && change.Property == v3.WebhooksLabel:
+		a.allOperations(name, true, "", a.indexes...)
+	case len(path) >= 3 && path[0] == v3.ComponentsLabel:
+		a.component(path[1], path[2], false)
+	case len(path) == 1 && path[0] == v3.ComponentsLabel:
+		// components that were added aren't referenced by anything that wasn't changed itself.
+		if change.ChangeType == model.ObjectRemoved || change.ChangeType == model.Renamed {
+			a.component(change.Property, change.Original, true)
+		}
+	}
+}
+
+// changedPathItem records the operations impacted by a change made inside a path item (or a webhook).
+func (a *impactAnalysis) changedPathItem(name string, webhook bool, rest []string, change *model.Change) {
+	switch {
+	case len(rest) > 0 && methodOrder(rest[0]) >= 0:
+		a.impacted(name, rest[0], webhook, "")
+	case len(rest) == 0 && methodOrder(change.Property) >= 0:
+		a.impacted(name, change.Property, webhook, "")
+	default:
+		a.allOperations(name, webhook, "", a.indexes...)
+	}
+}
+
+// component records a changed component, so the operations that reference it can be found once every change has
+// been walked.
+func (a *impactAnalysis) component(kind, name string, originalOnly bool) {
+	key := componentKey{kind: kind, name: name, originalOnly: originalOnly}
+	if a.components[key] {
+		return
+	}
+	a.components[key] = true
+	c := impactedComponent{kind: kind, name: name, indexes: a.indexes}
+	if originalOnly {
+		c.indexes = a.indexes[:1]
+	}
+	a.changed = append(a.changed, c)
+}
+
+// changedComponent records every operation that references a changed component.
+func (a *impactAnalysis) changedComponent(kind, name string, indexes ...*index.SpecIndex) {
+	definition := "#" + utils.BuildJSONPointer([]string{v3.ComponentsLabel, kind, name})
+	for _, idx := range indexes {
+		if idx == nil {
+			continue
+		}
+		found := definition
+		if len(idx.SearchIndexForReference(found)) == 0 {
+			swagger, ok := swaggerComponents[kind]
+			if !ok {
+				continue
+			}
+			found = "#" + utils.BuildJSONPointer([]string{swagger, name})
+		}
+		for _, loc := range idx.FindReferencesTo(found) {
+			if loc.File != "" && loc.File != idx.GetSpecAbsolutePath() {
+				continue
+			}
+			segs := pointerSegments(loc.JSONPointer)
+			switch {
+			case len(segs) >= 3 && segs[0] == v3.PathsLabel && methodOrder(segs[2]) >= 0:
+				a.impacted(segs[1], segs[2], false, found)
+			case len(segs) >= 2 && segs[0] == v3.PathsLabel:
+				a.allOperations(segs[1], false, found, idx)
+			case len(segs) >= 3 && segs[0] == v3.WebhooksLabel && methodOrder(segs[2]) >= 0:
+				a.impacted(segs[1], segs[2], true, found)
+			case len(segs) >= 2 && segs[0] == v3.WebhooksLabel:
+				a.allOperations(segs[1], true, found, idx)
+			}
+		}
+	}
+}
+
+// allOperations records every operation of a path item (or a webhook), as it's found in any of the indexes.
+func (a *impactAnalysis) allOperations(name string, webhook bool, component string, indexes ...*index.SpecIndex) {
+	parent := v3.PathsLabel
+	if webhook {
+		parent = v3.WebhooksLabel
+	}
+	for _, idx := range indexes {
+		if idx == nil || idx.GetRootNode() == nil || len(idx.GetRootNode().Content) == 0 {
+			continue
+		}
+		_, items := utils.FindKeyNodeTop(parent, idx.GetRootNode().Content[0].Content)
+		if items == nil {
+			continue
+		}
+		_, item := utils.FindKeyNodeTop(name, items.Content)
+		if item == nil {
+			continue
+		}
+		for i := 0; i+1 < len(item.Content); i += 2 {
+			if methodOrder(item.Content[i].Value) >= 0 {
+				a.impacted(name, item.Content[i].Value, webhook, component)
+			}
+		}
+	}
+}
+
+// impacted records an impacted operation, directly when there is no component that caused it.
+func (a *impactAnalysis) impacted(path, method string, webhook bool, component string) {
+	key := impactKey{path: path, method: method, webhook: webhook}
+	op := a.operations[key]
+	if op == nil {
+		op = &ImpactedOperation{Path: path, Method: method, Webhook: webhook}
+		a.operations[key] = op
+	}
+	if component == "" {
+		op.Direct = true
+		return
+	}
+	for _, c := range op.Components {
+		if c == component {
+			return
+		}
+	}
+	op.Components = append(op.Components, component)
+}
+
+// methodOrder returns the position of a method in operationMethods, or -1 if it's not a method.
+func methodOrder(method string) int {
+	for i, m := range operationMethods {
+		if m == method {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindImpactedOperations(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+    post:
+      description: make a pizza
+  /burger:
+    parameters:
+      - $ref: '#/components/parameters/Size'
+    get:
+      description: get a burger
+    delete:
+      description: eat a burger
+  /salad:
+    get:
+      description: get a salad
+components:
+  parameters:
+    Size:
+      name: size
+      in: query
+      schema:
+        type: string
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        topping:
+          $ref: '#/components/schemas/Topping'
+    Topping:
+      type: string`
+
+	right := `openapi: 3.1.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: a pizza
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pizza'
+    post:
+      description: make a hot pizza
+  /burger:
+    parameters:
+      - $ref: '#/components/parameters/Size'
+    get:
+      description: get a burger
+    delete:
+      description: eat a burger
+  /salad:
+    get:
+      description: get a salad
+components:
+  parameters:
+    Size:
+      name: size
+      in: query
+      schema:
+        type: string
+  schemas:
+    Pizza:
+      type: object
+      properties:
+        topping:
+          $ref: '#/components/schemas/Topping'
+    Topping:
+      type: integer`
+
+	lDoc, _ := libopenapi.NewDocument([]byte(left))
+	rDoc, _ := libopenapi.NewDocument([]byte(right))
+	lModel, _ := lDoc.BuildV3Model()
+	rModel, _ := rDoc.BuildV3Model()
+	changes, _ := libopenapi.CompareDocuments(lDoc, rDoc)
+
+	impacted := FindImpactedOperations(changes, lModel.Index, rModel.Index)
+	assert.Len(t, impacted, 2)
+
+	// the topping schema is referenced through the pizza schema.
+	assert.Equal(t, "/pizza", impacted[0].Path)
+	assert.Equal(t, "get", impacted[0].Method)
+	assert.False(t, impacted[0].Direct)
+	assert.Equal(t, []string{"#/components/schemas/Topping"}, impacted[0].Components)
+
+	assert.Equal(t, "/pizza", impacted[1].Path)
+	assert.Equal(t, "post", impacted[1].Method)
+	assert.True(t, impacted[1].Direct)
+	assert.Empty(t, impacted[1].Components)
+
+	report := CreateChangeReport(changes, lModel.Index, rModel.Index)
+	assert.Equal(t, impacted, report.ImpactedOperations)
+
+	// without indexes, only operations that were changed are found.
+	impacted = FindImpactedOperations(changes, nil, nil)
+	assert.Len(t, impacted, 1)
+	assert.Equal(t, "post", impacted[0].Method)
+}
+
+func TestFindImpactedOperations_PathItem(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /burger:
+    parameters:
+      - $ref: '#/components/parameters/Size'
+    get:
+      description: get a burger
+    delete:
+      description: eat a burger
+  /salad:
+    get:
+      description: get a salad
+webhooks:
+  delivered:
+    post:
+      description: a delivery
+components:
+  parameters:
+    Size:
+      name: size
+      in: query`
+
+	right := `openapi: 3.1.0
+paths:
+  /burger:
+    parameters:
+      - $ref: '#/components/parameters/Size'
+    get:
+      description: get a burger
+    delete:
+      description: eat a burger
+webhooks:
+  delivered:
+    post:
+      description: a late delivery
+components:
+  parameters:
+    Size:
+      name: size
+      in: query
+      required: true`
+
+	lDoc, _ := libopenapi.NewDocument([]byte(left))
+	rDoc, _ := libopenapi.NewDocument([]byte(right))
+	lModel, _ := lDoc.BuildV3Model()
+	rModel, _ := rDoc.BuildV3Model()
+	changes, _ := libopenapi.CompareDocuments(lDoc, rDoc)
+
+	impacted := FindImpactedOperations(changes, lModel.Index, rModel.Index)
+	var found []string
+	for _, op := range impacted {
+		found = append(found, op.Method+" "+op.Path)
+	}
+	assert.Equal(t, []string{"get /burger", "delete /burger", "get /salad", "post delivered"}, found)
+	assert.True(t, impacted[2].Direct)
+	assert.True(t, impacted[3].Webhook)
+}
+
+func TestFindImpactedOperations_Stripe(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/stripe.yaml")
+	modified := bytes.ReplaceAll(original, []byte("maxLength: 5000"), []byte("maxLength: 4000"))
+
+	lDoc, _ := libopenapi.NewDocument(original)
+	rDoc, _ := libopenapi.NewDocument(modified)
+	lModel, _ := lDoc.BuildV3Model()
+	rModel, _ := rDoc.BuildV3Model()
+	changes, _ := libopenapi.CompareDocuments(lDoc, rDoc)
+
+	// thousands of changes are made, mostly to components referenced by many operations.
+	impacted := FindImpactedOperations(changes, lModel.Index, rModel.Index)
+	assert.Len(t, impacted, 402)
+	for _, op := range impacted {
+		assert.NotEmpty(t, op.Components)
+	}
+}
@@ -34,3 +34,47 @@ func CompareOpenAPIDocuments(original, updated *v3.Document) *model.DocumentChan
 func CompareSwaggerDocuments(original, updated *v2.Swagger) *model.DocumentChanges {
 	return model.CompareDocuments(original, updated)
 }
+
+// CompareOpenAPIDocumentsThreeWay will compare a base OpenAPI 3+ document with two documents made from it (left and
+// right), like two branches that modify the same specification. The changes made by each side are reported, along with
+// the edits that conflict, so the documents can be merged.
+func CompareOpenAPIDocumentsThreeWay(base, left, right *v3.Document) *model.ThreeWayChanges {
+	return model.CompareThreeWay(base, left, right)
+}
+
+// CompareSwaggerDocumentsThreeWay is the same as CompareOpenAPIDocumentsThreeWay, for Swagger documents.
+func CompareSwaggerDocumentsThreeWay(base, left, right *v2.Swagger) *model.ThreeWayChanges {
+	return model.CompareThreeWay(base, left, right)
+}
+
+// CompareOptions changes what is reported when comparing documents, changes can be ignored, and the comparison can be
+// limited to a single path, operation or component.
+type CompareOptions struct {
+	Ignore *model.IgnoreRules // changes to leave out of the report.
+	Scope  *model.ChangeScope // the only part of the documents to report changes for.
+}
+
+// CompareOpenAPIDocumentsWithOptions is the same as CompareOpenAPIDocuments, except changes are ignored and scoped
+// by the options. Returns nil if no changes are left.
+func CompareOpenAPIDocumentsWithOptions(original, updated *v3.Document, options *CompareOptions) *model.DocumentChanges {
+	return applyCompareOptions(model.CompareDocuments(original, updated), options)
+}
+
+// CompareSwaggerDocumentsWithOptions is the same as CompareSwaggerDocuments, except changes are ignored and scoped
+// by the options. Returns nil if no changes are left.
+func CompareSwaggerDocumentsWithOptions(original, updated *v2.Swagger, options *CompareOptions) *model.DocumentChanges {
+	return applyCompareOptions(model.CompareDocuments(original, updated), options)
+}
+
+func applyCompareOptions(changes *model.DocumentChanges, options *CompareOptions) *model.DocumentChanges {
+	if changes == nil || options == nil {
+		return changes
+	}
+	if options.Scope != nil && model.ScopeChanges(changes, options.Scope) == 0 {
+		return nil
+	}
+	if options.Ignore != nil && model.IgnoreChanges(changes, options.Ignore) == 0 {
+		return nil
+	}
+	return changes
+}
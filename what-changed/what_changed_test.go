@@ -4,10 +4,12 @@
 package what_changed
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"testing"
@@ -24,8 +26,8 @@ func TestCompareOpenAPIDocuments(t *testing.T) {
 	modDoc, _ := v3.CreateDocument(infoMod)
 
 	changes := CompareOpenAPIDocuments(origDoc, modDoc)
-	assert.Equal(t, 72, changes.TotalChanges())
-	assert.Equal(t, 17, changes.TotalBreakingChanges())
+	assert.Equal(t, 71, changes.TotalChanges())
+	assert.Equal(t, 16, changes.TotalBreakingChanges())
 	//out, _ := json.MarshalIndent(changes, "", "  ")
 	//_ = ioutil.WriteFile("outputv3.json", out, 0776)
 }
@@ -49,6 +51,54 @@ func TestCompareSwaggerDocuments(t *testing.T) {
 
 }
 
+func TestCompareOpenAPIDocumentsWithOptions(t *testing.T) {
+
+	original, _ := ioutil.ReadFile("../test_specs/burgershop.openapi.yaml")
+	modified, _ := ioutil.ReadFile("../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v3.CreateDocument(infoOrig)
+	modDoc, _ := v3.CreateDocument(infoMod)
+
+	all := CompareOpenAPIDocuments(origDoc, modDoc).TotalChanges()
+	changes := CompareOpenAPIDocumentsWithOptions(origDoc, modDoc, &CompareOptions{
+		Ignore: &model.IgnoreRules{Descriptions: true, Examples: true},
+	})
+	assert.Less(t, changes.TotalChanges(), all)
+
+	changes = CompareOpenAPIDocumentsWithOptions(origDoc, modDoc, &CompareOptions{
+		Scope: &model.ChangeScope{Path: "/burgers"},
+	})
+	assert.Len(t, changes.PathsChanges.PathItemsChanges, 1)
+	assert.Nil(t, changes.ComponentsChanges)
+
+	changes = CompareOpenAPIDocumentsWithOptions(origDoc, modDoc, &CompareOptions{
+		Scope: &model.ChangeScope{Path: "/not-a-path"},
+	})
+	assert.Nil(t, changes)
+}
+
+func TestCompareSwaggerDocumentsWithOptions(t *testing.T) {
+
+	original, _ := ioutil.ReadFile("../test_specs/petstorev2-complete.yaml")
+	modified, _ := ioutil.ReadFile("../test_specs/petstorev2-complete-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v2.CreateDocument(infoOrig)
+	modDoc, _ := v2.CreateDocument(infoMod)
+
+	changes := CompareSwaggerDocumentsWithOptions(origDoc, modDoc, nil)
+	assert.Equal(t, 52, changes.TotalChanges())
+
+	changes = CompareSwaggerDocumentsWithOptions(origDoc, modDoc, &CompareOptions{
+		Ignore: &model.IgnoreRules{Paths: []string{"/*"}},
+	})
+	assert.Empty(t, changes.PathsChanges.PathItemsChanges)
+	assert.NotNil(t, changes.PathsChanges.ExtensionChanges)
+}
+
 func Benchmark_CompareOpenAPIDocuments(b *testing.B) {
 
 	original, _ := ioutil.ReadFile("../test_specs/burgershop.openapi.yaml")
@@ -149,5 +199,21 @@ func ExampleCompareOpenAPIDocuments() {
 	// Print out some interesting stats.
 	fmt.Printf("There are %d changes, of which %d are breaking. %v schemas have changes.",
 		changes.TotalChanges(), changes.TotalBreakingChanges(), len(schemaChanges))
-	//Output: There are 72 changes, of which 17 are breaking. 5 schemas have changes.
+	//Output: There are 71 changes, of which 16 are breaking. 5 schemas have changes.
+}
+
+func Benchmark_CompareStripe_Changed(b *testing.B) {
+
+	original, _ := ioutil.ReadFile("../test_specs/stripe.yaml")
+	modified := bytes.ReplaceAll(original, []byte("maxLength: 5000"), []byte("maxLength: 5001"))
+
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+	origDoc, _ := v3.CreateDocument(infoOrig)
+	modDoc, _ := v3.CreateDocument(infoMod)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CompareOpenAPIDocuments(origDoc, modDoc)
+	}
 }